@@ -4,6 +4,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -12,6 +13,8 @@ import (
 
 var nilCleanupRegex = regexp.MustCompile(`\w+?="nil"`)
 
+var masteryEffectRegex = regexp.MustCompile(`{(\d+),(\d+)}`)
+
 var pobGemIDtoGameGemIDs = map[string]string{
 	"Metadata/Items/Gems/Smite":                   "Metadata/Items/Gems/SkillGemSmite",
 	"Metadata/Items/Gems/ConsecratedPath":         "Metadata/Items/Gems/SkillGemConsecratedPath",
@@ -46,17 +49,47 @@ func ParseBuild(rawXML []byte) (*pob.PathOfBuilding, error) {
 		}
 	}
 
-	build.Build.PassiveNodes = make([]int64, 0, 100)
-	// TODO: This only grabs the activeSpec but there are many here
-	var spec = build.Tree.Specs[build.Tree.ActiveSpec-1]
-	var nodeStrs = strings.Split(spec.NodesAttr, ",")
-	for _, str := range nodeStrs {
-		var num, err = strconv.ParseInt(str, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("spec has some non-integer nodes: %s", spec.NodesAttr)
+	if err := build.Items.ParseItems(); err != nil {
+		return nil, fmt.Errorf("failed to parse items: %w", err)
+	}
+
+	for i := range build.Tree.Specs {
+		if err := parseSpec(&build.Tree.Specs[i]); err != nil {
+			return nil, fmt.Errorf("failed to parse spec %d: %w", i+1, err)
 		}
-		build.Build.PassiveNodes = append(build.Build.PassiveNodes, num)
+	}
+
+	if len(build.Tree.Specs) > 0 {
+		if build.Tree.ActiveSpec < 1 || build.Tree.ActiveSpec > len(build.Tree.Specs) {
+			return nil, fmt.Errorf("active spec %d is out of range", build.Tree.ActiveSpec)
+		}
+		build.Build.PassiveNodes = slices.Clone(build.ActiveSpec().Nodes)
 	}
 
 	return &build, nil
 }
+
+func parseSpec(spec *pob.Spec) error {
+	spec.Nodes = make([]int64, 0, 100)
+	if spec.NodesAttr != "" {
+		for _, str := range strings.Split(spec.NodesAttr, ",") {
+			var num, err = strconv.ParseInt(str, 10, 64)
+			if err != nil {
+				return fmt.Errorf("spec has some non-integer nodes: %s", spec.NodesAttr)
+			}
+			spec.Nodes = append(spec.Nodes, num)
+		}
+	}
+
+	spec.MasterySelections = make([]pob.MasterySelection, 0)
+	for _, match := range masteryEffectRegex.FindAllStringSubmatch(spec.MasteryEffects, -1) {
+		nodeID, _ := strconv.ParseInt(match[1], 10, 64)
+		effectID, _ := strconv.ParseInt(match[2], 10, 64)
+		spec.MasterySelections = append(spec.MasterySelections, pob.MasterySelection{
+			NodeID:   nodeID,
+			EffectID: effectID,
+		})
+	}
+
+	return nil
+}
@@ -1,12 +1,27 @@
 package builds
 
 import (
+	"context"
 	"os"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/MarvinJWendt/testza"
+	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/Vilsol/go-pob/cache"
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/data/raw"
+	"github.com/Vilsol/go-pob/pob"
 )
 
+func init() {
+	if err := poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil); err != nil {
+		panic(err)
+	}
+}
+
 func TestParseBuild(t *testing.T) {
 	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
 	testza.AssertNoError(t, err)
@@ -14,3 +29,189 @@ func TestParseBuild(t *testing.T) {
 	_, err = ParseBuild(file)
 	testza.AssertNoError(t, err)
 }
+
+func TestParseBuildItems(t *testing.T) {
+	entries, err := os.ReadDir("../testdata/many-builds")
+	testza.AssertNoError(t, err)
+
+	for _, entry := range entries {
+		file, err := os.ReadFile("../testdata/many-builds/" + entry.Name())
+		testza.AssertNoError(t, err)
+
+		build, err := ParseBuild(file)
+		testza.AssertNoError(t, err, entry.Name())
+
+		for _, set := range build.Items.ItemSets {
+			for _, slot := range set.Slots {
+				if slot.ItemID != 0 {
+					item := build.Items.SlotItem(slot)
+					testza.AssertNotNil(t, item, entry.Name()+": "+slot.Name)
+					testza.AssertEqual(t, slot.ItemID, item.ID)
+				}
+			}
+		}
+	}
+
+	file, err := os.ReadFile("../testdata/many-builds/13.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	weapon := build.Items.SetSlotItem(build.Items.ActiveSet(), "Weapon 1")
+	testza.AssertNotNil(t, weapon)
+	testza.AssertEqual(t, pob.RarityUnique, weapon.Rarity)
+	testza.AssertEqual(t, "Terminus Est", weapon.Title)
+	testza.AssertEqual(t, "Tiger Sword", weapon.BaseName)
+	testza.AssertEqual(t, 3, weapon.SelectedVariant)
+	testza.AssertEqual(t, 20, weapon.Quality)
+	testza.AssertEqual(t, 6, weapon.Links())
+	testza.AssertLen(t, weapon.ImplicitModLines, 2)
+	testza.AssertLen(t, weapon.ExplicitModLines, 8)
+
+	active := weapon.ActiveModLines()
+	testza.AssertLen(t, active, 7)
+	testza.AssertEqual(t, "+360 to Accuracy Rating", active[0].Value())
+	testza.AssertEqual(t, "220% increased Physical Damage", active[1].Value())
+	testza.AssertEqual(t, "75% increased Critical Strike Chance", active[2].Value())
+
+	file, err = os.ReadFile("../testdata/many-builds/3.xml")
+	testza.AssertNoError(t, err)
+
+	build, err = ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	boots := build.Items.SetSlotItem(build.Items.ActiveSet(), "Boots")
+	testza.AssertNotNil(t, boots)
+	testza.AssertEqual(t, pob.RarityRare, boots.Rarity)
+	testza.AssertEqual(t, 143, boots.Evasion)
+	testza.AssertEqual(t, 29, boots.EnergyShield)
+	testza.AssertEqual(t, 4, boots.Links())
+	testza.AssertLen(t, boots.ImplicitModLines, 1)
+	testza.AssertTrue(t, boots.ExplicitModLines[0].Fractured)
+}
+
+func TestParseBuildMagicItem(t *testing.T) {
+	file, err := os.ReadFile("../testdata/many-builds/3.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	// Magic items resolve their base from the name
+	flask := build.Items.SetSlotItem(build.Items.ActiveSet(), "Flask 1")
+	testza.AssertNotNil(t, flask)
+	testza.AssertEqual(t, pob.RarityMagic, flask.Rarity)
+	testza.AssertEqual(t, "Eternal Life Flask", flask.BaseName)
+	testza.AssertNotNil(t, flask.BaseType())
+}
+
+func TestParseBuildInvalidItems(t *testing.T) {
+	file, err := os.ReadFile("../testdata/many-builds/13.xml")
+	testza.AssertNoError(t, err)
+
+	// Break the boots and point the helmet at an item that does not exist
+	xml := strings.Replace(string(file), "<Item id=\"1\">\n\t\t\tRarity: UNIQUE", "<Item id=\"1\">\n\t\t\tRarity: BROKEN", 1)
+	xml = strings.ReplaceAll(xml, `<Slot itemId="2" name="Helmet"/>`, `<Slot itemId="999" name="Helmet"/>`)
+
+	build, err := ParseBuild([]byte(xml))
+	testza.AssertNoError(t, err)
+
+	set := build.Items.ActiveSet()
+	testza.AssertNil(t, build.Items.SetSlotItem(set, "Boots"))
+	testza.AssertNil(t, build.Items.SetSlotItem(set, "Helmet"))
+	testza.AssertNotNil(t, build.Items.SetSlotItem(set, "Weapon 1"))
+
+	// Copies of the build, such as the ones passed from javascript, find the same items
+	copied := pob.Items{Items: slices.Clone(build.Items.Items), ItemSets: build.Items.ItemSets}
+	testza.AssertNil(t, copied.SetSlotItem(set, "Boots"))
+	testza.AssertEqual(t, build.Items.SetSlotItem(set, "Weapon 1").Raw, copied.SetSlotItem(set, "Weapon 1").Raw)
+}
+
+func TestParseBuildWithoutGameData(t *testing.T) {
+	file, err := os.ReadFile("../testdata/many-builds/13.xml")
+	testza.AssertNoError(t, err)
+
+	bases := poe.BaseItemTypeByNameMap
+	poe.BaseItemTypeByNameMap = nil
+	defer func() {
+		poe.BaseItemTypeByNameMap = bases
+	}()
+
+	_, err = ParseBuild(file)
+	testza.AssertErrorIs(t, err, pob.ErrGameDataNotLoaded)
+}
+
+func TestParseBuildSpecs(t *testing.T) {
+	file, err := os.ReadFile("../testdata/many-builds/7.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	specs := build.ListSpecs()
+	testza.AssertLen(t, specs, 2)
+	testza.AssertEqual(t, data.TreeVersion3_17, specs[0].TreeVersion)
+	testza.AssertEqual(t, data.TreeVersion3_18, specs[1].TreeVersion)
+	testza.AssertLen(t, specs[0].Nodes, 132)
+	testza.AssertLen(t, specs[1].Nodes, 133)
+	testza.AssertNotContains(t, specs[0].Nodes, int64(33823))
+	testza.AssertContains(t, specs[1].Nodes, int64(33823))
+	testza.AssertLen(t, specs[0].MasterySelections, 4)
+	testza.AssertLen(t, specs[1].MasterySelections, 5)
+	testza.AssertEqual(t, pob.MasterySelection{NodeID: 33823, EffectID: 2987}, specs[1].MasterySelections[1])
+
+	testza.AssertEqual(t, 2, build.Tree.ActiveSpec)
+	testza.AssertEqual(t, specs[1].Nodes, build.Build.PassiveNodes)
+
+	testza.AssertNoError(t, build.SetActiveSpec(0))
+	testza.AssertEqual(t, specs[0].Nodes, build.Build.PassiveNodes)
+
+	// The build nodes do not share their backing array with the spec
+	first := build.Build.PassiveNodes[0]
+	build.Build.PassiveNodes[0] = 0
+	testza.AssertEqual(t, first, build.Tree.Specs[0].Nodes[0])
+	build.Build.PassiveNodes[0] = first
+	testza.AssertNotNil(t, build.SetActiveSpec(2))
+
+	build.AllocateNodes([]int64{33823})
+	testza.AssertContains(t, build.ActiveSpec().Nodes, int64(33823))
+
+	copied, err := build.CopySpec(0)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, 2, copied)
+	build.DeallocateNodes(33823)
+	testza.AssertNotContains(t, build.Tree.Specs[0].Nodes, int64(33823))
+	testza.AssertContains(t, build.Tree.Specs[copied].Nodes, int64(33823))
+
+	// The copy does not share its jewel sockets with the original
+	testza.AssertNotNil(t, build.Tree.Specs[copied].Sockets)
+	socketed := build.Tree.Specs[0].Sockets.Sockets[0].ItemID
+	build.Tree.Specs[copied].Sockets.Sockets[0].ItemID = socketed + 1
+	testza.AssertEqual(t, socketed, build.Tree.Specs[0].Sockets.Sockets[0].ItemID)
+
+	added := build.AddSpec("Leveling")
+	testza.AssertEqual(t, 3, added)
+	testza.AssertEqual(t, data.LatestTreeVersion, build.Tree.Specs[added].TreeVersion)
+	testza.AssertEqual(t, 4, build.Tree.Specs[added].ClassID)
+	testza.AssertLen(t, build.Tree.Specs[added].Nodes, 0)
+
+	testza.AssertNoError(t, build.SetActiveSpec(added))
+	testza.AssertNoError(t, build.DeleteSpec(0))
+	testza.AssertEqual(t, 3, build.Tree.ActiveSpec)
+	testza.AssertEqual(t, "Leveling", build.ActiveSpec().Title)
+	testza.AssertNoError(t, build.DeleteSpec(2))
+	testza.AssertEqual(t, 2, build.Tree.ActiveSpec)
+	testza.AssertContains(t, build.Build.PassiveNodes, int64(33823))
+	testza.AssertNoError(t, build.DeleteSpec(0))
+	testza.AssertNotNil(t, build.DeleteSpec(0))
+
+	out, err := SerializeBuild(build)
+	testza.AssertNoError(t, err)
+
+	reparsed, err := ParseBuild(out)
+	testza.AssertNoError(t, err)
+	testza.AssertLen(t, reparsed.Tree.Specs, 1)
+	testza.AssertEqual(t, build.Build.PassiveNodes, reparsed.Build.PassiveNodes)
+	testza.AssertEqual(t, build.ActiveSpec().MasterySelections, reparsed.ActiveSpec().MasterySelections)
+}
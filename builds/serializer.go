@@ -0,0 +1,97 @@
+package builds
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Vilsol/go-pob/pob"
+)
+
+var gameGemIDsToPobGemID = make(map[string]string, len(pobGemIDtoGameGemIDs))
+
+func init() {
+	for pobID, gameID := range pobGemIDtoGameGemIDs {
+		gameGemIDsToPobGemID[gameID] = pobID
+	}
+}
+
+func SerializeBuildStr(build *pob.PathOfBuilding) (string, error) {
+	out, err := SerializeBuild(build)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// SerializeBuild converts the build back into XML that Path of Building can import
+func SerializeBuild(build *pob.PathOfBuilding) ([]byte, error) {
+	out := *build
+
+	out.Skills.SkillSets = make([]pob.SkillSet, len(build.Skills.SkillSets))
+	for i, set := range build.Skills.SkillSets {
+		set.Skills = make([]pob.Skill, len(set.Skills))
+		for j, skill := range build.Skills.SkillSets[i].Skills {
+			gems := make([]pob.Gem, len(skill.Gems))
+			for k, gem := range skill.Gems {
+				if pobID, ok := gameGemIDsToPobGemID[gem.GemID]; ok {
+					gem.GemID = pobID
+				}
+				gems[k] = gem
+			}
+			skill.Gems = gems
+			set.Skills[j] = skill
+		}
+		out.Skills.SkillSets[i] = set
+	}
+
+	out.Tree.Specs = make([]pob.Spec, len(build.Tree.Specs))
+	copy(out.Tree.Specs, build.Tree.Specs)
+	if build.Build.PassiveNodes != nil && build.Tree.ActiveSpec > 0 && build.Tree.ActiveSpec <= len(out.Tree.Specs) {
+		out.Tree.Specs[build.Tree.ActiveSpec-1].Nodes = build.Build.PassiveNodes
+	}
+	for i := range out.Tree.Specs {
+		serializeSpec(&out.Tree.Specs[i])
+	}
+
+	rawXML, err := xml.Marshal(&out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize build as xml: %w", err)
+	}
+
+	return append([]byte(xml.Header), rawXML...), nil
+}
+
+func serializeSpec(spec *pob.Spec) {
+	if spec.Nodes != nil {
+		nodeStrs := make([]string, len(spec.Nodes))
+		for i, node := range spec.Nodes {
+			nodeStrs[i] = strconv.FormatInt(node, 10)
+		}
+		spec.NodesAttr = strings.Join(nodeStrs, ",")
+	}
+
+	if spec.MasterySelections != nil {
+		effectStrs := make([]string, len(spec.MasterySelections))
+		for i, selection := range spec.MasterySelections {
+			effectStrs[i] = fmt.Sprintf("{%d,%d}", selection.NodeID, selection.EffectID)
+		}
+		spec.MasteryEffects = strings.Join(effectStrs, ",")
+	}
+}
+
+// ExportBuild serializes the build and encodes it into a shareable build code
+func ExportBuild(build *pob.PathOfBuilding) (string, error) {
+	rawXML, err := SerializeBuildStr(build)
+	if err != nil {
+		return "", err
+	}
+
+	code, err := pob.CompressEncode(rawXML)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode build: %w", err)
+	}
+
+	return code, nil
+}
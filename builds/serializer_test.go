@@ -0,0 +1,180 @@
+package builds
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/Vilsol/go-pob/pob"
+)
+
+// xmlNode is a generic element used to compare documents regardless of attribute order and number formatting
+type xmlNode struct {
+	Name     string
+	Attrs    map[string]string
+	Text     string
+	Children []*xmlNode
+}
+
+// defaultAttrValues are attributes that Path of Building reads the same whether they are absent or hold this value
+var defaultAttrValues = map[string]string{
+	"enableGlobal2": "false",
+}
+
+func parseXMLNode(t *testing.T, raw []byte) *xmlNode {
+	t.Helper()
+
+	decoder := xml.NewDecoder(bytes.NewReader(nilCleanupRegex.ReplaceAllLiteral(raw, []byte{})))
+	var stack []*xmlNode
+	var root *xmlNode
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch tok := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{Name: tok.Name.Local, Attrs: make(map[string]string)}
+			for _, attr := range tok.Attr {
+				value := normalizeXMLValue(attr.Value)
+				if defaultValue, ok := defaultAttrValues[attr.Name.Local]; ok && defaultValue == value {
+					continue
+				}
+				node.Attrs[attr.Name.Local] = value
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, node)
+			} else {
+				root = node
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += string(tok)
+			}
+		}
+	}
+
+	testza.AssertNotNil(t, root)
+	return root
+}
+
+func normalizeXMLValue(value string) string {
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return value
+}
+
+// String renders the node canonically, keeping the order of sibling elements that share a name
+func (n *xmlNode) String() string {
+	var sb strings.Builder
+	n.write(&sb, "")
+	return sb.String()
+}
+
+func (n *xmlNode) write(sb *strings.Builder, indent string) {
+	keys := make([]string, 0, len(n.Attrs))
+	for k := range n.Attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sb.WriteString(indent + "<" + n.Name)
+	for _, k := range keys {
+		sb.WriteString(" " + k + "=" + strconv.Quote(n.Attrs[k]))
+	}
+	sb.WriteString(">")
+	if text := strings.TrimSpace(n.Text); text != "" {
+		sb.WriteString(strconv.Quote(text))
+	}
+	sb.WriteString("\n")
+
+	children := make([]*xmlNode, len(n.Children))
+	copy(children, n.Children)
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].Name < children[j].Name
+	})
+	for _, child := range children {
+		child.write(sb, indent+"  ")
+	}
+}
+
+func testBuildFiles(t *testing.T) []string {
+	t.Helper()
+
+	files, err := filepath.Glob("../testdata/many-builds/*.xml")
+	testza.AssertNoError(t, err)
+
+	builds, err := filepath.Glob("../testdata/builds/*.xml")
+	testza.AssertNoError(t, err)
+
+	return append(files, builds...)
+}
+
+func TestSerializeBuildRoundTrip(t *testing.T) {
+	for _, path := range testBuildFiles(t) {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			file, err := os.ReadFile(path)
+			testza.AssertNoError(t, err)
+
+			build, err := ParseBuild(file)
+			testza.AssertNoError(t, err)
+
+			out, err := SerializeBuild(build)
+			testza.AssertNoError(t, err)
+
+			testza.AssertEqual(t, parseXMLNode(t, file).String(), parseXMLNode(t, out).String())
+
+			reparsed, err := ParseBuild(out)
+			testza.AssertNoError(t, err)
+
+			again, err := SerializeBuild(reparsed)
+			testza.AssertNoError(t, err)
+			testza.AssertEqual(t, string(out), string(again))
+		})
+	}
+}
+
+func TestSerializeBuildChanges(t *testing.T) {
+	file, err := os.ReadFile("../testdata/many-builds/1.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	nodes := len(build.Build.PassiveNodes)
+	removed := build.Build.PassiveNodes[0]
+	build.DeallocateNodes(removed)
+	build.SetLevel(95)
+	value := "Guardian"
+	build.SetConfigOption(pob.Input{Name: "test", String: &value})
+
+	code, err := ExportBuild(build)
+	testza.AssertNoError(t, err)
+
+	rawXML, err := pob.DecodeDecompress(code)
+	testza.AssertNoError(t, err)
+	testza.AssertContains(t, rawXML, `gemId="Metadata/Items/Gems/HeraldOfAgony"`)
+	testza.AssertNotContains(t, rawXML, `gemId="Metadata/Items/Gems/SkillGemHeraldOfAgony"`)
+
+	decoded, err := ParseBuildStr(rawXML)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, 95, decoded.Build.Level)
+	testza.AssertLen(t, decoded.Build.PassiveNodes, nodes-1)
+	testza.AssertNotContains(t, decoded.Build.PassiveNodes, removed)
+	testza.AssertEqual(t, "Guardian", decoded.GetStringOption("test"))
+	testza.AssertEqual(t, build.Items.Items[0].Raw, decoded.Items.Items[0].Raw)
+	testza.AssertEqual(t, build.Skills.SkillSets, decoded.Skills.SkillSets)
+}
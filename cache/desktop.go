@@ -4,6 +4,7 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -18,29 +19,51 @@ import (
 
 var cache *fscache.FSCache
 
+// DataDirEnv names the environment variable that points all data loading at a local data directory
+const DataDirEnv = "GO_POB_DATA_DIR"
+
+// ErrCacheUnavailable is returned by the disk cache when it could not be initialized
+var ErrCacheUnavailable = errors.New("disk cache is unavailable")
+
 func init() {
+	if dataDir := os.Getenv(DataDirEnv); dataDir != "" {
+		Use(Directory(dataDir))
+	}
+
+	if err := initDiskCache(); err != nil {
+		slog.Warn("disk cache disabled", slog.String("error", err.Error()))
+	}
+}
+
+func initDiskCache() error {
 	dir, err := os.UserCacheDir()
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("failed to find user cache dir: %w", err)
 	}
 
 	baseCacheDir := filepath.Join(dir, "go-pob", "bundle-cache")
 	if err := os.MkdirAll(baseCacheDir, 0777); err != nil {
 		if !os.IsExist(err) {
-			panic(err)
+			return fmt.Errorf("failed to create cache dir: %w", err)
 		}
 	}
 
 	cache, err = fscache.New(baseCacheDir, 0755, time.Hour*24*30) // 30 day cache
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("failed to create cache: %w", err)
 	}
+
+	return nil
 }
 
 type desktopCache struct {
 }
 
 func Disk() DiskCache {
+	if override != nil {
+		return override
+	}
+
 	return desktopCache{}
 }
 
@@ -52,6 +75,10 @@ func (d desktopCache) Get(key string) ([]byte, error) {
 		slog.String("key", key),
 	)
 
+	if cache == nil {
+		return nil, ErrCacheUnavailable
+	}
+
 	r, _, err := cache.Get(key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get key from cache: %s: %w", key, err)
@@ -78,6 +105,10 @@ func (d desktopCache) Set(key string, value []byte) error {
 		slog.Int("len", len(value)),
 	)
 
+	if cache == nil {
+		return ErrCacheUnavailable
+	}
+
 	_ = cache.Remove(key)
 
 	_, w, err := cache.Get(key)
@@ -99,7 +130,7 @@ func (d desktopCache) Set(key string, value []byte) error {
 }
 
 func (d desktopCache) Exists(key string) bool {
-	return cache.Exists(key)
+	return cache != nil && cache.Exists(key)
 }
 
 func InitializeDiskCache(_ func(key string) []byte, _ func(key string, value []byte), _ func(key string) bool) {
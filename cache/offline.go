@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CDNBase is the prefix of the keys of all data files
+const CDNBase = "https://go-pob-data.pages.dev/data/"
+
+var override DiskCache
+
+// Use replaces the cache returned by Disk, pass nil to restore the default
+func Use(source DiskCache) {
+	override = source
+}
+
+// KeyPath returns the path of a data file relative to the data directory, e.g. 3.18/tree/data.json.br
+func KeyPath(key string) string {
+	return strings.TrimPrefix(key, CDNBase)
+}
+
+type offlineCache struct {
+	fsys fs.FS
+}
+
+// FS returns a read-only cache that serves data files from fsys, laid out as they are on the CDN.
+// Missing files are reported as errors instead of being fetched.
+func FS(fsys fs.FS) DiskCache {
+	return offlineCache{fsys: fsys}
+}
+
+// Directory returns a read-only cache that serves data files from a local directory
+func Directory(dir string) DiskCache {
+	return FS(os.DirFS(dir))
+}
+
+func (o offlineCache) Get(key string) ([]byte, error) {
+	b, err := fs.ReadFile(o.fsys, KeyPath(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read offline data: %s: %w", key, err)
+	}
+	return b, nil
+}
+
+func (o offlineCache) Set(key string, _ []byte) error {
+	return fmt.Errorf("offline data is read-only: %s", key)
+}
+
+// Exists always reports true, so that callers read missing files from the offline data and fail instead of going online
+func (o offlineCache) Exists(_ string) bool {
+	return true
+}
+
+type mirrorCache struct {
+	source DiskCache
+	dir    string
+}
+
+// Mirror returns a cache that copies every data file read from or stored in source into dir
+func Mirror(source DiskCache, dir string) DiskCache {
+	return mirrorCache{source: source, dir: dir}
+}
+
+func (m mirrorCache) Get(key string) ([]byte, error) {
+	b, err := m.source.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if b != nil {
+		if err := m.write(key, b); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+func (m mirrorCache) Set(key string, value []byte) error {
+	if err := m.write(key, value); err != nil {
+		return err
+	}
+	return m.source.Set(key, value)
+}
+
+func (m mirrorCache) Exists(key string) bool {
+	return m.source.Exists(key)
+}
+
+func (m mirrorCache) write(key string, value []byte) error {
+	target := filepath.Join(m.dir, filepath.FromSlash(KeyPath(key)))
+	if err := os.MkdirAll(filepath.Dir(target), 0777); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	if err := os.WriteFile(target, value, 0666); err != nil {
+		return fmt.Errorf("failed to write data file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MarvinJWendt/testza"
+)
+
+func TestOffline(t *testing.T) {
+	treeKey := CDNBase + "3.18/tree/data.json.br"
+	offline := FS(fstest.MapFS{
+		"3.18/tree/data.json.br": &fstest.MapFile{Data: []byte("tree")},
+	})
+
+	testza.AssertTrue(t, offline.Exists(treeKey))
+	b, err := offline.Get(treeKey)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, []byte("tree"), b)
+
+	testza.AssertTrue(t, offline.Exists(CDNBase+"3.18/raw/Mods.msgpack.br"))
+	_, err = offline.Get(CDNBase + "3.18/raw/Mods.msgpack.br")
+	testza.AssertErrorIs(t, err, os.ErrNotExist)
+	testza.AssertNotNil(t, offline.Set(treeKey, []byte("other")))
+
+	Use(offline)
+	defer Use(nil)
+	testza.AssertEqual(t, offline, Disk())
+}
+
+func TestMirror(t *testing.T) {
+	treeKey := CDNBase + "3.18/tree/data.json.br"
+	dir := t.TempDir()
+	mirror := Mirror(FS(fstest.MapFS{
+		"3.18/tree/data.json.br": &fstest.MapFile{Data: []byte("tree")},
+	}), dir)
+
+	_, err := mirror.Get(treeKey)
+	testza.AssertNoError(t, err)
+
+	b, err := os.ReadFile(filepath.Join(dir, "3.18", "tree", "data.json.br"))
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, []byte("tree"), b)
+
+	b, err = Directory(dir).Get(treeKey)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, []byte("tree"), b)
+}
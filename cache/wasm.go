@@ -17,6 +17,10 @@ type wasmCache struct {
 }
 
 func Disk() DiskCache {
+	if override != nil {
+		return override
+	}
+
 	// This gets invoked by tests
 	if cache == nil {
 		return &wasmCache{
@@ -0,0 +1,222 @@
+package calculator
+
+import (
+	"fmt"
+
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/moddb"
+)
+
+// Breakdown records how the output stats of an actor or a damage pass were calculated.
+// It is only populated in OutputModeCalcs.
+type Breakdown struct {
+	Stats    map[string]*StatBreakdown
+	MainHand *Breakdown
+	OffHand  *Breakdown
+
+	modStore moddb.ModStoreFuncs
+}
+
+// StatBreakdown explains how a single output stat was calculated
+type StatBreakdown struct {
+	Base  float64
+	Inc   float64 // Multiplier from increased and reduced modifiers
+	More  float64 // Multiplier from more and less modifiers
+	Total float64
+
+	Mods        []BreakdownMod         // Modifiers that contributed to the stat
+	DamageTypes []*DamageTypeBreakdown // Damage types and conversions that make up the stat
+	Lines       []string               // Formula, one step per line
+}
+
+// BreakdownMod is a single modifier contributing to a stat
+type BreakdownMod struct {
+	Name         string
+	Type         mod.Type
+	Value        float64
+	Source       mod.Source
+	Flags        mod.MFlag
+	KeywordFlags mod.KeywordFlag
+}
+
+// DamageTypeBreakdown is a single damage type in the conversion chain of a hit
+type DamageTypeBreakdown struct {
+	Source     data.DamageType
+	BaseMin    float64
+	BaseMax    float64
+	Inc        float64
+	More       float64
+	ConvSrcMin float64 // Damage converted or gained from preceding damage types
+	ConvSrcMax float64
+	TotalMin   float64
+	TotalMax   float64
+
+	ConvDst        data.DamageType // Damage type this one is converted to, empty if it is the final type
+	ConvDstPercent float64
+
+	Mods []BreakdownMod
+}
+
+func newBreakdown(modStore moddb.ModStoreFuncs) *Breakdown {
+	return &Breakdown{
+		Stats:    make(map[string]*StatBreakdown),
+		modStore: modStore,
+	}
+}
+
+// Stat returns the breakdown of a stat, creating it if it does not exist yet
+func (b *Breakdown) Stat(stat string) *StatBreakdown {
+	if s, ok := b.Stats[stat]; ok {
+		return s
+	}
+
+	s := &StatBreakdown{Inc: 1, More: 1}
+	b.Stats[stat] = s
+	return s
+}
+
+// Set replaces the breakdown of a stat with the provided formula lines
+func (b *Breakdown) Set(stat string, total float64, lines ...string) *StatBreakdown {
+	s := &StatBreakdown{Inc: 1, More: 1, Total: total, Lines: lines}
+	b.Stats[stat] = s
+	return s
+}
+
+func (s *StatBreakdown) line(format string, args ...interface{}) {
+	s.Lines = append(s.Lines, fmt.Sprintf(format, args...))
+}
+
+// simple records a stat that was calculated as base * increased * more
+func (b *Breakdown) simple(extraBase float64, cfg *moddb.ListCfg, total float64, names ...string) *StatBreakdown {
+	base := b.modStore.Sum(mod.TypeBase, cfg, names[0])
+	inc := b.modStore.Sum(mod.TypeIncrease, cfg, names...)
+	more := b.modStore.More(cfg, names...)
+
+	s := &StatBreakdown{
+		Base:  base + extraBase,
+		Inc:   1 + inc/100,
+		More:  more,
+		Total: total,
+		Mods:  append(tabulateMods(b.modStore, cfg, mod.TypeBase, names[0]), tabulateMods(b.modStore, cfg, mod.TypeIncrease, names...)...),
+	}
+	s.Mods = append(s.Mods, tabulateMods(b.modStore, cfg, mod.TypeMore, names...)...)
+
+	if base != 0 && extraBase != 0 {
+		s.line("(%g + %g) (base)", extraBase, base)
+	} else {
+		s.line("%g (base)", base+extraBase)
+	}
+	if inc != 0 {
+		s.line("x %.2f (increased/reduced)", 1+inc/100)
+	}
+	if more != 1 {
+		s.line("x %.2f (more/less)", more)
+	}
+	s.line("= %g", total)
+
+	b.Stats[names[0]] = s
+	return s
+}
+
+// leech records the instant and per instance leech recovery of a pool
+func (b *Breakdown) leech(stat string, instant float64, instances float64, pool float64, instanceRate float64, max float64, duration float64) *StatBreakdown {
+	total := instanceRate * instances
+	s := b.Set(stat, total)
+	if instant > 0 {
+		s.line("Instant Leech: %.1f", instant)
+	}
+	if instances > 0 {
+		s.line("Rate per instance:")
+		s.line("%.0f (size of pool)", pool)
+		s.line("x %.2f (base leech rate is %g%% per second)", data.LeechRateBase, data.LeechRateBase*100)
+		if rateMod := instanceRate / pool / data.LeechRateBase; rateMod != 1 {
+			s.line("x %.2f (leech rate modifier)", rateMod)
+		}
+		s.line("= %.1f per second", instanceRate)
+		s.line("Maximum leech rate against one target:")
+		s.line("%.1f", instanceRate)
+		s.line("x %.1f (average instances)", instances)
+		s.line("= %.1f per second", total)
+		if total <= max {
+			s.line("Time to reach max: %.1fs", duration)
+		}
+	}
+	return s
+}
+
+// chainFactor is a single multiplier of a multiChain
+type chainFactor struct {
+	format string
+	value  float64
+}
+
+// multiChain records a base value scaled by a chain of multipliers, skipping multipliers that have no effect.
+// Nothing is recorded if none of the multipliers have an effect.
+func (s *StatBreakdown) multiChain(label string, base string, total string, factors ...chainFactor) {
+	lines := make([]string, 0, len(factors))
+	for _, factor := range factors {
+		if factor.value != 1 {
+			lines = append(lines, "x "+fmt.Sprintf(factor.format, factor.value))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	if label != "" {
+		s.line("%s", label)
+	}
+	s.line("%s", base)
+	s.Lines = append(s.Lines, lines...)
+	s.line("%s", total)
+}
+
+// effMult records how the resistance and damage taken modifiers of the enemy scale damage dealt to it
+func (b *Breakdown) effMult(stat string, damageType data.DamageType, resist float64, takenInc float64, takenMore float64, total float64) *StatBreakdown {
+	resistForm := "resistance"
+	if damageType == data.DamageTypePhysical {
+		resistForm = "physical damage reduction"
+	}
+
+	s := b.Set(stat, total)
+	if resist != 0 {
+		s.line("Enemy %s: %.0f%%", resistForm, resist)
+	}
+	if resist != 0 || takenInc != 0 || takenMore != 1 {
+		s.line("Effective DPS modifier:")
+		if resist != 0 {
+			s.line("%.2f (%s)", 1-resist/100, resistForm)
+		}
+		if takenInc != 0 {
+			s.line("x %.2f (increased/reduced damage taken)", 1+takenInc/100)
+		}
+		if takenMore != 1 {
+			s.line("x %.2f (more/less damage taken)", takenMore)
+		}
+		s.line("= %.3f", total)
+	}
+	return s
+}
+
+// tabulateMods lists the modifiers of a type that match the query
+func tabulateMods(modStore moddb.ModStoreFuncs, cfg *moddb.ListCfg, modType mod.Type, names ...string) []BreakdownMod {
+	tabulated := modStore.Tabulate(modType, cfg, names...)
+	out := make([]BreakdownMod, 0, len(tabulated))
+	for _, t := range tabulated {
+		value, ok := t.Value.(float64)
+		if !ok {
+			continue
+		}
+
+		out = append(out, BreakdownMod{
+			Name:         t.Mod.Name(),
+			Type:         t.Mod.Type(),
+			Value:        value,
+			Source:       t.Mod.GetSource(),
+			Flags:        t.Mod.Flags(),
+			KeywordFlags: t.Mod.KeywordFlags(),
+		})
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package calculator
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/Vilsol/go-pob/builds"
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/pob"
+)
+
+func TestBreakdown(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	env, err := NewCalculator(*build.WithMainSocketGroup(6)).BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertNil(t, env.Player.Breakdown)
+
+	skillNumber := float64(6)
+	build.Calcs.Inputs = []pob.Input{{Name: "skill_number", Number: &skillNumber}}
+	env, err = NewCalculator(*build).BuildOutput(context.Background(), OutputModeCalcs)
+	testza.AssertNoError(t, err)
+
+	breakdown := env.Player.Breakdown
+	testza.AssertNotNil(t, breakdown)
+	testza.AssertNil(t, breakdown.MainHand)
+
+	for _, stat := range []string{"Str", "Dex", "Int"} {
+		testza.AssertNotNil(t, breakdown.Stats[stat])
+		testza.AssertEqual(t, env.Player.Output[stat], breakdown.Stats[stat].Total)
+		testza.AssertNotZero(t, len(breakdown.Stats[stat].Lines))
+	}
+
+	speed := breakdown.Stats["Speed"]
+	testza.AssertNotNil(t, speed)
+	testza.AssertNotZero(t, len(speed.Lines))
+
+	fire := breakdown.Stats[string(data.DamageTypeFire)]
+	testza.AssertNotNil(t, fire)
+	testza.AssertNotZero(t, len(fire.DamageTypes))
+	testza.AssertEqual(t, data.DamageTypeFire, fire.DamageTypes[0].Source)
+
+	cold := breakdown.Stats[string(data.DamageTypeCold)]
+	testza.AssertNotNil(t, cold)
+	testza.AssertNotZero(t, len(cold.DamageTypes))
+	testza.AssertNotZero(t, len(cold.Mods))
+	testza.AssertNotEqual(t, mod.Source(""), cold.Mods[0].Source)
+
+	dps := breakdown.Stats["TotalDPS"]
+	testza.AssertNotNil(t, dps)
+	testza.AssertEqual(t, env.Player.Output["TotalDPS"], dps.Total)
+	testza.AssertContains(t, dps.Lines, "x 1.33 (cast rate)")
+}
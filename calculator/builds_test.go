@@ -47,7 +47,8 @@ func TestManyBuilds(t *testing.T) {
 				testza.AssertNoError(t, err)
 
 				calculator := &Calculator{PoB: build}
-				env := calculator.BuildOutput(OutputModeMain)
+				env, err := calculator.BuildOutput(context.Background(), OutputModeMain)
+				testza.AssertNoError(t, err)
 
 				for _, stat := range build.Build.PlayerStats {
 					testza.AssertEqual(t, stat.Value, env.Player.OutputTable[OutTableMainHand][stat.Stat], stat.Stat)
@@ -1,6 +1,13 @@
 package calculator
 
 import (
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+
 	"github.com/Vilsol/go-pob-data/poe"
 	"github.com/Vilsol/go-pob/data"
 	raw2 "github.com/Vilsol/go-pob/data/raw"
@@ -109,8 +116,9 @@ func mergeLevelMod(modList *moddb.ModList, m mod.Mod, value float64) {
 		cast.ModValue = value
 	case *mod.ListMod:
 		switch inner := cast.Value().(type) {
-		case *mod.SkillData:
+		case mod.SkillData:
 			inner.Value = value
+			cast.ModValue = inner
 		}
 	}
 
@@ -366,6 +374,7 @@ func CalcBuildActiveSkillModList(env *Environment, activeSkill *ActiveSkill) {
 		Flags:        utils.Ptr(skillModFlags | activeSkill.Weapon1Flags | activeSkill.Weapon2Flags),
 		KeywordFlags: utils.Ptr(skillKeywordFlags),
 		SkillCond:    make(map[string]bool),
+		SkillID:      activeGrantedEffect.Raw.ID,
 		/*
 			TODO
 			skillName = activeGrantedEffect.name:gsub("^Vaal ",""):gsub("Summon Skeletons","Summon Skeleton"), -- This allows modifiers that target specific skills to also apply to their Vaal counterpart
@@ -389,24 +398,27 @@ func CalcBuildActiveSkillModList(env *Environment, activeSkill *ActiveSkill) {
 			Source:       activeSkill.SkillCfg.Source,
 			SkillStats:   activeSkill.SkillCfg.SkillStats,
 			SkillCond:    cond,
+			SkillID:      activeSkill.SkillCfg.SkillID,
 		}
 	}
 
 	if skillFlags[SkillFlagWeapon2Attack] {
 		cond := utils.CopyMap(activeSkill.SkillCfg.SkillCond)
 		cond["OffHandAttack"] = true
-		activeSkill.Weapon1Cfg = &moddb.ListCfg{
+		activeSkill.Weapon2Cfg = &moddb.ListCfg{
 			Flags:        utils.Ptr(skillModFlags | activeSkill.Weapon2Flags),
 			KeywordFlags: activeSkill.SkillCfg.KeywordFlags,
 			Source:       activeSkill.SkillCfg.Source,
 			SkillStats:   activeSkill.SkillCfg.SkillStats,
 			SkillCond:    cond,
+			SkillID:      activeSkill.SkillCfg.SkillID,
 		}
 	}
 
 	// Initialise skill modifier list
 	skillModList := moddb.NewModList()
 	skillModList.Parent = activeSkill.Actor.ModDB
+	skillModList.SkillID = activeSkill.SkillCfg.SkillID
 	activeSkill.SkillModList = skillModList
 	activeSkill.BaseSkillModList = skillModList
 
@@ -433,7 +445,7 @@ func CalcBuildActiveSkillModList(env *Environment, activeSkill *ActiveSkill) {
 			level := raw2.GetCalculatedGrantedEffect(skillEffect.GrantedEffect.Raw).GetCalculatedLevels()[skillEffect.Level]
 			if level.ManaMultiplier != nil {
 				// TODO skillEffect.grantedEffect.modSource
-				skillModList.AddMod(mod.NewFloat("SupportManaMultiplier", mod.TypeBase, *level.ManaMultiplier))
+				skillModList.AddMod(mod.NewFloat("SupportManaMultiplier", mod.TypeMore, *level.ManaMultiplier))
 			}
 			if level.ManaReservationPercent != nil {
 				activeSkill.SkillData["ManaReservationPercent"] = *level.ManaReservationPercent
@@ -455,8 +467,9 @@ func CalcBuildActiveSkillModList(env *Environment, activeSkill *ActiveSkill) {
 
 	// Add active gem modifiers
 
-	// TODO Minions
-	// activeEffect.actorLevel = activeSkill.actor.minionData and activeSkill.actor.level
+	if activeSkill.Actor.MinionData != nil {
+		activeEffect.ActorLevel = activeSkill.Actor.Level
+	}
 	CalcMergeSkillInstanceMods(env, skillModList, activeEffect, skillModList.List(activeSkill.SkillCfg, "ExtraSkillStat"))
 	activeEffect.GrantedEffectLevel = raw2.GetCalculatedGrantedEffect(activeGrantedEffect.Raw).GetCalculatedLevels()[activeEffect.Level]
 
@@ -525,218 +538,320 @@ func CalcBuildActiveSkillModList(env *Environment, activeSkill *ActiveSkill) {
 	*/
 
 	// Extract skill data
-	for _, value := range utils.CastSlice[*mod.SkillData](env.ModDB.List(activeSkill.SkillCfg, "SkillData")) {
+	for _, value := range utils.CastSlice[mod.SkillData](env.ModDB.List(activeSkill.SkillCfg, "SkillData")) {
 		activeSkill.SkillData[value.Key] = value.Value
 	}
-	for _, value := range utils.CastSlice[*mod.SkillData](skillModList.List(activeSkill.SkillCfg, "SkillData")) {
+	for _, value := range utils.CastSlice[mod.SkillData](skillModList.List(activeSkill.SkillCfg, "SkillData")) {
 		activeSkill.SkillData[value.Key] = value.Value
 	}
 
-	/*
-		TODO -- Create minion
-		local minionList, isSpectre
-		if activeGrantedEffect.minionList then
-			if activeGrantedEffect.minionList[1] then
-				minionList = copyTable(activeGrantedEffect.minionList)
-			else
-				minionList = copyTable(env.build.spectreList)
-				isSpectre = true
-			end
-		else
-			minionList = { }
-		end
-		for _, skillEffect in ipairs(activeSkill.effectList) do
-			if skillEffect.grantedEffect.support and skillEffect.grantedEffect.addMinionList then
-				for _, minionType in ipairs(skillEffect.grantedEffect.addMinionList) do
-					t_insert(minionList, minionType)
-				end
-			end
-		end
-		activeSkill.minionList = minionList
-		if minionList[1] and not activeSkill.actor.minionData then
-			local minionType
-			if env.mode == "CALCS" and activeSkill == env.player.mainSkill then
-				local index = isValueInArray(minionList, activeEffect.srcInstance.skillMinionCalcs) or 1
-				minionType = minionList[index]
-				activeEffect.srcInstance.skillMinionCalcs = minionType
-			else
-				local index = isValueInArray(minionList, activeEffect.srcInstance.skillMinion) or 1
-				minionType = minionList[index]
-				activeEffect.srcInstance.skillMinion = minionType
-			end
-			if minionType then
-				local minion = { }
-				activeSkill.minion = minion
-				skillFlags.haveMinion = true
-				minion.parent = env.player
-				minion.enemy = env.enemy
-				minion.type = minionType
-				minion.minionData = env.data.minions[minionType]
-				minion.level = activeSkill.skillData.minionLevelIsEnemyLevel and env.enemyLevel or activeSkill.skillData.minionLevel or activeEffect.grantedEffectLevel.levelRequirement
-				-- fix minion level between 1 and 100
-				minion.level = m_min(m_max(minion.level,1),100)
-				minion.itemList = { }
-				minion.uses = activeGrantedEffect.minionUses
-				minion.lifeTable = isSpectre and env.data.monsterLifeTable or env.data.monsterAllyLifeTable
-				local attackTime = minion.minionData.attackTime * (1 - (minion.minionData.damageFixup or 0))
-				local damage = env.data.monsterDamageTable[minion.level] * minion.minionData.damage * attackTime
-				if activeGrantedEffect.minionHasItemSet then
-					if env.mode == "CALCS" and activeSkill == env.player.mainSkill then
-						if not env.build.itemsTab.itemSets[activeEffect.srcInstance.skillMinionItemSetCalcs] then
-							activeEffect.srcInstance.skillMinionItemSetCalcs = env.build.itemsTab.itemSetOrderList[1]
-						end
-						minion.itemSet = env.build.itemsTab.itemSets[activeEffect.srcInstance.skillMinionItemSetCalcs]
-					else
-						if not env.build.itemsTab.itemSets[activeEffect.srcInstance.skillMinionItemSet] then
-							activeEffect.srcInstance.skillMinionItemSet = env.build.itemsTab.itemSetOrderList[1]
-						end
-						minion.itemSet = env.build.itemsTab.itemSets[activeEffect.srcInstance.skillMinionItemSet]
-					end
-				end
-				if activeSkill.skillData.minionUseBowAndQuiver and env.player.weaponData1.type == "Bow" then
-					minion.weaponData1 = env.player.weaponData1
-				elseif env.theIronMass and minionType == "RaisedSkeleton" then
-					minion.weaponData1 = env.player.weaponData1
-				else
-					minion.weaponData1 = {
-						type = minion.minionData.weaponType1 or "None",
-						AttackRate = 1 / attackTime,
-						CritChance = 5,
-						PhysicalMin = round(damage * (1 - minion.minionData.damageSpread)),
-						PhysicalMax = round(damage * (1 + minion.minionData.damageSpread)),
-						range = minion.minionData.attackRange,
-					}
-				end
-				minion.weaponData2 = { }
-				if minion.uses then
-					if minion.uses["Weapon 1"] then
-						if minion.itemSet then
-							local item = env.build.itemsTab.items[minion.itemSet[minion.itemSet.useSecondWeaponSet and "Weapon 1 Swap" or "Weapon 1"].selItemId]
-							if item and item.weaponData then
-								minion.weaponData1 = item.weaponData[1]
-							end
-						else
-							minion.weaponData1 = env.player.weaponData1
-						end
-					end
-					if minion.uses["Weapon 2"] then
-						if minion.itemSet then
-							local item = env.build.itemsTab.items[minion.itemSet[minion.itemSet.useSecondWeaponSet and "Weapon 2 Swap" or "Weapon 2"].selItemId]
-							if item and item.weaponData then
-								minion.weaponData2 = item.weaponData[2]
-							end
-						else
-							minion.weaponData2 = env.player.weaponData2
-						end
-					end
-				end
-			end
-		end
-	*/
-	/*
-		TODO -- Separate global effect modifiers (mods that can affect defensive stats or other skills)
-		local i = 1
-		while skillModList[i] do
-			local effectType, effectName, effectTag
-			for _, tag in ipairs(skillModList[i]) do
-				if tag.type == "GlobalEffect" then
-					effectType = tag.effectType
-					effectName = tag.effectName or activeGrantedEffect.name
-					effectTag = tag
-					break
-				end
-			end
-			if effectTag and effectTag.modCond and not skillModList:GetCondition(effectTag.modCond, activeSkill.skillCfg) then
-				t_remove(skillModList, i)
-			elseif effectType then
-				local buff
-				for _, skillBuff in ipairs(activeSkill.buffList) do
-					if skillBuff.type == effectType and skillBuff.name == effectName then
-						buff = skillBuff
-						break
-					end
-				end
-				if not buff then
-					buff = {
-						type = effectType,
-						name = effectName,
-						allowTotemBuff = effectTag.allowTotemBuff,
-						cond = effectTag.effectCond,
-						enemyCond = effectTag.effectEnemyCond,
-						stackVar = effectTag.effectStackVar,
-						stackLimit = effectTag.effectStackLimit,
-						stackLimitVar = effectTag.effectStackLimitVar,
-						applyNotPlayer = effectTag.applyNotPlayer,
-						applyMinions = effectTag.applyMinions,
-						modList = { },
-						unscalableModList = { },
-					}
-					if skillModList[i].source == activeGrantedEffect.modSource then
-						-- Inherit buff configuration from the active skill
-						buff.activeSkillBuff = true
-						buff.applyNotPlayer = buff.applyNotPlayer or activeSkill.skillData.buffNotPlayer
-						buff.applyMinions = buff.applyMinions or activeSkill.skillData.buffMinions
-						buff.applyAllies = activeSkill.skillData.buffAllies
-						buff.allowTotemBuff = activeSkill.skillData.allowTotemBuff
-					end
-					t_insert(activeSkill.buffList, buff)
-				end
-				local match = false
-				local modList = effectTag.unscalable and buff.unscalableModList or buff.modList
-				for d = 1, #modList do
-					local destMod = modList[d]
-					if modLib.compareModParams(skillModList[i], destMod) and (destMod.type == "BASE" or destMod.type == "INC") then
-						destMod = copyTable(destMod)
-						destMod.value = destMod.value + skillModList[i].value
-						modList[d] = destMod
-						match = true
-						break
-					end
-				end
-				if not match then
-					t_insert(modList, skillModList[i])
-				end
-				t_remove(skillModList, i)
-			else
-				i = i + 1
-			end
-		end
+	// Create minion
+	var minionSkill *data.MinionSkill
+	if activeSkill.Actor.MinionData == nil {
+		minionSkill = data.MinionSkills[activeGrantedEffect.Raw.ID]
+	}
+	// TODO Spectres, which use the build's spectre list, and minions added by support gems
+	activeSkill.MinionList = nil
+	if minionSkill != nil {
+		activeSkill.MinionList = utils.CopySlice(minionSkill.MinionList)
+	}
+	if len(activeSkill.MinionList) > 0 {
+		minionType := activeSkill.MinionList[0]
+		srcInstance := activeEffect.SrcInstance
+		calcsMain := env.Mode == OutputModeCalcs && activeSkill == env.Player.MainSkill
+		if srcInstance != nil {
+			if calcsMain {
+				if slices.Contains(activeSkill.MinionList, srcInstance.SkillMinionCalcs) {
+					minionType = srcInstance.SkillMinionCalcs
+				}
+				srcInstance.SkillMinionCalcs = minionType
+			} else {
+				if slices.Contains(activeSkill.MinionList, srcInstance.SkillMinion) {
+					minionType = srcInstance.SkillMinion
+				}
+				srcInstance.SkillMinion = minionType
+			}
+		}
 
-		if activeSkill.buffList[1] then
-			-- Add to auxiliary skill list
-			t_insert(env.auxSkillList, activeSkill)
-		end
-	*/
+		minionData, err := data.Minion(minionType)
+		if err != nil {
+			env.DebugErrors = append(env.DebugErrors, fmt.Sprintf("failed to create minion of %s: %s", activeGrantedEffect.Raw.ID, err))
+		} else {
+			minion := &Actor{
+				Parent:          env.Player,
+				Enemy:           env.Enemy,
+				MinionType:      minionType,
+				MinionData:      minionData,
+				Level:           activeEffect.GrantedEffectLevel.LevelRequirement,
+				ItemList:        make(map[string]*pob.Item),
+				ActiveSkillList: make([]*ActiveSkill, 0),
+				Uses:            maps.Clone(minionSkill.MinionUses),
+				LifeTable:       data.MonsterAllyLifeTable,
+			}
+			activeSkill.Minion = minion
+			skillFlags[SkillFlagHaveMinion] = true
+
+			if skillDataFlag(activeSkill, "minionLevelIsEnemyLevel") {
+				minion.Level = env.EnemyLevel
+			} else if level, ok := activeSkill.SkillData["minionLevel"].(float64); ok {
+				minion.Level = int(level)
+			}
+			// Fix minion level between 1 and 100
+			minion.Level = min(max(minion.Level, 1), 100)
+
+			attackTime := minionData.AttackTime * (1 - minionData.DamageFixup)
+			damage := data.MonsterDamageTable[minion.Level] * minionData.Damage * attackTime
+			if minionSkill.MinionHasItemSet && srcInstance != nil {
+				itemSetID := utils.Ternary(calcsMain, srcInstance.SkillMinionItemSetCalcs, srcInstance.SkillMinionItemSet)
+				minion.ItemSet = env.Build.Items.SetOrFirst(itemSetID)
+				if minion.ItemSet != nil {
+					itemSetID, _ = strconv.Atoi(minion.ItemSet.ID)
+				}
+				if calcsMain {
+					srcInstance.SkillMinionItemSetCalcs = itemSetID
+				} else {
+					srcInstance.SkillMinionItemSet = itemSetID
+				}
+			}
+
+			// TODO minionUseBowAndQuiver and The Iron Mass
+			minion.WeaponData1 = &WeaponData{
+				Type:       utils.Ternary(minionData.WeaponType != "", minionData.WeaponType, data.None),
+				AttackRate: 1 / attackTime,
+				CritChance: 5,
+				Range:      minionData.AttackRange,
+				Damage: map[data.DamageType]DamageRange{
+					data.DamageTypePhysical: {
+						Min: math.Round(damage * (1 - minionData.DamageSpread)),
+						Max: math.Round(damage * (1 + minionData.DamageSpread)),
+					},
+				},
+			}
+			if minion.Uses["Weapon 1"] {
+				if minion.ItemSet == nil {
+					minion.WeaponData1 = env.Player.WeaponData1.Clone()
+				} else if _, _, weaponData := minionItem(env, minion, "Weapon 1"); weaponData != nil {
+					minion.WeaponData1 = weaponData
+				}
+			}
+			if minion.Uses["Weapon 2"] {
+				if minion.ItemSet == nil {
+					minion.WeaponData2 = env.Player.WeaponData2.Clone()
+				} else if _, _, weaponData := minionItem(env, minion, "Weapon 2"); weaponData != nil {
+					minion.WeaponData2 = weaponData
+				}
+			}
+		}
+	}
+
+	// Separate global effect modifiers (mods that can affect defensive stats or other skills)
+	// TODO effectTag.modCond, effectCond, effectEnemyCond and stack variables
+	skillName := activeGrantedEffect.Raw.GetActiveSkill().DisplayedName
+	modSource := mod.Source("Skill:" + activeGrantedEffect.Raw.ID)
+	activeSkill.BuffList = nil
+	skillModList.RemoveMods(func(m mod.Mod) bool {
+		var effectTag *mod.GlobalEffectTag
+		for _, tag := range m.Tags() {
+			if cast, ok := tag.(*mod.GlobalEffectTag); ok && len(cast.GlobalEffectList) > 0 {
+				effectTag = cast
+				break
+			}
+		}
+		if effectTag == nil {
+			return false
+		}
+
+		effectType := effectTag.GlobalEffectList[0]
+		effectName := utils.Ternary(effectTag.NameTag != "", effectTag.NameTag, skillName)
+
+		var buff *Buff
+		for _, skillBuff := range activeSkill.BuffList {
+			if skillBuff.Type == effectType && skillBuff.Name == effectName {
+				buff = skillBuff
+				break
+			}
+		}
+		if buff == nil {
+			buff = &Buff{
+				Type:              effectType,
+				Name:              effectName,
+				ModList:           moddb.NewModList(),
+				UnscalableModList: moddb.NewModList(),
+			}
+			if m.GetSource() == modSource {
+				// Inherit buff configuration from the active skill
+				buff.ActiveSkillBuff = true
+				buff.ApplyNotPlayer = skillDataFlag(activeSkill, "buffNotPlayer")
+				buff.ApplyMinions = skillDataFlag(activeSkill, "buffMinions")
+				buff.ApplyAllies = skillDataFlag(activeSkill, "buffAllies")
+				buff.AllowTotemBuff = skillDataFlag(activeSkill, "allowTotemBuff")
+			}
+			activeSkill.BuffList = append(activeSkill.BuffList, buff)
+		}
+
+		modList := utils.Ternary(effectTag.UnscalableTag, buff.UnscalableModList, buff.ModList)
+		mergeBuffMod(modList, m)
+		return true
+	})
+
+	// TODO Add to auxiliary skill list
 }
 
-func getWeaponFlags(env *Environment, weaponData map[string]interface{}, weaponTypes [][]data.ItemClassName) (mod.MFlag, *data.WeaponTypeInfo) {
-	if _, ok := weaponData["type"]; !ok {
-		return 0, nil
+// mergeBuffMod adds the mod to the buff mod list, summing it into an existing BASE or INC mod with the same parameters
+func mergeBuffMod(modList *moddb.ModList, m mod.Mod) {
+	floatMod, ok := m.(*mod.FloatMod)
+	if ok && (m.Type() == mod.TypeBase || m.Type() == mod.TypeIncrease) {
+		mods := modList.Mods()
+		for i, destMod := range mods {
+			dest, ok := destMod.(*mod.FloatMod)
+			if !ok || !compareModParams(dest, floatMod) {
+				continue
+			}
+
+			merged := dest.Clone().(*mod.FloatMod)
+			merged.ModValue += floatMod.ModValue
+			mods[i] = merged
+			return
+		}
+	}
+
+	modList.AddMod(m)
+}
+
+// compareModParams reports whether both mods only differ in their value
+func compareModParams(a mod.Mod, b mod.Mod) bool {
+	return a.Name() == b.Name() &&
+		a.Type() == b.Type() &&
+		a.Flags() == b.Flags() &&
+		a.KeywordFlags() == b.KeywordFlags() &&
+		a.GetSource() == b.GetSource() &&
+		reflect.DeepEqual(a.Tags(), b.Tags())
+}
+
+// skillDataFlag reports whether the skill data holds a truthy value for the key
+func skillDataFlag(activeSkill *ActiveSkill, key string) bool {
+	switch value := activeSkill.SkillData[key].(type) {
+	case bool:
+		return value
+	case float64:
+		return value != 0
 	}
+	return false
+}
 
-	info := data.WeaponTypes[data.ItemClassName(weaponData["type"].(string))]
+// skillDataNumber returns the numeric skill data value for the key, or the fallback if it is not set
+func skillDataNumber(activeSkill *ActiveSkill, key string, fallback float64) float64 {
+	if value, ok := activeSkill.SkillData[key].(float64); ok {
+		return value
+	}
+	return fallback
+}
 
+// minionItem returns the item the minion uses in the given slot, taken from the minion's item set or the player, with its modifiers
+func minionItem(env *Environment, minion *Actor, slotName string) (*pob.Item, *moddb.ModList, *WeaponData) {
+	var item *pob.Item
+	if minion.ItemSet != nil {
+		lookup := slotName
+		if itemSlotWeaponSet(slotName) == 1 && minion.ItemSet.UseSecondWeaponSet != nil && *minion.ItemSet.UseSecondWeaponSet {
+			lookup += " Swap"
+		}
+		item = env.Build.Items.SetSlotItem(minion.ItemSet, lookup)
+	} else {
+		item = env.Player.ItemList[slotName]
+	}
+
+	if item == nil {
+		return nil, nil, nil
+	}
+
+	modList, weaponData, _ := buildModListForItem(env, item, slotName)
+	return item, modList, weaponData
+}
+
+// createMinionSkills creates the active skills of the skill's minion and selects the minion's main skill
+func createMinionSkills(env *Environment, activeSkill *ActiveSkill) error {
+	minion := activeSkill.Minion
+
+	skillList := make([]string, 0, len(minion.MinionData.SkillList))
+	for _, skillID := range minion.MinionData.SkillList {
+		if grantedEffect := poe.GrantedEffectByID(skillID); grantedEffect != nil && grantedEffect.GetActiveSkill() != nil {
+			skillList = append(skillList, skillID)
+		}
+	}
+	// TODO Skills granted by the minion's weapons and ExtraMinionSkill mods
+	if len(skillList) == 0 {
+		return fmt.Errorf("minion %s has no supported skills", minion.MinionType)
+	}
+
+	damageEffectiveness, _ := activeSkill.SkillData["minionDamageEffectiveness"].(float64)
+	minion.ActiveSkillList = make([]*ActiveSkill, 0, len(skillList))
+	for _, skillID := range skillList {
+		grantedEffect := poe.GrantedEffectByID(skillID)
+		baseFlags, skillTypes := TypesToFlagsAndTypes(grantedEffect.GetActiveSkill().GetActiveSkillTypes())
+		activeEffect := &GemEffect{
+			GrantedEffect: &GrantedEffect{
+				Raw:        grantedEffect,
+				Parts:      nil, // TODO Parts
+				SkillTypes: skillTypes,
+				BaseFlags:  baseFlags,
+			},
+			Level: 1,
+		}
+
+		// Use the highest level of the skill the minion meets the requirement of
+		levels := raw2.GetCalculatedGrantedEffect(grantedEffect).GetCalculatedLevels()
+		for level := 2; levels[level] != nil && levels[level].LevelRequirement <= minion.Level; level++ {
+			activeEffect.Level = level
+		}
+
+		minionSkill := CreateActiveSkill(activeEffect, activeSkill.SupportList, minion, nil, activeSkill)
+		CalcBuildActiveSkillModList(env, minionSkill)
+		minionSkill.SkillFlags[SkillFlagMinion] = true
+		minionSkill.SkillFlags[SkillFlagHaveMinion] = true
+		minionSkill.SkillData["DamageEffectiveness"] = 1 + damageEffectiveness/100
+		minion.ActiveSkillList = append(minion.ActiveSkillList, minionSkill)
+	}
+
+	skillIndex := 1
+	if srcInstance := activeSkill.ActiveEffect.SrcInstance; srcInstance != nil {
+		if env.Mode == OutputModeCalcs {
+			skillIndex = max(min(srcInstance.SkillMinionSkillCalcs, len(minion.ActiveSkillList)), 1)
+			srcInstance.SkillMinionSkillCalcs = skillIndex
+		} else {
+			skillIndex = max(min(srcInstance.SkillMinionSkill, len(minion.ActiveSkillList)), 1)
+			if env.Mode == OutputModeMain {
+				srcInstance.SkillMinionSkill = skillIndex
+			}
+		}
+	}
+	minion.MainSkill = minion.ActiveSkillList[skillIndex-1]
+	return nil
+}
+
+func getWeaponFlags(env *Environment, weaponData *WeaponData, weaponTypes [][]data.ItemClassName) (mod.MFlag, *data.WeaponTypeInfo) {
+	info := weaponData.Info()
 	if info == nil {
 		return 0, nil
 	}
 
-	if weaponTypes != nil {
-		/*
-			for _, types in ipairs(weaponTypes) do
-				if not types[weaponData.type] and
-				(not weaponData.countsAsAll1H or not (types["Claw"] or types["Dagger"] or types["One Handed Axe"] or types["One Handed Mace"] or types["One Handed Sword"])) then
-					return nil, info
-				end
-			end
-		*/
+	for _, types := range weaponTypes {
+		if len(types) == 0 || slices.Contains(types, weaponData.Type) {
+			continue
+		}
+
+		if !weaponData.CountsAsAll1H || !slices.ContainsFunc(types, func(t data.ItemClassName) bool {
+			return t == data.Claw || t == data.Dagger || t == data.OneHandAxe || t == data.OneHandMace || t == data.OneHandSword
+		}) {
+			return 0, info
+		}
 	}
 
 	flags := info.ModFlag
-	if utils.HasTrue(weaponData, "CountsAsAll1H") {
+	if weaponData.CountsAsAll1H {
 		flags = mod.MFlagAxe | mod.MFlagClaw | mod.MFlagDagger | mod.MFlagMace | mod.MFlagSword
 	}
 
-	if weaponData["type"] != "None" {
+	if weaponData.Type != data.None {
 		flags |= mod.MFlagWeapon
 		if info.OneHand {
 			flags |= mod.MFlagWeapon1H
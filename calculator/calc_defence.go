@@ -1,12 +1,23 @@
 package calculator
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/Vilsol/go-pob/data"
 	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/moddb"
+	"github.com/Vilsol/go-pob/utils"
 )
 
+var resistTypeList = []data.DamageType{data.DamageTypeFire, data.DamageTypeCold, data.DamageTypeLightning, data.DamageTypeChaos}
+
+var dmgTypeList = data.DamageType("").Values()
+
+var hitSourceList = []string{"Attack", "Spell"}
+
+var ailmentTypeList = append([]data.Ailment{data.AilmentBleed, data.AilmentPoison}, data.Ailment("").Values()...)
+
 func CalcArmourReductionF(armour float64, raw float64) float64 {
 	if armour == 0 && raw == 0 {
 		return 0
@@ -26,15 +37,8 @@ func CalcHitChance(evasion float64, accuracy float64) float64 {
 	return math.Max(math.Min(math.Round(rawChance), 100), 5)
 }
 
-func CalculateDefence(environment *Environment, actor *Actor) {
-	/*
-		local modDB = actor.modDB
-		local enemyDB = actor.enemy.modDB
-		local output = actor.output
-		local breakdown = actor.breakdown
-
-		local condList = modDB.conditions
-	*/
+func CalculateDefence(env *Environment, actor *Actor) {
+	enemyDB := actor.Enemy.ModDB
 
 	// Action Speed
 	actor.Output["ActionSpeedMod"] = CalcActionSpeedMod(actor)
@@ -49,131 +53,120 @@ func CalculateDefence(environment *Environment, actor *Actor) {
 	actor.Output["PhysicalResist"] = math.Min(math.Max(0, actor.ModDB.Sum(mod.TypeBase, nil, "PhysicalDamageReduction")), actor.Output["DamageReductionMax"])
 	actor.Output["PhysicalResistWhenHit"] = math.Min(math.Max(0, actor.Output["PhysicalResist"]+actor.ModDB.Sum(mod.TypeBase, nil, "PhysicalDamageReductionWhenHit")), actor.Output["DamageReductionMax"])
 
-	/*
-		TODO -- Highest Maximum Elemental Resistance for Melding of the Flesh
-		if modDB:Flag(nil, "ElementalResistMaxIsHighestResistMax") then
-			local highestResistMax = 0;
-			local highestResistMaxType = "";
-			for _, elem in ipairs(resistTypeList) do
-				local resistMax = modDB:Override(nil, elem.."ResistMax") or m_min(data.misc.MaxResistCap, modDB:Sum("BASE", nil, elem.."ResistMax", isElemental[elem] and "ElementalResistMax"))
-				if resistMax > highestResistMax and isElemental[elem] then
-					highestResistMax = resistMax;
-					highestResistMaxType = elem;
-				end
-			end
-			for _, elem in ipairs(resistTypeList) do
-				if isElemental[elem] then
-					modDB:NewMod(elem.."ResistMax", "OVERRIDE", highestResistMax, highestResistMaxType.." Melding of the Flesh");
-				end
-			end
-		end
-
-		for _, elem in ipairs(resistTypeList) do
-			local min, max, total
-			min = data.misc.ResistFloor
-			max = modDB:Override(nil, elem.."ResistMax") or m_min(data.misc.MaxResistCap, modDB:Sum("BASE", nil, elem.."ResistMax", isElemental[elem] and "ElementalResistMax"))
-			totemMax = modDB:Override(nil, "Totem"..elem.."ResistMax") or m_min(data.misc.MaxResistCap, modDB:Sum("BASE", nil, "Totem"..elem.."ResistMax", isElemental[elem] and "TotemElementalResistMax"))
-			total = modDB:Override(nil, elem.."Resist")
-			totemTotal = modDB:Override(nil, "Totem"..elem.."Resist")
-			if not total then
-				local base = modDB:Sum("BASE", nil, elem.."Resist", isElemental[elem] and "ElementalResist")
-				total = base * calcLib.mod(modDB, nil, elem.."Resist", isElemental[elem] and "ElementalResist")
-			end
-			if not totemTotal then
-				local base = modDB:Sum("BASE", nil, "Totem"..elem.."Resist", isElemental[elem] and "TotemElementalResist")
-				totemTotal = base * calcLib.mod(modDB, nil, "Totem"..elem.."Resist", isElemental[elem] and "TotemElementalResist")
-			end
-			local final = m_max(m_min(total, max), min)
-			local totemFinal = m_max(m_min(totemTotal, totemMax), min)
-			output[elem.."Resist"] = final
-			output[elem.."ResistTotal"] = total
-			output[elem.."ResistOverCap"] = m_max(0, total - max)
-			output[elem.."ResistOver75"] = m_max(0, final - 75)
-			output["Missing"..elem.."Resist"] = m_max(0, totemMax - final)
-			output["Totem"..elem.."Resist"] = totemFinal
-			output["Totem"..elem.."ResistTotal"] = totemTotal
-			output["Totem"..elem.."ResistOverCap"] = m_max(0, totemTotal - totemMax)
-			output["MissingTotem"..elem.."Resist"] = m_max(0, totemMax - totemFinal)
-			if breakdown then
-				breakdown[elem.."Resist"] = {
-					"Min: "..min.."%",
-					"Max: "..max.."%",
-					"Total: "..total.."%",
-				}
-				breakdown["Totem"..elem.."Resist"] = {
-					"Min: "..min.."%",
-					"Max: "..totemMax.."%",
-					"Total: "..totemTotal.."%",
-				}
-			end
-		end
-	*/
-	/*
-		TODO -- Block
-		output.BlockChanceMax = modDB:Sum("BASE", nil, "BlockChanceMax")
-		output.BlockChanceOverCap = 0
-		output.SpellBlockChanceOverCap = 0
-		local baseBlockChance = 0
-		if actor.itemList["Weapon 2"] and actor.itemList["Weapon 2"].armourData then
-			baseBlockChance = baseBlockChance + actor.itemList["Weapon 2"].armourData.BlockChance
-		end
-		if actor.itemList["Weapon 3"] and actor.itemList["Weapon 3"].armourData then
-			baseBlockChance = baseBlockChance + actor.itemList["Weapon 3"].armourData.BlockChance
-		end
-		output.ShieldBlockChance = baseBlockChance
-		if modDB:Flag(nil, "MaxBlockIfNotBlockedRecently") then
-			output.BlockChance = output.BlockChanceMax
-		else
-			local totalBlockChance = (baseBlockChance + modDB:Sum("BASE", nil, "BlockChance")) * calcLib.mod(modDB, nil, "BlockChance")
-			output.BlockChance = m_min(totalBlockChance, output.BlockChanceMax)
-			output.BlockChanceOverCap = m_max(0, totalBlockChance - output.BlockChanceMax)
-		end
-		output.ProjectileBlockChance = m_min(output.BlockChance + modDB:Sum("BASE", nil, "ProjectileBlockChance") * calcLib.mod(modDB, nil, "BlockChance"), output.BlockChanceMax)
-		if modDB:Flag(nil, "SpellBlockChanceMaxIsBlockChanceMax") then
-			output.SpellBlockChanceMax = output.BlockChanceMax
-		else
-			output.SpellBlockChanceMax = modDB:Sum("BASE", nil, "SpellBlockChanceMax")
-		end
-		if modDB:Flag(nil, "SpellBlockChanceIsBlockChance") then
-			output.SpellBlockChance = output.BlockChance
-			output.SpellProjectileBlockChance = output.ProjectileBlockChance
-			output.SpellBlockChanceOverCap = output.BlockChanceOverCap
-		else
-			local totalSpellBlockChance = modDB:Sum("BASE", nil, "SpellBlockChance") * calcLib.mod(modDB, nil, "SpellBlockChance")
-			output.SpellBlockChance = m_min(totalSpellBlockChance, output.SpellBlockChanceMax)
-			output.SpellBlockChanceOverCap = m_max(0, totalSpellBlockChance - output.SpellBlockChanceMax)
-			output.SpellProjectileBlockChance = output.SpellBlockChance
-		end
-		if breakdown then
-			breakdown.BlockChance = {
-				"Base: "..baseBlockChance.."%",
-				"Max: "..output.BlockChanceMax.."%",
-				"Total: "..output.BlockChance+output.BlockChanceOverCap.."%",
+	// Highest Maximum Elemental Resistance for Melding of the Flesh
+	if actor.ModDB.Flag(nil, "ElementalResistMaxIsHighestResistMax") {
+		highestResistMax := float64(0)
+		highestResistMaxType := data.DamageType("")
+		for _, elem := range resistTypeList {
+			resistMax := calcResistMax(actor.ModDB, "", elem)
+			if resistMax > highestResistMax && elem.IsElemental() {
+				highestResistMax = resistMax
+				highestResistMaxType = elem
 			}
-			breakdown.SpellBlockChance = {
-				"Max: "..output.SpellBlockChanceMax.."%",
-				"Total: "..output.SpellBlockChance+output.SpellBlockChanceOverCap.."%",
+		}
+		for _, elem := range resistTypeList {
+			if elem.IsElemental() {
+				actor.ModDB.AddMod(mod.NewFloat(string(elem)+"ResistMax", mod.TypeOverride, highestResistMax).Source(mod.Source(string(highestResistMaxType) + " Melding of the Flesh")))
 			}
-		end
-		if modDB:Flag(nil, "CannotBlockAttacks") then
-			output.BlockChance = 0
-			output.ProjectileBlockChance = 0
-		end
-		if modDB:Flag(nil, "CannotBlockSpells") then
-			output.SpellBlockChance = 0
-			output.SpellProjectileBlockChance = 0
-		end
-		output.AverageBlockChance = (output.BlockChance + output.ProjectileBlockChance + output.SpellBlockChance + output.SpellProjectileBlockChance) / 4
-		output.BlockEffect = m_max(100 - modDB:Sum("BASE", nil, "BlockEffect"), 0)
-		if output.BlockEffect == 0 then
-			output.BlockEffect = 100
-		else
-			output.ShowBlockEffect = true
-			output.DamageTakenOnBlock = 100 - output.BlockEffect
-		end
+		}
+	}
 
+	for _, elem := range resistTypeList {
+		minResist := float64(data.ResistFloor)
+		maxResist := calcResistMax(actor.ModDB, "", elem)
+		totemMax := calcResistMax(actor.ModDB, "Totem", elem)
+		total := calcResistTotal(actor.ModDB, "", elem)
+		totemTotal := calcResistTotal(actor.ModDB, "Totem", elem)
+		final := math.Max(math.Min(total, maxResist), minResist)
+		totemFinal := math.Max(math.Min(totemTotal, totemMax), minResist)
+		actor.Output[string(elem)+"Resist"] = final
+		actor.Output[string(elem)+"ResistTotal"] = total
+		actor.Output[string(elem)+"ResistOverCap"] = math.Max(0, total-maxResist)
+		actor.Output[string(elem)+"ResistOver75"] = math.Max(0, final-75)
+		actor.Output["Missing"+string(elem)+"Resist"] = math.Max(0, totemMax-final)
+		actor.Output["Totem"+string(elem)+"Resist"] = totemFinal
+		actor.Output["Totem"+string(elem)+"ResistTotal"] = totemTotal
+		actor.Output["Totem"+string(elem)+"ResistOverCap"] = math.Max(0, totemTotal-totemMax)
+		actor.Output["MissingTotem"+string(elem)+"Resist"] = math.Max(0, totemMax-totemFinal)
+		if actor.Breakdown != nil {
+			actor.Breakdown.Set(string(elem)+"Resist", final,
+				fmt.Sprintf("Min: %g%%", minResist),
+				fmt.Sprintf("Max: %g%%", maxResist),
+				fmt.Sprintf("Total: %g%%", total),
+			)
+			actor.Breakdown.Set("Totem"+string(elem)+"Resist", totemFinal,
+				fmt.Sprintf("Min: %g%%", minResist),
+				fmt.Sprintf("Max: %g%%", totemMax),
+				fmt.Sprintf("Total: %g%%", totemTotal),
+			)
+		}
+	}
+
+	// Block
+	actor.Output["BlockChanceMax"] = actor.ModDB.Sum(mod.TypeBase, nil, "BlockChanceMax")
+	actor.Output["BlockChanceOverCap"] = 0
+	actor.Output["SpellBlockChanceOverCap"] = 0
+	baseBlockChance := float64(0)
+	for _, slot := range []string{"Weapon 2", "Weapon 3"} {
+		if armourData := actor.ArmourData[slot]; armourData != nil {
+			baseBlockChance += armourData.BlockChance
+		}
+	}
+	actor.Output["ShieldBlockChance"] = baseBlockChance
+	if actor.ModDB.Flag(nil, "MaxBlockIfNotBlockedRecently") {
+		actor.Output["BlockChance"] = actor.Output["BlockChanceMax"]
+	} else {
+		totalBlockChance := (baseBlockChance + actor.ModDB.Sum(mod.TypeBase, nil, "BlockChance")) * CalcMod(actor.ModDB, nil, "BlockChance")
+		actor.Output["BlockChance"] = math.Min(totalBlockChance, actor.Output["BlockChanceMax"])
+		actor.Output["BlockChanceOverCap"] = math.Max(0, totalBlockChance-actor.Output["BlockChanceMax"])
+	}
+	actor.Output["ProjectileBlockChance"] = math.Min(actor.Output["BlockChance"]+actor.ModDB.Sum(mod.TypeBase, nil, "ProjectileBlockChance")*CalcMod(actor.ModDB, nil, "BlockChance"), actor.Output["BlockChanceMax"])
+	if actor.ModDB.Flag(nil, "SpellBlockChanceMaxIsBlockChanceMax") {
+		actor.Output["SpellBlockChanceMax"] = actor.Output["BlockChanceMax"]
+	} else {
+		actor.Output["SpellBlockChanceMax"] = actor.ModDB.Sum(mod.TypeBase, nil, "SpellBlockChanceMax")
+	}
+	if actor.ModDB.Flag(nil, "SpellBlockChanceIsBlockChance") {
+		actor.Output["SpellBlockChance"] = actor.Output["BlockChance"]
+		actor.Output["SpellProjectileBlockChance"] = actor.Output["ProjectileBlockChance"]
+		actor.Output["SpellBlockChanceOverCap"] = actor.Output["BlockChanceOverCap"]
+	} else {
+		totalSpellBlockChance := actor.ModDB.Sum(mod.TypeBase, nil, "SpellBlockChance") * CalcMod(actor.ModDB, nil, "SpellBlockChance")
+		actor.Output["SpellBlockChance"] = math.Min(totalSpellBlockChance, actor.Output["SpellBlockChanceMax"])
+		actor.Output["SpellBlockChanceOverCap"] = math.Max(0, totalSpellBlockChance-actor.Output["SpellBlockChanceMax"])
+		actor.Output["SpellProjectileBlockChance"] = actor.Output["SpellBlockChance"]
+	}
+	if actor.Breakdown != nil {
+		actor.Breakdown.Set("BlockChance", actor.Output["BlockChance"],
+			fmt.Sprintf("Base: %g%%", baseBlockChance),
+			fmt.Sprintf("Max: %g%%", actor.Output["BlockChanceMax"]),
+			fmt.Sprintf("Total: %g%%", actor.Output["BlockChance"]+actor.Output["BlockChanceOverCap"]),
+		)
+		actor.Breakdown.Set("SpellBlockChance", actor.Output["SpellBlockChance"],
+			fmt.Sprintf("Max: %g%%", actor.Output["SpellBlockChanceMax"]),
+			fmt.Sprintf("Total: %g%%", actor.Output["SpellBlockChance"]+actor.Output["SpellBlockChanceOverCap"]),
+		)
+	}
+	if actor.ModDB.Flag(nil, "CannotBlockAttacks") {
+		actor.Output["BlockChance"] = 0
+		actor.Output["ProjectileBlockChance"] = 0
+	}
+	if actor.ModDB.Flag(nil, "CannotBlockSpells") {
+		actor.Output["SpellBlockChance"] = 0
+		actor.Output["SpellProjectileBlockChance"] = 0
+	}
+	actor.Output["AverageBlockChance"] = (actor.Output["BlockChance"] + actor.Output["ProjectileBlockChance"] + actor.Output["SpellBlockChance"] + actor.Output["SpellProjectileBlockChance"]) / 4
+	actor.Output["BlockEffect"] = math.Max(100-actor.ModDB.Sum(mod.TypeBase, nil, "BlockEffect"), 0)
+	if actor.Output["BlockEffect"] == 0 {
+		actor.Output["BlockEffect"] = 100
+	} else {
+		actor.Output["ShowBlockEffect"] = 1
+		actor.Output["DamageTakenOnBlock"] = 100 - actor.Output["BlockEffect"]
+	}
+
+	/*
+		TODO -- Armour to ES Recharge conversion from Armour and Energy Shield Mastery
 		if modDB:Flag(nil, "ArmourAppliesToEnergyShieldRecharge") then
-			-- Armour to ES Recharge conversion from Armour and Energy Shield Mastery
 			local multiplier = (modDB:Max(nil, "ImprovedArmourAppliesToEnergyShieldRecharge") or 100) / 100
 			for _, value in ipairs(modDB:Tabulate("INC", nil, "Armour", "ArmourAndEvasion", "Defences")) do
 				local mod = value.mod
@@ -182,596 +175,454 @@ func CalculateDefence(environment *Environment, actor *Actor) {
 			end
 		end
 	*/
-	/*
-		TODO -- Primary defences: Energy shield, evasion and armour
-		do
-			local ironReflexes = modDB:Flag(nil, "IronReflexes")
-			local ward = 0
-			local energyShield = 0
-			local armour = 0
-			local evasion = 0
-			if breakdown then
-				breakdown.Ward = { slots = { } }
-				breakdown.EnergyShield = { slots = { } }
-				breakdown.Armour = { slots = { } }
-				breakdown.Evasion = { slots = { } }
-			end
-			local energyShieldBase, armourBase, evasionBase, wardBase
-			local gearWard = 0
-			local gearEnergyShield = 0
-			local gearArmour = 0
-			local gearEvasion = 0
-			local slotCfg = wipeTable(tempTable1)
-			for _, slot in pairs({"Helmet","Body Armour","Gloves","Boots","Weapon 2","Weapon 3"}) do
-				local armourData = actor.itemList[slot] and actor.itemList[slot].armourData
-				if armourData then
-					slotCfg.slotName = slot
-					wardBase = armourData.Ward or 0
-					if wardBase > 0 then
-						output["WardOn"..slot] = wardBase
-						if modDB:Flag(nil, "EnergyShieldToWard") then
-							local inc = modDB:Sum("INC", slotCfg, "Ward", "Defences", "EnergyShield")
-							local more = modDB:More(slotCfg, "Ward", "Defences")
-							ward = ward + wardBase * (1 + inc / 100) * more
-							gearWard = gearWard + wardBase
-							if breakdown then
-								t_insert(breakdown["Ward"].slots, {
-									base = wardBase,
-									inc = (inc ~= 0) and s_format(" x %.2f", 1 + inc/100),
-									more = (more ~= 1) and s_format(" x %.2f", more),
-									total = s_format("%.2f", wardBase * (1 + inc / 100) * more),
-									source = slot,
-									item = actor.itemList[slot],
-								})
-							end
-						else
-							ward = ward + wardBase * calcLib.mod(modDB, slotCfg, "Ward", "Defences")
-							gearWard = gearWard + wardBase
-							if breakdown then
-								breakdown.slot(slot, nil, slotCfg, wardBase, nil, "Ward", "Defences")
-							end
-						end
-					end
-					energyShieldBase = armourData.EnergyShield or 0
-					if energyShieldBase > 0 then
-						output["EnergyShieldOn"..slot] = energyShieldBase
-						if modDB:Flag(nil, "EnergyShieldToWard") then
-							local more = modDB:More(slotCfg, "EnergyShield", "Defences")
-							energyShield = energyShield + energyShieldBase * more
-							gearEnergyShield = gearEnergyShield + energyShieldBase
-							if breakdown then
-								t_insert(breakdown["EnergyShield"].slots, {
-									base = energyShieldBase,
-									more = (more ~= 1) and s_format(" x %.2f", more),
-									total = s_format("%.2f", energyShieldBase * more),
-									source = slot,
-									item = actor.itemList[slot],
-								})
-							end
-						else
-							energyShield = energyShield + energyShieldBase * calcLib.mod(modDB, slotCfg, "EnergyShield", "Defences")
-							gearEnergyShield = gearEnergyShield + energyShieldBase
-							if breakdown then
-								breakdown.slot(slot, nil, slotCfg, energyShieldBase, nil, "EnergyShield", "Defences")
-							end
-						end
-					end
-					armourBase = armourData.Armour or 0
-					if armourBase > 0 then
-						output["ArmourOn"..slot] = armourBase
-						if slot == "Body Armour" and modDB:Flag(nil, "Unbreakable") then
-							armourBase = armourBase * 2
-						end
-						armour = armour + armourBase * calcLib.mod(modDB, slotCfg, "Armour", "ArmourAndEvasion", "Defences")
-						gearArmour = gearArmour + armourBase
-						if breakdown then
-							breakdown.slot(slot, nil, slotCfg, armourBase, nil, "Armour", "ArmourAndEvasion", "Defences")
-						end
-					end
-					evasionBase = armourData.Evasion or 0
-					if evasionBase > 0 then
-						output["EvasionOn"..slot] = evasionBase
-						if ironReflexes then
-							armour = armour + evasionBase * calcLib.mod(modDB, slotCfg, "Armour", "Evasion", "ArmourAndEvasion", "Defences")
-							gearArmour = gearArmour + evasionBase
-							if breakdown then
-								breakdown.slot(slot, nil, slotCfg, evasionBase, nil, "Armour", "Evasion", "ArmourAndEvasion", "Defences")
-							end
-						else
-							evasion = evasion + evasionBase * calcLib.mod(modDB, slotCfg, "Evasion", "ArmourAndEvasion", "Defences")
-							gearEvasion = gearEvasion + evasionBase
-							if breakdown then
-								breakdown.slot(slot, nil, slotCfg, evasionBase, nil, "Evasion", "ArmourAndEvasion", "Defences")
-							end
-						end
-					end
-				end
-			end
-			wardBase = modDB:Sum("BASE", nil, "Ward")
-
-			if wardBase > 0 then
-				if modDB:Flag(nil, "EnergyShieldToWard") then
-					local inc = modDB:Sum("INC", slotCfg, "Ward", "Defences", "EnergyShield")
-					local more = modDB:More(slotCfg, "Ward", "Defences")
-					ward = ward + wardBase * (1 + inc / 100) * more
-					if breakdown then
-						t_insert(breakdown["Ward"].slots, {
-							base = wardBase,
-							inc = (inc ~= 0) and s_format(" x %.2f", 1 + inc/100),
-							more = (more ~= 1) and s_format(" x %.2f", more),
-							total = s_format("%.2f", wardBase * (1 + inc / 100) * more),
-							source = "Global",
-							item = actor.itemList["Global"],
-						})
-					end
-				else
-					ward = ward + wardBase * calcLib.mod(modDB, nil, "Ward", "Defences")
-					if breakdown then
-						breakdown.slot("Global", nil, nil, wardBase, nil, "Ward", "Defences")
-					end
-				end
-			end
-			energyShieldBase = modDB:Sum("BASE", nil, "EnergyShield")
-			if energyShieldBase > 0 then
-				if modDB:Flag(nil, "EnergyShieldToWard") then
-					energyShield = energyShield + energyShieldBase * modDB:More(slotCfg, "EnergyShield", "Defences")
-				else
-					energyShield = energyShield + energyShieldBase * calcLib.mod(modDB, nil, "EnergyShield", "Defences")
-				end
-				if breakdown then
-					local more = modDB:More(slotCfg, "EnergyShield", "Defences")
-					t_insert(breakdown["EnergyShield"].slots, {
-						base = energyShieldBase,
-						more = (more ~= 1) and s_format(" x %.2f", more),
-						total = s_format("%.2f", energyShieldBase * more),
-						source = "Global",
-						item = actor.itemList["Global"],
-					})
-				end
-			end
-			armourBase = modDB:Sum("BASE", nil, "Armour", "ArmourAndEvasion")
-			if armourBase > 0 then
-				armour = armour + armourBase * calcLib.mod(modDB, nil, "Armour", "ArmourAndEvasion", "Defences")
-				if breakdown then
-					breakdown.slot("Global", nil, nil, armourBase, nil, "Armour", "ArmourAndEvasion", "Defences")
-				end
-			end
-			evasionBase = modDB:Sum("BASE", nil, "Evasion", "ArmourAndEvasion")
-			if evasionBase > 0 then
-				if ironReflexes then
-					armour = armour + evasionBase * calcLib.mod(modDB, nil, "Armour", "Evasion", "ArmourAndEvasion", "Defences")
-					if breakdown then
-						breakdown.slot("Conversion", "Evasion to Armour", nil, evasionBase, nil, "Armour", "Evasion", "ArmourAndEvasion", "Defences")
-					end
-				else
-					evasion = evasion + evasionBase * calcLib.mod(modDB, nil, "Evasion", "ArmourAndEvasion", "Defences")
-					if breakdown then
-						breakdown.slot("Global", nil, nil, evasionBase, nil, "Evasion", "ArmourAndEvasion", "Defences")
-					end
-				end
-			end
-			local convManaToArmour = modDB:Sum("BASE", nil, "ManaConvertToArmour")
-			if convManaToArmour > 0 then
-				armourBase = 2 * modDB:Sum("BASE", nil, "Mana") * convManaToArmour / 100
-				local total = armourBase * calcLib.mod(modDB, nil, "Mana", "Armour", "ArmourAndEvasion", "Defences")
-				armour = armour + total
-				if breakdown then
-					breakdown.slot("Conversion", "Mana to Armour", nil, armourBase, total, "Armour", "ArmourAndEvasion", "Defences", "Mana")
-				end
-			end
-			local convManaToES = modDB:Sum("BASE", nil, "ManaGainAsEnergyShield")
-			if convManaToES > 0 then
-				energyShieldBase = modDB:Sum("BASE", nil, "Mana") * convManaToES / 100
-				energyShield = energyShield + energyShieldBase * calcLib.mod(modDB, nil, "Mana", "EnergyShield", "Defences")
-				if breakdown then
-					breakdown.slot("Conversion", "Mana to Energy Shield", nil, energyShieldBase, nil, "EnergyShield", "Defences", "Mana")
-				end
-			end
-			local convLifeToArmour = modDB:Sum("BASE", nil, "LifeGainAsArmour")
-			if convLifeToArmour > 0 then
-				armourBase = modDB:Sum("BASE", nil, "Life") * convLifeToArmour / 100
-				local total
-				if modDB:Flag(nil, "ChaosInoculation") then
-					total = 1
-				else
-					total = armourBase * calcLib.mod(modDB, nil, "Life", "Armour", "ArmourAndEvasion", "Defences")
-				end
-				armour = armour + total
-				if breakdown then
-					breakdown.slot("Conversion", "Life to Armour", nil, armourBase, total, "Armour", "ArmourAndEvasion", "Defences", "Life")
-				end
-			end
-			local convLifeToES = modDB:Sum("BASE", nil, "LifeConvertToEnergyShield", "LifeGainAsEnergyShield")
-			if convLifeToES > 0 then
-				energyShieldBase = modDB:Sum("BASE", nil, "Life") * convLifeToES / 100
-				local total
-				if modDB:Flag(nil, "ChaosInoculation") then
-					total = 1
-				else
-					total = energyShieldBase * calcLib.mod(modDB, nil, "Life", "EnergyShield", "Defences")
-				end
-				energyShield = energyShield + total
-				if breakdown then
-					breakdown.slot("Conversion", "Life to Energy Shield", nil, energyShieldBase, total, "EnergyShield", "Defences", "Life")
-				end
-			end
-			local convEvasionToArmour = modDB:Sum("BASE", nil, "EvasionGainAsArmour")
-			if convEvasionToArmour > 0 then
-				armourBase = (modDB:Sum("BASE", nil, "Evasion") + gearEvasion) * convEvasionToArmour / 100
-				local total = armourBase * calcLib.mod(modDB, nil, "Evasion", "Armour", "ArmourAndEvasion", "Defences")
-				armour = armour + total
-				if breakdown then
-					breakdown.slot("Conversion", "Evasion to Armour", nil, armourBase, total, "Armour", "ArmourAndEvasion", "Defences", "Evasion")
-				end
-			end
-			output.EnergyShield = modDB:Override(nil, "EnergyShield") or m_max(round(energyShield), 0)
-			output.Armour = m_max(round(armour), 0)
-			output.ArmourDefense = (modDB:Max(nil, "ArmourDefense") or 0) / 100
-			output.RawArmourDefense = output.ArmourDefense > 0 and ((1 + output.ArmourDefense) * 100) or nil
-			output.Evasion = m_max(round(evasion), 0)
-			output.LowestOfArmourAndEvasion = m_min(output.Armour, output.Evasion)
-			output.Ward = m_max(round(ward), 0)
-			output["Gear:Ward"] = gearWard
-			output["Gear:EnergyShield"] = gearEnergyShield
-			output["Gear:Armour"] = gearArmour
-			output["Gear:Evasion"] = gearEvasion
-			output.CappingES = modDB:Flag(nil, "ArmourESRecoveryCap") and output.Armour < output.EnergyShield or modDB:Flag(nil, "EvasionESRecoveryCap") and output.Evasion < output.EnergyShield or env.configInput["conditionLowEnergyShield"]
-
-			if output.CappingES then
-				output.EnergyShieldRecoveryCap = modDB:Flag(nil, "ArmourESRecoveryCap") and modDB:Flag(nil, "EvasionESRecoveryCap") and m_min(output.Armour, output.Evasion) or modDB:Flag(nil, "ArmourESRecoveryCap") and output.Armour or modDB:Flag(nil, "EvasionESRecoveryCap") and output.Evasion or output.EnergyShield or 0
-				output.EnergyShieldRecoveryCap = env.configInput["conditionLowEnergyShield"] and m_min(output.EnergyShield * data.misc.LowPoolThreshold, output.EnergyShieldRecoveryCap) or output.EnergyShieldRecoveryCap
-			else
-				output.EnergyShieldRecoveryCap = output.EnergyShield or 0
-			end
-
-			if modDB:Flag(nil, "CannotEvade") then
-				output.EvadeChance = 0
-				output.MeleeEvadeChance = 0
-				output.ProjectileEvadeChance = 0
-			else
-				local enemyAccuracy = round(calcLib.val(enemyDB, "Accuracy"))
-				output.EvadeChance = 100 - (calcs.hitChance(output.Evasion, enemyAccuracy) - modDB:Sum("BASE", nil, "EvadeChance")) * calcLib.mod(enemyDB, nil, "HitChance")
-				output.MeleeEvadeChance = m_max(0, m_min(data.misc.EvadeChanceCap, output.EvadeChance * calcLib.mod(modDB, nil, "EvadeChance", "MeleeEvadeChance")))
-				output.ProjectileEvadeChance = m_max(0, m_min(data.misc.EvadeChanceCap, output.EvadeChance * calcLib.mod(modDB, nil, "EvadeChance", "ProjectileEvadeChance")))
-				-- Condition for displayng evade chance only if melee or projectile evade chance have the same values
-				if output.MeleeEvadeChance ~= output.ProjectileEvadeChance then
-					output.splitEvade = true
-				else
-					output.EvadeChance = output.MeleeEvadeChance
-					output.dontSplitEvade = true
-				end
-				if breakdown then
-					breakdown.EvadeChance = {
-						s_format("Enemy level: %d ^8(%s the Configuration tab)", env.enemyLevel, env.configInput.enemyLevel and "overridden from" or "can be overridden in"),
-						s_format("Average enemy accuracy: %d", enemyAccuracy),
-						s_format("Approximate evade chance: %d%%", output.EvadeChance),
-					}
-					breakdown.MeleeEvadeChance = {
-						s_format("Enemy level: %d ^8(%s the Configuration tab)", env.enemyLevel, env.configInput.enemyLevel and "overridden from" or "can be overridden in"),
-						s_format("Average enemy accuracy: %d", enemyAccuracy),
-						s_format("Approximate melee evade chance: %d%%", output.MeleeEvadeChance),
-					}
-					breakdown.ProjectileEvadeChance = {
-						s_format("Enemy level: %d ^8(%s the Configuration tab)", env.enemyLevel, env.configInput.enemyLevel and "overridden from" or "can be overridden in"),
-						s_format("Average enemy accuracy: %d", enemyAccuracy),
-						s_format("Approximate projectile evade chance: %d%%", output.ProjectileEvadeChance),
-					}
-				end
-			end
-		end
-	*/
-	/*
-		TODO -- Dodge
-		-- Acrobatics Spell Suppression to Spell Dodge Chance conversion.
-		if modDB:Flag(nil, "ConvertSpellSuppressionToSpellDodge") then
-			local SpellSuppressionChance = modDB:Sum("BASE", nil, "SpellSuppressionChance")
-			modDB:NewMod("SpellDodgeChance", "BASE", SpellSuppressionChance / 2, "Acrobatics")
-		end
-
-		local totalSpellSuppressionChance = modDB:Override(nil, "SpellSuppressionChance") or modDB:Sum("BASE", nil, "SpellSuppressionChance")
 
-		output.SpellSuppressionChance = m_min(totalSpellSuppressionChance, data.misc.SuppressionChanceCap)
-		output.SpellSuppressionEffect = data.misc.SuppressionEffect + modDB:Sum("BASE", nil, "SpellSuppressionEffect")
+	// Primary defences: Energy shield, evasion and armour
+	ironReflexes := actor.ModDB.Flag(nil, "IronReflexes")
+	energyShieldToWard := actor.ModDB.Flag(nil, "EnergyShieldToWard")
+	ward, energyShield, armour, evasion := float64(0), float64(0), float64(0), float64(0)
+	gearWard, gearEnergyShield, gearArmour, gearEvasion := float64(0), float64(0), float64(0), float64(0)
+	defenceLines := make(map[string][]string)
+	addDefence := func(stat string, source string, base float64, total float64) {
+		if actor.Breakdown != nil {
+			defenceLines[stat] = append(defenceLines[stat], fmt.Sprintf("%s: %g (base) -> %.1f", source, base, total))
+		}
+	}
 
-		if env.mode_effective and modDB:Flag(nil, "SpellSuppressionChanceIsUnlucky") then
-			output.SpellSuppressionChance = output.SpellSuppressionChance / 100 * output.SpellSuppressionChance
-		elseif env.mode_effective and modDB:Flag(nil, "SpellSuppressionChanceIsLucky") then
-			output.SpellSuppressionChance = (1 - (1 - output.SpellSuppressionChance / 100) ^ 2) * 100
-		end
+	for _, slot := range []string{"Helmet", "Body Armour", "Gloves", "Boots", "Weapon 2", "Weapon 3"} {
+		armourData := actor.ArmourData[slot]
+		if armourData == nil {
+			continue
+		}
 
-		output.SpellSuppressionChanceOverCap = m_max(0, totalSpellSuppressionChance - data.misc.SuppressionChanceCap)
+		slotCfg := &moddb.ListCfg{SlotName: slot}
+		if wardBase := armourData.Ward; wardBase > 0 {
+			actor.Output["WardOn"+slot] = wardBase
+			total := wardBase * CalcMod(actor.ModDB, slotCfg, "Ward", "Defences")
+			if energyShieldToWard {
+				inc := actor.ModDB.Sum(mod.TypeIncrease, slotCfg, "Ward", "Defences", "EnergyShield")
+				total = wardBase * (1 + inc/100) * actor.ModDB.More(slotCfg, "Ward", "Defences")
+			}
+			ward += total
+			gearWard += wardBase
+			addDefence("Ward", slot, wardBase, total)
+		}
 
-		if actor.itemList["Weapon 3"] and actor.itemList["Weapon 3"].armourData then
-			baseBlockChance = baseBlockChance + actor.itemList["Weapon 3"].armourData.BlockChance
-		end
-		output.ShieldBlockChance = baseBlockChance
-		if modDB:Flag(nil, "MaxBlockIfNotBlockedRecently") then
-			output.BlockChance = output.BlockChanceMax
-		else
-			output.BlockChance = m_min((baseBlockChance + modDB:Sum("BASE", nil, "BlockChance")) * calcLib.mod(modDB, nil, "BlockChance"), output.BlockChanceMax)
-		end
-		output.ProjectileBlockChance = m_min(output.BlockChance + modDB:Sum("BASE", nil, "ProjectileBlockChance") * calcLib.mod(modDB, nil, "BlockChance"), output.BlockChanceMax)
-		if modDB:Flag(nil, "SpellBlockChanceMaxIsBlockChanceMax") then
-			output.SpellBlockChanceMax = output.BlockChanceMax
-		else
-			output.SpellBlockChanceMax = modDB:Sum("BASE", nil, "SpellBlockChanceMax")
-		end
-		if modDB:Flag(nil, "SpellBlockChanceIsBlockChance") then
-			output.SpellBlockChance = output.BlockChance
-			output.SpellProjectileBlockChance = output.ProjectileBlockChance
-		else
-			output.SpellBlockChance = m_min(modDB:Sum("BASE", nil, "SpellBlockChance") * calcLib.mod(modDB, nil, "SpellBlockChance"), output.SpellBlockChanceMax)
-			output.SpellProjectileBlockChance = output.SpellBlockChance
-		end
-		if breakdown then
-			breakdown.BlockChance = breakdown.simple(baseBlockChance, nil, output.BlockChance, "BlockChance")
-			breakdown.SpellBlockChance = breakdown.simple(0, nil, output.SpellBlockChance, "SpellBlockChance")
-		end
-		if modDB:Flag(nil, "CannotBlockAttacks") then
-			output.BlockChance = 0
-			output.ProjectileBlockChance = 0
-		end
-		if modDB:Flag(nil, "CannotBlockSpells") then
-			output.SpellBlockChance = 0
-			output.SpellProjectileBlockChance = 0
-		end
-		output.AverageBlockChance = (output.BlockChance + output.ProjectileBlockChance + output.SpellBlockChance + output.SpellProjectileBlockChance) / 4
-		output.BlockEffect = m_max(100 - modDB:Sum("BASE", nil, "BlockEffect"), 0)
-		if output.BlockEffect == 0 or output.BlockEffect == 100 then
-			output.BlockEffect = 100
-		else
-			output.ShowBlockEffect = true
-			output.DamageTakenOnBlock = 100 - output.BlockEffect
-		end
-		output.LifeOnBlock = modDB:Sum("BASE", nil, "LifeOnBlock")
-		output.ManaOnBlock = modDB:Sum("BASE", nil, "ManaOnBlock")
-		output.EnergyShieldOnBlock = modDB:Sum("BASE", nil, "EnergyShieldOnBlock")
-	*/
-	/*
-		TODO -- Dodge
-		local baseDodgeChance = 0
-		local totalAttackDodgeChance = modDB:Sum("BASE", nil, "AttackDodgeChance")
-		local totalSpellDodgeChance = modDB:Sum("BASE", nil, "SpellDodgeChance")
-		local attackDodgeChanceMax = data.misc.DodgeChanceCap
-		local spellDodgeChanceMax = modDB:Override(nil, "SpellDodgeChanceMax") or modDB:Sum("BASE", nil, "SpellDodgeChanceMax")
-
-		output.AttackDodgeChance = m_min(totalAttackDodgeChance, attackDodgeChanceMax)
-		output.SpellDodgeChance = m_min(totalSpellDodgeChance, spellDodgeChanceMax)
-		if env.mode_effective and modDB:Flag(nil, "DodgeChanceIsUnlucky") then
-			output.AttackDodgeChance = output.AttackDodgeChance / 100 * output.AttackDodgeChance
-			output.SpellDodgeChance = output.SpellDodgeChance / 100 * output.SpellDodgeChance
-		end
-		output.AttackDodgeChanceOverCap = m_max(0, totalAttackDodgeChance - attackDodgeChanceMax)
-		output.SpellDodgeChanceOverCap = m_max(0, totalSpellDodgeChance - spellDodgeChanceMax)
+		if energyShieldBase := armourData.EnergyShield; energyShieldBase > 0 {
+			actor.Output["EnergyShieldOn"+slot] = energyShieldBase
+			total := energyShieldBase * CalcMod(actor.ModDB, slotCfg, "EnergyShield", "Defences")
+			if energyShieldToWard {
+				total = energyShieldBase * actor.ModDB.More(slotCfg, "EnergyShield", "Defences")
+			}
+			energyShield += total
+			gearEnergyShield += energyShieldBase
+			addDefence("EnergyShield", slot, energyShieldBase, total)
+		}
 
-		if breakdown then
-			breakdown.AttackDodgeChance = {
-				"Base: "..baseDodgeChance.."%",
-				"Max: "..attackDodgeChanceMax.."%",
-				"Total: "..output.AttackDodgeChance+output.AttackDodgeChanceOverCap.."%",
+		if armourBase := armourData.Armour; armourBase > 0 {
+			actor.Output["ArmourOn"+slot] = armourBase
+			if slot == "Body Armour" && actor.ModDB.Flag(nil, "Unbreakable") {
+				armourBase *= 2
 			}
-			breakdown.SpellDodgeChance = {
-				"Base: "..baseDodgeChance.."%",
-				"Max: "..spellDodgeChanceMax.."%",
-				"Total: "..output.SpellDodgeChance+output.SpellDodgeChanceOverCap.."%",
+			total := armourBase * CalcMod(actor.ModDB, slotCfg, "Armour", "ArmourAndEvasion", "Defences")
+			armour += total
+			gearArmour += armourBase
+			addDefence("Armour", slot, armourBase, total)
+		}
+
+		if evasionBase := armourData.Evasion; evasionBase > 0 {
+			actor.Output["EvasionOn"+slot] = evasionBase
+			if ironReflexes {
+				total := evasionBase * CalcMod(actor.ModDB, slotCfg, "Armour", "Evasion", "ArmourAndEvasion", "Defences")
+				armour += total
+				gearArmour += evasionBase
+				addDefence("Armour", slot, evasionBase, total)
+			} else {
+				total := evasionBase * CalcMod(actor.ModDB, slotCfg, "Evasion", "ArmourAndEvasion", "Defences")
+				evasion += total
+				gearEvasion += evasionBase
+				addDefence("Evasion", slot, evasionBase, total)
 			}
-		end
-	*/
-	/*
-		TODO -- Recovery modifiers
-		output.LifeRecoveryRateMod = calcLib.mod(modDB, nil, "LifeRecoveryRate")
-		output.ManaRecoveryRateMod = calcLib.mod(modDB, nil, "ManaRecoveryRate")
-		output.EnergyShieldRecoveryRateMod = calcLib.mod(modDB, nil, "EnergyShieldRecoveryRate")
-	*/
-	/*
-		TODO -- Leech caps
-		output.MaxLifeLeechInstance = output.Life * calcLib.val(modDB, "MaxLifeLeechInstance") / 100
-		output.MaxLifeLeechRatePercent = calcLib.val(modDB, "MaxLifeLeechRate")
-		output.MaxLifeLeechRate = output.Life * output.MaxLifeLeechRatePercent / 100
-		if breakdown then
-			breakdown.MaxLifeLeechRate = {
-				s_format("%d ^8(maximum life)", output.Life),
-				s_format("x %d%% ^8(percentage of life to maximum leech rate)", output.MaxLifeLeechRatePercent),
-				s_format("= %.1f", output.MaxLifeLeechRate)
+		}
+	}
+
+	if wardBase := actor.ModDB.Sum(mod.TypeBase, nil, "Ward"); wardBase > 0 {
+		total := wardBase * CalcMod(actor.ModDB, nil, "Ward", "Defences")
+		if energyShieldToWard {
+			inc := actor.ModDB.Sum(mod.TypeIncrease, nil, "Ward", "Defences", "EnergyShield")
+			total = wardBase * (1 + inc/100) * actor.ModDB.More(nil, "Ward", "Defences")
+		}
+		ward += total
+		addDefence("Ward", "Global", wardBase, total)
+	}
+
+	if energyShieldBase := actor.ModDB.Sum(mod.TypeBase, nil, "EnergyShield"); energyShieldBase > 0 {
+		total := energyShieldBase * CalcMod(actor.ModDB, nil, "EnergyShield", "Defences")
+		if energyShieldToWard {
+			total = energyShieldBase * actor.ModDB.More(nil, "EnergyShield", "Defences")
+		}
+		energyShield += total
+		addDefence("EnergyShield", "Global", energyShieldBase, total)
+	}
+
+	if armourBase := actor.ModDB.Sum(mod.TypeBase, nil, "Armour", "ArmourAndEvasion"); armourBase > 0 {
+		total := armourBase * CalcMod(actor.ModDB, nil, "Armour", "ArmourAndEvasion", "Defences")
+		armour += total
+		addDefence("Armour", "Global", armourBase, total)
+	}
+
+	if evasionBase := actor.ModDB.Sum(mod.TypeBase, nil, "Evasion", "ArmourAndEvasion"); evasionBase > 0 {
+		if ironReflexes {
+			total := evasionBase * CalcMod(actor.ModDB, nil, "Armour", "Evasion", "ArmourAndEvasion", "Defences")
+			armour += total
+			addDefence("Armour", "Evasion to Armour", evasionBase, total)
+		} else {
+			total := evasionBase * CalcMod(actor.ModDB, nil, "Evasion", "ArmourAndEvasion", "Defences")
+			evasion += total
+			addDefence("Evasion", "Global", evasionBase, total)
+		}
+	}
+
+	chaosInoculation := actor.ModDB.Flag(nil, "ChaosInoculation")
+	if convManaToArmour := actor.ModDB.Sum(mod.TypeBase, nil, "ManaConvertToArmour"); convManaToArmour > 0 {
+		armourBase := 2 * actor.ModDB.Sum(mod.TypeBase, nil, "Mana") * convManaToArmour / 100
+		total := armourBase * CalcMod(actor.ModDB, nil, "Mana", "Armour", "ArmourAndEvasion", "Defences")
+		armour += total
+		addDefence("Armour", "Mana to Armour", armourBase, total)
+	}
+
+	if convManaToES := actor.ModDB.Sum(mod.TypeBase, nil, "ManaGainAsEnergyShield"); convManaToES > 0 {
+		energyShieldBase := actor.ModDB.Sum(mod.TypeBase, nil, "Mana") * convManaToES / 100
+		total := energyShieldBase * CalcMod(actor.ModDB, nil, "Mana", "EnergyShield", "Defences")
+		energyShield += total
+		addDefence("EnergyShield", "Mana to Energy Shield", energyShieldBase, total)
+	}
+
+	if convLifeToArmour := actor.ModDB.Sum(mod.TypeBase, nil, "LifeGainAsArmour"); convLifeToArmour > 0 {
+		armourBase := actor.ModDB.Sum(mod.TypeBase, nil, "Life") * convLifeToArmour / 100
+		total := float64(1)
+		if !chaosInoculation {
+			total = armourBase * CalcMod(actor.ModDB, nil, "Life", "Armour", "ArmourAndEvasion", "Defences")
+		}
+		armour += total
+		addDefence("Armour", "Life to Armour", armourBase, total)
+	}
+
+	if convLifeToES := actor.ModDB.Sum(mod.TypeBase, nil, "LifeConvertToEnergyShield", "LifeGainAsEnergyShield"); convLifeToES > 0 {
+		energyShieldBase := actor.ModDB.Sum(mod.TypeBase, nil, "Life") * convLifeToES / 100
+		total := float64(1)
+		if !chaosInoculation {
+			total = energyShieldBase * CalcMod(actor.ModDB, nil, "Life", "EnergyShield", "Defences")
+		}
+		energyShield += total
+		addDefence("EnergyShield", "Life to Energy Shield", energyShieldBase, total)
+	}
+
+	if convEvasionToArmour := actor.ModDB.Sum(mod.TypeBase, nil, "EvasionGainAsArmour"); convEvasionToArmour > 0 {
+		armourBase := (actor.ModDB.Sum(mod.TypeBase, nil, "Evasion") + gearEvasion) * convEvasionToArmour / 100
+		total := armourBase * CalcMod(actor.ModDB, nil, "Evasion", "Armour", "ArmourAndEvasion", "Defences")
+		armour += total
+		addDefence("Armour", "Evasion to Armour", armourBase, total)
+	}
+
+	actor.Output["EnergyShield"] = math.Max(math.Round(energyShield), 0)
+	if override := actor.ModDB.Override(nil, "EnergyShield"); override != nil {
+		actor.Output["EnergyShield"] = override.(float64)
+	}
+	actor.Output["Armour"] = math.Max(math.Round(armour), 0)
+	armourDefense, _ := actor.ModDB.Max(nil, "ArmourDefense")
+	actor.Output["ArmourDefense"] = armourDefense / 100
+	if actor.Output["ArmourDefense"] > 0 {
+		actor.Output["RawArmourDefense"] = (1 + actor.Output["ArmourDefense"]) * 100
+	}
+	actor.Output["Evasion"] = math.Max(math.Round(evasion), 0)
+	actor.Output["LowestOfArmourAndEvasion"] = math.Min(actor.Output["Armour"], actor.Output["Evasion"])
+	actor.Output["Ward"] = math.Max(math.Round(ward), 0)
+	actor.Output["Gear:Ward"] = gearWard
+	actor.Output["Gear:EnergyShield"] = gearEnergyShield
+	actor.Output["Gear:Armour"] = gearArmour
+	actor.Output["Gear:Evasion"] = gearEvasion
+	if actor.Breakdown != nil {
+		for _, stat := range []string{"Ward", "EnergyShield", "Armour", "Evasion"} {
+			if lines := defenceLines[stat]; len(lines) > 0 {
+				actor.Breakdown.Set(stat, actor.Output[stat], append(lines, fmt.Sprintf("= %g", actor.Output[stat]))...)
 			}
-		end
-		output.MaxEnergyShieldLeechInstance = output.EnergyShield * calcLib.val(modDB, "MaxEnergyShieldLeechInstance") / 100
-		output.MaxEnergyShieldLeechRate = output.EnergyShield * calcLib.val(modDB, "MaxEnergyShieldLeechRate") / 100
-		if breakdown then
-			breakdown.MaxEnergyShieldLeechRate = {
-				s_format("%d ^8(maximum energy shield)", output.EnergyShield),
-				s_format("x %d%% ^8(percentage of energy shield to maximum leech rate)", calcLib.val(modDB, "MaxEnergyShieldLeechRate")),
-				s_format("= %.1f", output.MaxEnergyShieldLeechRate)
+		}
+	}
+
+	armourESRecoveryCap := actor.ModDB.Flag(nil, "ArmourESRecoveryCap")
+	evasionESRecoveryCap := actor.ModDB.Flag(nil, "EvasionESRecoveryCap")
+	lowEnergyShield := env.configBool("conditionLowEnergyShield")
+	actor.Output["EnergyShieldRecoveryCap"] = actor.Output["EnergyShield"]
+	if armourESRecoveryCap && actor.Output["Armour"] < actor.Output["EnergyShield"] || evasionESRecoveryCap && actor.Output["Evasion"] < actor.Output["EnergyShield"] || lowEnergyShield {
+		switch {
+		case armourESRecoveryCap && evasionESRecoveryCap:
+			actor.Output["EnergyShieldRecoveryCap"] = math.Min(actor.Output["Armour"], actor.Output["Evasion"])
+		case armourESRecoveryCap:
+			actor.Output["EnergyShieldRecoveryCap"] = actor.Output["Armour"]
+		case evasionESRecoveryCap:
+			actor.Output["EnergyShieldRecoveryCap"] = actor.Output["Evasion"]
+		}
+		if lowEnergyShield {
+			actor.Output["EnergyShieldRecoveryCap"] = math.Min(actor.Output["EnergyShield"]*data.LowPoolThreshold, actor.Output["EnergyShieldRecoveryCap"])
+		}
+	}
+
+	if actor.ModDB.Flag(nil, "CannotEvade") {
+		actor.Output["EvadeChance"] = 0
+		actor.Output["MeleeEvadeChance"] = 0
+		actor.Output["ProjectileEvadeChance"] = 0
+	} else {
+		enemyAccuracy := math.Round(CalcVal(enemyDB, "Accuracy", nil))
+		actor.Output["EvadeChance"] = 100 - (CalcHitChance(actor.Output["Evasion"], enemyAccuracy)-actor.ModDB.Sum(mod.TypeBase, nil, "EvadeChance"))*CalcMod(enemyDB, nil, "HitChance")
+		actor.Output["MeleeEvadeChance"] = math.Max(0, math.Min(data.EvadeChanceCap, actor.Output["EvadeChance"]*CalcMod(actor.ModDB, nil, "EvadeChance", "MeleeEvadeChance")))
+		actor.Output["ProjectileEvadeChance"] = math.Max(0, math.Min(data.EvadeChanceCap, actor.Output["EvadeChance"]*CalcMod(actor.ModDB, nil, "EvadeChance", "ProjectileEvadeChance")))
+		// Only display a single evade chance if melee and projectile evade chance are the same
+		if actor.Output["MeleeEvadeChance"] == actor.Output["ProjectileEvadeChance"] {
+			actor.Output["EvadeChance"] = actor.Output["MeleeEvadeChance"]
+		}
+		if actor.Breakdown != nil {
+			for _, stat := range []string{"EvadeChance", "MeleeEvadeChance", "ProjectileEvadeChance"} {
+				actor.Breakdown.Set(stat, actor.Output[stat],
+					fmt.Sprintf("Enemy level: %d", env.EnemyLevel),
+					fmt.Sprintf("Average enemy accuracy: %g", enemyAccuracy),
+					fmt.Sprintf("Approximate evade chance: %.0f%%", actor.Output[stat]),
+				)
 			}
-		end
-		output.MaxManaLeechInstance = output.Mana * calcLib.val(modDB, "MaxManaLeechInstance") / 100
-		output.MaxManaLeechRate = output.Mana * calcLib.val(modDB, "MaxManaLeechRate") / 100
-		if breakdown then
-			breakdown.MaxManaLeechRate = {
-				s_format("%d ^8(maximum mana)", output.Mana),
-				s_format("x %d%% ^8(percentage of mana to maximum leech rate)", modDB:Sum("BASE", nil, "MaxManaLeechRate")),
-				s_format("= %.1f", output.MaxManaLeechRate)
+		}
+	}
+
+	// Spell suppression
+	// Acrobatics Spell Suppression to Spell Dodge Chance conversion
+	if actor.ModDB.Flag(nil, "ConvertSpellSuppressionToSpellDodge") {
+		spellSuppressionChance := actor.ModDB.Sum(mod.TypeBase, nil, "SpellSuppressionChance")
+		actor.ModDB.AddMod(mod.NewFloat("SpellDodgeChance", mod.TypeBase, spellSuppressionChance/2).Source("Acrobatics"))
+	}
+
+	totalSpellSuppressionChance := actor.ModDB.Sum(mod.TypeBase, nil, "SpellSuppressionChance")
+	if override := actor.ModDB.Override(nil, "SpellSuppressionChance"); override != nil {
+		totalSpellSuppressionChance = override.(float64)
+	}
+
+	actor.Output["SpellSuppressionChance"] = math.Min(totalSpellSuppressionChance, data.SuppressionChanceCap)
+	actor.Output["SpellSuppressionEffect"] = data.SuppressionEffect + actor.ModDB.Sum(mod.TypeBase, nil, "SpellSuppressionEffect")
+
+	if env.ModeEffective && actor.ModDB.Flag(nil, "SpellSuppressionChanceIsUnlucky") {
+		actor.Output["SpellSuppressionChance"] = actor.Output["SpellSuppressionChance"] / 100 * actor.Output["SpellSuppressionChance"]
+	} else if env.ModeEffective && actor.ModDB.Flag(nil, "SpellSuppressionChanceIsLucky") {
+		actor.Output["SpellSuppressionChance"] = (1 - math.Pow(1-actor.Output["SpellSuppressionChance"]/100, 2)) * 100
+	}
+
+	actor.Output["SpellSuppressionChanceOverCap"] = math.Max(0, totalSpellSuppressionChance-data.SuppressionChanceCap)
+
+	// Dodge
+	baseDodgeChance := float64(0)
+	totalAttackDodgeChance := actor.ModDB.Sum(mod.TypeBase, nil, "AttackDodgeChance")
+	totalSpellDodgeChance := actor.ModDB.Sum(mod.TypeBase, nil, "SpellDodgeChance")
+	attackDodgeChanceMax := float64(data.DodgeChanceCap)
+	spellDodgeChanceMax := actor.ModDB.Sum(mod.TypeBase, nil, "SpellDodgeChanceMax")
+	if override := actor.ModDB.Override(nil, "SpellDodgeChanceMax"); override != nil {
+		spellDodgeChanceMax = override.(float64)
+	}
+
+	actor.Output["AttackDodgeChance"] = math.Min(totalAttackDodgeChance, attackDodgeChanceMax)
+	actor.Output["SpellDodgeChance"] = math.Min(totalSpellDodgeChance, spellDodgeChanceMax)
+	if env.ModeEffective && actor.ModDB.Flag(nil, "DodgeChanceIsUnlucky") {
+		actor.Output["AttackDodgeChance"] = actor.Output["AttackDodgeChance"] / 100 * actor.Output["AttackDodgeChance"]
+		actor.Output["SpellDodgeChance"] = actor.Output["SpellDodgeChance"] / 100 * actor.Output["SpellDodgeChance"]
+	}
+	actor.Output["AttackDodgeChanceOverCap"] = math.Max(0, totalAttackDodgeChance-attackDodgeChanceMax)
+	actor.Output["SpellDodgeChanceOverCap"] = math.Max(0, totalSpellDodgeChance-spellDodgeChanceMax)
+
+	if actor.Breakdown != nil {
+		actor.Breakdown.Set("AttackDodgeChance", actor.Output["AttackDodgeChance"],
+			fmt.Sprintf("Base: %g%%", baseDodgeChance),
+			fmt.Sprintf("Max: %g%%", attackDodgeChanceMax),
+			fmt.Sprintf("Total: %g%%", actor.Output["AttackDodgeChance"]+actor.Output["AttackDodgeChanceOverCap"]),
+		)
+		actor.Breakdown.Set("SpellDodgeChance", actor.Output["SpellDodgeChance"],
+			fmt.Sprintf("Base: %g%%", baseDodgeChance),
+			fmt.Sprintf("Max: %g%%", spellDodgeChanceMax),
+			fmt.Sprintf("Total: %g%%", actor.Output["SpellDodgeChance"]+actor.Output["SpellDodgeChanceOverCap"]),
+		)
+	}
+
+	// Recovery modifiers
+	actor.Output["LifeRecoveryRateMod"] = CalcMod(actor.ModDB, nil, "LifeRecoveryRate")
+	actor.Output["ManaRecoveryRateMod"] = CalcMod(actor.ModDB, nil, "ManaRecoveryRate")
+	actor.Output["EnergyShieldRecoveryRateMod"] = CalcMod(actor.ModDB, nil, "EnergyShieldRecoveryRate")
+
+	// Leech caps
+	actor.Output["MaxLifeLeechInstance"] = actor.Output["Life"] * CalcVal(actor.ModDB, "MaxLifeLeechInstance", nil) / 100
+	actor.Output["MaxLifeLeechRatePercent"] = CalcVal(actor.ModDB, "MaxLifeLeechRate", nil)
+	actor.Output["MaxLifeLeechRate"] = actor.Output["Life"] * actor.Output["MaxLifeLeechRatePercent"] / 100
+	actor.Output["MaxEnergyShieldLeechInstance"] = actor.Output["EnergyShield"] * CalcVal(actor.ModDB, "MaxEnergyShieldLeechInstance", nil) / 100
+	maxEnergyShieldLeechRatePercent := CalcVal(actor.ModDB, "MaxEnergyShieldLeechRate", nil)
+	actor.Output["MaxEnergyShieldLeechRate"] = actor.Output["EnergyShield"] * maxEnergyShieldLeechRatePercent / 100
+	actor.Output["MaxManaLeechInstance"] = actor.Output["Mana"] * CalcVal(actor.ModDB, "MaxManaLeechInstance", nil) / 100
+	maxManaLeechRatePercent := CalcVal(actor.ModDB, "MaxManaLeechRate", nil)
+	actor.Output["MaxManaLeechRate"] = actor.Output["Mana"] * maxManaLeechRatePercent / 100
+	if actor.Breakdown != nil {
+		actor.Breakdown.Set("MaxLifeLeechRate", actor.Output["MaxLifeLeechRate"],
+			fmt.Sprintf("%.0f (maximum life)", actor.Output["Life"]),
+			fmt.Sprintf("x %g%% (percentage of life to maximum leech rate)", actor.Output["MaxLifeLeechRatePercent"]),
+			fmt.Sprintf("= %.1f", actor.Output["MaxLifeLeechRate"]),
+		)
+		actor.Breakdown.Set("MaxEnergyShieldLeechRate", actor.Output["MaxEnergyShieldLeechRate"],
+			fmt.Sprintf("%.0f (maximum energy shield)", actor.Output["EnergyShield"]),
+			fmt.Sprintf("x %g%% (percentage of energy shield to maximum leech rate)", maxEnergyShieldLeechRatePercent),
+			fmt.Sprintf("= %.1f", actor.Output["MaxEnergyShieldLeechRate"]),
+		)
+		actor.Breakdown.Set("MaxManaLeechRate", actor.Output["MaxManaLeechRate"],
+			fmt.Sprintf("%.0f (maximum mana)", actor.Output["Mana"]),
+			fmt.Sprintf("x %g%% (percentage of mana to maximum leech rate)", maxManaLeechRatePercent),
+			fmt.Sprintf("= %.1f", actor.Output["MaxManaLeechRate"]),
+		)
+	}
+
+	// Mana, life, energy shield, and rage regen
+	if actor.ModDB.Flag(nil, "NoManaRegen") {
+		actor.Output["ManaRegen"] = 0
+	} else {
+		base := actor.ModDB.Sum(mod.TypeBase, nil, "ManaRegen") + actor.Output["Mana"]*actor.ModDB.Sum(mod.TypeBase, nil, "ManaRegenPercent")/100
+		actor.Output["ManaRegenInc"] = actor.ModDB.Sum(mod.TypeIncrease, nil, "ManaRegen")
+		more := actor.ModDB.More(nil, "ManaRegen")
+		if actor.ModDB.Flag(nil, "ManaRegenToRageRegen") {
+			actor.Output["ManaRegenInc"] = 0
+		}
+		regen := base * (1 + actor.Output["ManaRegenInc"]/100) * more
+		regenRate := utils.RoundTo(regen*actor.Output["ManaRecoveryRateMod"], 1)
+		degen := actor.ModDB.Sum(mod.TypeBase, nil, "ManaDegen")
+		actor.Output["ManaRegen"] = regenRate - degen
+		if actor.Breakdown != nil {
+			s := actor.Breakdown.Set("ManaRegen", actor.Output["ManaRegen"],
+				"Mana Regeneration:",
+				fmt.Sprintf("%.1f (base)", base),
+				fmt.Sprintf("x %.2f (increased/reduced)", 1+actor.Output["ManaRegenInc"]/100),
+				fmt.Sprintf("x %.2f (more/less)", more),
+				fmt.Sprintf("= %.1f per second", regen),
+				"Effective Mana Regeneration:",
+				fmt.Sprintf("%.1f", regen),
+				fmt.Sprintf("x %.2f (recovery rate modifier)", actor.Output["ManaRecoveryRateMod"]),
+				fmt.Sprintf("= %.1f per second", regenRate),
+			)
+			if degen != 0 {
+				s.line("- %g", degen)
+				s.line("= %.1f per second", actor.Output["ManaRegen"])
 			}
-		end
-	*/
-	/*
-		TODO -- Mana, life, energy shield, and rage regen
-		if modDB:Flag(nil, "NoManaRegen") then
-			output.ManaRegen = 0
-		else
-			local base = modDB:Sum("BASE", nil, "ManaRegen") + output.Mana * modDB:Sum("BASE", nil, "ManaRegenPercent") / 100
-			output.ManaRegenInc = modDB:Sum("INC", nil, "ManaRegen")
-			local more = modDB:More(nil, "ManaRegen")
-			if modDB:Flag(nil, "ManaRegenToRageRegen") then
-				output.ManaRegenInc = 0
-			end
-			local regen = base * (1 + output.ManaRegenInc/100) * more
-			local regenRate = round(regen * output.ManaRecoveryRateMod, 1)
-			local degen = modDB:Sum("BASE", nil, "ManaDegen")
-			output.ManaRegen = regenRate - degen
-			if breakdown then
-				breakdown.ManaRegen = { }
-				breakdown.multiChain(breakdown.ManaRegen, {
-					label = "Mana Regeneration:",
-					base = s_format("%.1f ^8(base)", base),
-					{ "%.2f ^8(increased/reduced)", 1 + output.ManaRegenInc/100 },
-					{ "%.2f ^8(more/less)", more },
-					total = s_format("= %.1f ^8per second", regen),
-				})
-				breakdown.multiChain(breakdown.ManaRegen, {
-					label = "Effective Mana Regeneration:",
-					base = s_format("%.1f", regen),
-					{ "%.2f ^8(recovery rate modifier)", output.ManaRecoveryRateMod },
-					total = s_format("= %.1f ^8per second", regenRate),
-				})
-				if degen ~= 0 then
-					t_insert(breakdown.ManaRegen, s_format("- %d", degen))
-					t_insert(breakdown.ManaRegen, s_format("= %.1f ^8per second", output.ManaRegen))
-				end
-			end
-		end
-		if modDB:Flag(nil, "NoLifeRegen") then
-			output.LifeRegen = 0
-		elseif modDB:Flag(nil, "ZealotsOath") then
-			output.LifeRegen = 0
-			local lifeBase = modDB:Sum("BASE", nil, "LifeRegen")
-			if lifeBase > 0 then
-				modDB:NewMod("EnergyShieldRegen", "BASE", lifeBase, "Zealot's Oath")
-			end
-			local lifePercent = modDB:Sum("BASE", nil, "LifeRegenPercent")
-			if lifePercent > 0 then
-				modDB:NewMod("EnergyShieldRegenPercent", "BASE", lifePercent, "Zealot's Oath")
-			end
-		else
-			local lifeBase = modDB:Sum("BASE", nil, "LifeRegen")
-			local lifePercent = modDB:Sum("BASE", nil, "LifeRegenPercent")
-			if lifePercent > 0 then
-				lifeBase = lifeBase + output.Life * lifePercent / 100
-			end
-			if lifeBase > 0 then
-				output.LifeRegen = lifeBase * output.LifeRecoveryRateMod * modDB:More(nil, "LifeRegen") * (1 + modDB:Sum("INC", nil, "LifeRegen") / 100)
-			else
-				output.LifeRegen = 0
-			end
-			-- Don't add life recovery mod for this
-			if output.LifeRegen and modDB:Flag(nil, "LifeRegenerationRecoversEnergyShield") and output.EnergyShield > 0 then
-				modDB:NewMod("EnergyShieldRecovery", "BASE", lifeBase * modDB:More(nil, "LifeRegen") * (1 + modDB:Sum("INC", nil, "LifeRegen") / 100), "Life Regeneration Recovers Energy Shield")
-			end
-		end
-		output.LifeRegen = output.LifeRegen - modDB:Sum("BASE", nil, "LifeDegen") + modDB:Sum("BASE", nil, "LifeRecovery") * output.LifeRecoveryRateMod
-		output.LifeRegenPercent = round(output.LifeRegen / output.Life * 100, 1)
-		if modDB:Flag(nil, "NoEnergyShieldRegen") then
-			output.EnergyShieldRegen = 0 - modDB:Sum("BASE", nil, "EnergyShieldDegen")
-			output.EnergyShieldRegenPercent = round(output.EnergyShieldRegen / output.EnergyShield * 100, 1)
-		else
-			local esBase = modDB:Sum("BASE", nil, "EnergyShieldRegen")
-			local esPercent = modDB:Sum("BASE", nil, "EnergyShieldRegenPercent")
-			if esPercent > 0 then
-				esBase = esBase + output.EnergyShield * esPercent / 100
-			end
-			if esBase > 0 then
-				output.EnergyShieldRegen = esBase * output.EnergyShieldRecoveryRateMod * calcLib.mod(modDB, nil, "EnergyShieldRegen") - modDB:Sum("BASE", nil, "EnergyShieldDegen")
-				output.EnergyShieldRegenPercent = round(output.EnergyShieldRegen / output.EnergyShield * 100, 1)
-			else
-				output.EnergyShieldRegen = 0 - modDB:Sum("BASE", nil, "EnergyShieldDegen")
-			end
-		end
-		output.EnergyShieldRegen = output.EnergyShieldRegen + modDB:Sum("BASE", nil, "EnergyShieldRecovery") * output.EnergyShieldRecoveryRateMod
-		output.EnergyShieldRegenPercent = round(output.EnergyShieldRegen / output.EnergyShield * 100, 1)
-		if modDB:Sum("BASE", nil, "RageRegen") > 0 then
-			modDB:NewMod("Condition:CanGainRage", "FLAG", true, "RageRegen")
-			local base = modDB:Sum("BASE", nil, "RageRegen")
-			if modDB:Flag(nil, "ManaRegenToRageRegen") then
-				local mana = modDB:Sum("INC", nil, "ManaRegen")
-				modDB:NewMod("RageRegen", "INC", mana, "Mana Regen to Rage Regen")
-			end
-			local inc = modDB:Sum("INC", nil, "RageRegen")
-			local more = modDB:More(nil, "RageRegen")
-			output.RageRegen = base * (1 + inc /100) * more
-			if breakdown then
-				breakdown.RageRegen = { }
-				breakdown.multiChain(breakdown.RageRegen, {
-					base = s_format("%.1f ^8(base)", base),
-					{ "%.2f ^8(increased/reduced)", 1 + inc/100 },
-					{ "%.2f ^8(more/less)", more },
-					total = s_format("= %.1f ^8per second", output.RageRegen),
-				})
-			end
-		end
-	*/
-	/*
-		TODO -- Energy Shield Recharge
-		if modDB:Flag(nil, "NoEnergyShieldRecharge") then
-			output.EnergyShieldRecharge = 0
-		else
-			local inc = modDB:Sum("INC", nil, "EnergyShieldRecharge")
-			local more = modDB:More(nil, "EnergyShieldRecharge")
-			if modDB:Flag(nil, "EnergyShieldRechargeAppliesToLife") then
-				output.EnergyShieldRechargeAppliesToLife = true
-				local recharge = output.Life * data.misc.EnergyShieldRechargeBase * (1 + inc/100) * more
-				output.LifeRecharge = round(recharge * output.LifeRecoveryRateMod)
-				if breakdown then
-					breakdown.LifeRecharge = { }
-					breakdown.multiChain(breakdown.LifeRecharge, {
-						label = "Recharge rate:",
-						base = s_format("%.1f ^8(33%% per second)", output.Life * data.misc.EnergyShieldRechargeBase),
-						{ "%.2f ^8(increased/reduced)", 1 + inc/100 },
-						{ "%.2f ^8(more/less)", more },
-						total = s_format("= %.1f ^8per second", recharge),
-					})
-					breakdown.multiChain(breakdown.LifeRecharge, {
-						label = "Effective Recharge rate:",
-						base = s_format("%.1f", recharge),
-						{ "%.2f ^8(recovery rate modifier)", output.LifeRecoveryRateMod },
-						total = s_format("= %.1f ^8per second", output.LifeRecharge),
-					})
-				end
-			else
-				output.EnergyShieldRechargeAppliesToEnergyShield = true
-				local recharge = output.EnergyShield * data.misc.EnergyShieldRechargeBase * (1 + inc/100) * more
-				output.EnergyShieldRecharge = round(recharge * output.EnergyShieldRecoveryRateMod)
-				if breakdown then
-					breakdown.EnergyShieldRecharge = { }
-					breakdown.multiChain(breakdown.EnergyShieldRecharge, {
-						label = "Recharge rate:",
-						base = s_format("%.1f ^8(33%% per second)", output.EnergyShield * data.misc.EnergyShieldRechargeBase),
-						{ "%.2f ^8(increased/reduced)", 1 + inc/100 },
-						{ "%.2f ^8(more/less)", more },
-						total = s_format("= %.1f ^8per second", recharge),
-					})
-					breakdown.multiChain(breakdown.EnergyShieldRecharge, {
-						label = "Effective Recharge rate:",
-						base = s_format("%.1f", recharge),
-						{ "%.2f ^8(recovery rate modifier)", output.EnergyShieldRecoveryRateMod },
-						total = s_format("= %.1f ^8per second", output.EnergyShieldRecharge),
-					})
-				end
-			end
-			output.EnergyShieldRechargeDelay = data.misc.EnergyShieldRechargeDelay / (1 + modDB:Sum("INC", nil, "EnergyShieldRechargeFaster") / 100)
-			if breakdown then
-				if output.EnergyShieldRechargeDelay ~= data.misc.EnergyShieldRechargeDelay then
-					breakdown.EnergyShieldRechargeDelay = {
-						s_format("%.2fs ^8(base)", data.misc.EnergyShieldRechargeDelay),
-						s_format("/ %.2f ^8(faster start)", 1 + modDB:Sum("INC", nil, "EnergyShieldRechargeFaster") / 100),
-						s_format("= %.2fs", output.EnergyShieldRechargeDelay)
-					}
-				end
-			end
-		end
-	*/
-	/*
-		TODO -- Ward recharge
-		output.WardRechargeDelay = data.misc.WardRechargeDelay / (1 + modDB:Sum("INC", nil, "WardRechargeFaster") / 100)
-			if breakdown then
-				if output.WardRechargeDelay ~= data.misc.WardRechargeDelay then
-					breakdown.WardRechargeDelay = {
-						s_format("%.2fs ^8(base)", data.misc.WardRechargeDelay),
-						s_format("/ %.2f ^8(faster start)", 1 + modDB:Sum("INC", nil, "WardRechargeFaster") / 100),
-						s_format("= %.2fs", output.WardRechargeDelay)
-					}
-				end
-			end
-	*/
+		}
+	}
+
+	if actor.ModDB.Flag(nil, "NoLifeRegen") {
+		actor.Output["LifeRegen"] = 0
+	} else if actor.ModDB.Flag(nil, "ZealotsOath") {
+		actor.Output["LifeRegen"] = 0
+		if lifeBase := actor.ModDB.Sum(mod.TypeBase, nil, "LifeRegen"); lifeBase > 0 {
+			actor.ModDB.AddMod(mod.NewFloat("EnergyShieldRegen", mod.TypeBase, lifeBase).Source("Zealot's Oath"))
+		}
+		if lifePercent := actor.ModDB.Sum(mod.TypeBase, nil, "LifeRegenPercent"); lifePercent > 0 {
+			actor.ModDB.AddMod(mod.NewFloat("EnergyShieldRegenPercent", mod.TypeBase, lifePercent).Source("Zealot's Oath"))
+		}
+	} else {
+		lifeBase := actor.ModDB.Sum(mod.TypeBase, nil, "LifeRegen")
+		if lifePercent := actor.ModDB.Sum(mod.TypeBase, nil, "LifeRegenPercent"); lifePercent > 0 {
+			lifeBase += actor.Output["Life"] * lifePercent / 100
+		}
+		lifeRegenMod := actor.ModDB.More(nil, "LifeRegen") * (1 + actor.ModDB.Sum(mod.TypeIncrease, nil, "LifeRegen")/100)
+		actor.Output["LifeRegen"] = 0
+		if lifeBase > 0 {
+			actor.Output["LifeRegen"] = lifeBase * actor.Output["LifeRecoveryRateMod"] * lifeRegenMod
+		}
+		// Don't add life recovery mod for this
+		if actor.ModDB.Flag(nil, "LifeRegenerationRecoversEnergyShield") && actor.Output["EnergyShield"] > 0 {
+			actor.ModDB.AddMod(mod.NewFloat("EnergyShieldRecovery", mod.TypeBase, lifeBase*lifeRegenMod).Source("Life Regeneration Recovers Energy Shield"))
+		}
+	}
+	actor.Output["LifeRegen"] = actor.Output["LifeRegen"] - actor.ModDB.Sum(mod.TypeBase, nil, "LifeDegen") + actor.ModDB.Sum(mod.TypeBase, nil, "LifeRecovery")*actor.Output["LifeRecoveryRateMod"]
+	actor.Output["LifeRegenPercent"] = utils.RoundTo(actor.Output["LifeRegen"]/actor.Output["Life"]*100, 1)
+
+	actor.Output["EnergyShieldRegen"] = -actor.ModDB.Sum(mod.TypeBase, nil, "EnergyShieldDegen")
+	if !actor.ModDB.Flag(nil, "NoEnergyShieldRegen") {
+		esBase := actor.ModDB.Sum(mod.TypeBase, nil, "EnergyShieldRegen")
+		if esPercent := actor.ModDB.Sum(mod.TypeBase, nil, "EnergyShieldRegenPercent"); esPercent > 0 {
+			esBase += actor.Output["EnergyShield"] * esPercent / 100
+		}
+		if esBase > 0 {
+			actor.Output["EnergyShieldRegen"] += esBase * actor.Output["EnergyShieldRecoveryRateMod"] * CalcMod(actor.ModDB, nil, "EnergyShieldRegen")
+		}
+	}
+	actor.Output["EnergyShieldRegen"] += actor.ModDB.Sum(mod.TypeBase, nil, "EnergyShieldRecovery") * actor.Output["EnergyShieldRecoveryRateMod"]
+	actor.Output["EnergyShieldRegenPercent"] = 0
+	if actor.Output["EnergyShield"] > 0 {
+		actor.Output["EnergyShieldRegenPercent"] = utils.RoundTo(actor.Output["EnergyShieldRegen"]/actor.Output["EnergyShield"]*100, 1)
+	}
+
+	if base := actor.ModDB.Sum(mod.TypeBase, nil, "RageRegen"); base > 0 {
+		actor.ModDB.AddMod(mod.NewFlag("Condition:CanGainRage", true).Source("RageRegen"))
+		if actor.ModDB.Flag(nil, "ManaRegenToRageRegen") {
+			manaInc := actor.ModDB.Sum(mod.TypeIncrease, nil, "ManaRegen")
+			actor.ModDB.AddMod(mod.NewFloat("RageRegen", mod.TypeIncrease, manaInc).Source("Mana Regen to Rage Regen"))
+		}
+		inc := actor.ModDB.Sum(mod.TypeIncrease, nil, "RageRegen")
+		more := actor.ModDB.More(nil, "RageRegen")
+		actor.Output["RageRegen"] = base * (1 + inc/100) * more
+		if actor.Breakdown != nil {
+			actor.Breakdown.Set("RageRegen", actor.Output["RageRegen"],
+				fmt.Sprintf("%.1f (base)", base),
+				fmt.Sprintf("x %.2f (increased/reduced)", 1+inc/100),
+				fmt.Sprintf("x %.2f (more/less)", more),
+				fmt.Sprintf("= %.1f per second", actor.Output["RageRegen"]),
+			)
+		}
+	}
+
+	// Energy Shield Recharge
+	if actor.ModDB.Flag(nil, "NoEnergyShieldRecharge") {
+		actor.Output["EnergyShieldRecharge"] = 0
+	} else {
+		inc := actor.ModDB.Sum(mod.TypeIncrease, nil, "EnergyShieldRecharge")
+		more := actor.ModDB.More(nil, "EnergyShieldRecharge")
+		pool, stat, rateMod := actor.Output["EnergyShield"], "EnergyShieldRecharge", actor.Output["EnergyShieldRecoveryRateMod"]
+		if actor.ModDB.Flag(nil, "EnergyShieldRechargeAppliesToLife") {
+			pool, stat, rateMod = actor.Output["Life"], "LifeRecharge", actor.Output["LifeRecoveryRateMod"]
+		}
+		recharge := pool * data.EnergyShieldRechargeBase * (1 + inc/100) * more
+		actor.Output[stat] = math.Round(recharge * rateMod)
+		if actor.Breakdown != nil {
+			actor.Breakdown.Set(stat, actor.Output[stat],
+				"Recharge rate:",
+				fmt.Sprintf("%.1f (33%% per second)", pool*data.EnergyShieldRechargeBase),
+				fmt.Sprintf("x %.2f (increased/reduced)", 1+inc/100),
+				fmt.Sprintf("x %.2f (more/less)", more),
+				fmt.Sprintf("= %.1f per second", recharge),
+				"Effective Recharge rate:",
+				fmt.Sprintf("%.1f", recharge),
+				fmt.Sprintf("x %.2f (recovery rate modifier)", rateMod),
+				fmt.Sprintf("= %.1f per second", actor.Output[stat]),
+			)
+		}
+
+		faster := 1 + actor.ModDB.Sum(mod.TypeIncrease, nil, "EnergyShieldRechargeFaster")/100
+		actor.Output["EnergyShieldRechargeDelay"] = data.EnergyShieldRechargeDelay / faster
+		if actor.Breakdown != nil && actor.Output["EnergyShieldRechargeDelay"] != data.EnergyShieldRechargeDelay {
+			actor.Breakdown.Set("EnergyShieldRechargeDelay", actor.Output["EnergyShieldRechargeDelay"],
+				fmt.Sprintf("%.2fs (base)", float64(data.EnergyShieldRechargeDelay)),
+				fmt.Sprintf("/ %.2f (faster start)", faster),
+				fmt.Sprintf("= %.2fs", actor.Output["EnergyShieldRechargeDelay"]),
+			)
+		}
+	}
+
+	// Ward recharge
+	wardFaster := 1 + actor.ModDB.Sum(mod.TypeIncrease, nil, "WardRechargeFaster")/100
+	actor.Output["WardRechargeDelay"] = data.WardRechargeDelay / wardFaster
+	if actor.Breakdown != nil && actor.Output["WardRechargeDelay"] != data.WardRechargeDelay {
+		actor.Breakdown.Set("WardRechargeDelay", actor.Output["WardRechargeDelay"],
+			fmt.Sprintf("%.2fs (base)", float64(data.WardRechargeDelay)),
+			fmt.Sprintf("/ %.2f (faster start)", wardFaster),
+			fmt.Sprintf("= %.2fs", actor.Output["WardRechargeDelay"]),
+		)
+	}
+
 	/*
 		TODO -- Miscellaneous: move speed, stun recovery, avoidance
 		output.MovementSpeedMod = modDB:Override(nil, "MovementSpeed") or calcLib.mod(modDB, nil, "MovementSpeed")
@@ -792,58 +643,55 @@ func CalculateDefence(environment *Environment, actor *Actor) {
 			output.BlindEffectMod = calcLib.mod(enemyDB, nil, "BlindEffect", "BuffEffectOnSelf") * 100
 		end
 	*/
-	/*
-		TODO -- recovery on block, needs to be after primary defences
-		output.LifeOnBlock = modDB:Sum("BASE", nil, "LifeOnBlock")
-		output.ManaOnBlock = modDB:Sum("BASE", nil, "ManaOnBlock")
-		output.EnergyShieldOnBlock = modDB:Sum("BASE", nil, "EnergyShieldOnBlock")
-		output.EnergyShieldOnSpellBlock = modDB:Sum("BASE", nil, "EnergyShieldOnSpellBlock")
-	*/
-	/*
-		TODO -- damage avoidances
-		for _, damageType in ipairs(dmgTypeList) do
-			output["Avoid"..damageType.."DamageChance"] = m_min(modDB:Sum("BASE", nil, "Avoid"..damageType.."DamageChance"), data.misc.AvoidChanceCap)
-		end
-		output.AvoidProjectilesChance = m_min(modDB:Sum("BASE", nil, "AvoidProjectilesChance"), data.misc.AvoidChanceCap)
-	*/
-	/*
-		TODO -- other avoidances etc
-		local stunChance = 100 - m_min(modDB:Sum("BASE", nil, "AvoidStun"), 100)
-		if output.EnergyShield > output.Life * 2 then
-			stunChance = stunChance * 0.5
-		end
-		output.StunAvoidChance = 100 - stunChance
-		if output.StunAvoidChance >= 100 then
-			output.StunDuration = 0
-			output.BlockDuration = 0
-		else
-			output.StunDuration = 0.35 / (1 + modDB:Sum("INC", nil, "StunRecovery") / 100)
-			output.BlockDuration = 0.35 / (1 + modDB:Sum("INC", nil, "StunRecovery", "BlockRecovery") / 100)
-			if breakdown then
-				breakdown.StunDuration = {
-					"0.35s ^8(base)",
-					s_format("/ %.2f ^8(increased/reduced recovery)", 1 + modDB:Sum("INC", nil, "StunRecovery") / 100),
-					s_format("= %.2fs", output.StunDuration)
-				}
-				breakdown.BlockDuration = {
-					"0.35s ^8(base)",
-					s_format("/ %.2f ^8(increased/reduced recovery)", 1 + modDB:Sum("INC", nil, "StunRecovery", "BlockRecovery") / 100),
-					s_format("= %.2fs", output.BlockDuration)
-				}
-			end
-		end
-		output.InteruptStunAvoidChance = m_min(modDB:Sum("BASE", nil, "AvoidInteruptStun"), 100)
-		output.BlindAvoidChance = m_min(modDB:Sum("BASE", nil, "AvoidBlind"), 100)
-		for _, ailment in ipairs(data.ailmentTypeList) do
-			output[ailment.."AvoidChance"] = m_min(modDB:Sum("BASE", nil, "Avoid"..ailment), 100)
-		end
-		output.CritExtraDamageReduction = m_min(modDB:Sum("BASE", nil, "ReduceCritExtraDamage"), 100)
-		output.LightRadiusMod = calcLib.mod(modDB, nil, "LightRadius")
-		if breakdown then
-			breakdown.LightRadiusMod = breakdown.mod(modDB, nil, "LightRadius")
-		end
-		output.CurseEffectOnSelf = modDB:More(nil, "CurseEffectOnSelf") * (100 + modDB:Sum("INC", nil, "CurseEffectOnSelf"))
-	*/
+
+	// Recovery on block, needs to be after primary defences
+	actor.Output["LifeOnBlock"] = actor.ModDB.Sum(mod.TypeBase, nil, "LifeOnBlock")
+	actor.Output["ManaOnBlock"] = actor.ModDB.Sum(mod.TypeBase, nil, "ManaOnBlock")
+	actor.Output["EnergyShieldOnBlock"] = actor.ModDB.Sum(mod.TypeBase, nil, "EnergyShieldOnBlock")
+	actor.Output["EnergyShieldOnSpellBlock"] = actor.ModDB.Sum(mod.TypeBase, nil, "EnergyShieldOnSpellBlock")
+
+	// Damage avoidances
+	for _, damageType := range dmgTypeList {
+		actor.Output["Avoid"+string(damageType)+"DamageChance"] = math.Min(actor.ModDB.Sum(mod.TypeBase, nil, "Avoid"+string(damageType)+"DamageChance"), data.AvoidChanceCap)
+	}
+	actor.Output["AvoidProjectilesChance"] = math.Min(actor.ModDB.Sum(mod.TypeBase, nil, "AvoidProjectilesChance"), data.AvoidChanceCap)
+
+	// Other avoidances etc
+	stunChance := 100 - math.Min(actor.ModDB.Sum(mod.TypeBase, nil, "AvoidStun"), 100)
+	if actor.Output["EnergyShield"] > actor.Output["Life"]*2 {
+		stunChance *= 0.5
+	}
+	actor.Output["StunAvoidChance"] = 100 - stunChance
+	if actor.Output["StunAvoidChance"] >= 100 {
+		actor.Output["StunDuration"] = 0
+		actor.Output["BlockDuration"] = 0
+	} else {
+		stunRecovery := 1 + actor.ModDB.Sum(mod.TypeIncrease, nil, "StunRecovery")/100
+		blockRecovery := 1 + actor.ModDB.Sum(mod.TypeIncrease, nil, "StunRecovery", "BlockRecovery")/100
+		actor.Output["StunDuration"] = 0.35 / stunRecovery
+		actor.Output["BlockDuration"] = 0.35 / blockRecovery
+		if actor.Breakdown != nil {
+			actor.Breakdown.Set("StunDuration", actor.Output["StunDuration"],
+				"0.35s (base)",
+				fmt.Sprintf("/ %.2f (increased/reduced recovery)", stunRecovery),
+				fmt.Sprintf("= %.2fs", actor.Output["StunDuration"]),
+			)
+			actor.Breakdown.Set("BlockDuration", actor.Output["BlockDuration"],
+				"0.35s (base)",
+				fmt.Sprintf("/ %.2f (increased/reduced recovery)", blockRecovery),
+				fmt.Sprintf("= %.2fs", actor.Output["BlockDuration"]),
+			)
+		}
+	}
+	actor.Output["InteruptStunAvoidChance"] = math.Min(actor.ModDB.Sum(mod.TypeBase, nil, "AvoidInteruptStun"), 100)
+	actor.Output["BlindAvoidChance"] = math.Min(actor.ModDB.Sum(mod.TypeBase, nil, "AvoidBlind"), 100)
+	for _, ailment := range ailmentTypeList {
+		actor.Output[string(ailment)+"AvoidChance"] = math.Min(actor.ModDB.Sum(mod.TypeBase, nil, "Avoid"+string(ailment)), 100)
+	}
+	actor.Output["CritExtraDamageReduction"] = math.Min(actor.ModDB.Sum(mod.TypeBase, nil, "ReduceCritExtraDamage"), 100)
+	actor.Output["LightRadiusMod"] = CalcMod(actor.ModDB, nil, "LightRadius")
+	actor.Output["CurseEffectOnSelf"] = actor.ModDB.More(nil, "CurseEffectOnSelf") * (100 + actor.ModDB.Sum(mod.TypeIncrease, nil, "CurseEffectOnSelf"))
+
 	/*
 		TODO -- Ailment duration on self
 		output.SelfBlindDuration = modDB:More(nil, "SelfBlindDuration") * (100 + modDB:Sum("INC", nil, "SelfBlindDuration"))
@@ -853,1162 +701,982 @@ func CalculateDefence(environment *Environment, actor *Actor) {
 		output.SelfChillEffect = modDB:More(nil, "SelfChillEffect") * (100 + modDB:Sum("INC", nil, "SelfChillEffect"))
 		output.SelfShockEffect = modDB:More(nil, "SelfShockEffect") * (100 + modDB:Sum("INC", nil, "SelfShockEffect"))
 	*/
-	/*
-		TODO --Enemy damage input and modifications
-		do
-			output["totalEnemyDamage"] = 0
-			output["totalEnemyDamageIn"] = 0
-			if breakdown then
-				breakdown["totalEnemyDamage"] = {
-					label = "Total damage from the enemy",
-					rowList = { },
-					colList = {
-						{ label = "Type", key = "type" },
-						{ label = "Value", key = "value" },
-						{ label = "Mult", key = "mult" },
-						{ label = "Crit", key = "crit" },
-						{ label = "Final", key = "final" },
-						{ label = "From", key = "from" },
-					},
-				}
-			end
-			local enemyCritChance = env.configInput["enemyCritChance"] or env.configPlaceholder["enemyCritChance"] or 0
-			local enemyCritDamage = env.configInput["enemyCritDamage"] or env.configPlaceholder["enemyCritDamage"] or 0
-			output["EnemyCritEffect"] = 1 + enemyCritChance / 100 * (enemyCritDamage / 100) * (1 - output.CritExtraDamageReduction / 100)
-			for _, damageType in ipairs(dmgTypeList) do
-				local enemyDamageMult = calcLib.mod(enemyDB, nil, "Damage", damageType.."Damage", isElemental[damageType] and "ElementalDamage" or nil) --missing taunt from allies
-				local enemyDamage = env.configInput["enemy"..damageType.."Damage"]
-				local enemyPen = env.configInput["enemy"..damageType.."Pen"]
-				local sourceStr = enemyDamage == nil and "Default" or "Config"
-
-				if enemyDamage == nil and env.configPlaceholder["enemy"..damageType.."Damage"] then
-					enemyDamage = env.configPlaceholder["enemy"..damageType.."Damage"]
-				end
-				if enemyPen == nil and env.configPlaceholder["enemy"..damageType.."Pen"] then
-					enemyPen = env.configPlaceholder["enemy"..damageType.."Pen"]
-				end
-				enemyDamage = enemyDamage or 0
-				output[damageType.."EnemyPen"] = enemyPen or 0
-				output["totalEnemyDamageIn"] = output["totalEnemyDamageIn"] + enemyDamage
-				output[damageType.."EnemyDamage"] = enemyDamage * enemyDamageMult * output["EnemyCritEffect"]
-				output["totalEnemyDamage"] = output["totalEnemyDamage"] + output[damageType.."EnemyDamage"]
-				if breakdown then
-					breakdown[damageType.."EnemyDamage"] = {
-					s_format("from %s: %d", sourceStr, enemyDamage),
-					s_format("* %.2f (modifiers to enemy damage)", enemyDamageMult),
-					s_format("* %.3f (enemy crit effect)", output["EnemyCritEffect"]),
-					s_format("= %d", output[damageType.."EnemyDamage"]),
-					}
-					t_insert(breakdown["totalEnemyDamage"].rowList, {
-						type = s_format("%s", damageType),
-						value = s_format("%d", enemyDamage),
-						mult = s_format("%.2f", enemyDamageMult),
-						crit = s_format("%.2f", output["EnemyCritEffect"]),
-						final = s_format("%d", output[damageType.."EnemyDamage"]),
-						from = s_format("%s", sourceStr),
-					})
-				end
-			end
-		end
-	*/
-	/*
-		TODO --Damage Taken as
-		do
-			actor.damageShiftTable = wipeTable(actor.damageShiftTable)
-			for _, damageType in ipairs(dmgTypeList) do
-				-- Build damage shift table
-				local shiftTable = { }
-				local destTotal = 0
-				for _, destType in ipairs(dmgTypeList) do
-					if destType ~= damageType then
-						shiftTable[destType] = modDB:Sum("BASE", nil, damageType.."DamageTakenAs"..destType, isElemental[damageType] and "ElementalDamageTakenAs"..destType or nil)
-						destTotal = destTotal + shiftTable[destType]
-					end
-				end
-				if destTotal > 100 then
-					local factor = 100 / destTotal
-					for destType, portion in pairs(shiftTable) do
-						shiftTable[destType] = portion * factor
-					end
-					destTotal = 100
-				end
-				shiftTable[damageType] = 100 - destTotal
-				actor.damageShiftTable[damageType] = shiftTable
-
-				--add same type damage
-				output[damageType.."TakenDamage"] = output[damageType.."EnemyDamage"] * actor.damageShiftTable[damageType][damageType] / 100
-				if breakdown then
-					breakdown[damageType.."TakenDamage"] = {
-						label = "Taken",
-						rowList = { },
-						colList = {
-							{ label = "Type", key = "type" },
-							{ label = "Value", key = "value" },
-						},
-					}
-					t_insert(breakdown[damageType.."TakenDamage"].rowList, {
-						type = s_format("%s", damageType),
-						value = s_format("%d", output[damageType.."TakenDamage"]),
-					})
-				end
-			end
-			--converted damage types
-			for _, damageType in ipairs(dmgTypeList) do
-				for _, damageConvertedType in ipairs(dmgTypeList) do
-					if damageType ~= damageConvertedType then
-						local damage = output[damageType.."EnemyDamage"] * actor.damageShiftTable[damageType][damageConvertedType] / 100
-						output[damageConvertedType.."TakenDamage"] = output[damageConvertedType.."TakenDamage"] + damage
-						if breakdown and damage > 0 then
-							t_insert(breakdown[damageConvertedType.."TakenDamage"].rowList, {
-								type = s_format("%s", damageType),
-								value = s_format("%d", damage),
-							})
-						end
-					end
-				end
-			end
-			--total
-			output["totalTakenDamage"] = 0
-			if breakdown then
-				breakdown["totalTakenDamage"] = {
-					label = "Total damage taken from the enemy after taken as",
-					rowList = { },
-					colList = {
-						{ label = "Type", key = "type" },
-						{ label = "Value", key = "value" },
-					},
+
+	// Enemy damage input and modifications
+	actor.Output["totalEnemyDamage"] = 0
+	actor.Output["totalEnemyDamageIn"] = 0
+	enemyCritChance, _ := env.configNumber("enemyCritChance")
+	enemyCritDamage, _ := env.configNumber("enemyCritDamage")
+	actor.Output["EnemyCritEffect"] = 1 + enemyCritChance/100*(enemyCritDamage/100)*(1-actor.Output["CritExtraDamageReduction"]/100)
+	var totalEnemyDamageLines []string
+	for _, damageType := range dmgTypeList {
+		damageNames := []string{"Damage", string(damageType) + "Damage"}
+		if damageType.IsElemental() {
+			damageNames = append(damageNames, "ElementalDamage")
+		}
+		enemyDamageMult := CalcMod(enemyDB, nil, damageNames...) // TODO Taunt from allies
+		enemyDamage, _ := env.configNumber("enemy" + string(damageType) + "Damage")
+		enemyPen, _ := env.configNumber("enemy" + string(damageType) + "Pen")
+		sourceStr := "Default"
+		if _, ok := env.ConfigInput["enemy"+string(damageType)+"Damage"]; ok {
+			sourceStr = "Config"
+		}
+
+		actor.Output[string(damageType)+"EnemyPen"] = enemyPen
+		actor.Output["totalEnemyDamageIn"] += enemyDamage
+		actor.Output[string(damageType)+"EnemyDamage"] = enemyDamage * enemyDamageMult * actor.Output["EnemyCritEffect"]
+		actor.Output["totalEnemyDamage"] += actor.Output[string(damageType)+"EnemyDamage"]
+		if actor.Breakdown != nil {
+			actor.Breakdown.Set(string(damageType)+"EnemyDamage", actor.Output[string(damageType)+"EnemyDamage"],
+				fmt.Sprintf("from %s: %g", sourceStr, enemyDamage),
+				fmt.Sprintf("* %.2f (modifiers to enemy damage)", enemyDamageMult),
+				fmt.Sprintf("* %.3f (enemy crit effect)", actor.Output["EnemyCritEffect"]),
+				fmt.Sprintf("= %.0f", actor.Output[string(damageType)+"EnemyDamage"]),
+			)
+			totalEnemyDamageLines = append(totalEnemyDamageLines, fmt.Sprintf("%s: %g x %.2f x %.2f = %.0f (%s)", damageType, enemyDamage, enemyDamageMult, actor.Output["EnemyCritEffect"], actor.Output[string(damageType)+"EnemyDamage"], sourceStr))
+		}
+	}
+	if actor.Breakdown != nil {
+		actor.Breakdown.Set("totalEnemyDamage", actor.Output["totalEnemyDamage"], totalEnemyDamageLines...)
+	}
+
+	// Damage Taken as
+	actor.DamageShiftTable = make(map[data.DamageType]map[data.DamageType]float64, len(dmgTypeList))
+	takenDamageLines := make(map[data.DamageType][]string, len(dmgTypeList))
+	for _, damageType := range dmgTypeList {
+		// Build damage shift table
+		shiftTable := make(map[data.DamageType]float64, len(dmgTypeList))
+		destTotal := float64(0)
+		for _, destType := range dmgTypeList {
+			if destType != damageType {
+				names := []string{string(damageType) + "DamageTakenAs" + string(destType)}
+				if damageType.IsElemental() {
+					names = append(names, "ElementalDamageTakenAs"+string(destType))
 				}
-			end
-			for _, damageType in ipairs(dmgTypeList) do
-				output["totalTakenDamage"] = output["totalTakenDamage"] + output[damageType.."TakenDamage"]
-				if breakdown then
-					t_insert(breakdown["totalTakenDamage"].rowList, {
-						type = s_format("%s", damageType),
-						value = s_format("%d", output[damageType.."TakenDamage"]),
-					})
-				end
-			end
-		end
-	*/
-	/*
-		TODO -- Damage taken multipliers/Degen calculations
-		output.AnyTakenReflect = false
-		local damageCategoryConfig = env.configInput.enemyDamageType or "Average"
-		for _, damageType in ipairs(dmgTypeList) do
-			local baseTakenInc = modDB:Sum("INC", nil, "DamageTaken", damageType.."DamageTaken")
-			local baseTakenMore = modDB:More(nil, "DamageTaken", damageType.."DamageTaken")
-			if isElemental[damageType] then
-				baseTakenInc = baseTakenInc + modDB:Sum("INC", nil, "ElementalDamageTaken")
-				baseTakenMore = baseTakenMore * modDB:More(nil, "ElementalDamageTaken")
-			end
-			do	-- Hit
-				local takenInc = baseTakenInc + modDB:Sum("INC", nil, "DamageTakenWhenHit", damageType.."DamageTakenWhenHit")
-				local takenMore = baseTakenMore * modDB:More(nil, "DamageTakenWhenHit", damageType.."DamageTakenWhenHit")
-				if isElemental[damageType] then
-					takenInc = takenInc + modDB:Sum("INC", nil, "ElementalDamageTakenWhenHit")
-					takenMore = takenMore * modDB:More(nil, "ElementalDamageTakenWhenHit")
-				end
-				output[damageType.."TakenHitMult"] = m_max((1 + takenInc / 100) * takenMore, 0)
-
-				for _, hitType in ipairs(hitSourceList) do
-					local baseTakenIncType = takenInc + modDB:Sum("INC", nil, hitType.."DamageTaken")
-					local baseTakenMoreType = takenMore * modDB:More(nil, hitType.."DamageTaken")
-					output[hitType.."TakenHitMult"] = m_max((1 + baseTakenIncType / 100) * baseTakenMoreType, 0)
-					output[damageType..hitType.."TakenHitMult"] = output[hitType.."TakenHitMult"]
-				end
-				do
-					-- Reflect
-					takenInc = takenInc + modDB:Sum("INC", nil, damageType.."ReflectedDamageTaken")
-					takenMore = takenMore * modDB:More(nil, damageType.."ReflectedDamageTaken")
-					if isElemental[damageType] then
-						takenInc = takenInc + modDB:Sum("INC", nil, "ElementalReflectedDamageTaken")
-						takenMore = takenMore * modDB:More(nil, "ElementalReflectedDamageTaken")
-					end
-					output[damageType.."TakenReflect"] = m_max((1 + takenInc / 100) * takenMore, 0)
-					if output[damageType.."TakenReflect"] ~= output[damageType.."TakenHitMult"] then
-						output.AnyTakenReflect = false --true --this needs a rework as well
-					end
-				end
-			end
-			do	-- Dot
-				local takenInc = baseTakenInc + modDB:Sum("INC", nil, "DamageTakenOverTime", damageType.."DamageTakenOverTime")
-				local takenMore = baseTakenMore * modDB:More(nil, "DamageTakenOverTime", damageType.."DamageTakenOverTime")
-				if isElemental[damageType] then
-					takenInc = takenInc + modDB:Sum("INC", nil, "ElementalDamageTakenOverTime")
-					takenMore = takenMore * modDB:More(nil, "ElementalDamageTakenOverTime")
-				end
-				local resist = modDB:Flag(nil, "SelfIgnore"..damageType.."Resistance") and 0 or output[damageType.."Resist"]
-				if damageType == "Physical" then
-					resist = m_max(resist, 0)
-				end
-				output[damageType.."TakenDotMult"] = (1 - resist / 100) * (1 + takenInc / 100) * takenMore
-				if breakdown then
-					breakdown[damageType.."TakenDotMult"] = { }
-					breakdown.multiChain(breakdown[damageType.."TakenDotMult"], {
-						label = "DoT Multiplier:",
-						{ "%.2f ^8(%s)", (1 - resist / 100), damageType == "Physical" and "physical damage reduction" or "resistance" },
-						{ "%.2f ^8(increased/reduced damage taken)", (1 + takenInc / 100) },
-						{ "%.2f ^8(more/less damage taken)", takenMore },
-						total = s_format("= %.2f", output[damageType.."TakenDotMult"]),
-					})
-				end
-			end
-		end
-	*/
-	/*
-		TODO -- Incoming hit damage multipliers
-		output["totalTakenHit"] = 0
-		if breakdown then
-			breakdown["totalTakenHit"] = {
-				label = "Total damage taken after mitigation",
-				rowList = { },
-				colList = {
-					{ label = "Type", key = "type" },
-					{ label = "Incoming", key = "incoming" },
-					{ label = "Mult", key = "mult" },
-					{ label = "Value", key = "value" },
-				},
+				shiftTable[destType] = actor.ModDB.Sum(mod.TypeBase, nil, names...)
+				destTotal += shiftTable[destType]
 			}
-		end
-		for _, damageType in ipairs(dmgTypeList) do
-			-- Calculate incoming damage multiplier
-			local resist = modDB:Flag(nil, "SelfIgnore"..damageType.."Resistance") and 0 or output[damageType.."ResistWhenHit"] or output[damageType.."Resist"]
-			local enemyPen = modDB:Flag(nil, "SelfIgnore"..damageType.."Resistance") and 0 or output[damageType.."EnemyPen"]
-			local takenFlat = modDB:Sum("BASE", nil, "DamageTaken", damageType.."DamageTaken", "DamageTakenWhenHit", damageType.."DamageTakenWhenHit")
-			if damageCategoryConfig == "Melee" or damageCategoryConfig == "Projectile" then
-				takenFlat = takenFlat + modDB:Sum("BASE", nil, "DamageTakenFromAttacks", damageType.."DamageTakenFromAttacks")
-			elseif damageCategoryConfig == "Average" then
-				takenFlat = takenFlat + modDB:Sum("BASE", nil, "DamageTakenFromAttacks", damageType.."DamageTakenFromAttacks") / 2
-			end
-			if damageType == "Physical" or modDB:Flag(nil, "ArmourAppliesTo"..damageType.."DamageTaken") then
-				local damage = output[damageType.."TakenDamage"]
-				local armourReduct = 0
-				local portionArmour = 100
-				if damageType == "Physical" then
-					if not modDB:Flag(nil, "ArmourDoesNotApplyToPhysicalDamageTaken") then
-						armourReduct = calcs.armourReduction(output.Armour * (1 + output.ArmourDefense), damage)
-						armourReduct = m_max(m_min(output.DamageReductionMax, resist - enemyPen + armourReduct), 0)
-						resist = armourReduct
-					end
-				else
-					portionArmour = 100 - (resist - enemyPen)
-					armourReduct = calcs.armourReduction(output.Armour * (1 + output.ArmourDefense), damage * portionArmour / 100)
-					armourReduct = m_min(output.DamageReductionMax, armourReduct)
-					resist = resist + armourReduct * portionArmour / 100
-				end
-				output[damageType.."DamageReduction"] = portionArmour < 100 and armourReduct * portionArmour / 100 or armourReduct
-				if breakdown then
-					if portionArmour > 100 then
-						breakdown[damageType.."DamageReduction"] = {
-							s_format("Enemy Hit Damage:"),
-							s_format("    %d ^8(total incoming damage)", damage),
-							s_format("    * %.2f ^8(from resistance, applies before armour)", (portionArmour / 100)),
-						}
-					elseif portionArmour < 100 then
-						breakdown[damageType.."DamageReduction"] = {
-							s_format("Enemy Hit Damage: %d ^8(total incoming damage)", damage),
-							s_format("Portion mitigated by Armour: %d%%", portionArmour),
-						}
-					else
-						breakdown[damageType.."DamageReduction"] = {
-							s_format("Enemy Hit Damage: %d ^8(total incoming damage)", damage),
-						}
-					end
-					t_insert(breakdown[damageType.."DamageReduction"], s_format("Reduction from Armour: %d%%", armourReduct))
-				end
-			end
-			local takenMult = output[damageType.."TakenHitMult"]
-			if damageCategoryConfig == "Melee" or damageCategoryConfig == "Projectile" then
-				takenMult = output[damageType.."AttackTakenHitMult"]
-			elseif damageCategoryConfig == "Spell" or damageCategoryConfig == "SpellProjectile" then
-				takenMult = output[damageType.."SpellTakenHitMult"]
-			elseif damageCategoryConfig == "Average" then
-				takenMult = (output[damageType.."SpellTakenHitMult"] + output[damageType.."AttackTakenHitMult"]) / 2
-			end
-			output[damageType.."BaseTakenHitMult"] = (1 - (resist - enemyPen) / 100) * takenMult
-			local takenMultReflect = output[damageType.."TakenReflect"]
-			local finalReflect = (1 - (resist - enemyPen) / 100) * takenMultReflect
-			output[damageType.."TakenHit"] = m_max(output[damageType.."TakenDamage"] * (1 - (resist - enemyPen) / 100) + takenFlat, 0) * takenMult
-			output[damageType.."TakenHitMult"] = (output[damageType.."TakenDamage"] > 0) and (output[damageType.."TakenHit"] / output[damageType.."TakenDamage"]) or 0
-			output["totalTakenHit"] = output["totalTakenHit"] + output[damageType.."TakenHit"]
-			if output.AnyTakenReflect then
-				output[damageType.."TakenReflectMult"] = finalReflect
-			end
-			if breakdown then
-				breakdown[damageType.."TakenHitMult"] = {
-					s_format("Resistance: %.2f", 1 - resist / 100),
-				}
-				if enemyPen > 0 then
-					t_insert(breakdown[damageType.."TakenHitMult"], s_format("Enemy Pen: %.2f", enemyPen))
-				end
-				t_insert(breakdown[damageType.."TakenHitMult"], s_format("+ Flat: %.3f", takenFlat))
-				t_insert(breakdown[damageType.."TakenHitMult"], s_format("x Taken: %.3f", takenMult))
-				t_insert(breakdown[damageType.."TakenHitMult"], s_format("= %.3f", output[damageType.."TakenHitMult"]))
-				breakdown[damageType.."TakenHit"] = {
-					s_format("Final %s Damage taken:", damageType),
-					s_format("%.1f incoming damage", output[damageType.."TakenDamage"]),
-					s_format("x %.3f damage mult", output[damageType.."TakenHitMult"]),
-					s_format("= %.1f", output[damageType.."TakenHit"]),
-				}
-				t_insert(breakdown["totalTakenHit"].rowList, {
-					type = s_format("%s", damageType),
-					incoming = s_format("%.1f incoming damage", output[damageType.."TakenDamage"]),
-					mult = s_format("x %.3f damage mult", output[damageType.."TakenHitMult"] ),
-					value = s_format("%d", output[damageType.."TakenHit"]),
-				})
-				if output.AnyTakenReflect then
-					breakdown[damageType.."TakenReflectMult"] = {
-						s_format("Resistance: %.3f", 1 - resist / 100),
-					}
-					if enemyPen > 0 then
-						t_insert(breakdown[damageType.."TakenReflectMult"], s_format("Enemy Pen: %.2f", enemyPen))
-					end
-					t_insert(breakdown[damageType.."TakenReflectMult"], s_format("Taken: %.3f", takenMultReflect))
-					t_insert(breakdown[damageType.."TakenReflectMult"], s_format("= %.3f", finalReflect))
-				end
-			end
-		end
-	*/
-	/*
-		TODO -- Life Recoverable
-		output.LifeRecoverable = output.LifeUnreserved
-		if env.configInput["conditionLowLife"] then
-			output.LifeRecoverable = m_min(output.Life * data.misc.LowPoolThreshold, output.LifeUnreserved)
-			if output.LifeRecoverable < output.LifeUnreserved then
-				output.CappingLife = true
-			end
-		end
-	*/
-	/*
-		TODO -- Prevented life loss (Petrified Blood)
-		do
-			output["preventedLifeLoss"] = modDB:Sum("BASE", nil, "LifeLossBelowHalfPrevented")
-			local portionLife = 1
-			if not env.configInput["conditionLowLife"] then
-				--portion of life that is lowlife
-				portionLife = m_min(output.Life * data.misc.LowPoolThreshold / output.LifeRecoverable, 1)
-				output["preventedLifeLoss"] = output["preventedLifeLoss"] * portionLife
-			end
-			if breakdown then
-				breakdown["preventedLifeLoss"] = {
-					s_format("Total life protected:"),
-				}
-				if portionLife ~= 1 then
-					t_insert(breakdown["preventedLifeLoss"], s_format("%.2f ^8(initial portion taken from petrified blood)", output["preventedLifeLoss"] / portionLife / 100))
-					t_insert(breakdown["preventedLifeLoss"], s_format("* %.2f ^8(portion of life on low life)", portionLife))
-					t_insert(breakdown["preventedLifeLoss"], s_format("= %.2f ^8(final portion taken from petrified blood)", output["preventedLifeLoss"] / 100))
-					t_insert(breakdown["preventedLifeLoss"], s_format(""))
-				else
-					t_insert(breakdown["preventedLifeLoss"], s_format("%.2f ^8(portion taken from petrified blood)", output["preventedLifeLoss"] / 100))
-				end
-				t_insert(breakdown["preventedLifeLoss"], s_format("%.2f ^8(portion taken from life)", 1 - output["preventedLifeLoss"] / 100))
-			end
-		end
-	*/
-	/*
-		TODO -- Energy Shield bypass
-		output.AnyBypass = false
-		output.MinimumBypass = 100
-		for _, damageType in ipairs(dmgTypeList) do
-			if modDB:Flag(nil, "UnblockedDamageDoesBypassES") then
-				output[damageType.."EnergyShieldBypass"] = 100
-				output.AnyBypass = true
-			else
-				output[damageType.."EnergyShieldBypass"] = modDB:Sum("BASE", nil, damageType.."EnergyShieldBypass") or 0
-				if output[damageType.."EnergyShieldBypass"] ~= 0 then
-					output.AnyBypass = true
-				end
-				if damageType == "Chaos" then
-					if not modDB:Flag(nil, "ChaosNotBypassEnergyShield") then
-						output[damageType.."EnergyShieldBypass"] = output[damageType.."EnergyShieldBypass"] + 100
-					else
-						output.AnyBypass = true
-					end
-				end
-			end
-			output[damageType.."EnergyShieldBypass"] = m_max(m_min(output[damageType.."EnergyShieldBypass"], 100), 0)
-			output.MinimumBypass = m_min(output.MinimumBypass, output[damageType.."EnergyShieldBypass"])
-		end
+		}
+		if destTotal > 100 {
+			factor := 100 / destTotal
+			for destType, portion := range shiftTable {
+				shiftTable[destType] = portion * factor
+			}
+			destTotal = 100
+		}
+		shiftTable[damageType] = 100 - destTotal
+		actor.DamageShiftTable[damageType] = shiftTable
 
-		output.ehpSectionAnySpecificTypes = false
-	*/
-	/*
-		TODO -- Mind over Matter
-		output.OnlySharedMindOverMatter = false
-		output.AnySpecificMindOverMatter = false
-		output["sharedMindOverMatter"] = m_min(modDB:Sum("BASE", nil, "DamageTakenFromManaBeforeLife"), 100)
-		if output["sharedMindOverMatter"] > 0 then
-			output.OnlySharedMindOverMatter = true
-			local sourcePool = m_max(output.ManaUnreserved or 0, 0)
-			local manatext = "unreserved mana"
-			if modDB:Flag(nil, "EnergyShieldProtectsMana") and output.MinimumBypass < 100 then
-				manatext = manatext.." + non-bypassed energy shield"
-				if output.MinimumBypass > 0 then
-					local manaProtected = output.EnergyShieldRecoveryCap / (1 - output.MinimumBypass / 100) * (output.MinimumBypass / 100)
-					sourcePool = m_max(sourcePool - manaProtected, 0) + m_min(sourcePool, manaProtected) / (output.MinimumBypass / 100)
-				else
-					sourcePool = sourcePool + output.EnergyShieldRecoveryCap
-				end
-			end
-			local poolProtected = sourcePool / (output["sharedMindOverMatter"] / 100) * (1 - output["sharedMindOverMatter"] / 100)
-			if output["sharedMindOverMatter"] >= 100 then
-				poolProtected = m_huge
-				output["sharedManaEffectiveLife"] = output.LifeRecoverable + sourcePool
-			else
-				output["sharedManaEffectiveLife"] = m_max(output.LifeRecoverable - poolProtected, 0) + m_min(output.LifeRecoverable, poolProtected) / (1 - output["sharedMindOverMatter"] / 100)
-			end
-			if breakdown then
-				if output["sharedMindOverMatter"] then
-					breakdown["sharedMindOverMatter"] = {
-						s_format("Total life protected:"),
-						s_format("%d ^8(%s)", sourcePool, manatext),
-						s_format("/ %.2f ^8(portion taken from mana)", output["sharedMindOverMatter"] / 100),
-						s_format("x %.2f ^8(portion taken from life)", 1 - output["sharedMindOverMatter"] / 100),
-						s_format("= %d", poolProtected),
-						s_format("Effective life: %d", output["sharedManaEffectiveLife"])
-					}
-				end
-			end
-		else
-			output["sharedManaEffectiveLife"] = output.LifeRecoverable
-		end
-		for _, damageType in ipairs(dmgTypeList) do
-			output[damageType.."MindOverMatter"] = m_min(modDB:Sum("BASE", nil, damageType.."DamageTakenFromManaBeforeLife"), 100 - output["sharedMindOverMatter"])
-			if output[damageType.."MindOverMatter"] > 0 or (output[damageType.."EnergyShieldBypass"] > output.MinimumBypass and output["sharedMindOverMatter"] > 0) then
-				local MindOverMatter = output[damageType.."MindOverMatter"] + output["sharedMindOverMatter"]
-				output.ehpSectionAnySpecificTypes = true
-				output.AnySpecificMindOverMatter = true
-				output.OnlySharedMindOverMatter = false
-				local sourcePool = m_max(output.ManaUnreserved or 0, 0)
-				local manatext = "unreserved mana"
-				if modDB:Flag(nil, "EnergyShieldProtectsMana") and output[damageType.."EnergyShieldBypass"] < 100 then
-					manatext = manatext.." + non-bypassed energy shield"
-					if output[damageType.."EnergyShieldBypass"] > 0 then
-						local manaProtected = output.EnergyShieldRecoveryCap / (1 - output[damageType.."EnergyShieldBypass"] / 100) * (output[damageType.."EnergyShieldBypass"] / 100)
-						sourcePool = m_max(sourcePool - manaProtected, 0) + m_min(sourcePool, manaProtected) / (output[damageType.."EnergyShieldBypass"] / 100)
-					else
-						sourcePool = sourcePool + output.EnergyShieldRecoveryCap
-					end
-				end
-				local poolProtected = sourcePool / (MindOverMatter / 100) * (1 - MindOverMatter / 100)
-				if MindOverMatter >= 100 then
-					poolProtected = m_huge
-					output[damageType.."ManaEffectiveLife"] = output.LifeRecoverable + sourcePool
-				else
-					output[damageType.."ManaEffectiveLife"] = m_max(output.LifeRecoverable - poolProtected, 0) + m_min(output.LifeRecoverable, poolProtected) / (1 - MindOverMatter / 100)
-				end
-				if breakdown then
-					if output[damageType.."MindOverMatter"] then
-						breakdown[damageType.."MindOverMatter"] = {
-							s_format("Total life protected:"),
-							s_format("%d ^8(%s)", sourcePool, manatext),
-							s_format("/ %.2f ^8(portion taken from mana)", MindOverMatter / 100),
-							s_format("x %.2f ^8(portion taken from life)", 1 - MindOverMatter / 100),
-							s_format("= %d", poolProtected),
-							s_format("Effective life: %d", output[damageType.."ManaEffectiveLife"])
-						}
-					end
-				end
-			else
-				output[damageType.."ManaEffectiveLife"] = output["sharedManaEffectiveLife"]
-			end
-		end
-	*/
-	/*
-		TODO -- Guard
-		output.AnyGuard = false
-		output["sharedGuardAbsorbRate"] = m_min(modDB:Sum("BASE", nil, "GuardAbsorbRate"), 100)
-		if output["sharedGuardAbsorbRate"] > 0 then
-			output.OnlySharedGuard = true
-			output["sharedGuardAbsorb"] = calcLib.val(modDB, "GuardAbsorbLimit")
-			local lifeProtected = output["sharedGuardAbsorb"] / (output["sharedGuardAbsorbRate"] / 100) * (1 - output["sharedGuardAbsorbRate"] / 100)
-			if breakdown then
-				breakdown["sharedGuardAbsorb"] = {
-					s_format("Total life protected:"),
-					s_format("%d ^8(guard limit)", output["sharedGuardAbsorb"]),
-					s_format("/ %.2f ^8(portion taken from guard)", output["sharedGuardAbsorbRate"] / 100),
-					s_format("x %.2f ^8(portion taken from life and energy shield)", 1 - output["sharedGuardAbsorbRate"] / 100),
-					s_format("= %d", lifeProtected)
-				}
-			end
-		end
-		for _, damageType in ipairs(dmgTypeList) do
-			output[damageType.."GuardAbsorbRate"] = m_min(modDB:Sum("BASE", nil, damageType.."GuardAbsorbRate"), 100)
-			if output[damageType.."GuardAbsorbRate"] > 0 then
-				output.ehpSectionAnySpecificTypes = true
-				output.AnyGuard = true
-				output.OnlySharedGuard = false
-				output[damageType.."GuardAbsorb"] = calcLib.val(modDB, damageType.."GuardAbsorbLimit")
-				local lifeProtected = output[damageType.."GuardAbsorb"] / (output[damageType.."GuardAbsorbRate"] / 100) * (1 - output[damageType.."GuardAbsorbRate"] / 100)
-				if breakdown then
-					breakdown[damageType.."GuardAbsorb"] = {
-						s_format("Total life protected:"),
-						s_format("%d ^8(guard limit)", output[damageType.."GuardAbsorb"]),
-						s_format("/ %.2f ^8(portion taken from guard)", output[damageType.."GuardAbsorbRate"] / 100),
-						s_format("x %.2f ^8(portion taken from life and energy shield)", 1 - output[damageType.."GuardAbsorbRate"] / 100),
-						s_format("= %d", lifeProtected),
-					}
-				end
-			end
-		end
-	*/
-	/*
-		TODO --aegis
-		output.AnyAegis = false
-		output["sharedAegis"] = modDB:Max(nil, "AegisValue") or 0
-		output["sharedElementalAegis"] = modDB:Max(nil, "ElementalAegisValue") or 0
-		if output["sharedAegis"] > 0 then
-			output.AnyAegis = true
-		end
-		if output["sharedElementalAegis"] > 0 then
-			output.ehpSectionAnySpecificTypes = true
-			output.AnyAegis = true
-		end
-		for _, damageType in ipairs(dmgTypeList) do
-			local aegisValue = modDB:Max(nil, damageType.."AegisValue") or 0
-			if aegisValue > 0 then
-				output.ehpSectionAnySpecificTypes = true
-				output.AnyAegis = true
-				output[damageType.."Aegis"] = aegisValue
-			else
-				output[damageType.."Aegis"] = 0
-			end
-			if isElemental[damageType] then
-				output[damageType.."AegisDisplay"] = output[damageType.."Aegis"] + output["sharedElementalAegis"]
-			end
-		end
-	*/
-	/*
-		TODO --frost shield
-		do
-			output["FrostShieldLife"] = modDB:Sum("BASE", nil, "FrostGlobeHealth")
-			output["FrostShieldDamageMitigation"] = modDB:Sum("BASE", nil, "FrostGlobeDamageMitigation")
-
-			local lifeProtected = output["FrostShieldLife"] / (output["FrostShieldDamageMitigation"] / 100) * (1 - output["FrostShieldDamageMitigation"] / 100)
-			if breakdown then
-				breakdown["FrostShieldLife"] = {
-					s_format("Total life protected:"),
-					s_format("%d ^8(frost shield limit)", output["FrostShieldLife"]),
-					s_format("/ %.2f ^8(portion taken from frost shield)", output["FrostShieldDamageMitigation"] / 100),
-					s_format("x %.2f ^8(portion taken from life and energy shield)", 1 - output["FrostShieldDamageMitigation"] / 100),
-					s_format("= %d", lifeProtected),
-				}
-			end
-		end
-	*/
-	/*
-		TODO --total pool
-		for _, damageType in ipairs(dmgTypeList) do
-			output[damageType.."TotalPool"] = output[damageType.."ManaEffectiveLife"]
-			local manatext = "Mana"
-			if output[damageType.."EnergyShieldBypass"] < 100 then
-				if modDB:Flag(nil, "EnergyShieldProtectsMana") then
-					manatext = manatext.." and non-bypassed Energy Shield"
-				else
-					if output[damageType.."EnergyShieldBypass"] > 0 then
-						local poolProtected = output.EnergyShieldRecoveryCap / (1 - output[damageType.."EnergyShieldBypass"] / 100) * (output[damageType.."EnergyShieldBypass"] / 100)
-						output[damageType.."TotalPool"] = m_max(output[damageType.."TotalPool"] - poolProtected, 0) + m_min(output[damageType.."TotalPool"], poolProtected) / (output[damageType.."EnergyShieldBypass"] / 100)
-					else
-						output[damageType.."TotalPool"] = output[damageType.."TotalPool"] + output.EnergyShieldRecoveryCap
-					end
-				end
-			end
-			if breakdown then
-				breakdown[damageType.."TotalPool"] = {
-					s_format("Life: %d", output.LifeRecoverable)
+		// Add same type damage
+		actor.Output[string(damageType)+"TakenDamage"] = actor.Output[string(damageType)+"EnemyDamage"] * shiftTable[damageType] / 100
+		takenDamageLines[damageType] = []string{fmt.Sprintf("%s: %.0f", damageType, actor.Output[string(damageType)+"TakenDamage"])}
+	}
+
+	// Converted damage types
+	for _, damageType := range dmgTypeList {
+		for _, damageConvertedType := range dmgTypeList {
+			if damageType != damageConvertedType {
+				damage := actor.Output[string(damageType)+"EnemyDamage"] * actor.DamageShiftTable[damageType][damageConvertedType] / 100
+				actor.Output[string(damageConvertedType)+"TakenDamage"] += damage
+				if damage > 0 {
+					takenDamageLines[damageConvertedType] = append(takenDamageLines[damageConvertedType], fmt.Sprintf("%s: %.0f", damageType, damage))
 				}
-				if output[damageType.."ManaEffectiveLife"] ~= output.LifeRecoverable then
-					t_insert(breakdown[damageType.."TotalPool"], s_format("%s through MoM: %d", manatext, output[damageType.."ManaEffectiveLife"] - output.LifeRecoverable))
-				end
-				if (not modDB:Flag(nil, "EnergyShieldProtectsMana")) and output[damageType.."EnergyShieldBypass"] < 100 then
-					t_insert(breakdown[damageType.."TotalPool"], s_format("Non-bypassed Energy Shield: %d", output[damageType.."TotalPool"] - output[damageType.."ManaEffectiveLife"]))
-				end
-				t_insert(breakdown[damageType.."TotalPool"], s_format("TotalPool: %d", output[damageType.."TotalPool"]))
-			end
-		end
-	*/
-	/*
-		TODO -- helper function that iteratively reduces pools until life hits 0 to determine the number of hits it would take with given damage to die
-		function numberOfHitsToDie(DamageIn)
-			local numHits = 0
-			DamageIn["cycles"] = DamageIn["cycles"] or 1
-
-			--check damage in isnt 0 and that ward doesnt mitigate all damage
-			for _, damageType in ipairs(dmgTypeList) do
-				numHits = numHits + DamageIn[damageType]
-			end
-			if numHits == 0 then
-				return m_huge
-			elseif modDB:Flag(nil, "WardNotBreak") and output.Ward > 0 and  numHits < output.Ward then
-				return m_huge
-			else
-				numHits = 0
-			end
+			}
+		}
+	}
 
-			local life = output.LifeRecoverable or 0
-			local mana = output.ManaUnreserved or 0
-			local energyShield = output.EnergyShieldRecoveryCap
-			local ward = output.Ward or 0
-			local restoreWard = modDB:Flag(nil, "WardNotBreak") and ward or 0
-			-- dont apply non-perma ward for speed up calcs as it wont zero it correctly per hit
-			if (not modDB:Flag(nil, "WardNotBreak")) and DamageIn["cycles"] > 1 then
-				ward = 0
-				restoreWard = 0
-			end
-			local frostShield = output["FrostShieldLife"] or 0
-			local aegis = {}
-			aegis["shared"] = output["sharedAegis"] or 0
-			aegis["sharedElemental"] = output["sharedElementalAegis"] or 0
-			local guard = {}
-			guard["shared"] = output.sharedGuardAbsorb or 0
-			for _, damageType in ipairs(dmgTypeList) do
-				aegis[damageType] = output[damageType.."Aegis"] or 0
-				guard[damageType] = output[damageType.."GuardAbsorb"] or 0
-				if not DamageIn[damageType.."EnergyShieldBypass"] then
-					DamageIn[damageType.."EnergyShieldBypass"] = output[damageType.."EnergyShieldBypass"] or 0
-				end
+	// Total
+	actor.Output["totalTakenDamage"] = 0
+	totalTakenDamageLines := make([]string, 0, len(dmgTypeList))
+	for _, damageType := range dmgTypeList {
+		actor.Output["totalTakenDamage"] += actor.Output[string(damageType)+"TakenDamage"]
+		totalTakenDamageLines = append(totalTakenDamageLines, fmt.Sprintf("%s: %.0f", damageType, actor.Output[string(damageType)+"TakenDamage"]))
+		if actor.Breakdown != nil {
+			actor.Breakdown.Set(string(damageType)+"TakenDamage", actor.Output[string(damageType)+"TakenDamage"], takenDamageLines[damageType]...)
+		}
+	}
+	if actor.Breakdown != nil {
+		actor.Breakdown.Set("totalTakenDamage", actor.Output["totalTakenDamage"], totalTakenDamageLines...)
+	}
 
-			end
-			DamageIn["LifeLossBelowHalfLost"] = DamageIn["LifeLossBelowHalfLost"] or 0
-			DamageIn["WardBypass"] = DamageIn["WardBypass"] or modDB:Sum("BASE", nil, "WardBypass") or 0
-
-			local itterationMultiplier = 1
-			local maxHits = data.misc.ehpCalcMaxHitsToCalc
-			maxHits = maxHits / DamageIn["cycles"]
-			while life > 0 and numHits < maxHits do
-				numHits = numHits + itterationMultiplier
-				local Damage = {}
-				for _, damageType in ipairs(dmgTypeList) do
-					Damage[damageType] = DamageIn[damageType] * itterationMultiplier
-				end
-				if DamageIn.GainWhenHit and (itterationMultiplier > 1 or DamageIn["cycles"] > 1) then
-					local gainMult = itterationMultiplier * DamageIn["cycles"]
-					life = m_min(life + DamageIn.LifeWhenHit * (gainMult - 1), gainMult * (output.LifeRecoverable or 0))
-					mana = m_min(mana + DamageIn.ManaWhenHit * (gainMult - 1), gainMult * (output.ManaUnreserved or 0))
-					energyShield = m_min(energyShield + DamageIn.EnergyShieldWhenHit * (gainMult - 1), gainMult * output.EnergyShieldRecoveryCap)
-				end
-				for _, damageType in ipairs(dmgTypeList) do
-					if Damage[damageType] > 0 then
-						if frostShield > 0 then
-							local tempDamage = m_min(Damage[damageType] * output["FrostShieldDamageMitigation"] / 100 / itterationMultiplier, frostShield)
-							frostShield = frostShield - tempDamage
-							Damage[damageType] = Damage[damageType] - tempDamage
-						end
-						if aegis[damageType] > 0 then
-							local tempDamage = m_min(Damage[damageType], aegis[damageType])
-							aegis[damageType] = aegis[damageType] - tempDamage
-							Damage[damageType] = Damage[damageType] - tempDamage
-						end
-						if isElemental[damageType] and aegis["sharedElemental"] > 0 then
-							local tempDamage = m_min(Damage[damageType], aegis["sharedElemental"])
-							aegis["sharedElemental"] = aegis["sharedElemental"] - tempDamage
-							Damage[damageType] = Damage[damageType] - tempDamage
-						end
-						if aegis["shared"] > 0 then
-							local tempDamage = m_min(Damage[damageType], aegis["shared"])
-							aegis["shared"] = aegis["shared"] - tempDamage
-							Damage[damageType] = Damage[damageType] - tempDamage
-						end
-						if guard[damageType] > 0 then
-							local tempDamage = m_min(Damage[damageType] * output[damageType.."GuardAbsorbRate"] / 100 / itterationMultiplier, guard[damageType])
-							guard[damageType] = guard[damageType] - tempDamage
-							Damage[damageType] = Damage[damageType] - tempDamage
-						end
-						if guard["shared"] > 0 then
-							local tempDamage = m_min(Damage[damageType] * output["sharedGuardAbsorbRate"] / 100 / itterationMultiplier, guard["shared"])
-							guard["shared"] = guard["shared"] - tempDamage
-							Damage[damageType] = Damage[damageType] - tempDamage
-						end
-						if ward > 0 then
-							local tempDamage = m_min(Damage[damageType] * (1 - DamageIn["WardBypass"] / 100), ward)
-							ward = ward - tempDamage
-							Damage[damageType] = Damage[damageType] - tempDamage
-						end
-						if energyShield > 0 and (not modDB:Flag(nil, "EnergyShieldProtectsMana")) and DamageIn[damageType.."EnergyShieldBypass"] < 100 then
-							local tempDamage = m_min(Damage[damageType] * (1 - DamageIn[damageType.."EnergyShieldBypass"] / 100), energyShield)
-							energyShield = energyShield - tempDamage
-							Damage[damageType] = Damage[damageType] - tempDamage
-						end
-						if (output.sharedMindOverMatter + output[damageType.."MindOverMatter"]) > 0 then
-							local MoMDamage = Damage[damageType] * m_min(output.sharedMindOverMatter + output[damageType.."MindOverMatter"], 100) / 100
-							if modDB:Flag(nil, "EnergyShieldProtectsMana") and energyShield > 0 and DamageIn[damageType.."EnergyShieldBypass"] < 100 then
-								local tempDamage = m_min(MoMDamage * (1 - DamageIn[damageType.."EnergyShieldBypass"] / 100), energyShield)
-								energyShield = energyShield - tempDamage
-								MoMDamage = MoMDamage - tempDamage
-								local tempDamage2 = m_min(MoMDamage, mana)
-								mana = mana - tempDamage2
-								Damage[damageType] = Damage[damageType] - tempDamage - tempDamage2
-							elseif mana > 0 then
-								local tempDamage = m_min(MoMDamage, mana)
-								mana = mana - tempDamage
-								Damage[damageType] = Damage[damageType] - tempDamage
-							end
-						end
-						if output.preventedLifeLoss > 0 then
-							if DamageIn["LifeLossBelowHalfLost"] > 0 then
-								output["LifeLossBelowHalfLost"] = output["LifeLossBelowHalfLost"] + Damage[damageType] * output.preventedLifeLoss / 100
-							end
-							Damage[damageType] = Damage[damageType] * (1 - output.preventedLifeLoss / 100)
-						end
-						life = life - Damage[damageType]
-					end
-				end
-				if modDB:Flag(nil, "WardNotBreak") then
-					ward = restoreWard
-				elseif ward > 0 then
-					ward = 0
-				end
-				if DamageIn.GainWhenHit and life > 0 then
-					life = m_min(life + DamageIn.LifeWhenHit, output.LifeRecoverable or 0)
-					mana = m_min(mana + DamageIn.ManaWhenHit, output.ManaUnreserved or 0)
-					energyShield = m_min(energyShield + DamageIn.EnergyShieldWhenHit, output.EnergyShieldRecoveryCap)
-				end
-				itterationMultiplier = 1
-				--To speed it up, run recurivly but speed up
-				local maxDepth = data.misc.ehpCalcMaxDepth
-				local speedUp = data.misc.ehpCalcSpeedUp
-				DamageIn["cyclesRan"] = DamageIn["cyclesRan"] or false
-				if not DamageIn["cyclesRan"] and life > 0 and DamageIn["cycles"] < maxDepth then
-					Damage = {}
-					for _, damageType in ipairs(dmgTypeList) do
-						Damage[damageType] = DamageIn[damageType] * speedUp
-					end
-					Damage["cycles"] = DamageIn["cycles"] * speedUp
-					itterationMultiplier = m_max((numberOfHitsToDie(Damage) - 1) * speedUp - 1, 1)
-					DamageIn["cyclesRan"] = true
-				end
-			end
-			if numHits >= maxHits then
-				return m_huge
-			end
-			return numHits
-		end
-	*/
-	/*
-		TODO --number of damaging hits needed to be taken to die
-		do
-			local DamageIn = {}
-			for _, damageType in ipairs(dmgTypeList) do
-				DamageIn[damageType] = output[damageType.."TakenHit"]
-			end
-			output["NumberOfDamagingHits"] = numberOfHitsToDie(DamageIn)
-		end
+	// Damage taken multipliers
+	damageCategoryConfig := env.configString("enemyDamageType")
+	for _, damageType := range dmgTypeList {
+		baseTakenInc := actor.ModDB.Sum(mod.TypeIncrease, nil, "DamageTaken", string(damageType)+"DamageTaken")
+		baseTakenMore := actor.ModDB.More(nil, "DamageTaken", string(damageType)+"DamageTaken")
+		if damageType.IsElemental() {
+			baseTakenInc += actor.ModDB.Sum(mod.TypeIncrease, nil, "ElementalDamageTaken")
+			baseTakenMore *= actor.ModDB.More(nil, "ElementalDamageTaken")
+		}
 
+		// Hit
+		takenInc := baseTakenInc + actor.ModDB.Sum(mod.TypeIncrease, nil, "DamageTakenWhenHit", string(damageType)+"DamageTakenWhenHit")
+		takenMore := baseTakenMore * actor.ModDB.More(nil, "DamageTakenWhenHit", string(damageType)+"DamageTakenWhenHit")
+		if damageType.IsElemental() {
+			takenInc += actor.ModDB.Sum(mod.TypeIncrease, nil, "ElementalDamageTakenWhenHit")
+			takenMore *= actor.ModDB.More(nil, "ElementalDamageTakenWhenHit")
+		}
+		actor.Output[string(damageType)+"TakenHitMult"] = math.Max((1+takenInc/100)*takenMore, 0)
 
-		do
-			local DamageIn = {}
-			local BlockChance = 0
-			local blockEffect = 1
-			local suppressChance = 0
-			local suppressionEffect = 1
-			local ExtraAvoidChance = 0
-			local averageAvoidChance = 0
-			local worstOf = env.configInput.EHPUnluckyWorstOf or 1
-			--block effect
-			if damageCategoryConfig == "Melee" then
-				BlockChance = output.BlockChance / 100
-			else
-				BlockChance = output[damageCategoryConfig.."BlockChance"] / 100
-			end
-			--unlucky config to lower the value of block, dodge, evade etc for ehp
-			if worstOf > 1 then
-				BlockChance = BlockChance * BlockChance
-				if worstOf == 4 then
-					BlockChance = BlockChance * BlockChance
-				end
-			end
-			blockEffect = (1 - BlockChance * output.BlockEffect / 100)
-			if not env.configInput.DisableEHPGainOnBlock then
-				DamageIn.LifeWhenHit = output.LifeOnBlock * BlockChance
-				DamageIn.ManaWhenHit = output.ManaOnBlock * BlockChance
-				DamageIn.EnergyShieldWhenHit = output.EnergyShieldOnBlock * BlockChance
-				if damageCategoryConfig == "Spell" or damageCategoryConfig == "SpellProjectile" then
-					DamageIn.EnergyShieldWhenHit = DamageIn.EnergyShieldWhenHit + output.EnergyShieldOnSpellBlock * BlockChance
-				elseif damageCategoryConfig == "Average" then
-					DamageIn.EnergyShieldWhenHit = DamageIn.EnergyShieldWhenHit + output.EnergyShieldOnSpellBlock / 2 * BlockChance
-				end
-			end
-			-- suppression
-			if damageCategoryConfig == "Spell" or damageCategoryConfig == "SpellProjectile" or damageCategoryConfig == "Average" then
-				suppressChance = output.SpellSuppressionChance / 100
-			end
-			--unlucky config to lower the value of block, dodge, evade etc for ehp
-			if worstOf > 1 then
-				suppressChance = suppressChance * suppressChance
-				if worstOf == 4 then
-					suppressChance = suppressChance * suppressChance
-				end
-			end
-			if damageCategoryConfig == "Average" then
-				suppressChance = suppressChance / 2
-			end
-			suppressionEffect = 1 - suppressChance * output.SpellSuppressionEffect / 100
-			--extra avoid chance
-			if damageCategoryConfig == "Projectile" or damageCategoryConfig == "SpellProjectile" then
-				ExtraAvoidChance = ExtraAvoidChance + output.AvoidProjectilesChance
-			elseif damageCategoryConfig == "Average" then
-				ExtraAvoidChance = ExtraAvoidChance + output.AvoidProjectilesChance / 2
-			end
-			--gain when hit (currently just gain on block)
-			if not env.configInput.DisableEHPGainOnBlock then
-				if DamageIn.LifeWhenHit ~= 0 or DamageIn.ManaWhenHit ~= 0 or DamageIn.EnergyShieldWhenHit ~= 0 then
-					DamageIn.GainWhenHit = true
-				end
-			end
-			for _, damageType in ipairs(dmgTypeList) do
-				 -- Emperor's Vigilance (this needs to fail with divine flesh as it cant override it, hence the check for high bypass)
-				if modDB:Flag(nil, "BlockedDamageDoesntBypassES")and output[damageType.."EnergyShieldBypass"] < 100 and damageType ~= "Chaos"  then
-					DamageIn[damageType.."EnergyShieldBypass"] = output[damageType.."EnergyShieldBypass"] * (1 - BlockChance)
-				end
-				local AvoidChance = m_min(output["Avoid"..damageType.."DamageChance"] + ExtraAvoidChance, data.misc.AvoidChanceCap)
-				--unlucky config to lower the value of block, dodge, evade etc for ehp
-				if worstOf > 1 then
-					AvoidChance = AvoidChance / 100 * AvoidChance
-					if worstOf == 4 then
-						AvoidChance = AvoidChance / 100 * AvoidChance
-					end
-				end
-				averageAvoidChance = averageAvoidChance + AvoidChance
-				DamageIn[damageType] = output[damageType.."TakenHit"] * (blockEffect * suppressionEffect * (1 - AvoidChance / 100))
-			end
-			--petrified blood degen initialisation
-			if output["preventedLifeLoss"] > 0 then
-				output["LifeLossBelowHalfLost"] = 0
-				DamageIn["LifeLossBelowHalfLost"] = modDB:Sum("BASE", nil, "LifeLossBelowHalfLost") / 100
-			end
-			output["NumberOfMitigatedDamagingHits"] = numberOfHitsToDie(DamageIn)
-			averageAvoidChance = averageAvoidChance / 5
-			output["ConfiguredDamageChance"] = 100 * (blockEffect * suppressionEffect * (1 - averageAvoidChance / 100))
-			if breakdown then
-				breakdown["ConfiguredDamageChance"] = {
-					s_format("%.2f ^8(chance for block to fail)", 1 - BlockChance)
-				}
-				if output.ShowBlockEffect then
-					t_insert(breakdown["ConfiguredDamageChance"], s_format("x %.2f ^8(block effect)", output.BlockEffect / 100))
-				end
-				if suppressionEffect > 0 then
-					t_insert(breakdown["ConfiguredDamageChance"], s_format("x %.3f ^8(suppression effect)", suppressionEffect))
-				end
-				if averageAvoidChance > 0 then
-					t_insert(breakdown["ConfiguredDamageChance"], s_format("x %.2f ^8(chance for avoidance to fail)", 1 - averageAvoidChance / 100))
-				end
-				t_insert(breakdown["ConfiguredDamageChance"], s_format("= %.1f%% ^8(of damage taken from a%s hit)", output["ConfiguredDamageChance"], (damageCategoryConfig == "Average" and "n " or " ")..damageCategoryConfig))
-			end
-		end
-	*/
-	/*
-		TODO --chance to not be hit
-		do
-			local worstOf = env.configInput.EHPUnluckyWorstOf or 1
-			output.MeleeNotHitChance = 100 - (1 - output.MeleeEvadeChance / 100) * (1 - output.AttackDodgeChance / 100) * 100
-			output.ProjectileNotHitChance = 100 - (1 - output.ProjectileEvadeChance / 100) * (1 - output.AttackDodgeChance / 100) * 100
-			output.SpellNotHitChance = 100 - (1 - output.SpellDodgeChance / 100) * 100
-			output.SpellProjectileNotHitChance = output.SpellNotHitChance
-			output.AverageNotHitChance = (output.MeleeNotHitChance + output.ProjectileNotHitChance + output.SpellNotHitChance + output.SpellProjectileNotHitChance) / 4
-			output.ConfiguredNotHitChance = output[damageCategoryConfig.."NotHitChance"]
-			--unlucky config to lower the value of block, dodge, evade etc for ehp
-			if worstOf > 1 then
-				output.ConfiguredNotHitChance = output.ConfiguredNotHitChance / 100 * output.ConfiguredNotHitChance
-				if worstOf == 4 then
-					output.ConfiguredNotHitChance = output.ConfiguredNotHitChance / 100 * output.ConfiguredNotHitChance
-				end
-			end
-			output["TotalNumberOfHits"] = output["NumberOfMitigatedDamagingHits"] / (1 - output["ConfiguredNotHitChance"] / 100)
-			if breakdown then
-				breakdown.ConfiguredNotHitChance = { }
-				if damageCategoryConfig == "Melee" or damageCategoryConfig == "Projectile" then
-					t_insert(breakdown["ConfiguredNotHitChance"], s_format("%.2f ^8(chance for evasion to fail)", 1 - output[damageCategoryConfig.."EvadeChance"] / 100))
-					t_insert(breakdown["ConfiguredNotHitChance"], s_format("x %.2f ^8(chance for dodge to fail)", 1 - output.AttackDodgeChance / 100))
-				elseif damageCategoryConfig == "Spell" or damageCategoryConfig == "SpellProjectile" then
-					t_insert(breakdown["ConfiguredNotHitChance"], s_format("%.2f ^8(chance for dodge to fail)", 1 - output.SpellDodgeChance / 100))
-				elseif damageCategoryConfig == "Average" then
-					t_insert(breakdown["ConfiguredNotHitChance"], s_format("%.2f ^8(chance for evasion to fail, only applies to the attack portion)", 1 - (output.MeleeEvadeChance + output.ProjectileEvadeChance) / 2 / 100))
-					t_insert(breakdown["ConfiguredNotHitChance"], s_format("x%.2f ^8(chance for dodge to fail)", 1 - (output.AttackDodgeChance + output.SpellDodgeChance) / 2 / 100))
-				end
-				if worstOf > 1 then
-					t_insert(breakdown["ConfiguredNotHitChance"], s_format("unlucky worst of %d", worstOf))
-				end
-				t_insert(breakdown["ConfiguredNotHitChance"], s_format("= %d%% ^8(chance to be hit by a%s hit)", 100 - output.ConfiguredNotHitChance, (damageCategoryConfig == "Average" and "n " or " ")..damageCategoryConfig))
-				breakdown["TotalNumberOfHits"] = {
-					s_format("%.2f ^8(Number of mitigated hits)", output["NumberOfMitigatedDamagingHits"]),
-					s_format("/ %.2f ^8(Chance to even be hit)", 1 - output["ConfiguredNotHitChance"] / 100),
-					s_format("= %.2f ^8(total average number of hits you can take)", output["TotalNumberOfHits"]),
+		for _, hitType := range hitSourceList {
+			baseTakenIncType := takenInc + actor.ModDB.Sum(mod.TypeIncrease, nil, hitType+"DamageTaken")
+			baseTakenMoreType := takenMore * actor.ModDB.More(nil, hitType+"DamageTaken")
+			actor.Output[hitType+"TakenHitMult"] = math.Max((1+baseTakenIncType/100)*baseTakenMoreType, 0)
+			actor.Output[string(damageType)+hitType+"TakenHitMult"] = actor.Output[hitType+"TakenHitMult"]
+		}
+
+		// Reflect
+		reflectInc := takenInc + actor.ModDB.Sum(mod.TypeIncrease, nil, string(damageType)+"ReflectedDamageTaken")
+		reflectMore := takenMore * actor.ModDB.More(nil, string(damageType)+"ReflectedDamageTaken")
+		if damageType.IsElemental() {
+			reflectInc += actor.ModDB.Sum(mod.TypeIncrease, nil, "ElementalReflectedDamageTaken")
+			reflectMore *= actor.ModDB.More(nil, "ElementalReflectedDamageTaken")
+		}
+		actor.Output[string(damageType)+"TakenReflect"] = math.Max((1+reflectInc/100)*reflectMore, 0)
+
+		// Dot
+		dotInc := baseTakenInc + actor.ModDB.Sum(mod.TypeIncrease, nil, "DamageTakenOverTime", string(damageType)+"DamageTakenOverTime")
+		dotMore := baseTakenMore * actor.ModDB.More(nil, "DamageTakenOverTime", string(damageType)+"DamageTakenOverTime")
+		if damageType.IsElemental() {
+			dotInc += actor.ModDB.Sum(mod.TypeIncrease, nil, "ElementalDamageTakenOverTime")
+			dotMore *= actor.ModDB.More(nil, "ElementalDamageTakenOverTime")
+		}
+		resist := actor.Output[string(damageType)+"Resist"]
+		if actor.ModDB.Flag(nil, "SelfIgnore"+string(damageType)+"Resistance") {
+			resist = 0
+		}
+		resistLabel := "resistance"
+		if damageType == data.DamageTypePhysical {
+			resist = math.Max(resist, 0)
+			resistLabel = "physical damage reduction"
+		}
+		actor.Output[string(damageType)+"TakenDotMult"] = (1 - resist/100) * (1 + dotInc/100) * dotMore
+		if actor.Breakdown != nil {
+			actor.Breakdown.Set(string(damageType)+"TakenDotMult", actor.Output[string(damageType)+"TakenDotMult"],
+				fmt.Sprintf("%.2f (%s)", 1-resist/100, resistLabel),
+				fmt.Sprintf("x %.2f (increased/reduced damage taken)", 1+dotInc/100),
+				fmt.Sprintf("x %.2f (more/less damage taken)", dotMore),
+				fmt.Sprintf("= %.2f", actor.Output[string(damageType)+"TakenDotMult"]),
+			)
+		}
+	}
+
+	// Incoming hit damage multipliers
+	actor.Output["totalTakenHit"] = 0
+	totalTakenHitLines := make([]string, 0, len(dmgTypeList))
+	for _, damageType := range dmgTypeList {
+		// Calculate incoming damage multiplier
+		resist, ok := actor.Output[string(damageType)+"ResistWhenHit"]
+		if !ok {
+			resist = actor.Output[string(damageType)+"Resist"]
+		}
+		enemyPen := actor.Output[string(damageType)+"EnemyPen"]
+		if actor.ModDB.Flag(nil, "SelfIgnore"+string(damageType)+"Resistance") {
+			resist = 0
+			enemyPen = 0
+		}
+
+		takenFlat := actor.ModDB.Sum(mod.TypeBase, nil, "DamageTaken", string(damageType)+"DamageTaken", "DamageTakenWhenHit", string(damageType)+"DamageTakenWhenHit")
+		switch damageCategoryConfig {
+		case "Melee", "Projectile":
+			takenFlat += actor.ModDB.Sum(mod.TypeBase, nil, "DamageTakenFromAttacks", string(damageType)+"DamageTakenFromAttacks")
+		case "Average":
+			takenFlat += actor.ModDB.Sum(mod.TypeBase, nil, "DamageTakenFromAttacks", string(damageType)+"DamageTakenFromAttacks") / 2
+		}
+
+		if damageType == data.DamageTypePhysical || actor.ModDB.Flag(nil, "ArmourAppliesTo"+string(damageType)+"DamageTaken") {
+			damage := actor.Output[string(damageType)+"TakenDamage"]
+			armourReduct := float64(0)
+			portionArmour := float64(100)
+			if damageType == data.DamageTypePhysical {
+				if !actor.ModDB.Flag(nil, "ArmourDoesNotApplyToPhysicalDamageTaken") {
+					armourReduct = CalcArmourReduction(actor.Output["Armour"]*(1+actor.Output["ArmourDefense"]), damage)
+					armourReduct = math.Max(math.Min(actor.Output["DamageReductionMax"], resist-enemyPen+armourReduct), 0)
+					resist = armourReduct
 				}
-			end
-		end
-	*/
-	/*
-		TODO --effective hit pool
-		output["TotalEHP"] = output["TotalNumberOfHits"] * output["totalEnemyDamageIn"]
-		if breakdown then
-			breakdown["TotalEHP"] = {
-				s_format("%.2f ^8(total average number of hits you can take)", output["TotalNumberOfHits"]),
-				s_format("x %d ^8(total incoming damage)", output["totalEnemyDamageIn"]),
-				s_format("= %d ^8(total damage you can take)", output["TotalEHP"]),
+			} else {
+				portionArmour = 100 - (resist - enemyPen)
+				armourReduct = CalcArmourReduction(actor.Output["Armour"]*(1+actor.Output["ArmourDefense"]), damage*portionArmour/100)
+				armourReduct = math.Min(actor.Output["DamageReductionMax"], armourReduct)
+				resist += armourReduct * portionArmour / 100
 			}
-		end
-	*/
-	/*
-		TODO --survival time
-		do
-			local enemySkillTime = env.configInput.enemySpeed or env.configPlaceholder.enemySpeed or 700
-			local enemyActionSpeed = calcs.actionSpeedMod(actor.enemy)
-			enemySkillTime = enemySkillTime / 1000 / enemyActionSpeed
-			output["EHPsurvivalTime"] = output["TotalNumberOfHits"] * enemySkillTime
-			if breakdown then
-				breakdown["EHPsurvivalTime"] = {
-					s_format("%.2f ^8(total average number of hits you can take)", output["TotalNumberOfHits"]),
-					s_format("x %.2f ^8enemy attack/cast time", enemySkillTime),
-					s_format("= %.2f seconds ^8(total time it would take to die)", output["EHPsurvivalTime"]),
-				}
-			end
-		end
-	*/
-	/*
-		TODO --petrified blood "degen"
-		if output.preventedLifeLoss > 0 then
-			local LifeLossBelowHalfLost = modDB:Sum("BASE", nil, "LifeLossBelowHalfLost") / 100
-			output["LifeLossBelowHalfLostMax"] = output["LifeLossBelowHalfLost"] * LifeLossBelowHalfLost / 4
-			output["LifeLossBelowHalfLostAvg"] = output["LifeLossBelowHalfLost"] * LifeLossBelowHalfLost / (output["EHPsurvivalTime"] + 4)
-			if breakdown then
-				breakdown["LifeLossBelowHalfLostMax"] = {
-					s_format("%d ^8(total damage prevented by petrified blood)", output["LifeLossBelowHalfLost"]),
-					s_format("* %.2f ^8(percent of damage taken)", LifeLossBelowHalfLost),
-					s_format("/ %.2f ^8(over 4 seconds)", 4),
-					s_format("= %.2f per second", output["LifeLossBelowHalfLostMax"]),
-				}
-				breakdown["LifeLossBelowHalfLostAvg"] = {
-					s_format("%d ^8(total damage prevented by petrified blood)", output["LifeLossBelowHalfLost"]),
-					s_format("* %.2f ^8(percent of damage taken)", LifeLossBelowHalfLost),
-					s_format("/ %.2f ^8(total time of the degen (survival time + 4))", (output["EHPsurvivalTime"] + 4)),
-					s_format("= %.2f per second", output["LifeLossBelowHalfLostAvg"]),
+
+			actor.Output[string(damageType)+"DamageReduction"] = armourReduct
+			if portionArmour < 100 {
+				actor.Output[string(damageType)+"DamageReduction"] = armourReduct * portionArmour / 100
+			}
+			if actor.Breakdown != nil {
+				lines := []string{fmt.Sprintf("Enemy Hit Damage: %.0f (total incoming damage)", damage)}
+				if portionArmour > 100 {
+					lines = append(lines, fmt.Sprintf("* %.2f (from resistance, applies before armour)", portionArmour/100))
+				} else if portionArmour < 100 {
+					lines = append(lines, fmt.Sprintf("Portion mitigated by Armour: %.0f%%", portionArmour))
 				}
-			end
+				lines = append(lines, fmt.Sprintf("Reduction from Armour: %.0f%%", armourReduct))
+				actor.Breakdown.Set(string(damageType)+"DamageReduction", actor.Output[string(damageType)+"DamageReduction"], lines...)
+			}
+		}
 
-		end
-	*/
-	/*
-		TODO --effective health pool vs dots
-		for _, damageType in ipairs(dmgTypeList) do
-			output[damageType.."DotEHP"] = output[damageType.."TotalPool"] / output[damageType.."TakenDotMult"]
-			if breakdown then
-				breakdown[damageType.."DotEHP"] = {
-					s_format("Total Pool: %d", output[damageType.."TotalPool"]),
-					s_format("Dot Damage Taken modifier: %.2f", output[damageType.."TakenDotMult"]),
-					s_format("Total Effective Dot Pool: %d", output[damageType.."DotEHP"]),
+		takenMult := actor.Output[string(damageType)+"TakenHitMult"]
+		switch damageCategoryConfig {
+		case "Melee", "Projectile":
+			takenMult = actor.Output[string(damageType)+"AttackTakenHitMult"]
+		case "Spell", "SpellProjectile":
+			takenMult = actor.Output[string(damageType)+"SpellTakenHitMult"]
+		case "Average":
+			takenMult = (actor.Output[string(damageType)+"SpellTakenHitMult"] + actor.Output[string(damageType)+"AttackTakenHitMult"]) / 2
+		}
+
+		actor.Output[string(damageType)+"BaseTakenHitMult"] = (1 - (resist-enemyPen)/100) * takenMult
+		actor.Output[string(damageType)+"TakenHit"] = math.Max(actor.Output[string(damageType)+"TakenDamage"]*(1-(resist-enemyPen)/100)+takenFlat, 0) * takenMult
+		actor.Output[string(damageType)+"TakenHitMult"] = 0
+		if actor.Output[string(damageType)+"TakenDamage"] > 0 {
+			actor.Output[string(damageType)+"TakenHitMult"] = actor.Output[string(damageType)+"TakenHit"] / actor.Output[string(damageType)+"TakenDamage"]
+		}
+		actor.Output["totalTakenHit"] += actor.Output[string(damageType)+"TakenHit"]
+		if actor.Breakdown != nil {
+			lines := []string{fmt.Sprintf("Resistance: %.2f", 1-resist/100)}
+			if enemyPen > 0 {
+				lines = append(lines, fmt.Sprintf("Enemy Pen: %.2f", enemyPen))
+			}
+			lines = append(lines,
+				fmt.Sprintf("+ Flat: %.3f", takenFlat),
+				fmt.Sprintf("x Taken: %.3f", takenMult),
+				fmt.Sprintf("= %.3f", actor.Output[string(damageType)+"TakenHitMult"]),
+			)
+			actor.Breakdown.Set(string(damageType)+"TakenHitMult", actor.Output[string(damageType)+"TakenHitMult"], lines...)
+			actor.Breakdown.Set(string(damageType)+"TakenHit", actor.Output[string(damageType)+"TakenHit"],
+				fmt.Sprintf("Final %s Damage taken:", damageType),
+				fmt.Sprintf("%.1f incoming damage", actor.Output[string(damageType)+"TakenDamage"]),
+				fmt.Sprintf("x %.3f damage mult", actor.Output[string(damageType)+"TakenHitMult"]),
+				fmt.Sprintf("= %.1f", actor.Output[string(damageType)+"TakenHit"]),
+			)
+			totalTakenHitLines = append(totalTakenHitLines, fmt.Sprintf("%s: %.1f incoming damage x %.3f damage mult = %.0f", damageType, actor.Output[string(damageType)+"TakenDamage"], actor.Output[string(damageType)+"TakenHitMult"], actor.Output[string(damageType)+"TakenHit"]))
+		}
+	}
+	if actor.Breakdown != nil {
+		actor.Breakdown.Set("totalTakenHit", actor.Output["totalTakenHit"], totalTakenHitLines...)
+	}
+
+	// Life Recoverable
+	lowLife := env.configBool("conditionLowLife")
+	actor.Output["LifeRecoverable"] = actor.Output["LifeUnreserved"]
+	if lowLife {
+		actor.Output["LifeRecoverable"] = math.Min(actor.Output["Life"]*data.LowPoolThreshold, actor.Output["LifeUnreserved"])
+	}
+
+	// Prevented life loss (Petrified Blood)
+	actor.Output["preventedLifeLoss"] = actor.ModDB.Sum(mod.TypeBase, nil, "LifeLossBelowHalfPrevented")
+	portionLife := float64(1)
+	if !lowLife {
+		// Portion of life that is low life
+		portionLife = math.Min(actor.Output["Life"]*data.LowPoolThreshold/actor.Output["LifeRecoverable"], 1)
+		actor.Output["preventedLifeLoss"] *= portionLife
+	}
+	if actor.Breakdown != nil && actor.Output["preventedLifeLoss"] > 0 {
+		lines := []string{"Total life protected:"}
+		if portionLife != 1 {
+			lines = append(lines,
+				fmt.Sprintf("%.2f (initial portion taken from petrified blood)", actor.Output["preventedLifeLoss"]/portionLife/100),
+				fmt.Sprintf("* %.2f (portion of life on low life)", portionLife),
+				fmt.Sprintf("= %.2f (final portion taken from petrified blood)", actor.Output["preventedLifeLoss"]/100),
+			)
+		} else {
+			lines = append(lines, fmt.Sprintf("%.2f (portion taken from petrified blood)", actor.Output["preventedLifeLoss"]/100))
+		}
+		lines = append(lines, fmt.Sprintf("%.2f (portion taken from life)", 1-actor.Output["preventedLifeLoss"]/100))
+		actor.Breakdown.Set("preventedLifeLoss", actor.Output["preventedLifeLoss"], lines...)
+	}
+
+	// Energy Shield bypass
+	actor.Output["MinimumBypass"] = 100
+	for _, damageType := range dmgTypeList {
+		bypass := float64(100)
+		if !actor.ModDB.Flag(nil, "UnblockedDamageDoesBypassES") {
+			bypass = actor.ModDB.Sum(mod.TypeBase, nil, string(damageType)+"EnergyShieldBypass")
+			if damageType == data.DamageTypeChaos && !actor.ModDB.Flag(nil, "ChaosNotBypassEnergyShield") {
+				bypass += 100
+			}
+		}
+		actor.Output[string(damageType)+"EnergyShieldBypass"] = math.Max(math.Min(bypass, 100), 0)
+		actor.Output["MinimumBypass"] = math.Min(actor.Output["MinimumBypass"], actor.Output[string(damageType)+"EnergyShieldBypass"])
+	}
+
+	// Mind over Matter
+	energyShieldProtectsMana := actor.ModDB.Flag(nil, "EnergyShieldProtectsMana")
+
+	// manaEffectiveLife returns the life pool extended by the mana and energy shield that protect it
+	manaEffectiveLife := func(stat string, mindOverMatter float64, bypass float64) float64 {
+		sourcePool := math.Max(actor.Output["ManaUnreserved"], 0)
+		manaText := "unreserved mana"
+		if energyShieldProtectsMana && bypass < 100 {
+			manaText += " + non-bypassed energy shield"
+			if bypass > 0 {
+				manaProtected := actor.Output["EnergyShieldRecoveryCap"] / (1 - bypass/100) * (bypass / 100)
+				sourcePool = math.Max(sourcePool-manaProtected, 0) + math.Min(sourcePool, manaProtected)/(bypass/100)
+			} else {
+				sourcePool += actor.Output["EnergyShieldRecoveryCap"]
+			}
+		}
+
+		poolProtected := sourcePool / (mindOverMatter / 100) * (1 - mindOverMatter/100)
+		effectiveLife := math.Max(actor.Output["LifeRecoverable"]-poolProtected, 0) + math.Min(actor.Output["LifeRecoverable"], poolProtected)/(1-mindOverMatter/100)
+		if mindOverMatter >= 100 {
+			poolProtected = math.Inf(1)
+			effectiveLife = actor.Output["LifeRecoverable"] + sourcePool
+		}
+
+		if actor.Breakdown != nil {
+			actor.Breakdown.Set(stat, mindOverMatter,
+				"Total life protected:",
+				fmt.Sprintf("%.0f (%s)", sourcePool, manaText),
+				fmt.Sprintf("/ %.2f (portion taken from mana)", mindOverMatter/100),
+				fmt.Sprintf("x %.2f (portion taken from life)", 1-mindOverMatter/100),
+				fmt.Sprintf("= %.0f", poolProtected),
+				fmt.Sprintf("Effective life: %.0f", effectiveLife),
+			)
+		}
+		return effectiveLife
+	}
+
+	actor.Output["sharedMindOverMatter"] = math.Min(actor.ModDB.Sum(mod.TypeBase, nil, "DamageTakenFromManaBeforeLife"), 100)
+	actor.Output["sharedManaEffectiveLife"] = actor.Output["LifeRecoverable"]
+	if actor.Output["sharedMindOverMatter"] > 0 {
+		actor.Output["sharedManaEffectiveLife"] = manaEffectiveLife("sharedMindOverMatter", actor.Output["sharedMindOverMatter"], actor.Output["MinimumBypass"])
+	}
+	for _, damageType := range dmgTypeList {
+		actor.Output[string(damageType)+"MindOverMatter"] = math.Min(actor.ModDB.Sum(mod.TypeBase, nil, string(damageType)+"DamageTakenFromManaBeforeLife"), 100-actor.Output["sharedMindOverMatter"])
+		actor.Output[string(damageType)+"ManaEffectiveLife"] = actor.Output["sharedManaEffectiveLife"]
+		if actor.Output[string(damageType)+"MindOverMatter"] > 0 || (actor.Output[string(damageType)+"EnergyShieldBypass"] > actor.Output["MinimumBypass"] && actor.Output["sharedMindOverMatter"] > 0) {
+			mindOverMatter := actor.Output[string(damageType)+"MindOverMatter"] + actor.Output["sharedMindOverMatter"]
+			actor.Output[string(damageType)+"ManaEffectiveLife"] = manaEffectiveLife(string(damageType)+"MindOverMatter", mindOverMatter, actor.Output[string(damageType)+"EnergyShieldBypass"])
+		}
+	}
+
+	// Guard
+	// guardLifeProtected returns the life and energy shield protected by a guard skill
+	guardLifeProtected := func(stat string, absorb float64, absorbRate float64) {
+		if actor.Breakdown != nil {
+			lifeProtected := absorb / (absorbRate / 100) * (1 - absorbRate/100)
+			actor.Breakdown.Set(stat, absorb,
+				"Total life protected:",
+				fmt.Sprintf("%.0f (guard limit)", absorb),
+				fmt.Sprintf("/ %.2f (portion taken from guard)", absorbRate/100),
+				fmt.Sprintf("x %.2f (portion taken from life and energy shield)", 1-absorbRate/100),
+				fmt.Sprintf("= %.0f", lifeProtected),
+			)
+		}
+	}
+
+	actor.Output["sharedGuardAbsorbRate"] = math.Min(actor.ModDB.Sum(mod.TypeBase, nil, "GuardAbsorbRate"), 100)
+	if actor.Output["sharedGuardAbsorbRate"] > 0 {
+		actor.Output["sharedGuardAbsorb"] = CalcVal(actor.ModDB, "GuardAbsorbLimit", nil)
+		guardLifeProtected("sharedGuardAbsorb", actor.Output["sharedGuardAbsorb"], actor.Output["sharedGuardAbsorbRate"])
+	}
+	for _, damageType := range dmgTypeList {
+		actor.Output[string(damageType)+"GuardAbsorbRate"] = math.Min(actor.ModDB.Sum(mod.TypeBase, nil, string(damageType)+"GuardAbsorbRate"), 100)
+		if actor.Output[string(damageType)+"GuardAbsorbRate"] > 0 {
+			actor.Output[string(damageType)+"GuardAbsorb"] = CalcVal(actor.ModDB, string(damageType)+"GuardAbsorbLimit", nil)
+			guardLifeProtected(string(damageType)+"GuardAbsorb", actor.Output[string(damageType)+"GuardAbsorb"], actor.Output[string(damageType)+"GuardAbsorbRate"])
+		}
+	}
+
+	// Aegis
+	actor.Output["sharedAegis"], _ = actor.ModDB.Max(nil, "AegisValue")
+	actor.Output["sharedElementalAegis"], _ = actor.ModDB.Max(nil, "ElementalAegisValue")
+	for _, damageType := range dmgTypeList {
+		actor.Output[string(damageType)+"Aegis"], _ = actor.ModDB.Max(nil, string(damageType)+"AegisValue")
+		if damageType.IsElemental() {
+			actor.Output[string(damageType)+"AegisDisplay"] = actor.Output[string(damageType)+"Aegis"] + actor.Output["sharedElementalAegis"]
+		}
+	}
+
+	// Frost shield
+	actor.Output["FrostShieldLife"] = actor.ModDB.Sum(mod.TypeBase, nil, "FrostGlobeHealth")
+	actor.Output["FrostShieldDamageMitigation"] = actor.ModDB.Sum(mod.TypeBase, nil, "FrostGlobeDamageMitigation")
+	if actor.Breakdown != nil && actor.Output["FrostShieldLife"] > 0 {
+		lifeProtected := actor.Output["FrostShieldLife"] / (actor.Output["FrostShieldDamageMitigation"] / 100) * (1 - actor.Output["FrostShieldDamageMitigation"]/100)
+		actor.Breakdown.Set("FrostShieldLife", actor.Output["FrostShieldLife"],
+			"Total life protected:",
+			fmt.Sprintf("%.0f (frost shield limit)", actor.Output["FrostShieldLife"]),
+			fmt.Sprintf("/ %.2f (portion taken from frost shield)", actor.Output["FrostShieldDamageMitigation"]/100),
+			fmt.Sprintf("x %.2f (portion taken from life and energy shield)", 1-actor.Output["FrostShieldDamageMitigation"]/100),
+			fmt.Sprintf("= %.0f", lifeProtected),
+		)
+	}
+
+	// Total pool
+	for _, damageType := range dmgTypeList {
+		totalPool := actor.Output[string(damageType)+"ManaEffectiveLife"]
+		bypass := actor.Output[string(damageType)+"EnergyShieldBypass"]
+		manaText := "Mana"
+		if bypass < 100 {
+			if energyShieldProtectsMana {
+				manaText += " and non-bypassed Energy Shield"
+			} else if bypass > 0 {
+				poolProtected := actor.Output["EnergyShieldRecoveryCap"] / (1 - bypass/100) * (bypass / 100)
+				totalPool = math.Max(totalPool-poolProtected, 0) + math.Min(totalPool, poolProtected)/(bypass/100)
+			} else {
+				totalPool += actor.Output["EnergyShieldRecoveryCap"]
+			}
+		}
+		actor.Output[string(damageType)+"TotalPool"] = totalPool
+
+		if actor.Breakdown != nil {
+			lines := []string{fmt.Sprintf("Life: %.0f", actor.Output["LifeRecoverable"])}
+			if actor.Output[string(damageType)+"ManaEffectiveLife"] != actor.Output["LifeRecoverable"] {
+				lines = append(lines, fmt.Sprintf("%s through MoM: %.0f", manaText, actor.Output[string(damageType)+"ManaEffectiveLife"]-actor.Output["LifeRecoverable"]))
+			}
+			if !energyShieldProtectsMana && bypass < 100 {
+				lines = append(lines, fmt.Sprintf("Non-bypassed Energy Shield: %.0f", totalPool-actor.Output[string(damageType)+"ManaEffectiveLife"]))
+			}
+			lines = append(lines, fmt.Sprintf("TotalPool: %.0f", totalPool))
+			actor.Breakdown.Set(string(damageType)+"TotalPool", totalPool, lines...)
+		}
+	}
+
+	// Number of damaging hits needed to be taken to die
+	{
+		damageIn := &ehpDamage{Damage: make(map[data.DamageType]float64)}
+		for _, damageType := range dmgTypeList {
+			damageIn.Damage[damageType] = actor.Output[string(damageType)+"TakenHit"]
+		}
+		actor.Output["NumberOfDamagingHits"] = numberOfHitsToDie(actor, damageIn)
+	}
+
+	worstOf := float64(1)
+	if value, ok := env.configNumber("EHPUnluckyWorstOf"); ok {
+		worstOf = value
+	}
+
+	// unluckyChance lowers the value of block, dodge, evade etc. for the unlucky configuration
+	unluckyChance := func(chance float64, scale float64) float64 {
+		if worstOf > 1 {
+			chance = chance / scale * chance
+			if worstOf == 4 {
+				chance = chance / scale * chance
+			}
+		}
+		return chance
+	}
+
+	{
+		damageIn := &ehpDamage{
+			Damage:             make(map[data.DamageType]float64),
+			EnergyShieldBypass: make(map[data.DamageType]float64),
+		}
+
+		// Block effect
+		blockChance := actor.Output["BlockChance"] / 100
+		if damageCategoryConfig != "Melee" {
+			blockChance = actor.Output[damageCategoryConfig+"BlockChance"] / 100
+		}
+		blockChance = unluckyChance(blockChance, 1)
+		blockEffect := 1 - blockChance*actor.Output["BlockEffect"]/100
+		gainOnBlock := !env.configBool("DisableEHPGainOnBlock")
+		if gainOnBlock {
+			damageIn.LifeWhenHit = actor.Output["LifeOnBlock"] * blockChance
+			damageIn.ManaWhenHit = actor.Output["ManaOnBlock"] * blockChance
+			damageIn.EnergyShieldWhenHit = actor.Output["EnergyShieldOnBlock"] * blockChance
+			if damageCategoryConfig == "Spell" || damageCategoryConfig == "SpellProjectile" {
+				damageIn.EnergyShieldWhenHit += actor.Output["EnergyShieldOnSpellBlock"] * blockChance
+			} else if damageCategoryConfig == "Average" {
+				damageIn.EnergyShieldWhenHit += actor.Output["EnergyShieldOnSpellBlock"] / 2 * blockChance
+			}
+			damageIn.GainWhenHit = damageIn.LifeWhenHit != 0 || damageIn.ManaWhenHit != 0 || damageIn.EnergyShieldWhenHit != 0
+		}
+
+		// Suppression
+		suppressChance := float64(0)
+		if damageCategoryConfig == "Spell" || damageCategoryConfig == "SpellProjectile" || damageCategoryConfig == "Average" {
+			suppressChance = actor.Output["SpellSuppressionChance"] / 100
+		}
+		suppressChance = unluckyChance(suppressChance, 1)
+		if damageCategoryConfig == "Average" {
+			suppressChance /= 2
+		}
+		suppressionEffect := 1 - suppressChance*actor.Output["SpellSuppressionEffect"]/100
+
+		// Extra avoid chance
+		extraAvoidChance := float64(0)
+		if damageCategoryConfig == "Projectile" || damageCategoryConfig == "SpellProjectile" {
+			extraAvoidChance += actor.Output["AvoidProjectilesChance"]
+		} else if damageCategoryConfig == "Average" {
+			extraAvoidChance += actor.Output["AvoidProjectilesChance"] / 2
+		}
+
+		averageAvoidChance := float64(0)
+		for _, damageType := range dmgTypeList {
+			// Emperor's Vigilance (this needs to fail with divine flesh as it cant override it, hence the check for high bypass)
+			if actor.ModDB.Flag(nil, "BlockedDamageDoesntBypassES") && actor.Output[string(damageType)+"EnergyShieldBypass"] < 100 && damageType != data.DamageTypeChaos {
+				damageIn.EnergyShieldBypass[damageType] = actor.Output[string(damageType)+"EnergyShieldBypass"] * (1 - blockChance)
+			}
+			avoidChance := unluckyChance(math.Min(actor.Output["Avoid"+string(damageType)+"DamageChance"]+extraAvoidChance, data.AvoidChanceCap), 100)
+			averageAvoidChance += avoidChance
+			damageIn.Damage[damageType] = actor.Output[string(damageType)+"TakenHit"] * (blockEffect * suppressionEffect * (1 - avoidChance/100))
+		}
+
+		// Petrified blood degen initialisation
+		if actor.Output["preventedLifeLoss"] > 0 {
+			actor.Output["LifeLossBelowHalfLost"] = 0
+			damageIn.LifeLossBelowHalfLost = actor.ModDB.Sum(mod.TypeBase, nil, "LifeLossBelowHalfLost") / 100
+		}
+
+		actor.Output["NumberOfMitigatedDamagingHits"] = numberOfHitsToDie(actor, damageIn)
+		averageAvoidChance /= float64(len(dmgTypeList))
+		actor.Output["ConfiguredDamageChance"] = 100 * (blockEffect * suppressionEffect * (1 - averageAvoidChance/100))
+		if actor.Breakdown != nil {
+			lines := []string{fmt.Sprintf("%.2f (chance for block to fail)", 1-blockChance)}
+			if actor.Output["ShowBlockEffect"] != 0 {
+				lines = append(lines, fmt.Sprintf("x %.2f (block effect)", actor.Output["BlockEffect"]/100))
+			}
+			if suppressionEffect > 0 {
+				lines = append(lines, fmt.Sprintf("x %.3f (suppression effect)", suppressionEffect))
+			}
+			if averageAvoidChance > 0 {
+				lines = append(lines, fmt.Sprintf("x %.2f (chance for avoidance to fail)", 1-averageAvoidChance/100))
+			}
+			lines = append(lines, fmt.Sprintf("= %.1f%% (of damage taken from a %s hit)", actor.Output["ConfiguredDamageChance"], damageCategoryConfig))
+			actor.Breakdown.Set("ConfiguredDamageChance", actor.Output["ConfiguredDamageChance"], lines...)
+		}
+	}
+
+	// Chance to not be hit
+	actor.Output["MeleeNotHitChance"] = 100 - (1-actor.Output["MeleeEvadeChance"]/100)*(1-actor.Output["AttackDodgeChance"]/100)*100
+	actor.Output["ProjectileNotHitChance"] = 100 - (1-actor.Output["ProjectileEvadeChance"]/100)*(1-actor.Output["AttackDodgeChance"]/100)*100
+	actor.Output["SpellNotHitChance"] = 100 - (1-actor.Output["SpellDodgeChance"]/100)*100
+	actor.Output["SpellProjectileNotHitChance"] = actor.Output["SpellNotHitChance"]
+	actor.Output["AverageNotHitChance"] = (actor.Output["MeleeNotHitChance"] + actor.Output["ProjectileNotHitChance"] + actor.Output["SpellNotHitChance"] + actor.Output["SpellProjectileNotHitChance"]) / 4
+	actor.Output["ConfiguredNotHitChance"] = unluckyChance(actor.Output[damageCategoryConfig+"NotHitChance"], 100)
+	actor.Output["TotalNumberOfHits"] = actor.Output["NumberOfMitigatedDamagingHits"] / (1 - actor.Output["ConfiguredNotHitChance"]/100)
+	if actor.Breakdown != nil {
+		var lines []string
+		switch damageCategoryConfig {
+		case "Melee", "Projectile":
+			lines = append(lines,
+				fmt.Sprintf("%.2f (chance for evasion to fail)", 1-actor.Output[damageCategoryConfig+"EvadeChance"]/100),
+				fmt.Sprintf("x %.2f (chance for dodge to fail)", 1-actor.Output["AttackDodgeChance"]/100),
+			)
+		case "Spell", "SpellProjectile":
+			lines = append(lines, fmt.Sprintf("%.2f (chance for dodge to fail)", 1-actor.Output["SpellDodgeChance"]/100))
+		case "Average":
+			lines = append(lines,
+				fmt.Sprintf("%.2f (chance for evasion to fail, only applies to the attack portion)", 1-(actor.Output["MeleeEvadeChance"]+actor.Output["ProjectileEvadeChance"])/2/100),
+				fmt.Sprintf("x %.2f (chance for dodge to fail)", 1-(actor.Output["AttackDodgeChance"]+actor.Output["SpellDodgeChance"])/2/100),
+			)
+		}
+		if worstOf > 1 {
+			lines = append(lines, fmt.Sprintf("unlucky worst of %.0f", worstOf))
+		}
+		lines = append(lines, fmt.Sprintf("= %.0f%% (chance to be hit by a %s hit)", 100-actor.Output["ConfiguredNotHitChance"], damageCategoryConfig))
+		actor.Breakdown.Set("ConfiguredNotHitChance", actor.Output["ConfiguredNotHitChance"], lines...)
+		actor.Breakdown.Set("TotalNumberOfHits", actor.Output["TotalNumberOfHits"],
+			fmt.Sprintf("%.2f (number of mitigated hits)", actor.Output["NumberOfMitigatedDamagingHits"]),
+			fmt.Sprintf("/ %.2f (chance to even be hit)", 1-actor.Output["ConfiguredNotHitChance"]/100),
+			fmt.Sprintf("= %.2f (total average number of hits you can take)", actor.Output["TotalNumberOfHits"]),
+		)
+	}
+
+	// Effective hit pool
+	actor.Output["TotalEHP"] = actor.Output["TotalNumberOfHits"] * actor.Output["totalEnemyDamageIn"]
+	if actor.Breakdown != nil {
+		actor.Breakdown.Set("TotalEHP", actor.Output["TotalEHP"],
+			fmt.Sprintf("%.2f (total average number of hits you can take)", actor.Output["TotalNumberOfHits"]),
+			fmt.Sprintf("x %.0f (total incoming damage)", actor.Output["totalEnemyDamageIn"]),
+			fmt.Sprintf("= %.0f (total damage you can take)", actor.Output["TotalEHP"]),
+		)
+	}
+
+	// Survival time
+	enemySkillTime := float64(700)
+	if value, ok := env.configNumber("enemySpeed"); ok {
+		enemySkillTime = value
+	}
+	enemySkillTime = enemySkillTime / 1000 / CalcActionSpeedMod(actor.Enemy)
+	actor.Output["EHPsurvivalTime"] = actor.Output["TotalNumberOfHits"] * enemySkillTime
+	if actor.Breakdown != nil {
+		actor.Breakdown.Set("EHPsurvivalTime", actor.Output["EHPsurvivalTime"],
+			fmt.Sprintf("%.2f (total average number of hits you can take)", actor.Output["TotalNumberOfHits"]),
+			fmt.Sprintf("x %.2f enemy attack/cast time", enemySkillTime),
+			fmt.Sprintf("= %.2f seconds (total time it would take to die)", actor.Output["EHPsurvivalTime"]),
+		)
+	}
+
+	// Petrified blood "degen"
+	if actor.Output["preventedLifeLoss"] > 0 {
+		lifeLossBelowHalfLost := actor.ModDB.Sum(mod.TypeBase, nil, "LifeLossBelowHalfLost") / 100
+		actor.Output["LifeLossBelowHalfLostMax"] = actor.Output["LifeLossBelowHalfLost"] * lifeLossBelowHalfLost / 4
+		actor.Output["LifeLossBelowHalfLostAvg"] = actor.Output["LifeLossBelowHalfLost"] * lifeLossBelowHalfLost / (actor.Output["EHPsurvivalTime"] + 4)
+		if actor.Breakdown != nil {
+			actor.Breakdown.Set("LifeLossBelowHalfLostMax", actor.Output["LifeLossBelowHalfLostMax"],
+				fmt.Sprintf("%.0f (total damage prevented by petrified blood)", actor.Output["LifeLossBelowHalfLost"]),
+				fmt.Sprintf("* %.2f (percent of damage taken)", lifeLossBelowHalfLost),
+				"/ 4.00 (over 4 seconds)",
+				fmt.Sprintf("= %.2f per second", actor.Output["LifeLossBelowHalfLostMax"]),
+			)
+			actor.Breakdown.Set("LifeLossBelowHalfLostAvg", actor.Output["LifeLossBelowHalfLostAvg"],
+				fmt.Sprintf("%.0f (total damage prevented by petrified blood)", actor.Output["LifeLossBelowHalfLost"]),
+				fmt.Sprintf("* %.2f (percent of damage taken)", lifeLossBelowHalfLost),
+				fmt.Sprintf("/ %.2f (total time of the degen (survival time + 4))", actor.Output["EHPsurvivalTime"]+4),
+				fmt.Sprintf("= %.2f per second", actor.Output["LifeLossBelowHalfLostAvg"]),
+			)
+		}
+	}
+
+	// Effective health pool vs dots
+	for _, damageType := range dmgTypeList {
+		actor.Output[string(damageType)+"DotEHP"] = actor.Output[string(damageType)+"TotalPool"] / actor.Output[string(damageType)+"TakenDotMult"]
+		if actor.Breakdown != nil {
+			actor.Breakdown.Set(string(damageType)+"DotEHP", actor.Output[string(damageType)+"DotEHP"],
+				fmt.Sprintf("Total Pool: %.0f", actor.Output[string(damageType)+"TotalPool"]),
+				fmt.Sprintf("Dot Damage Taken modifier: %.2f", actor.Output[string(damageType)+"TakenDotMult"]),
+				fmt.Sprintf("Total Effective Dot Pool: %.0f", actor.Output[string(damageType)+"DotEHP"]),
+			)
+		}
+	}
+
+	// Degens
+	var totalDegenLines []string
+	hasDegen := false
+	for _, damageType := range dmgTypeList {
+		baseVal := actor.ModDB.Sum(mod.TypeBase, nil, string(damageType)+"Degen")
+		if baseVal > 0 {
+			total := baseVal * actor.Output[string(damageType)+"TakenDotMult"]
+			actor.Output[string(damageType)+"Degen"] = total
+			actor.Output["TotalDegen"] += total
+			hasDegen = true
+			if actor.Breakdown != nil {
+				line := fmt.Sprintf("%s: %.1f x %.2f = %.1f", damageType, baseVal, actor.Output[string(damageType)+"TakenDotMult"], total)
+				totalDegenLines = append(totalDegenLines, line)
+				actor.Breakdown.Set(string(damageType)+"Degen", total, line)
+			}
+		}
+	}
+	if hasDegen {
+		if actor.Breakdown != nil {
+			actor.Breakdown.Set("TotalDegen", actor.Output["TotalDegen"], totalDegenLines...)
+		}
+
+		totalLifeDegen, totalManaDegen, totalEnergyShieldDegen := float64(0), float64(0), float64(0)
+		var lifeDegenLines, manaDegenLines, energyShieldDegenLines []string
+		for _, damageType := range dmgTypeList {
+			degen, ok := actor.Output[string(damageType)+"Degen"]
+			if !ok {
+				continue
+			}
+
+			bypass := actor.Output[string(damageType)+"EnergyShieldBypass"] / 100
+			takenFromMana := (actor.Output[string(damageType)+"MindOverMatter"] + actor.Output["sharedMindOverMatter"]) / 100
+			lifeDegen, manaDegen, energyShieldDegen := float64(0), float64(0), float64(0)
+			if actor.Output["EnergyShieldRegen"] > 0 {
+				if actor.ModDB.Flag(nil, "EnergyShieldProtectsMana") {
+					lifeDegen = degen * (1 - takenFromMana)
+					energyShieldDegen = degen * (1 - bypass) * takenFromMana
+				} else {
+					lifeDegen = degen * bypass * (1 - takenFromMana)
+					energyShieldDegen = degen * (1 - bypass)
 				}
-			end
-		end
-	*/
-	/*
-		TODO -- Degens
-		for _, damageType in ipairs(dmgTypeList) do
-			local baseVal = modDB:Sum("BASE", nil, damageType.."Degen")
-			if baseVal > 0 then
-				local total = baseVal * output[damageType.."TakenDotMult"]
-				output[damageType.."Degen"] = total
-				output.TotalDegen = (output.TotalDegen or 0) + total
-				if breakdown then
-					breakdown.TotalDegen = breakdown.TotalDegen or {
-						rowList = { },
-						colList = {
-							{ label = "Type", key = "type" },
-							{ label = "Base", key = "base" },
-							{ label = "Multiplier", key = "mult" },
-							{ label = "Total", key = "total" },
-						}
-					}
-					t_insert(breakdown.TotalDegen.rowList, {
-						type = damageType,
-						base = s_format("%.1f", baseVal),
-						mult = s_format("x %.2f", output[damageType.."TakenDotMult"]),
-						total = s_format("%.1f", total),
-					})
-					breakdown[damageType.."Degen"] = {
-						rowList = { },
-						colList = {
-							{ label = "Type", key = "type" },
-							{ label = "Base", key = "base" },
-							{ label = "Multiplier", key = "mult" },
-							{ label = "Total", key = "total" },
-						}
-					}
-					t_insert(breakdown[damageType.."Degen"].rowList, {
-						type = damageType,
-						base = s_format("%.1f", baseVal),
-						mult = s_format("x %.2f", output[damageType.."TakenDotMult"]),
-						total = s_format("%.1f", total),
-					})
-				end
-			end
-		end
-		if output.TotalDegen then
-			output.NetLifeRegen = output.LifeRegen
-			output.NetManaRegen = output.ManaRegen
-			output.NetEnergyShieldRegen = output.EnergyShieldRegen
-			local totalLifeDegen = 0
-			local totalManaDegen = 0
-			local totalEnergyShieldDegen = 0
-			if breakdown then
-				breakdown.NetLifeRegen = {
-						label = "Total Life Degen",
-						rowList = { },
-						colList = {
-							{ label = "Type", key = "type" },
-							{ label = "Degen", key = "degen" },
-						},
-					}
-				breakdown.NetManaRegen = {
-						label = "Total Mana Degen",
-						rowList = { },
-						colList = {
-							{ label = "Type", key = "type" },
-							{ label = "Degen", key = "degen" },
-						},
-					}
-				breakdown.NetEnergyShieldRegen = {
-						label = "Total Energy Shield Degen",
-						rowList = { },
-						colList = {
-							{ label = "Type", key = "type" },
-							{ label = "Degen", key = "degen" },
-						},
-					}
-			end
-			for _, damageType in ipairs(dmgTypeList) do
-				if output[damageType.."Degen"] then
-					local energyShieldDegen = 0
-					local lifeDegen = 0
-					local manaDegen = 0
-					local takenFromMana = output[damageType.."MindOverMatter"] + output["sharedMindOverMatter"]
-					if output.EnergyShieldRegen > 0 then
-						if modDB:Flag(nil, "EnergyShieldProtectsMana") then
-							lifeDegen = output[damageType.."Degen"] * (1 - takenFromMana / 100)
-							energyShieldDegen = output[damageType.."Degen"] * (1 - output[damageType.."EnergyShieldBypass"] / 100) * (takenFromMana / 100)
-						else
-							lifeDegen = output[damageType.."Degen"] * (output[damageType.."EnergyShieldBypass"] / 100) * (1 - takenFromMana / 100)
-							energyShieldDegen = output[damageType.."Degen"] * (1 - output[damageType.."EnergyShieldBypass"] / 100)
-						end
-						manaDegen = output[damageType.."Degen"] * (output[damageType.."EnergyShieldBypass"] / 100) * (takenFromMana / 100)
-					else
-						lifeDegen = output[damageType.."Degen"] * (1 - takenFromMana / 100)
-						manaDegen = output[damageType.."Degen"] * (takenFromMana / 100)
-					end
-					totalLifeDegen = totalLifeDegen + lifeDegen
-					totalManaDegen = totalManaDegen + manaDegen
-					totalEnergyShieldDegen = totalEnergyShieldDegen + energyShieldDegen
-					if breakdown then
-						t_insert(breakdown.NetLifeRegen.rowList, {
-							type = s_format("%s", damageType),
-							degen = s_format("%.2f", lifeDegen),
-						})
-						t_insert(breakdown.NetManaRegen.rowList, {
-							type = s_format("%s", damageType),
-							degen = s_format("%.2f", manaDegen),
-						})
-						t_insert(breakdown.NetEnergyShieldRegen.rowList, {
-							type = s_format("%s", damageType),
-							degen = s_format("%.2f", energyShieldDegen),
-						})
-					end
-				end
-			end
-			output.NetLifeRegen = output.NetLifeRegen - totalLifeDegen
-			output.NetManaRegen = output.NetManaRegen - totalManaDegen
-			output.NetEnergyShieldRegen = output.NetEnergyShieldRegen - totalEnergyShieldDegen
-			output.TotalNetRegen = output.NetLifeRegen + output.NetManaRegen + output.NetEnergyShieldRegen
-			if breakdown then
-				t_insert(breakdown.NetLifeRegen, s_format("%.1f ^8(total life regen)", output.LifeRegen))
-				t_insert(breakdown.NetLifeRegen, s_format("- %.1f ^8(total life degen)", totalLifeDegen))
-				t_insert(breakdown.NetLifeRegen, s_format("= %.1f", output.NetLifeRegen))
-				t_insert(breakdown.NetManaRegen, s_format("%.1f ^8(total mana regen)", output.ManaRegen))
-				t_insert(breakdown.NetManaRegen, s_format("- %.1f ^8(total mana degen)", totalManaDegen))
-				t_insert(breakdown.NetManaRegen, s_format("= %.1f", output.NetManaRegen))
-				t_insert(breakdown.NetEnergyShieldRegen, s_format("%.1f ^8(total energy shield regen)", output.EnergyShieldRegen))
-				t_insert(breakdown.NetEnergyShieldRegen, s_format("- %.1f ^8(total energy shield degen)", totalEnergyShieldDegen))
-				t_insert(breakdown.NetEnergyShieldRegen, s_format("= %.1f", output.NetEnergyShieldRegen))
-				breakdown.TotalNetRegen = {
-					s_format("Net Life Regen: %.1f", output.NetLifeRegen),
-					s_format("+ Net Mana Regen: %.1f", output.NetManaRegen),
-					s_format("+ Net Energy Shield Regen: %.1f", output.NetEnergyShieldRegen),
-					s_format("= Total Net Regen: %.1f", output.TotalNetRegen)
+				manaDegen = degen * bypass * takenFromMana
+			} else {
+				lifeDegen = degen * (1 - takenFromMana)
+				manaDegen = degen * takenFromMana
+			}
+			totalLifeDegen += lifeDegen
+			totalManaDegen += manaDegen
+			totalEnergyShieldDegen += energyShieldDegen
+			lifeDegenLines = append(lifeDegenLines, fmt.Sprintf("%s: %.2f", damageType, lifeDegen))
+			manaDegenLines = append(manaDegenLines, fmt.Sprintf("%s: %.2f", damageType, manaDegen))
+			energyShieldDegenLines = append(energyShieldDegenLines, fmt.Sprintf("%s: %.2f", damageType, energyShieldDegen))
+		}
+		actor.Output["NetLifeRegen"] = actor.Output["LifeRegen"] - totalLifeDegen
+		actor.Output["NetManaRegen"] = actor.Output["ManaRegen"] - totalManaDegen
+		actor.Output["NetEnergyShieldRegen"] = actor.Output["EnergyShieldRegen"] - totalEnergyShieldDegen
+		actor.Output["TotalNetRegen"] = actor.Output["NetLifeRegen"] + actor.Output["NetManaRegen"] + actor.Output["NetEnergyShieldRegen"]
+		if actor.Breakdown != nil {
+			actor.Breakdown.Set("NetLifeRegen", actor.Output["NetLifeRegen"], append(lifeDegenLines,
+				fmt.Sprintf("%.1f (total life regen)", actor.Output["LifeRegen"]),
+				fmt.Sprintf("- %.1f (total life degen)", totalLifeDegen),
+				fmt.Sprintf("= %.1f", actor.Output["NetLifeRegen"]),
+			)...)
+			actor.Breakdown.Set("NetManaRegen", actor.Output["NetManaRegen"], append(manaDegenLines,
+				fmt.Sprintf("%.1f (total mana regen)", actor.Output["ManaRegen"]),
+				fmt.Sprintf("- %.1f (total mana degen)", totalManaDegen),
+				fmt.Sprintf("= %.1f", actor.Output["NetManaRegen"]),
+			)...)
+			actor.Breakdown.Set("NetEnergyShieldRegen", actor.Output["NetEnergyShieldRegen"], append(energyShieldDegenLines,
+				fmt.Sprintf("%.1f (total energy shield regen)", actor.Output["EnergyShieldRegen"]),
+				fmt.Sprintf("- %.1f (total energy shield degen)", totalEnergyShieldDegen),
+				fmt.Sprintf("= %.1f", actor.Output["NetEnergyShieldRegen"]),
+			)...)
+			actor.Breakdown.Set("TotalNetRegen", actor.Output["TotalNetRegen"],
+				fmt.Sprintf("Net Life Regen: %.1f", actor.Output["NetLifeRegen"]),
+				fmt.Sprintf("+ Net Mana Regen: %.1f", actor.Output["NetManaRegen"]),
+				fmt.Sprintf("+ Net Energy Shield Regen: %.1f", actor.Output["NetEnergyShieldRegen"]),
+				fmt.Sprintf("= Total Net Regen: %.1f", actor.Output["TotalNetRegen"]),
+			)
+		}
+	}
+
+	// Maximum hit taken
+	wardBypass := actor.ModDB.Sum(mod.TypeBase, nil, "WardBypass")
+	for _, damageType := range dmgTypeList {
+		totalPool := actor.Output[string(damageType)+"TotalPool"]
+		// Petrified blood
+		if actor.Output["preventedLifeLoss"] > 0 {
+			totalPool /= 1 - actor.Output["preventedLifeLoss"]/100
+		}
+
+		// Ward
+		if wardBypass > 0 {
+			poolProtected := actor.Output["Ward"] / (1 - wardBypass/100) * (wardBypass / 100)
+			totalPool = math.Max(totalPool-poolProtected, 0) + math.Min(totalPool, poolProtected)/(wardBypass/100)
+		} else {
+			totalPool += actor.Output["Ward"]
+		}
+		actor.Output[string(damageType)+"TotalPool"] = totalPool
+
+		// Aegis
+		totalHitPool := totalPool + actor.Output[string(damageType)+"Aegis"] + actor.Output["sharedAegis"]
+		if damageType.IsElemental() {
+			totalHitPool += actor.Output["sharedElementalAegis"]
+		}
+
+		// Guard skill
+		guardAbsorbRate := actor.Output["sharedGuardAbsorbRate"] + actor.Output[string(damageType)+"GuardAbsorbRate"]
+		if guardAbsorbRate > 0 {
+			guardAbsorb := actor.Output["sharedGuardAbsorb"] + actor.Output[string(damageType)+"GuardAbsorb"]
+			if guardAbsorbRate >= 100 {
+				totalHitPool += guardAbsorb
+			} else {
+				poolProtected := guardAbsorb / (guardAbsorbRate / 100) * (1 - guardAbsorbRate/100)
+				totalHitPool = math.Max(totalHitPool-poolProtected, 0) + math.Min(totalHitPool, poolProtected)/(1-guardAbsorbRate/100)
+			}
+		}
+
+		// Frost shield
+		if actor.Output["FrostShieldLife"] > 0 {
+			poolProtected := actor.Output["FrostShieldLife"] / (actor.Output["FrostShieldDamageMitigation"] / 100) * (1 - actor.Output["FrostShieldDamageMitigation"]/100)
+			totalHitPool = math.Max(totalHitPool-poolProtected, 0) + math.Min(totalHitPool, poolProtected)/(1-actor.Output["FrostShieldDamageMitigation"]/100)
+		}
+		actor.Output[string(damageType)+"TotalHitPool"] = totalHitPool
+	}
+
+	for _, damageType := range dmgTypeList {
+		maximumHitTaken := math.Inf(1)
+		var lines []string
+		for _, damageConvertedType := range dmgTypeList {
+			shift := actor.DamageShiftTable[damageType][damageConvertedType]
+			if shift > 0 {
+				hitTaken := actor.Output[string(damageConvertedType)+"TotalHitPool"] / (shift / 100) / actor.Output[string(damageConvertedType)+"BaseTakenHitMult"]
+				maximumHitTaken = math.Min(maximumHitTaken, hitTaken)
+				lines = append(lines, fmt.Sprintf("%.0f%% as %s: %.0f / %.2f = %.0f", shift, damageConvertedType, actor.Output[string(damageConvertedType)+"TotalHitPool"], actor.Output[string(damageConvertedType)+"BaseTakenHitMult"], hitTaken))
+			}
+		}
+		actor.Output[string(damageType)+"MaximumHitTaken"] = maximumHitTaken
+
+		if actor.Breakdown != nil {
+			lines = append(lines,
+				fmt.Sprintf("Total Pool: %.0f", actor.Output[string(damageType)+"TotalHitPool"]),
+				fmt.Sprintf("Taken Mult: %.2f", actor.Output[string(damageType)+"TotalHitPool"]/maximumHitTaken),
+				fmt.Sprintf("Maximum hit you can take: %.0f", maximumHitTaken),
+			)
+			actor.Breakdown.Set(string(damageType)+"MaximumHitTaken", maximumHitTaken, lines...)
+		}
+	}
+}
+
+// calcResistMax returns the maximum resistance of the given type, prefix selects e.g. totem resistances
+func calcResistMax(modDB moddb.ModStoreFuncs, prefix string, elem data.DamageType) float64 {
+	if override := modDB.Override(nil, prefix+string(elem)+"ResistMax"); override != nil {
+		return override.(float64)
+	}
+	names := []string{prefix + string(elem) + "ResistMax"}
+	if elem.IsElemental() {
+		names = append(names, prefix+"ElementalResistMax")
+	}
+	return math.Min(data.MaxResistCap, modDB.Sum(mod.TypeBase, nil, names...))
+}
+
+// calcResistTotal returns the uncapped resistance of the given type, prefix selects e.g. totem resistances
+func calcResistTotal(modDB moddb.ModStoreFuncs, prefix string, elem data.DamageType) float64 {
+	if override := modDB.Override(nil, prefix+string(elem)+"Resist"); override != nil {
+		return override.(float64)
+	}
+	names := []string{prefix + string(elem) + "Resist"}
+	if elem.IsElemental() {
+		names = append(names, prefix+"ElementalResist")
+	}
+	return modDB.Sum(mod.TypeBase, nil, names...) * CalcMod(modDB, nil, names...)
+}
+
+// ehpDamage is the damage taken per hit when simulating hits until death
+type ehpDamage struct {
+	Damage map[data.DamageType]float64
+
+	// Overrides the energy shield bypass of the actor per damage type
+	EnergyShieldBypass map[data.DamageType]float64
+
+	// Pools gained per hit taken, only applied if GainWhenHit is set
+	LifeWhenHit         float64
+	ManaWhenHit         float64
+	EnergyShieldWhenHit float64
+	GainWhenHit         bool
+
+	// Portion of the life loss prevented by Petrified Blood that is lost later
+	LifeLossBelowHalfLost float64
+
+	cycles    float64
+	cyclesRan bool
+}
+
+// numberOfHitsToDie iteratively reduces pools until life hits 0 to determine the number of hits it would take with given damage to die
+func numberOfHitsToDie(actor *Actor, damageIn *ehpDamage) float64 {
+	if damageIn.cycles == 0 {
+		damageIn.cycles = 1
+	}
+
+	wardNotBreak := actor.ModDB.Flag(nil, "WardNotBreak")
+
+	// Check damage in isn't 0 and that ward doesn't mitigate all damage
+	totalDamage := float64(0)
+	for _, damageType := range dmgTypeList {
+		totalDamage += damageIn.Damage[damageType]
+	}
+	if totalDamage == 0 || (wardNotBreak && actor.Output["Ward"] > 0 && totalDamage < actor.Output["Ward"]) {
+		return math.Inf(1)
+	}
+
+	life := actor.Output["LifeRecoverable"]
+	mana := actor.Output["ManaUnreserved"]
+	energyShield := actor.Output["EnergyShieldRecoveryCap"]
+	ward := actor.Output["Ward"]
+	restoreWard := float64(0)
+	if wardNotBreak {
+		restoreWard = ward
+	} else if damageIn.cycles > 1 {
+		// Don't apply non-perma ward for speed up calcs as it won't zero it correctly per hit
+		ward = 0
+	}
+	frostShield := actor.Output["FrostShieldLife"]
+	sharedAegis := actor.Output["sharedAegis"]
+	sharedElementalAegis := actor.Output["sharedElementalAegis"]
+	sharedGuard := actor.Output["sharedGuardAbsorb"]
+	aegis := make(map[data.DamageType]float64, len(dmgTypeList))
+	guard := make(map[data.DamageType]float64, len(dmgTypeList))
+	bypass := make(map[data.DamageType]float64, len(dmgTypeList))
+	for _, damageType := range dmgTypeList {
+		aegis[damageType] = actor.Output[string(damageType)+"Aegis"]
+		guard[damageType] = actor.Output[string(damageType)+"GuardAbsorb"]
+		bypass[damageType] = actor.Output[string(damageType)+"EnergyShieldBypass"]
+		if value, ok := damageIn.EnergyShieldBypass[damageType]; ok {
+			bypass[damageType] = value
+		}
+	}
+	wardBypass := actor.ModDB.Sum(mod.TypeBase, nil, "WardBypass")
+	energyShieldProtectsMana := actor.ModDB.Flag(nil, "EnergyShieldProtectsMana")
+
+	// absorb removes up to the pool from the damage and returns the damage left
+	absorb := func(damage float64, absorbed float64, pool *float64) float64 {
+		tempDamage := math.Min(absorbed, *pool)
+		*pool -= tempDamage
+		return damage - tempDamage
+	}
+
+	iterationMultiplier := float64(1)
+	maxHits := data.EHPCalcMaxHitsToCalc / damageIn.cycles
+	numHits := float64(0)
+	for life > 0 && numHits < maxHits {
+		numHits += iterationMultiplier
+		damage := make(map[data.DamageType]float64, len(dmgTypeList))
+		for _, damageType := range dmgTypeList {
+			damage[damageType] = damageIn.Damage[damageType] * iterationMultiplier
+		}
+		if damageIn.GainWhenHit && (iterationMultiplier > 1 || damageIn.cycles > 1) {
+			gainMult := iterationMultiplier * damageIn.cycles
+			life = math.Min(life+damageIn.LifeWhenHit*(gainMult-1), gainMult*actor.Output["LifeRecoverable"])
+			mana = math.Min(mana+damageIn.ManaWhenHit*(gainMult-1), gainMult*actor.Output["ManaUnreserved"])
+			energyShield = math.Min(energyShield+damageIn.EnergyShieldWhenHit*(gainMult-1), gainMult*actor.Output["EnergyShieldRecoveryCap"])
+		}
+		for _, damageType := range dmgTypeList {
+			taken := damage[damageType]
+			if taken <= 0 {
+				continue
+			}
+			if frostShield > 0 {
+				taken = absorb(taken, taken*actor.Output["FrostShieldDamageMitigation"]/100/iterationMultiplier, &frostShield)
+			}
+			if aegis[damageType] > 0 {
+				pool := aegis[damageType]
+				taken = absorb(taken, taken, &pool)
+				aegis[damageType] = pool
+			}
+			if damageType.IsElemental() && sharedElementalAegis > 0 {
+				taken = absorb(taken, taken, &sharedElementalAegis)
+			}
+			if sharedAegis > 0 {
+				taken = absorb(taken, taken, &sharedAegis)
+			}
+			if guard[damageType] > 0 {
+				pool := guard[damageType]
+				taken = absorb(taken, taken*actor.Output[string(damageType)+"GuardAbsorbRate"]/100/iterationMultiplier, &pool)
+				guard[damageType] = pool
+			}
+			if sharedGuard > 0 {
+				taken = absorb(taken, taken*actor.Output["sharedGuardAbsorbRate"]/100/iterationMultiplier, &sharedGuard)
+			}
+			if ward > 0 {
+				taken = absorb(taken, taken*(1-wardBypass/100), &ward)
+			}
+			if energyShield > 0 && !energyShieldProtectsMana && bypass[damageType] < 100 {
+				taken = absorb(taken, taken*(1-bypass[damageType]/100), &energyShield)
+			}
+			mindOverMatter := actor.Output["sharedMindOverMatter"] + actor.Output[string(damageType)+"MindOverMatter"]
+			if mindOverMatter > 0 {
+				momDamage := taken * math.Min(mindOverMatter, 100) / 100
+				if energyShieldProtectsMana && energyShield > 0 && bypass[damageType] < 100 {
+					left := absorb(momDamage, momDamage*(1-bypass[damageType]/100), &energyShield)
+					left = absorb(left, left, &mana)
+					taken -= momDamage - left
+				} else if mana > 0 {
+					taken = absorb(taken, momDamage, &mana)
 				}
-			end
-		end
-	*/
-	/*
-		TODO --maximum hit taken
-		-- this is not done yet, using old max hit taken
-		--fix total pools, as they arnt used anymore
-		for _, damageType in ipairs(dmgTypeList) do
-			--base + petrified blood
-			if output["preventedLifeLoss"] > 0 then
-				output[damageType.."TotalPool"] =  output[damageType.."TotalPool"] / (1 - output["preventedLifeLoss"] / 100)
-			end
-			--ward
-			local wardBypass = modDB:Sum("BASE", nil, "WardBypass") or 0
-			if wardBypass > 0 then
-				local poolProtected = output.Ward / (1 - wardBypass / 100) * (wardBypass / 100)
-				local sourcePool = output[damageType.."TotalPool"]
-				sourcePool = m_max(sourcePool - poolProtected, 0) + m_min(sourcePool, poolProtected) / (wardBypass / 100)
-				output[damageType.."TotalPool"] = sourcePool
-			else
-				output[damageType.."TotalPool"] = output[damageType.."TotalPool"] + output.Ward or 0
-			end
-			--aegis
-			output[damageType.."TotalHitPool"] = output[damageType.."TotalPool"] + output[damageType.."Aegis"] or 0 + output[damageType.."sharedAegis"] or 0 + isElemental[damageType] and output[damageType.."sharedElementalAegis"] or 0
-			--guardskill
-			local GuardAbsorbRate = output["sharedGuardAbsorbRate"] or 0 + output[damageType.."GuardAbsorbRate"] or 0
-			if GuardAbsorbRate > 0 then
-				local GuardAbsorb = output["sharedGuardAbsorb"] or 0 + output[damageType.."GuardAbsorb"] or 0
-				if GuardAbsorbRate >= 100 then
-					output[damageType.."TotalHitPool"] = output[damageType.."TotalHitPool"] + GuardAbsorb
-				else
-					local poolProtected = GuardAbsorb / (GuardAbsorbRate / 100) * (1 - GuardAbsorbRate / 100)
-					output[damageType.."TotalHitPool"] = m_max(output[damageType.."TotalHitPool"] - poolProtected, 0) + m_min(output[damageType.."TotalHitPool"], poolProtected) / (1 - GuardAbsorbRate / 100)
-				end
-			end
-			--frost shield
-			if output["FrostShieldLife"] > 0 then
-				local poolProtected = output["FrostShieldLife"] / (output["FrostShieldDamageMitigation"] / 100) * (1 - output["FrostShieldDamageMitigation"] / 100)
-				output[damageType.."TotalHitPool"] = m_max(output[damageType.."TotalHitPool"] - poolProtected, 0) + m_min(output[damageType.."TotalHitPool"], poolProtected) / (1 - output["FrostShieldDamageMitigation"] / 100)
-			end
-		end
-		for _, damageType in ipairs(dmgTypeList) do
-			if breakdown then
-				breakdown[damageType.."MaximumHitTaken"] = {
-					label = "Maximum Hit Taken (uses lowest value)",
-					rowList = { },
-					colList = {
-						{ label = "Type", key = "type" },
-						{ label = "TotalPool", key = "pool" },
-						{ label = "Taken", key = "taken" },
-						{ label = "Final", key = "final" },
-					},
+			}
+			if actor.Output["preventedLifeLoss"] > 0 {
+				if damageIn.LifeLossBelowHalfLost > 0 {
+					actor.Output["LifeLossBelowHalfLost"] += taken * actor.Output["preventedLifeLoss"] / 100
 				}
-			end
-			output[damageType.."MaximumHitTaken"] = m_huge
-			for _, damageConvertedType in ipairs(dmgTypeList) do
-				if actor.damageShiftTable[damageType][damageConvertedType] > 0 then
-					local hitTaken = output[damageConvertedType.."TotalHitPool"] / (actor.damageShiftTable[damageType][damageConvertedType] / 100) / output[damageConvertedType.."BaseTakenHitMult"]
-					if hitTaken < output[damageType.."MaximumHitTaken"] then
-						output[damageType.."MaximumHitTaken"] = hitTaken
-					end
-					if breakdown then
-						t_insert(breakdown[damageType.."MaximumHitTaken"].rowList, {
-							type = s_format("%d%% as %s", actor.damageShiftTable[damageType][damageConvertedType], damageConvertedType),
-							pool = s_format("x %d", output[damageConvertedType.."TotalHitPool"]),
-							taken = s_format("/ %.2f", output[damageConvertedType.."BaseTakenHitMult"]),
-							final = s_format("x %.0f", hitTaken),
-						})
-					end
-				end
-			end
-			if breakdown then
-				 t_insert(breakdown[damageType.."MaximumHitTaken"], s_format("Total Pool: %d", output[damageType.."TotalHitPool"]))
-				 t_insert(breakdown[damageType.."MaximumHitTaken"], s_format("Taken Mult: %.2f",  output[damageType.."TotalHitPool"] / output[damageType.."MaximumHitTaken"]))
-				 t_insert(breakdown[damageType.."MaximumHitTaken"], s_format("Maximum hit you can take: %.0f", output[damageType.."MaximumHitTaken"]))
-			end
-		end
-	*/
+				taken *= 1 - actor.Output["preventedLifeLoss"]/100
+			}
+			life -= taken
+		}
+		if wardNotBreak {
+			ward = restoreWard
+		} else {
+			ward = 0
+		}
+		if damageIn.GainWhenHit && life > 0 {
+			life = math.Min(life+damageIn.LifeWhenHit, actor.Output["LifeRecoverable"])
+			mana = math.Min(mana+damageIn.ManaWhenHit, actor.Output["ManaUnreserved"])
+			energyShield = math.Min(energyShield+damageIn.EnergyShieldWhenHit, actor.Output["EnergyShieldRecoveryCap"])
+		}
+
+		// To speed it up, run recursively with bigger hits
+		iterationMultiplier = 1
+		if !damageIn.cyclesRan && life > 0 && damageIn.cycles < data.EHPCalcMaxDepth {
+			fasterDamage := &ehpDamage{
+				Damage: make(map[data.DamageType]float64, len(dmgTypeList)),
+				cycles: damageIn.cycles * data.EHPCalcSpeedUp,
+			}
+			for _, damageType := range dmgTypeList {
+				fasterDamage.Damage[damageType] = damageIn.Damage[damageType] * data.EHPCalcSpeedUp
+			}
+			iterationMultiplier = math.Max((numberOfHitsToDie(actor, fasterDamage)-1)*data.EHPCalcSpeedUp-1, 1)
+			damageIn.cyclesRan = true
+		}
+	}
+
+	if numHits >= maxHits {
+		return math.Inf(1)
+	}
+	return numHits
 }
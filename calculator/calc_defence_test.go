@@ -0,0 +1,92 @@
+package calculator
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/Vilsol/go-pob/builds"
+	"github.com/Vilsol/go-pob/mod"
+)
+
+func TestDefence(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	calculator := &Calculator{PoB: build}
+	env, err := calculator.BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	output := env.Player.Output
+	testza.AssertEqual(t, float64(60), output["Life"])
+	testza.AssertEqual(t, float64(60), output["LifeRecoverable"])
+	testza.AssertEqual(t, float64(-60), output["FireResist"])
+
+	// 60 life against 7.105 damage per hit
+	testza.AssertEqual(t, 7.105, output["PhysicalTakenHit"])
+	testza.AssertEqual(t, float64(9), output["NumberOfDamagingHits"])
+
+	// The build has no weapons, so it has no inherent dual wielding block
+	testza.AssertEqual(t, float64(0), output["BlockChance"])
+	testza.AssertEqual(t, float64(9), output["TotalNumberOfHits"])
+	testza.AssertEqual(t, float64(63), output["TotalEHP"])
+	testza.AssertEqual(t, 6.3, output["EHPsurvivalTime"])
+
+	testza.AssertEqual(t, float64(60), output["PhysicalMaximumHitTaken"])
+	testza.AssertEqual(t, 37.5, output["FireMaximumHitTaken"])
+	testza.AssertEqual(t, float64(60), output["PhysicalDotEHP"])
+}
+
+func TestBlockAndSuppression(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball-full.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	env, _, _, _, err := InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	// Dual wielding grants inherent block
+	env.ModDB.AddMod(mod.NewFlag("Condition:DualWielding", true).Source("Test"))
+	PerformCalc(env)
+
+	output := env.Player.Output
+	testza.AssertEqual(t, float64(75), output["BlockChanceMax"])
+	testza.AssertEqual(t, float64(15), output["BlockChance"])
+	testza.AssertEqual(t, float64(5), output["SpellSuppressionChance"])
+	testza.AssertEqual(t, float64(50), output["SpellSuppressionEffect"])
+	testza.AssertEqual(t, float64(100), output["BlockEffect"])
+
+	// Block and suppression reduce the damage taken from the average hit
+	testza.AssertInRange(t, output["ConfiguredDamageChance"], 91.34, 91.35)
+}
+
+func TestRecovery(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball-full.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	calculator := &Calculator{PoB: build}
+	env, err := calculator.BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	output := env.Player.Output
+	testza.AssertEqual(t, float64(1), output["LifeRecoveryRateMod"])
+	testza.AssertEqual(t, float64(4), output["ManaRegen"])
+	testza.AssertEqual(t, 5.8, output["LifeRegenPercent"])
+	testza.AssertEqual(t, 47.4, output["MaxLifeLeechRate"])
+	testza.AssertEqual(t, float64(2), output["EnergyShieldRechargeDelay"])
+	testza.AssertEqual(t, float64(5), output["WardRechargeDelay"])
+
+	// Fireball does not leech
+	testza.AssertEqual(t, float64(0), output["LifeLeechRate"])
+	testza.AssertFalse(t, env.Player.MainSkill.SkillFlags[SkillFlagLeechLife])
+}
@@ -1,11 +1,20 @@
 package calculator
 
 import (
+	"maps"
+	"math"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/Vilsol/go-pob-data/poe"
+
 	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/mod"
 	"github.com/Vilsol/go-pob/moddb"
+	"github.com/Vilsol/go-pob/pob"
+	"github.com/Vilsol/go-pob/utils"
 )
 
 func buildModListForNodeList(env *Environment, nodes map[string]data.Node) *moddb.ModList { // TODO ADD: finishJewels)
@@ -19,13 +28,20 @@ func buildModListForNodeList(env *Environment, nodes map[string]data.Node) *modd
 
 	// Add node modifiers
 	var modList = moddb.NewModList()
+	var nodeMods = env.Cache.nodeMods(env.Spec.TreeVersion, len(env.Spec.Tree().Nodes))
 	for nodeId, node := range nodes {
-		cachedModList, isCached := env.Cache.modsForNodes[nodeId]
+		// Mastery mods depend on the selected effect
+		var cacheKey = nodeId
+		if effectID, ok := env.Spec.MasterySelections[nodeId]; ok {
+			cacheKey += ":" + strconv.FormatInt(effectID, 10)
+		}
+
+		cachedModList, isCached := nodeMods[cacheKey]
 		if isCached {
 			modList.AddDB(&cachedModList)
 		} else {
 			var nodeModList = buildModListForNode(env, node)
-			env.Cache.modsForNodes[nodeId] = *nodeModList
+			nodeMods[cacheKey] = *nodeModList
 			modList.AddDB(nodeModList)
 		}
 
@@ -130,3 +146,338 @@ func buildModListForNode(env *Environment, node data.Node) *moddb.ModList {
 
 	return modList
 }
+
+var (
+	slotNumberRegex     = regexp.MustCompile(`(\d+)\D*$`)
+	abyssalSocketRegex  = regexp.MustCompile(`^(.+?) ?Abyssal Socket (\d+)$`)
+	weaponSwapReplacer  = strings.NewReplacer(" Swap", "", "Swap ", " ")
+	weaponSetSlotRegexp = regexp.MustCompile(`^Weapon \d( ?Swap)?( |$)`)
+)
+
+// itemSlotNumber returns the slot number used by SlotNumber and InSlot tags, e.g. 2 for "Ring 2"
+func itemSlotNumber(slotName string) int {
+	if match := slotNumberRegex.FindStringSubmatch(slotName); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		return n
+	}
+	return 1
+}
+
+// itemSlotWeaponSet returns which weapon set a slot belongs to, or 0 if it is not a weapon slot
+func itemSlotWeaponSet(slotName string) int {
+	match := weaponSetSlotRegexp.FindStringSubmatch(slotName)
+	if match == nil {
+		return 0
+	}
+	if match[1] != "" {
+		return 2
+	}
+	return 1
+}
+
+// abyssalSocketParent returns the parent slot and socket number of an abyssal socket slot
+func abyssalSocketParent(slotName string) (string, int) {
+	match := abyssalSocketRegex.FindStringSubmatch(slotName)
+	if match == nil {
+		return "", 0
+	}
+	n, _ := strconv.Atoi(match[2])
+	return match[1], n
+}
+
+func buildModListForItem(env *Environment, item *pob.Item, slotName string) (*moddb.ModList, *WeaponData, *ArmourData) {
+	source := mod.Source("Item:" + strconv.Itoa(item.ID) + ":" + item.Title)
+	slotNum := itemSlotNumber(slotName)
+
+	mods := make([]mod.Mod, 0)
+	for _, line := range item.ActiveModLines() {
+		value := line.Value()
+		parsed, extra := env.Cache.itemLineMods(value)
+
+		if strings.Trim(extra, " ") != "" {
+			env.DebugErrors = append(env.DebugErrors, "Error parsing Item ("+item.Title+") mod: "+extra+", stat text: "+value+", with "+strconv.Itoa(len(parsed))+" mods found")
+			continue
+		}
+
+		for _, m := range parsed {
+			// Parsed mods are shared with the parser tables and the cache
+			m = m.Clone()
+			if !resolveItemModTags(m, slotName, slotNum) {
+				continue
+			}
+			mods = append(mods, m.Source(source))
+		}
+	}
+
+	var weaponData *WeaponData
+	if weaponType := item.WeaponType(); weaponType != nil {
+		localiseWeaponMods(mods, slotNum)
+		weaponData = buildWeaponData(item, weaponType, &mods)
+	}
+
+	var armourData *ArmourData
+	if armourType := item.ArmourType(); armourType != nil {
+		armourData = buildArmourData(item, armourType, &mods)
+		if armourType.IncreasedMovementSpeed != 0 {
+			mods = append(mods, mod.NewFloat("MovementSpeed", mod.TypeIncrease, float64(armourType.IncreasedMovementSpeed)).Source(source).Tag(mod.Condition("IgnoreMovementPenalties").Neg(true)))
+		}
+	}
+
+	/*
+		TODO Local item stats
+		-- Socketed gem modifiers are handled in Item:BuildModList
+	*/
+
+	modList := moddb.NewModList()
+	for _, m := range mods {
+		modList.AddMod(m)
+	}
+
+	return modList, weaponData, armourData
+}
+
+// localiseWeaponMods restricts weapon modifiers that only apply to attacks with that weapon
+func localiseWeaponMods(mods []mod.Mod, slotNum int) {
+	hand := "MainHandAttack"
+	if slotNum == 2 {
+		hand = "OffHandAttack"
+	}
+
+	for _, m := range mods {
+		tags := m.Tags()
+		switch m.Name() {
+		case "Accuracy", "LifeOnHit", "ManaOnHit", "PhysicalDamageLifeLeech", "PhysicalDamageManaLeech":
+			flags := mod.MFlagAttack
+			if m.Name() == "Accuracy" {
+				flags = 0
+			}
+			if m.Flags() == flags && (m.KeywordFlags() == 0 || m.KeywordFlags() == mod.KeywordFlagAttack) && len(tags) == 0 {
+				m.Tag(mod.Condition(hand))
+			}
+		case "PoisonChance", "BleedChance":
+			if len(tags) == 0 {
+				m.Tag(mod.Condition(hand))
+			} else if cond, ok := tags[0].(*mod.ConditionTag); ok && len(tags) == 1 && slices.Equal(cond.VarList, []string{"CriticalStrike"}) {
+				m.Tag(mod.Condition(hand))
+			}
+		}
+	}
+}
+
+// calcLocal combines and removes the local modifiers of a weapon or armour item
+func calcLocal(mods *[]mod.Mod, name string, modType mod.Type, flags mod.MFlag) float64 {
+	result := float64(0)
+	if modType == mod.TypeMore {
+		result = 1
+	}
+
+	*mods = slices.DeleteFunc(*mods, func(m mod.Mod) bool {
+		if m.Name() != name || m.Type() != modType || m.Flags() != flags || m.KeywordFlags() != 0 {
+			return false
+		}
+
+		if tags := m.Tags(); len(tags) > 0 {
+			if _, ok := tags[0].(*mod.InSlotTag); !ok {
+				return false
+			}
+		}
+
+		value, _ := m.Value().(float64)
+		if modType == mod.TypeMore {
+			result *= 1 + value/100
+		} else {
+			result += value
+		}
+		return true
+	})
+
+	return result
+}
+
+// buildWeaponData calculates the base stats of a weapon, consuming its local modifiers
+func buildWeaponData(item *pob.Item, weaponType *poe.WeaponType, mods *[]mod.Mod) *WeaponData {
+	weaponData := &WeaponData{
+		Type:   item.Type(),
+		Name:   item.Title,
+		Damage: make(map[data.DamageType]DamageRange),
+	}
+
+	weaponData.AttackSpeedInc = calcLocal(mods, "Speed", mod.TypeIncrease, mod.MFlagAttack)
+	weaponData.AttackRate = utils.RoundTo(1000/float64(weaponType.Speed)*(1+weaponData.AttackSpeedInc/100), 2)
+	weaponData.RangeBonus = calcLocal(mods, "WeaponRange", mod.TypeBase, 0) + 10*calcLocal(mods, "WeaponRangeMetre", mod.TypeBase, 0)
+	weaponData.Range = float64(weaponType.RangeMax) + weaponData.RangeBonus
+
+	for _, damageType := range data.DamageType("").Values() {
+		minDamage := calcLocal(mods, string(damageType)+"Min", mod.TypeBase, 0)
+		maxDamage := calcLocal(mods, string(damageType)+"Max", mod.TypeBase, 0)
+		if damageType == data.DamageTypePhysical {
+			physInc := calcLocal(mods, "PhysicalDamage", mod.TypeIncrease, 0)
+			physMore := calcLocal(mods, "PhysicalDamage", mod.TypeMore, 0)
+			minDamage = math.Round((float64(weaponType.DamageMin) + minDamage) * (1 + (physInc+float64(item.Quality))/100) * physMore)
+			maxDamage = math.Round((float64(weaponType.DamageMax) + maxDamage) * (1 + (physInc+float64(item.Quality))/100) * physMore)
+		}
+
+		if minDamage > 0 && maxDamage > 0 {
+			weaponData.Damage[damageType] = DamageRange{Min: minDamage, Max: maxDamage}
+		}
+	}
+
+	critInc := calcLocal(mods, "CritChance", mod.TypeIncrease, 0)
+	critBase := calcLocal(mods, "CritChance", mod.TypeBase, 0)
+	weaponData.CritChance = utils.RoundTo(float64(weaponType.Critical)/100*(1+critInc/100)+critBase, 2)
+
+	// Weapon data modifiers such as "No Physical Damage" take priority over the calculated values
+	for _, value := range *mods {
+		if value.Name() != "WeaponData" {
+			continue
+		}
+
+		override, ok := value.Value().(mod.WeaponData)
+		if !ok {
+			continue
+		}
+
+		switch override.Key {
+		case "countsAsDualWielding":
+			weaponData.CountsAsDualWielding = override.Value != 0
+		case "countsAsAll1H":
+			weaponData.CountsAsAll1H = override.Value != 0
+		case "CritChance":
+			weaponData.CritChance = override.Value
+		case "PhysicalMin", "PhysicalMax":
+			delete(weaponData.Damage, data.DamageTypePhysical)
+		}
+	}
+
+	return weaponData
+}
+
+// basePercentile rolls a base defence of the item within the range of its base type
+func basePercentile(minValue int, maxValue int, percentile *float64) float64 {
+	if percentile == nil {
+		return float64(maxValue)
+	}
+	return float64(minValue) + math.Round(float64(maxValue-minValue)*(*percentile))
+}
+
+// buildArmourData calculates the defences of an armour or shield, consuming its local modifiers
+func buildArmourData(item *pob.Item, armourType *poe.ArmourType, mods *[]mod.Mod) *ArmourData {
+	armourBase := calcLocal(mods, "Armour", mod.TypeBase, 0) + basePercentile(armourType.ArmourMin, armourType.ArmourMax, item.ArmourBasePercentile)
+	armourEvasionBase := calcLocal(mods, "ArmourAndEvasion", mod.TypeBase, 0)
+	evasionBase := calcLocal(mods, "Evasion", mod.TypeBase, 0) + basePercentile(armourType.EvasionMin, armourType.EvasionMax, item.EvasionBasePercentile)
+	evasionEnergyShieldBase := calcLocal(mods, "EvasionAndEnergyShield", mod.TypeBase, 0)
+	energyShieldBase := calcLocal(mods, "EnergyShield", mod.TypeBase, 0) + basePercentile(armourType.EnergyShieldMin, armourType.EnergyShieldMax, item.EnergyShieldBasePercentile)
+	armourEnergyShieldBase := calcLocal(mods, "ArmourAndEnergyShield", mod.TypeBase, 0)
+	wardBase := calcLocal(mods, "Ward", mod.TypeBase, 0) + basePercentile(armourType.WardMin, armourType.WardMax, item.WardBasePercentile)
+
+	armourInc := calcLocal(mods, "Armour", mod.TypeIncrease, 0)
+	armourEvasionInc := calcLocal(mods, "ArmourAndEvasion", mod.TypeIncrease, 0)
+	evasionInc := calcLocal(mods, "Evasion", mod.TypeIncrease, 0)
+	evasionEnergyShieldInc := calcLocal(mods, "EvasionAndEnergyShield", mod.TypeIncrease, 0)
+	energyShieldInc := calcLocal(mods, "EnergyShield", mod.TypeIncrease, 0)
+	wardInc := calcLocal(mods, "Ward", mod.TypeIncrease, 0)
+	armourEnergyShieldInc := calcLocal(mods, "ArmourAndEnergyShield", mod.TypeIncrease, 0)
+	defencesInc := calcLocal(mods, "Defences", mod.TypeIncrease, 0)
+	quality := float64(item.Quality)
+
+	armourData := &ArmourData{
+		Armour:       math.Round((armourBase + armourEvasionBase + armourEnergyShieldBase) * (1 + (armourInc+armourEvasionInc+armourEnergyShieldInc+defencesInc+quality)/100)),
+		Evasion:      math.Round((evasionBase + armourEvasionBase + evasionEnergyShieldBase) * (1 + (evasionInc+armourEvasionInc+evasionEnergyShieldInc+defencesInc+quality)/100)),
+		EnergyShield: math.Round((energyShieldBase + evasionEnergyShieldBase + armourEnergyShieldBase) * (1 + (energyShieldInc+armourEnergyShieldInc+evasionEnergyShieldInc+defencesInc+quality)/100)),
+		Ward:         math.Round(wardBase * (1 + (wardInc+defencesInc+quality)/100)),
+	}
+
+	if shieldType := item.ShieldType(); shieldType != nil {
+		blockBase := calcLocal(mods, "BlockChance", mod.TypeBase, 0)
+		blockInc := calcLocal(mods, "BlockChance", mod.TypeIncrease, 0)
+		armourData.BlockChance = math.Floor((float64(shieldType.Block) + blockBase) * (1 + blockInc/100))
+	}
+
+	// Armour data modifiers such as "Has no Energy Shield" take priority over the calculated values
+	for _, value := range *mods {
+		if value.Name() != "ArmourData" {
+			continue
+		}
+
+		override, ok := value.Value().(mod.ArmourData)
+		if !ok {
+			continue
+		}
+
+		switch override.Key {
+		case "Armour":
+			armourData.Armour = override.Value
+		case "Evasion":
+			armourData.Evasion = override.Value
+		case "EnergyShield":
+			armourData.EnergyShield = override.Value
+		case "Ward":
+			armourData.Ward = override.Value
+		case "BlockChance":
+			armourData.BlockChance = override.Value
+		}
+	}
+
+	return armourData
+}
+
+// resolveItemModTags fills in slot placeholders and reports whether the mod applies to the given slot number
+func resolveItemModTags(m mod.Mod, slotName string, slotNum int) bool {
+	hand := "MainHand"
+	otherSlotNum := "2"
+	if slotNum == 2 {
+		hand = "OffHand"
+		otherSlotNum = "1"
+	}
+
+	replacer := strings.NewReplacer("{SlotName}", slotName, "{Hand}", hand, "{OtherSlotNum}", otherSlotNum)
+
+	tags := make([]mod.Tag, 0, len(m.Tags()))
+	for _, tag := range m.Tags() {
+		switch t := tag.(type) {
+		case *mod.SlotNumberTag:
+			if t.N != slotNum {
+				return false
+			}
+		case *mod.InSlotTag:
+			if t.N != slotNum {
+				return false
+			}
+		case *mod.SocketedInTag:
+			resolved := *t
+			resolved.SlotName = replacer.Replace(t.SlotName)
+			tag = &resolved
+		case *mod.SlotNameTag:
+			resolved := *t
+			resolved.SlotNameList = make([]string, len(t.SlotNameList))
+			for i, name := range t.SlotNameList {
+				resolved.SlotNameList[i] = replacer.Replace(name)
+			}
+			tag = &resolved
+		case *mod.ConditionTag:
+			resolved := *t
+			resolved.VarList = make([]string, len(t.VarList))
+			for i, name := range t.VarList {
+				resolved.VarList[i] = replacer.Replace(name)
+			}
+			tag = &resolved
+		}
+		tags = append(tags, tag)
+	}
+
+	m.ClearTags()
+	m.Tag(tags...)
+	return true
+}
+
+// mergeDB adds all modifiers, multipliers and conditions of itemModDB into modDB
+func mergeDB(modDB *moddb.ModDB, itemModDB *moddb.ModDB) {
+	// AddDB replaces the mods of each name, so they are appended instead
+	for k, v := range itemModDB.Mods {
+		modDB.Mods[k] = append(modDB.Mods[k], utils.CopySlice(v)...)
+	}
+	for k, v := range itemModDB.Multipliers {
+		modDB.Multipliers[k] += v
+	}
+	maps.Copy(modDB.Conditions, itemModDB.Conditions)
+}
@@ -182,8 +182,10 @@ func CalcBuildSkillInstanceStats(skillInstance *GemEffect, grantedEffect *Grante
 
 	var availableEffectiveness *float64
 
-	// TODO local actorLevel = skillInstance.actorLevel or level.levelRequirement
 	actorLevel := float64(level.LevelRequirement)
+	if skillInstance.ActorLevel != 0 {
+		actorLevel = float64(skillInstance.ActorLevel)
+	}
 
 	for index, stat := range calculatedGrantedEffect.GetCalculatedStats() {
 		// Static value used as default (assumes statInterpolation == 1)
@@ -0,0 +1,1021 @@
+package calculator
+
+// configSections lists the options of the configuration tab in display order, with the labels, tooltips and values shown to the user
+var configSections = []configSectionSchema{
+	{
+		name: "General",
+		options: []configOptionSchema{
+			{
+				name:  "resistancePenalty",
+				label: "Resistance penalty:",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: float64(-30), Label: "Act 5 (-30%)"},
+					{Value: float64(-60), Label: "Act 10 (-60%)"},
+				},
+			},
+			{
+				name:  "bandit",
+				label: "Bandit quest:",
+				values: []ConfigValue{
+					{Value: "None", Label: "Kill all"},
+					{Value: "Oak", Label: "Help Oak"},
+					{Value: "Kraityn", Label: "Help Kraityn"},
+					{Value: "Alira", Label: "Help Alira"},
+				},
+			},
+			{
+				name:  "pantheonMajorGod",
+				label: "Major God:",
+				values: []ConfigValue{
+					{Value: "None", Label: "Nothing"},
+					{Value: "TheBrineKing", Label: "Soul of the Brine King"},
+					{Value: "Lunaris", Label: "Soul of Lunaris"},
+					{Value: "Solaris", Label: "Soul of Solaris"},
+					{Value: "Arakaali", Label: "Soul of Arakaali"},
+				},
+			},
+			{
+				name:  "pantheonMinorGod",
+				label: "Minor God:",
+				values: []ConfigValue{
+					{Value: "None", Label: "Nothing"},
+					{Value: "Gruthkul", Label: "Soul of Gruthkul"},
+					{Value: "Yugul", Label: "Soul of Yugul"},
+					{Value: "Abberath", Label: "Soul of Abberath"},
+					{Value: "Tukohama", Label: "Soul of Tukohama"},
+					{Value: "Garukhan", Label: "Soul of Garukhan"},
+					{Value: "Ralakesh", Label: "Soul of Ralakesh"},
+					{Value: "Ryslatha", Label: "Soul of Ryslatha"},
+					{Value: "Shakari", Label: "Soul of Shakari"},
+				},
+			},
+			{name: "detonateDeadCorpseLife", label: "Enemy Corpse Life:", tooltip: "Sets the maximum life of the target corpse for Detonate Dead and similar skills.\nFor reference, a level 70 monster has 6937 base life, and a level 80 monster has 12787."},
+			{name: "conditionStationary", label: "Time spent stationary", tooltip: "Applies mods that use `while stationary` and `per / every second while stationary`"},
+			{name: "conditionMoving", label: "Are you always moving?"},
+			{name: "conditionInsane", label: "Are you insane?"},
+			{name: "conditionFullLife", label: "Are you always on Full Life?", tooltip: "You will automatically be considered to be on Full Life if you have Chaos Inoculation,\nbut you can use this option to force it if necessary."},
+			{name: "conditionLowLife", label: "Are you always on Low Life?", tooltip: "You will automatically be considered to be on Low Life if you have at least 50% life reserved,\nbut you can use this option to force it if necessary."},
+			{name: "conditionFullMana", label: "Are you always on Full Mana?"},
+			{name: "conditionLowMana", label: "Are you always on Low Mana?", tooltip: "You will automatically be considered to be on Low Mana if you have at least 50% mana reserved,\nbut you can use this option to force it if necessary."},
+			{name: "conditionFullEnergyShield", label: "Are you always on Full Energy Shield?"},
+			{name: "conditionLowEnergyShield", label: "Are you always on Low Energy Shield?", tooltip: "You will automatically be considered to be on Low Energy Shield if you have at least 50% ES reserved,\nbut you can use this option to force it if necessary."},
+			{name: "conditionHaveEnergyShield", label: "Do you always have Energy Shield?"},
+			{name: "minionsConditionFullLife", label: "Are your Minions always on Full Life?"},
+			{name: "minionsConditionCreatedRecently", label: "Have your Minions been created Recently?"},
+			{
+				name:    "igniteMode",
+				label:   "Ailment calculation mode:",
+				tooltip: "Controls how the base damage for applying Ailments is calculated:\n\tAverage: damage is based on the average application, including both crits and non-crits\n\tCrits Only: damage is based solely on Ailments inflicted with crits",
+				values: []ConfigValue{
+					{Value: "AVERAGE", Label: "Average"},
+					{Value: "CRIT", Label: "Crits Only"},
+				},
+			},
+			{
+				name:    "physMode",
+				label:   "Random element mode:",
+				tooltip: "Controls how modifiers which choose a random element will function.\n\tAverage: Modifiers will grant one third of their value to Fire, Cold, and Lightning simultaneously\n\tFire / Cold / Lightning: Modifiers will grant their full value as the specified element\nIf a modifier chooses between just two elements, the full value can only be given as those two elements.",
+				values: []ConfigValue{
+					{Value: "AVERAGE", Label: "Average"},
+					{Value: "Fire", Label: "Fire"},
+					{Value: "Cold", Label: "Cold"},
+					{Value: "Lightning", Label: "Lightning"},
+				},
+			},
+			{
+				name:    "lifeRegenMode",
+				label:   "Life regen calculation mode:",
+				tooltip: "Controls how life regeneration is calculated:\n\tMinimum: does not include burst regen\n\tAverage: includes burst regen, averaged based on uptime\n\tBurst: includes full burst regen",
+				values: []ConfigValue{
+					{Value: "MIN", Label: "Minimum"},
+					{Value: "AVERAGE", Label: "Average"},
+					{Value: "FULL", Label: "Burst"},
+				},
+			},
+			{
+				name:    "EHPUnluckyWorstOf",
+				label:   "EHP calc unlucky:",
+				tooltip: "Sets the EHP calc to pretend its unlucky and reduce the effects of random events",
+				values: []ConfigValue{
+					{Value: float64(1), Label: "Average"},
+					{Value: float64(2), Label: "Unlucky"},
+					{Value: float64(4), Label: "Very Unlucky"},
+				},
+			},
+			{name: "DisableEHPGainOnBlock", label: "Disable EHP gain on block:", tooltip: "Sets the EHP calc to not apply gain on block effects"},
+			{
+				name:    "armourCalculationMode",
+				label:   "Armour calculation mode:",
+				tooltip: "Controls how Defending with Double Armour is calculated:\n\tMinimum: never Defend with Double Armour\n\tAverage: Damage Reduction from Defending with Double Armour is proportional to chance\n\tMaximum: always Defend with Double Armour\nThis setting has no effect if you have 100% chance to Defend with Double Armour.",
+				values: []ConfigValue{
+					{Value: "MIN", Label: "Minimum"},
+					{Value: "AVERAGE", Label: "Average"},
+					{Value: "MAX", Label: "Maximum"},
+				},
+			},
+			{
+				name:    "warcryMode",
+				label:   "Warcry calculation mode:",
+				tooltip: "Controls how exerted attacks from Warcries are calculated:\nAverage: Averages out Warcry usage with cast time, attack speed and warcry cooldown.\nMax Hit: Shows maximum hit for lining up all warcries.",
+				values: []ConfigValue{
+					{Value: "AVERAGE", Label: "Average"},
+					{Value: "MAX", Label: "Max Hit"},
+				},
+			},
+			{name: "EVBypass", label: "Disable Emperor's Vigilance Bypass"},
+		},
+	},
+	{
+		name: "Arcanist Brand",
+		options: []configOptionSchema{
+			{name: "targetBrandedEnemy", label: "Are skills targeting the Branded enemy?"},
+		},
+	},
+	{
+		name: "Aspect of the Avian",
+		options: []configOptionSchema{
+			{name: "aspectOfTheAvianAviansMight", label: "Is Avian's Might active?"},
+			{name: "aspectOfTheAvianAviansFlight", label: "Is Avian's Flight active?"},
+		},
+	},
+	{
+		name: "Aspect of the Cat",
+		options: []configOptionSchema{
+			{name: "aspectOfTheCatCatsStealth", label: "Is Cat's Stealth active?"},
+			{name: "aspectOfTheCatCatsAgility", label: "Is Cat's Agility active?"},
+		},
+	},
+	{
+		name: "Aspect of the Crab",
+		options: []configOptionSchema{
+			{name: "overrideCrabBarriers", label: "# of Crab Barriers (if not maximum):"},
+		},
+	},
+	{
+		name: "Aspect of the Spider",
+		options: []configOptionSchema{
+			{name: "aspectOfTheSpiderWebStacks", label: "# of Spider's Web Stacks:"},
+		},
+	},
+	{
+		name: "Banner Skills",
+		options: []configOptionSchema{
+			{name: "bannerPlanted", label: "Is Banner Planted?"},
+			{name: "bannerStages", label: "Banner Stages:"},
+		},
+	},
+	{
+		name: "Bladestorm",
+		options: []configOptionSchema{
+			{name: "bladestormInBloodstorm", label: "Are you in a Bloodstorm?"},
+			{name: "bladestormInSandstorm", label: "Are you in a Sandstorm?"},
+		},
+	},
+	{
+		name: "Bonechill Support",
+		options: []configOptionSchema{
+			{name: "bonechillEffect", label: "Effect of Chill:", tooltip: "The effect of Chill is automatically calculated if you have a guaranteed source of Chill,\nbut you can use this to override the effect if necessary."},
+		},
+	},
+	{
+		name: "Boneshatter",
+		options: []configOptionSchema{
+			{name: "boneshatterTraumaStacks", label: "# of Trauma Stacks:"},
+		},
+	},
+	{
+		name: "Brand Skills",
+		options: []configOptionSchema{
+			{name: "ActiveBrands", label: "# of active Brands:"},
+			{name: "BrandsAttachedToEnemy", label: "# of Brands attached to the enemy:"},
+			{name: "BrandsInLastQuarter", label: "Last 25% of Attached Duration?"},
+		},
+	},
+	{
+		name: "Carrion Golem",
+		options: []configOptionSchema{
+			{name: "carrionGolemNearbyMinion", label: "# of Nearby Non-Golem Minions:"},
+		},
+	},
+	{
+		name: "Close Combat",
+		options: []configOptionSchema{
+			{name: "closeCombatCombatRush", label: "Is Combat Rush active?", tooltip: "Combat Rush grants 20% more Attack Speed to Travel Skills not Supported by Close Combat."},
+		},
+	},
+	{
+		name: "Cruelty",
+		options: []configOptionSchema{
+			{name: "overrideCruelty", label: "Damage % (if not maximum):", tooltip: "Cruelty is a buff provided by Cruelty Support which grants\nup to 40% more damage over time to the skills it supports."},
+		},
+	},
+	{
+		name: "Cyclone",
+		options: []configOptionSchema{
+			{name: "channellingCycloneCheck", label: "Are you Channelling Cyclone?"},
+		},
+	},
+	{
+		name: "Dark Pact",
+		options: []configOptionSchema{
+			{name: "darkPactSkeletonLife", label: "Skeleton Life:", tooltip: "Sets the maximum Life of the Skeleton that is being targeted."},
+		},
+	},
+	{
+		name: "Predator",
+		options: []configOptionSchema{
+			{name: "deathmarkDeathmarkActive", label: "Is the enemy marked with Signal Prey?"},
+		},
+	},
+	{
+		name: "Elemental Army",
+		options: []configOptionSchema{
+			{
+				name:  "elementalArmyExposureType",
+				label: "Exposure Type:",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: "Fire", Label: "Fire"},
+					{Value: "Cold", Label: "Cold"},
+					{Value: "Lightning", Label: "Lightning"},
+				},
+			},
+		},
+	},
+	{
+		name: "Energy Blade",
+		options: []configOptionSchema{
+			{name: "energyBladeActive", label: "Is Energy Blade active?", tooltip: "Energy Blade transforms your weapons into Swords formed from energy"},
+		},
+	},
+	{
+		name: "Embrace Madness",
+		options: []configOptionSchema{
+			{name: "embraceMadnessActive", label: "Is Embrace Madness active?"},
+		},
+	},
+	{
+		name: "Feeding Frenzy",
+		options: []configOptionSchema{
+			{name: "feedingFrenzyFeedingFrenzyActive", label: "Is Feeding Frenzy active?", tooltip: "Feeding Frenzy grants:\n\t10% more Minion Damage\n\t10% increased Minion Movement Speed\n\t10% increased Minion Attack and Cast Speed"},
+		},
+	},
+	{
+		name: "Flame Wall",
+		options: []configOptionSchema{
+			{name: "flameWallAddedDamage", label: "Projectile Travelled through Flame Wall?"},
+		},
+	},
+	{
+		name: "Frostbolt",
+		options: []configOptionSchema{
+			{name: "frostboltExposure", label: "Can you apply Exposure?"},
+		},
+	},
+	{
+		name: "Frost Shield",
+		options: []configOptionSchema{
+			{name: "frostShieldStages", label: "Stages:"},
+		},
+	},
+	{
+		name: "Greater Harbinger of Time",
+		options: []configOptionSchema{
+			{name: "greaterHarbingerOfTimeSlipstream", label: "Is Slipstream active?:", tooltip: "Greater Harbinger of Time Slipstream buff grants:\n10% increased Action Speed\nBuff affects the player and allies\nBuff has a base duration of 8s with a 10s Cooldown"},
+		},
+	},
+	{
+		name: "Harbinger of Time",
+		options: []configOptionSchema{
+			{name: "harbingerOfTimeSlipstream", label: "Is Slipstream active?:", tooltip: "Harbinger of Time Slipstream buff grants:\n10% increased Action Speed\nBuff affects the player, allies and enemies in a small radius\nBuff has a base duration of 8s with a 20s Cooldown"},
+		},
+	},
+	{
+		name: "Hex",
+		options: []configOptionSchema{
+			{name: "multiplierHexDoom", label: "Doom on Hex:"},
+		},
+	},
+	{
+		name: "Herald of Agony",
+		options: []configOptionSchema{
+			{name: "heraldOfAgonyVirulenceStack", label: "# of Virulence Stacks:"},
+		},
+	},
+	{
+		name: "Ice Nova",
+		options: []configOptionSchema{
+			{name: "iceNovaCastOnFrostbolt", label: "Cast on Frostbolt?"},
+		},
+	},
+	{
+		name: "Infusion",
+		options: []configOptionSchema{
+			{name: "infusedChannellingInfusion", label: "Is Infusion active?"},
+		},
+	},
+	{
+		name: "Innervate",
+		options: []configOptionSchema{
+			{name: "innervateInnervation", label: "Is Innervation active?"},
+		},
+	},
+	{
+		name: "Intensify",
+		options: []configOptionSchema{
+			{name: "intensifyIntensity", label: "# of Intensity:"},
+		},
+	},
+	{
+		name: "Meat Shield",
+		options: []configOptionSchema{
+			{name: "meatShieldEnemyNearYou", label: "Is the enemy near you?"},
+		},
+	},
+	{
+		name: "Plague Bearer",
+		options: []configOptionSchema{
+			{
+				name:  "plagueBearerState",
+				label: "State:",
+				values: []ConfigValue{
+					{Value: "INC", Label: "Incubating"},
+					{Value: "INF", Label: "Infecting"},
+				},
+			},
+		},
+	},
+	{
+		name: "Perforate",
+		options: []configOptionSchema{
+			{name: "perforateSpikeOverlap", label: "# of Overlapping Spikes:", tooltip: "Affects the DPS of Perforate in Blood Stance.\nMaximum is limited by the number of Spikes of Perforate."},
+		},
+	},
+	{
+		name: "Physical Aegis",
+		options: []configOptionSchema{
+			{name: "physicalAegisDepleted", label: "Is Physical Aegis depleted?"},
+		},
+	},
+	{
+		name: "Pride",
+		options: []configOptionSchema{
+			{
+				name:  "prideEffect",
+				label: "Pride Aura Effect:",
+				values: []ConfigValue{
+					{Value: "MIN", Label: "Initial effect"},
+					{Value: "MAX", Label: "Maximum effect"},
+				},
+			},
+		},
+	},
+	{
+		name: "Rage Vortex",
+		options: []configOptionSchema{
+			{name: "sacrificedRageCount", label: "Amount of Rage Sacrificed?"},
+		},
+	},
+	{
+		name: "Raise Spectre",
+		options: []configOptionSchema{
+			{name: "raiseSpectreEnableBuffs", label: "Enable buffs:", tooltip: "Enable any buff skills that your spectres have."},
+			{name: "raiseSpectreEnableCurses", label: "Enable curses:", tooltip: "Enable any curse skills that your spectres have."},
+			{name: "raiseSpectreBladeVortexBladeCount", label: "Blade Vortex blade count:", tooltip: "Sets the blade count for Blade Vortex skills used by spectres.\nDefault is 1; maximum is 5."},
+			{name: "raiseSpectreKaomFireBeamTotemStage", label: "Scorching Ray Totem stage count:"},
+			{name: "raiseSpectreEnableSummonedUrsaRallyingCry", label: "Enable Summoned Ursa's Rallying Cry:"},
+		},
+	},
+	{
+		name: "Raise Spiders",
+		options: []configOptionSchema{
+			{name: "raiseSpidersSpiderCount", label: "# of Spiders:"},
+			{name: "animateWeaponLingeringBlade", label: "Are you animating Lingering Blades?", tooltip: "Enables additional damage given to Lingering Blades\nThe exact weapon is unknown but should be similar to Glass Shank"},
+		},
+	},
+	{
+		name: "Sigil of Power",
+		options: []configOptionSchema{
+			{name: "sigilOfPowerStages", label: "Stages:"},
+		},
+	},
+	{
+		name: "Siphoning Trap",
+		options: []configOptionSchema{
+			{name: "siphoningTrapAffectedEnemies", label: "# of Enemies affected:", tooltip: "Sets the number of enemies affected by Siphoning Trap."},
+		},
+	},
+	{
+		name: "Snipe",
+		options: []configOptionSchema{
+			{name: "configSnipeStages", label: "# of Snipe stages:", tooltip: "Sets the number of stages reached before releasing Snipe."},
+		},
+	},
+	{
+		name: "Trinity Support",
+		options: []configOptionSchema{
+			{name: "configResonanceCount", label: "Lowest Resonance Count:", tooltip: "Sets the amount of resonance on the lowest element."},
+		},
+	},
+	{
+		name: "Spectral Wolf",
+		options: []configOptionSchema{
+			{name: "configSpectralWolfCount", label: "# of Active Spectral Wolves:", tooltip: "Sets the number of active Spectral Wolves.\nThe maximum number of Spectral Wolves is 10."},
+		},
+	},
+	{
+		name: "Stance Skills",
+		options: []configOptionSchema{
+			{
+				name:  "bloodSandStance",
+				label: "Stance:",
+				values: []ConfigValue{
+					{Value: "BLOOD", Label: "Blood Stance"},
+					{Value: "SAND", Label: "Sand Stance"},
+				},
+			},
+			{name: "changedStance", label: "Changed Stance recently?"},
+		},
+	},
+	{
+		name: "Steel Skills",
+		options: []configOptionSchema{
+			{name: "shardsConsumed", label: "Steel Shards consumed:"},
+			{name: "steelWards", label: "Steel Wards:", tooltip: "Steel Wards are gained from using Shattering Steel with at least 2 Steel Shards.\nYou can have up to 6 Steel Wards, and each grants +4% chance to Block Projectile Attack Damage."},
+		},
+	},
+	{
+		name: "Storm Rain",
+		options: []configOptionSchema{
+			{name: "stormRainBeamOverlap", label: "# of Overlapping Beams:"},
+		},
+	},
+	{
+		name: "Summon Holy Relic",
+		options: []configOptionSchema{
+			{name: "summonHolyRelicEnableHolyRelicBoon", label: "Enable Holy Relic's Boon Aura:"},
+		},
+	},
+	{
+		name: "Summon Lightning Golem",
+		options: []configOptionSchema{
+			{name: "summonLightningGolemEnableWrath", label: "Enable Wrath Aura:"},
+		},
+	},
+	{
+		name: "Thirst for Blood",
+		options: []configOptionSchema{
+			{name: "nearbyBleedingEnemies", label: "# of Nearby Bleeding Enemies:"},
+		},
+	},
+	{
+		name: "Toxic Rain",
+		options: []configOptionSchema{
+			{name: "toxicRainPodOverlap", label: "# of Overlapping Pods:", tooltip: "Maximum is limited by the number of Projectiles."},
+		},
+	},
+	{
+		name: "Herald of Ash",
+		options: []configOptionSchema{
+			{name: "hoaOverkill", label: "Overkill damage:", tooltip: "Herald of Ash's base Burning damage is equal to 25% of Overkill damage."},
+		},
+	},
+	{
+		name: "Voltaxic Burst",
+		options: []configOptionSchema{
+			{name: "voltaxicBurstSpellsQueued", label: "# of Casts currently waiting:"},
+		},
+	},
+	{
+		name: "Vortex",
+		options: []configOptionSchema{
+			{name: "vortexCastOnFrostbolt", label: "Cast on Frostbolt?"},
+		},
+	},
+	{
+		name: "Cold Snap",
+		options: []configOptionSchema{
+			{name: "ColdSnapBypassCD", label: "Bypass CD?"},
+		},
+	},
+	{
+		name: "Warcry Skills",
+		options: []configOptionSchema{
+			{name: "multiplierWarcryPower", label: "Warcry Power:", tooltip: "Power determines how strong your Warcry buffs will be, and is based on the total strength of nearby enemies.\nPower is assumed to be 20 if your target is a Boss, but you can override it here if necessary.\n\tEach Normal enemy grants 1 Power\n\tEach Magic enemy grants 2 Power\n\tEach Rare enemy grants 10 Power\n\tEach Unique enemy grants 20 Power"},
+		},
+	},
+	{
+		name: "Wave of Conviction",
+		options: []configOptionSchema{
+			{
+				name:  "waveOfConvictionExposureType",
+				label: "Exposure Type:",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: "Fire", Label: "Fire"},
+					{Value: "Cold", Label: "Cold"},
+					{Value: "Lightning", Label: "Lightning"},
+				},
+			},
+		},
+	},
+	{
+		name: "Molten Shell",
+		options: []configOptionSchema{
+			{name: "MoltenShellDamageMitigated", label: "Damage mitigated:", tooltip: "Molten Shell reflects damage to the enemy,\nbased on the amount of damage it has mitigated."},
+		},
+	},
+	{
+		name: "Vaal Molten Shell",
+		options: []configOptionSchema{
+			{name: "VaalMoltenShellDamageMitigated", label: "Damage mitigated:", tooltip: "Vaal Molten Shell reflects damage to the enemy,\nbased on the amount of damage it has mitigated in the last second."},
+		},
+	},
+	{
+		name: "Map Prefix Modifiers",
+		options: []configOptionSchema{
+			{
+				name:    "enemyHasPhysicalReduction",
+				label:   "Enemy Physical Damage reduction:",
+				tooltip: "'Armoured'",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: float64(20), Label: "20% (Low tier)"},
+					{Value: float64(30), Label: "30% (Mid tier)"},
+					{Value: float64(40), Label: "40% (High tier)"},
+				},
+			},
+			{name: "enemyIsHexproof", label: "Enemy is Hexproof?", tooltip: "'Hexproof'"},
+			{
+				name:    "enemyHasLessCurseEffectOnSelf",
+				label:   "Less effect of Curses on enemy:",
+				tooltip: "'Hexwarded'",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: float64(25), Label: "25% (Low tier)"},
+					{Value: float64(40), Label: "40% (Mid tier)"},
+					{Value: float64(60), Label: "60% (High tier)"},
+				},
+			},
+			{
+				name:    "enemyCanAvoidPoisonBlindBleed",
+				label:   "Enemy avoid Poison / Blind / Bleed:",
+				tooltip: "'Impervious'",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: float64(25), Label: "25% (Low tier)"},
+					{Value: float64(45), Label: "45% (Mid tier)"},
+					{Value: float64(65), Label: "65% (High tier)"},
+				},
+			},
+			{
+				name:    "enemyHasResistances",
+				label:   "Enemy has Elemental / Chaos Resist:",
+				tooltip: "'Resistant'",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: "LOW", Label: "20% / 15% (Low tier)"},
+					{Value: "MID", Label: "30% / 20% (Mid tier)"},
+					{Value: "HIGH", Label: "40% / 25% (High tier)"},
+				},
+			},
+		},
+	},
+	{
+		name: "Map Suffix Modifiers",
+		options: []configOptionSchema{
+			{name: "playerHasElementalEquilibrium", label: "Player has Elemental Equilibrium?", tooltip: "'of Balance'"},
+			{name: "playerCannotLeech", label: "Cannot Leech Life / Mana?", tooltip: "'of Congealment'"},
+			{
+				name:    "playerGainsReducedFlaskCharges",
+				label:   "Gains reduced Flask Charges:",
+				tooltip: "'of Drought'",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: float64(30), Label: "30% (Low tier)"},
+					{Value: float64(40), Label: "40% (Mid tier)"},
+					{Value: float64(50), Label: "50% (High tier)"},
+				},
+			},
+			{name: "playerHasMinusMaxResist", label: "-X% maximum Resistances:", tooltip: "'of Exposure'\nMid tier: 5-8%\nHigh tier: 9-12%"},
+			{
+				name:    "playerHasLessAreaOfEffect",
+				label:   "Less Area of Effect:",
+				tooltip: "'of Impotence'",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: float64(15), Label: "15% (Low tier)"},
+					{Value: float64(20), Label: "20% (Mid tier)"},
+					{Value: float64(25), Label: "25% (High tier)"},
+				},
+			},
+			{
+				name:    "enemyCanAvoidStatusAilment",
+				label:   "Enemy avoid Elem. Status Ailments:",
+				tooltip: "'of Insulation'",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: float64(30), Label: "30% (Low tier)"},
+					{Value: float64(60), Label: "60% (Mid tier)"},
+					{Value: float64(90), Label: "90% (High tier)"},
+				},
+			},
+			{
+				name:    "enemyHasIncreasedAccuracy",
+				label:   "Unlucky Dodge / Enemy has inc. Accuracy:",
+				tooltip: "'of Miring'",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: float64(30), Label: "30% (Low tier)"},
+					{Value: float64(40), Label: "40% (Mid tier)"},
+					{Value: float64(50), Label: "50% (High tier)"},
+				},
+			},
+			{
+				name:    "playerHasLessArmourAndBlock",
+				label:   "Reduced Block Chance / less Armour:",
+				tooltip: "'of Rust'",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: "LOW", Label: "20% / 20% (Low tier)"},
+					{Value: "MID", Label: "30% / 25% (Mid tier)"},
+					{Value: "HIGH", Label: "40% / 30% (High tier)"},
+				},
+			},
+			{name: "playerHasPointBlank", label: "Player has Point Blank?", tooltip: "'of Skirmishing'"},
+			{
+				name:    "playerHasLessLifeESRecovery",
+				label:   "Less Recovery Rate of Life and Energy Shield:",
+				tooltip: "'of Smothering'",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: float64(20), Label: "20% (Low tier)"},
+					{Value: float64(40), Label: "40% (Mid tier)"},
+					{Value: float64(60), Label: "60% (High tier)"},
+				},
+			},
+			{name: "playerCannotRegenLifeManaEnergyShield", label: "Cannot Regen Life, Mana or ES?", tooltip: "'of Stasis'"},
+			{name: "enemyTakesReducedExtraCritDamage", label: "Enemy takes red. Extra Crit Damage:", tooltip: "'of Toughness'\nLow tier: 25-30%\nMid tier: 31-35%\nHigh tier: 36-40%"},
+			{name: "multiplierSextant", label: "# of Sextants affecting the area"},
+		},
+	},
+	{
+		name: "Player is cursed by",
+		options: []configOptionSchema{
+			{name: "playerCursedWithAssassinsMark", label: "Assassin's Mark:", tooltip: "Sets the level of Assassin's Mark to apply to the player."},
+			{name: "playerCursedWithConductivity", label: "Conductivity:", tooltip: "Sets the level of Conductivity to apply to the player."},
+			{name: "playerCursedWithDespair", label: "Despair:", tooltip: "Sets the level of Despair to apply to the player."},
+			{name: "playerCursedWithElementalWeakness", label: "Elemental Weakness:", tooltip: "Sets the level of Elemental Weakness to apply to the player.\nIn mid tier maps, 'of Elemental Weakness' applies level 10.\nIn high tier maps, 'of Elemental Weakness' applies level 15."},
+			{name: "playerCursedWithEnfeeble", label: "Enfeeble:", tooltip: "Sets the level of Enfeeble to apply to the player.\nIn mid tier maps, 'of Enfeeblement' applies level 10.\nIn high tier maps, 'of Enfeeblement' applies level 15."},
+			{name: "playerCursedWithFlammability", label: "Flammability:", tooltip: "Sets the level of Flammability to apply to the player."},
+			{name: "playerCursedWithFrostbite", label: "Frostbite:", tooltip: "Sets the level of Frostbite to apply to the player."},
+			{name: "playerCursedWithPoachersMark", label: "Poacher's Mark:", tooltip: "Sets the level of Poacher's Mark to apply to the player."},
+			{name: "playerCursedWithProjectileWeakness", label: "Projectile Weakness:", tooltip: "Sets the level of Projectile Weakness to apply to the player."},
+			{name: "playerCursedWithPunishment", label: "Punishment:", tooltip: "Sets the level of Punishment to apply to the player."},
+			{name: "playerCursedWithTemporalChains", label: "Temporal Chains:", tooltip: "Sets the level of Temporal Chains to apply to the player.\nIn mid tier maps, 'of Temporal Chains' applies level 10.\nIn high tier maps, 'of Temporal Chains' applies level 15."},
+			{name: "playerCursedWithVulnerability", label: "Vulnerability:", tooltip: "Sets the level of Vulnerability to apply to the player.\nIn mid tier maps, 'of Vulnerability' applies level 10.\nIn high tier maps, 'of Vulnerability' applies level 15."},
+			{name: "playerCursedWithWarlordsMark", label: "Warlord's Mark:", tooltip: "Sets the level of Warlord's Mark to apply to the player."},
+		},
+	},
+	{
+		name: "When In Combat",
+		options: []configOptionSchema{
+			{name: "usePowerCharges", label: "Do you use Power Charges?"},
+			{name: "overridePowerCharges", label: "# of Power Charges (if not maximum):"},
+			{name: "useFrenzyCharges", label: "Do you use Frenzy Charges?"},
+			{name: "overrideFrenzyCharges", label: "# of Frenzy Charges (if not maximum):"},
+			{name: "useEnduranceCharges", label: "Do you use Endurance Charges?"},
+			{name: "overrideEnduranceCharges", label: "# of Endurance Charges (if not maximum):"},
+			{name: "useSiphoningCharges", label: "Do you use Siphoning Charges?"},
+			{name: "overrideSiphoningCharges", label: "# of Siphoning Charges (if not maximum):"},
+			{name: "useChallengerCharges", label: "Do you use Challenger Charges?"},
+			{name: "overrideChallengerCharges", label: "# of Challenger Charges (if not maximum):"},
+			{name: "useBlitzCharges", label: "Do you use Blitz Charges?"},
+			{name: "overrideBlitzCharges", label: "# of Blitz Charges (if not maximum):"},
+			{name: "multiplierGaleForce", label: "# of Gale Force:", tooltip: "Base maximum Gale Force is 10."},
+			{name: "overrideInspirationCharges", label: "# of Inspiration Charges (if not maximum):"},
+			{name: "useGhostShrouds", label: "Do you use Ghost Shrouds?"},
+			{name: "overrideGhostShrouds", label: "# of Ghost Shrouds (if not maximum):"},
+			{name: "waitForMaxSeals", label: "Do you wait for Max Unleash Seals?"},
+			{name: "overrideBloodCharges", label: "# of Blood Charges (if not maximum):"},
+			{name: "minionsUsePowerCharges", label: "Do your Minions use Power Charges?"},
+			{name: "minionsUseFrenzyCharges", label: "Do your Minions use Frenzy Charges?"},
+			{name: "minionsUseEnduranceCharges", label: "Do your Minions use Endur. Charges?"},
+			{name: "minionsOverridePowerCharges", label: "# of Power Charges (if not maximum):"},
+			{name: "minionsOverrideFrenzyCharges", label: "# of Frenzy Charges (if not maximum):"},
+			{name: "minionsOverrideEnduranceCharges", label: "# of Endurance Charges (if not maximum):"},
+			{name: "multiplierRampage", label: "# of Rampage Kills:", tooltip: "Rampage grants the following, up to 1000 stacks:\n\t1% increased Movement Speed per 20 Rampage\n\t2% increased Damage per 20 Rampage\nYou lose Rampage if you do not get a Kill within 5 seconds."},
+			{name: "conditionFocused", label: "Are you Focused?"},
+			{name: "buffLifetap", label: "Do you have Lifetap?"},
+			{name: "buffOnslaught", label: "Do you have Onslaught?", tooltip: "In addition to allowing any 'while you have Onslaught' modifiers to apply,\nthis will enable the Onslaught buff itself. (Grants 20% increased Attack, Cast, and Movement Speed)"},
+			{name: "minionBuffOnslaught", label: "Do your minions have Onslaught?", tooltip: "In addition to allowing any 'while your minions have Onslaught' modifiers to apply,\nthis will enable the Onslaught buff itself. (Grants 20% increased Attack, Cast, and Movement Speed)"},
+			{name: "buffUnholyMight", label: "Do you have Unholy Might?", tooltip: "This will enable the Unholy Might buff. (Grants 30% of Physical Damage as Extra Chaos Damage)"},
+			{name: "minionbuffUnholyMight", label: "Do your minions have Unholy Might?", tooltip: "This will enable the Unholy Might buff on your minions. (Grants 30% of Physical Damage as Extra Chaos Damage)"},
+			{name: "buffPhasing", label: "Do you have Phasing?"},
+			{name: "buffFortification", label: "Are you Fortified?"},
+			{name: "overrideFortification", label: "# of Fortification Stacks (if not maximum):", tooltip: "You have 1% less damage taken from hits per stack of fortification:\nHas a default cap of 20 stacks."},
+			{name: "buffTailwind", label: "Do you have Tailwind?", tooltip: "In addition to allowing any 'while you have Tailwind' modifiers to apply,\nthis will enable the Tailwind buff itself. (Grants 8% increased Action Speed)"},
+			{name: "buffAdrenaline", label: "Do you have Adrenaline?", tooltip: "This will enable the Adrenaline buff, which grants:\n\t100% increased Damage\n\t25% increased Attack, Cast and Movement Speed\n\t10% additional Physical Damage Reduction"},
+			{name: "buffAlchemistsGenius", label: "Do you have Alchemist's Genius?", tooltip: "This will enable the Alchemist's Genius buff:\n20% increased Flask Charges gained\n10% increased effect of Flasks"},
+			{name: "buffVaalArcLuckyHits", label: "Do you have Vaal Arc's Lucky Buff?", tooltip: "Causes Damage with Arc Hits to be rolled twice, and the maximum roll used."},
+			{name: "buffElusive", label: "Are you Elusive?", tooltip: "In addition to allowing any 'while Elusive' modifiers to apply,\nthis will enable the Elusive buff itself:\n\t15% Chance to Avoid all Damage from Hits\n\t30% increased Movement Speed\nThe effect of Elusive decays over time."},
+			{name: "overrideBuffElusive", label: "Effect of Elusive (if not maximum):", tooltip: "If you have a guaranteed source of Elusive, the strongest one will apply. \nYou can change this to see decaying buff values"},
+			{name: "buffDivinity", label: "Do you have Divinity?", tooltip: "This will enable the Divinity buff, which grants:\n\t50% more Elemental Damage\n\t20% less Elemental Damage taken"},
+			{name: "multiplierDefiance", label: "Defiance:"},
+			{name: "multiplierRage", label: "Rage:", tooltip: "Base Maximum Rage is 50, and inherently grants the following:\n\t1% increased Attack Damage per 1 Rage\n\t1% increased Attack Speed per 2 Rage\n\t1% increased Movement Speed per 5 Rage\nYou lose 1 Rage every 0.5 seconds if you have not been Hit or gained Rage Recently."},
+			{name: "conditionLeeching", label: "Are you Leeching?", tooltip: "You will automatically be considered to be Leeching if you have 'Life Leech effects are not removed at Full Life',\nbut you can use this option to force it if necessary."},
+			{name: "conditionLeechingLife", label: "Are you Leeching Life?"},
+			{name: "conditionLeechingEnergyShield", label: "Are you Leeching Energy Shield?"},
+			{name: "conditionLeechingMana", label: "Are you Leeching Mana?"},
+			{name: "conditionUsingFlask", label: "Do you have a Flask active?", tooltip: "This is automatically enabled if you have a flask active,\nbut you can use this option to force it if necessary."},
+			{name: "conditionHaveTotem", label: "Do you have a Totem summoned?", tooltip: "You will automatically be considered to have a Totem if your main skill is a Totem,\nbut you can use this option to force it if necessary."},
+			{name: "conditionSummonedTotemRecently", label: "Have you Summoned a Totem Recently?", tooltip: "You will automatically be considered to have Summoned a Totem Recently if your main skill is a Totem,\nbut you can use this option to force it if necessary."},
+			{name: "TotemsSummoned", label: "# of Summoned Totems (if not maximum):", tooltip: "This also implies that you have a Totem summoned.\nThis will affect all 'per Summoned Totem' modifiers, even for non-Totem skills."},
+			{name: "conditionSummonedGolemInPast8Sec", label: "Summoned a Golem in the past 8 Seconds?"},
+			{name: "conditionSummonedGolemInPast10Sec", label: "Summoned a Golem in the past 10 Seconds?"},
+			{name: "multiplierNearbyAlly", label: "# of Nearby Allies:"},
+			{name: "multiplierNearbyCorpse", label: "# of Nearby Corpses:"},
+			{name: "multiplierSummonedMinion", label: "# of Summoned Minions:"},
+			{name: "conditionOnConsecratedGround", label: "Are you on Consecrated Ground?", tooltip: "In addition to allowing any 'while on Consecrated Ground' modifiers to apply,\nConsecrated Ground grants 5% Life Regeneration to players and allies."},
+			{name: "conditionOnFungalGround", label: "Are you on Fungal Ground?", tooltip: "Allies on your Fungal Ground gain 10% of Non-Chaos Damage as extra Chaos Damage."},
+			{name: "conditionOnBurningGround", label: "Are you on Burning Ground?", tooltip: "This also implies that you are Burning."},
+			{name: "conditionOnChilledGround", label: "Are you on Chilled Ground?", tooltip: "This also implies that you are Chilled."},
+			{name: "conditionOnShockedGround", label: "Are you on Shocked Ground?", tooltip: "This also implies that you are Shocked."},
+			{name: "conditionBlinded", label: "Are you Blinded?"},
+			{name: "conditionBurning", label: "Are you Burning?"},
+			{name: "conditionIgnited", label: "Are you Ignited?", tooltip: "This also implies that you are Burning."},
+			{name: "conditionChilled", label: "Are you Chilled?"},
+			{name: "conditionChilledEffect", label: "Effect of Chill:"},
+			{name: "conditionSelfChill", label: "Did you Chill yourself?"},
+			{name: "conditionFrozen", label: "Are you Frozen?", tooltip: "This also implies that you are Chilled."},
+			{name: "conditionShocked", label: "Are you Shocked?"},
+			{name: "conditionBleeding", label: "Are you Bleeding?"},
+			{name: "conditionPoisoned", label: "Are you Poisoned?"},
+			{name: "multiplierPoisonOnSelf", label: "# of Poison on You:", tooltip: "This also implies that you are Poisoned."},
+			{name: "conditionAgainstDamageOverTime", label: "Are you against Damage over Time?"},
+			{name: "multiplierNearbyEnemies", label: "# of nearby Enemies:"},
+			{name: "multiplierNearbyRareOrUniqueEnemies", label: "# of nearby Rare or Unique Enemies:"},
+			{name: "conditionHitRecently", label: "Have you Hit Recently?", tooltip: "You will automatically be considered to have Hit Recently if your main skill Hits and is self-cast,\nbut you can use this option to force it if necessary."},
+			{name: "conditionCritRecently", label: "Have you Crit Recently?", tooltip: "This also implies that your Skills have Crit Recently."},
+			{name: "conditionSkillCritRecently", label: "Have your Skills Crit Recently?"},
+			{name: "conditionCritWithHeraldSkillRecently", label: "Have your Herald Skills Crit Recently?", tooltip: "This also implies that your Skills have Crit Recently."},
+			{name: "LostNonVaalBuffRecently", label: "Lost a Non-Vaal Guard Skill buff recently?"},
+			{name: "conditionNonCritRecently", label: "Have you dealt a Non-Crit Recently?"},
+			{name: "conditionChannelling", label: "Are you Channelling?", tooltip: "You will automatically be considered to be Channeling if your main skill is a channelled skill,\nbut you can use this option to force it if necessary."},
+			{name: "conditionHitRecentlyWithWeapon", label: "Have you Hit Recently with Your Weapon?", tooltip: "This also implies that you have Hit Recently."},
+			{name: "conditionKilledRecently", label: "Have you Killed Recently?"},
+			{name: "multiplierKilledRecently", label: "# of Enemies Killed Recently:", tooltip: "This also implies that you have Killed Recently."},
+			{name: "conditionKilledLast3Seconds", label: "Have you Killed in the last 3 Seconds?", tooltip: "This also implies that you have Killed Recently."},
+			{name: "conditionKilledPosionedLast2Seconds", label: "Killed a poisoned enemy in the last 2 Seconds?", tooltip: "This also implies that you have Killed Recently."},
+			{name: "conditionTotemsNotSummonedInPastTwoSeconds", label: "No summoned Totems in the past 2 seconds?"},
+			{name: "conditionTotemsKilledRecently", label: "Have your Totems Killed Recently?"},
+			{name: "conditionUsedBrandRecently", label: "Have you used a Brand Skill recently?"},
+			{name: "multiplierTotemsKilledRecently", label: "# of Enemies Killed by Totems Recently:", tooltip: "This also implies that your Totems have Killed Recently."},
+			{name: "conditionMinionsKilledRecently", label: "Have your Minions Killed Recently?"},
+			{name: "conditionMinionsDiedRecently", label: "Has a Minion Died Recently?"},
+			{name: "multiplierMinionsKilledRecently", label: "# of Enemies Killed by Minions Recently:", tooltip: "This also implies that your Minions have Killed Recently."},
+			{name: "conditionKilledAffectedByDoT", label: "Killed enemy affected by your DoT Recently?"},
+			{name: "multiplierShockedEnemyKilledRecently", label: "# of Shocked Enemies Killed Recently:"},
+			{name: "conditionFrozenEnemyRecently", label: "Have you Frozen an enemy Recently?"},
+			{name: "conditionChilledEnemyRecently", label: "Have you Chilled an enemy Recently?"},
+			{name: "conditionShatteredEnemyRecently", label: "Have you Shattered an enemy Recently?"},
+			{name: "conditionIgnitedEnemyRecently", label: "Have you Ignited an enemy Recently?"},
+			{name: "conditionShockedEnemyRecently", label: "Have you Shocked an enemy Recently?"},
+			{name: "conditionStunnedEnemyRecently", label: "Have you Stunned an enemy Recently?"},
+			{name: "multiplierPoisonAppliedRecently", label: "# of Poisons applied Recently:"},
+			{name: "multiplierLifeSpentRecently", label: "# of Life spent Recently:"},
+			{name: "multiplierManaSpentRecently", label: "# of Mana spent Recently:"},
+			{name: "conditionBeenHitRecently", label: "Have you been Hit Recently?"},
+			{name: "multiplierBeenHitRecently", label: "# of times you have been Hit Recently:"},
+			{name: "conditionBeenHitByAttackRecently", label: "Have you been Hit by an Attack Recently?"},
+			{name: "conditionBeenCritRecently", label: "Have you been Crit Recently?"},
+			{name: "conditionConsumed12SteelShardsRecently", label: "Consumed 12 Steel Shards Recently?"},
+			{name: "conditionGainedPowerChargeRecently", label: "Gained a Power Charge Recently?"},
+			{name: "conditionGainedFrenzyChargeRecently", label: "Gained a Frenzy Charge Recently?"},
+			{name: "conditionBeenSavageHitRecently", label: "Have you taken a Savage Hit Recently?", tooltip: "This also implies that you have been Hit Recently."},
+			{name: "conditionHitByFireDamageRecently", label: "Have you been hit by Fire Recently?", tooltip: "This also implies that you have been Hit Recently."},
+			{name: "conditionHitByColdDamageRecently", label: "Have you been hit by Cold Recently?", tooltip: "This also implies that you have been Hit Recently."},
+			{name: "conditionHitByLightningDamageRecently", label: "Have you been hit by Light. Recently?", tooltip: "This also implies that you have been Hit Recently."},
+			{name: "conditionHitBySpellDamageRecently", label: "Have you taken Spell Damage Recently?", tooltip: "This also implies that you have been Hit Recently."},
+			{name: "conditionTakenFireDamageFromEnemyHitRecently", label: "Taken Fire Damage from enemy Hit Recently?", tooltip: "This also implies that you have been Hit Recently."},
+			{name: "conditionBlockedRecently", label: "Have you Blocked Recently?"},
+			{name: "conditionBlockedAttackRecently", label: "Have you Blocked an Attack Recently?", tooltip: "This also implies that you have Blocked Recently."},
+			{name: "conditionBlockedSpellRecently", label: "Have you Blocked a Spell Recently?", tooltip: "This also implies that you have Blocked Recently."},
+			{name: "conditionEnergyShieldRechargeRecently", label: "Energy Shield Recharge started Recently?"},
+			{name: "conditionStoppedTakingDamageOverTimeRecently", label: "Have you stopped taking DoT recently?"},
+			{name: "conditionConvergence", label: "Do you have Convergence?"},
+			{
+				name:  "buffPendulum",
+				label: "Is Pendulum of Destruction active?",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: "AREA", Label: "Area of Effect"},
+					{Value: "DAMAGE", Label: "Elemental Damage"},
+				},
+			},
+			{
+				name:  "buffConflux",
+				label: "Conflux Buff:",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: "CHILLING", Label: "Chilling"},
+					{Value: "SHOCKING", Label: "Shocking"},
+					{Value: "IGNITING", Label: "Igniting"},
+					{Value: "ALL", Label: "Chill + Shock + Ignite"},
+				},
+			},
+			{name: "buffBastionOfHope", label: "Is Bastion of Hope active?"},
+			{name: "buffNgamahuFlamesAdvance", label: "Is Ngamahu, Flame's Advance active?"},
+			{name: "buffHerEmbrace", label: "Are you in Her Embrace?", tooltip: "This option is specific to Oni-Goroshi."},
+			{name: "conditionUsedSkillRecently", label: "Have you used a Skill Recently?"},
+			{name: "multiplierSkillUsedRecently", label: "# of Skills Used Recently:"},
+			{name: "conditionAttackedRecently", label: "Have you Attacked Recently?", tooltip: "This also implies that you have used a Skill Recently.\nYou will automatically be considered to have Attacked Recently if your main skill is an attack,\nbut you can use this option to force it if necessary."},
+			{name: "conditionCastSpellRecently", label: "Have you Cast a Spell Recently?", tooltip: "This also implies that you have used a Skill Recently.\nYou will automatically be considered to have Cast a Spell Recently if your main skill is a spell,\nbut you can use this option to force it if necessary."},
+			{name: "conditionCastLast1Seconds", label: "Have you Cast a Spell in the last second?"},
+			{name: "multiplierCastLast8Seconds", label: "How many spells cast in the last 8 seconds?", tooltip: "Only non-instant spells you cast count"},
+			{name: "conditionUsedFireSkillRecently", label: "Have you used a Fire Skill Recently?", tooltip: "This also implies that you have used a Skill Recently."},
+			{name: "conditionUsedColdSkillRecently", label: "Have you used a Cold Skill Recently?", tooltip: "This also implies that you have used a Skill Recently."},
+			{name: "conditionUsedMinionSkillRecently", label: "Have you used a Minion Skill Recently?", tooltip: "This also implies that you have used a Skill Recently.\nYou will automatically be considered to have used a Minion skill Recently if your main skill is a Minion skill,\nbut you can use this option to force it if necessary."},
+			{name: "conditionUsedTravelSkillRecently", label: "Have you used a Travel Skill Recently?", tooltip: "This also implies that you have used a Skill Recently+"},
+			{name: "conditionUsedDashRecently", label: "Have you cast Dash Recently?", tooltip: "This also implies that you have used a Skill Recently+"},
+			{name: "conditionUsedMovementSkillRecently", label: "Have you used a Movement Skill Recently?", tooltip: "This also implies that you have used a Skill Recently.\nYou will automatically be considered to have used a Movement skill Recently if your main skill is a movement skill,\nbut you can use this option to force it if necessary."},
+			{name: "conditionUsedVaalSkillRecently", label: "Have you used a Vaal Skill Recently?", tooltip: "This also implies that you have used a Skill Recently.\nYou will automatically be considered to have used a Vaal skill Recently if your main skill is a Vaal skill,\nbut you can use this option to force it if necessary."},
+			{name: "conditionSoulGainPrevention", label: "Do you have Soul Gain Prevention?"},
+			{name: "conditionUsedWarcryRecently", label: "Have you used a Warcry Recently?", tooltip: "This also implies that you have used a Skill Recently."},
+			{name: "conditionUsedWarcryInPast8Seconds", label: "Used a Warcry in the past 8 seconds?"},
+			{name: "multiplierMineDetonatedRecently", label: "# of Mines Detonated Recently:"},
+			{name: "multiplierTrapTriggeredRecently", label: "# of Traps Triggered Recently:"},
+			{name: "conditionThrownTrapOrMineRecently", label: "Have you thrown a Trap or Mine Recently?"},
+			{name: "conditionCursedEnemyRecently", label: "Have you Cursed an enemy Recently?"},
+			{name: "conditionCastMarkRecently", label: "Have you cast a Mark Spell Recently?"},
+			{name: "conditionSpawnedCorpseRecently", label: "Spawned a corpse Recently?"},
+			{name: "conditionConsumedCorpseRecently", label: "Consumed a corpse Recently?"},
+			{name: "conditionConsumedCorpseInPast2Sec", label: "Consumed a corpse in the past 2s?", tooltip: "This also implies you have 'Consumed a corpse Recently'"},
+			{name: "multiplierCorpseConsumedRecently", label: "# of Corpses Consumed Recently:"},
+			{name: "multiplierWarcryUsedRecently", label: "# of Warcries Used Recently:", tooltip: "This also implies you have 'Used a Warcry Recently', 'Used a Warcry in the past 8 seconds', and 'Used a Skill Recently'"},
+			{name: "conditionTauntedEnemyRecently", label: "Taunted an enemy Recently?"},
+			{name: "conditionLostEnduranceChargeInPast8Sec", label: "Lost an Endurance Charge in the past 8s?"},
+			{name: "multiplierEnduranceChargesLostRecently", label: "# of Endurance Charges lost Recently:"},
+			{name: "conditionBlockedHitFromUniqueEnemyInPast10Sec", label: "Blocked a Hit from a Unique enemy in the past 10s?"},
+			{name: "BlockedPast10Sec", label: "Number of times you've Blocked in the past 10s"},
+			{name: "conditionImpaledRecently", label: "Impaled an enemy recently?"},
+			{name: "multiplierImpalesOnEnemy", label: "# of Impales on enemy (if not maximum):"},
+			{name: "multiplierBleedsOnEnemy", label: "# of Bleeds on enemy (if not maximum):", tooltip: "Sets current number of Bleeds on the enemy if using the Crimson Dance keystone.\nThis also implies that the enemy is Bleeding."},
+			{name: "multiplierFragileRegrowth", label: "# of Fragile Regrowth Stacks:"},
+			{name: "conditionKilledUniqueEnemy", label: "Killed a Rare or Unique enemy Recently?"},
+			{name: "conditionHaveArborix", label: "Do you have Iron Reflexes?", tooltip: "This option is specific to Arborix."},
+			{
+				name:    "conditionHaveAugyre",
+				label:   "Augyre rotating buff:",
+				tooltip: "This option is specific to Augyre.",
+				values: []ConfigValue{
+					{Value: "EleOverload", Label: "Elemental Overload"},
+					{Value: "ResTechnique", Label: "Resolute Technique"},
+				},
+			},
+			{name: "conditionHaveVulconus", label: "Do you have Avatar Of Fire?", tooltip: "This option is specific to Vulconus."},
+			{name: "conditionHaveManaStorm", label: "Do you have Manastorm's Lightning Buff?", tooltip: "This option enables Manastorm's Lightning Damage Buff.\n(When you cast a Spell, Sacrifice all Mana to gain Added Maximum Lightning Damage\nequal to 25% of Sacrificed Mana for 4 seconds)"},
+			{name: "buffFanaticism", label: "Do you have Fanaticism?", tooltip: "This will enable the Fanaticism buff itself. (Grants 75% more cast speed, reduced mana cost, and increased area of effect)"},
+		},
+	},
+	{
+		name: "For Effective DPS",
+		options: []configOptionSchema{
+			{name: "critChanceLucky", label: "Is your Crit Chance Lucky?"},
+			{name: "skillForkCount", label: "# of times Skill has Forked:"},
+			{name: "skillChainCount", label: "# of times Skill has Chained:"},
+			{name: "skillPierceCount", label: "# of times Skill has Pierced:"},
+			{name: "meleeDistance", label: "Melee distance to enemy:"},
+			{name: "projectileDistance", label: "Projectile travel distance:"},
+			{name: "conditionAtCloseRange", label: "Is the enemy at Close Range?"},
+			{name: "conditionEnemyMoving", label: "Is the enemy Moving?"},
+			{name: "conditionEnemyFullLife", label: "Is the enemy on Full Life?"},
+			{name: "conditionEnemyLowLife", label: "Is the enemy on Low Life?"},
+			{name: "conditionEnemyCursed", label: "Is the enemy Cursed?", tooltip: "The enemy will automatically be considered to be Cursed if you have at least one curse enabled,\nbut you can use this option to force it if necessary."},
+			{name: "conditionEnemyBleeding", label: "Is the enemy Bleeding?"},
+			{name: "multiplierRuptureStacks", label: "# of Rupture stacks?", tooltip: "Rupture applies 25% more bleed damage and 25% faster bleeds for 3 seconds, up to 3 stacks"},
+			{name: "conditionEnemyPoisoned", label: "Is the enemy Poisoned?"},
+			{name: "multiplierPoisonOnEnemy", label: "# of Poison on enemy:"},
+			{name: "multiplierWitheredStackCount", label: "# of Withered Stacks:", tooltip: "Withered applies 6% increased Chaos Damage Taken to the enemy, up to 15 stacks."},
+			{name: "multiplierCorrosionStackCount", label: "# of Corrosion Stacks:", tooltip: "Each stack of Corrosion applies -5000 to total Armour and -1000 to total Evasion Rating to the enemy.\nCorrosion lasts 4 seconds and refreshes the duration of existing Corrosion stacks\nCorrosion has no stack limit"},
+			{name: "multiplierEnsnaredStackCount", label: "# of Ensnare Stacks:", tooltip: "While ensnared, enemies take increased Projectile Damage from Attack Hits\nEnsnared enemies always count as moving, and have less movement speed while trying to break the snare."},
+			{name: "conditionEnemyMaimed", label: "Is the enemy Maimed?"},
+			{name: "conditionEnemyHindered", label: "Is the enemy Hindered?"},
+			{name: "conditionEnemyBlinded", label: "Is the enemy Blinded?", tooltip: "In addition to allowing 'against Blinded Enemies' modifiers to apply,\n Blind applies the following effects.\n -20% Accuracy \n -20% Evasion"},
+			{name: "overrideBuffBlinded", label: "Effect of Blind (if not maximum):", tooltip: "If you have a guaranteed source of Blind, the strongest one will apply."},
+			{name: "conditionEnemyTaunted", label: "Is the enemy Taunted?"},
+			{name: "conditionEnemyBurning", label: "Is the enemy Burning?"},
+			{name: "conditionEnemyIgnited", label: "Is the enemy Ignited?", tooltip: "This also implies that the enemy is Burning."},
+			{name: "conditionEnemyScorched", label: "Is the enemy Scorched?", tooltip: "Scorched enemies have lowered elemental resistances, up to -30%.\nThis option will also allow you to input the effect of Scorched."},
+			{name: "conditionScorchedEffect", label: "Effect of Scorched:", tooltip: "This effect will only be applied while you can inflict Scorched."},
+			{name: "conditionEnemyOnScorchedGround", label: "Is the enemy on Scorched Ground?", tooltip: "This also implies that the enemy is Scorched."},
+			{name: "conditionEnemyChilled", label: "Is the enemy Chilled?"},
+			{name: "conditionEnemyChilledEffect", label: "Effect of Chill:"},
+			{name: "conditionEnemyChilledByYourHits", label: "Is the enemy Chilled by your Hits?"},
+			{name: "conditionEnemyFrozen", label: "Is the enemy Frozen?", tooltip: "This also implies that the enemy is Chilled."},
+			{name: "conditionEnemyBrittle", label: "Is the enemy Brittle?", tooltip: "Hits against Brittle enemies have up to +15% Critical Strike Chance.\nThis option will also allow you to input the effect of Brittle."},
+			{name: "conditionBrittleEffect", label: "Effect of Brittle:", tooltip: "This effect will only be applied while you can inflict Brittle."},
+			{name: "conditionEnemyOnBrittleGround", label: "Is the enemy on Brittle Ground?", tooltip: "This also implies that the enemy is Brittle."},
+			{name: "conditionEnemyShocked", label: "Is the enemy Shocked?", tooltip: "In addition to allowing any 'against Shocked Enemies' modifiers to apply,\nthis will allow you to input the effect of the Shock applied to the enemy."},
+			{name: "conditionShockEffect", label: "Effect of Shock:", tooltip: "If you have a guaranteed source of Shock,\nthe strongest one will apply instead unless this option would apply a stronger Shock."},
+			{name: "conditionEnemyOnShockedGround", label: "Is the enemy on Shocked Ground?", tooltip: "This also implies that the enemy is Shocked."},
+			{name: "conditionEnemySapped", label: "Is the enemy Sapped?", tooltip: "Sapped enemies deal less damage, up to 20%."},
+			{name: "conditionSapEffect", label: "Effect of Sap:", tooltip: "If you have a guaranteed source of Sap,\nthe strongest one will apply instead unless this option would apply a stronger Sap."},
+			{name: "conditionEnemyOnSappedGround", label: "Is the enemy on Sapped Ground?", tooltip: "This also implies that the enemy is Sapped."},
+			{name: "multiplierFreezeShockIgniteOnEnemy", label: "# of Freeze / Shock / Ignite on enemy:"},
+			{name: "conditionEnemyFireExposure", label: "Is the enemy Exposed to Fire?", tooltip: "This applies -10% Fire Resistance to the enemy."},
+			{name: "conditionEnemyColdExposure", label: "Is the enemy Exposed to Cold?", tooltip: "This applies -10% Cold Resistance to the enemy."},
+			{name: "conditionEnemyLightningExposure", label: "Is the enemy Exposed to Lightning?", tooltip: "This applies -10% Lightning Resistance to the enemy."},
+			{name: "conditionEnemyIntimidated", label: "Is the enemy Intimidated?", tooltip: "Intimidated enemies take 10% increased Attack Damage."},
+			{name: "conditionEnemyCrushed", label: "Is the enemy Crushed?", tooltip: "Crushed enemies have 15% reduced Physical Damage Reduction."},
+			{name: "conditionNearLinkedTarget", label: "Is the enemy near you Linked target?"},
+			{name: "conditionEnemyUnnerved", label: "Is the enemy Unnerved?", tooltip: "Unnerved enemies take 10% increased Spell Damage."},
+			{name: "conditionEnemyCoveredInAsh", label: "Is the enemy covered in Ash?", tooltip: "Covered in Ash applies the following to the enemy:\n\t20% increased Fire Damage taken\n\t20% less Movement Speed"},
+			{name: "conditionEnemyCoveredInFrost", label: "Is the enemy covered in Frost?", tooltip: "Covered in Frost applies the following to the enemy:\n\t20% increased Cold Damage taken\n\t50% less Critical Strike Chance"},
+			{name: "conditionEnemyOnConsecratedGround", label: "Is the enemy on Consecrated Ground?"},
+			{name: "conditionEnemyOnProfaneGround", label: "Is the enemy on Profane Ground?", tooltip: "Enemies on Profane Ground receive the following modifiers:\n\t-10% to all Resistances\n\t+1% chance to be Critically Hit"},
+			{name: "multiplierEnemyAffectedByGraspingVines", label: "# of Grasping Vines affecting enemy:"},
+			{name: "conditionEnemyOnFungalGround", label: "Is the enemy on Fungal Ground?", tooltip: "Enemies on your Fungal Ground deal 10% less Damage."},
+			{name: "conditionEnemyInChillingArea", label: "Is the enemy in a Chilling area?"},
+			{name: "conditionEnemyInFrostGlobe", label: "Is the enemy in the Frost Shield area?"},
+			{name: "enemyConditionHitByFireDamage", label: "Enemy was Hit by Fire Damage?"},
+			{name: "enemyConditionHitByColdDamage", label: "Enemy was Hit by Cold Damage?"},
+			{name: "enemyConditionHitByLightningDamage", label: "Enemy was Hit by Light. Damage?"},
+			{name: "EEIgnoreHitDamage", label: "Ignore Skill Hit Damage?", tooltip: "This option prevents EE from being reset by the hit damage of your main skill."},
+		},
+	},
+	{
+		name: "Enemy Stats",
+		options: []configOptionSchema{
+			{name: "enemyLevel", label: "Enemy Level:", tooltip: "This overrides the default enemy level used to estimate your hit and evade chances.\nThe default level is your character level, capped at 85, which is the same value\nused in-game to calculate the stats on the character sheet."},
+			{name: "conditionEnemyRareOrUnique", label: "Is the enemy Rare or Unique?", tooltip: "The enemy will automatically be considered to be Unique if they are a Boss,\nbut you can use this option to force it if necessary."},
+			{
+				name:    "enemyIsBoss",
+				label:   "Is the enemy a Boss?",
+				tooltip: "\nBosses' damage is monster damage scaled to an average damage of their attacks\nThis is divided by 4.25 to represent 4 damage types + some chaos\nFill in the exact damage numbers if more precision is needed\n\nStandard Boss adds the following modifiers:\n\t33% less Effect of your Hexes\n\t+40% to enemy Elemental Resistances\n\t+25% to enemy Chaos Resistance\n\t94% of monster damage\n\nGuardian / Pinnacle Boss adds the following modifiers:\n\t66% less Effect of your Hexes\n\t+50% to enemy Elemental Resistances\n\t+30% to enemy Chaos Resistance\n\t+33% to enemy Armour\n\t188% of monster damage\n\t5% penetration\n\nUber Pinnacle Boss adds the following modifiers:\n\t66% less Effect of your Hexes\n\t+50% to enemy Elemental Resistances\n\t+30% to enemy Chaos Resistance\n\t+100% to enemy Armour\n\t70% less to enemy Damage taken\n\t235% of monster damage\n\t8% penetration\n\t",
+				values: []ConfigValue{
+					{Value: "None", Label: "No"},
+					{Value: "Boss", Label: "Standard Boss"},
+					{Value: "Pinnacle", Label: "Guardian/Pinnacle Boss"},
+					{Value: "Uber", Label: "Uber Pinnacle Boss"},
+				},
+			},
+			{
+				name:    "deliriousPercentage",
+				label:   "Delirious Effect:",
+				tooltip: "Delirium scales enemy 'less Damage Taken' as well as enemy 'increased Damage dealt'\nAt 100% effect:\nEnemies Deal 30% Increased Damage\nEnemies take 96% Less Damage",
+				values: []ConfigValue{
+					{Value: float64(0), Label: "None"},
+					{Value: "20Percent", Label: "20% Delirious"},
+					{Value: "40Percent", Label: "40% Delirious"},
+					{Value: "60Percent", Label: "60% Delirious"},
+					{Value: "80Percent", Label: "80% Delirious"},
+					{Value: "100Percent", Label: "100% Delirious"},
+				},
+			},
+			{name: "enemyPhysicalReduction", label: "Enemy Phys. Damage Reduction:"},
+			{name: "enemyLightningResist", label: "Enemy Lightning Resistance:"},
+			{name: "enemyColdResist", label: "Enemy Cold Resistance:"},
+			{name: "enemyFireResist", label: "Enemy Fire Resistance:"},
+			{name: "enemyChaosResist", label: "Enemy Chaos Resistance:"},
+			{
+				name:    "presetBossSkills",
+				label:   "Boss Skill Preset",
+				tooltip: "\nUsed to fill in defaults for specific boss skills if the boss config is not set\n\nBosses' damage is assumed at a 2/3 roll, with no Atlas passives, at the normal monster level for your character level (capped at 84)\nFill in the exact damage numbers if more precision is needed\n\nCaveats for certain skills are below\n\nShaper Ball: Allocating Cosmic Wounds increases the penetration to 40% and adds 2 projectiles\nShaper Slam: Cannot be Evaded.  Allocating Cosmic Wounds doubles the damage and cannot be blocked or dodged\nMaven Memory Game: Is three separate hits, and has a large DoT effect.  Neither is taken into account here.  i.e. Hits before death should be >: 4 to survive",
+				values: []ConfigValue{
+					{Value: "None", Label: "None"},
+					{Value: "Uber Atziri Flameblast", Label: "Uber Atziri Flameblast"},
+					{Value: "Shaper Ball", Label: "Shaper Ball"},
+					{Value: "Shaper Slam", Label: "Shaper Slam"},
+					{Value: "Maven Memory Game", Label: "Maven Memory Game"},
+				},
+			},
+			{
+				name:    "enemyDamageType",
+				label:   "Enemy Damage Type:",
+				tooltip: "Controls which types of damage the EHP calculation uses:\n\tAverage: uses the Average of all damage types\n\nIf a specific damage type is selected, that will be the only type used.",
+				values: []ConfigValue{
+					{Value: "Average", Label: "Average"},
+					{Value: "Melee", Label: "Melee"},
+					{Value: "Projectile", Label: "Projectile"},
+					{Value: "Spell", Label: "Spell"},
+					{Value: "SpellProjectile", Label: "Projectile Spell"},
+				},
+			},
+			{name: "enemySpeed", label: "Enemy attack / cast time in ms:"},
+			{name: "enemyCritChance", label: "Enemy critical strike chance:"},
+			{name: "enemyCritDamage", label: "Enemy critical strike multipler:"},
+			{name: "enemyPhysicalDamage", label: "Enemy Skill Physical Damage:", tooltip: "This overrides the default damage amount used to estimate your damage reduction from armour.\nThe default is 1.5 times the enemy's base damage, which is the same value\nused in-game to calculate the estimate shown on the character sheet."},
+			{name: "enemyLightningDamage", label: "Enemy Skill Lightning Damage:"},
+			{name: "enemyLightningPen", label: "Enemy Skill Lightning Pen:"},
+			{name: "enemyColdDamage", label: "Enemy Skill Cold Damage:"},
+			{name: "enemyColdPen", label: "Enemy Skill Cold Pen:"},
+			{name: "enemyFireDamage", label: "Enemy Skill Fire Damage:"},
+			{name: "enemyFirePen", label: "Enemy Skill Fire Pen:"},
+			{name: "enemyChaosDamage", label: "Enemy Skill Chaos Damage:"},
+			{name: "enemyArmour", label: "Enemy Base Armour:"},
+			{name: "enemyEvasion", label: "Enemy Base Evasion:"},
+		},
+	},
+}
@@ -12,7 +12,10 @@ import (
 
 	"github.com/Vilsol/go-pob/builds"
 	"github.com/Vilsol/go-pob/config"
+	"github.com/Vilsol/go-pob/data"
 	"github.com/Vilsol/go-pob/data/raw"
+	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/moddb"
 	"github.com/Vilsol/go-pob/pob"
 	"github.com/Vilsol/go-pob/utils"
 )
@@ -41,7 +44,8 @@ func TestOnslaught(t *testing.T) {
 	})
 
 	calculator := &Calculator{PoB: build}
-	env := calculator.BuildOutput(OutputModeMain)
+	env, err := calculator.BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
 
 	testza.AssertEqual(t, 0.9523809523809523, env.Player.OutputTable[OutTableMainHand]["TotalMin"])
 	testza.AssertEqual(t, 2.8571428571428568, env.Player.OutputTable[OutTableMainHand]["TotalMax"])
@@ -49,3 +53,194 @@ func TestOnslaught(t *testing.T) {
 	testza.AssertEqual(t, 1.8857142857142855, env.Player.OutputTable[OutTableMainHand]["AverageDamage"])
 	testza.AssertEqual(t, 2.715428571428571, env.Player.OutputTable[OutTableMainHand]["TotalDPS"])
 }
+
+func TestConfigOptions(t *testing.T) {
+	onslaught := GetConfigOption("buffOnslaught")
+	testza.AssertNotNil(t, onslaught)
+	testza.AssertEqual(t, ConfigTypeBool, onslaught.Type)
+	testza.AssertEqual(t, false, onslaught.Default)
+	testza.AssertNil(t, GetConfigOption("doesNotExist"))
+
+	modList := moddb.NewModList()
+	enemyModList := moddb.NewModList()
+	onslaught.Apply(false, modList, enemyModList)
+	onslaught.Apply(nil, modList, enemyModList)
+	testza.AssertLen(t, modList.List(nil, "Condition:Onslaught"), 0)
+
+	resistances := GetConfigOption("enemyHasResistances")
+	testza.AssertEqual(t, ConfigTypeList, resistances.Type)
+	testza.AssertEqual(t, "Map Prefix Modifiers", resistances.Section)
+	testza.AssertEqual(t, []ConfigValue{
+		{Value: float64(0), Label: "None"},
+		{Value: "LOW", Label: "20% / 15% (Low tier)"},
+		{Value: "MID", Label: "30% / 20% (Mid tier)"},
+		{Value: "HIGH", Label: "40% / 25% (High tier)"},
+	}, resistances.Values)
+	resistances.Apply("MID", modList, enemyModList)
+	testza.AssertEqual(t, float64(30), enemyModList.Sum(mod.TypeBase, nil, "ElementalResist"))
+	testza.AssertEqual(t, float64(20), enemyModList.Sum(mod.TypeBase, nil, "ChaosResist"))
+
+	stationary := GetConfigOption("conditionStationary")
+	testza.AssertEqual(t, ConfigTypeNumber, stationary.Type)
+	stationary.Apply(true, modList, enemyModList)
+	testza.AssertEqual(t, float64(1), modList.Sum(mod.TypeBase, nil, "Multiplier:StationarySeconds"))
+	testza.AssertTrue(t, modList.Flag(nil, "Condition:Stationary"))
+	testza.AssertEqual(t, "Time spent stationary", stationary.Label)
+	testza.AssertNotEqual(t, "", stationary.Tooltip)
+}
+
+func TestConfigSections(t *testing.T) {
+	sections := ConfigSections()
+	testza.AssertEqual(t, "General", sections[0].Name)
+	testza.AssertEqual(t, "resistancePenalty", sections[0].Options[0].Name)
+
+	// Every option of the schema is implemented
+	for _, section := range configSections {
+		for _, schema := range section.options {
+			testza.AssertNotNil(t, GetConfigOption(schema.name), section.name+": "+schema.name)
+		}
+	}
+
+	// Every option is shown exactly once and list options can be selected from
+	shown := make(map[string]bool)
+	for _, section := range sections {
+		for _, option := range section.Options {
+			testza.AssertFalse(t, shown[option.Name], option.Name)
+			shown[option.Name] = true
+			testza.AssertEqual(t, section.Name, option.Section)
+			testza.AssertNotEqual(t, "", option.Label, option.Name)
+			if option.Type == ConfigTypeList {
+				testza.AssertGreater(t, len(option.Values), 1, option.Name)
+			}
+		}
+	}
+	for _, option := range ConfigOptions() {
+		testza.AssertTrue(t, shown[option.Name], option.Name)
+	}
+
+	testza.AssertEqual(t, []ConfigValue{
+		{Value: "MIN", Label: "Initial effect"},
+		{Value: "MAX", Label: "Maximum effect"},
+	}, GetConfigOption("prideEffect").Values)
+}
+
+func TestEnemyConfig(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	build.SetLevel(95)
+	env, _, _, _, err := InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, 85, env.EnemyLevel)
+	testza.AssertEqual(t, float64(0), env.EnemyModDB.Sum(mod.TypeBase, nil, "FireResist"))
+
+	// Unset options fall back to their defaults, zero defaults are reported as unset
+	worstOf, ok := env.configNumber("EHPUnluckyWorstOf")
+	testza.AssertTrue(t, ok)
+	testza.AssertEqual(t, float64(1), worstOf)
+	_, ok = env.configNumber("enemyLevel")
+	testza.AssertFalse(t, ok)
+	testza.AssertTrue(t, env.configBool("raiseSpectreEnableBuffs"))
+	testza.AssertEqual(t, "Average", env.configString("enemyDamageType"))
+
+	build.SetConfigOption(pob.Input{Name: "enemyIsBoss", String: utils.Ptr("Pinnacle")})
+	env, _, _, _, err = InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, 84, env.EnemyLevel)
+	testza.AssertEqual(t, float64(50), env.EnemyModDB.Sum(mod.TypeBase, nil, "FireResist"))
+	testza.AssertEqual(t, float64(30), env.EnemyModDB.Sum(mod.TypeBase, nil, "ChaosResist"))
+	testza.AssertEqual(t, float64(data.PinnacleBossPen), env.ConfigPlaceholder["enemyFirePen"])
+	testza.AssertTrue(t, env.EnemyModDB.Flag(nil, "Condition:PinnacleBoss"))
+
+	build.SetConfigOption(pob.Input{Name: "enemyFireResist", Number: utils.Ptr[float64](10)})
+	build.SetConfigOption(pob.Input{Name: "enemyLevel", Number: utils.Ptr[float64](150)})
+	build.SetConfigOption(pob.Input{Name: "enemyArmour", Number: utils.Ptr[float64](1000)})
+	env, _, _, _, err = InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, data.MaxEnemyLevel, env.EnemyLevel)
+	testza.AssertEqual(t, float64(10), env.EnemyModDB.Sum(mod.TypeBase, nil, "FireResist"))
+	testza.AssertEqual(t, float64(1000), env.EnemyModDB.Sum(mod.TypeBase, nil, "Armour"))
+
+	// Boss skills change the damage type without replacing the input of the build
+	build.SetConfigOption(pob.Input{Name: "presetBossSkills", String: utils.Ptr("Shaper Ball")})
+	env, _, _, _, err = InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, "SpellProjectile", env.configString("enemyDamageType"))
+	testza.AssertNil(t, env.ConfigInput["enemyDamageType"])
+
+	build.SetConfigOption(pob.Input{Name: "enemyDamageType", String: utils.Ptr("Melee")})
+	env, _, _, _, err = InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, "Melee", env.configString("enemyDamageType"))
+}
+
+// spectreBladeVortex calculates the Blade Vortex skill used by spectres as the main skill of the build
+func spectreBladeVortex(t *testing.T, build *pob.PathOfBuilding) *Environment {
+	t.Helper()
+
+	env, _, _, _, err := InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	grantedEffect := poe.GrantedEffectByID("DemonModularBladeVortexSpectre")
+	testza.AssertNotNil(t, grantedEffect)
+
+	baseFlags, skillTypes := TypesToFlagsAndTypes(grantedEffect.GetActiveSkill().GetActiveSkillTypes())
+	activeEffect := &GemEffect{
+		GrantedEffect: &GrantedEffect{
+			Raw:        grantedEffect,
+			SkillTypes: skillTypes,
+			BaseFlags:  baseFlags,
+		},
+		Level: 1,
+	}
+	activeSkill := CreateActiveSkill(activeEffect, []*GemEffect{}, env.Player, nil, nil)
+	CalcBuildActiveSkillModList(env, activeSkill)
+	env.Player.ActiveSkillList = append(env.Player.ActiveSkillList, activeSkill)
+	env.Player.MainSkill = activeSkill
+
+	PerformCalc(env)
+	return env
+}
+
+func TestSpectreBladeVortexBladeCount(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	env := spectreBladeVortex(t, build)
+	dps := env.Player.Output["TotalDPS"]
+	testza.AssertGreater(t, dps, 0)
+	testza.AssertNil(t, env.Player.MainSkill.SkillData["DpsMultiplier"])
+
+	build.SetConfigOption(pob.Input{Name: "raiseSpectreBladeVortexBladeCount", Number: utils.Ptr[float64](5)})
+	env = spectreBladeVortex(t, build)
+	testza.AssertEqual(t, float64(5), env.Player.MainSkill.SkillData["DpsMultiplier"])
+	testza.AssertInRange(t, env.Player.Output["TotalDPS"], dps*5-0.001, dps*5+0.001)
+
+	// Only the spectre skill is affected by the blade count
+	for _, activeSkill := range env.Player.ActiveSkillList[:len(env.Player.ActiveSkillList)-1] {
+		testza.AssertNil(t, activeSkill.SkillData["DpsMultiplier"])
+	}
+}
+
+func TestResistancePenalty(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	env, err := NewCalculator(*build).BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, float64(-60), env.Player.Output["FireResist"])
+
+	build.SetConfigOption(pob.Input{Name: "resistancePenalty", Number: utils.Ptr[float64](-30)})
+	env, err = NewCalculator(*build).BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, float64(-30), env.Player.Output["FireResist"])
+}
@@ -1,1457 +1,1473 @@
 package calculator
 
 import (
+	"math"
+
 	"github.com/Vilsol/go-pob/mod"
 	"github.com/Vilsol/go-pob/moddb"
 )
 
 type ConfigApplyFunc func(val interface{}, modList *moddb.ModList, enemyModList *moddb.ModList)
 
-var configurations = map[string]ConfigApplyFunc{
-	/*
-		   	"detonateDeadCorpseLife": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewList("SkillData", mod.SkillData{
-		   			Key:   "corpseLife",
-		   			Value: val,
-		   		}).Source("Config"))
-		   	},
-		   	"conditionStationary": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   	out := float64(0)
-		   		if v, ok := val.(bool); ok && v {
-		   			// Backwards compatibility with older versions that set this condition as a boolean
-					out = 1
-		   		}
-		   		sanitizedValue := math.Max(0, out)
-		   		modList.AddMod(mod.NewFloat("Multiplier:StationarySeconds", mod.TypeBase, sanitizedValue).Source("Config"))
-		   		if sanitizedValue > 0 {
-		   			modList.AddMod(mod.NewFlag("Condition:Stationary", true).Source("Config"))
-		   		}
-		   	},
-		   "conditionMoving": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:Moving", true).Source("Config"))
-		   	},
-		   "conditionInsane": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:Insane", true).Source("Config"))
-		   	},
-		   "conditionFullLife": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:FullLife", true).Source("Config"))
-		   	},
-		   "conditionLowLife": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:LowLife", true).Source("Config"))
-		   	},
-		   "conditionFullMana": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:FullMana", true).Source("Config"))
-		   	},
-		   "conditionLowMana": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:LowMana", true).Source("Config"))
-		   	},
-		   "conditionFullEnergyShield": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:FullEnergyShield", true).Source("Config"))
-		   	},
-		   "conditionLowEnergyShield": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:LowEnergyShield", true).Source("Config"))
-		   	},
-		   "conditionHaveEnergyShield": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:HaveEnergyShield", true).Source("Config"))
-		   	},
-		   "minionsConditionFullLife": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewList("MinionModifier", { mod = modLib.createMod("Condition:FullLife", "FLAG", true).Source("Config")) }, "Config")
-		   	},
-		   "minionsConditionCreatedRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:MinionsCreatedRecently", true).Source("Config"))
-		   	},
-		   "lifeRegenMode": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val == "AVERAGE" {
-		   			modList.AddMod(mod.NewFlag("Condition:LifeRegenBurstAvg", true).Source("Config"))
-		   		} else if val == "FULL" {
-		   			modList.AddMod(mod.NewFlag("Condition:LifeRegenBurstFull", true).Source("Config"))
-		   		}
-		   	},
-		   "armourCalculationMode": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val == "MAX" {
-		   			modList.AddMod(mod.NewFlag("Condition:ArmourMax", true).Source("Config"))
-		   		} else if val == "AVERAGE" {
-		   			modList.AddMod(mod.NewFlag("Condition:ArmourAvg", true).Source("Config"))
-		   		}
-		   	},
-		   "warcryMode": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val == "MAX" {
-		   			modList.AddMod(mod.NewFlag("Condition:WarcryMaxHit", true).Source("Config"))
-		   		}
-		   	},
-		   "EVBypass": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:EVBypass", true).Source("Config"))
-		   	},
-		   "targetBrandedEnemy": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:TargetingBrandedEnemy", true).Source("Config"))
-		   	},
-		   "aspectOfTheAvianAviansMight": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:AviansMightActive", true).Source("Config"))
-		   	},
-		   "aspectOfTheAvianAviansFlight": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:AviansFlightActive", true).Source("Config"))
-		   	},
-		   "aspectOfTheCatCatsStealth": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:CatsStealthActive", true).Source("Config"))
-		   	},
-		   "aspectOfTheCatCatsAgility": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:CatsAgilityActive", true).Source("Config"))
-		   	},
-		   "overrideCrabBarriers": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("CrabBarriers", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "aspectOfTheSpiderWebStacks": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("ExtraSkillMod", "LIST", { mod = modLib.createMod("Multiplier:SpiderWebApplyStack", "BASE", val) }, "Config", { type = "SkillName", skillName = "Aspect of the Spider" })
-		   	},
-		   "bannerPlanted": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:BannerPlanted", true).Source("Config"))
-		   	},
-		   "bannerStages": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Multiplier:BannerStage", "BASE", math.Min(val, 50), "Config")
-		   	},
-		   "bladestormInBloodstorm": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Condition:BladestormInBloodstorm", "FLAG", true, "Config", { type = "SkillName", skillName = "Bladestorm" })
-		   	},
-		   "bladestormInSandstorm": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Condition:BladestormInSandstorm", "FLAG", true, "Config", { type = "SkillName", skillName = "Bladestorm" })
-		   	},
-		   "bonechillEffect": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		enemyModList.AddMod(mod.NewFloat("BonechillEffect", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
-		   		enemyModList.AddMod(mod.NewFloat("DesiredBonechillEffect", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
-		   	},
-		   "boneshatterTraumaStacks": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:TraumaStacks", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "ActiveBrands": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:ConfigActiveBrands", mod.TypeBase, val).Source("Config"))
-		   	},
-		   "BrandsAttachedToEnemy": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:ConfigBrandsAttachedToEnemy", mod.TypeBase, val).Source("Config"))
-		   	},
-		   "BrandsInLastQuarter": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:BrandLastQuarter", true).Source("Config"))
-		   	},
-		   "carrionGolemNearbyMinion": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:NearbyNonGolemMinion", mod.TypeBase, val).Source("Config"))
-		   	},
-		   "closeCombatCombatRush": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:CombatRushActive", true).Source("Config"))
-		   	},
-		   "overrideCruelty": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Cruelty", "OVERRIDE", math.Min(val, 40), "Config", { type = "Condition", var = "Combat" })
-		   	},
-		   "channellingCycloneCheck": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:ChannellingCyclone", true).Source("Config"))
-		   	},
-		   "darkPactSkeletonLife": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("SkillData", "LIST", { key = "skeletonLife", value = val }, "Config", { type = "SkillName", skillName = "Dark Pact" })
-		   	},
-		   "deathmarkDeathmarkActive": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:EnemyHasDeathmark", true).Source("Config"))
-		   	},
-		   "elementalArmyExposureType": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val == "Fire" {
-		   			modList.AddMod(mod.NewFloat("FireExposureChance", mod.TypeBase, 100).Source("Config"))
-		   		} else if val == "Cold" {
-		   			modList.AddMod(mod.NewFloat("ColdExposureChance", mod.TypeBase, 100).Source("Config"))
-		   		} else if val == "Lightning" {
-		   			modList.AddMod(mod.NewFloat("LightningExposureChance", mod.TypeBase, 100).Source("Config"))
-		   		}
-		   	},
-		   "energyBladeActive": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:EnergyBladeActive", true).Source("Config"))
-		   	},
-		   "embraceMadnessActive": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:AffectedByGloriousMadness", true).Source("Config"))
-		   	},
-		   "feedingFrenzyFeedingFrenzyActive": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:FeedingFrenzyActive", true).Source("Config"))
-		   		modList.AddMod(mod.NewList("MinionModifier", { mod = modLib.createMod("Damage", "MORE", 10).Source("Feeding Frenzy")) }, "Config")
-		   		modList.AddMod(mod.NewList("MinionModifier", { mod = modLib.createMod("MovementSpeed", "INC", 10).Source("Feeding Frenzy")) }, "Config")
-		   		modList.AddMod(mod.NewList("MinionModifier", { mod = modLib.createMod("Speed", "INC", 10).Source("Feeding Frenzy")) }, "Config")
-		   	},
-		   "flameWallAddedDamage": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:FlameWallAddedDamage", true).Source("Config"))
-		   	},
-		   "frostboltExposure": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("ColdExposureChance", mod.TypeBase, 20).Source("Config"))
-		   	},
-		   "frostShieldStages": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:FrostShieldStage", mod.TypeBase, val).Source("Config"))
-		   	},
-		   "greaterHarbingerOfTimeSlipstream": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:GreaterHarbingerOfTime", true).Source("Config"))
-		   	},
-		   "harbingerOfTimeSlipstream": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:HarbingerOfTime", true).Source("Config"))
-		   	},
-		   "multiplierHexDoom": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:HexDoomStack", mod.TypeBase, val).Source("Config"))
-		   	},
-		   "heraldOfAgonyVirulenceStack": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:VirulenceStack", mod.TypeBase, val).Source("Config"))
-		   	},
-		   "iceNovaCastOnFrostbolt": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Condition:CastOnFrostbolt", "FLAG", true, "Config", { type = "SkillName", skillName = "Ice Nova" })
-		   	},
-		   "infusedChannellingInfusion": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:InfusionActive", true).Source("Config"))
-		   	},
-		   "innervateInnervation": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:InnervationActive", true).Source("Config"))
-		   	},
-		   "intensifyIntensity": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:Intensity", mod.TypeBase, val).Source("Config"))
-		   	},
-		   "meatShieldEnemyNearYou": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:MeatShieldEnemyNearYou", true).Source("Config"))
-		   	},
-		   "plagueBearerState": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val == "INC" {
-		   			modList.AddMod(mod.NewFlag("Condition:PlagueBearerIncubating", true).Source("Config"))
-		   		} else if val == "INF" {
-		   			modList.AddMod(mod.NewFlag("Condition:PlagueBearerInfecting", true).Source("Config"))
-		   		}
-		   	},
-		   "perforateSpikeOverlap": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Multiplier:PerforateSpikeOverlap", "BASE", val, "Config", { type = "SkillName", skillName = "Perforate" })
-		   	},
-		   "physicalAegisDepleted": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:PhysicalAegisDepleted", true).Source("Config"))
-		   	},
-		   "prideEffect": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val == "MAX" {
-		   			modList.AddMod(mod.NewFlag("Condition:PrideMaxEffect", true).Source("Config"))
-		   		}
-		   	},
-		   "sacrificedRageCount": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:RageSacrificed", mod.TypeBase, val).Source("Config"))
-		   	},
-		   "raiseSpectreEnableBuffs": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("SkillData", "LIST", { key = "enable", value = true }, "Config", { type = "SkillType", skillType = SkillType.Buff }, { type = "SkillName", skillName = "Raise Spectre", summonSkill = true })
-		   	},
-		   "raiseSpectreEnableCurses": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("SkillData", "LIST", { key = "enable", value = true }, "Config", { type = "SkillType", skillType = SkillType.Hex }, { type = "SkillName", skillName = "Raise Spectre", summonSkill = true })
-		   		modList.AddMod("SkillData", "LIST", { key = "enable", value = true }, "Config", { type = "SkillType", skillType = SkillType.Mark }, { type = "SkillName", skillName = "Raise Spectre", summonSkill = true })
-		   	},
-		   "raiseSpectreBladeVortexBladeCount": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("SkillData", "LIST", { key = "dpsMultiplier", value = val }, "Config", { type = "SkillId", skillId = "DemonModularBladeVortexSpectre" })
-		   		modList.AddMod("SkillData", "LIST", { key = "dpsMultiplier", value = val }, "Config", { type = "SkillId", skillId = "GhostPirateBladeVortexSpectre" })
-		   	},
-		   "raiseSpectreKaomFireBeamTotemStage": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:KaomFireBeamTotemStage", mod.TypeBase, val).Source("Config"))
-		   	},
-		   "raiseSpectreEnableSummonedUrsaRallyingCry": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("SkillData", "LIST", { key = "enable", value = true }, "Config", { type = "SkillId", skillId = "DropBearSummonedRallyingCry" })
-		   	},
-		   "raiseSpidersSpiderCount": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Multiplier:RaisedSpider", "BASE", math.Min(val, 20), "Config")
-		   	},
-		   "animateWeaponLingeringBlade": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:AnimatingLingeringBlades", true).Source("Config"))
-		   	},
-		   "sigilOfPowerStages": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:SigilOfPowerStage", mod.TypeBase, val).Source("Config"))
-		   	},
-		   "siphoningTrapAffectedEnemies": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:EnemyAffectedBySiphoningTrap", mod.TypeBase, val).Source("Config"))
-		   		modList.AddMod(mod.NewFlag("Condition:SiphoningTrapSiphoning", true).Source("Config"))
-		   	},
-		   "configSnipeStages": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Multiplier:SnipeStage", "BASE", math.Min(val, 6), "Config")
-		   	},
-		   "configResonanceCount": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Multiplier:ResonanceCount", "BASE", m_max(math.Min(val, 50), 0), "Config")
-		   	},
-		   "configSpectralWolfCount": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Multiplier:SpectralWolfCount", "BASE", math.Min(val, 10), "Config")
-		   	},
-		   "bloodSandStance": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val == "SAND" {
-		   			modList.AddMod(mod.NewFlag("Condition:SandStance", true).Source("Config"))
-		   		}
-		   	},
-		   "changedStance": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:ChangedStanceRecently", true).Source("Config"))
-		   	},
-		   "shardsConsumed": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Multiplier:SteelShardConsumed", "BASE", math.Min(val, 12), "Config")
-		   	},
-		   "steelWards": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:SteelWardCount", mod.TypeBase, val).Source("Config"))
-		   	},
-		   "stormRainBeamOverlap": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("SkillData", "LIST", { key = "beamOverlapMultiplier", value = val }, "Config", { type = "SkillName", skillName = "Storm Rain" })
-		   	},
-		   "summonHolyRelicEnableHolyRelicBoon": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:HolyRelicBoonActive", true).Source("Config"))
-		   	},
-		   "summonLightningGolemEnableWrath": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("SkillData", "LIST", { key = "enable", value = true }, "Config", { type = "SkillId", skillId = "LightningGolemWrath" })
-		   	},
-		   "nearbyBleedingEnemies": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Multiplier:NearbyBleedingEnemies", "BASE", val, "Config" )
-		   	},
-		   "toxicRainPodOverlap": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("SkillData", "LIST", { key = "podOverlapMultiplier", value = val }, "Config", { type = "SkillName", skillName = "Toxic Rain" })
-		   	},
-		   "hoaOverkill": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("SkillData", "LIST", { key = "hoaOverkill", value = val }, "Config", { type = "SkillName", skillName = "Herald of Ash" })
-		   	},
-		   "voltaxicBurstSpellsQueued": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:VoltaxicWaitingStages", mod.TypeBase, val).Source("Config"))
-		   	},
-		   "vortexCastOnFrostbolt": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Condition:CastOnFrostbolt", "FLAG", true, "Config", { type = "SkillName", skillName = "Vortex" })
-		   	},
-		   "ColdSnapBypassCD": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("CooldownRecovery", "OVERRIDE", 0, "Config", { type = "SkillName", skillName = "Cold Snap" })
-		   	},
-		   "multiplierWarcryPower": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("WarcryPower", mod.TypeOverride, val).Source("Config"))
-		   	},
-		   "waveOfConvictionExposureType": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val == "Fire" {
-		   			modList.AddMod(mod.NewFlag("Condition:WaveOfConvictionFireExposureActive", true).Source("Config"))
-		   		} else if val == "Cold" {
-		   			modList.AddMod(mod.NewFlag("Condition:WaveOfConvictionColdExposureActive", true).Source("Config"))
-		   		} else if val == "Lightning" {
-		   			modList.AddMod(mod.NewFlag("Condition:WaveOfConvictionLightningExposureActive", true).Source("Config"))
-		   		}
-		   	},
-		   "MoltenShellDamageMitigated": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("SkillData", "LIST", { key = "MoltenShellDamageMitigated", value = val }, "Config", { type = "SkillName", skillName = "Molten Shell" })
-		   	},
-		   "VaalMoltenShellDamageMitigated": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("SkillData", "LIST", { key = "VaalMoltenShellDamageMitigated", value = val }, "Config", { type = "SkillName", skillName = "Molten Shell" })
-		   	},
-		   "enemyHasPhysicalReduction": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		enemyModList.AddMod(mod.NewFloat("PhysicalDamageReduction", mod.TypeBase, val).Source("Config"))
-		   	},
-		   "enemyIsHexproof": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		enemymodList.AddMod(mod.NewFlag("Hexproof", true).Source("Config"))
-		   	},
-		   "enemyHasLessCurseEffectOnSelf": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val != 0 {
-		   			enemyModList.AddMod(mod.NewFloat("CurseEffectOnSelf", mod.TypeMore, -val).Source("Config"))
-		   		}
-		   	},
-		   "enemyCanAvoidPoisonBlindBleed": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val != 0 {
-		   			enemyModList.AddMod(mod.NewFloat("AvoidPoison", mod.TypeBase, val).Source("Config"))
-		   			enemyModList.AddMod(mod.NewFloat("AvoidBleed", mod.TypeBase, val).Source("Config"))
-		   		}
-		   	},
-		   "enemyHasResistances": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		//map := { ["LOW"] = {20,15}, ["MID"] = {30,20}, ["HIGH"] = {40,25} }
-		   		//if map[val] {
-		   		//	enemyModList.AddMod(mod.NewFloat("ElementalResist", mod.TypeBase, map[val][1]).Source("Config"))
-		   		//	enemyModList.AddMod(mod.NewFloat("ChaosResist", mod.TypeBase, map[val][2]).Source("Config"))
-		   		//}
-		   	},
-		   "playerHasElementalEquilibrium": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewList("Keystone", "Elemental Equilibrium").Source("Config"))
-		   	},
-		   "playerCannotLeech": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		enemymodList.AddMod(mod.NewFlag("CannotLeechLifeFromSelf", true).Source("Config"))
-		   		enemymodList.AddMod(mod.NewFlag("CannotLeechManaFromSelf", true).Source("Config"))
-		   	},
-		   "playerGainsReducedFlaskCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val != 0 {
-		   			modList.AddMod(mod.NewFloat("FlaskChargesGained", mod.TypeIncrease, -val).Source("Config"))
-		   		}
-		   	},
-		   "playerHasMinusMaxResist": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val != 0 {
-		   			modList.AddMod(mod.NewFloat("FireResistMax", mod.TypeBase, -val).Source("Config"))
-		   			modList.AddMod(mod.NewFloat("ColdResistMax", mod.TypeBase, -val).Source("Config"))
-		   			modList.AddMod(mod.NewFloat("LightningResistMax", mod.TypeBase, -val).Source("Config"))
-		   			modList.AddMod(mod.NewFloat("ChaosResistMax", mod.TypeBase, -val).Source("Config"))
-		   		}
-		   	},
-		   "playerHasLessAreaOfEffect": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val != 0 {
-		   			modList.AddMod(mod.NewFloat("AreaOfEffect", mod.TypeMore, -val).Source("Config"))
-		   		}
-		   	},
-		   "enemyCanAvoidStatusAilment": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val != 0 {
-		   			enemyModList.AddMod(mod.NewFloat("AvoidIgnite", mod.TypeBase, val).Source("Config"))
-		   			enemyModList.AddMod(mod.NewFloat("AvoidShock", mod.TypeBase, val).Source("Config"))
-		   			enemyModList.AddMod(mod.NewFloat("AvoidFreeze", mod.TypeBase, val).Source("Config"))
-		   		}
-		   	},
-		   "enemyHasIncreasedAccuracy": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val != 0 {
-		   			modList.AddMod(mod.NewFlag("DodgeChanceIsUnlucky", true).Source("Config"))
-		   			enemyModList.AddMod(mod.NewFloat("Accuracy", mod.TypeIncrease, val).Source("Config"))
-		   		}
-		   	},
-		   "playerHasLessArmourAndBlock": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		//map := { ["LOW"] = {20,20}, ["MID"] = {30,25}, ["HIGH"] = {40,30} }
-		   		//if map[val] {
-		   		//	modList.AddMod(mod.NewFloat("BlockChance", mod.TypeIncrease, -map[val][1]).Source("Config"))
-		   		//	modList.AddMod(mod.NewFloat("Armour", mod.TypeMore, -map[val][2]).Source("Config"))
-		   		//}
-		   	},
-		   "playerHasPointBlank": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewList("Keystone", "Point Blank").Source("Config"))
-		   	},
-		   "playerHasLessLifeESRecovery": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val != 0 {
-		   			modList.AddMod(mod.NewFloat("LifeRecoveryRate", mod.TypeMore, -val).Source("Config"))
-		   			modList.AddMod(mod.NewFloat("EnergyShieldRecoveryRate", mod.TypeMore, -val).Source("Config"))
-		   		}
-		   	},
-		   "playerCannotRegenLifeManaEnergyShield": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("NoLifeRegen", true).Source("Config"))
-		   		modList.AddMod(mod.NewFlag("NoEnergyShieldRegen", true).Source("Config"))
-		   		modList.AddMod(mod.NewFlag("NoManaRegen", true).Source("Config"))
-		   	},
-		   "enemyTakesReducedExtraCritDamage": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		if val != 0 {
-		   			enemyModList.AddMod(mod.NewFloat("SelfCritMultiplier", mod.TypeIncrease, -val).Source("Config"))
-		   		}
-		   	},
-		   "multiplierSextant": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Multiplier:Sextant", "BASE", math.Min(val, 5), "Config")
-		   	},
-		   "playerCursedWithAssassinsMark": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("ExtraCurse", "LIST", { skillId = "AssassinsMark", level = val, applyToPlayer = true })
-		   	},
-		   "playerCursedWithConductivity": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("ExtraCurse", "LIST", { skillId = "Conductivity", level = val, applyToPlayer = true })
-		   	},
-		   "playerCursedWithDespair": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("ExtraCurse", "LIST", { skillId = "Despair", level = val, applyToPlayer = true })
-		   	},
-		   "playerCursedWithElementalWeakness": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("ExtraCurse", "LIST", { skillId = "ElementalWeakness", level = val, applyToPlayer = true })
-		   	},
-		   "playerCursedWi{feeble": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("ExtraCurse", "LIST", { skillId = "Enfeeble", level = val, applyToPlayer = true })
-		   	},
-		   "playerCursedWithFlammability": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("ExtraCurse", "LIST", { skillId = "Flammability", level = val, applyToPlayer = true })
-		   	},
-		   "playerCursedWithFrostbite": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("ExtraCurse", "LIST", { skillId = "Frostbite", level = val, applyToPlayer = true })
-		   	},
-		   "playerCursedWithPoachersMark": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("ExtraCurse", "LIST", { skillId = "PoachersMark", level = val, applyToPlayer = true })
-		   	},
-		   "playerCursedWithProjectileWeakness": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("ExtraCurse", "LIST", { skillId = "ProjectileWeakness", level = val, applyToPlayer = true })
-		   	},
-		   "playerCursedWithPunishment": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("ExtraCurse", "LIST", { skillId = "Punishment", level = val, applyToPlayer = true })
-		   	},
-		   "playerCursedWithTemporalChains": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("ExtraCurse", "LIST", { skillId = "TemporalChains", level = val, applyToPlayer = true })
-		   	},
-		   "playerCursedWithVulnerability": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("ExtraCurse", "LIST", { skillId = "Vulnerability", level = val, applyToPlayer = true })
-		   	},
-		   "playerCursedWithWarlordsMark": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("ExtraCurse", "LIST", { skillId = "WarlordsMark", level = val, applyToPlayer = true })
-		   	},
-		   "usePowerCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("UsePowerCharges", true).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "overridePowerCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("PowerCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "useFrenzyCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("UseFrenzyCharges", true).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "overrideFrenzyCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("FrenzyCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "use}uranceCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Use}uranceCharges", true).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "override}uranceCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("}uranceCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "useSiphoningCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("UseSiphoningCharges", true).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "overrideSiphoningCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("SiphoningCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "useChallengerCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("UseChallengerCharges", true).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "overrideChallengerCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("ChallengerCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "useBlitzCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("UseBlitzCharges", true).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "overrideBlitzCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("BlitzCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "multiplierGaleForce": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("Multiplier:GaleForce", "BASE", val, "Config", { type = "IgnoreCond" }, { type = "Condition", var = "Combat" }, { type = "Condition", var = "CanGainGaleForce" })
-		   	},
-		   "overrideInspirationCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("InspirationCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "useGhostShrouds": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("UseGhostShrouds", true).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "overrideGhostShrouds": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("GhostShrouds", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "waitForMaxSeals": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("UseMaxUnleash", true).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "overrideBloodCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("BloodCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "minionsUsePowerCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("MinionModifier", "LIST", { mod = modLib.createMod("UsePowerCharges", "FLAG", true, "Config", { type = "Condition", var = "Combat" }) }, "Config")
-		   	},
-		   "minionsUseFrenzyCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("MinionModifier", "LIST", { mod = modLib.createMod("UseFrenzyCharges", "FLAG", true, "Config", { type = "Condition", var = "Combat" }) }, "Config")
-		   	},
-		   "minionsUse}uranceCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("MinionModifier", "LIST", { mod = modLib.createMod("Use}uranceCharges", "FLAG", true, "Config", { type = "Condition", var = "Combat" }) }, "Config")
-		   	},
-		   "minionsOverridePowerCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("MinionModifier", "LIST", { mod = modLib.createMod("PowerCharges", "OVERRIDE", val, "Config", { type = "Condition", var = "Combat" }) }, "Config")
-		   	},
-		   "minionsOverrideFrenzyCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("MinionModifier", "LIST", { mod = modLib.createMod("FrenzyCharges", "OVERRIDE", val, "Config", { type = "Condition", var = "Combat" }) }, "Config")
-		   	},
-		   "minionsOverride}uranceCharges": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod("MinionModifier", "LIST", { mod = modLib.createMod("}uranceCharges", "OVERRIDE", val, "Config", { type = "Condition", var = "Combat" }) }, "Config")
-		   	},
-		   "multiplierRampage": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFloat("Multiplier:Rampage", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "conditionFocused": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:Focused", true).Source("Config").Tag(mod.Condition("Combat")))
-		   	},
-		   "buffLifetap": func(val interface{}, modList *ModList, enemyModList *ModList) {
-		   		modList.AddMod(mod.NewFlag("Condition:Lifetap", true).Source("Config").Tag(mod.Condition("Combat")))
-		   		modList.AddMod(mod.NewFloat("FlaskLifeRecovery", mod.TypeIncrease, 20).Source("Lifetap"))
-		   	},
-	*/
-	"buffOnslaught": func(val interface{}, modList *moddb.ModList, enemyModList *moddb.ModList) {
-		modList.AddMod(mod.NewFlag("Condition:Onslaught", true).Source("Config").Tag(mod.Condition("Combat")))
-	},
-	/*
-	   "minionBuffOnslaught": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod("MinionModifier", "LIST", { mod = modLib.createMod("Condition:Onslaught", "FLAG", true, "Config", { type = "Condition", var = "Combat" }) })
-	   	},
-	   "buffUnholyMight": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:UnholyMight", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "minionbuffUnholyMight": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod("MinionModifier", "LIST", { mod = modLib.createMod("Condition:UnholyMight", "FLAG", true, "Config", { type = "Condition", var = "Combat" }) })
-	   	},
-	   "buffPhasing": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Phasing", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "buffFortification": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Fortified", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "overrideFortification": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("FortificationStacks", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "buffTailwind": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Tailwind", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "buffAdrenaline": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Adrenaline", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "buffAlchemistsGenius": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod("Condition:AlchemistsGenius", "FLAG", true, "Config", { type = "Condition", var = "Combat" }, { type = "Condition", var = "CanHaveAlchemistGenius" })
-	   	},
-	   "buffVaalArcLuckyHits": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod("LuckyHits", "FLAG", true, "Config", { type = "Condition", varList = { "Combat", "CanBeLucky" } }, { type = "SkillName", skillNameList = { "Arc", "Vaal Arc" } })
-	   	},
-	   "buffElusive": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod("Condition:Elusive", "FLAG", true, "Config", { type = "Condition", var = "Combat" }, { type = "Condition", var = "CanBeElusive" })
-	   		modList.AddMod("Elusive", "FLAG", true, "Config", { type = "Condition", var = "Combat" }, { type = "Condition", var = "CanBeElusive" })
-	   	},
-	   "overrideBuffElusive": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod("ElusiveEffect", "OVERRIDE", val, "Config", {type = "GlobalEffect", effectType = "Buff" })
-	   	},
-	   "buffDivinity": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Divinity", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierDefiance": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod("Multiplier:Defiance", "BASE", math.Min(val, 10), "Config", { type = "Condition", var = "Combat" })
-	   	},
-	   "multiplierRage": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod("Multiplier:RageStack", "BASE", val, "Config", { type = "IgnoreCond" }, { type = "Condition", var = "Combat" }, { type = "Condition", var = "CanGainRage" })
-	   	},
-	   "conditionLeeching": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Leeching", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionLeechingLife": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:LeechingLife", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:Leeching", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionLeechingEnergyShield": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:LeechingEnergyShield", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:Leeching", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionLeechingMana": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:LeechingMana", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:Leeching", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionUsingFlask": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:UsingFlask", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionHaveTotem": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:HaveTotem", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionSummonedTotemRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:SummonedTotemRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "TotemsSummoned": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("TotemsSummoned", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:HaveTotem", val >= 1).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionSummonedGolemInPast8Sec": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:SummonedGolemInPast8Sec", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionSummonedGolemInPast10Sec": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:SummonedGolemInPast10Sec", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierNearbyAlly": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:NearbyAlly", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierNearbyCorpse": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:NearbyCorpse", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierSummonedMinion": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:SummonedMinion", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionOnConsecratedGround": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:OnConsecratedGround", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod("MinionModifier", "LIST", { mod = modLib.createMod("Condition:OnConsecratedGround", "FLAG", true, "Config", { type = "Condition", var = "Combat" }) })
-	   	},
-	   "conditionOnFungalGround": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:OnFungalGround", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionOnBurningGround": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:OnBurningGround", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:Burning", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionOnChilledGround": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:OnChilledGround", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:Chilled", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionOnShockedGround": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:OnShockedGround", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:Shocked", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionBlinded": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Blinded", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionBurning": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Burning", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionIgnited": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Ignited", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionChilled": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Chilled", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionChilledEffect": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("ChillVal", mod.TypeOverride, val).Source("Chill").Tag(mod.Condition("Chilled")))
-	   	},
-	   "conditionSelfChill": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:ChilledSelf", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionFrozen": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Frozen", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionShocked": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Shocked", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFloat("DamageTaken", mod.TypeBase, 15).Source("Shock").Tag(mod.Condition("Shocked")))
-	   	},
-	   "conditionBleeding": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Bleeding", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionPoisoned": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Poisoned", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierPoisonOnSelf": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:PoisonStack", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionAgainstDamageOverTime": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:AgainstDamageOverTime", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierNearbyEnemies": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:NearbyEnemies", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:OnlyOneNearbyEnemy", val == 1).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierNearbyRareOrUniqueEnemies": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:NearbyRareOrUniqueEnemies", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFloat("Multiplier:NearbyEnemies", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:AtMostOneNearbyRareOrUniqueEnemy", val <= 1).Source("Config").Tag(mod.Condition("Combat")))
-	   		enemyModList.AddMod(mod.NewFlag("Condition:NearbyRareOrUniqueEnemy", val >= 1).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionHitRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:HitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionCritRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:CritRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:SkillCritRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionSkillCritRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:SkillCritRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionCritWithHeraldSkillRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:CritWithHeraldSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "LostNonVaalBuffRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:LostNonVaalBuffRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionNonCritRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:NonCritRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionChannelling": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Channelling", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionHitRecentlyWithWeapon": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:HitRecentlyWithWeapon", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionKilledRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:KilledRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierKilledRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:EnemyKilledRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:KilledRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionKilledLast3Seconds": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:KilledLast3Seconds", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionKilledPosionedLast2Seconds": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:KilledPosionedLast2Seconds", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionTotemsNotSummonedInPastTwoSeconds": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:NoSummonedTotemsInPastTwoSeconds", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionTotemsKilledRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:TotemsKilledRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionUsedBrandRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:UsedBrandRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierTotemsKilledRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:EnemyKilledByTotemsRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:TotemsKilledRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionMinionsKilledRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:MinionsKilledRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionMinionsDiedRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:MinionsDiedRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierMinionsKilledRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:EnemyKilledByMinionsRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:MinionsKilledRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionKilledAffectedByDoT": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:KilledAffectedByDotRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierShockedEnemyKilledRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:ShockedEnemyKilledRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionFrozenEnemyRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:FrozenEnemyRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionChilledEnemyRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:ChilledEnemyRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionShatteredEnemyRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:ShatteredEnemyRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionIgnitedEnemyRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:IgnitedEnemyRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionShockedEnemyRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:ShockedEnemyRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionStunnedEnemyRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:StunnedEnemyRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierPoisonAppliedRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:PoisonAppliedRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierLifeSpentRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:LifeSpentRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierManaSpentRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:ManaSpentRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionBeenHitRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierBeenHitRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:BeenHitRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", 1 <= val).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionBeenHitByAttackRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:BeenHitByAttackRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionBeenCritRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:BeenCritRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionConsumed12SteelShardsRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Consumed12SteelShardsRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionGainedPowerChargeRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:GainedPowerChargeRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionGainedFrenzyChargeRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:GainedFrenzyChargeRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionBeenSavageHitRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:BeenSavageHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionHitByFireDamageRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:HitByFireDamageRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionHitByColdDamageRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:HitByColdDamageRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionHitByLightningDamageRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:HitByLightningDamageRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionHitBySpellDamageRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:HitBySpellDamageRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionTakenFireDamageFromEnemyHitRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:TakenFireDamageFromEnemyHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionBlockedRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:BlockedRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionBlockedAttackRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:BlockedAttackRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:BlockedRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionBlockedSpellRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:BlockedSpellRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:BlockedRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionEnergyShieldRechargeRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:EnergyShieldRechargeRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionStoppedTakingDamageOverTimeRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:StoppedTakingDamageOverTimeRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionConvergence": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod("Condition:Convergence", "FLAG", true, "Config", { type = "Condition", var = "Combat" }, { type = "Condition", var = "CanGainConvergence" })
-	   	},
-	   "buffP}ulum": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		if val == "AREA" {
-	   			modList.AddMod(mod.NewFlag("Condition:P}ulumOfDestructionAreaOfEffect", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		} else if val == "DAMAGE" {
-	   			modList.AddMod(mod.NewFlag("Condition:P}ulumOfDestructionElementalDamage", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		}
-	   	},
-	   "buffConflux": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		if val == "CHILLING" || val == "ALL" {
-	   			modList.AddMod(mod.NewFlag("Condition:ChillingConflux", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		}
-	   		if val == "SHOCKING" || val == "ALL" {
-	   			modList.AddMod(mod.NewFlag("Condition:ShockingConflux", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		}
-	   		if val == "IGNITING" || val == "ALL" {
-	   			modList.AddMod(mod.NewFlag("Condition:IgnitingConflux", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		}
-	   	},
-	   "buffBastionOfHope": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:BastionOfHopeActive", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "buffNgamahuFlamesAdvance": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:NgamahuFlamesAdvance", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "buffHerEmbrace": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod("HerEmbrace", "FLAG", true, "Config", { type = "Condition", var = "Combat" }, { type = "Condition", var = "CanGainHerEmbrace" })
-	   	},
-	   "conditionUsedSkillRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierSkillUsedRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:SkillUsedRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionAttackedRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:AttackedRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionCastSpellRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:CastSpellRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionCastLast1Seconds": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:CastLast1Seconds", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierCastLast8Seconds": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:CastLast8Seconds", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionUsedFireSkillRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:UsedFireSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionUsedColdSkillRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:UsedColdSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionUsedMinionSkillRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:UsedMinionSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionUsedTravelSkillRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:UsedTravelSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedMovementSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionUsedDashRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:CastDashRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedTravelSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedMovementSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionUsedMovementSkillRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:UsedMovementSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionUsedVaalSkillRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:UsedVaalSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionSoulGainPrevention": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:SoulGainPrevention", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionUsedWarcryRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:UsedWarcryRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedWarcryInPast8Seconds", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionUsedWarcryInPast8Seconds": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:UsedWarcryInPast8Seconds", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierMineDetonatedRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:MineDetonatedRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierTrapTriggeredRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:TrapTriggeredRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionThrownTrapOrMineRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:TrapOrMineThrownRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionCursedEnemyRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:CursedEnemyRecently", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionCastMarkRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:CastMarkRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionSpawnedCorpseRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:SpawnedCorpseRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionConsumedCorpseRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:ConsumedCorpseRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionConsumedCorpseInPast2Sec": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:ConsumedCorpseInPast2Sec", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierCorpseConsumedRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:CorpseConsumedRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:ConsumedCorpseRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierWarcryUsedRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod("Multiplier:WarcryUsedRecently", "BASE", math.Min(val, 100), "Config", { type = "Condition", var = "Combat" })
-	   		modList.AddMod(mod.NewFlag("Condition:UsedWarcryRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedWarcryInPast8Seconds", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionTauntedEnemyRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:TauntedEnemyRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionLost}uranceChargeInPast8Sec": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:Lost}uranceChargeInPast8Sec", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplier}uranceChargesLostRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:}uranceChargesLostRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewFlag("Condition:Lost}uranceChargeInPast8Sec", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionBlockedHitFromUniqueEnemyInPast10Sec": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:BlockedHitFromUniqueEnemyInPast10Sec", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "BlockedPast10Sec": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:BlockedPast10Sec", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionImpaledRecently": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:ImpaledRecently", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierImpalesOnEnemy": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFloat("Multiplier:ImpaleStacks", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "multiplierBleedsOnEnemy": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFloat("Multiplier:BleedStacks", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Bleeding", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "multiplierFragileRegrowth": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod("Multiplier:FragileRegrowthCount", "BASE", math.Min(val,10), "Config", { type = "Condition", var = "Combat" })
-	   	},
-	   "conditionKilledUniqueEnemy": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:KilledUniqueEnemy", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "conditionHaveArborix": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:HaveIronReflexes", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewList("Keystone", "Iron Reflexes").Source("Config"))
-	   	},
-	   "conditionHaveAugyre": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		if val == "EleOverload" {
-	   			modList.AddMod(mod.NewFlag("Condition:HaveElementalOverload", true).Source("Config").Tag(mod.Condition("Combat")))
-	   			modList.AddMod(mod.NewList("Keystone", "Elemental Overload").Source("Config"))
-	   		} else if val == "ResTechnique" {
-	   			modList.AddMod(mod.NewFlag("Condition:HaveResoluteTechnique", true).Source("Config").Tag(mod.Condition("Combat")))
-	   			modList.AddMod(mod.NewList("Keystone", "Resolute Technique").Source("Config"))
-	   		}
-	   	},
-	   "conditionHaveVulconus": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:HaveAvatarOfFire", true).Source("Config").Tag(mod.Condition("Combat")))
-	   		modList.AddMod(mod.NewList("Keystone", "Avatar of Fire").Source("Config"))
-	   	},
-	   "conditionHaveManaStorm": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:SacrificeManaForLightning", true).Source("Config").Tag(mod.Condition("Combat")))
-	   	},
-	   "buffFanaticism": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod("Condition:Fanaticism", "FLAG", true, "Config", { type = "Condition", var = "Combat" }, { type = "Condition", var = "CanGainFanaticism" })
-	   	},
-	   "critChanceLucky": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("CritChanceLucky", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "skillForkCount": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("ForkedCount", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "skillChainCount": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("ChainCount", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "skillPierceCount": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("PiercedCount", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionAtCloseRange": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFlag("Condition:AtCloseRange", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyMoving": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Moving", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyFullLife": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:FullLife", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyLowLife": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:LowLife", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyCursed": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Cursed", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyBleeding": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Bleeding", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "multiplierRuptureStacks": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFloat("Multiplier:RuptureStack", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
-	   		enemyModList.AddMod("DamageTaken", "MORE", 25, "Rupture", nil, KeywordFlag.Bleed, { type = "Multiplier", var = "RuptureStack", limit = 3 }, { type = "ActorCondition", actor = "enemy", var = "CanInflictRupture" })
-	   		enemyModList.AddMod("BleedExpireRate", "MORE", 25, "Rupture", nil, KeywordFlag.Bleed, { type = "Multiplier", var = "RuptureStack", limit = 3 }, { type = "ActorCondition", actor = "enemy", var = "CanInflictRupture" })
-	   	},
-	   "conditionEnemyPoisoned": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Poisoned", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "multiplierPoisonOnEnemy": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFloat("Multiplier:PoisonStack", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "multiplierWitheredStackCount": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFloat("Multiplier:WitheredStack", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "multiplierCorrosionStackCount": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFloat("Multiplier:CorrosionStack", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
-	   		enemyModList.AddMod("Armour", "BASE", -5000, "Corrosion", { type = "Multiplier", var = "CorrosionStack" }, { type = "ActorCondition", actor = "enemy", var = "CanCorrode" })
-	   		enemyModList.AddMod("Evasion", "BASE", -1000, "Corrosion", { type = "Multiplier", var = "CorrosionStack" }, { type = "ActorCondition", actor = "enemy", var = "CanCorrode" })
-	   	},
-	   "multiplierEnsnaredStackCount": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:EnsnareStackCount", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
-	   		enemyModList.AddMod("Condition:Moving", "FLAG", true, "Config", { type = "MultiplierThreshold", actor = "enemy", var = "EnsnareStackCount", threshold = 1 })
-	   	},
-	   "conditionEnemyMaimed": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Maimed", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyHindered": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Hindered", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyBlinded": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Blinded", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "overrideBuffBlinded": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod("BlindEffect", "OVERRIDE", val, "Config", {type = "GlobalEffect", effectType = "Buff" })
-	   	},
-	   "conditionEnemyTaunted": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Taunted", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyBurning": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Burning", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyIgnited": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Ignited", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyScorched": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Scorched", true).Source("Config").Tag(mod.Condition("Effective")))
-	   		enemyModList.AddMod(mod.NewFlag("Condition:ScorchedConfig", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionScorchedEffect": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFloat("ScorchVal", mod.TypeBase, val).Source("Config").Tag(mod.Condition("ScorchedConfig")))
-	   		enemyModList.AddMod("DesiredScorchVal", "BASE", val, "Brittle", { type = "Condition", var = "ScorchedConfig", neg = true })
-	   	},
-	   "conditionEnemyOnScorchedGround": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Scorched", true).Source("Config").Tag(mod.Condition("Effective")))
-	   		enemyModList.AddMod(mod.NewFlag("Condition:OnScorchedGround", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyChilled": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Chilled", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyChilledEffect": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFloat("ChillVal", mod.TypeOverride, val).Source("Chill").Tag(mod.Condition("Chilled")))
-	   	},
-	   "conditionEnemyChilledByYourHits": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Chilled", true).Source("Config").Tag(mod.Condition("Effective")))
-	   		enemyModList.AddMod(mod.NewFlag("Condition:ChilledByYourHits", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyFrozen": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Frozen", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyBrittle": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Brittle", true).Source("Config").Tag(mod.Condition("Effective")))
-	   		enemyModList.AddMod(mod.NewFlag("Condition:BrittleConfig", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionBrittleEffect": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFloat("BrittleVal", mod.TypeBase, val).Source("Config").Tag(mod.Condition("BrittleConfig")))
-	   		enemyModList.AddMod("DesiredBrittleVal", "BASE", val, "Brittle", { type = "Condition", var = "BrittleConfig", neg = true })
-	   	},
-	   "conditionEnemyOnBrittleGround": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Brittle", true).Source("Config").Tag(mod.Condition("Effective")))
-	   		enemyModList.AddMod(mod.NewFlag("Condition:OnBrittleGround", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyShocked": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Shocked", true).Source("Config").Tag(mod.Condition("Effective")))
-	   		enemyModList.AddMod(mod.NewFlag("Condition:ShockedConfig", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionShockEffect": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod("ShockVal", "BASE", val, "Shock", { type = "Condition", var = "ShockedConfig" })
-	   		enemyModList.AddMod("DesiredShockVal", "BASE", val, "Shock", { type = "Condition", var = "ShockedConfig", neg = true })
-	   	},
-	   "conditionEnemyOnShockedGround": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Shocked", true).Source("Config").Tag(mod.Condition("Effective")))
-	   		enemyModList.AddMod(mod.NewFlag("Condition:OnShockedGround", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemySapped": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Sapped", true).Source("Config").Tag(mod.Condition("Effective")))
-	   		enemyModList.AddMod(mod.NewFlag("Condition:SappedConfig", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionSapEffect": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   	enemyModList.AddMod(mod.NewFloat("SapVal"))
-	   		enemyModList.AddMod("SapVal", "BASE", val, "Sap", { type = "Condition", var = "SappedConfig" })
-	   		enemyModList.AddMod("DesiredSapVal", "BASE", val, "Sap", { type = "Condition", var = "SappedConfig", neg = true })
-	   	},
-	   "conditionEnemyOnSappedGround": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Sapped", true).Source("Config").Tag(mod.Condition("Effective")))
-	   		enemyModList.AddMod(mod.NewFlag("Condition:OnSappedGround", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "multiplierFreezeShockIgniteOnEnemy": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:FreezeShockIgniteOnEnemy", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyFireExposure": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod("FireExposure", "BASE", -10, "Config", { type = "Condition", var = "Effective" }, { type = "ActorCondition", actor = "enemy", var = "CanApplyFireExposure" })
-	   	},
-	   "conditionEnemyColdExposure": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod("ColdExposure", "BASE", -10, "Config", { type = "Condition", var = "Effective" }, { type = "ActorCondition", actor = "enemy", var = "CanApplyColdExposure" })
-	   	},
-	   "conditionEnemyLightningExposure": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod("LightningExposure", "BASE", -10, "Config", { type = "Condition", var = "Effective" }, { type = "ActorCondition", actor = "enemy", var = "CanApplyLightningExposure" })
-	   	},
-	   "conditionEnemyIntimidated": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Intimidated", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyCrushed": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Crushed", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionNearLinkedTarget": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:NearLinkedTarget", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyUnnerved": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:Unnerved", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyCoveredInAsh": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("CoveredInAshEffect", mod.TypeBase, 20).Source("Covered in Ash"))
-	   	},
-	   "conditionEnemyCoveredInFrost": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("CoveredInFrostEffect", mod.TypeBase, 20).Source("Covered in Frost"))
-	   	},
-	   "conditionEnemyOnConsecratedGround": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:OnConsecratedGround", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyOnProfaneGround": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:OnProfaneGround", true).Source("Config").Tag(mod.Condition("Effective")))
-	   		enemyModList.AddMod(mod.NewFloat("ElementalResist", mod.TypeBase, -10).Source("Config").Tag(mod.Condition("OnProfaneGround")))
-	   		enemyModList.AddMod(mod.NewFloat("ChaosResist", mod.TypeBase, -10).Source("Config").Tag(mod.Condition("OnProfaneGround")))
-	   		modList.AddMod("CritChance", "BASE", 1, "Config", { type = "ActorCondition", actor = "enemy", var = "OnProfaneGround" })
-	   		modList.AddMod("MinionModifier", "LIST", { mod = modLib.createMod("CritChance", "BASE", 1, "Config", { type = "ActorCondition", actor = "enemy", var = "OnProfaneGround" }) })
-	   	},
-	   "multiplierEnemyAffectedByGraspingVines": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		modList.AddMod(mod.NewFloat("Multiplier:GraspingVinesAffectingEnemy", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyOnFungalGround": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:OnFungalGround", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyInChillingArea": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:InChillingArea", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "conditionEnemyInFrostGlobe": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:EnemyInFrostGlobe", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   "enemyConditionHitByFireDamage": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemymodList.AddMod(mod.NewFlag("Condition:HitByFireDamage", true).Source("Config"))
-	   	},
-	   "enemyConditionHitByColdDamage": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemymodList.AddMod(mod.NewFlag("Condition:HitByColdDamage", true).Source("Config"))
-	   	},
-	   "enemyConditionHitByLightningDamage": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemymodList.AddMod(mod.NewFlag("Condition:HitByLightningDamage", true).Source("Config"))
-	   	},
-	   "conditionEnemyRareOrUnique": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFlag("Condition:RareOrUnique", true).Source("Config").Tag(mod.Condition("Effective")))
-	   	},
-	   	"enemyIsBoss": func(val interface{}, modList *ModList, enemyModList *ModList, build) {
-	   		//these defaults are here so that the placeholder gets reset correctly
-	   		build.configTab.varControls['enemySpeed']:SetPlaceholder(700, true)
-	   		build.configTab.varControls['enemyCritChance']:SetPlaceholder(5, true)
-	   		build.configTab.varControls['enemyCritDamage']:SetPlaceholder(30, true)
-	   		if val == "None" {
-	   			defaultResist := ""
-	   			build.configTab.varControls['enemyLightningResist']:SetPlaceholder(defaultResist, true)
-	   			build.configTab.varControls['enemyColdResist']:SetPlaceholder(defaultResist, true)
-	   			build.configTab.varControls['enemyFireResist']:SetPlaceholder(defaultResist, true)
-	   			build.configTab.varControls['enemyChaosResist']:SetPlaceholder(defaultResist, true)
+type ConfigType string
+
+const (
+	ConfigTypeBool   = ConfigType("bool")
+	ConfigTypeNumber = ConfigType("number")
+	ConfigTypeList   = ConfigType("list")
+)
+
+// ConfigOption is a single option of the configuration tab
+type ConfigOption struct {
+	Name    string
+	Section string // Name of the section of the configuration tab the option is shown in
+	Label   string
+	Tooltip string
+	Type    ConfigType
+	Default interface{}
+	Values  []ConfigValue // Selectable values of a list option, number options with values only accept those values
 
-	   			defaultLevel := 66
-	   			if build.calcsTab.mainEnv {
-	   				defaultLevel = build.calcsTab.mainEnv.enemyLevel
-	   			}
+	apply ConfigApplyFunc
+}
 
-	   			defaultDamage := round(data.monsterDamageTable[defaultLevel] * 1.5)
-	   			build.configTab.varControls['enemyPhysicalDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyLightningDamage']:SetPlaceholder("", true)
-	   			build.configTab.varControls['enemyColdDamage']:SetPlaceholder("", true)
-	   			build.configTab.varControls['enemyFireDamage']:SetPlaceholder("", true)
-	   			build.configTab.varControls['enemyChaosDamage']:SetPlaceholder("", true)
+// ConfigValue is a selectable value of an option
+type ConfigValue struct {
+	Value interface{}
+	Label string
+}
 
-	   			defaultPen := ""
-	   			build.configTab.varControls['enemyLightningPen']:SetPlaceholder(defaultPen, true)
-	   			build.configTab.varControls['enemyColdPen']:SetPlaceholder(defaultPen, true)
-	   			build.configTab.varControls['enemyFirePen']:SetPlaceholder(defaultPen, true)
-	   		} else if val == "Boss" {
-	   			enemyModList.AddMod(mod.NewFlag("Condition:RareOrUnique", true).Source("Config").Tag(mod.Condition("Effective")))
-	   			enemyModList.AddMod(mod.NewFloat("CurseEffectOnSelf", mod.TypeMore, -33).Source("Boss"))
-	   			enemyModList.AddMod(mod.NewFloat("AilmentThreshold", mod.TypeMore, 488).Source("Boss"))
-	   			modList.AddMod(mod.NewFloat("WarcryPower", mod.TypeBase, 20).Source("Boss"))
+// ConfigSection is a section of the configuration tab
+type ConfigSection struct {
+	Name    string
+	Options []*ConfigOption
+}
 
-	   			defaultEleResist := 40
-	   			build.configTab.varControls['enemyLightningResist']:SetPlaceholder(defaultEleResist, true)
-	   			build.configTab.varControls['enemyColdResist']:SetPlaceholder(defaultEleResist, true)
-	   			build.configTab.varControls['enemyFireResist']:SetPlaceholder(defaultEleResist, true)
-	   			build.configTab.varControls['enemyChaosResist']:SetPlaceholder(25, true)
+type configOptionSchema struct {
+	name    string
+	label   string
+	tooltip string
+	values  []ConfigValue
+}
 
-	   			defaultLevel := 83
-	   			build.configTab.varControls['enemyLevel']:SetPlaceholder(defaultLevel, true)
-	   			if build.calcsTab.mainEnv {
-	   				defaultLevel = build.calcsTab.mainEnv.enemyLevel
-	   			}
+type configSectionSchema struct {
+	name    string
+	options []configOptionSchema
+}
 
-	   			defaultDamage := round(data.monsterDamageTable[defaultLevel] * 1.5  * data.misc.stdBossDPSMult)
-	   			build.configTab.varControls['enemyPhysicalDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyLightningDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyColdDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyFireDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyChaosDamage']:SetPlaceholder(defaultDamage / 4, true)
+// Apply adds the mods of the option to the provided lists, falling back to the default if val is nil
+func (o *ConfigOption) Apply(val interface{}, modList *moddb.ModList, enemyModList *moddb.ModList) {
+	if val == nil {
+		val = o.Default
+	}
 
-	   			defaultPen := ""
-	   			build.configTab.varControls['enemyLightningPen']:SetPlaceholder(defaultPen, true)
-	   			build.configTab.varControls['enemyColdPen']:SetPlaceholder(defaultPen, true)
-	   			build.configTab.varControls['enemyFirePen']:SetPlaceholder(defaultPen, true)
-	   		} else if val == "Pinnacle" {
-	   			enemyModList.AddMod(mod.NewFlag("Condition:RareOrUnique", true).Source("Config").Tag(mod.Condition("Effective")))
-	   			enemyModList.AddMod(mod.NewFlag("Condition:PinnacleBoss", true).Source("Config").Tag(mod.Condition("Effective")))
-	   			enemyModList.AddMod(mod.NewFloat("CurseEffectOnSelf", mod.TypeMore, -66).Source("Boss"))
-	   			enemyModList.AddMod(mod.NewFloat("Armour", mod.TypeMore, 33).Source("Boss"))
-	   			enemyModList.AddMod(mod.NewFloat("AilmentThreshold", mod.TypeMore, 404).Source("Boss"))
-	   			modList.AddMod(mod.NewFloat("WarcryPower", mod.TypeBase, 20).Source("Boss"))
+	switch o.Type {
+	case ConfigTypeBool:
+		if v, ok := val.(bool); !ok || !v {
+			return
+		}
+	case ConfigTypeNumber:
+		// Some options were booleans in older versions
+		if v, ok := val.(bool); ok {
+			if !v {
+				return
+			}
+			val = float64(1)
+		}
+		if v, ok := val.(float64); !ok || v == 0 {
+			return
+		}
+	case ConfigTypeList:
+		if v, ok := val.(string); !ok || v == "" {
+			return
+		}
+	}
 
-	   			defaultEleResist := 50
-	   			build.configTab.varControls['enemyLightningResist']:SetPlaceholder(defaultEleResist, true)
-	   			build.configTab.varControls['enemyColdResist']:SetPlaceholder(defaultEleResist, true)
-	   			build.configTab.varControls['enemyFireResist']:SetPlaceholder(defaultEleResist, true)
-	   			build.configTab.varControls['enemyChaosResist']:SetPlaceholder(30, true)
+	if o.apply != nil {
+		o.apply(val, modList, enemyModList)
+	}
+}
 
-	   			defaultLevel := 84
-	   			build.configTab.varControls['enemyLevel']:SetPlaceholder(defaultLevel, true)
-	   			if build.calcsTab.mainEnv {
-	   				defaultLevel = build.calcsTab.mainEnv.enemyLevel
-	   			}
+// WithDefault overrides the default value of the option
+func (o *ConfigOption) WithDefault(val interface{}) *ConfigOption {
+	o.Default = val
+	return o
+}
 
-	   			defaultDamage := round(data.monsterDamageTable[defaultLevel] * 1.5  * data.misc.pinnacleBossDPSMult)
-	   			build.configTab.varControls['enemyPhysicalDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyLightningDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyColdDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyFireDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyChaosDamage']:SetPlaceholder(defaultDamage / 4, true)
+// ConfigOptions returns all supported configuration options in the order they are applied
+func ConfigOptions() []*ConfigOption {
+	return configOptions
+}
 
-	   			build.configTab.varControls['enemyLightningPen']:SetPlaceholder(data.misc.pinnacleBossPen, true)
-	   			build.configTab.varControls['enemyColdPen']:SetPlaceholder(data.misc.pinnacleBossPen, true)
-	   			build.configTab.varControls['enemyFirePen']:SetPlaceholder(data.misc.pinnacleBossPen, true)
-	   		} else if val == "Uber" {
-	   			enemyModList.AddMod(mod.NewFlag("Condition:RareOrUnique", true).Source("Config").Tag(mod.Condition("Effective")))
-	   			enemyModList.AddMod(mod.NewFlag("Condition:PinnacleBoss", true).Source("Config").Tag(mod.Condition("Effective")))
-	   			enemyModList.AddMod(mod.NewFloat("CurseEffectOnSelf", mod.TypeMore, -66).Source("Boss"))
-	   			enemyModList.AddMod(mod.NewFloat("Armour", mod.TypeMore, 100).Source("Boss"))
-	   			enemyModList.AddMod(mod.NewFloat("DamageTaken", mod.TypeMore, -70).Source("Boss"))
-	   			enemyModList.AddMod(mod.NewFloat("AilmentThreshold", mod.TypeMore, 404).Source("Boss"))
-	   			modList.AddMod(mod.NewFloat("WarcryPower", mod.TypeBase, 20).Source("Boss"))
+// GetConfigOption returns the configuration option with the provided name, or nil if it is not supported
+func GetConfigOption(name string) *ConfigOption {
+	return configOptionsByName[name]
+}
 
-	   			defaultEleResist := 50
-	   			build.configTab.varControls['enemyLightningResist']:SetPlaceholder(defaultEleResist, true)
-	   			build.configTab.varControls['enemyColdResist']:SetPlaceholder(defaultEleResist, true)
-	   			build.configTab.varControls['enemyFireResist']:SetPlaceholder(defaultEleResist, true)
-	   			build.configTab.varControls['enemyChaosResist']:SetPlaceholder(30, true)
+// ConfigSections returns all configuration options grouped by section in display order
+func ConfigSections() []*ConfigSection {
+	return configSectionsOrdered
+}
 
-	   			defaultLevel := 85
-	   			build.configTab.varControls['enemyLevel']:SetPlaceholder(defaultLevel, true)
-	   			if build.calcsTab.mainEnv {
-	   				defaultLevel = build.calcsTab.mainEnv.enemyLevel
-	   			}
+// boolConfig creates a bool option, options without an apply function are read directly from the environment
+func boolConfig(name string, apply func(modList *moddb.ModList, enemyModList *moddb.ModList)) *ConfigOption {
+	option := &ConfigOption{
+		Name:    name,
+		Type:    ConfigTypeBool,
+		Default: false,
+	}
+	if apply != nil {
+		option.apply = func(_ interface{}, modList *moddb.ModList, enemyModList *moddb.ModList) {
+			apply(modList, enemyModList)
+		}
+	}
+	return option
+}
 
-	   			defaultDamage := round(data.monsterDamageTable[defaultLevel] * 1.5  * data.misc.uberBossDPSMult)
-	   			build.configTab.varControls['enemyPhysicalDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyLightningDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyColdDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyFireDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyChaosDamage']:SetPlaceholder(defaultDamage / 4, true)
+// numberConfig creates a number option, options without an apply function are read directly from the environment
+func numberConfig(name string, apply func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList)) *ConfigOption {
+	option := &ConfigOption{
+		Name:    name,
+		Type:    ConfigTypeNumber,
+		Default: float64(0),
+	}
+	if apply != nil {
+		option.apply = func(val interface{}, modList *moddb.ModList, enemyModList *moddb.ModList) {
+			apply(val.(float64), modList, enemyModList)
+		}
+	}
+	return option
+}
 
-	   			build.configTab.varControls['enemyLightningPen']:SetPlaceholder(data.misc.uberBossPen, true)
-	   			build.configTab.varControls['enemyColdPen']:SetPlaceholder(data.misc.uberBossPen, true)
-	   			build.configTab.varControls['enemyFirePen']:SetPlaceholder(data.misc.uberBossPen, true)
-	   		}
-	   	 },
-	   "deliriousPercentage": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		if val == "20Percent" {
-	   			enemyModList.AddMod(mod.NewFloat("DamageTaken", mod.TypeMore, -19.2).Source("20% Delirious"))
-	   			enemyModList.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 6).Source("20% Delirious"))
-	   		}
-	   		if val == "40Percent" {
-	   			enemyModList.AddMod(mod.NewFloat("DamageTaken", mod.TypeMore, -38.4).Source("40% Delirious"))
-	   			enemyModList.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 12).Source("40% Delirious"))
-	   		}
-	   		if val == "60Percent" {
-	   			enemyModList.AddMod(mod.NewFloat("DamageTaken", mod.TypeMore, -57.6).Source("60% Delirious"))
-	   			enemyModList.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 18).Source("60% Delirious"))
-	   		}
-	   		if val == "80Percent" {
-	   			enemyModList.AddMod(mod.NewFloat("DamageTaken", mod.TypeMore, -76.8).Source("80% Delirious"))
-	   			enemyModList.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 24).Source("80% Delirious"))
-	   		}
-	   		if val == "100Percent" {
-	   			enemyModList.AddMod(mod.NewFloat("DamageTaken", mod.TypeMore, -96).Source("100% Delirious"))
-	   			enemyModList.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 30).Source("100% Delirious"))
-	   		}
-	   	},
-	   "enemyPhysicalReduction": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFloat("PhysicalDamageReduction", mod.TypeBase, val).Source("Config"))
-	   	},
-	   "enemyLightningResist": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFloat("LightningResist", mod.TypeBase, val).Source("Config"))
-	   	},
-	   "enemyColdResist": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFloat("ColdResist", mod.TypeBase, val).Source("Config"))
-	   	},
-	   "enemyFireResist": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFloat("FireResist", mod.TypeBase, val).Source("Config"))
-	   	},
-	   "enemyChaosResist": func(val interface{}, modList *ModList, enemyModList *ModList) {
-	   		enemyModList.AddMod(mod.NewFloat("ChaosResist", mod.TypeBase, val).Source("Config"))
-	   	},
-	   	"presetBossSkills": func(val interface{}, modList *ModList, enemyModList *ModList, build) {
-	   		//reset to empty
-	   		if !(val == "None") {
-	   			defaultDamage := ""
-	   			build.configTab.varControls['enemyPhysicalDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyLightningDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyColdDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyFireDamage']:SetPlaceholder(defaultDamage, true)
-	   			build.configTab.varControls['enemyChaosDamage']:SetPlaceholder(defaultDamage, true)
+// listConfig creates a list option, options without an apply function are read directly from the environment
+func listConfig(name string, apply func(val string, modList *moddb.ModList, enemyModList *moddb.ModList)) *ConfigOption {
+	option := &ConfigOption{
+		Name:    name,
+		Type:    ConfigTypeList,
+		Default: "",
+	}
+	if apply != nil {
+		option.apply = func(val interface{}, modList *moddb.ModList, enemyModList *moddb.ModList) {
+			apply(val.(string), modList, enemyModList)
+		}
+	}
+	return option
+}
 
-	   			defaultPen := ""
-	   			build.configTab.varControls['enemyLightningPen']:SetPlaceholder(defaultPen, true)
-	   			build.configTab.varControls['enemyColdPen']:SetPlaceholder(defaultPen, true)
-	   			build.configTab.varControls['enemyFirePen']:SetPlaceholder(defaultPen, true)
-	   		else
-	   			build.configTab.varControls['enemyDamageType'].enabled = true
-	   		}
+var configOptionsByName = make(map[string]*ConfigOption)
 
-	   		if val == "Uber Atziri Flameblast" {
-	   			if build.calcsTab.mainEnv {
-	   				build.configTab.varControls['enemyFireDamage']:SetPlaceholder(round(data.monsterDamageTable[build.calcsTab.mainEnv.enemyLevel] * 3.48 * 10.9), true)
-	   				build.configTab.varControls['enemyDamageType']:SelByValue("Spell", "val")
-	   				build.configTab.varControls['enemyDamageType'].enabled = false
-	   				build.configTab.input['enemyDamageType'] = "Spell"
-	   			}
-	   			build.configTab.varControls['enemyFirePen']:SetPlaceholder(10, true)
+var configSectionsOrdered []*ConfigSection
 
-	   			build.configTab.varControls['enemySpeed']:SetPlaceholder(25000, true)
-	   			build.configTab.varControls['enemyCritChance']:SetPlaceholder(0, true)
-	   		} else if val == "Shaper Ball" {
-	   			if build.calcsTab.mainEnv {
-	   				build.configTab.varControls['enemyColdDamage']:SetPlaceholder(round(data.monsterDamageTable[build.calcsTab.mainEnv.enemyLevel] * 9.17), true)
-	   			}
+func init() {
+	for _, option := range configOptions {
+		configOptionsByName[option.Name] = option
+	}
 
-	   			build.configTab.varControls['enemyColdPen']:SetPlaceholder(25, true)
-	   			build.configTab.varControls['enemySpeed']:SetPlaceholder(1400, true)
-	   			build.configTab.varControls['enemyDamageType'].enabled = false
-	   			build.configTab.varControls['enemyDamageType']:SelByValue("SpellProjectile", "val")
-	   			build.configTab.input['enemyDamageType'] = "SpellProjectile"
-	   		} else if val == "Shaper Slam" {
-	   			if build.calcsTab.mainEnv {
-	   				build.configTab.varControls['enemyPhysicalDamage']:SetPlaceholder(round(data.monsterDamageTable[build.calcsTab.mainEnv.enemyLevel] * 15.2), true)
-	   			}
-	   			build.configTab.varControls['enemyDamageType'].enabled = false
-	   			build.configTab.varControls['enemyDamageType']:SelByValue("Melee", "val")
-	   			build.configTab.input['enemyDamageType'] = "Melee"
+	configSectionsOrdered = make([]*ConfigSection, len(configSections))
+	for i, section := range configSections {
+		configSectionsOrdered[i] = &ConfigSection{
+			Name:    section.name,
+			Options: make([]*ConfigOption, 0, len(section.options)),
+		}
+		for _, schema := range section.options {
+			// Options missing from the table are reported by the tests
+			option, ok := configOptionsByName[schema.name]
+			if !ok {
+				continue
+			}
+			option.Section = section.name
+			option.Label = schema.label
+			option.Tooltip = schema.tooltip
+			option.Values = schema.values
+			configSectionsOrdered[i].Options = append(configSectionsOrdered[i].Options, option)
+		}
+	}
+}
 
-	   			build.configTab.varControls['enemySpeed']:SetPlaceholder(3510, true)
-	   		} else if val == "Maven Memory Game" {
-	   			if build.calcsTab.mainEnv {
-	   				defaultEleDamage := round(data.monsterDamageTable[build.calcsTab.mainEnv.enemyLevel] * 24.69)
-	   				build.configTab.varControls['enemyLightningDamage']:SetPlaceholder(defaultEleDamage, true)
-	   				build.configTab.varControls['enemyColdDamage']:SetPlaceholder(defaultEleDamage, true)
-	   				build.configTab.varControls['enemyFireDamage']:SetPlaceholder(defaultEleDamage, true)
-	   			}
-	   			build.configTab.varControls['enemyDamageType'].enabled = false
-	   			build.configTab.varControls['enemyDamageType']:SelByValue("Melee", "val")
-	   			build.configTab.input['enemyDamageType'] = "Melee"
-	   		}
-	   	},
-	*/
+var configOptions = []*ConfigOption{
+	// Read directly by InitEnv
+	numberConfig("resistancePenalty", nil).WithDefault(float64(-60)),
+	listConfig("bandit", nil).WithDefault("None"),
+	// Not calculated yet
+	listConfig("pantheonMajorGod", nil).WithDefault("None"),
+	listConfig("pantheonMinorGod", nil).WithDefault("None"),
+	listConfig("physMode", nil).WithDefault("AVERAGE"),
+	numberConfig("detonateDeadCorpseLife", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("SkillData", mod.SkillData{Key: "corpseLife", Value: val}).Source("Config"))
+	}),
+	numberConfig("conditionStationary", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		sanitizedValue := math.Max(0, val)
+		modList.AddMod(mod.NewFloat("Multiplier:StationarySeconds", mod.TypeBase, sanitizedValue).Source("Config"))
+		if sanitizedValue > 0 {
+			modList.AddMod(mod.NewFlag("Condition:Stationary", true).Source("Config"))
+		}
+	}),
+	boolConfig("conditionMoving", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Moving", true).Source("Config"))
+	}),
+	boolConfig("conditionInsane", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Insane", true).Source("Config"))
+	}),
+	boolConfig("conditionFullLife", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:FullLife", true).Source("Config"))
+	}),
+	boolConfig("conditionLowLife", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:LowLife", true).Source("Config"))
+	}),
+	boolConfig("conditionFullMana", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:FullMana", true).Source("Config"))
+	}),
+	boolConfig("conditionLowMana", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:LowMana", true).Source("Config"))
+	}),
+	boolConfig("conditionFullEnergyShield", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:FullEnergyShield", true).Source("Config"))
+	}),
+	boolConfig("conditionLowEnergyShield", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:LowEnergyShield", true).Source("Config"))
+	}),
+	boolConfig("conditionHaveEnergyShield", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:HaveEnergyShield", true).Source("Config"))
+	}),
+	boolConfig("minionsConditionFullLife", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("MinionModifier", mod.MinionModifier{Mod: mod.NewFlag("Condition:FullLife", true).Source("Config")}).Source("Config"))
+	}),
+	boolConfig("minionsConditionCreatedRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:MinionsCreatedRecently", true).Source("Config"))
+	}),
+	listConfig("igniteMode", nil).WithDefault("AVERAGE"),
+	listConfig("lifeRegenMode", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		if val == "AVERAGE" {
+			modList.AddMod(mod.NewFlag("Condition:LifeRegenBurstAvg", true).Source("Config"))
+		} else if val == "FULL" {
+			modList.AddMod(mod.NewFlag("Condition:LifeRegenBurstFull", true).Source("Config"))
+		}
+	}),
+	listConfig("armourCalculationMode", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		if val == "MAX" {
+			modList.AddMod(mod.NewFlag("Condition:ArmourMax", true).Source("Config"))
+		} else if val == "AVERAGE" {
+			modList.AddMod(mod.NewFlag("Condition:ArmourAvg", true).Source("Config"))
+		}
+	}),
+	listConfig("warcryMode", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		if val == "MAX" {
+			modList.AddMod(mod.NewFlag("Condition:WarcryMaxHit", true).Source("Config"))
+		}
+	}),
+	boolConfig("EVBypass", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:EVBypass", true).Source("Config"))
+	}),
+	boolConfig("targetBrandedEnemy", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:TargetingBrandedEnemy", true).Source("Config"))
+	}),
+	boolConfig("aspectOfTheAvianAviansMight", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:AviansMightActive", true).Source("Config"))
+	}),
+	boolConfig("aspectOfTheAvianAviansFlight", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:AviansFlightActive", true).Source("Config"))
+	}),
+	boolConfig("aspectOfTheCatCatsStealth", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:CatsStealthActive", true).Source("Config"))
+	}),
+	boolConfig("aspectOfTheCatCatsAgility", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:CatsAgilityActive", true).Source("Config"))
+	}),
+	numberConfig("overrideCrabBarriers", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("CrabBarriers", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("aspectOfTheSpiderWebStacks", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("ExtraSkillMod", mod.ExtraSkillMod{Mod: mod.NewFloat("Multiplier:SpiderWebApplyStack", mod.TypeBase, val)}).Source("Config").Tag(mod.SkillName("Aspect of the Spider")))
+	}),
+	boolConfig("bannerPlanted", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:BannerPlanted", true).Source("Config"))
+	}),
+	numberConfig("bannerStages", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:BannerStage", mod.TypeBase, math.Min(val, 50)).Source("Config"))
+	}),
+	boolConfig("bladestormInBloodstorm", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:BladestormInBloodstorm", true).Source("Config").Tag(mod.SkillName("Bladestorm")))
+	}),
+	boolConfig("bladestormInSandstorm", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:BladestormInSandstorm", true).Source("Config").Tag(mod.SkillName("Bladestorm")))
+	}),
+	numberConfig("bonechillEffect", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("BonechillEffect", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
+		enemyModList.AddMod(mod.NewFloat("DesiredBonechillEffect", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("boneshatterTraumaStacks", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:TraumaStacks", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("ActiveBrands", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:ConfigActiveBrands", mod.TypeBase, val).Source("Config"))
+	}),
+	numberConfig("BrandsAttachedToEnemy", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:ConfigBrandsAttachedToEnemy", mod.TypeBase, val).Source("Config"))
+	}),
+	boolConfig("BrandsInLastQuarter", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:BrandLastQuarter", true).Source("Config"))
+	}),
+	numberConfig("carrionGolemNearbyMinion", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:NearbyNonGolemMinion", mod.TypeBase, val).Source("Config"))
+	}),
+	boolConfig("closeCombatCombatRush", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:CombatRushActive", true).Source("Config"))
+	}),
+	numberConfig("overrideCruelty", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Cruelty", mod.TypeOverride, math.Min(val, 40)).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("channellingCycloneCheck", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:ChannellingCyclone", true).Source("Config"))
+	}),
+	numberConfig("darkPactSkeletonLife", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("SkillData", mod.SkillData{Key: "skeletonLife", Value: val}).Source("Config").Tag(mod.SkillName("Dark Pact")))
+	}),
+	boolConfig("deathmarkDeathmarkActive", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:EnemyHasDeathmark", true).Source("Config"))
+	}),
+	listConfig("elementalArmyExposureType", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		if val == "Fire" {
+			modList.AddMod(mod.NewFloat("FireExposureChance", mod.TypeBase, 100).Source("Config"))
+		} else if val == "Cold" {
+			modList.AddMod(mod.NewFloat("ColdExposureChance", mod.TypeBase, 100).Source("Config"))
+		} else if val == "Lightning" {
+			modList.AddMod(mod.NewFloat("LightningExposureChance", mod.TypeBase, 100).Source("Config"))
+		}
+	}),
+	boolConfig("energyBladeActive", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:EnergyBladeActive", true).Source("Config"))
+	}),
+	boolConfig("embraceMadnessActive", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:AffectedByGloriousMadness", true).Source("Config"))
+	}),
+	boolConfig("feedingFrenzyFeedingFrenzyActive", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:FeedingFrenzyActive", true).Source("Config"))
+		modList.AddMod(mod.NewList("MinionModifier", mod.MinionModifier{Mod: mod.NewFloat("Damage", mod.TypeMore, 10).Source("Feeding Frenzy")}).Source("Config"))
+		modList.AddMod(mod.NewList("MinionModifier", mod.MinionModifier{Mod: mod.NewFloat("MovementSpeed", mod.TypeIncrease, 10).Source("Feeding Frenzy")}).Source("Config"))
+		modList.AddMod(mod.NewList("MinionModifier", mod.MinionModifier{Mod: mod.NewFloat("Speed", mod.TypeIncrease, 10).Source("Feeding Frenzy")}).Source("Config"))
+	}),
+	boolConfig("flameWallAddedDamage", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:FlameWallAddedDamage", true).Source("Config"))
+	}),
+	boolConfig("frostboltExposure", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("ColdExposureChance", mod.TypeBase, 20).Source("Config"))
+	}),
+	numberConfig("frostShieldStages", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:FrostShieldStage", mod.TypeBase, val).Source("Config"))
+	}),
+	boolConfig("greaterHarbingerOfTimeSlipstream", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:GreaterHarbingerOfTime", true).Source("Config"))
+	}),
+	boolConfig("harbingerOfTimeSlipstream", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:HarbingerOfTime", true).Source("Config"))
+	}),
+	numberConfig("multiplierHexDoom", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:HexDoomStack", mod.TypeBase, val).Source("Config"))
+	}),
+	numberConfig("heraldOfAgonyVirulenceStack", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:VirulenceStack", mod.TypeBase, val).Source("Config"))
+	}),
+	boolConfig("iceNovaCastOnFrostbolt", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:CastOnFrostbolt", true).Source("Config").Tag(mod.SkillName("Ice Nova")))
+	}),
+	boolConfig("infusedChannellingInfusion", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:InfusionActive", true).Source("Config"))
+	}),
+	boolConfig("innervateInnervation", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:InnervationActive", true).Source("Config"))
+	}),
+	numberConfig("intensifyIntensity", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:Intensity", mod.TypeBase, val).Source("Config"))
+	}),
+	boolConfig("meatShieldEnemyNearYou", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:MeatShieldEnemyNearYou", true).Source("Config"))
+	}),
+	listConfig("plagueBearerState", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		if val == "INC" {
+			modList.AddMod(mod.NewFlag("Condition:PlagueBearerIncubating", true).Source("Config"))
+		} else if val == "INF" {
+			modList.AddMod(mod.NewFlag("Condition:PlagueBearerInfecting", true).Source("Config"))
+		}
+	}),
+	numberConfig("perforateSpikeOverlap", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:PerforateSpikeOverlap", mod.TypeBase, val).Source("Config").Tag(mod.SkillName("Perforate")))
+	}),
+	boolConfig("physicalAegisDepleted", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:PhysicalAegisDepleted", true).Source("Config"))
+	}),
+	listConfig("prideEffect", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		if val == "MAX" {
+			modList.AddMod(mod.NewFlag("Condition:PrideMaxEffect", true).Source("Config"))
+		}
+	}),
+	numberConfig("sacrificedRageCount", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:RageSacrificed", mod.TypeBase, val).Source("Config"))
+	}),
+	// Not calculated yet, spectres are not supported
+	boolConfig("raiseSpectreEnableBuffs", nil).WithDefault(true),
+	boolConfig("raiseSpectreEnableCurses", nil).WithDefault(true),
+	numberConfig("raiseSpectreBladeVortexBladeCount", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("SkillData", mod.SkillData{Key: "DpsMultiplier", Value: val}).Source("Config").Tag(mod.SkillId("DemonModularBladeVortexSpectre")))
+		modList.AddMod(mod.NewList("SkillData", mod.SkillData{Key: "DpsMultiplier", Value: val}).Source("Config").Tag(mod.SkillId("GhostPirateBladeVortexSpectre")))
+	}),
+	numberConfig("raiseSpectreKaomFireBeamTotemStage", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:KaomFireBeamTotemStage", mod.TypeBase, val).Source("Config"))
+	}),
+	boolConfig("raiseSpectreEnableSummonedUrsaRallyingCry", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("SkillData", mod.SkillData{Key: "enable", Value: 1}).Source("Config").Tag(mod.SkillId("DropBearSummonedRallyingCry")))
+	}),
+	numberConfig("raiseSpidersSpiderCount", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:RaisedSpider", mod.TypeBase, math.Min(val, 20)).Source("Config"))
+	}),
+	boolConfig("animateWeaponLingeringBlade", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:AnimatingLingeringBlades", true).Source("Config"))
+	}),
+	numberConfig("sigilOfPowerStages", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:SigilOfPowerStage", mod.TypeBase, val).Source("Config"))
+	}),
+	numberConfig("siphoningTrapAffectedEnemies", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:EnemyAffectedBySiphoningTrap", mod.TypeBase, val).Source("Config"))
+		modList.AddMod(mod.NewFlag("Condition:SiphoningTrapSiphoning", true).Source("Config"))
+	}),
+	numberConfig("configSnipeStages", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:SnipeStage", mod.TypeBase, math.Min(val, 6)).Source("Config"))
+	}),
+	numberConfig("configResonanceCount", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:ResonanceCount", mod.TypeBase, math.Max(math.Min(val, 50), 0)).Source("Config"))
+	}),
+	numberConfig("configSpectralWolfCount", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:SpectralWolfCount", mod.TypeBase, math.Min(val, 10)).Source("Config"))
+	}),
+	listConfig("bloodSandStance", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		if val == "SAND" {
+			modList.AddMod(mod.NewFlag("Condition:SandStance", true).Source("Config"))
+		}
+	}),
+	boolConfig("changedStance", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:ChangedStanceRecently", true).Source("Config"))
+	}),
+	numberConfig("shardsConsumed", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:SteelShardConsumed", mod.TypeBase, math.Min(val, 12)).Source("Config"))
+	}),
+	numberConfig("steelWards", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:SteelWardCount", mod.TypeBase, val).Source("Config"))
+	}),
+	numberConfig("stormRainBeamOverlap", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("SkillData", mod.SkillData{Key: "beamOverlapMultiplier", Value: val}).Source("Config").Tag(mod.SkillName("Storm Rain")))
+	}),
+	boolConfig("summonHolyRelicEnableHolyRelicBoon", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:HolyRelicBoonActive", true).Source("Config"))
+	}),
+	boolConfig("summonLightningGolemEnableWrath", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("SkillData", mod.SkillData{Key: "enable", Value: 1}).Source("Config").Tag(mod.SkillId("LightningGolemWrath")))
+	}),
+	numberConfig("nearbyBleedingEnemies", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:NearbyBleedingEnemies", mod.TypeBase, val).Source("Config"))
+	}),
+	numberConfig("toxicRainPodOverlap", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("SkillData", mod.SkillData{Key: "podOverlapMultiplier", Value: val}).Source("Config").Tag(mod.SkillName("Toxic Rain")))
+	}),
+	numberConfig("hoaOverkill", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("SkillData", mod.SkillData{Key: "hoaOverkill", Value: val}).Source("Config").Tag(mod.SkillName("Herald of Ash")))
+	}),
+	numberConfig("voltaxicBurstSpellsQueued", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:VoltaxicWaitingStages", mod.TypeBase, val).Source("Config"))
+	}),
+	boolConfig("vortexCastOnFrostbolt", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:CastOnFrostbolt", true).Source("Config").Tag(mod.SkillName("Vortex")))
+	}),
+	boolConfig("ColdSnapBypassCD", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("CooldownRecovery", mod.TypeOverride, 0).Source("Config").Tag(mod.SkillName("Cold Snap")))
+	}),
+	numberConfig("multiplierWarcryPower", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("WarcryPower", mod.TypeOverride, val).Source("Config"))
+	}),
+	listConfig("waveOfConvictionExposureType", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		if val == "Fire" {
+			modList.AddMod(mod.NewFlag("Condition:WaveOfConvictionFireExposureActive", true).Source("Config"))
+		} else if val == "Cold" {
+			modList.AddMod(mod.NewFlag("Condition:WaveOfConvictionColdExposureActive", true).Source("Config"))
+		} else if val == "Lightning" {
+			modList.AddMod(mod.NewFlag("Condition:WaveOfConvictionLightningExposureActive", true).Source("Config"))
+		}
+	}),
+	numberConfig("MoltenShellDamageMitigated", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("SkillData", mod.SkillData{Key: "MoltenShellDamageMitigated", Value: val}).Source("Config").Tag(mod.SkillName("Molten Shell")))
+	}),
+	numberConfig("VaalMoltenShellDamageMitigated", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("SkillData", mod.SkillData{Key: "VaalMoltenShellDamageMitigated", Value: val}).Source("Config").Tag(mod.SkillName("Molten Shell")))
+	}),
+	numberConfig("enemyHasPhysicalReduction", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("PhysicalDamageReduction", mod.TypeBase, val).Source("Config"))
+	}),
+	boolConfig("enemyIsHexproof", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Hexproof", true).Source("Config"))
+	}),
+	numberConfig("enemyHasLessCurseEffectOnSelf", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("CurseEffectOnSelf", mod.TypeMore, -val).Source("Config"))
+	}),
+	numberConfig("enemyCanAvoidPoisonBlindBleed", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("AvoidPoison", mod.TypeBase, val).Source("Config"))
+		enemyModList.AddMod(mod.NewFloat("AvoidBleed", mod.TypeBase, val).Source("Config"))
+	}),
+	listConfig("enemyHasResistances", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		resists := map[string][2]float64{"LOW": {20, 15}, "MID": {30, 20}, "HIGH": {40, 25}}
+		if r, ok := resists[val]; ok {
+			enemyModList.AddMod(mod.NewFloat("ElementalResist", mod.TypeBase, r[0]).Source("Config"))
+			enemyModList.AddMod(mod.NewFloat("ChaosResist", mod.TypeBase, r[1]).Source("Config"))
+		}
+	}),
+	boolConfig("playerHasElementalEquilibrium", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("Keystone", "Elemental Equilibrium").Source("Config"))
+	}),
+	boolConfig("playerCannotLeech", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("CannotLeechLifeFromSelf", true).Source("Config"))
+		enemyModList.AddMod(mod.NewFlag("CannotLeechManaFromSelf", true).Source("Config"))
+	}),
+	numberConfig("playerGainsReducedFlaskCharges", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("FlaskChargesGained", mod.TypeIncrease, -val).Source("Config"))
+	}),
+	numberConfig("playerHasMinusMaxResist", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("FireResistMax", mod.TypeBase, -val).Source("Config"))
+		modList.AddMod(mod.NewFloat("ColdResistMax", mod.TypeBase, -val).Source("Config"))
+		modList.AddMod(mod.NewFloat("LightningResistMax", mod.TypeBase, -val).Source("Config"))
+		modList.AddMod(mod.NewFloat("ChaosResistMax", mod.TypeBase, -val).Source("Config"))
+	}),
+	numberConfig("playerHasLessAreaOfEffect", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("AreaOfEffect", mod.TypeMore, -val).Source("Config"))
+	}),
+	numberConfig("enemyCanAvoidStatusAilment", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("AvoidIgnite", mod.TypeBase, val).Source("Config"))
+		enemyModList.AddMod(mod.NewFloat("AvoidShock", mod.TypeBase, val).Source("Config"))
+		enemyModList.AddMod(mod.NewFloat("AvoidFreeze", mod.TypeBase, val).Source("Config"))
+	}),
+	numberConfig("enemyHasIncreasedAccuracy", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("DodgeChanceIsUnlucky", true).Source("Config"))
+		enemyModList.AddMod(mod.NewFloat("Accuracy", mod.TypeIncrease, val).Source("Config"))
+	}),
+	listConfig("playerHasLessArmourAndBlock", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		reductions := map[string][2]float64{"LOW": {20, 20}, "MID": {30, 25}, "HIGH": {40, 30}}
+		if r, ok := reductions[val]; ok {
+			modList.AddMod(mod.NewFloat("BlockChance", mod.TypeIncrease, -r[0]).Source("Config"))
+			modList.AddMod(mod.NewFloat("Armour", mod.TypeMore, -r[1]).Source("Config"))
+		}
+	}),
+	boolConfig("playerHasPointBlank", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("Keystone", "Point Blank").Source("Config"))
+	}),
+	numberConfig("playerHasLessLifeESRecovery", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("LifeRecoveryRate", mod.TypeMore, -val).Source("Config"))
+		modList.AddMod(mod.NewFloat("EnergyShieldRecoveryRate", mod.TypeMore, -val).Source("Config"))
+	}),
+	boolConfig("playerCannotRegenLifeManaEnergyShield", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("NoLifeRegen", true).Source("Config"))
+		modList.AddMod(mod.NewFlag("NoEnergyShieldRegen", true).Source("Config"))
+		modList.AddMod(mod.NewFlag("NoManaRegen", true).Source("Config"))
+	}),
+	numberConfig("enemyTakesReducedExtraCritDamage", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("SelfCritMultiplier", mod.TypeIncrease, -val).Source("Config"))
+	}),
+	numberConfig("multiplierSextant", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:Sextant", mod.TypeBase, math.Min(val, 5)).Source("Config"))
+	}),
+	numberConfig("playerCursedWithAssassinsMark", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("ExtraCurse", mod.ExtraCurse{SkillID: "AssassinsMark", Level: int(val), ApplyToPlayer: true}))
+	}),
+	numberConfig("playerCursedWithConductivity", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("ExtraCurse", mod.ExtraCurse{SkillID: "Conductivity", Level: int(val), ApplyToPlayer: true}))
+	}),
+	numberConfig("playerCursedWithDespair", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("ExtraCurse", mod.ExtraCurse{SkillID: "Despair", Level: int(val), ApplyToPlayer: true}))
+	}),
+	numberConfig("playerCursedWithElementalWeakness", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("ExtraCurse", mod.ExtraCurse{SkillID: "ElementalWeakness", Level: int(val), ApplyToPlayer: true}))
+	}),
+	numberConfig("playerCursedWithEnfeeble", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("ExtraCurse", mod.ExtraCurse{SkillID: "Enfeeble", Level: int(val), ApplyToPlayer: true}))
+	}),
+	numberConfig("playerCursedWithFlammability", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("ExtraCurse", mod.ExtraCurse{SkillID: "Flammability", Level: int(val), ApplyToPlayer: true}))
+	}),
+	numberConfig("playerCursedWithFrostbite", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("ExtraCurse", mod.ExtraCurse{SkillID: "Frostbite", Level: int(val), ApplyToPlayer: true}))
+	}),
+	numberConfig("playerCursedWithPoachersMark", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("ExtraCurse", mod.ExtraCurse{SkillID: "PoachersMark", Level: int(val), ApplyToPlayer: true}))
+	}),
+	numberConfig("playerCursedWithProjectileWeakness", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("ExtraCurse", mod.ExtraCurse{SkillID: "ProjectileWeakness", Level: int(val), ApplyToPlayer: true}))
+	}),
+	numberConfig("playerCursedWithPunishment", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("ExtraCurse", mod.ExtraCurse{SkillID: "Punishment", Level: int(val), ApplyToPlayer: true}))
+	}),
+	numberConfig("playerCursedWithTemporalChains", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("ExtraCurse", mod.ExtraCurse{SkillID: "TemporalChains", Level: int(val), ApplyToPlayer: true}))
+	}),
+	numberConfig("playerCursedWithVulnerability", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("ExtraCurse", mod.ExtraCurse{SkillID: "Vulnerability", Level: int(val), ApplyToPlayer: true}))
+	}),
+	numberConfig("playerCursedWithWarlordsMark", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("ExtraCurse", mod.ExtraCurse{SkillID: "WarlordsMark", Level: int(val), ApplyToPlayer: true}))
+	}),
+	boolConfig("usePowerCharges", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("UsePowerCharges", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("overridePowerCharges", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("PowerCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("useFrenzyCharges", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("UseFrenzyCharges", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("overrideFrenzyCharges", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("FrenzyCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("useEnduranceCharges", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("UseEnduranceCharges", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("overrideEnduranceCharges", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("EnduranceCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("useSiphoningCharges", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("UseSiphoningCharges", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("overrideSiphoningCharges", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("SiphoningCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("useChallengerCharges", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("UseChallengerCharges", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("overrideChallengerCharges", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("ChallengerCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("useBlitzCharges", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("UseBlitzCharges", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("overrideBlitzCharges", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("BlitzCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierGaleForce", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:GaleForce", mod.TypeBase, val).Source("Config").Tag(mod.IgnoreCond(), mod.Condition("Combat"), mod.Condition("CanGainGaleForce")))
+	}),
+	numberConfig("overrideInspirationCharges", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("InspirationCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("useGhostShrouds", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("UseGhostShrouds", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("overrideGhostShrouds", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("GhostShrouds", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("waitForMaxSeals", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("UseMaxUnleash", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("overrideBloodCharges", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("BloodCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("minionsUsePowerCharges", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("MinionModifier", mod.MinionModifier{Mod: mod.NewFlag("UsePowerCharges", true).Source("Config").Tag(mod.Condition("Combat"))}).Source("Config"))
+	}),
+	boolConfig("minionsUseFrenzyCharges", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("MinionModifier", mod.MinionModifier{Mod: mod.NewFlag("UseFrenzyCharges", true).Source("Config").Tag(mod.Condition("Combat"))}).Source("Config"))
+	}),
+	boolConfig("minionsUseEnduranceCharges", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("MinionModifier", mod.MinionModifier{Mod: mod.NewFlag("UseEnduranceCharges", true).Source("Config").Tag(mod.Condition("Combat"))}).Source("Config"))
+	}),
+	numberConfig("minionsOverridePowerCharges", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("MinionModifier", mod.MinionModifier{Mod: mod.NewFloat("PowerCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat"))}).Source("Config"))
+	}),
+	numberConfig("minionsOverrideFrenzyCharges", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("MinionModifier", mod.MinionModifier{Mod: mod.NewFloat("FrenzyCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat"))}).Source("Config"))
+	}),
+	numberConfig("minionsOverrideEnduranceCharges", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("MinionModifier", mod.MinionModifier{Mod: mod.NewFloat("EnduranceCharges", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat"))}).Source("Config"))
+	}),
+	numberConfig("multiplierRampage", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:Rampage", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionFocused", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Focused", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("buffLifetap", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Lifetap", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFloat("FlaskLifeRecovery", mod.TypeIncrease, 20).Source("Lifetap"))
+	}),
+	boolConfig("buffOnslaught", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Onslaught", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("minionBuffOnslaught", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("MinionModifier", mod.MinionModifier{Mod: mod.NewFlag("Condition:Onslaught", true).Source("Config").Tag(mod.Condition("Combat"))}))
+	}),
+	boolConfig("buffUnholyMight", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:UnholyMight", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("minionbuffUnholyMight", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewList("MinionModifier", mod.MinionModifier{Mod: mod.NewFlag("Condition:UnholyMight", true).Source("Config").Tag(mod.Condition("Combat"))}))
+	}),
+	boolConfig("buffPhasing", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Phasing", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("buffFortification", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Fortified", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("overrideFortification", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("FortificationStacks", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("buffTailwind", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Tailwind", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("buffAdrenaline", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Adrenaline", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("buffAlchemistsGenius", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:AlchemistsGenius", true).Source("Config").Tag(mod.Condition("Combat"), mod.Condition("CanHaveAlchemistGenius")))
+	}),
+	boolConfig("buffVaalArcLuckyHits", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("LuckyHits", true).Source("Config").Tag(mod.Condition("Combat", "CanBeLucky"), mod.SkillName("Arc", "Vaal Arc")))
+	}),
+	boolConfig("buffElusive", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Elusive", true).Source("Config").Tag(mod.Condition("Combat"), mod.Condition("CanBeElusive")))
+		modList.AddMod(mod.NewFlag("Elusive", true).Source("Config").Tag(mod.Condition("Combat"), mod.Condition("CanBeElusive")))
+	}),
+	numberConfig("overrideBuffElusive", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("ElusiveEffect", mod.TypeOverride, val).Source("Config").Tag(mod.GlobalEffect("Buff")))
+	}),
+	boolConfig("buffDivinity", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Divinity", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierDefiance", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:Defiance", mod.TypeBase, math.Min(val, 10)).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierRage", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:RageStack", mod.TypeBase, val).Source("Config").Tag(mod.IgnoreCond(), mod.Condition("Combat"), mod.Condition("CanGainRage")))
+	}),
+	boolConfig("conditionLeeching", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Leeching", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionLeechingLife", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:LeechingLife", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:Leeching", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionLeechingEnergyShield", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:LeechingEnergyShield", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:Leeching", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionLeechingMana", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:LeechingMana", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:Leeching", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionUsingFlask", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:UsingFlask", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionHaveTotem", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:HaveTotem", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionSummonedTotemRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:SummonedTotemRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("TotemsSummoned", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("TotemsSummoned", mod.TypeOverride, val).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:HaveTotem", val >= 1).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionSummonedGolemInPast8Sec", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:SummonedGolemInPast8Sec", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionSummonedGolemInPast10Sec", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:SummonedGolemInPast10Sec", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierNearbyAlly", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:NearbyAlly", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierNearbyCorpse", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:NearbyCorpse", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierSummonedMinion", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:SummonedMinion", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionOnConsecratedGround", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:OnConsecratedGround", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewList("MinionModifier", mod.MinionModifier{Mod: mod.NewFlag("Condition:OnConsecratedGround", true).Source("Config").Tag(mod.Condition("Combat"))}))
+	}),
+	boolConfig("conditionOnFungalGround", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:OnFungalGround", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionOnBurningGround", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:OnBurningGround", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:Burning", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionOnChilledGround", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:OnChilledGround", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:Chilled", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionOnShockedGround", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:OnShockedGround", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:Shocked", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionBlinded", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Blinded", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionBurning", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Burning", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionIgnited", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Ignited", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionChilled", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Chilled", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("conditionChilledEffect", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("ChillVal", mod.TypeOverride, val).Source("Chill").Tag(mod.Condition("Chilled")))
+	}),
+	boolConfig("conditionSelfChill", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:ChilledSelf", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionFrozen", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Frozen", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionShocked", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Shocked", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFloat("DamageTaken", mod.TypeBase, 15).Source("Shock").Tag(mod.Condition("Shocked")))
+	}),
+	boolConfig("conditionBleeding", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Bleeding", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionPoisoned", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Poisoned", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierPoisonOnSelf", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:PoisonStack", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionAgainstDamageOverTime", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:AgainstDamageOverTime", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierNearbyEnemies", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:NearbyEnemies", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:OnlyOneNearbyEnemy", val == 1).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierNearbyRareOrUniqueEnemies", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:NearbyRareOrUniqueEnemies", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFloat("Multiplier:NearbyEnemies", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:AtMostOneNearbyRareOrUniqueEnemy", val <= 1).Source("Config").Tag(mod.Condition("Combat")))
+		enemyModList.AddMod(mod.NewFlag("Condition:NearbyRareOrUniqueEnemy", val >= 1).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionHitRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:HitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionCritRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:CritRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:SkillCritRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionSkillCritRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:SkillCritRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionCritWithHeraldSkillRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:CritWithHeraldSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("LostNonVaalBuffRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:LostNonVaalBuffRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionNonCritRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:NonCritRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionChannelling", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Channelling", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionHitRecentlyWithWeapon", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:HitRecentlyWithWeapon", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionKilledRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:KilledRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierKilledRecently", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:EnemyKilledRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:KilledRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionKilledLast3Seconds", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:KilledLast3Seconds", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionKilledPosionedLast2Seconds", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:KilledPosionedLast2Seconds", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionTotemsNotSummonedInPastTwoSeconds", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:NoSummonedTotemsInPastTwoSeconds", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionTotemsKilledRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:TotemsKilledRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionUsedBrandRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:UsedBrandRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierTotemsKilledRecently", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:EnemyKilledByTotemsRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:TotemsKilledRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionMinionsKilledRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:MinionsKilledRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionMinionsDiedRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:MinionsDiedRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierMinionsKilledRecently", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:EnemyKilledByMinionsRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:MinionsKilledRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionKilledAffectedByDoT", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:KilledAffectedByDotRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierShockedEnemyKilledRecently", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:ShockedEnemyKilledRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionFrozenEnemyRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:FrozenEnemyRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionChilledEnemyRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:ChilledEnemyRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionShatteredEnemyRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:ShatteredEnemyRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionIgnitedEnemyRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:IgnitedEnemyRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionShockedEnemyRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:ShockedEnemyRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionStunnedEnemyRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:StunnedEnemyRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierPoisonAppliedRecently", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:PoisonAppliedRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierLifeSpentRecently", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:LifeSpentRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierManaSpentRecently", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:ManaSpentRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionBeenHitRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierBeenHitRecently", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:BeenHitRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", 1 <= val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionBeenHitByAttackRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:BeenHitByAttackRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionBeenCritRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:BeenCritRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionConsumed12SteelShardsRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Consumed12SteelShardsRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionGainedPowerChargeRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:GainedPowerChargeRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionGainedFrenzyChargeRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:GainedFrenzyChargeRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionBeenSavageHitRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:BeenSavageHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionHitByFireDamageRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:HitByFireDamageRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionHitByColdDamageRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:HitByColdDamageRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionHitByLightningDamageRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:HitByLightningDamageRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionHitBySpellDamageRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:HitBySpellDamageRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionTakenFireDamageFromEnemyHitRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:TakenFireDamageFromEnemyHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:BeenHitRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionBlockedRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:BlockedRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionBlockedAttackRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:BlockedAttackRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:BlockedRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionBlockedSpellRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:BlockedSpellRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:BlockedRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionEnergyShieldRechargeRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:EnergyShieldRechargeRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionStoppedTakingDamageOverTimeRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:StoppedTakingDamageOverTimeRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionConvergence", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Convergence", true).Source("Config").Tag(mod.Condition("Combat"), mod.Condition("CanGainConvergence")))
+	}),
+	listConfig("buffPendulum", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		if val == "AREA" {
+			modList.AddMod(mod.NewFlag("Condition:PendulumOfDestructionAreaOfEffect", true).Source("Config").Tag(mod.Condition("Combat")))
+		} else if val == "DAMAGE" {
+			modList.AddMod(mod.NewFlag("Condition:PendulumOfDestructionElementalDamage", true).Source("Config").Tag(mod.Condition("Combat")))
+		}
+	}),
+	listConfig("buffConflux", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		if val == "CHILLING" || val == "ALL" {
+			modList.AddMod(mod.NewFlag("Condition:ChillingConflux", true).Source("Config").Tag(mod.Condition("Combat")))
+		}
+		if val == "SHOCKING" || val == "ALL" {
+			modList.AddMod(mod.NewFlag("Condition:ShockingConflux", true).Source("Config").Tag(mod.Condition("Combat")))
+		}
+		if val == "IGNITING" || val == "ALL" {
+			modList.AddMod(mod.NewFlag("Condition:IgnitingConflux", true).Source("Config").Tag(mod.Condition("Combat")))
+		}
+	}),
+	boolConfig("buffBastionOfHope", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:BastionOfHopeActive", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("buffNgamahuFlamesAdvance", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:NgamahuFlamesAdvance", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("buffHerEmbrace", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("HerEmbrace", true).Source("Config").Tag(mod.Condition("Combat"), mod.Condition("CanGainHerEmbrace")))
+	}),
+	boolConfig("conditionUsedSkillRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierSkillUsedRecently", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:SkillUsedRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionAttackedRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:AttackedRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionCastSpellRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:CastSpellRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionCastLast1Seconds", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:CastLast1Seconds", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierCastLast8Seconds", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:CastLast8Seconds", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionUsedFireSkillRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:UsedFireSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionUsedColdSkillRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:UsedColdSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionUsedMinionSkillRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:UsedMinionSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionUsedTravelSkillRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:UsedTravelSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedMovementSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionUsedDashRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:CastDashRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedTravelSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedMovementSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionUsedMovementSkillRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:UsedMovementSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionUsedVaalSkillRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:UsedVaalSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionSoulGainPrevention", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:SoulGainPrevention", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionUsedWarcryRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:UsedWarcryRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedWarcryInPast8Seconds", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionUsedWarcryInPast8Seconds", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:UsedWarcryInPast8Seconds", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierMineDetonatedRecently", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:MineDetonatedRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierTrapTriggeredRecently", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:TrapTriggeredRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionThrownTrapOrMineRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:TrapOrMineThrownRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionCursedEnemyRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:CursedEnemyRecently", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionCastMarkRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:CastMarkRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionSpawnedCorpseRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:SpawnedCorpseRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionConsumedCorpseRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:ConsumedCorpseRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionConsumedCorpseInPast2Sec", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:ConsumedCorpseInPast2Sec", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierCorpseConsumedRecently", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:CorpseConsumedRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:ConsumedCorpseRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierWarcryUsedRecently", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:WarcryUsedRecently", mod.TypeBase, math.Min(val, 100)).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedWarcryRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedWarcryInPast8Seconds", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:UsedSkillRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionTauntedEnemyRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:TauntedEnemyRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionLostEnduranceChargeInPast8Sec", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:LostEnduranceChargeInPast8Sec", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierEnduranceChargesLostRecently", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:EnduranceChargesLostRecently", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewFlag("Condition:LostEnduranceChargeInPast8Sec", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionBlockedHitFromUniqueEnemyInPast10Sec", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:BlockedHitFromUniqueEnemyInPast10Sec", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("BlockedPast10Sec", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:BlockedPast10Sec", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionImpaledRecently", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:ImpaledRecently", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierImpalesOnEnemy", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("Multiplier:ImpaleStacks", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	numberConfig("multiplierBleedsOnEnemy", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("Multiplier:BleedStacks", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Combat")))
+		enemyModList.AddMod(mod.NewFlag("Condition:Bleeding", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("multiplierFragileRegrowth", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:FragileRegrowthCount", mod.TypeBase, math.Min(val, 10)).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionKilledUniqueEnemy", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:KilledUniqueEnemy", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("conditionHaveArborix", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:HaveIronReflexes", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewList("Keystone", "Iron Reflexes").Source("Config"))
+	}),
+	listConfig("conditionHaveAugyre", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		if val == "EleOverload" {
+			modList.AddMod(mod.NewFlag("Condition:HaveElementalOverload", true).Source("Config").Tag(mod.Condition("Combat")))
+			modList.AddMod(mod.NewList("Keystone", "Elemental Overload").Source("Config"))
+		} else if val == "ResTechnique" {
+			modList.AddMod(mod.NewFlag("Condition:HaveResoluteTechnique", true).Source("Config").Tag(mod.Condition("Combat")))
+			modList.AddMod(mod.NewList("Keystone", "Resolute Technique").Source("Config"))
+		}
+	}),
+	boolConfig("conditionHaveVulconus", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:HaveAvatarOfFire", true).Source("Config").Tag(mod.Condition("Combat")))
+		modList.AddMod(mod.NewList("Keystone", "Avatar of Fire").Source("Config"))
+	}),
+	boolConfig("conditionHaveManaStorm", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:SacrificeManaForLightning", true).Source("Config").Tag(mod.Condition("Combat")))
+	}),
+	boolConfig("buffFanaticism", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:Fanaticism", true).Source("Config").Tag(mod.Condition("Combat"), mod.Condition("CanGainFanaticism")))
+	}),
+	boolConfig("critChanceLucky", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("CritChanceLucky", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("skillForkCount", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("ForkedCount", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("skillChainCount", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("ChainCount", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("skillPierceCount", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("PiercedCount", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionAtCloseRange", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFlag("Condition:AtCloseRange", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyMoving", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Moving", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyFullLife", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:FullLife", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyLowLife", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:LowLife", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyCursed", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Cursed", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyBleeding", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Bleeding", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("multiplierRuptureStacks", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("Multiplier:RuptureStack", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
+		enemyModList.AddMod(mod.NewFloat("DamageTaken", mod.TypeMore, 25).Source("Rupture").KeywordFlag(mod.KeywordFlagBleed).Tag(mod.Multiplier("RuptureStack").Limit(3), mod.ActorCondition("enemy", "CanInflictRupture")))
+		enemyModList.AddMod(mod.NewFloat("BleedExpireRate", mod.TypeMore, 25).Source("Rupture").KeywordFlag(mod.KeywordFlagBleed).Tag(mod.Multiplier("RuptureStack").Limit(3), mod.ActorCondition("enemy", "CanInflictRupture")))
+	}),
+	boolConfig("conditionEnemyPoisoned", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Poisoned", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("multiplierPoisonOnEnemy", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("Multiplier:PoisonStack", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("multiplierWitheredStackCount", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("Multiplier:WitheredStack", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("multiplierCorrosionStackCount", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("Multiplier:CorrosionStack", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
+		enemyModList.AddMod(mod.NewFloat("Armour", mod.TypeBase, -5000).Source("Corrosion").Tag(mod.Multiplier("CorrosionStack"), mod.ActorCondition("enemy", "CanCorrode")))
+		enemyModList.AddMod(mod.NewFloat("Evasion", mod.TypeBase, -1000).Source("Corrosion").Tag(mod.Multiplier("CorrosionStack"), mod.ActorCondition("enemy", "CanCorrode")))
+	}),
+	numberConfig("multiplierEnsnaredStackCount", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:EnsnareStackCount", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
+		enemyModList.AddMod(mod.NewFlag("Condition:Moving", true).Source("Config").Tag(mod.MultiplierThreshold("EnsnareStackCount").Threshold(1).Actor("enemy")))
+	}),
+	boolConfig("conditionEnemyMaimed", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Maimed", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyHindered", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Hindered", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyBlinded", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Blinded", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("overrideBuffBlinded", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("BlindEffect", mod.TypeOverride, val).Source("Config").Tag(mod.GlobalEffect("Buff")))
+	}),
+	boolConfig("conditionEnemyTaunted", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Taunted", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyBurning", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Burning", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyIgnited", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Ignited", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyScorched", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Scorched", true).Source("Config").Tag(mod.Condition("Effective")))
+		enemyModList.AddMod(mod.NewFlag("Condition:ScorchedConfig", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("conditionScorchedEffect", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("ScorchVal", mod.TypeBase, val).Source("Config").Tag(mod.Condition("ScorchedConfig")))
+		enemyModList.AddMod(mod.NewFloat("DesiredScorchVal", mod.TypeBase, val).Source("Brittle").Tag(mod.Condition("ScorchedConfig").Neg(true)))
+	}),
+	boolConfig("conditionEnemyOnScorchedGround", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Scorched", true).Source("Config").Tag(mod.Condition("Effective")))
+		enemyModList.AddMod(mod.NewFlag("Condition:OnScorchedGround", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyChilled", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Chilled", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("conditionEnemyChilledEffect", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("ChillVal", mod.TypeOverride, val).Source("Chill").Tag(mod.Condition("Chilled")))
+	}),
+	boolConfig("conditionEnemyChilledByYourHits", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Chilled", true).Source("Config").Tag(mod.Condition("Effective")))
+		enemyModList.AddMod(mod.NewFlag("Condition:ChilledByYourHits", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyFrozen", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Frozen", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyBrittle", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Brittle", true).Source("Config").Tag(mod.Condition("Effective")))
+		enemyModList.AddMod(mod.NewFlag("Condition:BrittleConfig", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("conditionBrittleEffect", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("BrittleVal", mod.TypeBase, val).Source("Config").Tag(mod.Condition("BrittleConfig")))
+		enemyModList.AddMod(mod.NewFloat("DesiredBrittleVal", mod.TypeBase, val).Source("Brittle").Tag(mod.Condition("BrittleConfig").Neg(true)))
+	}),
+	boolConfig("conditionEnemyOnBrittleGround", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Brittle", true).Source("Config").Tag(mod.Condition("Effective")))
+		enemyModList.AddMod(mod.NewFlag("Condition:OnBrittleGround", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyShocked", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Shocked", true).Source("Config").Tag(mod.Condition("Effective")))
+		enemyModList.AddMod(mod.NewFlag("Condition:ShockedConfig", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("conditionShockEffect", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("ShockVal", mod.TypeBase, val).Source("Shock").Tag(mod.Condition("ShockedConfig")))
+		enemyModList.AddMod(mod.NewFloat("DesiredShockVal", mod.TypeBase, val).Source("Shock").Tag(mod.Condition("ShockedConfig").Neg(true)))
+	}),
+	boolConfig("conditionEnemyOnShockedGround", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Shocked", true).Source("Config").Tag(mod.Condition("Effective")))
+		enemyModList.AddMod(mod.NewFlag("Condition:OnShockedGround", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemySapped", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Sapped", true).Source("Config").Tag(mod.Condition("Effective")))
+		enemyModList.AddMod(mod.NewFlag("Condition:SappedConfig", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("conditionSapEffect", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("SapVal", mod.TypeBase, val).Source("Sap").Tag(mod.Condition("SappedConfig")))
+		enemyModList.AddMod(mod.NewFloat("DesiredSapVal", mod.TypeBase, val).Source("Sap").Tag(mod.Condition("SappedConfig").Neg(true)))
+	}),
+	boolConfig("conditionEnemyOnSappedGround", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Sapped", true).Source("Config").Tag(mod.Condition("Effective")))
+		enemyModList.AddMod(mod.NewFlag("Condition:OnSappedGround", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	numberConfig("multiplierFreezeShockIgniteOnEnemy", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:FreezeShockIgniteOnEnemy", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyFireExposure", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("FireExposure", mod.TypeBase, -10).Source("Config").Tag(mod.Condition("Effective"), mod.ActorCondition("enemy", "CanApplyFireExposure")))
+	}),
+	boolConfig("conditionEnemyColdExposure", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("ColdExposure", mod.TypeBase, -10).Source("Config").Tag(mod.Condition("Effective"), mod.ActorCondition("enemy", "CanApplyColdExposure")))
+	}),
+	boolConfig("conditionEnemyLightningExposure", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("LightningExposure", mod.TypeBase, -10).Source("Config").Tag(mod.Condition("Effective"), mod.ActorCondition("enemy", "CanApplyLightningExposure")))
+	}),
+	boolConfig("conditionEnemyIntimidated", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Intimidated", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyCrushed", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Crushed", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionNearLinkedTarget", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:NearLinkedTarget", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyUnnerved", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:Unnerved", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyCoveredInAsh", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("CoveredInAshEffect", mod.TypeBase, 20).Source("Covered in Ash"))
+	}),
+	boolConfig("conditionEnemyCoveredInFrost", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("CoveredInFrostEffect", mod.TypeBase, 20).Source("Covered in Frost"))
+	}),
+	boolConfig("conditionEnemyOnConsecratedGround", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:OnConsecratedGround", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyOnProfaneGround", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:OnProfaneGround", true).Source("Config").Tag(mod.Condition("Effective")))
+		enemyModList.AddMod(mod.NewFloat("ElementalResist", mod.TypeBase, -10).Source("Config").Tag(mod.Condition("OnProfaneGround")))
+		enemyModList.AddMod(mod.NewFloat("ChaosResist", mod.TypeBase, -10).Source("Config").Tag(mod.Condition("OnProfaneGround")))
+		modList.AddMod(mod.NewFloat("CritChance", mod.TypeBase, 1).Source("Config").Tag(mod.ActorCondition("enemy", "OnProfaneGround")))
+		modList.AddMod(mod.NewList("MinionModifier", mod.MinionModifier{Mod: mod.NewFloat("CritChance", mod.TypeBase, 1).Source("Config").Tag(mod.ActorCondition("enemy", "OnProfaneGround"))}))
+	}),
+	numberConfig("multiplierEnemyAffectedByGraspingVines", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		modList.AddMod(mod.NewFloat("Multiplier:GraspingVinesAffectingEnemy", mod.TypeBase, val).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyOnFungalGround", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:OnFungalGround", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyInChillingArea", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:InChillingArea", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("conditionEnemyInFrostGlobe", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:EnemyInFrostGlobe", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	boolConfig("enemyConditionHitByFireDamage", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:HitByFireDamage", true).Source("Config"))
+	}),
+	boolConfig("enemyConditionHitByColdDamage", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:HitByColdDamage", true).Source("Config"))
+	}),
+	boolConfig("enemyConditionHitByLightningDamage", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:HitByLightningDamage", true).Source("Config"))
+	}),
+	boolConfig("conditionEnemyRareOrUnique", func(modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFlag("Condition:RareOrUnique", true).Source("Config").Tag(mod.Condition("Effective")))
+	}),
+	listConfig("enemyIsBoss", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		switch val {
+		case "Boss":
+			enemyModList.AddMod(mod.NewFlag("Condition:RareOrUnique", true).Source("Config").Tag(mod.Condition("Effective")))
+			enemyModList.AddMod(mod.NewFloat("CurseEffectOnSelf", mod.TypeMore, -33).Source("Boss"))
+			enemyModList.AddMod(mod.NewFloat("AilmentThreshold", mod.TypeMore, 488).Source("Boss"))
+			modList.AddMod(mod.NewFloat("WarcryPower", mod.TypeBase, 20).Source("Boss"))
+		case "Pinnacle":
+			enemyModList.AddMod(mod.NewFlag("Condition:RareOrUnique", true).Source("Config").Tag(mod.Condition("Effective")))
+			enemyModList.AddMod(mod.NewFlag("Condition:PinnacleBoss", true).Source("Config").Tag(mod.Condition("Effective")))
+			enemyModList.AddMod(mod.NewFloat("CurseEffectOnSelf", mod.TypeMore, -66).Source("Boss"))
+			enemyModList.AddMod(mod.NewFloat("Armour", mod.TypeMore, 33).Source("Boss"))
+			enemyModList.AddMod(mod.NewFloat("AilmentThreshold", mod.TypeMore, 404).Source("Boss"))
+			modList.AddMod(mod.NewFloat("WarcryPower", mod.TypeBase, 20).Source("Boss"))
+		case "Uber":
+			enemyModList.AddMod(mod.NewFlag("Condition:RareOrUnique", true).Source("Config").Tag(mod.Condition("Effective")))
+			enemyModList.AddMod(mod.NewFlag("Condition:PinnacleBoss", true).Source("Config").Tag(mod.Condition("Effective")))
+			enemyModList.AddMod(mod.NewFloat("CurseEffectOnSelf", mod.TypeMore, -66).Source("Boss"))
+			enemyModList.AddMod(mod.NewFloat("Armour", mod.TypeMore, 100).Source("Boss"))
+			enemyModList.AddMod(mod.NewFloat("DamageTaken", mod.TypeMore, -70).Source("Boss"))
+			enemyModList.AddMod(mod.NewFloat("AilmentThreshold", mod.TypeMore, 404).Source("Boss"))
+			modList.AddMod(mod.NewFloat("WarcryPower", mod.TypeBase, 20).Source("Boss"))
+		}
+	}).WithDefault("None"),
+	listConfig("deliriousPercentage", func(val string, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		if val == "20Percent" {
+			enemyModList.AddMod(mod.NewFloat("DamageTaken", mod.TypeMore, -19.2).Source("20% Delirious"))
+			enemyModList.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 6).Source("20% Delirious"))
+		}
+		if val == "40Percent" {
+			enemyModList.AddMod(mod.NewFloat("DamageTaken", mod.TypeMore, -38.4).Source("40% Delirious"))
+			enemyModList.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 12).Source("40% Delirious"))
+		}
+		if val == "60Percent" {
+			enemyModList.AddMod(mod.NewFloat("DamageTaken", mod.TypeMore, -57.6).Source("60% Delirious"))
+			enemyModList.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 18).Source("60% Delirious"))
+		}
+		if val == "80Percent" {
+			enemyModList.AddMod(mod.NewFloat("DamageTaken", mod.TypeMore, -76.8).Source("80% Delirious"))
+			enemyModList.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 24).Source("80% Delirious"))
+		}
+		if val == "100Percent" {
+			enemyModList.AddMod(mod.NewFloat("DamageTaken", mod.TypeMore, -96).Source("100% Delirious"))
+			enemyModList.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 30).Source("100% Delirious"))
+		}
+	}),
+	numberConfig("enemyPhysicalReduction", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("PhysicalDamageReduction", mod.TypeBase, val).Source("Config"))
+	}),
+	numberConfig("enemyLightningResist", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("LightningResist", mod.TypeBase, val).Source("Config"))
+	}),
+	numberConfig("enemyColdResist", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("ColdResist", mod.TypeBase, val).Source("Config"))
+	}),
+	numberConfig("enemyFireResist", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("FireResist", mod.TypeBase, val).Source("Config"))
+	}),
+	numberConfig("enemyChaosResist", func(val float64, modList *moddb.ModList, enemyModList *moddb.ModList) {
+		enemyModList.AddMod(mod.NewFloat("ChaosResist", mod.TypeBase, val).Source("Config"))
+	}),
+	// Read directly by InitEnv and the defence calculations, boss presets provide their placeholders
+	numberConfig("enemyLevel", nil),
+	numberConfig("enemyArmour", nil),
+	numberConfig("enemyEvasion", nil),
+	numberConfig("enemySpeed", nil),
+	numberConfig("enemyCritChance", nil),
+	numberConfig("enemyCritDamage", nil),
+	numberConfig("enemyPhysicalDamage", nil),
+	numberConfig("enemyLightningDamage", nil),
+	numberConfig("enemyColdDamage", nil),
+	numberConfig("enemyFireDamage", nil),
+	numberConfig("enemyChaosDamage", nil),
+	numberConfig("enemyLightningPen", nil),
+	numberConfig("enemyColdPen", nil),
+	numberConfig("enemyFirePen", nil),
+	listConfig("enemyDamageType", nil).WithDefault("Average"),
+	listConfig("presetBossSkills", nil).WithDefault("None"),
+	numberConfig("EHPUnluckyWorstOf", nil).WithDefault(float64(1)),
+	boolConfig("DisableEHPGainOnBlock", nil),
+	// Not calculated yet
+	numberConfig("meleeDistance", nil),
+	numberConfig("projectileDistance", nil),
+	boolConfig("EEIgnoreHitDamage", nil),
 }
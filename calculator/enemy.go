@@ -0,0 +1,117 @@
+package calculator
+
+import (
+	"math"
+
+	"github.com/Vilsol/go-pob/data"
+)
+
+// enemyPreset holds the placeholder values of a boss type from the enemyIsBoss option
+type enemyPreset struct {
+	Level       int
+	EleResist   float64
+	ChaosResist float64
+	DPSMult     float64
+	Pen         float64
+}
+
+var enemyPresets = map[string]enemyPreset{
+	"Boss": {
+		Level:       83,
+		EleResist:   40,
+		ChaosResist: 25,
+		DPSMult:     data.StdBossDPSMult,
+	},
+	"Pinnacle": {
+		Level:       84,
+		EleResist:   50,
+		ChaosResist: 30,
+		DPSMult:     data.PinnacleBossDPSMult,
+		Pen:         data.PinnacleBossPen,
+	},
+	"Uber": {
+		Level:       85,
+		EleResist:   50,
+		ChaosResist: 30,
+		DPSMult:     data.UberBossDPSMult,
+		Pen:         data.UberBossPen,
+	},
+}
+
+var elementalDamageTypes = []data.DamageType{data.DamageTypeLightning, data.DamageTypeCold, data.DamageTypeFire}
+
+// initEnemyLevel populates the placeholders of the enemy and boss skill presets and calculates the enemy level
+func (env *Environment) initEnemyLevel() {
+	env.ConfigPlaceholder = map[string]interface{}{
+		"enemySpeed":      float64(700),
+		"enemyCritChance": float64(5),
+		"enemyCritDamage": float64(30),
+	}
+
+	preset, isBoss := enemyPresets[env.configString("enemyIsBoss")]
+	if isBoss {
+		env.ConfigPlaceholder["enemyLevel"] = float64(preset.Level)
+		for _, damageType := range elementalDamageTypes {
+			env.ConfigPlaceholder["enemy"+string(damageType)+"Resist"] = preset.EleResist
+		}
+		env.ConfigPlaceholder["enemyChaosResist"] = preset.ChaosResist
+	}
+
+	level := float64(min(env.Build.Build.Level, data.MaxEnemyLevel))
+	if v, ok := env.configNumber("enemyLevel"); ok {
+		level = v
+	}
+	env.EnemyLevel = int(max(1, min(data.MaxEnemyLevel, level)))
+
+	bossSkill := env.configString("presetBossSkills")
+	if bossSkill != "None" && bossSkill != "" {
+		// Boss skills replace the damage of the boss preset
+		env.initBossSkill(bossSkill)
+		return
+	}
+
+	monsterDamage := data.MonsterDamageTable[env.EnemyLevel] * 1.5
+	if !isBoss {
+		env.ConfigPlaceholder["enemyPhysicalDamage"] = math.Round(monsterDamage)
+		return
+	}
+
+	damage := math.Round(monsterDamage * preset.DPSMult)
+	env.ConfigPlaceholder["enemyPhysicalDamage"] = damage
+	for _, damageType := range elementalDamageTypes {
+		env.ConfigPlaceholder["enemy"+string(damageType)+"Damage"] = damage
+		if preset.Pen != 0 {
+			env.ConfigPlaceholder["enemy"+string(damageType)+"Pen"] = preset.Pen
+		}
+	}
+	env.ConfigPlaceholder["enemyChaosDamage"] = damage / 4
+}
+
+// initBossSkill populates the placeholders of a boss skill from the presetBossSkills option, inputs set by the build take precedence
+func (env *Environment) initBossSkill(skill string) {
+	monsterDamage := data.MonsterDamageTable[env.EnemyLevel]
+
+	switch skill {
+	case "Uber Atziri Flameblast":
+		env.ConfigPlaceholder["enemyFireDamage"] = math.Round(monsterDamage * 3.48 * 10.9)
+		env.ConfigPlaceholder["enemyFirePen"] = float64(10)
+		env.ConfigPlaceholder["enemySpeed"] = float64(25000)
+		env.ConfigPlaceholder["enemyCritChance"] = float64(0)
+		env.ConfigPlaceholder["enemyDamageType"] = "Spell"
+	case "Shaper Ball":
+		env.ConfigPlaceholder["enemyColdDamage"] = math.Round(monsterDamage * 9.17)
+		env.ConfigPlaceholder["enemyColdPen"] = float64(25)
+		env.ConfigPlaceholder["enemySpeed"] = float64(1400)
+		env.ConfigPlaceholder["enemyDamageType"] = "SpellProjectile"
+	case "Shaper Slam":
+		env.ConfigPlaceholder["enemyPhysicalDamage"] = math.Round(monsterDamage * 15.2)
+		env.ConfigPlaceholder["enemySpeed"] = float64(3510)
+		env.ConfigPlaceholder["enemyDamageType"] = "Melee"
+	case "Maven Memory Game":
+		damage := math.Round(monsterDamage * 24.69)
+		for _, damageType := range elementalDamageTypes {
+			env.ConfigPlaceholder["enemy"+string(damageType)+"Damage"] = damage
+		}
+		env.ConfigPlaceholder["enemyDamageType"] = "Melee"
+	}
+}
@@ -1,6 +1,10 @@
 package calculator
 
 import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -13,30 +17,35 @@ import (
 	"github.com/Vilsol/go-pob/utils"
 )
 
-func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputMode) (*Environment, moddb.ModStoreFuncs, moddb.ModStoreFuncs, moddb.ModStoreFuncs) {
+func InitEnv(ctx context.Context, build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputMode) (*Environment, moddb.ModStoreFuncs, moddb.ModStoreFuncs, moddb.ModStoreFuncs, error) {
 	env := &Environment{}
 	env.Cache = envCache
-
-	// TODO This come from the build at some point but below it's not so not sure what to do here yet
-	currentTreeVersion := data.LatestTreeVersion
-
-	// Clear Node Mod cache if tree has changed
-	if env.Cache.TreeVersion != currentTreeVersion {
-		env.Cache.modsForNodes = make(map[string]moddb.ModList, len(data.TreeVersions[currentTreeVersion].Tree().Nodes))
-		env.Cache.TreeVersion = currentTreeVersion
-	}
+	env.ctx = ctx
 
 	env.DebugErrors = make([]string, 0)
 	env.Build = build
 	env.Mode = mode
-	env.Spec = NewPassiveSpec(build, data.LatestTreeVersion)
+
+	treeVersion := data.LatestTreeVersion
+	if spec := build.ActiveSpec(); spec != nil {
+		var ok bool
+		if treeVersion, ok = data.ResolveTreeVersion(spec.TreeVersion); !ok {
+			env.DebugErrors = append(env.DebugErrors, "Tree version "+string(spec.TreeVersion)+" is not available, using "+string(treeVersion))
+		}
+	}
+
+	spec, err := NewPassiveSpec(ctx, build, treeVersion)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to initialize passive spec: %w", err)
+	}
+	env.Spec = spec
 
 	env.ModDB = moddb.NewModDB()
 	env.EnemyModDB = moddb.NewModDB()
 	env.ItemModDB = moddb.NewModDB()
 
-	// TODO m_max(1, m_min(100, env.configInput.enemyLevel and env.configInput.enemyLevel or env.configPlaceholder["enemyLevel"] or m_min(env.build.characterLevel, data.misc.MaxEnemyLevel)))
-	env.EnemyLevel = 1
+	env.ConfigInput = configInputs(build)
+	env.initEnemyLevel()
 
 	env.Player = &Actor{
 		ModDB:           env.ModDB,
@@ -101,10 +110,11 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 	env.ModDB.AddMod(mod.NewFloat("Accuracy", mod.TypeBase, 2).Source("Base").Tag(mod.Multiplier("Level").Base(-2)))
 	env.ModDB.AddMod(mod.NewFloat("CritMultiplier", mod.TypeBase, 50).Source("Base"))
 	env.ModDB.AddMod(mod.NewFloat("DotMultiplier", mod.TypeBase, 50).Source("Base").Tag(mod.Condition("CriticalStrike")))
-	env.ModDB.AddMod(mod.NewFloat("FireResist", mod.TypeBase /* TODO env.configInput.resistancePenalty or */, -60).Source("Base"))
-	env.ModDB.AddMod(mod.NewFloat("ColdResist", mod.TypeBase /* TODO env.configInput.resistancePenalty or */, -60).Source("Base"))
-	env.ModDB.AddMod(mod.NewFloat("LightningResist", mod.TypeBase /* TODO env.configInput.resistancePenalty or */, -60).Source("Base"))
-	env.ModDB.AddMod(mod.NewFloat("ChaosResist", mod.TypeBase /* TODO env.configInput.resistancePenalty or */, -60).Source("Base"))
+	resistancePenalty, _ := env.configNumber("resistancePenalty")
+	env.ModDB.AddMod(mod.NewFloat("FireResist", mod.TypeBase, resistancePenalty).Source("Base"))
+	env.ModDB.AddMod(mod.NewFloat("ColdResist", mod.TypeBase, resistancePenalty).Source("Base"))
+	env.ModDB.AddMod(mod.NewFloat("LightningResist", mod.TypeBase, resistancePenalty).Source("Base"))
+	env.ModDB.AddMod(mod.NewFloat("ChaosResist", mod.TypeBase, resistancePenalty).Source("Base"))
 	env.ModDB.AddMod(mod.NewFloat("TotemFireResist", mod.TypeBase, 40).Source("Base"))
 	env.ModDB.AddMod(mod.NewFloat("TotemColdResist", mod.TypeBase, 40).Source("Base"))
 	env.ModDB.AddMod(mod.NewFloat("TotemLightningResist", mod.TypeBase, 40).Source("Base"))
@@ -181,33 +191,37 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 	// Initialise enemy modifier database
 	initModDB(env, env.EnemyModDB)
 	env.EnemyModDB.AddMod(mod.NewFloat("Accuracy", mod.TypeBase, data.MonsterAccuracyTable[env.EnemyLevel]).Source("Base"))
-	env.EnemyModDB.AddMod(mod.NewFloat("Evasion", mod.TypeBase, data.MonsterEvasionTable[env.EnemyLevel]).Source("Base"))
-	env.EnemyModDB.AddMod(mod.NewFloat("Armour", mod.TypeBase, data.MonsterArmourTable[env.EnemyLevel]).Source("Base"))
+	enemyEvasion, ok := env.configNumber("enemyEvasion")
+	if !ok {
+		enemyEvasion = data.MonsterEvasionTable[env.EnemyLevel]
+	}
+	env.EnemyModDB.AddMod(mod.NewFloat("Evasion", mod.TypeBase, enemyEvasion).Source("Base"))
+	enemyArmour, ok := env.configNumber("enemyArmour")
+	if !ok {
+		enemyArmour = data.MonsterArmourTable[env.EnemyLevel]
+	}
+	env.EnemyModDB.AddMod(mod.NewFloat("Armour", mod.TypeBase, enemyArmour).Source("Base"))
 
 	// Add mods from the config tab
 	confModList := moddb.NewModList()
 	confEnemyModList := moddb.NewModList()
-	for _, input := range build.Config.Inputs {
-		if config, ok := configurations[input.Name]; ok {
-			var value interface{}
-			if input.String != nil {
-				value = *input.String
-			} else if input.Boolean != nil {
-				value = *input.Boolean
-			} else if input.Number != nil {
-				value = *input.Number
-			}
-			config(value, confModList, confEnemyModList)
+	for _, option := range configOptions {
+		val, ok := env.ConfigInput[option.Name]
+		if !ok {
+			val = env.ConfigPlaceholder[option.Name]
 		}
+		option.Apply(val, confModList, confEnemyModList)
 	}
 	env.ModDB.AddList(confModList)
 	env.EnemyModDB.AddList(confEnemyModList)
 
 	cachedPlayerDB := env.ModDB.Clone()
 	cachedEnemyDB := env.EnemyModDB.Clone()
-	cachedMinionDB := env.Minion.Clone()
+	var cachedMinionDB moddb.ModStoreFuncs
+	if env.Minion != nil {
+		cachedMinionDB = env.Minion.ModDB.Clone()
+	}
 
-	var tree = data.TreeVersions[data.LatestTreeVersion].Tree()
 	env.AllocatedNodes = make(map[string]data.Node)
 	/* *
 	// TODO
@@ -225,268 +239,207 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 		end
 	end
 	/* */
-	for _, id := range env.Build.Build.PassiveNodes {
-		var strId = strconv.FormatInt(id, 10)
-		env.AllocatedNodes[strId] = tree.Nodes[strId]
+	for id, node := range env.Spec.AllocNodes {
+		env.AllocatedNodes[id] = node
 	}
 
-	/*
-		TODO -- Build and merge item modifiers, and create list of radius jewels
-		for _, slot in pairs(build.itemsTab.orderedSlots) do
-			local slotName = slot.slotName
-			local item
-			if slotName == override.repSlotName then
-				item = override.repItem
-			elseif override.repItem and override.repSlotName:match("^Weapon 1") and slotName:match("^Weapon 2") and
-			(override.repItem.base.type == "Staff" or override.repItem.base.type == "Two Handed Sword" or override.repItem.base.type == "Two Handed Axe" or override.repItem.base.type == "Two Handed Mace"
-			or (override.repItem.base.type == "Bow" and item and item.base.type ~= "Quiver")) then
-				item = nil
-			elseif slot.nodeId and override.spec then
-				item = build.itemsTab.items[env.spec.jewels[slot.nodeId]]
-			else
-				item = build.itemsTab.items[slot.selItemId]
-			end
-			if item then
-				-- Find skills granted by this item
-				for _, skill in ipairs(item.grantedSkills) do
-					local grantedSkill = copyTable(skill)
-					grantedSkill.sourceItem = item
-					grantedSkill.slotName = slotName
-					t_insert(env.grantedSkillsItems, grantedSkill)
-				end
+	// Build and merge item modifiers
+	env.Player.ItemList = make(map[string]*pob.Item)
+	env.Player.ArmourData = make(map[string]*ArmourData)
+
+	itemSlots := build.Items.Slots
+	useSecondWeaponSet := build.Items.UseSecondWeaponSet != nil && *build.Items.UseSecondWeaponSet
+	if itemSet := build.Items.ActiveSet(); itemSet != nil {
+		itemSlots = itemSet.Slots
+		useSecondWeaponSet = itemSet.UseSecondWeaponSet != nil && *itemSet.UseSecondWeaponSet
+	}
+
+	// Abyssal sockets depend on the item in their parent slot, so they are processed last
+	orderedSlots := utils.CopySlice(itemSlots)
+	slices.SortStableFunc(orderedSlots, func(a, b pob.Slot) int {
+		aParent, _ := abyssalSocketParent(a.Name)
+		bParent, _ := abyssalSocketParent(b.Name)
+		return cmp.Compare(utils.Ternary(aParent != "", 1, 0), utils.Ternary(bParent != "", 1, 0))
+	})
+
+	itemWeaponData := make(map[string]*WeaponData)
+	for _, slot := range orderedSlots {
+		slotName := slot.Name
+		item := build.Items.SlotItem(slot)
+
+		/*
+			TODO -- Find skills granted by this item
+			for _, skill in ipairs(item.grantedSkills) do
+				local grantedSkill = copyTable(skill)
+				grantedSkill.sourceItem = item
+				grantedSkill.slotName = slotName
+				t_insert(env.grantedSkillsItems, grantedSkill)
 			end
-			if slot.weaponSet and slot.weaponSet ~= (build.itemsTab.activeItemSet.useSecondWeaponSet and 2 or 1) then
+		*/
+
+		if weaponSet := itemSlotWeaponSet(slotName); weaponSet != 0 {
+			if (weaponSet == 2) != useSecondWeaponSet {
 				item = nil
-			end
-			if slot.weaponSet == 2 and build.itemsTab.activeItemSet.useSecondWeaponSet then
-				slotName = slotName:gsub(" Swap","")
-			end
-			if slot.nodeId then
-				-- Slot is a jewel socket, check if socket is allocated
-				if not env.allocNodes[slot.nodeId] then
-					item = nil
-				elseif item and item.jewelRadiusIndex then
-					-- Jewel has a radius, add it to the list
-					local funcList = item.jewelData.funcList or { { type = "Self", func = function(node, out, data)
-						-- Default function just tallies all stats in radius
-						if node then
-							for _, stat in pairs({"Str","Dex","Int"}) do
-								data[stat] = (data[stat] or 0) + out:Sum("BASE", nil, stat)
-							end
-						end
-					end } }
-					for _, func in ipairs(funcList) do
-						local node = env.spec.nodes[slot.nodeId]
-						t_insert(env.radiusJewelList, {
-							nodes = node.nodesInRadius and node.nodesInRadius[item.jewelRadiusIndex] or { },
-							func = func.func,
-							type = func.type,
-							item = item,
-							nodeId = slot.nodeId,
-							attributes = node.attributesInRadius and node.attributesInRadius[item.jewelRadiusIndex] or { },
-							data = { }
-						})
-						if func.type ~= "Self" and node.nodesInRadius then
-							-- Add nearby unallocated nodes to the extra node list
-							for nodeId, node in pairs(node.nodesInRadius[item.jewelRadiusIndex]) do
-								if not env.allocNodes[nodeId] then
-									env.extraRadiusNodeList[nodeId] = env.spec.nodes[nodeId]
-								end
-							end
-						end
-					end
-				end
-			end
-			if item and item.type == "Flask" then
-				if slot.active then
-					env.flasks[item] = true
-				end
+			} else if weaponSet == 2 {
+				slotName = weaponSwapReplacer.Replace(slotName)
+			}
+		}
+
+		if item == nil {
+			continue
+		}
+
+		if strings.HasPrefix(slotName, "Flask ") {
+			if slot.Active {
+				env.Flasks[slotName] = item
+			}
+			/*
+				TODO Life flask recovery
 				if item.base.subType == "Life" then
 					local highestLifeRecovery = env.itemModDB.multipliers["LifeFlaskRecovery"] or 0
 					if item.flaskData.lifeTotal > highestLifeRecovery then
 						env.itemModDB.multipliers["LifeFlaskRecovery"] = item.flaskData.lifeTotal
 					end
 				end
-				item = nil
-			end
-			local scale = 1
-			if item and item.type == "Jewel" and item.base.subType == "Abyss" and slot.parentSlot then
-				-- Check if the item in the parent slot has enough Abyssal Sockets
-				local parentItem = env.player.itemList[slot.parentSlot.slotName]
-				if not parentItem or parentItem.abyssalSocketCount < slot.slotNum then
-					item = nil
-				else
-					scale = parentItem.socketedJewelEffectModifier
-				end
+			*/
+			continue
+		}
+
+		parentSlot, socketNum := abyssalSocketParent(slotName)
+		if parentSlot != "" {
+			// Check if the item in the parent slot has enough Abyssal Sockets
+			parentItem := env.Player.ItemList[parentSlot]
+			if parentItem == nil || parentItem.AbyssalSocketCount() < socketNum {
+				continue
+			}
+			// TODO scale = parentItem.socketedJewelEffectModifier
+		}
+
+		env.Player.ItemList[slotName] = item
+
+		/*
+			TODO Requirements
+			if item.requirements and not accelerate.requirementsItems then
+				t_insert(env.requirementsTableItems, {
+					source = "Item",
+					sourceItem = item,
+					sourceSlot = slotName,
+					Str = item.requirements.strMod,
+					Dex = item.requirements.dexMod,
+					Int = item.requirements.intMod,
+				})
 			end
-			if slot.nodeId and item and item.type == "Jewel" and item.jewelData and item.jewelData.jewelIncEffectFromClassStart then
-				local node = env.spec.nodes[slot.nodeId]
-				if node and node.distanceToClassStart then
-					scale = scale + node.distanceToClassStart * (item.jewelData.jewelIncEffectFromClassStart / 100)
-				end
+		*/
+
+		if parentSlot != "" {
+			// Update Abyss Jewel conditions/multipliers
+			baseName := strings.ReplaceAll(item.BaseName, " ", "")
+			cond := "Have" + baseName
+			if !env.ItemModDB.Conditions[cond] {
+				env.ItemModDB.Conditions[cond] = true
+				env.ItemModDB.Multipliers["AbyssJewelType"]++
+			}
+			env.ItemModDB.Conditions[cond+"In"+parentSlot] = true
+			env.ItemModDB.Multipliers["AbyssJewel"]++
+			env.ItemModDB.Multipliers[baseName]++
+		}
+
+		/*
+			TODO Special handling for Necromantic Aegis, Energy Blade, The Iron Mass and The Dancing Dervish
+			See CalcSetup.lua, these split the item mods between the player and a separate mod list
+		*/
+		itemModList, weaponData, armourData := buildModListForItem(env, item, slotName)
+		env.ItemModDB.AddList(itemModList)
+		if weaponData != nil {
+			itemWeaponData[slotName] = weaponData
+		}
+		if armourData != nil {
+			env.Player.ArmourData[slotName] = armourData
+		}
+
+		/*
+			TODO -- set conditions on restricted items
+			if item.classRestriction then
+				env.itemModDB.conditions[item.title:gsub(" ", "")] = item.classRestriction
 			end
-			if item then
-				env.player.itemList[slotName] = item
-				-- Merge mods for this item
-				local srcList = item.modList or item.slotModList[slot.slotNum]
-				if item.requirements and not accelerate.requirementsItems then
-					t_insert(env.requirementsTableItems, {
-						source = "Item",
-						sourceItem = item,
-						sourceSlot = slotName,
-						Str = item.requirements.strMod,
-						Dex = item.requirements.dexMod,
-						Int = item.requirements.intMod,
-					})
-				end
-				if item.type == "Jewel" and item.base.subType == "Abyss" then
-					-- Update Abyss Jewel conditions/multipliers
-					local cond = "Have"..item.baseName:gsub(" ","")
-					if not env.itemModDB.conditions[cond] then
-						env.itemModDB.conditions[cond] = true
-						env.itemModDB.multipliers["AbyssJewelType"] = (env.itemModDB.multipliers["AbyssJewelType"] or 0) + 1
-					end
-					if slot.parentSlot then
-						env.itemModDB.conditions[cond.."In"..slot.parentSlot.slotName] = true
-					end
-					env.itemModDB.multipliers["AbyssJewel"] = (env.itemModDB.multipliers["AbyssJewel"] or 0) + 1
-					env.itemModDB.multipliers[item.baseName:gsub(" ","")] = (env.itemModDB.multipliers[item.baseName:gsub(" ","")] or 0) + 1
-				end
-				if item.type == "Shield" and env.allocNodes[45175] and env.allocNodes[45175].dn == "Necromantic Aegis" then
-					-- Special handling for Necromantic Aegis
-					env.aegisModList = new("ModList")
-					for _, mod in ipairs(srcList) do
-						-- Filter out mods that apply to socketed gems, or which add supports
-						local add = true
-						for _, tag in ipairs(mod) do
-							if tag.type == "SocketedIn" then
-								add = false
-								break
-							end
-						end
-						if add then
-							env.aegisModList:ScaleAddMod(mod, scale)
-						else
-							env.itemModDB:ScaleAddMod(mod, scale)
-						end
-					end
-				elseif (slotName == "Weapon 1" or slotName == "Weapon 2") and modDB:Flag(nil, "Condition:EnergyBladeActive") then
-					local type = env.player.itemList[slotName] and env.player.itemList[slotName].weaponData and env.player.itemList[slotName].weaponData[1].type
-					local info = env.data.weaponTypeInfo[type]
-					if info and type ~= "Bow" then
-						local name = info.oneHand and "Energy Blade One Handed" or "Energy Blade Two Handed"
-						local item = new("Item")
-						item.name = name
-						item.base = data.itemBases[name]
-						item.baseName = name
-						item.classRequirementModLines = { }
-						item.buffModLines = { }
-						item.enchantModLines = { }
-						item.scourgeModLines = { }
-						item.implicitModLines = { }
-						item.explicitModLines = { }
-						item.quality = 0
-						item.rarity = "NORMAL"
-						if item.baseName.implicit then
-							local implicitIndex = 1
-							for line in item.baseName.implicit:gmatch("[^\n]+") do
-								local modList, extra = modLib.parseMod(line)
-								t_insert(item.implicitModLines, { line = line, extra = extra, modList = modList or { }, modTags = item.baseName.implicitModTypes and item.baseName.implicitModTypes[implicitIndex] or { } })
-								implicitIndex = implicitIndex + 1
-							end
-						end
-						item:NormaliseQuality()
-						item:BuildAndParseRaw()
-						env.player.itemList[slotName] = item
-					else
-						env.itemModDB:ScaleAddList(srcList, scale)
-					end
-				elseif slotName == "Weapon 1" and item.name == "The Iron Mass, Gladius" then
-					-- Special handling for The Iron Mass
-					env.theIronMass = new("ModList")
-					for _, mod in ipairs(srcList) do
-						-- Filter out mods that apply to socketed gems, or which add supports
-						local add = true
-						for _, tag in ipairs(mod) do
-							if tag.type == "SocketedIn" then
-								add = false
-								break
-							end
-						end
-						if add then
-							env.theIronMass:ScaleAddMod(mod, scale)
-						end
-						-- Add all the stats to player as well
-						env.itemModDB:ScaleAddMod(mod, scale)
-					end
-				elseif slotName == "Weapon 1" and item.grantedSkills[1] and item.grantedSkills[1].skillId == "UniqueAnimateWeapon" then
-					-- Special handling for The Dancing Dervish
-					env.weaponModList1 = new("ModList")
-					for _, mod in ipairs(srcList) do
-						-- Filter out mods that apply to socketed gems, or which add supports
-						local add = true
-						for _, tag in ipairs(mod) do
-							if tag.type == "SocketedIn" then
-								add = false
-								break
-							end
-						end
-						if add then
-							env.weaponModList1:ScaleAddMod(mod, scale)
-						else
-							env.itemModDB:ScaleAddMod(mod, scale)
-						end
-					end
-				else
-					env.itemModDB:ScaleAddList(srcList, scale)
-				end
-				-- set conditions on restricted items
-				if item.classRestriction then
-					env.itemModDB.conditions[item.title:gsub(" ", "")] = item.classRestriction
-				end
-				if item.type ~= "Jewel" and item.type ~= "Flask" then
-					-- Update item counts
-					local key
-					if item.rarity == "UNIQUE" or item.rarity == "RELIC" then
-						key = "UniqueItem"
-					elseif item.rarity == "RARE" then
-						key = "RareItem"
-					elseif item.rarity == "MAGIC" then
-						key = "MagicItem"
-					else
-						key = "NormalItem"
-					end
-					env.itemModDB.multipliers[key] = (env.itemModDB.multipliers[key] or 0) + 1
-					env.itemModDB.conditions[key .. "In" .. slotName] = true
-					if item.corrupted then
-						env.itemModDB.multipliers.CorruptedItem = (env.itemModDB.multipliers.CorruptedItem or 0) + 1
-					else
-						env.itemModDB.multipliers.NonCorruptedItem = (env.itemModDB.multipliers.NonCorruptedItem or 0) + 1
-					end
-					if item.shaper then
-						env.itemModDB.multipliers.ShaperItem = (env.itemModDB.multipliers.ShaperItem or 0) + 1
-						env.itemModDB.conditions["ShaperItemIn"..slotName] = true
-					else
-						env.itemModDB.multipliers.NonShaperItem = (env.itemModDB.multipliers.NonShaperItem or 0) + 1
-					end
-					if item.elder then
-						env.itemModDB.multipliers.ElderItem = (env.itemModDB.multipliers.ElderItem or 0) + 1
-						env.itemModDB.conditions["ElderItemIn"..slotName] = true
-					else
-						env.itemModDB.multipliers.NonElderItem = (env.itemModDB.multipliers.NonElderItem or 0) + 1
-					end
-					if item.shaper or item.elder then
-						env.itemModDB.multipliers.ShaperOrElderItem = (env.itemModDB.multipliers.ShaperOrElderItem or 0) + 1
-					end
+		*/
+
+		if parentSlot == "" {
+			// Update item counts
+			var key string
+			switch item.Rarity {
+			case pob.RarityUnique, pob.RarityRelic:
+				key = "UniqueItem"
+			case pob.RarityRare:
+				key = "RareItem"
+			case pob.RarityMagic:
+				key = "MagicItem"
+			default:
+				key = "NormalItem"
+			}
+			env.ItemModDB.Multipliers[key]++
+			env.ItemModDB.Conditions[key+"In"+slotName] = true
+
+			if item.Corrupted {
+				env.ItemModDB.Multipliers["CorruptedItem"]++
+			} else {
+				env.ItemModDB.Multipliers["NonCorruptedItem"]++
+			}
+
+			shaper := slices.Contains(item.Influences, pob.InfluenceShaper)
+			if shaper {
+				env.ItemModDB.Multipliers["ShaperItem"]++
+				env.ItemModDB.Conditions["ShaperItemIn"+slotName] = true
+			} else {
+				env.ItemModDB.Multipliers["NonShaperItem"]++
+			}
+
+			elder := slices.Contains(item.Influences, pob.InfluenceElder)
+			if elder {
+				env.ItemModDB.Multipliers["ElderItem"]++
+				env.ItemModDB.Conditions["ElderItemIn"+slotName] = true
+			} else {
+				env.ItemModDB.Multipliers["NonElderItem"]++
+			}
+
+			if shaper || elder {
+				env.ItemModDB.Multipliers["ShaperOrElderItem"]++
+			}
+		}
+	}
+
+	// Jewels socketed in the passive tree only apply while their socket is allocated
+	if spec := build.ActiveSpec(); spec != nil && spec.Sockets != nil {
+		for _, socket := range spec.Sockets.Sockets {
+			nodeID := strconv.FormatInt(socket.NodeID, 10)
+			if _, ok := env.Spec.AllocNodes[nodeID]; !ok {
+				continue
+			}
+
+			item := build.Items.SlotItem(pob.Slot{ItemID: socket.ItemID})
+			if item == nil {
+				continue
+			}
+
+			/*
+				TODO Radius jewels
+				if item.jewelRadiusIndex then
+					-- Jewel has a radius, add it to the list
+					local funcList = item.jewelData.funcList or { { type = "Self", func = function(node, out, data)
+					...
 				end
-			end
-		end
-	*/
+			*/
 
-	/*
-		TODO -- Merge env.itemModDB with env.ModDB
-		mergeDB(env.modDB, env.itemModDB)
-	*/
+			slotName := "Jewel " + nodeID
+			env.Player.ItemList[slotName] = item
+
+			itemModList, _, _ := buildModListForItem(env, item, slotName)
+			env.ItemModDB.AddList(itemModList)
+		}
+	}
+
+	// Merge env.itemModDB with env.ModDB
+	mergeDB(env.ModDB, env.ItemModDB)
 
 	/*
 		TODO Flask Override
@@ -622,71 +575,50 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 		*/
 	}
 
-	env.Player.WeaponData1 = utils.CopyMap(data.UnarmedWeaponData[data.ClassIDs[env.Spec.ClassName]])
-	//if _, ok := env.Player.ItemList["Weapon 1"]; ok {
-	// TODO Weapon 1 Data
-	// env.player.itemList["Weapon 1"].weaponData and env.player.itemList["Weapon 1"].weaponData[1]
-	//}
+	// Get the weapon data tables for the equipped weapons
+	env.Player.WeaponData1 = NewUnarmedWeaponData(env.Spec.ClassName)
+	if weaponData, ok := itemWeaponData["Weapon 1"]; ok {
+		env.Player.WeaponData1 = weaponData
+	}
 
-	if utils.HasTrue(env.Player.WeaponData1, "countsAsDualWielding") {
-		// TODO
-		// env.player.weaponData2 = env.player.itemList["Weapon 1"].weaponData[2]
+	if env.Player.WeaponData1.CountsAsDualWielding {
+		env.Player.WeaponData2 = env.Player.WeaponData1.Clone()
 	} else {
-		// TODO
-		// env.player.weaponData2 = env.player.itemList["Weapon 2"] and env.player.itemList["Weapon 2"].weaponData and env.player.itemList["Weapon 2"].weaponData[2] or { }
-		env.Player.WeaponData2 = make(map[string]interface{})
+		env.Player.WeaponData2 = itemWeaponData["Weapon 2"]
 	}
 
-	/*
-		TODO -- Get the weapon data tables for the equipped weapons
-		if env.player.weaponData1.countsAsDualWielding then
-			env.player.weaponData2 = env.player.itemList["Weapon 1"].weaponData[2]
-		else
-			env.player.weaponData2 = env.player.itemList["Weapon 2"] and env.player.itemList["Weapon 2"].weaponData and env.player.itemList["Weapon 2"].weaponData[2] or { }
-		end
-	*/
-
-	if env.Mode == OutputModeCalcs {
+	// Determine main skill group
+	selectedSkillSet := build.Skills.ActiveSkillSet - 1
+	if selectedSkillSet < 0 {
+		selectedSkillSet = 0
+	}
 
-	} else {
-		selectedSkillSet := build.Skills.ActiveSkillSet - 1
-		if selectedSkillSet < 0 {
-			selectedSkillSet = 0
-		}
+	skillCount := 0
+	if len(build.Skills.SkillSets) > selectedSkillSet {
+		skillCount = len(build.Skills.SkillSets[selectedSkillSet].Skills)
+	}
 
-		skillCount := 0
-		if len(build.Skills.SkillSets) > selectedSkillSet {
-			skillCount = len(build.Skills.SkillSets[selectedSkillSet].Skills)
+	if env.Mode == OutputModeCalcs {
+		skillNumber := 1
+		for _, input := range build.Calcs.Inputs {
+			if input.Name == "skill_number" && input.Number != nil {
+				skillNumber = int(*input.Number)
+			}
 		}
-		build.Build.MainSocketGroup = min(max(skillCount, 1), build.Build.MainSocketGroup) - 1
-		env.MainSocketGroup = build.Build.MainSocketGroup
+		env.MainSocketGroup = min(max(skillCount, 1), skillNumber) - 1
+	} else {
+		env.MainSocketGroup = min(max(skillCount, 1), build.Build.MainSocketGroup) - 1
 	}
 
-	/*
-		TODO -- Determine main skill group
-		if env.mode == "CALCS" then
-			env.calcsInput.skill_number = m_min(m_max(#build.skillsTab.socketGroupList, 1), env.calcsInput.skill_number or 1)
-			env.mainSocketGroup = env.calcsInput.skill_number
-		else
-			build.mainSocketGroup = m_min(m_max(#build.skillsTab.socketGroupList, 1), build.mainSocketGroup or 1)
-			env.mainSocketGroup = build.mainSocketGroup
-		end
-	*/
-
 	// Build list of active skills
 	groupCfg := &moddb.ListCfg{}
 
 	// Below we re-order the socket group list in order to support modifiers introduced in 3.16
 	// which allow a Shield (Weapon 2) to link to a Main Hand and an Amulet to link to a Body Armour
 	// as we need their support gems and effects to be processed before we cross-link them to those slots
-	selectedSkillSet := build.Skills.ActiveSkillSet - 1
-	if selectedSkillSet < 0 {
-		selectedSkillSet = 0
-	}
-
 	var indexOrder []int
 	if selectedSkillSet < len(build.Skills.SkillSets) {
-		indexOrder = make([]int, len(build.Skills.SkillSets[selectedSkillSet].Skills))
+		indexOrder = make([]int, 0, len(build.Skills.SkillSets[selectedSkillSet].Skills))
 		for i, socketGroup := range build.Skills.SkillSets[selectedSkillSet].Skills {
 			if socketGroup.Slot == "Amulet" || socketGroup.Slot == "Weapon 2" {
 				indexOrder = append([]int{i}, indexOrder...)
@@ -718,7 +650,7 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 
 			// Build list of supports for this socket group
 			supportList := make([]*GemEffect, 0)
-			if socketGroup.Source == nil {
+			if socketGroup.Source == "" {
 				// Add extra supports from the item this group is socketed in
 				for _, value := range env.ModDB.List(groupCfg, "ExtraSupport") {
 					_ = value
@@ -1032,7 +964,64 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 		env.requirementsTable = tableConcat(env.requirementsTableItems, env.requirementsTableGems)
 	*/
 
-	return env, cachedPlayerDB, cachedEnemyDB, cachedMinionDB
+	return env, cachedPlayerDB, cachedEnemyDB, cachedMinionDB, nil
+}
+
+// configInputs converts the saved config inputs into values accepted by ConfigOption.Apply
+func configInputs(build *pob.PathOfBuilding) map[string]interface{} {
+	inputs := make(map[string]interface{}, len(build.Config.Inputs))
+	for _, input := range build.Config.Inputs {
+		if input.String != nil {
+			inputs[input.Name] = *input.String
+		} else if input.Boolean != nil {
+			inputs[input.Name] = *input.Boolean
+		} else if input.Number != nil {
+			inputs[input.Name] = *input.Number
+		}
+	}
+	return inputs
+}
+
+// configValue returns the input of an option, falling back to the placeholder and then the default
+func (env *Environment) configValue(name string) interface{} {
+	if v, ok := env.ConfigInput[name]; ok {
+		return v
+	}
+	if v, ok := env.ConfigPlaceholder[name]; ok {
+		return v
+	}
+	if option := GetConfigOption(name); option != nil {
+		return option.Default
+	}
+	return nil
+}
+
+// configString returns the value of a list option, or an empty string if it has none
+func (env *Environment) configString(name string) string {
+	v, _ := env.configValue(name).(string)
+	return v
+}
+
+// configNumber returns the value of a number option, a zero default counts as unset like in ConfigOption.Apply
+func (env *Environment) configNumber(name string) (float64, bool) {
+	if v, ok := env.ConfigInput[name].(float64); ok {
+		return v, true
+	}
+	if v, ok := env.ConfigPlaceholder[name].(float64); ok {
+		return v, true
+	}
+	if option := GetConfigOption(name); option != nil {
+		if v, ok := option.Default.(float64); ok && v != 0 {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// configBool reports whether a bool option is enabled
+func (env *Environment) configBool(name string) bool {
+	v, _ := env.configValue(name).(bool)
+	return v
 }
 
 func initModDB(env *Environment, modDB *moddb.ModDB) {
@@ -7,12 +7,14 @@ import (
 
 	"github.com/Vilsol/go-pob-data/poe"
 	"github.com/Vilsol/go-pob/cache"
+	"github.com/Vilsol/go-pob/mod"
 	"github.com/Vilsol/go-pob/moddb"
 
 	"github.com/MarvinJWendt/testza"
 
 	"github.com/Vilsol/go-pob/builds"
 	"github.com/Vilsol/go-pob/config"
+	"github.com/Vilsol/go-pob/data"
 	"github.com/Vilsol/go-pob/data/raw"
 )
 
@@ -32,9 +34,238 @@ func TestEmptyEnv(t *testing.T) {
 	build, err := builds.ParseBuild(file)
 	testza.AssertNoError(t, err)
 
-	_, cachedPlayerDB, cachedEnemyDB, cachedMinionDB := InitEnv(build, testCache, OutputModeMain)
+	_, cachedPlayerDB, cachedEnemyDB, cachedMinionDB, err := InitEnv(context.Background(), build, testCache, OutputModeMain)
+	testza.AssertNoError(t, err)
 
 	testza.AssertEqual(t, 101, len(cachedPlayerDB.(*moddb.ModDB).Mods))
 	testza.AssertEqual(t, 60, len(cachedEnemyDB.(*moddb.ModDB).Mods))
 	testza.AssertNil(t, cachedMinionDB)
 }
+
+func TestItemEnv(t *testing.T) {
+	err := poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil)
+	testza.AssertNoError(t, err)
+
+	file, err := os.ReadFile("../testdata/many-builds/13.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	env, _, _, _, err := InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	weapon := env.Player.ItemList["Weapon 1"]
+	testza.AssertNotNil(t, weapon)
+	testza.AssertEqual(t, "Terminus Est", weapon.Title)
+
+	source := mod.Source("Item:18:Terminus Est")
+	mainHandCfg := &moddb.ListCfg{Source: &source, SkillCond: map[string]bool{"MainHandAttack": true}}
+	testza.AssertEqual(t, float64(360), env.ItemModDB.Sum(mod.TypeBase, mainHandCfg, "Accuracy"))
+	testza.AssertEqual(t, float64(0), env.ItemModDB.Sum(mod.TypeBase, &moddb.ListCfg{Source: &source}, "Accuracy"))
+	testza.AssertEqual(t, float64(6), env.ModDB.Multipliers["UniqueItem"])
+	testza.AssertEqual(t, float64(3), env.ModDB.Multipliers["RareItem"])
+	testza.AssertTrue(t, env.ModDB.Sum(mod.TypeBase, mainHandCfg, "Accuracy") > 0)
+
+	// Local weapon modifiers are consumed by the weapon data
+	testza.AssertEqual(t, float64(0), env.ItemModDB.Sum(mod.TypeIncrease, &moddb.ListCfg{Source: &source}, "Speed"))
+	testza.AssertEqual(t, float64(0), env.ItemModDB.Sum(mod.TypeIncrease, &moddb.ListCfg{Source: &source}, "PhysicalDamage"))
+
+	weaponData := env.Player.WeaponData1
+	testza.AssertNotNil(t, weaponData)
+	testza.AssertEqual(t, data.TwoHandSword, weaponData.Type)
+	testza.AssertEqual(t, float64(20), weaponData.AttackSpeedInc)
+	testza.AssertEqual(t, 1.68, weaponData.AttackRate)
+	testza.AssertEqual(t, 8.75, weaponData.CritChance)
+	testza.AssertEqual(t, float64(13), weaponData.Range)
+	testza.AssertEqual(t, DamageRange{Min: 184, Max: 303}, weaponData.Damage[data.DamageTypePhysical])
+	testza.AssertNil(t, env.Player.WeaponData2)
+
+	flags, info := getWeaponFlags(env, weaponData, nil)
+	testza.AssertEqual(t, data.WeaponTypes[data.TwoHandSword], info)
+	testza.AssertEqual(t, mod.MFlagSword|mod.MFlagWeapon|mod.MFlagWeapon2H|mod.MFlagWeaponMelee, flags)
+
+	flags, info = getWeaponFlags(env, weaponData, [][]data.ItemClassName{{data.Bow}})
+	testza.AssertEqual(t, mod.MFlag(0), flags)
+	testza.AssertNotNil(t, info)
+}
+
+func TestSpecEnv(t *testing.T) {
+	err := poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil)
+	testza.AssertNoError(t, err)
+
+	file, err := os.ReadFile("../testdata/many-builds/7.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	env, _, _, _, err := InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+	// Cluster jewel nodes are not part of the tree
+	testza.AssertLen(t, env.AllocatedNodes, 112)
+	testza.AssertEqual(t, int64(33823), *env.AllocatedNodes["33823"].Skill)
+
+	testza.AssertNoError(t, build.SetActiveSpec(0))
+
+	env, _, _, _, err = InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertLen(t, env.AllocatedNodes, 111)
+	_, ok := env.AllocatedNodes["33823"]
+	testza.AssertFalse(t, ok)
+}
+
+func TestTreeVersionEnv(t *testing.T) {
+	err := poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil)
+	testza.AssertNoError(t, err)
+
+	file, err := os.ReadFile("../testdata/many-builds/7.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+	testza.AssertNoError(t, build.SetActiveSpec(0))
+
+	testCache := &EnvironmentCache{}
+	env, _, _, _, err := InitEnv(context.Background(), build, testCache, OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, data.LatestTreeVersion, env.Spec.TreeVersion)
+	testza.AssertContains(t, env.DebugErrors, "Tree version 3_17 is not available, using 3_18")
+
+	// Serve the 3.17 spec from the same tree data to check that each version is resolved and cached separately
+	data.TreeVersions[data.TreeVersion3_17] = &data.TreeVersionData{Display: "3.18", Num: 3.17}
+	defer delete(data.TreeVersions, data.TreeVersion3_17)
+
+	env, _, _, _, err = InitEnv(context.Background(), build, testCache, OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, data.TreeVersion3_17, env.Spec.TreeVersion)
+	testza.AssertNotContains(t, env.DebugErrors, "Tree version 3_17 is not available, using 3_18")
+	testza.AssertLen(t, env.AllocatedNodes, 111)
+
+	testza.AssertNoError(t, build.SetActiveSpec(1))
+	env, _, _, _, err = InitEnv(context.Background(), build, testCache, OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, data.TreeVersion3_18, env.Spec.TreeVersion)
+	testza.AssertLen(t, testCache.modsForNodes, 2)
+	testza.AssertLen(t, testCache.modsForNodes[data.TreeVersion3_17], 111)
+	testza.AssertLen(t, testCache.modsForNodes[data.TreeVersion3_18], 112)
+}
+
+func TestMasteryEnv(t *testing.T) {
+	err := poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil)
+	testza.AssertNoError(t, err)
+
+	file, err := os.ReadFile("../testdata/many-builds/7.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	testCache := &EnvironmentCache{}
+	env, _, _, _, err := InitEnv(context.Background(), build, testCache, OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, 5, env.Spec.AllocatedMasteryCount)
+	testza.AssertEqual(t, float64(5), env.ModDB.Sum(mod.TypeBase, nil, "Multiplier:AllocatedMastery"))
+	testza.AssertEqual(t, int64(35582), env.Spec.MasterySelections["35118"])
+	testza.AssertEqual(t, []string{"3% chance to deal Triple Damage"}, env.AllocatedNodes["35118"].Stats)
+	tripleDamage := env.ModDB.Sum(mod.TypeBase, nil, "TripleDamageChance")
+	testza.AssertTrue(t, tripleDamage >= 3)
+
+	// Effects can only be selected once and only on masteries that offer them
+	testza.AssertNotNil(t, build.SetMasteryEffect(env.Spec.Tree(), 35118, 27095))
+	testza.AssertNotNil(t, build.SetMasteryEffect(env.Spec.Tree(), 35118, 2987))
+	testza.AssertNotNil(t, build.SetMasteryEffect(env.Spec.Tree(), 24970, 35582))
+	testza.AssertNotNil(t, env.Spec.SelectMasteryEffect(35118, 27095))
+
+	var otherEffect int64
+	for _, effect := range env.Spec.Tree().Nodes["35118"].MasteryEffects {
+		if effect.Effect != 35582 && effect.Effect != 27095 {
+			otherEffect = effect.Effect
+			break
+		}
+	}
+	testza.AssertNoError(t, build.SetMasteryEffect(env.Spec.Tree(), 35118, otherEffect))
+
+	env, _, _, _, err = InitEnv(context.Background(), build, testCache, OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, otherEffect, env.Spec.MasterySelections["35118"])
+	testza.AssertEqual(t, tripleDamage-3, env.ModDB.Sum(mod.TypeBase, nil, "TripleDamageChance"))
+
+	// Masteries without a selected effect are not allocated
+	build.RemoveMasteryEffect(35118)
+	env, _, _, _, err = InitEnv(context.Background(), build, testCache, OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, 4, env.Spec.AllocatedMasteryCount)
+	_, ok := env.AllocatedNodes["35118"]
+	testza.AssertFalse(t, ok)
+
+	build.DeallocateNodes(33823)
+	testza.AssertLen(t, build.ActiveSpec().MasterySelections, 3)
+}
+
+func TestSupportManaMultiplierEnv(t *testing.T) {
+	err := poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil)
+	testza.AssertNoError(t, err)
+
+	file, err := os.ReadFile("../testdata/many-builds/9.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	env, _, _, _, err := InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	// Mana multipliers of supports multiply each other
+	multipliers := make(map[string]float64)
+	for _, activeSkill := range env.Player.ActiveSkillList {
+		multipliers[activeSkill.ActiveEffect.GrantedEffect.Raw.ID] = activeSkill.BaseSkillModList.More(activeSkill.SkillCfg, "SupportManaMultiplier")
+	}
+	testza.AssertEqual(t, 1.5*1.3*1.5*1.2*1.4, multipliers["MoltenStrike"])
+	testza.AssertEqual(t, float64(2), multipliers["HeraldOfAsh"])
+	testza.AssertEqual(t, float64(1), multipliers["Anger"])
+}
+
+func TestWeaponDataClone(t *testing.T) {
+	weaponData := NewUnarmedWeaponData(data.Marauder)
+	weaponData.CountsAsDualWielding = true
+
+	clone := weaponData.Clone()
+	clone.Damage[data.DamageTypeFire] = DamageRange{Min: 1, Max: 2}
+	testza.AssertEqual(t, weaponData.CountsAsDualWielding, clone.CountsAsDualWielding)
+	testza.AssertLen(t, weaponData.Damage, 1)
+	testza.AssertNil(t, (*WeaponData)(nil).Clone())
+}
+
+func TestTreeJewels(t *testing.T) {
+	err := poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil)
+	testza.AssertNoError(t, err)
+
+	file, err := os.ReadFile("../testdata/many-builds/3.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	jewelPhysicalDamage := func(env *Environment) float64 {
+		total := float64(0)
+		for _, tabulated := range env.ModDB.Tabulate(mod.TypeIncrease, nil, "PhysicalDamage") {
+			if tabulated.Mod.GetSource() == "Item:1:Dragon Wound" {
+				total += tabulated.Value.(float64)
+			}
+		}
+		return total
+	}
+
+	env, _, _, _, err := InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertNotNil(t, env.Player.ItemList["Jewel 54127"])
+	testza.AssertEqual(t, float64(16), jewelPhysicalDamage(env))
+
+	// Jewels in sockets that are not allocated do not apply
+	build.DeallocateNodes(54127)
+	env, _, _, _, err = InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertNil(t, env.Player.ItemList["Jewel 54127"])
+	testza.AssertEqual(t, float64(0), jewelPhysicalDamage(env))
+}
@@ -4783,35 +4783,35 @@ var specialModList = map[string]interface{}{
 	},
 	`([\w\s]+) reserves no mana`: func(num float64, captures []string) ([]mod.Mod, string) {
 		return []mod.Mod{
-			MOD("SkillData", "LIST", mod.SkillData{Key: "manaReservationFlat", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
-			MOD("SkillData", "LIST", mod.SkillData{Key: "lifeReservationFlat", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
-			MOD("SkillData", "LIST", mod.SkillData{Key: "manaReservationPercent", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
-			MOD("SkillData", "LIST", mod.SkillData{Key: "lifeReservationPercent", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
+			MOD("SkillData", "LIST", mod.SkillData{Key: "ManaReservationFlat", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
+			MOD("SkillData", "LIST", mod.SkillData{Key: "LifeReservationFlat", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
+			MOD("SkillData", "LIST", mod.SkillData{Key: "ManaReservationPercent", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
+			MOD("SkillData", "LIST", mod.SkillData{Key: "LifeReservationPercent", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
 		}, ""
 	},
 	`([\w\s]+) has no reservation`: func(num float64, captures []string) ([]mod.Mod, string) {
 		return []mod.Mod{
-			MOD("SkillData", "LIST", mod.SkillData{Key: "manaReservationFlat", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
-			MOD("SkillData", "LIST", mod.SkillData{Key: "lifeReservationFlat", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
-			MOD("SkillData", "LIST", mod.SkillData{Key: "manaReservationPercent", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
-			MOD("SkillData", "LIST", mod.SkillData{Key: "lifeReservationPercent", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
+			MOD("SkillData", "LIST", mod.SkillData{Key: "ManaReservationFlat", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
+			MOD("SkillData", "LIST", mod.SkillData{Key: "LifeReservationFlat", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
+			MOD("SkillData", "LIST", mod.SkillData{Key: "ManaReservationPercent", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
+			MOD("SkillData", "LIST", mod.SkillData{Key: "LifeReservationPercent", Value: 0}).Tag(mod.SkillIdByName(captures[0])),
 		}, ""
 	},
 	`([\w\s]+) has no reservation if cast as an aura`: func(num float64, captures []string) ([]mod.Mod, string) {
 		return []mod.Mod{
-			MOD("SkillData", "LIST", mod.SkillData{Key: "manaReservationFlat", Value: 0}).Tag(mod.SkillIdByName(captures[0])).Tag(mod.SkillType(string(data.SkillTypeAura))),
-			MOD("SkillData", "LIST", mod.SkillData{Key: "lifeReservationFlat", Value: 0}).Tag(mod.SkillIdByName(captures[0])).Tag(mod.SkillType(string(data.SkillTypeAura))),
-			MOD("SkillData", "LIST", mod.SkillData{Key: "manaReservationPercent", Value: 0}).Tag(mod.SkillIdByName(captures[0])).Tag(mod.SkillType(string(data.SkillTypeAura))),
-			MOD("SkillData", "LIST", mod.SkillData{Key: "lifeReservationPercent", Value: 0}).Tag(mod.SkillIdByName(captures[0])).Tag(mod.SkillType(string(data.SkillTypeAura))),
+			MOD("SkillData", "LIST", mod.SkillData{Key: "ManaReservationFlat", Value: 0}).Tag(mod.SkillIdByName(captures[0])).Tag(mod.SkillType(string(data.SkillTypeAura))),
+			MOD("SkillData", "LIST", mod.SkillData{Key: "LifeReservationFlat", Value: 0}).Tag(mod.SkillIdByName(captures[0])).Tag(mod.SkillType(string(data.SkillTypeAura))),
+			MOD("SkillData", "LIST", mod.SkillData{Key: "ManaReservationPercent", Value: 0}).Tag(mod.SkillIdByName(captures[0])).Tag(mod.SkillType(string(data.SkillTypeAura))),
+			MOD("SkillData", "LIST", mod.SkillData{Key: "LifeReservationPercent", Value: 0}).Tag(mod.SkillIdByName(captures[0])).Tag(mod.SkillType(string(data.SkillTypeAura))),
 		}, ""
 	},
 	"banner skills reserve no mana": []mod.Mod{
-		MOD("SkillData", "LIST", mod.SkillData{Key: "manaReservationPercent", Value: 0}).Tag(mod.SkillType(string(data.SkillTypeBanner))),
-		MOD("SkillData", "LIST", mod.SkillData{Key: "lifeReservationPercent", Value: 0}).Tag(mod.SkillType(string(data.SkillTypeBanner))),
+		MOD("SkillData", "LIST", mod.SkillData{Key: "ManaReservationPercent", Value: 0}).Tag(mod.SkillType(string(data.SkillTypeBanner))),
+		MOD("SkillData", "LIST", mod.SkillData{Key: "LifeReservationPercent", Value: 0}).Tag(mod.SkillType(string(data.SkillTypeBanner))),
 	},
 	"banner skills have no reservation": []mod.Mod{
-		MOD("SkillData", "LIST", mod.SkillData{Key: "manaReservationPercent", Value: 0}).Tag(mod.SkillType(string(data.SkillTypeBanner))),
-		MOD("SkillData", "LIST", mod.SkillData{Key: "lifeReservationPercent", Value: 0}).Tag(mod.SkillType(string(data.SkillTypeBanner))),
+		MOD("SkillData", "LIST", mod.SkillData{Key: "ManaReservationPercent", Value: 0}).Tag(mod.SkillType(string(data.SkillTypeBanner))),
+		MOD("SkillData", "LIST", mod.SkillData{Key: "LifeReservationPercent", Value: 0}).Tag(mod.SkillType(string(data.SkillTypeBanner))),
 	},
 	`placed banners also grant (\d+)% increased attack damage to you and allies`: func(num float64, captures []string) ([]mod.Mod, string) {
 		return []mod.Mod{
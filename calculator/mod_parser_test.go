@@ -2,7 +2,9 @@ package calculator
 
 import (
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/MarvinJWendt/testza"
@@ -145,10 +147,10 @@ func TestParser(t *testing.T) {
 		{
 			line: "Zealotry has no Reservation",
 			mods: []mod.Mod{
-				mod.NewList("SkillData", mod.SkillData{Key: "manaReservationFlat", Value: 0}).Tag(mod.SkillIdByName("Zealotry")),
-				mod.NewList("SkillData", mod.SkillData{Key: "lifeReservationFlat", Value: 0}).Tag(mod.SkillIdByName("Zealotry")),
-				mod.NewList("SkillData", mod.SkillData{Key: "manaReservationPercent", Value: 0}).Tag(mod.SkillIdByName("Zealotry")),
-				mod.NewList("SkillData", mod.SkillData{Key: "lifeReservationPercent", Value: 0}).Tag(mod.SkillIdByName("Zealotry")),
+				mod.NewList("SkillData", mod.SkillData{Key: "ManaReservationFlat", Value: 0}).Tag(mod.SkillIdByName("Zealotry")),
+				mod.NewList("SkillData", mod.SkillData{Key: "LifeReservationFlat", Value: 0}).Tag(mod.SkillIdByName("Zealotry")),
+				mod.NewList("SkillData", mod.SkillData{Key: "ManaReservationPercent", Value: 0}).Tag(mod.SkillIdByName("Zealotry")),
+				mod.NewList("SkillData", mod.SkillData{Key: "LifeReservationPercent", Value: 0}).Tag(mod.SkillIdByName("Zealotry")),
 			},
 		},
 		{
@@ -252,6 +254,29 @@ func TestManyMods(t *testing.T) {
 	}
 }
 
+func TestItemLineCache(t *testing.T) {
+	cache := &EnvironmentCache{}
+
+	// The cache is shared between calculations running at the same time
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mods, _ := cache.itemLineMods("+10 to maximum Life")
+			testza.AssertLen(t, mods, 1)
+		}()
+	}
+	wg.Wait()
+
+	// A full cache is cleared before adding more lines
+	for i := len(cache.modsForItemLines); i < maxCachedItemLines; i++ {
+		cache.modsForItemLines[strconv.Itoa(i)] = parsedModLine{}
+	}
+	cache.itemLineMods("+10 to maximum Mana")
+	testza.AssertLen(t, cache.modsForItemLines, 1)
+}
+
 func BenchmarkModParser(b *testing.B) {
 	file, err := os.ReadFile("../testdata/many-mods.txt")
 	if err != nil {
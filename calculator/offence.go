@@ -1,8 +1,10 @@
 package calculator
 
 import (
+	"fmt"
 	"maps"
 	"math"
+	"slices"
 	"strings"
 
 	"github.com/Vilsol/go-pob/data"
@@ -11,7 +13,7 @@ import (
 	"github.com/Vilsol/go-pob/utils"
 )
 
-func calcDamage(activeSkill *ActiveSkill, output map[string]float64, cfg *moddb.ListCfg, breakdown interface{}, damageType data.DamageType, typeFlags int, convDst *data.DamageType) (float64, float64) {
+func calcDamage(activeSkill *ActiveSkill, output map[string]float64, cfg *moddb.ListCfg, breakdown *StatBreakdown, damageType data.DamageType, typeFlags int, convDst *data.DamageType) (float64, float64) {
 	typeFlags = typeFlags | data.DamageTypeFlags[damageType]
 
 	// Calculate conversions
@@ -43,17 +45,18 @@ func calcDamage(activeSkill *ActiveSkill, output map[string]float64, cfg *moddb.
 
 	if baseMin == 0 && baseMax == 0 {
 		// No base damage for this type, don't need to calculate modifiers
-		/*
-			TODO Breakdown
-			if breakdown and (addMin ~= 0 or addMax ~= 0) then
-				t_insert(breakdown.damageTypes, {
-					source = damageType,
-					convSrc = (addMin ~= 0 or addMax ~= 0) and (addMin .. " to " .. addMax),
-					total = addMin .. " to " .. addMax,
-					convDst = convDst and s_format("%d%% to %s", conversionTable[damageType][convDst] * 100, convDst),
-				})
-			end
-		*/
+		if breakdown != nil && (addMin != 0 || addMax != 0) {
+			breakdown.DamageTypes = append(breakdown.DamageTypes, &DamageTypeBreakdown{
+				Source:     damageType,
+				Inc:        1,
+				More:       1,
+				ConvSrcMin: addMin,
+				ConvSrcMax: addMax,
+				TotalMin:   addMin,
+				TotalMax:   addMax,
+			})
+			setConvDst(activeSkill, breakdown, damageType, convDst)
+		}
 		return addMin, addMax
 	}
 
@@ -64,25 +67,40 @@ func calcDamage(activeSkill *ActiveSkill, output map[string]float64, cfg *moddb.
 	moreMinDamage := activeSkill.SkillModList.More(cfg, "Min"+string(damageType)+"Damage")
 	moreMaxDamage := activeSkill.SkillModList.More(cfg, "Max"+string(damageType)+"Damage")
 
-	/*
-		TODO Breakdown
-		if breakdown then
-			t_insert(breakdown.damageTypes, {
-				source = damageType,
-				base = baseMin .. " to " .. baseMax,
-				inc = (inc ~= 1 and "x "..inc),
-				more = (more ~= 1 and "x "..more),
-				convSrc = (addMin ~= 0 or addMax ~= 0) and (addMin .. " to " .. addMax),
-				total = (round(baseMin * inc * more) + addMin) .. " to " .. (round(baseMax * inc * more) + addMax),
-				convDst = convDst and conversionTable[damageType][convDst] > 0 and s_format("%d%% to %s", conversionTable[damageType][convDst] * 100, convDst),
-			})
-		end
-	*/
+	if breakdown != nil {
+		mods := tabulateMods(activeSkill.SkillModList, cfg, mod.TypeIncrease, modNames...)
+		breakdown.DamageTypes = append(breakdown.DamageTypes, &DamageTypeBreakdown{
+			Source:     damageType,
+			BaseMin:    baseMin,
+			BaseMax:    baseMax,
+			Inc:        inc,
+			More:       more,
+			ConvSrcMin: addMin,
+			ConvSrcMax: addMax,
+			TotalMin:   math.Round(baseMin*inc*more) + addMin,
+			TotalMax:   math.Round(baseMax*inc*more) + addMax,
+			Mods:       append(mods, tabulateMods(activeSkill.SkillModList, cfg, mod.TypeMore, modNames...)...),
+		})
+		if convDst != nil && activeSkill.ConversionTable[damageType].Targets[*convDst] > 0 {
+			setConvDst(activeSkill, breakdown, damageType, convDst)
+		}
+	}
 
 	return math.Round(((baseMin * inc * more) + addMin) * moreMinDamage),
 		math.Round(((baseMax * inc * more) + addMax) * moreMaxDamage)
 }
 
+// setConvDst records how much of the last damage type in the breakdown is converted to convDst
+func setConvDst(activeSkill *ActiveSkill, breakdown *StatBreakdown, damageType data.DamageType, convDst *data.DamageType) {
+	if convDst == nil {
+		return
+	}
+
+	last := breakdown.DamageTypes[len(breakdown.DamageTypes)-1]
+	last.ConvDst = *convDst
+	last.ConvDstPercent = activeSkill.ConversionTable[damageType].Targets[*convDst] * 100
+}
+
 /*
 local function calcAilmentSourceDamage(activeSkill, output, cfg, breakdown, damageType, typeFlags)
 	local min, max = calcDamage(activeSkill, output, cfg, breakdown, damageType, typeFlags)
@@ -97,21 +115,37 @@ local function calcAilmentSourceDamage(activeSkill, output, cfg, breakdown, dama
 end
 */
 
-func calcAilmentSourceDamage(activeSkill *ActiveSkill, output map[string]float64, cfg *moddb.ListCfg, breakdown map[string]interface{}, damageType data.DamageType, typeFlags int) (float64, float64) {
+func calcAilmentSourceDamage(activeSkill *ActiveSkill, output map[string]float64, cfg *moddb.ListCfg, breakdown *StatBreakdown, damageType data.DamageType, typeFlags int) (float64, float64) {
 	minDamage, maxDamage := calcDamage(activeSkill, output, cfg, breakdown, damageType, typeFlags, nil)
 	convMult := activeSkill.ConversionTable[damageType].Mult
-	/*
-		TODO Breakdown
-		if breakdown and convMult ~= 1 then
-			t_insert(breakdown, "Source damage:")
-			t_insert(breakdown, s_format("%d to %d ^8(total damage)", min, max))
-			t_insert(breakdown, s_format("x %g ^8(%g%% converted to other damage types)", convMult, (1-convMult)*100))
-			t_insert(breakdown, s_format("= %d to %d", min * convMult, max * convMult))
-		end
-	*/
+	if breakdown != nil && convMult != 1 {
+		breakdown.line("Source damage:")
+		breakdown.line("%.0f to %.0f (total damage)", minDamage, maxDamage)
+		breakdown.line("x %g (%g%% converted to other damage types)", convMult, (1-convMult)*100)
+		breakdown.line("= %.0f to %.0f", minDamage*convMult, maxDamage*convMult)
+	}
 	return minDamage * convMult, maxDamage * convMult
 }
 
+// ailmentDotCfg builds the config used to calculate the damage over time of an ailment inflicted by the given pass
+func ailmentDotCfg(skillCfg *moddb.ListCfg, cfg *moddb.ListCfg, keywordFlags mod.KeywordFlag) *moddb.ListCfg {
+	// TODO This is almost definitely a bad idea but I cba to implement a new interface with a struct that references underlying map and returns values so this is what you get
+	skillCond := maps.Clone(skillCfg.SkillCond)
+	if skillCond == nil {
+		skillCond = make(map[string]bool)
+	}
+	skillCond["CriticalStrike"] = true
+
+	return &moddb.ListCfg{
+		// TODO SkillName, SkillPart, SkillTypes, SkillDist
+		SlotName:     skillCfg.SlotName,
+		Flags:        utils.Ptr(mod.MFlagDot | mod.MFlagAilment | (cfg.Flags.Get() & mod.MFlagWeaponMask) | utils.Ternary((cfg.Flags.Get()&mod.MFlagMelee) != 0, mod.MFlagMeleeHit, 0)),
+		KeywordFlags: utils.Ptr((cfg.KeywordFlags.Get() & ^mod.KeywordFlagHit) | mod.KeywordFlagAilment | keywordFlags),
+		SkillCond:    skillCond,
+		SkillID:      skillCfg.SkillID,
+	}
+}
+
 func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill) {
 	modDB := actor.ModDB
 	enemyDB := actor.Enemy.ModDB
@@ -215,18 +249,18 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 
 		runSkillFunc("initialFunc")
 	*/
-	/*
-		TODO isTriggered
-		local isTriggered = skillData.triggeredWhileChannelling or skillData.triggeredByCoC or skillData.triggeredByMeleeKill or skillData.triggeredByCospris or skillData.triggeredByMjolner or skillData.triggeredByUnique or skillData.triggeredByFocus or skillData.triggeredByCraft or skillData.triggeredByManaSpent or skillData.triggeredByParentAttack
-		skillCfg.skillCond["SkillIsTriggered"] = skillData.triggered or isTriggered
-		if skillCfg.skillCond["SkillIsTriggered"] then
-			skillFlags.triggered = true
-		end
-		skillCfg.skillCond["SkillIsFocused"] = skillData.triggeredByFocus
-		if skillCfg.skillCond["SkillIsFocused"] then
-			skillFlags.focused = true
-		end
-	*/
+	isTriggered := skillDataFlag(activeSkill, "triggered") || utils.HasTrue(skillData, "Triggered")
+	for _, key := range []string{"triggeredWhileChannelling", "triggeredByCoC", "triggeredByMeleeKill", "triggeredByCospris", "triggeredByMjolner", "triggeredByUnique", "triggeredByFocus", "triggeredByCraft", "triggeredByManaSpent", "triggeredByParentAttack", "triggeredByDamageTaken"} {
+		isTriggered = isTriggered || skillDataFlag(activeSkill, key)
+	}
+	skillCfg.SkillCond["SkillIsTriggered"] = isTriggered
+	if isTriggered {
+		skillFlags[SkillFlagTriggered] = true
+	}
+	skillCfg.SkillCond["SkillIsFocused"] = skillDataFlag(activeSkill, "triggeredByFocus")
+	if skillCfg.SkillCond["SkillIsFocused"] {
+		skillFlags[SkillFlagFocused] = true
+	}
 	/*
 		TODO -- Update skill data
 		for _, value in ipairs(skillModList:List(skillCfg, "SkillData")) do
@@ -1227,13 +1261,14 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 		outputTable[OutTableOffHand] = make(map[string]float64)
 		critOverride := skillModList.Override(skillCfg, "WeaponBaseCritChance")
 		if skillFlags[SkillFlagWeapon1Attack] {
+			var passBreakdown *Breakdown
 			if breakdown != nil {
-				// TODO Breakdown
-				// breakdown.MainHand = LoadModule(calcs.breakdownModule, skillModList, output.MainHand)
+				breakdown.MainHand = newBreakdown(skillModList)
+				passBreakdown = breakdown.MainHand
 			}
 			activeSkill.Weapon1Cfg.SkillStats = outputTable[OutTableMainHand]
-			source := actor.WeaponData1 // TODO Copy
-			if critOverride != nil && source != nil && source["Type"] != "None" {
+			source := actor.WeaponData1.Source()
+			if critOverride != nil && source["type"] != nil && source["type"] != string(data.None) {
 				source["CritChance"] = critOverride.(float64)
 			}
 			passList = append(passList, &DamagePass{
@@ -1241,18 +1276,19 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 				Source:    source,
 				Config:    activeSkill.Weapon1Cfg,
 				Output:    outputTable[OutTableMainHand],
-				Breakdown: breakdown,
+				Breakdown: passBreakdown,
 			})
 		}
 
 		if skillFlags[SkillFlagWeapon2Attack] {
+			var passBreakdown *Breakdown
 			if breakdown != nil {
-				// TODO Breakdown
-				// breakdown.OffHand = LoadModule(calcs.breakdownModule, skillModList, output.OffHand)
+				breakdown.OffHand = newBreakdown(skillModList)
+				passBreakdown = breakdown.OffHand
 			}
 			activeSkill.Weapon2Cfg.SkillStats = outputTable[OutTableOffHand]
-			source := utils.CopyMap(actor.WeaponData2) // TODO Copy
-			if critOverride != nil && source != nil && source["Type"] != "None" {
+			source := actor.WeaponData2.Source()
+			if critOverride != nil && source["type"] != nil && source["type"] != string(data.None) {
 				source["CritChance"] = critOverride.(float64)
 			}
 			if utils.Has(skillData, "CritChance") {
@@ -1263,14 +1299,14 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 				source["PhysicalMax"] = skillData["SetOffHandPhysicalMax"]
 			}
 			if utils.Has(skillData, "AttackTime") {
-				source["AttackRate"] = utils.Ptr[float64](1000 / skillData["AttackTime"].(float64))
+				source["AttackRate"] = 1000 / skillData["AttackTime"].(float64)
 			}
 			passList = append(passList, &DamagePass{
 				Label:     "Off Hand",
 				Source:    source,
 				Config:    activeSkill.Weapon2Cfg,
 				Output:    outputTable[OutTableOffHand],
-				Breakdown: breakdown,
+				Breakdown: passBreakdown,
 			})
 		}
 	} else {
@@ -1314,25 +1350,21 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 				mainPortion := mainChance / (mainChance + offChance)
 				offPortion := offChance / (mainChance + offChance)
 				output[stat] = outputTable[OutTableMainHand][stat]*mainPortion + outputTable[OutTableOffHand][stat]*offPortion
-				/*
-					TODO Breakdown
-					if breakdown then
-						if not breakdown[stat] then
-							breakdown[stat] = { }
-						end
-						t_insert(breakdown[stat], "Contribution from Main Hand:")
-						t_insert(breakdown[stat], s_format("%.1f", output.MainHand[stat]))
-						t_insert(breakdown[stat], s_format("x %.3f ^8(portion of instances created by main hand)", mainPortion))
-						t_insert(breakdown[stat], s_format("= %.1f", output.MainHand[stat] * mainPortion))
-						t_insert(breakdown[stat], "Contribution from Off Hand:")
-						t_insert(breakdown[stat], s_format("%.1f", output.OffHand[stat]))
-						t_insert(breakdown[stat], s_format("x %.3f ^8(portion of instances created by off hand)", offPortion))
-						t_insert(breakdown[stat], s_format("= %.1f", output.OffHand[stat] * offPortion))
-						t_insert(breakdown[stat], "Total:")
-						t_insert(breakdown[stat], s_format("%.1f + %.1f", output.MainHand[stat] * mainPortion, output.OffHand[stat] * offPortion))
-						t_insert(breakdown[stat], s_format("= %.1f", output[stat]))
-					end
-				*/
+				if breakdown != nil {
+					s := breakdown.Stat(stat)
+					s.Total = output[stat]
+					s.line("Contribution from Main Hand:")
+					s.line("%.1f", outputTable[OutTableMainHand][stat])
+					s.line("x %.3f (portion of instances created by main hand)", mainPortion)
+					s.line("= %.1f", outputTable[OutTableMainHand][stat]*mainPortion)
+					s.line("Contribution from Off Hand:")
+					s.line("%.1f", outputTable[OutTableOffHand][stat])
+					s.line("x %.3f (portion of instances created by off hand)", offPortion)
+					s.line("= %.1f", outputTable[OutTableOffHand][stat]*offPortion)
+					s.line("Total:")
+					s.line("%.1f + %.1f", outputTable[OutTableMainHand][stat]*mainPortion, outputTable[OutTableOffHand][stat]*offPortion)
+					s.line("= %.1f", output[stat])
+				}
 			} else {
 				if utils.Has(outputTable[OutTableMainHand], stat) {
 					output[stat] = outputTable[OutTableMainHand][stat]
@@ -1342,55 +1374,41 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 			}
 		} else if mode == ModeChanceAilment {
 			if utils.Has(outputTable[OutTableMainHand], stat) && utils.Has(outputTable[OutTableOffHand], stat) {
-				//mainChance := outputTable[OutTableMainHand][idk[0]] * outputTable[OutTableMainHand]["HitChance"]
-				//offChance := outputTable[OutTableOffHand][idk[0]] * outputTable[OutTableOffHand]["HitChance"]
-				//mainPortion := mainChance / (mainChance + offChance)
-				//offPortion := offChance / (mainChance + offChance)
 				maxInstance := max(outputTable[OutTableMainHand][stat], outputTable[OutTableOffHand][stat])
 				minInstance := min(outputTable[OutTableMainHand][stat], outputTable[OutTableOffHand][stat])
 
-				// TODO Somehow pass globalOutput ???
-				//stackName := strings.ReplaceAll(stat, "DPS", "")+ "Stacks"
-				//maxInstanceStacks := min(1, (globalOutput[stackName] or 1) / (globalOutput[stackName.."Max"] or 1))
-				maxInstanceStacks := float64(0)
+				// Stacks are calculated into the global output by the passes
+				stackName := strings.ReplaceAll(stat, "DPS", "") + "Stacks"
+				maxInstanceStacks := min(1, utils.GetOr(output, stackName, 1)/utils.GetOr(output, stackName+"Max", 1))
 
 				output[stat] = maxInstance*maxInstanceStacks + minInstance*(1-maxInstanceStacks)
-				/*
-					TODO Chance Ailment
-				*/
-				/*
-					TODO Breakdown
-					if breakdown then
-						if not breakdown[stat] then breakdown[stat] = { } end
-						t_insert(breakdown[stat], s_format(""))
-						t_insert(breakdown[stat], s_format("%.2f%% of ailment stacks use maximum damage", maxInstanceStacks * 100))
-						t_insert(breakdown[stat], s_format("Max Damage comes from %s", output.MainHand[stat] >= output.OffHand[stat] and "Main Hand" or "Off Hand"))
-						t_insert(breakdown[stat], s_format("= %.1f", maxInstance * maxInstanceStacks))
-						if maxInstanceStacks < 1 then
-							t_insert(breakdown[stat], s_format("%.2f%% of ailment stacks use non-maximum damage", (1-maxInstanceStacks) * 100))
-							t_insert(breakdown[stat], s_format("= %.1f", minInstance * (1 - maxInstanceStacks)))
-						end
-						t_insert(breakdown[stat], "")
-						t_insert(breakdown[stat], "Total:")
-						if maxInstanceStacks < 1 then
-							t_insert(breakdown[stat], s_format("%.1f + %.1f", maxInstance * maxInstanceStacks, minInstance * (1 - maxInstanceStacks)))
-						end
-						t_insert(breakdown[stat], s_format("= %.1f", output[stat]))
-					end
-				*/
+				if breakdown != nil {
+					s := breakdown.Stat(stat)
+					s.Total = output[stat]
+					s.line("")
+					s.line("%.2f%% of ailment stacks use maximum damage", maxInstanceStacks*100)
+					s.line("Max Damage comes from %s", utils.Ternary(outputTable[OutTableMainHand][stat] >= outputTable[OutTableOffHand][stat], "Main Hand", "Off Hand"))
+					s.line("= %.1f", maxInstance*maxInstanceStacks)
+					if maxInstanceStacks < 1 {
+						s.line("%.2f%% of ailment stacks use non-maximum damage", (1-maxInstanceStacks)*100)
+						s.line("= %.1f", minInstance*(1-maxInstanceStacks))
+					}
+					s.line("")
+					s.line("Total:")
+					if maxInstanceStacks < 1 {
+						s.line("%.1f + %.1f", maxInstance*maxInstanceStacks, minInstance*(1-maxInstanceStacks))
+					}
+					s.line("= %.1f", output[stat])
+				}
 			} else {
 				if utils.Has(outputTable[OutTableMainHand], stat) {
 					output[stat] = outputTable[OutTableMainHand][stat]
 				} else {
 					output[stat] = outputTable[OutTableOffHand][stat]
 				}
-				/*
-					TODO Breakdown
-					if breakdown then
-						if not breakdown[stat] then breakdown[stat] = { } end
-						t_insert(breakdown[stat], s_format("All ailment stacks comes from %s", output.MainHand[stat] and "Main Hand" or "Off Hand"))
-					end
-				*/
+				if breakdown != nil {
+					breakdown.Stat(stat).line("All ailment stacks comes from %s", utils.Ternary(utils.Has(outputTable[OutTableMainHand], stat), "Main Hand", "Off Hand"))
+				}
 			}
 		} else if mode == ModeDPS {
 			output[stat] = outputTable[OutTableMainHand][stat] + outputTable[OutTableOffHand][stat]
@@ -1405,25 +1423,17 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 	for _, pass := range passList {
 		// Calculate hit chance
 		pass.Output["Accuracy"] = math.Max(0, CalcVal(skillModList, "Accuracy", pass.Config))
-		/*
-			TODO Breakdown
-			if breakdown then
-				breakdown.Accuracy = breakdown.simple(nil, cfg, output.Accuracy, "Accuracy")
-			end
-		*/
+		if pass.Breakdown != nil {
+			pass.Breakdown.simple(0, pass.Config, pass.Output["Accuracy"], "Accuracy")
+		}
 
 		if skillModList.Flag(nil, "Condition:OffHandAccuracyIsMainHandAccuracy") && pass.Label == "Main Hand" {
 			storedMainHandAccuracy = utils.Ptr(pass.Output["Accuracy"])
 		} else if skillModList.Flag(nil, "Condition:OffHandAccuracyIsMainHandAccuracy") && pass.Label == "Off Hand" && storedMainHandAccuracy != nil {
 			pass.Output["Accuracy"] = *storedMainHandAccuracy
-			/*
-				TODO Breakdown
-				if breakdown then
-					breakdown.Accuracy = {
-						"Using Main Hand Accuracy due to Mastery: "..output.Accuracy,
-					}
-				end
-			*/
+			if pass.Breakdown != nil {
+				pass.Breakdown.Set("Accuracy", pass.Output["Accuracy"], fmt.Sprintf("Using Main Hand Accuracy due to Mastery: %g", pass.Output["Accuracy"]))
+			}
 		}
 
 		if utils.MissingOrFalse(skillFlags, SkillFlagAttack) ||
@@ -1434,16 +1444,14 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 		} else {
 			enemyEvasion := math.Max(math.Round(CalcVal(enemyDB, "Evasion", nil)), 0)
 			pass.Output["HitChance"] = CalcHitChance(enemyEvasion, pass.Output["Accuracy"]) * CalcMod(skillModList, pass.Config, "HitChance")
-			/*
-				TODO Breakdown
-				if breakdown then
-					breakdown.HitChance = {
-						"Enemy level: "..env.enemyLevel..(env.configInput.enemyLevel and " ^8(overridden from the Configuration tab" or " ^8(can be overridden in the Configuration tab)"),
-						"Average enemy evasion: "..enemyEvasion,
-						"Approximate hit chance: "..output.HitChance.."%",
-					}
-				end
-			*/
+			if pass.Breakdown != nil {
+				// TODO Mention whether the enemy level was overridden from the Configuration tab
+				pass.Breakdown.Set("HitChance", pass.Output["HitChance"],
+					fmt.Sprintf("Enemy level: %d", env.EnemyLevel),
+					fmt.Sprintf("Average enemy evasion: %g", enemyEvasion),
+					fmt.Sprintf("Approximate hit chance: %g%%", pass.Output["HitChance"]),
+				)
+			}
 		}
 		/*
 			TODO -- Check Precise Technique Keystone condition per pass as MH/OH might have different values
@@ -1470,40 +1478,12 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 			pass.Output["TriggerTime"] = pass.Output["Time"]
 			pass.Output["Speed"] = 1 / pass.Output["Time"]
 		} else if utils.Has(skillData, "TriggerRate") && utils.HasTrue(skillData, "Triggered") {
-			/*
-				TODO -- Account for trigger unleash
-				if skillData.triggerUnleash then
-					-- process the source trigger skill to get it's full data
-					local calcMode = env.mode == "CALCS" and "CALCS" or "MAIN"
-					for _, triggerSkill in ipairs(actor.activeSkillList) do
-						if cacheSkillUUID(triggerSkill) == skillData.triggerSourceUUID then
-							calcs.buildActiveSkill(env, calcMode, triggerSkill)
-							break
-						end
-					end
-					local cachedSourceSkill = GlobalCache.cachedData[calcMode][skillData.triggerSourceUUID]
-					-- if properly processed, get it's dpsMultiplier to increase triggerRate
-					if cachedSourceSkill then
-						skillData.unleashTriggerRate = skillData.triggerRate * (cachedSourceSkill.ActiveSkill.skillData.dpsMultiplier or 1)
-						if breakdown then
-							breakdown.Speed = {
-								s_format("%.2f ^8(trigger rate)", skillData.triggerRate),
-								s_format("* %.2f ^8(multiplier from Unleash)", cachedSourceSkill.ActiveSkill.skillData.dpsMultiplier or 1),
-								s_format("= %.2f", skillData.unleashTriggerRate),
-							}
-						end
-						-- over-write the triggerRate modifier after breakdown as other calcs use it
-						skillData.triggerRate = skillData.unleashTriggerRate
-					end
-					-- give this activeSkill "HasSeals" flag so Configuration Option for UseMaxUnleash is available
-					activeSkill.skillFlags.HasSeals = true
-				end
-			*/
+			// Unleash is accounted for in the trigger rate when the trigger is processed
 			pass.Output["Time"] = 1 / skillData["TriggerRate"].(float64)
 			pass.Output["TriggerTime"] = pass.Output["Time"]
 			pass.Output["Speed"] = skillData["TriggerRate"].(float64)
 			skillData["ShowAverage"] = false
-		} else if utils.HasTrue(skillData, "TriggeredByBrand") && utils.HasTrue(skillData, "Triggered") {
+		} else if skillDataFlag(activeSkill, "triggeredByBrand") && utils.HasTrue(skillData, "Triggered") {
 			ArcanistSpellsLinked := skillModList.Sum(mod.TypeBase, pass.Config, "ArcanistSpellsLinked")
 			if ArcanistSpellsLinked == 0 {
 				ArcanistSpellsLinked = 1
@@ -1564,37 +1544,40 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 				pass.Output["Time"] = 1 / pass.Output["Speed"]
 			}
 
-			/*
-				TODO Breakdown
-				if breakdown then
-					breakdown.Speed = { }
-					breakdown.multiChain(breakdown.Speed, {
-						base = s_format("%.2f ^8(base)", 1 / baseTime),
-						{ "%.2f ^8(increased/reduced)", 1 + inc/100 },
-						{ "%.2f ^8(more/less)", more },
-						{ "%.2f ^8(action speed modifier)", skillFlags.selfCast and globalOutput.ActionSpeedMod or 1 },
-						total = s_format("= %.2f ^8casts per second", output.CastRate)
-					})
-					if output.Cooldown and (1 / output.Cooldown) < output.CastRate then
-						t_insert(breakdown.Speed, s_format("\n"))
-						t_insert(breakdown.Speed, s_format("1 / %.2f ^8(skill cooldown)", output.Cooldown))
-						if output.Repeats > 1 then
-							t_insert(breakdown.Speed, s_format("x %d ^8(repeat count)", output.Repeats))
-						end
-						t_insert(breakdown.Speed, s_format("= %.2f ^8(casts per second)", output.Repeats / output.Cooldown))
-						t_insert(breakdown.Speed, s_format("\n"))
-						t_insert(breakdown.Speed, s_format("= %.2f ^8(lower of cast rates)", output.Speed))
-					end
-				end
-				if breakdown and calcLib.mod(skillModList, skillCfg, "SkillAttackTime") > 0 then
-					breakdown.Time = { }
-					breakdown.multiChain(breakdown.Time, {
-						base = s_format("%.2f ^8(base)", 1 / (output.Speed * calcLib.mod(skillModList, skillCfg, "SkillAttackTime") )),
-						{ "%.2f ^8(total modifier)", calcLib.mod(skillModList, skillCfg, "SkillAttackTime")  },
-						total = s_format("= %.2f ^8seconds per attack", output.Time)
-					})
-				end
-			*/
+			if pass.Breakdown != nil {
+				s := pass.Breakdown.Set("Speed", pass.Output["Speed"])
+				s.Base = 1 / baseTime
+				s.Inc = 1 + inc/100
+				s.More = more
+				s.Mods = append(tabulateMods(skillModList, pass.Config, mod.TypeIncrease, "Speed"), tabulateMods(skillModList, pass.Config, mod.TypeMore, "Speed")...)
+				s.line("%.2f (base)", 1/baseTime)
+				if inc != 0 {
+					s.line("x %.2f (increased/reduced)", 1+inc/100)
+				}
+				if more != 1 {
+					s.line("x %.2f (more/less)", more)
+				}
+				if skillFlags[SkillFlagSelfCast] && output["ActionSpeedMod"] != 1 {
+					s.line("x %.2f (action speed modifier)", output["ActionSpeedMod"])
+				}
+				s.line("= %.2f casts per second", pass.Output["CastRate"])
+				if cooldown, ok := pass.Output["Cooldown"]; ok && 1/cooldown < pass.Output["CastRate"] {
+					s.line("1 / %.2f (skill cooldown)", cooldown)
+					if pass.Output["Repeats"] > 1 {
+						s.line("x %g (repeat count)", pass.Output["Repeats"])
+					}
+					s.line("= %.2f (casts per second)", pass.Output["Repeats"]/cooldown)
+					s.line("= %.2f (lower of cast rates)", pass.Output["Speed"])
+				}
+			}
+			if pass.Breakdown != nil && CalcMod(skillModList, skillCfg, "SkillAttackTime") > 0 {
+				attackTimeMod := CalcMod(skillModList, skillCfg, "SkillAttackTime")
+				pass.Breakdown.Set("Time", pass.Output["Time"],
+					fmt.Sprintf("%.2f (base)", 1/(pass.Output["Speed"]*attackTimeMod)),
+					fmt.Sprintf("x %.2f (total modifier)", attackTimeMod),
+					fmt.Sprintf("= %.2f seconds per attack", pass.Output["Time"]),
+				)
+			}
 		}
 		/*
 			TODO Time override
@@ -1628,17 +1611,12 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 			modDB.AddMod(mod.NewFlag("Condition:OneSecondAttackTime", true))
 		}
 
-		if utils.HasTrue(skillFlags, SkillFlagBothWeaponAttack) {
-			/*
-				TODO Breakdown
-				if breakdown then
-					breakdown.Speed = {
-						"Both weapons:",
-						s_format("(%.2f + %.2f) / 2", output.MainHand.Speed, output.OffHand.Speed),
-						s_format("= %.2f", output.Speed),
-					}
-				end
-			*/
+		if utils.HasTrue(skillFlags, SkillFlagBothWeaponAttack) && breakdown != nil {
+			breakdown.Set("Speed", output["Speed"],
+				"Both weapons:",
+				fmt.Sprintf("(%.2f + %.2f) / 2", outputTable[OutTableMainHand]["Speed"], outputTable[OutTableOffHand]["Speed"]),
+				fmt.Sprintf("= %.2f", output["Speed"]),
+			)
 		}
 	}
 
@@ -2030,8 +2008,7 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 
 				pass.Output["CritChance"] = (baseCrit + base) * (1 + inc/100) * more
 
-				// For Breakdown
-				// preCapCritChance := pass.Output["CritChance"]
+				preCapCritChance := pass.Output["CritChance"]
 				pass.Output["CritChance"] = math.Min(pass.Output["CritChance"], 100)
 
 				if baseCrit+base > 0 {
@@ -2039,52 +2016,54 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 				}
 
 				pass.Output["PreEffectiveCritChance"] = pass.Output["CritChance"]
-				// For Breakdown
-				// preLuckyCritChance := pass.Output["CritChance"]
+				preLuckyCritChance := pass.Output["CritChance"]
 
 				if env.ModeEffective && skillModList.Flag(pass.Config, "CritChanceLucky") {
 					pass.Output["CritChance"] = (1 - math.Pow(1-pass.Output["CritChance"]/100, 2)) * 100
 				}
 
-				// For Breakdown
-				// preHitCheckCritChance := pass.Output["CritChance"]
+				preHitCheckCritChance := pass.Output["CritChance"]
 				if env.ModeEffective {
 					pass.Output["CritChance"] = pass.Output["CritChance"] * pass.Output["HitChance"] / 100
 				}
 
-				/*
-					TODO Breakdown
-					if breakdown and output.CritChance ~= baseCrit then
-						breakdown.CritChance = { }
-						if base ~= 0 then
-							t_insert(breakdown.CritChance, s_format("(%g + %g) ^8(base)", baseCrit, base))
-						else
-							t_insert(breakdown.CritChance, s_format("%g ^8(base)", baseCrit + base))
-						end
-						if inc ~= 0 then
-							t_insert(breakdown.CritChance, s_format("x %.2f", 1 + inc/100).." ^8(increased/reduced)")
-						end
-						if more ~= 1 then
-							t_insert(breakdown.CritChance, s_format("x %.2f", more).." ^8(more/less)")
-						end
-						t_insert(breakdown.CritChance, s_format("= %.2f%% ^8(crit chance)", output.PreEffectiveCritChance))
-						if preCapCritChance > 100 then
-							local overCap = preCapCritChance - 100
-							t_insert(breakdown.CritChance, s_format("Crit is overcapped by %.2f%% (%d%% increased Critical Strike Chance)", overCap, overCap / more / (baseCrit + base) * 100))
-						end
-						if env.mode_effective and skillModList:Flag(cfg, "CritChanceLucky") then
-							t_insert(breakdown.CritChance, "Crit Chance is Lucky:")
-							t_insert(breakdown.CritChance, s_format("1 - (1 - %.4f) x (1 - %.4f)", preLuckyCritChance / 100, preLuckyCritChance / 100))
-							t_insert(breakdown.CritChance, s_format("= %.2f%%", preHitCheckCritChance))
-						end
-						if env.mode_effective and output.HitChance < 100 then
-							t_insert(breakdown.CritChance, "Crit confirmation roll:")
-							t_insert(breakdown.CritChance, s_format("%.2f%%", preHitCheckCritChance))
-							t_insert(breakdown.CritChance, s_format("x %.2f ^8(chance to hit)", output.HitChance / 100))
-							t_insert(breakdown.CritChance, s_format("= %.2f%%", output.CritChance))
-						end
-					end
-				*/
+				if pass.Breakdown != nil && pass.Output["CritChance"] != baseCrit {
+					s := pass.Breakdown.Set("CritChance", pass.Output["CritChance"])
+					s.Base = baseCrit + base
+					s.Inc = 1 + inc/100
+					s.More = more
+					if critOverride == nil {
+						s.Mods = append(tabulateMods(skillModList, pass.Config, mod.TypeBase, "CritChance"), tabulateMods(skillModList, pass.Config, mod.TypeIncrease, "CritChance")...)
+						s.Mods = append(s.Mods, tabulateMods(skillModList, pass.Config, mod.TypeMore, "CritChance")...)
+					}
+					if base != 0 {
+						s.line("(%g + %g) (base)", baseCrit, base)
+					} else {
+						s.line("%g (base)", baseCrit+base)
+					}
+					if inc != 0 {
+						s.line("x %.2f (increased/reduced)", 1+inc/100)
+					}
+					if more != 1 {
+						s.line("x %.2f (more/less)", more)
+					}
+					s.line("= %.2f%% (crit chance)", pass.Output["PreEffectiveCritChance"])
+					if preCapCritChance > 100 {
+						overCap := preCapCritChance - 100
+						s.line("Crit is overcapped by %.2f%% (%.0f%% increased Critical Strike Chance)", overCap, overCap/more/(baseCrit+base)*100)
+					}
+					if env.ModeEffective && skillModList.Flag(pass.Config, "CritChanceLucky") {
+						s.line("Crit Chance is Lucky:")
+						s.line("1 - (1 - %.4f) x (1 - %.4f)", preLuckyCritChance/100, preLuckyCritChance/100)
+						s.line("= %.2f%%", preHitCheckCritChance)
+					}
+					if env.ModeEffective && pass.Output["HitChance"] < 100 {
+						s.line("Crit confirmation roll:")
+						s.line("%.2f%%", preHitCheckCritChance)
+						s.line("x %.2f (chance to hit)", pass.Output["HitChance"]/100)
+						s.line("= %.2f%%", pass.Output["CritChance"])
+					}
+				}
 			}
 
 			if skillModList.Flag(pass.Config, "NoCritMultiplier") {
@@ -2119,16 +2098,13 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 			pass.Output["CritEffect"] = 1 - critChancePercentage + critChancePercentage*pass.Output["CritMultiplier"]
 			pass.Output["CritEffect"] = (skillModList.Sum(mod.TypeBase, pass.Config, "CritMultiplier") - 50) * skillModList.Sum(mod.TypeBase, pass.Config, "CritMultiplierAppliesToDegen") / 1000
 
-			/*
-				TODO Breakdown
-				if breakdown and output.CritEffect ~= 1 then
-					breakdown.CritEffect = {
-						s_format("(1 - %.4f) ^8(portion of damage from non-crits)", critChancePercentage),
-						s_format("+ [ (%.4f x %g) ^8(portion of damage from crits)", critChancePercentage, output.CritMultiplier),
-						s_format("= %.3f", output.CritEffect),
-					}
-				end
-			*/
+			if pass.Breakdown != nil && pass.Output["CritEffect"] != 1 {
+				pass.Breakdown.Set("CritEffect", pass.Output["CritEffect"],
+					fmt.Sprintf("(1 - %.4f) (portion of damage from non-crits)", critChancePercentage),
+					fmt.Sprintf("+ (%.4f x %g) (portion of damage from crits)", critChancePercentage, pass.Output["CritMultiplier"]),
+					fmt.Sprintf("= %.3f", pass.Output["CritEffect"]),
+				)
+			}
 		}
 
 		pass.Output["ScaledDamageEffect"] = 1
@@ -2205,33 +2181,33 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 			pass.Output[damageTypeMin+"Base"] = baseMin
 			pass.Output[damageTypeMax+"Base"] = baseMax
 
-			/*
-				TODO Breakdown
-				if breakdown then
-					breakdown[damageType] = { damageTypes = { } }
-					if baseMin ~= 0 and baseMax ~= 0 then
-						t_insert(breakdown[damageType], "Base damage:")
-						local plus = ""
-						if (source[damageTypeMin] or 0) ~= 0 or (source[damageTypeMax] or 0) ~= 0 then
-							t_insert(breakdown[damageType], s_format("%d to %d ^8(base damage from %s)", source[damageTypeMin], source[damageTypeMax], source.type and "weapon" or "skill"))
-							if baseMultiplier ~= 1 then
-								t_insert(breakdown[damageType], s_format("x %.2f ^8(base damage multiplier)", baseMultiplier))
-							end
-							plus = "+ "
-						end
-						if addedMin ~= 0 or addedMax ~= 0 then
-							t_insert(breakdown[damageType], s_format("%s%d to %d ^8(added damage)", plus, addedMin, addedMax))
-							if damageEffectiveness ~= 1 then
-								t_insert(breakdown[damageType], s_format("x %.2f ^8(damage effectiveness)", damageEffectiveness))
-							end
-							if addedMult ~= 1 then
-								t_insert(breakdown[damageType], s_format("x %.2f ^8(added damage multiplier)", addedMult))
-							end
-						end
-						t_insert(breakdown[damageType], s_format("= %.1f to %.1f", baseMin, baseMax))
-					end
-				end
-			*/
+			if pass.Breakdown != nil {
+				s := pass.Breakdown.Set(string(damageType), 0)
+				s.Mods = append(tabulateMods(skillModList, pass.Config, mod.TypeBase, damageTypeMin), tabulateMods(skillModList, pass.Config, mod.TypeBase, damageTypeMax)...)
+				if baseMin != 0 && baseMax != 0 {
+					s.line("Base damage:")
+					plus := ""
+					sourceMin := utils.GetOr(pass.Source, damageTypeMin, utils.Interface(float64(0))).(float64)
+					sourceMax := utils.GetOr(pass.Source, damageTypeMax, utils.Interface(float64(0))).(float64)
+					if sourceMin != 0 || sourceMax != 0 {
+						s.line("%g to %g (base damage from %s)", sourceMin, sourceMax, utils.Ternary(pass.Source["type"] != nil, "weapon", "skill"))
+						if baseMultiplier != 1 {
+							s.line("x %.2f (base damage multiplier)", baseMultiplier)
+						}
+						plus = "+ "
+					}
+					if addedMin != 0 || addedMax != 0 {
+						s.line("%s%g to %g (added damage)", plus, addedMin, addedMax)
+						if damageEffectiveness != 1 {
+							s.line("x %.2f (damage effectiveness)", damageEffectiveness)
+						}
+						if addedMult != 1 {
+							s.line("x %.2f (added damage multiplier)", addedMult)
+						}
+					}
+					s.line("= %.1f to %.1f", baseMin, baseMax)
+				}
+			}
 		}
 
 		totalHitMin := float64(0)
@@ -2274,37 +2250,34 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 				damageTypeHitAvgNotLucky := float64(0)
 
 				if utils.HasTrue(skillFlags, SkillFlagHit) && utils.HasTrue(canDeal, damageType) {
-					damageTypeHitMin, damageTypeHitMax = calcDamage(activeSkill, pass.Output, pass.Config, nil, damageType, 0, nil)
+					var damageTypeBreakdown *StatBreakdown
+					if p == 2 && pass.Breakdown != nil {
+						damageTypeBreakdown = pass.Breakdown.Stat(string(damageType))
+					}
+
+					damageTypeHitMin, damageTypeHitMax = calcDamage(activeSkill, pass.Output, pass.Config, damageTypeBreakdown, damageType, 0, nil)
 					convMult := activeSkill.ConversionTable[damageType].Mult
 
-					/*
-						TODO Breakdown
-						if pass == 2 and breakdown then
-							t_insert(breakdown[damageType], "Hit damage:")
-							t_insert(breakdown[damageType], s_format("%d to %d ^8(total damage)", damageTypeHitMin, damageTypeHitMax))
-							if convMult ~= 1 then
-								t_insert(breakdown[damageType], s_format("x %g ^8(%g%% converted to other damage types)", convMult, (1-convMult)*100))
-							end
-							if output.TripleDamageEffect ~= 1 then
-								t_insert(breakdown[damageType], s_format("x %.2f ^8(multiplier from %.2f%% chance to deal triple damage)", output.TripleDamageEffect, output.TripleDamageChance))
-							end
-							if output.DoubleDamageEffect ~= 1 then
-								t_insert(breakdown[damageType], s_format("x %.2f ^8(multiplier from %.2f%% chance to deal double damage)", output.DoubleDamageEffect, output.DoubleDamageChance))
-							end
-							if output.RuthlessBlowHitEffect ~= 1 then
-								t_insert(breakdown[damageType], s_format("x %.2f ^8(ruthless blow effect modifier)", output.RuthlessBlowHitEffect))
-							end
-							if output.FistOfWarHitEffect ~= 1 then
-								t_insert(breakdown[damageType], s_format("x %.2f ^8(fist of war effect modifier)", output.FistOfWarHitEffect))
-							end
-							if globalOutput.OffensiveWarcryEffect ~= 1  and not activeSkill.skillModList:Flag(nil, "Condition:WarcryMaxHit") then
-								t_insert(breakdown[damageType], s_format("x %.2f ^8(aggregated warcry exerted effect modifier)", globalOutput.OffensiveWarcryEffect))
-							end
-							if globalOutput.MaxOffensiveWarcryEffect ~= 1 and activeSkill.skillModList:Flag(nil, "Condition:WarcryMaxHit") then
-								t_insert(breakdown[damageType], s_format("x %.2f ^8(aggregated max warcry exerted effect modifier)", globalOutput.MaxOffensiveWarcryEffect))
-							end
-						end
-					*/
+					if damageTypeBreakdown != nil {
+						damageTypeBreakdown.line("Hit damage:")
+						damageTypeBreakdown.line("%.0f to %.0f (total damage)", damageTypeHitMin, damageTypeHitMax)
+						if convMult != 1 {
+							damageTypeBreakdown.line("x %g (%g%% converted to other damage types)", convMult, (1-convMult)*100)
+						}
+						// TODO Double and triple damage
+						if pass.Output["RuthlessBlowHitEffect"] != 1 {
+							damageTypeBreakdown.line("x %.2f (ruthless blow effect modifier)", pass.Output["RuthlessBlowHitEffect"])
+						}
+						if pass.Output["FistOfWarHitEffect"] != 1 {
+							damageTypeBreakdown.line("x %.2f (fist of war effect modifier)", pass.Output["FistOfWarHitEffect"])
+						}
+						if output["OffensiveWarcryEffect"] != 1 && !skillModList.Flag(nil, "Condition:WarcryMaxHit") {
+							damageTypeBreakdown.line("x %.2f (aggregated warcry exerted effect modifier)", output["OffensiveWarcryEffect"])
+						}
+						if output["MaxOffensiveWarcryEffect"] != 1 && skillModList.Flag(nil, "Condition:WarcryMaxHit") {
+							damageTypeBreakdown.line("x %.2f (aggregated max warcry exerted effect modifier)", output["MaxOffensiveWarcryEffect"])
+						}
+					}
 
 					if skillModList.Flag(nil, "Condition:WarcryMaxHit") {
 						pass.Output["AllMult"] = convMult * pass.Output["ScaledDamageEffect"] * pass.Output["RuthlessBlowHitEffect"] * pass.Output["FistOfWarHitEffect"] * output["MaxOffensiveWarcryEffect"]
@@ -2513,15 +2486,8 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 							}
 						}
 					}
-				} else {
-					/*
-						TODO Breakdown
-						if breakdown then
-							breakdown[damageType] = {
-								"You can't deal "..damageType.." damage"
-							}
-						end
-					*/
+				} else if pass.Breakdown != nil {
+					pass.Breakdown.Set(string(damageType), 0, "You can't deal "+string(damageType)+" damage")
 				}
 
 				if p == 1 {
@@ -2535,6 +2501,11 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 						pass.Output[string(damageType)+"Max"] = damageTypeHitMax
 					}
 					pass.Output[string(damageType)+"HitAverage"] = damageTypeHitAvg
+					if pass.Breakdown != nil {
+						if s, ok := pass.Breakdown.Stats[string(damageType)]; ok {
+							s.Total = damageTypeHitAvg
+						}
+					}
 					totalHitAvg = totalHitAvg + damageTypeHitAvg
 					totalHitMin = totalHitMin + damageTypeHitMin
 					totalHitMax = totalHitMax + damageTypeHitMax
@@ -2602,86 +2573,81 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 				end
 			end
 			skillModList:NewMod("Condition:"..highestType.."IsHighestDamageType", "FLAG", true, "Config")
-
-			local hitRate = output.HitChance / 100 * (globalOutput.HitSpeed or globalOutput.Speed) * (skillData.dpsMultiplier or 1)
-		*/
-		/*
-			TODO -- Calculate leech
-			local function getLeechInstances(amount, total)
-				if total == 0 then
-					return 0, 0
-				end
-				local duration = amount / total / data.misc.LeechRateBase
-				return duration, duration * hitRate
-			end
-			if ghostReaver then
-				output.EnergyShieldLeech = output.EnergyShieldLeech + output.LifeLeech
-				output.EnergyShieldLeechInstant = output.EnergyShieldLeechInstant + output.LifeLeechInstant
-				output.LifeLeech = 0
-				output.LifeLeechInstant = 0
-			end
-			output.LifeLeech = m_min(output.LifeLeech, globalOutput.MaxLifeLeechInstance)
-			output.LifeLeechDuration, output.LifeLeechInstances = getLeechInstances(output.LifeLeech, globalOutput.Life)
-			output.LifeLeechInstantRate = output.LifeLeechInstant * hitRate
-			output.EnergyShieldLeech = m_min(output.EnergyShieldLeech, globalOutput.MaxEnergyShieldLeechInstance)
-			output.EnergyShieldLeechDuration, output.EnergyShieldLeechInstances = getLeechInstances(output.EnergyShieldLeech, globalOutput.EnergyShield)
-			output.EnergyShieldLeechInstantRate = output.EnergyShieldLeechInstant * hitRate
-			output.ManaLeech = m_min(output.ManaLeech, globalOutput.MaxManaLeechInstance)
-			output.ManaLeechDuration, output.ManaLeechInstances = getLeechInstances(output.ManaLeech, globalOutput.Mana)
-			output.ManaLeechInstantRate = output.ManaLeechInstant * hitRate
 		*/
-		/*
-			TODO -- Calculate gain on hit
-			if skillFlags.mine or skillFlags.trap or skillFlags.totem then
-				output.LifeOnHit = 0
-				output.EnergyShieldOnHit = 0
-				output.ManaOnHit = 0
-			else
-				output.LifeOnHit = skillModList:Sum("BASE", cfg, "LifeOnHit") + enemyDB:Sum("BASE", cfg, "SelfLifeOnHit")
-				output.EnergyShieldOnHit = skillModList:Sum("BASE", cfg, "EnergyShieldOnHit") + enemyDB:Sum("BASE", cfg, "SelfEnergyShieldOnHit")
-				output.ManaOnHit = skillModList:Sum("BASE", cfg, "ManaOnHit") + enemyDB:Sum("BASE", cfg, "SelfManaOnHit")
-			end
-			output.LifeOnHitRate = output.LifeOnHit * hitRate
-			output.EnergyShieldOnHitRate = output.EnergyShieldOnHit * hitRate
-			output.ManaOnHitRate = output.ManaOnHit * hitRate
-		*/
-
-		// Calculate average damage and final DPS
-		pass.Output["AverageHit"] = totalHitAvg*(1-pass.Output["CritChance"]/100) + totalCritAvg*pass.Output["CritChance"]/100
-		pass.Output["AverageDamage"] = pass.Output["AverageHit"] * pass.Output["HitChance"] / 100
-
+		// Calculate hit rate, used for leech and gain on hit
 		selectedSpeed, gotSpeed := output["HitSpeed"]
 		if !gotSpeed || selectedSpeed == 0 {
 			selectedSpeed = output["Speed"]
 		}
 
 		DpsMultiplier := utils.GetOr(skillData, "DpsMultiplier", utils.Interface(float64(1))).(float64)
+		hitRate := pass.Output["HitChance"] / 100 * selectedSpeed * DpsMultiplier
+
+		// Calculate leech
+		getLeechInstances := func(amount float64, total float64) (float64, float64) {
+			if total == 0 {
+				return 0, 0
+			}
+			duration := amount / total / data.LeechRateBase
+			return duration, duration * hitRate
+		}
+		if ghostReaver {
+			pass.Output["EnergyShieldLeech"] += pass.Output["LifeLeech"]
+			pass.Output["EnergyShieldLeechInstant"] += pass.Output["LifeLeechInstant"]
+			pass.Output["LifeLeech"] = 0
+			pass.Output["LifeLeechInstant"] = 0
+		}
+		pass.Output["LifeLeech"] = math.Min(pass.Output["LifeLeech"], output["MaxLifeLeechInstance"])
+		pass.Output["LifeLeechDuration"], pass.Output["LifeLeechInstances"] = getLeechInstances(pass.Output["LifeLeech"], output["Life"])
+		pass.Output["LifeLeechInstantRate"] = pass.Output["LifeLeechInstant"] * hitRate
+		pass.Output["EnergyShieldLeech"] = math.Min(pass.Output["EnergyShieldLeech"], output["MaxEnergyShieldLeechInstance"])
+		pass.Output["EnergyShieldLeechDuration"], pass.Output["EnergyShieldLeechInstances"] = getLeechInstances(pass.Output["EnergyShieldLeech"], output["EnergyShield"])
+		pass.Output["EnergyShieldLeechInstantRate"] = pass.Output["EnergyShieldLeechInstant"] * hitRate
+		pass.Output["ManaLeech"] = math.Min(pass.Output["ManaLeech"], output["MaxManaLeechInstance"])
+		pass.Output["ManaLeechDuration"], pass.Output["ManaLeechInstances"] = getLeechInstances(pass.Output["ManaLeech"], output["Mana"])
+		pass.Output["ManaLeechInstantRate"] = pass.Output["ManaLeechInstant"] * hitRate
+
+		// Calculate gain on hit
+		if skillFlags[SkillFlagMine] || skillFlags[SkillFlagTrap] || skillFlags[SkillFlagTotem] {
+			pass.Output["LifeOnHit"] = 0
+			pass.Output["EnergyShieldOnHit"] = 0
+			pass.Output["ManaOnHit"] = 0
+		} else {
+			pass.Output["LifeOnHit"] = skillModList.Sum(mod.TypeBase, pass.Config, "LifeOnHit") + enemyDB.Sum(mod.TypeBase, pass.Config, "SelfLifeOnHit")
+			pass.Output["EnergyShieldOnHit"] = skillModList.Sum(mod.TypeBase, pass.Config, "EnergyShieldOnHit") + enemyDB.Sum(mod.TypeBase, pass.Config, "SelfEnergyShieldOnHit")
+			pass.Output["ManaOnHit"] = skillModList.Sum(mod.TypeBase, pass.Config, "ManaOnHit") + enemyDB.Sum(mod.TypeBase, pass.Config, "SelfManaOnHit")
+		}
+		pass.Output["LifeOnHitRate"] = pass.Output["LifeOnHit"] * hitRate
+		pass.Output["EnergyShieldOnHitRate"] = pass.Output["EnergyShieldOnHit"] * hitRate
+		pass.Output["ManaOnHitRate"] = pass.Output["ManaOnHit"] * hitRate
+
+		// Calculate average damage and final DPS
+		pass.Output["AverageHit"] = totalHitAvg*(1-pass.Output["CritChance"]/100) + totalCritAvg*pass.Output["CritChance"]/100
+		pass.Output["AverageDamage"] = pass.Output["AverageHit"] * pass.Output["HitChance"] / 100
+
 		pass.Output["TotalDPS"] = pass.Output["AverageDamage"] * selectedSpeed * DpsMultiplier * quantityMultiplier
-		/*
-			TODO Breakdown
-			if breakdown then
-				if output.CritEffect ~= 1 then
-					breakdown.AverageHit = { }
-					if skillModList:Flag(skillCfg, "LuckyHits") then
-						t_insert(breakdown.AverageHit, s_format("(1/3) x %d + (2/3) x %d = %.1f ^8(average from non-crits)", totalHitMin, totalHitMax, totalHitAvg))
-					end
-					if skillModList:Flag(skillCfg, "CritLucky") or skillModList:Flag(skillCfg, "LuckyHits") then
-						t_insert(breakdown.AverageHit, s_format("(1/3) x %d + (2/3) x %d = %.1f ^8(average from crits)", totalCritMin, totalCritMax, totalCritAvg))
-						t_insert(breakdown.AverageHit, "")
-					end
-					t_insert(breakdown.AverageHit, s_format("%.1f x (1 - %.4f) ^8(damage from non-crits)", totalHitAvg, output.CritChance / 100))
-					t_insert(breakdown.AverageHit, s_format("+ %.1f x %.4f ^8(damage from crits)", totalCritAvg, output.CritChance / 100))
-					t_insert(breakdown.AverageHit, s_format("= %.1f", output.AverageHit))
-				end
-				if isAttack then
-					breakdown.AverageDamage = { }
-					t_insert(breakdown.AverageDamage, s_format("%s:", pass.label))
-					t_insert(breakdown.AverageDamage, s_format("%.1f ^8(average hit)", output.AverageHit))
-					t_insert(breakdown.AverageDamage, s_format("x %.2f ^8(chance to hit)", output.HitChance / 100))
-					t_insert(breakdown.AverageDamage, s_format("= %.1f", output.AverageDamage))
-				end
-			end
-		*/
+		if pass.Breakdown != nil {
+			if pass.Output["CritEffect"] != 1 {
+				s := pass.Breakdown.Set("AverageHit", pass.Output["AverageHit"])
+				if skillModList.Flag(skillCfg, "LuckyHits") {
+					s.line("(1/3) x %.0f + (2/3) x %.0f = %.1f (average from non-crits)", totalHitMin, totalHitMax, totalHitAvg)
+				}
+				if skillModList.Flag(skillCfg, "CritLucky") || skillModList.Flag(skillCfg, "LuckyHits") {
+					s.line("(1/3) x %.0f + (2/3) x %.0f = %.1f (average from crits)", totalCritMin, totalCritMax, totalCritAvg)
+				}
+				s.line("%.1f x (1 - %.4f) (damage from non-crits)", totalHitAvg, pass.Output["CritChance"]/100)
+				s.line("+ %.1f x %.4f (damage from crits)", totalCritAvg, pass.Output["CritChance"]/100)
+				s.line("= %.1f", pass.Output["AverageHit"])
+			}
+			if isAttack {
+				pass.Breakdown.Set("AverageDamage", pass.Output["AverageDamage"],
+					pass.Label+":",
+					fmt.Sprintf("%.1f (average hit)", pass.Output["AverageHit"]),
+					fmt.Sprintf("x %.2f (chance to hit)", pass.Output["HitChance"]/100),
+					fmt.Sprintf("= %.1f", pass.Output["AverageDamage"]),
+				)
+			}
+		}
 	}
 
 	if isAttack {
@@ -2710,21 +2676,15 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 		combineStat("ManaOnHit", "DPS")
 		combineStat("ManaOnHitRate", "DPS")
 
-		/*
-			TODO bothWeaponAttack
-			if skillFlags.bothWeaponAttack then
-				if breakdown then
-					breakdown.AverageDamage = { }
-					t_insert(breakdown.AverageDamage, "Both weapons:")
-					if skillData.doubleHitsWhenDualWielding then
-						t_insert(breakdown.AverageDamage, s_format("%.1f + %.1f ^8(skill hits with both weapons at once)", output.MainHand.AverageDamage, output.OffHand.AverageDamage))
-					else
-						t_insert(breakdown.AverageDamage, s_format("(%.1f + %.1f) / 2 ^8(skill alternates weapons)", output.MainHand.AverageDamage, output.OffHand.AverageDamage))
-					end
-					t_insert(breakdown.AverageDamage, s_format("= %.1f", output.AverageDamage))
-				end
-			end
-		*/
+		if utils.HasTrue(skillFlags, SkillFlagBothWeaponAttack) && breakdown != nil {
+			s := breakdown.Set("AverageDamage", output["AverageDamage"], "Both weapons:")
+			if utils.HasTrue(skillData, "DoubleHitsWhenDualWielding") {
+				s.line("%.1f + %.1f (skill hits with both weapons at once)", outputTable[OutTableMainHand]["AverageDamage"], outputTable[OutTableOffHand]["AverageDamage"])
+			} else {
+				s.line("(%.1f + %.1f) / 2 (skill alternates weapons)", outputTable[OutTableMainHand]["AverageDamage"], outputTable[OutTableOffHand]["AverageDamage"])
+			}
+			s.line("= %.1f", output["AverageDamage"])
+		}
 	}
 
 	/*
@@ -2737,93 +2697,84 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 			end
 		end
 	*/
-	/*
-		TODO breakdown
-		if breakdown then
-			if isAttack then
-				breakdown.TotalDPS = {
-					s_format("%.1f ^8(average damage)", output.AverageDamage),
-					output.HitSpeed and s_format("x %.2f ^8(hit rate)", output.HitSpeed) or s_format("x %.2f ^8(attack rate)", output.Speed),
-				}
-			elseif isTriggered then
-				breakdown.TotalDPS = {
-					s_format("%.1f ^8(average damage)", output.AverageDamage),
-					output.HitSpeed and s_format("x %.2f ^8(hit rate)", output.HitSpeed) or s_format("x %.2f ^8(trigger rate)", output.Speed),
-				}
-			else
-				breakdown.TotalDPS = {
-					s_format("%.1f ^8(average hit)", output.AverageDamage),
-					output.HitSpeed and s_format("x %.2f ^8(hit rate)", output.HitSpeed) or s_format("x %.2f ^8(cast rate)", output.Speed),
-				}
-			end
-			if skillData.dpsMultiplier then
-				t_insert(breakdown.TotalDPS, s_format("x %g ^8(DPS multiplier for this skill)", skillData.dpsMultiplier))
-			end
-			if quantityMultiplier > 1 then
-				t_insert(breakdown.TotalDPS, s_format("x %g ^8(quantity multiplier for this skill)", quantityMultiplier))
-			end
-			t_insert(breakdown.TotalDPS, s_format("= %.1f", output.TotalDPS))
-		end
-	*/
-	/*
-		TODO -- Calculate leech rates
-		output.LifeLeechInstanceRate = output.Life * data.misc.LeechRateBase * calcLib.mod(skillModList, skillCfg, "LifeLeechRate")
-		output.LifeLeechRate = output.LifeLeechInstantRate + m_min(output.LifeLeechInstances * output.LifeLeechInstanceRate, output.MaxLifeLeechRate) * output.LifeRecoveryRateMod
-		output.LifeLeechPerHit = output.LifeLeechInstant + m_min(output.LifeLeechInstanceRate, output.MaxLifeLeechRate) * output.LifeLeechDuration * output.LifeRecoveryRateMod
-		output.EnergyShieldLeechInstanceRate = output.EnergyShield * data.misc.LeechRateBase * calcLib.mod(skillModList, skillCfg, "EnergyShieldLeechRate")
-		output.EnergyShieldLeechRate = output.EnergyShieldLeechInstantRate + m_min(output.EnergyShieldLeechInstances * output.EnergyShieldLeechInstanceRate, output.MaxEnergyShieldLeechRate) * output.EnergyShieldRecoveryRateMod
-		output.EnergyShieldLeechPerHit = output.EnergyShieldLeechInstant + m_min(output.EnergyShieldLeechInstanceRate, output.MaxEnergyShieldLeechRate) * output.EnergyShieldLeechDuration * output.EnergyShieldRecoveryRateMod
-		output.ManaLeechInstanceRate = output.Mana * data.misc.LeechRateBase * calcLib.mod(skillModList, skillCfg, "ManaLeechRate")
-		output.ManaLeechRate = output.ManaLeechInstantRate + m_min(output.ManaLeechInstances * output.ManaLeechInstanceRate, output.MaxManaLeechRate) * output.ManaRecoveryRateMod
-		output.ManaLeechPerHit = output.ManaLeechInstant + m_min(output.ManaLeechInstanceRate, output.MaxManaLeechRate) * output.ManaLeechDuration * output.ManaRecoveryRateMod
-		-- On full life, Immortal Ambition treats life leech as energy shield leech
-		if skillModList:Flag(nil, "ImmortalAmbition") then
-			output.EnergyShieldLeechRate = output.EnergyShieldLeechRate + output.LifeLeechRate
-			output.EnergyShieldLeechPerHit = output.EnergyShieldLeechPerHit  + output.LifeLeechPerHit
-			-- Clears output.LifeLeechRate to disable leechLife flag
-			output.LifeLeechRate = 0
-		end
-		skillFlags.leechLife = output.LifeLeechRate > 0
-		skillFlags.leechES = output.EnergyShieldLeechRate > 0
-		skillFlags.leechMana = output.ManaLeechRate > 0
-		if skillData.showAverage then
-			output.LifeLeechGainPerHit = output.LifeLeechPerHit + output.LifeOnHit
-			output.EnergyShieldLeechGainPerHit = output.EnergyShieldLeechPerHit + output.EnergyShieldOnHit
-			output.ManaLeechGainPerHit = output.ManaLeechPerHit + output.ManaOnHit
-		else
-			output.LifeLeechGainRate = output.LifeLeechRate + output.LifeOnHitRate
-			output.EnergyShieldLeechGainRate = output.EnergyShieldLeechRate + output.EnergyShieldOnHitRate
-			output.ManaLeechGainRate = output.ManaLeechRate + output.ManaOnHitRate
-		end
-		if breakdown then
-			if skillFlags.leechLife then
-				breakdown.LifeLeech = breakdown.leech(output.LifeLeechInstant, output.LifeLeechInstantRate, output.LifeLeechInstances, output.Life, "LifeLeechRate", output.MaxLifeLeechRate, output.LifeLeechDuration)
-			end
-			if skillFlags.leechES then
-				breakdown.EnergyShieldLeech = breakdown.leech(output.EnergyShieldLeechInstant, output.EnergyShieldLeechInstantRate, output.EnergyShieldLeechInstances, output.EnergyShield, "EnergyShieldLeechRate", output.MaxEnergyShieldLeechRate, output.EnergyShieldLeechDuration)
-			end
-			if skillFlags.leechMana then
-				breakdown.ManaLeech = breakdown.leech(output.ManaLeechInstant, output.ManaLeechInstantRate, output.ManaLeechInstances, output.Mana, "ManaLeechRate", output.MaxManaLeechRate, output.ManaLeechDuration)
-			end
-		end
-	*/
-	/*
-		TODO Calculate Ailments
-		local ailmentData = data.nonDamagingAilment
-		for _, ailment in ipairs(ailmentTypeList) do
-			skillFlags[string.lower(ailment)] = false
-		end
-		skillFlags.igniteCanStack = skillModList:Flag(skillCfg, "IgniteCanStack")
-		skillFlags.igniteToChaos = skillModList:Flag(skillCfg, "IgniteToChaos")
-		skillFlags.impale = false
-	*/
+	if breakdown != nil {
+		s := breakdown.Set("TotalDPS", output["TotalDPS"])
+		if isAttack {
+			s.line("%.1f (average damage)", output["AverageDamage"])
+		} else {
+			s.line("%.1f (average hit)", output["AverageDamage"])
+		}
+
+		if hitSpeed, ok := output["HitSpeed"]; ok {
+			s.line("x %.2f (hit rate)", hitSpeed)
+		} else if isTriggered {
+			s.line("x %.2f (trigger rate)", output["Speed"])
+		} else if isAttack {
+			s.line("x %.2f (attack rate)", output["Speed"])
+		} else {
+			s.line("x %.2f (cast rate)", output["Speed"])
+		}
+
+		if dpsMultiplier, ok := skillData["DpsMultiplier"]; ok {
+			s.line("x %g (DPS multiplier for this skill)", dpsMultiplier)
+		}
+		if quantityMultiplier > 1 {
+			s.line("x %g (quantity multiplier for this skill)", quantityMultiplier)
+		}
+		s.line("= %.1f", output["TotalDPS"])
+	}
+	// Calculate leech rates
+	output["LifeLeechInstanceRate"] = output["Life"] * data.LeechRateBase * CalcMod(skillModList, skillCfg, "LifeLeechRate")
+	output["LifeLeechRate"] = output["LifeLeechInstantRate"] + math.Min(output["LifeLeechInstances"]*output["LifeLeechInstanceRate"], output["MaxLifeLeechRate"])*output["LifeRecoveryRateMod"]
+	output["LifeLeechPerHit"] = output["LifeLeechInstant"] + math.Min(output["LifeLeechInstanceRate"], output["MaxLifeLeechRate"])*output["LifeLeechDuration"]*output["LifeRecoveryRateMod"]
+	output["EnergyShieldLeechInstanceRate"] = output["EnergyShield"] * data.LeechRateBase * CalcMod(skillModList, skillCfg, "EnergyShieldLeechRate")
+	output["EnergyShieldLeechRate"] = output["EnergyShieldLeechInstantRate"] + math.Min(output["EnergyShieldLeechInstances"]*output["EnergyShieldLeechInstanceRate"], output["MaxEnergyShieldLeechRate"])*output["EnergyShieldRecoveryRateMod"]
+	output["EnergyShieldLeechPerHit"] = output["EnergyShieldLeechInstant"] + math.Min(output["EnergyShieldLeechInstanceRate"], output["MaxEnergyShieldLeechRate"])*output["EnergyShieldLeechDuration"]*output["EnergyShieldRecoveryRateMod"]
+	output["ManaLeechInstanceRate"] = output["Mana"] * data.LeechRateBase * CalcMod(skillModList, skillCfg, "ManaLeechRate")
+	output["ManaLeechRate"] = output["ManaLeechInstantRate"] + math.Min(output["ManaLeechInstances"]*output["ManaLeechInstanceRate"], output["MaxManaLeechRate"])*output["ManaRecoveryRateMod"]
+	output["ManaLeechPerHit"] = output["ManaLeechInstant"] + math.Min(output["ManaLeechInstanceRate"], output["MaxManaLeechRate"])*output["ManaLeechDuration"]*output["ManaRecoveryRateMod"]
+
+	// On full life, Immortal Ambition treats life leech as energy shield leech
+	if skillModList.Flag(nil, "ImmortalAmbition") {
+		output["EnergyShieldLeechRate"] += output["LifeLeechRate"]
+		output["EnergyShieldLeechPerHit"] += output["LifeLeechPerHit"]
+		output["LifeLeechRate"] = 0
+	}
+	skillFlags[SkillFlagLeechLife] = output["LifeLeechRate"] > 0
+	skillFlags[SkillFlagLeechES] = output["EnergyShieldLeechRate"] > 0
+	skillFlags[SkillFlagLeechMana] = output["ManaLeechRate"] > 0
+	if skillFlags[SkillFlagShowAverage] {
+		output["LifeLeechGainPerHit"] = output["LifeLeechPerHit"] + output["LifeOnHit"]
+		output["EnergyShieldLeechGainPerHit"] = output["EnergyShieldLeechPerHit"] + output["EnergyShieldOnHit"]
+		output["ManaLeechGainPerHit"] = output["ManaLeechPerHit"] + output["ManaOnHit"]
+	} else {
+		output["LifeLeechGainRate"] = output["LifeLeechRate"] + output["LifeOnHitRate"]
+		output["EnergyShieldLeechGainRate"] = output["EnergyShieldLeechRate"] + output["EnergyShieldOnHitRate"]
+		output["ManaLeechGainRate"] = output["ManaLeechRate"] + output["ManaOnHitRate"]
+	}
+	if breakdown != nil {
+		if skillFlags[SkillFlagLeechLife] {
+			breakdown.leech("LifeLeech", output["LifeLeechInstant"], output["LifeLeechInstances"], output["Life"], output["LifeLeechInstanceRate"], output["MaxLifeLeechRate"], output["LifeLeechDuration"])
+		}
+		if skillFlags[SkillFlagLeechES] {
+			breakdown.leech("EnergyShieldLeech", output["EnergyShieldLeechInstant"], output["EnergyShieldLeechInstances"], output["EnergyShield"], output["EnergyShieldLeechInstanceRate"], output["MaxEnergyShieldLeechRate"], output["EnergyShieldLeechDuration"])
+		}
+		if skillFlags[SkillFlagLeechMana] {
+			breakdown.leech("ManaLeech", output["ManaLeechInstant"], output["ManaLeechInstances"], output["Mana"], output["ManaLeechInstanceRate"], output["MaxManaLeechRate"], output["ManaLeechDuration"])
+		}
+	}
+	// Calculate ailments
+	for _, ailment := range ailmentTypeList {
+		skillFlags[SkillFlag(strings.ToLower(string(ailment)))] = false
+	}
+	skillFlags[SkillFlagIgniteCanStack] = skillModList.Flag(skillCfg, "IgniteCanStack")
+	skillFlags[SkillFlagIgniteToChaos] = skillModList.Flag(skillCfg, "IgniteToChaos")
+	skillFlags[SkillFlagImpale] = false
 
 	for _, pass := range passList {
-		globalOutput := output
-		// globalBreakdown := breakdown
+		globalOutput, globalBreakdown := output, breakdown
 		// source := pass.Source
-		output, cfg := pass.Output, pass.Config
-		// breakdown := pass.Breakdown
+		output, cfg, breakdown := pass.Output, pass.Config, pass.Breakdown
 
 		// Calculate chance to inflict secondary dots/status effects
 		cfg.SkillCond["CriticalStrike"] = true
@@ -2913,7 +2864,7 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 		}
 
 		if env.ModeEffective {
-			for _, ailment := range data.Ailment("").Values() {
+			for _, ailment := range ailmentTypeList {
 				mult := 1 - enemyDB.Sum("BASE", nil, "Avoid"+string(ailment))/100
 				output[string(ailment)+"ChanceOnHit"] = output[string(ailment)+"ChanceOnHit"] * mult
 				output[string(ailment)+"ChanceOnCrit"] = output[string(ailment)+"ChanceOnCrit"] * mult
@@ -2923,37 +2874,32 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 			}
 		}
 
-		/*
-			TODO igniteMode
-			local igniteMode = env.configInput.igniteMode or "AVERAGE"
-			if igniteMode == "CRIT" then
-				for _, ailment in ipairs(ailmentTypeList) do
-					output[ailment.."ChanceOnHit"] = 0
-				end
-			end
-		*/
-		/*
-			TODO calcAverageSourceDamage
-			---Calculates normal and crit damage to be used in non-damaging ailment calculations
-			---@param ailment string
-			---@return number, number @average hit damage, average crit damage
-			local function calcAverageSourceDamage(ailment)
-				local sourceHitDmg, sourceCritDmg = 0, 0
-				for _, type in ipairs(dmgTypeList) do
-					if canDeal[type] and (function()
-						if type == ailmentData[ailment].associatedType then
-							return not skillModList:Flag(cfg, type.."Cannot"..ailment)
-						else
-							return skillModList:Flag(cfg, type.."Can"..ailment)
-						end
-					end)() then
-						sourceHitDmg = sourceHitDmg + output[type.."HitAverage"]
-						sourceCritDmg = sourceCritDmg + output[type.."CritAverage"]
-					end
-				end
-				return sourceHitDmg, sourceCritDmg
-			end
-		*/
+		ailmentMode := "Average Damage"
+		if env.configString("igniteMode") == "CRIT" {
+			ailmentMode = "Crits Only"
+			for _, ailment := range ailmentTypeList {
+				output[string(ailment)+"ChanceOnHit"] = 0
+			}
+		}
+		// calcAverageSourceDamage calculates the average hit and crit damage used for non-damaging ailments
+		calcAverageSourceDamage := func(ailment data.Ailment) (float64, float64) {
+			sourceHitDmg, sourceCritDmg := float64(0), float64(0)
+			for _, damageType := range dmgTypeList {
+				if !canDeal[damageType] {
+					continue
+				}
+
+				canInflict := skillModList.Flag(cfg, string(damageType)+"Can"+string(ailment))
+				if damageType == data.NonDamagingAilments[ailment].AssociatedType {
+					canInflict = !skillModList.Flag(cfg, string(damageType)+"Cannot"+string(ailment))
+				}
+				if canInflict {
+					sourceHitDmg += output[string(damageType)+"HitAverage"]
+					sourceCritDmg += output[string(damageType)+"CritAverage"]
+				}
+			}
+			return sourceHitDmg, sourceCritDmg
+		}
 
 		calcAilmentDamage := func(typee string, sourceHitDmg float64, sourceCritDmg float64) float64 {
 			// Calculate the inflict chance and base damage of a secondary effect (bleed/poison/ignite/shock/freeze)
@@ -2965,95 +2911,121 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 			baseFromHit := sourceHitDmg * chanceFromHit / (chanceFromHit + chanceFromCrit)
 			baseFromCrit := sourceCritDmg * chanceFromCrit / (chanceFromHit + chanceFromCrit)
 			baseVal := baseFromHit + baseFromCrit
-			/*
-				TODO Breakdown
-				sourceMult := skillModList.More(nil, typee+"AsThoughDealing")
-				if breakdown and chance ~= 0 then
-					local breakdownChance = breakdown[type.."Chance"] or { }
-					breakdown[type.."Chance"] = breakdownChance
-					if breakdownChance[1] then
-						t_insert(breakdownChance, "")
-					end
-					if isAttack then
-						t_insert(breakdownChance, pass.label..":")
-					end
-					t_insert(breakdownChance, s_format("Chance on Non-crit: %d%%", chanceOnHit))
-					t_insert(breakdownChance, s_format("Chance on Crit: %d%%", chanceOnCrit))
-					if chanceOnHit ~= chanceOnCrit then
-						t_insert(breakdownChance, "Combined chance:")
-						t_insert(breakdownChance, s_format("%d x (1 - %.4f) ^8(chance from non-crits)", chanceOnHit, output.CritChance/100))
-						t_insert(breakdownChance, s_format("+ %d x %.4f ^8(chance from crits)", chanceOnCrit, output.CritChance/100))
-						t_insert(breakdownChance, s_format("= %.2f", chance))
-					end
-				end
-				if breakdown and baseVal > 0 then
-					local breakdownDPS = breakdown[type.."DPS"] or { }
-					breakdown[type.."DPS"] = breakdownDPS
-					if breakdownDPS[1] then
-						t_insert(breakdownDPS, "")
-					end
-					if isAttack then
-						t_insert(breakdownDPS, pass.label..":")
-					end
-					if sourceHitDmg == sourceCritDmg then
-						t_insert(breakdownDPS, "Total damage:")
-						t_insert(breakdownDPS, s_format("%.1f ^8(source damage)",sourceHitDmg))
-						if sourceMult > 1 then
-							t_insert(breakdownDPS, s_format("x %.2f ^8(inflicting as though dealing more damage)", sourceMult))
-							t_insert(breakdownDPS, s_format("= %.1f", baseVal * sourceMult))
-						end
-					else
-						if baseFromHit > 0 then
-							t_insert(breakdownDPS, "Damage from Non-crits:")
-							t_insert(breakdownDPS, s_format("%.1f ^8(source damage from non-crits)", sourceHitDmg))
-							t_insert(breakdownDPS, s_format("x %.3f ^8(portion of instances created by non-crits)", chanceFromHit / (chanceFromHit + chanceFromCrit)))
-							if sourceMult == 1 or baseFromCrit ~= 0 then
-								t_insert(breakdownDPS, s_format("= %.1f", baseFromHit))
-							end
-						end
-						if baseFromCrit > 0 then
-							t_insert(breakdownDPS, "Damage from Crits:")
-							t_insert(breakdownDPS, s_format("%.1f ^8(source damage from crits)", sourceCritDmg))
-							t_insert(breakdownDPS, s_format("x %.3f ^8(portion of instances created by crits)", chanceFromCrit / (chanceFromHit + chanceFromCrit)))
-							if sourceMult == 1 or baseFromHit ~= 0 then
-								t_insert(breakdownDPS, s_format("= %.1f", baseFromCrit))
-							end
-						end
-						if baseFromHit > 0 and baseFromCrit > 0 then
-							t_insert(breakdownDPS, "Total damage:")
-							t_insert(breakdownDPS, s_format("%.1f + %.1f", baseFromHit, baseFromCrit))
-							if sourceMult == 1 then
-								t_insert(breakdownDPS, s_format("= %.1f", baseVal))
-							end
-						end
-						if sourceMult > 1 then
-							t_insert(breakdownDPS, s_format("x %.2f ^8(inflicting as though dealing more damage)", sourceMult))
-							t_insert(breakdownDPS, s_format("= %.1f", baseVal * sourceMult))
-						end
-					end
-				end
-			*/
+			sourceMult := skillModList.More(cfg, typee+"AsThoughDealing")
+			if breakdown != nil && chance != 0 {
+				s := breakdown.Stat(typee + "Chance")
+				s.Total = chance
+				if len(s.Lines) > 0 {
+					s.line("")
+				}
+				if isAttack {
+					s.line("%s:", pass.Label)
+				}
+				s.line("Chance on Non-crit: %.0f%%", chanceOnHit)
+				s.line("Chance on Crit: %.0f%%", chanceOnCrit)
+				if chanceOnHit != chanceOnCrit {
+					s.line("Combined chance:")
+					s.line("%.0f x (1 - %.4f) (chance from non-crits)", chanceOnHit, output["CritChance"]/100)
+					s.line("+ %.0f x %.4f (chance from crits)", chanceOnCrit, output["CritChance"]/100)
+					s.line("= %.2f", chance)
+				}
+			}
+			if breakdown != nil && baseVal > 0 {
+				s := breakdown.Stat(typee + "DPS")
+				if len(s.Lines) > 0 {
+					s.line("")
+				}
+				if isAttack {
+					s.line("%s:", pass.Label)
+				}
+				if sourceHitDmg == sourceCritDmg {
+					s.line("Total damage:")
+					s.line("%.1f (source damage)", sourceHitDmg)
+				} else {
+					if baseFromHit > 0 {
+						s.line("Damage from Non-crits:")
+						s.line("%.1f (source damage from non-crits)", sourceHitDmg)
+						s.line("x %.3f (portion of instances created by non-crits)", chanceFromHit/(chanceFromHit+chanceFromCrit))
+						if sourceMult == 1 || baseFromCrit != 0 {
+							s.line("= %.1f", baseFromHit)
+						}
+					}
+					if baseFromCrit > 0 {
+						s.line("Damage from Crits:")
+						s.line("%.1f (source damage from crits)", sourceCritDmg)
+						s.line("x %.3f (portion of instances created by crits)", chanceFromCrit/(chanceFromHit+chanceFromCrit))
+						if sourceMult == 1 || baseFromHit != 0 {
+							s.line("= %.1f", baseFromCrit)
+						}
+					}
+					if baseFromHit > 0 && baseFromCrit > 0 {
+						s.line("Total damage:")
+						s.line("%.1f + %.1f", baseFromHit, baseFromCrit)
+						if sourceMult == 1 {
+							s.line("= %.1f", baseVal)
+						}
+					}
+				}
+				if sourceMult > 1 {
+					s.line("x %.2f (inflicting as though dealing more damage)", sourceMult)
+					s.line("= %.1f", baseVal*sourceMult)
+				}
+			}
 			return baseVal
 		}
 
-		// Calculate bleeding chance and damage
-		if canDeal[data.DamageTypePhysical] && (output["BleedChanceOnHit"]+output["BleedChanceOnCrit"]) > 0 {
-			// TODO This is almost definitely a bad idea but I cba to implement a new interface with a struct that references underlying map and returns values so this is what you get
-			badIdea := maps.Clone(skillCfg.SkillCond)
-			badIdea["CriticalStrike"] = true
-
-			dotCfg := &moddb.ListCfg{
-				// TODO SkillName, SkillPart, SkillTypes, SkillDist
-				// SkillName: skillCfg.SkillName,
-				// SkillPart: skillCfg.SkillPart,
-				// SkillTypes: skillCfg.SkillTypes,
-				SlotName:     skillCfg.SlotName,
-				Flags:        utils.Ptr(mod.MFlagDot | mod.MFlagAilment | (cfg.Flags.Get() & mod.MFlagWeaponMask) | utils.Ternary((cfg.Flags.Get()&mod.MFlagMelee) != 0, mod.MFlagMeleeHit, 0)),
-				KeywordFlags: utils.Ptr((cfg.KeywordFlags.Get() & ^mod.KeywordFlagHit) | mod.KeywordFlagBleed | mod.KeywordFlagAilment | mod.KeywordFlagPhysicalDot),
-				SkillCond:    badIdea,
-				// SkillDist: skillCfg.SkillDist,
+		// ailmentDuration records how the duration of a damaging ailment was modified
+		ailmentDuration := func(stat string, durationBase float64, durationMod float64, rateMod float64, rateLabel string) {
+			if globalBreakdown == nil || globalOutput[stat] == durationBase {
+				return
 			}
 
+			s := globalBreakdown.Set(stat, globalOutput[stat], fmt.Sprintf("%.2fs (base duration)", durationBase))
+			if durationMod != 1 {
+				s.line("x %.2f (duration modifier)", durationMod)
+			}
+			if rateMod != 1 {
+				s.line("/ %.2f (%s)", rateMod, rateLabel)
+			}
+			if debuffDurationMult != 1 {
+				s.line("/ %.2f (debuff expires slower/faster)", 1/debuffDurationMult)
+			}
+			s.line("= %.2fs", globalOutput[stat])
+		}
+
+		// weightDotMulti combines the DoT multipliers of an ailment inflicted by non-crits and crits
+		weightDotMulti := func(typee string) {
+			dotMulti, critDotMulti := output[typee+"DotMulti"], output["Crit"+typee+"DotMulti"]
+			if critDotMulti == dotMulti {
+				return
+			}
+
+			chanceFromHit := output[typee+"ChanceOnHit"] / 100 * (1 - globalOutput["CritChance"]/100)
+			chanceFromCrit := output[typee+"ChanceOnCrit"] / 100 * output["CritChance"] / 100
+			totalFromHit := chanceFromHit / (chanceFromHit + chanceFromCrit)
+			totalFromCrit := chanceFromCrit / (chanceFromHit + chanceFromCrit)
+			output[typee+"DotMulti"] = dotMulti*totalFromHit + critDotMulti*totalFromCrit
+			breakdown.Set(typee+"DotMulti", output[typee+"DotMulti"],
+				fmt.Sprintf("%.2f x %.3f (DoT multiplier of ailments from non-crits)", dotMulti, totalFromHit),
+				fmt.Sprintf("+ %.2f x %.3f (DoT multiplier of ailments from crits)", critDotMulti, totalFromCrit),
+				fmt.Sprintf("= %.2f", output[typee+"DotMulti"]),
+			)
+		}
+
+		// weightedDerivation explains the source damage of an ailment, weighted towards the highest rolls by the number of stacks
+		weightedDerivation := func(label string, sourceLabel string, minDamage float64, maxDamage float64, stacks float64, multiLabel string, dotMulti float64, total float64) []string {
+			return []string{
+				label,
+				fmt.Sprintf("(%.2f + (%.2f - %.2f) (min %s + (max %s - min %s)", minDamage, maxDamage, minDamage, sourceLabel, sourceLabel, sourceLabel),
+				fmt.Sprintf("/ 2^(1 / (%.2f + 1))) (/ 2^(1 / (stack potential + 1)))", stacks),
+				fmt.Sprintf("* %.2f (%s)", dotMulti, multiLabel),
+				fmt.Sprintf("= %.2f", total),
+			}
+		}
+
+		// Calculate bleeding chance and damage
+		if canDeal[data.DamageTypePhysical] && (output["BleedChanceOnHit"]+output["BleedChanceOnCrit"]) > 0 {
+			dotCfg := ailmentDotCfg(skillCfg, cfg, mod.KeywordFlagBleed|mod.KeywordFlagPhysicalDot)
 			if strings.Contains(pass.Label, "Off Hand") {
 				activeSkill.OHBleedCfg = dotCfg
 			} else {
@@ -3062,22 +3034,21 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 
 			sourceHitDmg := float64(0)
 			sourceCritDmg := float64(0)
-			/*
-				TODO Breakdown
-				if breakdown then
-					breakdown.BleedPhysical = { damageTypes = { } }
-				end
-			*/
+			if breakdown != nil {
+				breakdown.Set("BleedPhysical", 0)
+			}
 
 			// For bleeds we will be using a weighted average calculation
-
 			configStacks := enemyDB.Sum("BASE", nil, "Multiplier:BleedStacks")
 			maxStacks := skillModList.Sum("BASE", cfg, "BleedStacksMax")
-			if skillModList.Override(cfg, "BleedStacksMax") != nil {
-				maxStacks = skillModList.Override(cfg, "BleedStacksMax").(float64)
+			if override, ok := skillModList.Override(cfg, "BleedStacksMax").(float64); ok {
+				maxStacks = override
 			}
 			globalOutput["BleedStacksMax"] = maxStacks
-			durationBase := utils.Ternary(utils.Has(skillData, "bleedDurationIsSkillDuration"), skillData["duration"].(float64), data.BleedDurationBase)
+			durationBase := float64(data.BleedDurationBase)
+			if skillDuration, ok := skillData["duration"].(float64); ok && utils.Has(skillData, "bleedDurationIsSkillDuration") {
+				durationBase = skillDuration
+			}
 
 			names := []string{"EnemyBleedDuration", "SkillAndDamagingAilmentDuration"}
 			if utils.Has(skillData, "bleedIsSkillEffect") {
@@ -3089,74 +3060,57 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 			bleedStacks := (output["HitChance"] / 100) * (globalOutput["BleedDuration"] / output["Time"]) / maxStacks
 			bleedStacks = utils.Ternary(configStacks > 0, min(bleedStacks, configStacks/maxStacks), bleedStacks)
 			globalOutput["BleedStackPotential"] = bleedStacks
-			/*
-				TODO Breakdown
-				if globalBreakdown then
-					globalBreakdown.BleedStackPotential = {
-						s_format(colorCodes.CUSTOM.."NOTE: Calculation uses new Weighted Avg Ailment formula"),
-						s_format(""),
-						s_format("%.2f ^8(chance to hit)", output.HitChance / 100),
-						s_format("* (%.2f / %.2f) ^8(BleedDuration / Attack Time)", globalOutput.BleedDuration, output.Time),
-						s_format("/ %d ^8(max number of stacks)", maxStacks),
-						s_format("= %.2f", globalOutput.BleedStackPotential),
-					}
-				end
-			*/
+			if globalBreakdown != nil {
+				globalBreakdown.Set("BleedStackPotential", bleedStacks,
+					"NOTE: Calculation uses new Weighted Avg Ailment formula",
+					"",
+					fmt.Sprintf("%.2f (chance to hit)", output["HitChance"]/100),
+					fmt.Sprintf("* (%.2f / %.2f) (BleedDuration / Attack Time)", globalOutput["BleedDuration"], output["Time"]),
+					fmt.Sprintf("/ %.0f (max number of stacks)", maxStacks),
+					fmt.Sprintf("= %.2f", bleedStacks),
+				)
+			}
 
-			for _, sub_pass := range []int{1, 2} {
-				if skillModList.Flag(dotCfg, "AilmentsAreNeverFromCrit") || sub_pass == 1 {
+			for _, subPass := range []int{1, 2} {
+				if skillModList.Flag(dotCfg, "AilmentsAreNeverFromCrit") || subPass == 1 {
 					dotCfg.SkillCond["CriticalStrike"] = false
 				} else {
 					dotCfg.SkillCond["CriticalStrike"] = true
 				}
 
-				// TODO Breakdown
-				// sub_pass == 1 && breakdown && breakdown.BleedPhysical
-				output["BleedPhysicalMin"], output["BleedPhysicalMax"] = calcAilmentSourceDamage(activeSkill, output, dotCfg, nil, "Physical", 0)
+				var sourceBreakdown *StatBreakdown
+				if subPass == 1 && breakdown != nil {
+					sourceBreakdown = breakdown.Stat("BleedPhysical")
+				}
+				output["BleedPhysicalMin"], output["BleedPhysicalMax"] = calcAilmentSourceDamage(activeSkill, output, dotCfg, sourceBreakdown, data.DamageTypePhysical, 0)
 
-				if sub_pass == 2 {
-					output["CritBleedDotMulti"] = 1 + skillModList.Sum("BASE", dotCfg, "DotMultiplier", "PhysicalDotMultiplier")/100
-					sourceCritDmg = output["BleedPhysicalMin"] + output["BleedPhysicalMax"] - output["BleedPhysicalMin"]/math.Pow(2, float64(1)/(bleedStacks+1))*output["CritBleedDotMulti"]
+				dotMulti := 1 + skillModList.Sum("BASE", dotCfg, "DotMultiplier", "PhysicalDotMultiplier")/100
+				sourceDmg := (output["BleedPhysicalMin"] + (output["BleedPhysicalMax"]-output["BleedPhysicalMin"])/math.Pow(2, 1/(bleedStacks+1))) * dotMulti
+				if subPass == 2 {
+					output["CritBleedDotMulti"] = dotMulti
+					sourceCritDmg = sourceDmg
 				} else {
-					output["BleedDotMulti"] = 1 + skillModList.Sum("BASE", dotCfg, "DotMultiplier", "PhysicalDotMultiplier")/100
-					sourceHitDmg = output["BleedPhysicalMin"] + output["BleedPhysicalMax"] - output["BleedPhysicalMin"]/math.Pow(2, float64(1)/(bleedStacks+1))*output["CritBleedDotMulti"]
+					output["BleedDotMulti"] = dotMulti
+					sourceHitDmg = sourceDmg
 				}
 			}
 
-			/*
-				TODO Breakdown
-				if globalBreakdown then
-					if sourceHitDmg == sourceCritDmg then
-						globalBreakdown.BleedDPS = {
-							s_format(colorCodes.CUSTOM.."NOTE: Calculation uses new Weighted Avg Ailment formula"),
-							s_format(""),
-							s_format("Dmg Derivation:"),
-							s_format("(%.2f + (%.2f - %.2f) ^8(min source physical + (max source physical - min source physical)", output.BleedPhysicalMin, output.BleedPhysicalMax, output.BleedPhysicalMin),
-							s_format("/ 2^(1 / (%.2f + 1))) ^8(/ 2^(1 / (stack potential + 1)))", bleedStacks),
-							s_format("* %.2f ^8(Bleed DoT Multi)", output.BleedDotMulti),
-							s_format("= %.2f", sourceHitDmg),
-						}
-					else
-						globalBreakdown.BleedDPS = {
-							s_format(colorCodes.CUSTOM.."NOTE: Calculation uses new Weighted Avg Ailment formula"),
-							s_format(""),
-							s_format("Non-Crit Dmg Derivation:"),
-							s_format("(%.2f + (%.2f - %.2f) ^8(min source physical + (max source physical - min source physical)", output.BleedPhysicalMin, output.BleedPhysicalMax, output.BleedPhysicalMin),
-							s_format("/ 2^(1 / (%.2f + 1))) ^8(/ 2^(1 / (stack potential + 1)))", bleedStacks),
-							s_format("* %.2f ^8(Bleed DoT Multi for Non-Crit)", output.BleedDotMulti),
-							s_format("= %.2f", sourceHitDmg),
-							s_format(""),
-							s_format("Crit Dmg Derivation:"),
-							s_format("(%.2f + (%.2f - %.2f) ^8(min source physical + (max source physical - min source physical)", output.BleedPhysicalMin, output.BleedPhysicalMax, output.BleedPhysicalMin),
-							s_format("/ 2^(1 / (%.2f + 1))) ^8(/ 2^(1 / (stack potential + 1)))", bleedStacks),
-							s_format("* %.2f ^8(Bleed DoT Multi for Crit)", output.CritBleedDotMulti),
-							s_format("= %.2f", sourceCritDmg),
-						}
-					end
-				end
-			*/
+			if globalBreakdown != nil {
+				lines := []string{"NOTE: Calculation uses new Weighted Avg Ailment formula", ""}
+				if sourceHitDmg == sourceCritDmg {
+					lines = append(lines, weightedDerivation("Dmg Derivation:", "source physical", output["BleedPhysicalMin"], output["BleedPhysicalMax"], bleedStacks, "Bleed DoT Multi", output["BleedDotMulti"], sourceHitDmg)...)
+				} else {
+					lines = append(lines, weightedDerivation("Non-Crit Dmg Derivation:", "source physical", output["BleedPhysicalMin"], output["BleedPhysicalMax"], bleedStacks, "Bleed DoT Multi for Non-Crit", output["BleedDotMulti"], sourceHitDmg)...)
+					lines = append(lines, "")
+					lines = append(lines, weightedDerivation("Crit Dmg Derivation:", "source physical", output["BleedPhysicalMin"], output["BleedPhysicalMax"], bleedStacks, "Bleed DoT Multi for Crit", output["CritBleedDotMulti"], sourceCritDmg)...)
+				}
+				globalBreakdown.Set("BleedDPS", 0, lines...)
+			}
 
-			basePercent := utils.Ternary(utils.Has(skillData, "bleedBasePercent"), skillData["bleedBasePercent"].(float64), data.BleedPercentBase)
+			basePercent := float64(data.BleedPercentBase)
+			if skillBasePercent, ok := skillData["bleedBasePercent"].(float64); ok {
+				basePercent = skillBasePercent
+			}
 			baseVal := calcAilmentDamage("Bleed", sourceHitDmg, sourceCritDmg) * basePercent / 100 * output["RuthlessBlowBleedEffect"] * output["FistOfWarAilmentEffect"] * globalOutput["AilmentWarcryEffect"]
 			if baseVal > 0 {
 				skillFlags[SkillFlagBleed] = true
@@ -3168,12 +3122,9 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 					takenMore := enemyDB.More(dotCfg, "DamageTaken", "DamageTakenOverTime", "PhysicalDamageTaken", "PhysicalDamageTakenOverTime")
 					effMult = (1 - resist/100) * (1 + takenInc/100) * takenMore
 					globalOutput["BleedEffMult"] = effMult
-					/*
-						TODO Breakdown
-						if breakdown and effMult ~= 1 then
-							globalBreakdown.BleedEffMult = breakdown.effMult("Physical", resist, 0, takenInc, effMult, takenMore)
-						end
-					*/
+					if breakdown != nil && effMult != 1 {
+						globalBreakdown.effMult("BleedEffMult", data.DamageTypePhysical, resist, takenInc, takenMore, effMult)
+					}
 				}
 
 				effectMod := CalcMod(skillModList, dotCfg, "AilmentEffect")
@@ -3184,626 +3135,577 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 				// reset bleed stacks to actual number doing damage after weighted avg DPS calculation is done
 				globalOutput["BleedStacks"] = bleedStacks
 				globalOutput["BleedDamage"] = output["BaseBleedDPS"] * globalOutput["BleedDuration"]
-				/*
-					if breakdown then
-						if output.CritBleedDotMulti and (output.CritBleedDotMulti ~= output.BleedDotMulti) then
-							local chanceFromHit = output.BleedChanceOnHit / 100 * (1 - globalOutput.CritChance / 100)
-							local chanceFromCrit = output.BleedChanceOnCrit / 100 * output.CritChance / 100
-							local totalFromHit = chanceFromHit / (chanceFromHit + chanceFromCrit)
-							local totalFromCrit = chanceFromCrit / (chanceFromHit + chanceFromCrit)
-							breakdown.BleedDotMulti = breakdown.critDot(output.BleedDotMulti, output.CritBleedDotMulti, totalFromHit, totalFromCrit)
-							output.BleedDotMulti = (output.BleedDotMulti * totalFromHit) + (output.CritBleedDotMulti * totalFromCrit)
-						end
-						t_insert(breakdown.BleedDPS, s_format("x %.2f ^8(bleed deals %d%% per second)", basePercent/100, basePercent))
-						if effectMod ~= 1 then
-							t_insert(breakdown.BleedDPS, s_format("x %.2f ^8(ailment effect modifier)", effectMod))
-						end
-						if output.RuthlessBlowBleedEffect ~= 1 then
-							t_insert(breakdown.BleedDPS, s_format("x %.2f ^8(ruthless blow effect modifier)", output.RuthlessBlowBleedEffect))
-						end
-						if output.FistOfWarAilmentEffect ~= 1 then
-							t_insert(breakdown.BleedDPS, s_format("x %.2f ^8(fist of war effect modifier)", output.FistOfWarAilmentEffect))
-						end
-						if globalOutput.AilmentWarcryEffect > 1 then
-							t_insert(breakdown.BleedDPS, s_format("x %.2f ^8(combined ailment warcry effect modifier)", globalOutput.AilmentWarcryEffect))
-						end
-						t_insert(breakdown.BleedDPS, s_format("= %.1f", baseVal))
-						breakdown.multiChain(breakdown.BleedDPS, {
-							label = "Bleed DPS:",
-							base = s_format("%.1f ^8(total damage per second)", baseVal),
-							{ "%.2f ^8(ailment effect modifier)", effectMod },
-							{ "%.2f ^8(damage rate modifier)", rateMod },
-							{ "%.3f ^8(effective DPS modifier)", effMult },
-							{ "%d ^8(bleed stacks)", globalOutput.BleedStacks },
-							{ "%.3f ^8(bleed chance based on chance to hit each second)", chanceToHitInOneSecInterval },
-							total = s_format("= %.1f ^8per second", output.BleedDPS),
-						})
-						if globalOutput.BleedDuration ~= durationBase then
-							globalBreakdown.BleedDuration = {
-								s_format("%.2fs ^8(base duration)", durationBase)
-							}
-							if durationMod ~= 1 then
-								t_insert(globalBreakdown.BleedDuration, s_format("x %.2f ^8(duration modifier)", durationMod))
-							end
-							if rateMod ~= 1 then
-								t_insert(globalBreakdown.BleedDuration, s_format("/ %.2f ^8(damage rate modifier)", rateMod))
-							end
-							if debuffDurationMult ~= 1 then
-								t_insert(globalBreakdown.BleedDuration, s_format("/ %.2f ^8(debuff expires slower/faster)", 1 / debuffDurationMult))
-							end
-							t_insert(globalBreakdown.BleedDuration, s_format("= %.2fs", globalOutput.BleedDuration))
-						end
-					end
-				*/
+				if breakdown != nil {
+					weightDotMulti("Bleed")
+
+					s := breakdown.Stat("BleedDPS")
+					s.Total = output["BleedDPS"]
+					s.line("x %.2f (bleed deals %.0f%% per second)", basePercent/100, basePercent)
+					if effectMod != 1 {
+						s.line("x %.2f (ailment effect modifier)", effectMod)
+					}
+					if output["RuthlessBlowBleedEffect"] != 1 {
+						s.line("x %.2f (ruthless blow effect modifier)", output["RuthlessBlowBleedEffect"])
+					}
+					if output["FistOfWarAilmentEffect"] != 1 {
+						s.line("x %.2f (fist of war effect modifier)", output["FistOfWarAilmentEffect"])
+					}
+					if globalOutput["AilmentWarcryEffect"] > 1 {
+						s.line("x %.2f (combined ailment warcry effect modifier)", globalOutput["AilmentWarcryEffect"])
+					}
+					s.line("= %.1f", baseVal)
+					s.multiChain("Bleed DPS:", fmt.Sprintf("%.1f (total damage per second)", baseVal), fmt.Sprintf("= %.1f per second", output["BleedDPS"]),
+						chainFactor{"%.2f (ailment effect modifier)", effectMod},
+						chainFactor{"%.2f (damage rate modifier)", rateMod},
+						chainFactor{"%.3f (effective DPS modifier)", effMult},
+						chainFactor{"%.2f (bleed stacks)", globalOutput["BleedStacks"]},
+						chainFactor{"%.3f (bleed chance based on chance to hit each second)", chanceToHitInOneSecInterval},
+					)
+
+					ailmentDuration("BleedDuration", durationBase, durationMod, rateMod, "damage rate modifier")
+				}
 			}
 		}
-		/*
-			TODO Calculate poison chance and damage
-			if canDeal.Chaos and (output.PoisonChanceOnHit + output.PoisonChanceOnCrit + output.ChaosPoisonChance) > 0 then
-				activeSkill[pass.label ~= "Off Hand" and "poisonCfg" or "OHpoisonCfg"] = {
-					skillName = skillCfg.skillName,
-					skillPart = skillCfg.skillPart,
-					skillTypes = skillCfg.skillTypes,
-					slotName = skillCfg.slotName,
-					flags = bor(ModFlag.Dot, ModFlag.Ailment, band(cfg.flags, ModFlag.WeaponMask), band(cfg.flags, ModFlag.Melee) ~= 0 and ModFlag.MeleeHit or 0),
-					keywordFlags = bor(band(cfg.keywordFlags, bnot(KeywordFlag.Hit)), KeywordFlag.Poison, KeywordFlag.Ailment, KeywordFlag.ChaosDot),
-					skillCond = setmetatable({["CriticalStrike"] = true }, { __index = function(table, key) return skillCfg.skillCond[key] or cfg.skillCond[key] end } ),
-					skillDist = skillCfg.skillDist,
+
+		// Calculate poison chance and damage
+		if canDeal[data.DamageTypeChaos] && (output["PoisonChanceOnHit"]+output["PoisonChanceOnCrit"]+output["ChaosPoisonChance"]) > 0 {
+			dotCfg := ailmentDotCfg(skillCfg, cfg, mod.KeywordFlagPoison|mod.KeywordFlagChaosDot)
+			if strings.Contains(pass.Label, "Off Hand") {
+				activeSkill.OHPoisonCfg = dotCfg
+			} else {
+				activeSkill.PoisonCfg = dotCfg
+			}
+
+			sourceHitDmg := float64(0)
+			sourceCritDmg := float64(0)
+			if breakdown != nil {
+				for _, damageType := range dmgTypeList {
+					breakdown.Set("Poison"+string(damageType), 0)
 				}
-				local dotCfg = pass.label ~= "Off Hand" and activeSkill.poisonCfg or activeSkill.OHpoisonCfg
-				local sourceHitDmg, sourceCritDmg
-				if breakdown then
-					breakdown.PoisonPhysical = { damageTypes = { } }
-					breakdown.PoisonLightning = { damageTypes = { } }
-					breakdown.PoisonCold = { damageTypes = { } }
-					breakdown.PoisonFire = { damageTypes = { } }
-					breakdown.PoisonChaos = { damageTypes = { } }
-				end
-				for sub_pass = 1, 2 do
-					if skillModList:Flag(dotCfg, "AilmentsAreNeverFromCrit") or sub_pass == 1 then
-						dotCfg.skillCond["CriticalStrike"] = false
-					else
-						dotCfg.skillCond["CriticalStrike"] = true
-					end
-					local totalMin, totalMax = 0, 0
-					do
-						local min, max = calcAilmentSourceDamage(activeSkill, output, dotCfg, sub_pass == 1 and breakdown and breakdown.PoisonChaos, "Chaos", 0)
-						output.PoisonChaosMin = min
-						output.PoisonChaosMax = max
-						totalMin = totalMin + min
-						totalMax = totalMax + max
-					end
-					local nonChaosMult = 1
-					if output.ChaosPoisonChance > 0 and output.PoisonChaosMax > 0 then
-						-- Additional chance for chaos
-						local chance = (sub_pass == 2) and "PoisonChanceOnCrit" or "PoisonChanceOnHit"
-						local chaosChance = m_min(100, output[chance] + output.ChaosPoisonChance)
-						nonChaosMult = output[chance] / chaosChance
-						output[chance] = chaosChance
-					end
-					if canDeal.Lightning and skillModList:Flag(cfg, "LightningCanPoison") then
-						local min, max = calcAilmentSourceDamage(activeSkill, output, dotCfg, sub_pass == 1 and breakdown and breakdown.PoisonLightning, "Lightning", dmgTypeFlags.Chaos)
-						output.PoisonLightningMin = min
-						output.PoisonLightningMax = max
-						totalMin = totalMin + min * nonChaosMult
-						totalMax = totalMax + max * nonChaosMult
-					end
-					if canDeal.Cold and skillModList:Flag(cfg, "ColdCanPoison") then
-						local min, max = calcAilmentSourceDamage(activeSkill, output, dotCfg, sub_pass == 1 and breakdown and breakdown.PoisonCold, "Cold", dmgTypeFlags.Chaos)
-						output.PoisonColdMin = min
-						output.PoisonColdMax = max
-						totalMin = totalMin + min * nonChaosMult
-						totalMax = totalMax + max * nonChaosMult
-					end
-					if canDeal.Fire and skillModList:Flag(cfg, "FireCanPoison") then
-						local min, max = calcAilmentSourceDamage(activeSkill, output, dotCfg, sub_pass == 1 and breakdown and breakdown.PoisonFire, "Fire", dmgTypeFlags.Chaos)
-						output.PoisonFireMin = min
-						output.PoisonFireMax = max
-						totalMin = totalMin + min * nonChaosMult
-						totalMax = totalMax + max * nonChaosMult
-					end
-					if canDeal.Physical then
-						local min, max = calcAilmentSourceDamage(activeSkill, output, dotCfg, sub_pass == 1 and breakdown and breakdown.PoisonPhysical, "Physical", dmgTypeFlags.Chaos)
-						output.PoisonPhysicalMin = min
-						output.PoisonPhysicalMax = max
-						totalMin = totalMin + min * nonChaosMult
-						totalMax = totalMax + max * nonChaosMult
-					end
-					if sub_pass == 2 then
-						output.CritPoisonDotMulti = 1 + skillModList:Sum("BASE", dotCfg, "DotMultiplier", "ChaosDotMultiplier") / 100
-						sourceCritDmg = (totalMin + totalMax) / 2 * output.CritPoisonDotMulti
-					else
-						output.PoisonDotMulti = 1 + skillModList:Sum("BASE", dotCfg, "DotMultiplier", "ChaosDotMultiplier") / 100
-						sourceHitDmg = (totalMin + totalMax) / 2 * output.PoisonDotMulti
-					end
-				end
-				if globalBreakdown then
-					globalBreakdown.PoisonDPS = {
-						s_format("Ailment mode: %s ^8(can be changed in the Configuration tab)", igniteMode == "CRIT" and "Crits Only" or "Average Damage")
+			}
+
+			for _, subPass := range []int{1, 2} {
+				if skillModList.Flag(dotCfg, "AilmentsAreNeverFromCrit") || subPass == 1 {
+					dotCfg.SkillCond["CriticalStrike"] = false
+				} else {
+					dotCfg.SkillCond["CriticalStrike"] = true
+				}
+
+				totalMin, totalMax := float64(0), float64(0)
+				addSource := func(damageType data.DamageType, typeFlags int, mult float64) {
+					var sourceBreakdown *StatBreakdown
+					if subPass == 1 && breakdown != nil {
+						sourceBreakdown = breakdown.Stat("Poison" + string(damageType))
 					}
-				end
-				local baseVal = calcAilmentDamage("Poison", sourceHitDmg, sourceCritDmg) * data.misc.PoisonPercentBase * output.FistOfWarAilmentEffect * globalOutput.AilmentWarcryEffect
-				if baseVal > 0 then
-					skillFlags.poison = true
-					skillFlags.duration = true
-					local effMult = 1
-					if env.mode_effective then
-						local resist = m_min(enemyDB:Sum("BASE", nil, "ChaosResist") * calcLib.mod(enemyDB, nil, "ChaosResist"), data.misc.EnemyMaxResist)
-						local takenInc = enemyDB:Sum("INC", dotCfg, "DamageTaken", "DamageTakenOverTime", "ChaosDamageTaken", "ChaosDamageTakenOverTime")
-						local takenMore = enemyDB:More(dotCfg, "DamageTaken", "DamageTakenOverTime", "ChaosDamageTaken", "ChaosDamageTakenOverTime")
-						effMult = (1 - resist / 100) * (1 + takenInc / 100) * takenMore
-						globalOutput["PoisonEffMult"] = effMult
-						if breakdown and effMult ~= 1 then
-							globalBreakdown.PoisonEffMult = breakdown.effMult("Chaos", resist, 0, takenInc, effMult, takenMore)
-						end
-					end
-					local effectMod = calcLib.mod(skillModList, dotCfg, "AilmentEffect")
-					local rateMod = calcLib.mod(skillModList, cfg, "PoisonFaster") + enemyDB:Sum("INC", nil, "SelfPoisonFaster")  / 100
-					output.PoisonDPS = baseVal * effectMod * rateMod * effMult
-					local durationBase
-					if skillData.poisonDurationIsSkillDuration then
-						durationBase = skillData.duration
-					else
-						durationBase = data.misc.PoisonDurationBase
-					end
-					local durationMod = calcLib.mod(skillModList, dotCfg, "EnemyPoisonDuration", "SkillAndDamagingAilmentDuration", skillData.poisonIsSkillEffect and "Duration" or nil) * calcLib.mod(enemyDB, nil, "SelfPoisonDuration")
-					globalOutput.PoisonDuration = durationBase * durationMod / rateMod * debuffDurationMult
-					output.PoisonDamage = output.PoisonDPS * globalOutput.PoisonDuration
-					if skillData.showAverage then
-						output.TotalPoisonAverageDamage = output.HitChance / 100 * output.PoisonChance / 100 * output.PoisonDamage
-						output.TotalPoisonDPS = output.PoisonDPS
-					else
-						output.TotalPoisonStacks = output.HitChance / 100 * output.PoisonChance / 100 * globalOutput.PoisonDuration * (globalOutput.HitSpeed or globalOutput.Speed) * (skillData.dpsMultiplier or 1) * (skillData.stackMultiplier or 1) * quantityMultiplier
-						output.TotalPoisonDPS = output.PoisonDPS * output.TotalPoisonStacks
-					end
-					if breakdown then
-						if output.CritPoisonDotMulti and (output.CritPoisonDotMulti ~= output.PoisonDotMulti) then
-							local chanceFromHit = output.PoisonChanceOnHit / 100 * (1 - globalOutput.CritChance / 100)
-							local chanceFromCrit = output.PoisonChanceOnCrit / 100 * globalOutput.CritChance / 100
-							local totalFromHit = chanceFromHit / (chanceFromHit + chanceFromCrit)
-							local totalFromCrit = chanceFromCrit / (chanceFromHit + chanceFromCrit)
-							breakdown.PoisonDotMulti = breakdown.critDot(output.PoisonDotMulti, output.CritPoisonDotMulti, totalFromHit, totalFromCrit)
-							output.PoisonDotMulti = (output.PoisonDotMulti * totalFromHit) + (output.CritPoisonDotMulti * totalFromCrit)
-						end
-						t_insert(breakdown.PoisonDPS, "x 0.30 ^8(poison deals 30% per second)")
-						t_insert(breakdown.PoisonDPS, s_format("= %.1f", baseVal, 1))
-						breakdown.multiChain(breakdown.PoisonDPS, {
-							label = "Poison DPS:",
-							base = s_format("%.1f ^8(total damage per second)", baseVal),
-							{ "%.2f ^8(ailment effect modifier)", effectMod },
-							{ "%.2f ^8(damage rate modifier)", rateMod },
-							{ "%.3f ^8(effective DPS modifier)", effMult },
-							total = s_format("= %.1f ^8per second", output.PoisonDPS),
-						})
-						if globalOutput.PoisonDuration ~= 2 then
-							globalBreakdown.PoisonDuration = {
-								s_format("%.2fs ^8(base duration)", durationBase)
-							}
-							if durationMod ~= 1 then
-								t_insert(globalBreakdown.PoisonDuration, s_format("x %.2f ^8(duration modifier)", durationMod))
-							end
-							if rateMod ~= 1 then
-								t_insert(globalBreakdown.PoisonDuration, s_format("/ %.2f ^8(damage rate modifier)", rateMod))
-							end
-							if debuffDurationMult ~= 1 then
-								t_insert(globalBreakdown.PoisonDuration, s_format("/ %.2f ^8(debuff expires slower/faster)", 1 / debuffDurationMult))
-							end
-							t_insert(globalBreakdown.PoisonDuration, s_format("= %.2fs", globalOutput.PoisonDuration))
-						end
-						breakdown.PoisonDamage = { }
-						if isAttack then
-							t_insert(breakdown.PoisonDamage, pass.label..":")
-						end
-						t_insert(breakdown.PoisonDamage, s_format("%.1f ^8(damage per second)", output.PoisonDPS))
-						t_insert(breakdown.PoisonDamage, s_format("x %.2fs ^8(poison duration)", globalOutput.PoisonDuration))
-						t_insert(breakdown.PoisonDamage, s_format("= %.1f ^8damage per poison stack", output.PoisonDamage))
-						if not skillData.showAverage then
-							breakdown.TotalPoisonStacks = { }
-							if isAttack then
-								t_insert(breakdown.TotalPoisonStacks, pass.label..":")
-							end
-							breakdown.multiChain(breakdown.TotalPoisonStacks, {
-								base = s_format("%.2fs ^8(poison duration)", globalOutput.PoisonDuration),
-								{ "%.2f ^8(poison chance)", output.PoisonChance / 100 },
-								{ "%.2f ^8(hit chance)", output.HitChance / 100 },
-								{ "%.2f ^8(hits per second)", globalOutput.HitSpeed or globalOutput.Speed },
-								{ "%g ^8(dps multiplier for this skill)", skillData.dpsMultiplier or 1 },
-								{ "%g ^8(stack multiplier for this skill)", skillData.stackMultiplier or 1 },
-								{ "%g ^8(quantity multiplier for this skill)", quantityMultiplier },
-								total = s_format("= %.1f", output.TotalPoisonStacks),
-							})
-						end
-					end
-				end
-			end
-		*/
-		/*
-			TODO Calculate ignite chance and damage
-			if canDeal.Fire and (output.IgniteChanceOnHit + output.IgniteChanceOnCrit) > 0 then
-				activeSkill[pass.label ~= "Off Hand" and "igniteCfg" or "OHigniteCfg"] = {
-					skillName = skillCfg.skillName,
-					skillPart = skillCfg.skillPart,
-					skillTypes = skillCfg.skillTypes,
-					slotName = skillCfg.slotName,
-					flags = bor(ModFlag.Dot, ModFlag.Ailment, band(cfg.flags, ModFlag.WeaponMask), band(cfg.flags, ModFlag.Melee) ~= 0 and ModFlag.MeleeHit or 0),
-					keywordFlags = bor(band(cfg.keywordFlags, bnot(KeywordFlag.Hit)), KeywordFlag.Ignite, KeywordFlag.Ailment, KeywordFlag.FireDot),
-					skillCond = setmetatable({["CriticalStrike"] = true }, { __index = function(table, key) return skillCfg.skillCond[key] or cfg.skillCond[key] end } ),
-					skillDist = skillCfg.skillDist,
+					minDamage, maxDamage := calcAilmentSourceDamage(activeSkill, output, dotCfg, sourceBreakdown, damageType, typeFlags)
+					output["Poison"+string(damageType)+"Min"] = minDamage
+					output["Poison"+string(damageType)+"Max"] = maxDamage
+					totalMin += minDamage * mult
+					totalMax += maxDamage * mult
 				}
-				local dotCfg = pass.label ~= "Off Hand" and activeSkill.igniteCfg or activeSkill.OHigniteCfg
-				local sourceHitDmg, sourceCritDmg
-				if breakdown then
-					breakdown.IgnitePhysical = { damageTypes = { } }
-					breakdown.IgniteLightning = { damageTypes = { } }
-					breakdown.IgniteCold = { damageTypes = { } }
-					breakdown.IgniteFire = { damageTypes = { } }
-					breakdown.IgniteChaos = { damageTypes = { } }
-				end
 
-				-- For ignites we will be using a weighted average calculation
-				local maxStacks = 1
-				if skillFlags.igniteCanStack then
-					maxStacks = maxStacks + skillModList:Sum("BASE", cfg, "IgniteStacks")
-				end
-				globalOutput.IgniteStacksMax = maxStacks
-
-				local rateMod = (calcLib.mod(skillModList, cfg, "IgniteBurnFaster") + enemyDB:Sum("INC", nil, "SelfIgniteBurnFaster") / 100)  / calcLib.mod(skillModList, cfg, "IgniteBurnSlower")
-				local durationBase = data.misc.IgniteDurationBase
-				local durationMod = m_max(calcLib.mod(skillModList, dotCfg, "EnemyIgniteDuration", "SkillAndDamagingAilmentDuration") * calcLib.mod(enemyDB, nil, "SelfIgniteDuration"), 0)
-				globalOutput.IgniteDuration = durationBase * durationMod / rateMod * debuffDurationMult
-				globalOutput.IgniteDuration = globalOutput.IgniteDuration > data.misc.IgniteMinDuration and globalOutput.IgniteDuration or 0
-				local igniteStacks = 1
-				if not skillData.triggeredOnDeath then
-					igniteStacks = (globalOutput.IgniteDuration / output.Time) / maxStacks
-				end
-				globalOutput.IgniteStackPotential = igniteStacks
-				if globalBreakdown then
-					globalBreakdown.IgniteStackPotential = {
-						s_format(colorCodes.CUSTOM.."NOTE: Calculation uses new Weighted Avg Ailment formula"),
-						s_format(""),
-						s_format("(%.2f / %.2f) ^8(IgniteDuration / Cast Time)", globalOutput.IgniteDuration, output.Time),
-						s_format("/ %d ^8(max number of stacks)", maxStacks),
-						s_format("= %.2f", globalOutput.IgniteStackPotential),
+				addSource(data.DamageTypeChaos, 0, 1)
+				nonChaosMult := float64(1)
+				if output["ChaosPoisonChance"] > 0 && output["PoisonChaosMax"] > 0 {
+					// Additional chance for chaos
+					chance := utils.Ternary(subPass == 2, "PoisonChanceOnCrit", "PoisonChanceOnHit")
+					chaosChance := min(100, output[chance]+output["ChaosPoisonChance"])
+					nonChaosMult = output[chance] / chaosChance
+					output[chance] = chaosChance
+				}
+
+				chaosFlags := data.DamageTypeFlags[data.DamageTypeChaos]
+				for _, damageType := range []data.DamageType{data.DamageTypeLightning, data.DamageTypeCold, data.DamageTypeFire} {
+					if canDeal[damageType] && skillModList.Flag(cfg, string(damageType)+"CanPoison") {
+						addSource(damageType, chaosFlags, nonChaosMult)
 					}
-				end
+				}
+				if canDeal[data.DamageTypePhysical] {
+					addSource(data.DamageTypePhysical, chaosFlags, nonChaosMult)
+				}
 
-				for sub_pass = 1, 2 do
-					if skillModList:Flag(dotCfg, "AilmentsAreNeverFromCrit") or sub_pass == 1 then
-						dotCfg.skillCond["CriticalStrike"] = false
-					else
-						dotCfg.skillCond["CriticalStrike"] = true
-					end
-					local totalMin, totalMax = 0, 0
-					if canDeal.Physical and skillModList:Flag(cfg, "PhysicalCanIgnite") then
-						local min, max = calcAilmentSourceDamage(activeSkill, output, dotCfg, sub_pass == 1 and breakdown and breakdown.IgnitePhysical, "Physical", dmgTypeFlags.Fire)
-						output.IgnitePhysicalMin = min
-						output.IgnitePhysicalMax = max
-						totalMin = totalMin + min
-						totalMax = totalMax + max
-					end
-					if canDeal.Lightning and skillModList:Flag(cfg, "LightningCanIgnite") then
-						local min, max = calcAilmentSourceDamage(activeSkill, output, dotCfg, sub_pass == 1 and breakdown and breakdown.IgniteLightning, "Lightning", dmgTypeFlags.Fire)
-						output.IgniteLightningMin = min
-						output.IgniteLightningMax = max
-						totalMin = totalMin + min
-						totalMax = totalMax + max
-					end
-					if canDeal.Cold and skillModList:Flag(cfg, "ColdCanIgnite") then
-						local min, max = calcAilmentSourceDamage(activeSkill, output, dotCfg, sub_pass == 1 and breakdown and breakdown.IgniteCold, "Cold", dmgTypeFlags.Fire)
-						output.IgniteColdMin = min
-						output.IgniteColdMax = max
-						totalMin = totalMin + min
-						totalMax = totalMax + max
-					end
-					if canDeal.Fire and not skillModList:Flag(cfg, "FireCannotIgnite") then
-						local min, max = calcAilmentSourceDamage(activeSkill, output, dotCfg, sub_pass == 1 and breakdown and breakdown.IgniteFire, "Fire", 0)
-						output.IgniteFireMin = min
-						output.IgniteFireMax = max
-						totalMin = totalMin + min
-						totalMax = totalMax + max
-					end
-					if canDeal.Chaos and skillModList:Flag(cfg, "ChaosCanIgnite") then
-						local min, max = calcAilmentSourceDamage(activeSkill, output, dotCfg, sub_pass == 1 and breakdown and breakdown.IgniteChaos, "Chaos", dmgTypeFlags.Fire)
-						output.IgniteChaosMin = min
-						output.IgniteChaosMax = max
-						totalMin = totalMin + min
-						totalMax = totalMax + max
-					end
-					if sub_pass == 2 then
-						output.CritIgniteDotMulti = 1 + skillModList:Sum("BASE", dotCfg, "DotMultiplier", "FireDotMultiplier") / 100
-						sourceCritDmg = (totalMin + (totalMax - totalMin) / m_pow(2, 1 / (igniteStacks + 1))) * output.CritIgniteDotMulti
-					else
-						output.IgniteDotMulti = 1 + skillModList:Sum("BASE", dotCfg, "DotMultiplier", "FireDotMultiplier") / 100
-						sourceHitDmg = (totalMin + (totalMax - totalMin) / m_pow(2, 1 / (igniteStacks + 1))) * output.IgniteDotMulti
-					end
-					output.IgniteTotalMin = totalMin
-					output.IgniteTotalMax = totalMax
-				end
-				if globalBreakdown then
-					if sourceHitDmg == sourceCritDmg then
-						globalBreakdown.IgniteDPS = {
-							s_format(colorCodes.CUSTOM.."NOTE: Calculation uses new Weighted Avg Ailment formula"),
-							s_format(""),
-							s_format("Dmg Derivation:"),
-							s_format("(%.2f + (%.2f - %.2f) ^8(min combined sources + (max combined sources - min combined sources)", output.IgniteTotalMin, output.IgniteTotalMax, output.IgniteTotalMin),
-							s_format("/ 2^(1 / (%.2f + 1))) ^8(/ 2^(1 / (stack potential + 1)))", igniteStacks),
-							s_format("* %.2f ^8(Ignite DoT Multi)", output.IgniteDotMulti),
-							s_format("= %.2f", sourceHitDmg),
+				dotMulti := 1 + skillModList.Sum("BASE", dotCfg, "DotMultiplier", "ChaosDotMultiplier")/100
+				if subPass == 2 {
+					output["CritPoisonDotMulti"] = dotMulti
+					sourceCritDmg = (totalMin + totalMax) / 2 * dotMulti
+				} else {
+					output["PoisonDotMulti"] = dotMulti
+					sourceHitDmg = (totalMin + totalMax) / 2 * dotMulti
+				}
+			}
+
+			if globalBreakdown != nil {
+				globalBreakdown.Set("PoisonDPS", 0, fmt.Sprintf("Ailment mode: %s (can be changed in the Configuration tab)", ailmentMode))
+			}
+
+			baseVal := calcAilmentDamage("Poison", sourceHitDmg, sourceCritDmg) * data.PoisonPercentBase * output["FistOfWarAilmentEffect"] * globalOutput["AilmentWarcryEffect"]
+			if baseVal > 0 {
+				skillFlags[SkillFlagPoison] = true
+				skillFlags[SkillFlagDuration] = true
+				effMult := float64(1)
+				if env.ModeEffective {
+					resist := min(enemyDB.Sum("BASE", nil, "ChaosResist")*CalcMod(enemyDB, nil, "ChaosResist"), data.EnemyMaxResist)
+					takenInc := enemyDB.Sum("INC", dotCfg, "DamageTaken", "DamageTakenOverTime", "ChaosDamageTaken", "ChaosDamageTakenOverTime")
+					takenMore := enemyDB.More(dotCfg, "DamageTaken", "DamageTakenOverTime", "ChaosDamageTaken", "ChaosDamageTakenOverTime")
+					effMult = (1 - resist/100) * (1 + takenInc/100) * takenMore
+					globalOutput["PoisonEffMult"] = effMult
+					if breakdown != nil && effMult != 1 {
+						globalBreakdown.effMult("PoisonEffMult", data.DamageTypeChaos, resist, takenInc, takenMore, effMult)
+					}
+				}
+
+				effectMod := CalcMod(skillModList, dotCfg, "AilmentEffect")
+				rateMod := CalcMod(skillModList, cfg, "PoisonFaster") + enemyDB.Sum("INC", nil, "SelfPoisonFaster")/100
+				output["PoisonDPS"] = baseVal * effectMod * rateMod * effMult
+
+				durationBase := float64(data.PoisonDurationBase)
+				if skillDuration, ok := skillData["duration"].(float64); ok && utils.Has(skillData, "poisonDurationIsSkillDuration") {
+					durationBase = skillDuration
+				}
+				names := []string{"EnemyPoisonDuration", "SkillAndDamagingAilmentDuration"}
+				if utils.Has(skillData, "poisonIsSkillEffect") {
+					names = append(names, "Duration")
+				}
+				durationMod := CalcMod(skillModList, dotCfg, names...) * CalcMod(enemyDB, nil, "SelfPoisonDuration")
+				globalOutput["PoisonDuration"] = durationBase * durationMod / rateMod * debuffDurationMult
+				output["PoisonDamage"] = output["PoisonDPS"] * globalOutput["PoisonDuration"]
+
+				hitSpeed := globalOutput["HitSpeed"]
+				if hitSpeed == 0 {
+					hitSpeed = globalOutput["Speed"]
+				}
+				dpsMultiplier := skillDataNumber(activeSkill, "DpsMultiplier", 1)
+				stackMultiplier := skillDataNumber(activeSkill, "stackMultiplier", 1)
+				if utils.HasTrue(skillData, "ShowAverage") {
+					output["TotalPoisonAverageDamage"] = output["HitChance"] / 100 * output["PoisonChance"] / 100 * output["PoisonDamage"]
+					output["TotalPoisonDPS"] = output["PoisonDPS"]
+				} else {
+					output["TotalPoisonStacks"] = output["HitChance"] / 100 * output["PoisonChance"] / 100 * globalOutput["PoisonDuration"] * hitSpeed * dpsMultiplier * stackMultiplier * quantityMultiplier
+					output["TotalPoisonDPS"] = output["PoisonDPS"] * output["TotalPoisonStacks"]
+				}
+
+				if breakdown != nil {
+					weightDotMulti("Poison")
+
+					s := breakdown.Stat("PoisonDPS")
+					s.Total = output["PoisonDPS"]
+					s.line("x %.2f (poison deals %.0f%% per second)", data.PoisonPercentBase, data.PoisonPercentBase*100)
+					s.line("= %.1f", baseVal)
+					s.multiChain("Poison DPS:", fmt.Sprintf("%.1f (total damage per second)", baseVal), fmt.Sprintf("= %.1f per second", output["PoisonDPS"]),
+						chainFactor{"%.2f (ailment effect modifier)", effectMod},
+						chainFactor{"%.2f (damage rate modifier)", rateMod},
+						chainFactor{"%.3f (effective DPS modifier)", effMult},
+					)
+
+					ailmentDuration("PoisonDuration", durationBase, durationMod, rateMod, "damage rate modifier")
+
+					s = breakdown.Set("PoisonDamage", output["PoisonDamage"])
+					if isAttack {
+						s.line("%s:", pass.Label)
+					}
+					s.line("%.1f (damage per second)", output["PoisonDPS"])
+					s.line("x %.2fs (poison duration)", globalOutput["PoisonDuration"])
+					s.line("= %.1f damage per poison stack", output["PoisonDamage"])
+
+					if !utils.HasTrue(skillData, "ShowAverage") {
+						s = breakdown.Set("TotalPoisonStacks", output["TotalPoisonStacks"])
+						if isAttack {
+							s.line("%s:", pass.Label)
 						}
-					else
-						globalBreakdown.IgniteDPS = {
-							s_format(colorCodes.CUSTOM.."NOTE: Calculation uses new Weighted Avg Ailment formula"),
-							s_format(""),
-							s_format("Non-Crit Dmg Derivation:"),
-							s_format("(%.2f + (%.2f - %.2f) ^8(min combined sources + (max combined sources - min combined sources)", output.IgniteTotalMin, output.IgniteTotalMax, output.IgniteTotalMin),
-							s_format("/ 2^(1 / (%.2f + 1))) ^8(/ 2^(1 / (stack potential + 1)))", igniteStacks),
-							s_format("* %.2f ^8(Ignite DoT Multi for Non-Crit)", output.IgniteDotMulti),
-							s_format("= %.2f", sourceHitDmg),
-							s_format(""),
-							s_format("Crit Dmg Derivation:"),
-							s_format("(%.2f + (%.2f - %.2f) ^8(min combined sources + (max combined sources - min combined sources)", output.IgniteTotalMin, output.IgniteTotalMax, output.IgniteTotalMin),
-							s_format("/ 2^(1 / (%.2f + 1))) ^8(/ 2^(1 / (stack potential + 1)))", igniteStacks),
-							s_format("* %.2f ^8(Ignite DoT Multi for Crit)", output.CritIgniteDotMulti),
-							s_format("= %.2f", sourceCritDmg),
+						s.line("%.2fs (poison duration)", globalOutput["PoisonDuration"])
+						s.line("x %.2f (poison chance)", output["PoisonChance"]/100)
+						s.line("x %.2f (hit chance)", output["HitChance"]/100)
+						s.line("x %.2f (hits per second)", hitSpeed)
+						if dpsMultiplier != 1 {
+							s.line("x %g (dps multiplier for this skill)", dpsMultiplier)
 						}
-					end
-				end
-				local baseVal = calcAilmentDamage("Ignite", sourceHitDmg, sourceCritDmg) * data.misc.IgnitePercentBase * output.FistOfWarAilmentEffect * globalOutput.AilmentWarcryEffect
-				if baseVal > 0 then
-					skillFlags.ignite = true
-					local effMult = 1
-					if env.mode_effective then
-						if skillModList:Flag(cfg, "IgniteToChaos") then
-							local resist = m_min(enemyDB:Sum("BASE", nil, "ChaosResist") * calcLib.mod(enemyDB, nil, "ChaosResist"), data.misc.EnemyMaxResist)
-							local takenInc = enemyDB:Sum("INC", dotCfg, "DamageTaken", "DamageTakenOverTime", "ChaosDamageTaken", "ChaosDamageTakenOverTime")
-							local takenMore = enemyDB:More(dotCfg, "DamageTaken", "DamageTakenOverTime", "ChaosDamageTaken", "ChaosDamageTakenOverTime")
-							effMult = (1 - resist / 100) * (1 + takenInc / 100) * takenMore
-							globalOutput["IgniteEffMult"] = effMult
-							if breakdown and effMult ~= 1 then
-								globalBreakdown.IgniteEffMult = breakdown.effMult("Chaos", resist, 0, takenInc, effMult, takenMore)
-							end
-						else
-							local resist = m_min(enemyDB:Sum("BASE", nil, "FireResist", "ElementalResist") * calcLib.mod(enemyDB, nil, "FireResist", "ElementalResist"), data.misc.EnemyMaxResist)
-							local takenInc = enemyDB:Sum("INC", dotCfg, "DamageTaken", "DamageTakenOverTime", "FireDamageTaken", "FireDamageTakenOverTime", "ElementalDamageTaken")
-							local takenMore = enemyDB:More(dotCfg, "DamageTaken", "DamageTakenOverTime", "FireDamageTaken", "FireDamageTakenOverTime", "ElementalDamageTaken")
-							effMult = (1 - resist / 100) * (1 + takenInc / 100) * takenMore
-							globalOutput["IgniteEffMult"] = effMult
-							if breakdown and effMult ~= 1 then
-								breakdown.IgniteEffMult = breakdown.effMult("Fire", resist, 0, takenInc, effMult, takenMore)
-							end
-						end
-					end
-					local effectMod = calcLib.mod(skillModList, dotCfg, "AilmentEffect")
-					igniteStacks = 1
-					if not skillData.triggeredOnDeath then
-						igniteStacks = m_min(maxStacks, (output.HitChance / 100) * globalOutput.IgniteDuration / output.Time)
-					end
-					output.IgniteDPS = baseVal * effectMod * rateMod * effMult * igniteStacks
-					globalOutput.IgniteDamage = output.IgniteDPS * globalOutput.IgniteDuration
-					if skillFlags.igniteCanStack then
-						output.IgniteDamage = output.IgniteDPS * globalOutput.IgniteDuration
-						output.IgniteStacksMax = maxStacks
-						output.TotalIgniteDPS = output.IgniteDPS
-					end
+						if stackMultiplier != 1 {
+							s.line("x %g (stack multiplier for this skill)", stackMultiplier)
+						}
+						if quantityMultiplier != 1 {
+							s.line("x %g (quantity multiplier for this skill)", quantityMultiplier)
+						}
+						s.line("= %.1f", output["TotalPoisonStacks"])
+					}
+				}
+			}
+		}
 
-					if breakdown then
-						t_insert(breakdown.IgniteDPS, "x 0.9 ^8(ignite deals 90% per second)")
-						t_insert(breakdown.IgniteDPS, s_format("= %.1f", baseVal, 1))
-						breakdown.multiChain(breakdown.IgniteDPS, {
-							label = "Ignite DPS:",
-							base = s_format("%.1f ^8(total damage per second)", baseVal),
-							{ "%.2f ^8(ailment effect modifier)", effectMod },
-							{ "%.2f ^8(burn rate modifier)", rateMod },
-							{ "%.3f ^8(effective DPS modifier)", effMult },
-							{ "%d ^8(ignite stacks)", output.IgniteStacksMax },
-							total = s_format("= %.1f ^8per second", output.IgniteDPS),
-						})
-						if output.CritIgniteDotMulti and (output.CritIgniteDotMulti ~= output.IgniteDotMulti) then
-							local chanceFromHit = output.IgniteChanceOnHit / 100 * (1 - globalOutput.CritChance / 100)
-							local chanceFromCrit = output.IgniteChanceOnCrit / 100 * output.CritChance / 100
-							local totalFromHit = chanceFromHit / (chanceFromHit + chanceFromCrit)
-							local totalFromCrit = chanceFromCrit / (chanceFromHit + chanceFromCrit)
-							breakdown.IgniteDotMulti = breakdown.critDot(output.IgniteDotMulti, output.CritIgniteDotMulti, totalFromHit, totalFromCrit)
-							output.IgniteDotMulti = (output.IgniteDotMulti * totalFromHit) + (output.CritIgniteDotMulti * totalFromCrit)
-						end
-						if skillFlags.igniteCanStack then
-							breakdown.IgniteDamage = { }
-							if isAttack then
-								t_insert(breakdown.IgniteDamage, pass.label..":")
-							end
-							t_insert(breakdown.IgniteDamage, s_format("%.1f ^8(damage per second)", output.IgniteDPS))
-							t_insert(breakdown.IgniteDamage, s_format("x %.2fs ^8(ignite duration)", globalOutput.IgniteDuration))
-							t_insert(breakdown.IgniteDamage, s_format("= %.1f ^8damage per ignite stack", output.IgniteDamage))
-						end
-						if globalOutput.IgniteDuration ~= data.misc.IgniteDurationBase then
-							globalBreakdown.IgniteDuration = {
-								s_format("%.2fs ^8(base duration)", durationBase)
-							}
-							if durationMod ~= 1 then
-								t_insert(globalBreakdown.IgniteDuration, s_format("x %.2f ^8(duration modifier)", durationMod))
-							end
-							if rateMod ~= 1 then
-								t_insert(globalBreakdown.IgniteDuration, s_format("/ %.2f ^8(burn rate modifier)", rateMod))
-							end
-							if debuffDurationMult ~= 1 then
-								t_insert(globalBreakdown.IgniteDuration, s_format("/ %.2f ^8(debuff expires slower/faster)", 1 / debuffDurationMult))
-							end
-							t_insert(globalBreakdown.IgniteDuration, s_format("= %.2fs", globalOutput.IgniteDuration))
-						end
-					end
-				end
-			end
-		*/
-		/*
-			TODO Calculate non-damaging ailments effect and duration modifiers
-			local isBoss = env.configInput["enemyIsBoss"] ~= "None"
-			local enemyBaseLife = data.monsterLifeTable[env.enemyLevel] * enemyDB:More(nil, "Life")
-			local enemyMapLifeMult = 1
-			local enemyMapAilmentMult = 1
-			if env.enemyLevel >= 66 then
-				enemyMapLifeMult = isBoss and data.mapLevelBossLifeMult[env.enemyLevel] or data.mapLevelLifeMult[env.enemyLevel]
-				enemyMapAilmentMult = isBoss and data.mapLevelBossAilmentMult[env.enemyLevel] or enemyMapAilmentMult
-			end
-			local enemyTypeMult = isBoss and 7.68 or 1
-			local enemyThreshold = enemyBaseLife * enemyTypeMult * enemyMapLifeMult * enemyMapAilmentMult * enemyDB:More(nil, "AilmentThreshold")
-
-			local bonechill = output.BonechillEffect or enemyDB:Sum("BASE", nil, "DesiredBonechillEffect")
-			local ailments = {
-				["Chill"] = {
-					effList = { 10, 20 },
-					effect = function(damage, effectMod) return 50 * ((damage / enemyThreshold) ^ 0.4) * effectMod end,
-					thresh = function(damage, value, effectMod) return damage * ((50 * effectMod / value) ^ 2.5) end,
-					ramping = bonechill > 0,
-				},
-				["Shock"] = {
-					effList = { 10, 20, 40 },
-					effect = function(damage, effectMod) return 50 * ((damage / enemyThreshold) ^ 0.4) * effectMod end,
-					thresh = function(damage, value, effectMod) return damage * ((50 * effectMod / value) ^ 2.5) end,
-					ramping = true,
-				},
-				["Scorch"] = {
-					effList = { 5, 10, 20 },
-					effect = function(damage, effectMod) return 50 * ((damage / enemyThreshold) ^ 0.4) * effectMod end,
-					thresh = function(damage, value, effectMod) return damage * ((50 * effectMod / value) ^ 2.5) end,
-					ramping = true,
-				},
-				["Brittle"] = {
-					effList = { 5, 10 },
-					effect = function(damage, effectMod) return 25 * ((damage / enemyThreshold) ^ 0.4) * effectMod end,
-					thresh = function(damage, value, effectMod) return damage * ((25 * effectMod / value) ^ 2.5) end,
-					ramping = true,
-				},
-				["Sap"] = {
-					effList = { 5, 10 },
-					effect = function(damage, effectMod) return (100 / 3) * ((damage / enemyThreshold) ^ 0.4) * effectMod end,
-					thresh = function(damage, value, effectMod) return damage * ((100 / 3 * effectMod / value) ^ 2.5) end,
-					ramping = false,
-				},
+		// Calculate ignite chance and damage
+		if canDeal[data.DamageTypeFire] && (output["IgniteChanceOnHit"]+output["IgniteChanceOnCrit"]) > 0 {
+			dotCfg := ailmentDotCfg(skillCfg, cfg, mod.KeywordFlagIgnite|mod.KeywordFlagFireDot)
+			if strings.Contains(pass.Label, "Off Hand") {
+				activeSkill.OHIgniteCfg = dotCfg
+			} else {
+				activeSkill.IgniteCfg = dotCfg
 			}
-			if activeSkill.skillTypes[SkillType.ChillingArea] or activeSkill.skillTypes[SkillType.NonHitChill] then
-				skillFlags.chill = true
-				output.ChillEffectMod = skillModList:Sum("INC", cfg, "EnemyChillEffect")
-				output.ChillDurationMod = 1 + skillModList:Sum("INC", cfg, "EnemyChillDuration") / 100
-				output.ChillSourceEffect = m_min(skillModList:Override(nil, "ChillMax") or ailmentData.Chill.max, m_floor(ailmentData.Chill.default * (1 + output.ChillEffectMod / 100)))
-				if breakdown then
-					breakdown.DotChill = { }
-					breakdown.multiChain(breakdown.DotChill, {
-						label = s_format("Effect of Chill: ^8(capped at %d%%)", skillModList:Override(nil, "ChillMax") or ailmentData.Chill.max),
-						base = s_format("%d%% ^8(base)", ailmentData.Chill.default),
-						{ "%.2f ^8(increased effect of chill)", 1 + output.ChillEffectMod / 100},
-						total = s_format("= %.0f%%", output.ChillSourceEffect)
-					})
-				end
-			end
-			if (output.FreezeChanceOnHit + output.FreezeChanceOnCrit) > 0 then
-				if globalBreakdown then
-					globalBreakdown.FreezeDurationMod = {
-						s_format("Ailment mode: %s ^8(can be changed in the Configuration tab)", igniteMode == "CRIT" and "Crits Only" or "Average Damage")
+
+			sourceHitDmg := float64(0)
+			sourceCritDmg := float64(0)
+			if breakdown != nil {
+				for _, damageType := range dmgTypeList {
+					breakdown.Set("Ignite"+string(damageType), 0)
+				}
+			}
+
+			// For ignites we will be using a weighted average calculation
+			maxStacks := float64(1)
+			if skillFlags[SkillFlagIgniteCanStack] {
+				maxStacks += skillModList.Sum("BASE", cfg, "IgniteStacks")
+			}
+			globalOutput["IgniteStacksMax"] = maxStacks
+
+			rateMod := (CalcMod(skillModList, cfg, "IgniteBurnFaster") + enemyDB.Sum("INC", nil, "SelfIgniteBurnFaster")/100) / CalcMod(skillModList, cfg, "IgniteBurnSlower")
+			durationBase := float64(data.IgniteDurationBase)
+			durationMod := max(CalcMod(skillModList, dotCfg, "EnemyIgniteDuration", "SkillAndDamagingAilmentDuration")*CalcMod(enemyDB, nil, "SelfIgniteDuration"), 0)
+			globalOutput["IgniteDuration"] = durationBase * durationMod / rateMod * debuffDurationMult
+			if globalOutput["IgniteDuration"] <= data.IgniteMinDuration {
+				globalOutput["IgniteDuration"] = 0
+			}
+
+			igniteStacks := float64(1)
+			if !skillDataFlag(activeSkill, "triggeredOnDeath") {
+				igniteStacks = (globalOutput["IgniteDuration"] / output["Time"]) / maxStacks
+			}
+			globalOutput["IgniteStackPotential"] = igniteStacks
+			if globalBreakdown != nil {
+				globalBreakdown.Set("IgniteStackPotential", igniteStacks,
+					"NOTE: Calculation uses new Weighted Avg Ailment formula",
+					"",
+					fmt.Sprintf("(%.2f / %.2f) (IgniteDuration / Cast Time)", globalOutput["IgniteDuration"], output["Time"]),
+					fmt.Sprintf("/ %.0f (max number of stacks)", maxStacks),
+					fmt.Sprintf("= %.2f", igniteStacks),
+				)
+			}
+
+			for _, subPass := range []int{1, 2} {
+				if skillModList.Flag(dotCfg, "AilmentsAreNeverFromCrit") || subPass == 1 {
+					dotCfg.SkillCond["CriticalStrike"] = false
+				} else {
+					dotCfg.SkillCond["CriticalStrike"] = true
+				}
+
+				totalMin, totalMax := float64(0), float64(0)
+				fireFlags := data.DamageTypeFlags[data.DamageTypeFire]
+				for _, damageType := range dmgTypeList {
+					if !canDeal[damageType] {
+						continue
 					}
-				end
-				local baseVal = calcAilmentDamage("Freeze", calcAverageSourceDamage("Freeze")) * skillModList:More(cfg, "FreezeAsThoughDealing")
-				if baseVal > 0 then
-					skillFlags.freeze = true
-					skillFlags.chill = true
-					output.FreezeDurationMod = 1 + skillModList:Sum("INC", cfg, "EnemyFreezeDuration") / 100 + enemyDB:Sum("INC", nil, "SelfFreezeDuration") / 100
-					if breakdown then
-						t_insert(breakdown.FreezeDPS, s_format("For freeze to apply for the minimum of 0.3 seconds, target must have no more than %.0f Ailment Threshold.", baseVal * 20 * output.FreezeDurationMod))
-						t_insert(breakdown.FreezeDPS, s_format("^8(Ailment Threshold is about equal to Life except on bosses where it is about half of their life)"))
-					end
-				end
-			end
-			for ailment, val in pairs(ailments) do
-				if (output[ailment.."ChanceOnHit"] + output[ailment.."ChanceOnCrit"]) > 0 then
-					if globalBreakdown then
-						globalBreakdown[ailment.."EffectMod"] = {
-							s_format("Ailment mode: %s ^8(can be changed in the Configuration tab)", igniteMode == "CRIT" and "Crits Only" or "Average Damage")
+
+					typeFlags := fireFlags
+					if damageType == data.DamageTypeFire {
+						if skillModList.Flag(cfg, "FireCannotIgnite") {
+							continue
 						}
-					end
-					local damage = calcAilmentDamage(ailment, calcAverageSourceDamage(ailment)) * skillModList:More(cfg, ailment.."AsThoughDealing")
-					if damage > 0 then
-						skillFlags[string.lower(ailment)] = true
-						local incDur = skillModList:Sum("INC", cfg, "Enemy"..ailment.."Duration") + enemyDB:Sum("INC", nil, "Self"..ailment.."Duration")
-						local moreDur = skillModList:More(cfg, "Enemy"..ailment.."Duration") * enemyDB:More(nil, "Self"..ailment.."Duration")
-						output[ailment.."Duration"] = ailmentData[ailment].duration * (1 + incDur / 100) * moreDur * debuffDurationMult
-						output[ailment.."EffectMod"] = calcLib.mod(skillModList, cfg, "Enemy"..ailment.."Effect")
-						if breakdown then
-							local maximum = skillModList:Override(nil, ailment.."Max") or ailmentData[ailment].max
-							local current = m_max(m_min(ailment == "Chill" and bonechill or globalOutput["Current"..ailment] or 0, maximum), 0)
-							local desired = m_max(m_min(enemyDB:Sum("BASE", nil, "Desired"..ailment.."Val"), maximum), 0)
-							if ailmentData[ailment].min ~= 0 then
-								t_insert(val.effList, ailmentData[ailment].min)
-							end
-							if enemyThreshold > 0 then
-								t_insert(val.effList, val.effect(damage, output[ailment.."EffectMod"]))
-							end
-							if not isValueInArray(val.effList, maximum) then
-								t_insert(val.effList, maximum)
-							end
-							if current > 0 and not isValueInArray(val.effList, current) then
-								t_insert(val.effList, current)
-							end
-							if desired > 0 and not isValueInArray(val.effList, desired) and current == 0 then
-								t_insert(val.effList, desired)
-							end
-							breakdown[ailment.."DPS"].label = "Resulting ailment effect"..((current > 0 and val.ramping) and s_format(" ^8(with a ^7%s%% ^8%s on the enemy)^7", current, ailment) or "")
-							breakdown[ailment.."DPS"].footer = s_format("^8(ailment threshold is about equal to life, except on bosses that have specific ailement thresholds)\n(the above table shows that when the enemy has X ailment threshold, you ^8%s for Y)", ailment:lower())
-							breakdown[ailment.."DPS"].rowList = { }
-							breakdown[ailment.."DPS"].colList = {
-								{ label = "Ailment Threshold", key = "thresh" },
-								{ label = ailment.." Effect", key = "effect" },
-							}
-							table.sort(val.effList)
-							for _, value in ipairs(val.effList) do
-								local thresh = val.thresh(damage, value, output[ailment.."EffectMod"])
-								local decCheck = value / m_floor(value)
-								local precision = ailmentData[ailment].precision
-								value = m_floor(value * (10 ^ precision)) / (10 ^ precision)
-								local valueFormat = "%."..tostring(precision).."f%%"
-								local threshString = s_format("%d", thresh)..(m_floor(thresh + 0.5) == m_floor(enemyThreshold + 0.5) and s_format(" ^8(%s)", env.configInput.enemyIsBoss) or "")
-								local labels = { }
-								if decCheck == 1 and value ~= 0 then
-									if ailment == "Chill" and value == bonechill then
-										t_insert(labels, "bonechill")
-									elseif value == current then
-										t_insert(labels, "current")
-									end
-									if value == desired then
-										t_insert(labels, "desired")
-									end
-									if value == maximum then
-										t_insert(labels, "maximum")
-									end
-									if value == ailmentData[ailment].min then
-										t_insert(labels, "minimum")
-									end
-								end
-								t_insert(breakdown[ailment.."DPS"].rowList, {
-									effect = s_format(valueFormat, value)..(next(labels) ~= nil and " ^8("..table.concat(labels, ", ")..")" or ""),
-									thresh = threshString,
-								})
-							end
-						end
-						if breakdown and output[ailment.."Duration"] ~= ailmentData[ailment].duration then
-							breakdown[ailment.."Duration"] = { }
-							if isAttack then
-								t_insert(breakdown[ailment.."Duration"], pass.label..":")
-							end
-							t_insert(breakdown[ailment.."Duration"], s_format("%.2fs ^8(base duration)", ailmentData[ailment].duration))
-							if incDur ~= 0 then
-								t_insert(breakdown[ailment.."Duration"], s_format("x %.2f ^8(increased/reduced duration)", 1 + incDur / 100))
-							end
-							if moreDur ~= 1 then
-								t_insert(breakdown[ailment.."Duration"], s_format("x %.2f ^8(more/less duration)", moreDur))
-							end
-							if debuffDurationMult ~= 1 then
-								t_insert(breakdown[ailment.."Duration"], s_format("/ %.2f ^8(debuff expires slower/faster)", 1 / debuffDurationMult))
-							end
-							t_insert(breakdown[ailment.."Duration"], s_format("= %.2fs", output[ailment.."Duration"]))
-						end
-					end
-				end
-			end
-		*/
+						typeFlags = 0
+					} else if !skillModList.Flag(cfg, string(damageType)+"CanIgnite") {
+						continue
+					}
+
+					var sourceBreakdown *StatBreakdown
+					if subPass == 1 && breakdown != nil {
+						sourceBreakdown = breakdown.Stat("Ignite" + string(damageType))
+					}
+					minDamage, maxDamage := calcAilmentSourceDamage(activeSkill, output, dotCfg, sourceBreakdown, damageType, typeFlags)
+					output["Ignite"+string(damageType)+"Min"] = minDamage
+					output["Ignite"+string(damageType)+"Max"] = maxDamage
+					totalMin += minDamage
+					totalMax += maxDamage
+				}
+
+				dotMulti := 1 + skillModList.Sum("BASE", dotCfg, "DotMultiplier", "FireDotMultiplier")/100
+				sourceDmg := (totalMin + (totalMax-totalMin)/math.Pow(2, 1/(igniteStacks+1))) * dotMulti
+				if subPass == 2 {
+					output["CritIgniteDotMulti"] = dotMulti
+					sourceCritDmg = sourceDmg
+				} else {
+					output["IgniteDotMulti"] = dotMulti
+					sourceHitDmg = sourceDmg
+				}
+				output["IgniteTotalMin"] = totalMin
+				output["IgniteTotalMax"] = totalMax
+			}
+
+			if globalBreakdown != nil {
+				lines := []string{"NOTE: Calculation uses new Weighted Avg Ailment formula", ""}
+				if sourceHitDmg == sourceCritDmg {
+					lines = append(lines, weightedDerivation("Dmg Derivation:", "combined sources", output["IgniteTotalMin"], output["IgniteTotalMax"], igniteStacks, "Ignite DoT Multi", output["IgniteDotMulti"], sourceHitDmg)...)
+				} else {
+					lines = append(lines, weightedDerivation("Non-Crit Dmg Derivation:", "combined sources", output["IgniteTotalMin"], output["IgniteTotalMax"], igniteStacks, "Ignite DoT Multi for Non-Crit", output["IgniteDotMulti"], sourceHitDmg)...)
+					lines = append(lines, "")
+					lines = append(lines, weightedDerivation("Crit Dmg Derivation:", "combined sources", output["IgniteTotalMin"], output["IgniteTotalMax"], igniteStacks, "Ignite DoT Multi for Crit", output["CritIgniteDotMulti"], sourceCritDmg)...)
+				}
+				globalBreakdown.Set("IgniteDPS", 0, lines...)
+			}
+
+			baseVal := calcAilmentDamage("Ignite", sourceHitDmg, sourceCritDmg) * data.IgnitePercentBase * output["FistOfWarAilmentEffect"] * globalOutput["AilmentWarcryEffect"]
+			if baseVal > 0 {
+				skillFlags[SkillFlagIgnite] = true
+				effMult := float64(1)
+				if env.ModeEffective {
+					if skillModList.Flag(cfg, "IgniteToChaos") {
+						resist := min(enemyDB.Sum("BASE", nil, "ChaosResist")*CalcMod(enemyDB, nil, "ChaosResist"), data.EnemyMaxResist)
+						takenInc := enemyDB.Sum("INC", dotCfg, "DamageTaken", "DamageTakenOverTime", "ChaosDamageTaken", "ChaosDamageTakenOverTime")
+						takenMore := enemyDB.More(dotCfg, "DamageTaken", "DamageTakenOverTime", "ChaosDamageTaken", "ChaosDamageTakenOverTime")
+						effMult = (1 - resist/100) * (1 + takenInc/100) * takenMore
+						globalOutput["IgniteEffMult"] = effMult
+						if breakdown != nil && effMult != 1 {
+							globalBreakdown.effMult("IgniteEffMult", data.DamageTypeChaos, resist, takenInc, takenMore, effMult)
+						}
+					} else {
+						resist := min(enemyDB.Sum("BASE", nil, "FireResist", "ElementalResist")*CalcMod(enemyDB, nil, "FireResist", "ElementalResist"), data.EnemyMaxResist)
+						takenInc := enemyDB.Sum("INC", dotCfg, "DamageTaken", "DamageTakenOverTime", "FireDamageTaken", "FireDamageTakenOverTime", "ElementalDamageTaken")
+						takenMore := enemyDB.More(dotCfg, "DamageTaken", "DamageTakenOverTime", "FireDamageTaken", "FireDamageTakenOverTime", "ElementalDamageTaken")
+						effMult = (1 - resist/100) * (1 + takenInc/100) * takenMore
+						globalOutput["IgniteEffMult"] = effMult
+						if breakdown != nil && effMult != 1 {
+							breakdown.effMult("IgniteEffMult", data.DamageTypeFire, resist, takenInc, takenMore, effMult)
+						}
+					}
+				}
+
+				effectMod := CalcMod(skillModList, dotCfg, "AilmentEffect")
+				igniteStacks = 1
+				if !skillDataFlag(activeSkill, "triggeredOnDeath") {
+					igniteStacks = min(maxStacks, (output["HitChance"]/100)*globalOutput["IgniteDuration"]/output["Time"])
+				}
+				output["IgniteDPS"] = baseVal * effectMod * rateMod * effMult * igniteStacks
+				globalOutput["IgniteDamage"] = output["IgniteDPS"] * globalOutput["IgniteDuration"]
+				if skillFlags[SkillFlagIgniteCanStack] {
+					output["IgniteDamage"] = output["IgniteDPS"] * globalOutput["IgniteDuration"]
+					output["IgniteStacksMax"] = maxStacks
+					output["TotalIgniteDPS"] = output["IgniteDPS"]
+				}
+
+				if breakdown != nil {
+					s := breakdown.Stat("IgniteDPS")
+					s.Total = output["IgniteDPS"]
+					s.line("x %.2f (ignite deals %.0f%% per second)", data.IgnitePercentBase, data.IgnitePercentBase*100)
+					s.line("= %.1f", baseVal)
+					s.multiChain("Ignite DPS:", fmt.Sprintf("%.1f (total damage per second)", baseVal), fmt.Sprintf("= %.1f per second", output["IgniteDPS"]),
+						chainFactor{"%.2f (ailment effect modifier)", effectMod},
+						chainFactor{"%.2f (burn rate modifier)", rateMod},
+						chainFactor{"%.3f (effective DPS modifier)", effMult},
+						chainFactor{"%.2f (ignite stacks)", igniteStacks},
+					)
+
+					weightDotMulti("Ignite")
+
+					if skillFlags[SkillFlagIgniteCanStack] {
+						s = breakdown.Set("IgniteDamage", output["IgniteDamage"])
+						if isAttack {
+							s.line("%s:", pass.Label)
+						}
+						s.line("%.1f (damage per second)", output["IgniteDPS"])
+						s.line("x %.2fs (ignite duration)", globalOutput["IgniteDuration"])
+						s.line("= %.1f damage per ignite stack", output["IgniteDamage"])
+					}
+
+					ailmentDuration("IgniteDuration", durationBase, durationMod, rateMod, "burn rate modifier")
+				}
+			}
+		}
+		// Calculate non-damaging ailments effect and duration modifiers
+		enemyIsBoss := env.configString("enemyIsBoss")
+		isBoss := enemyIsBoss != "None"
+		enemyBaseLife := data.MonsterLifeTable[env.EnemyLevel] * enemyDB.More(nil, "Life")
+		enemyMapLifeMult := float64(1)
+		enemyMapAilmentMult := float64(1)
+		if env.EnemyLevel >= 66 {
+			if isBoss {
+				enemyMapLifeMult = data.MapLevelBossLifeMult(env.EnemyLevel)
+				enemyMapAilmentMult = data.MapLevelBossAilmentMult(env.EnemyLevel)
+			} else {
+				enemyMapLifeMult = data.MapLevelLifeMult(env.EnemyLevel)
+			}
+		}
+		enemyTypeMult := utils.Ternary(isBoss, 7.68, 1)
+		enemyThreshold := enemyBaseLife * enemyTypeMult * enemyMapLifeMult * enemyMapAilmentMult * enemyDB.More(nil, "AilmentThreshold")
+
+		bonechill, ok := output["BonechillEffect"]
+		if !ok {
+			bonechill = enemyDB.Sum("BASE", nil, "DesiredBonechillEffect")
+		}
+
+		// The effect of most non-damaging ailments scales with the damage dealt relative to the ailment threshold of the enemy
+		type ailmentEffect struct {
+			ailment data.Ailment
+			effList []float64
+			scale   float64
+			ramping bool
+		}
+		ailments := []ailmentEffect{
+			{ailment: data.AilmentChill, effList: []float64{10, 20}, scale: 50, ramping: bonechill > 0},
+			{ailment: data.AilmentShock, effList: []float64{10, 20, 40}, scale: 50, ramping: true},
+			{ailment: data.AilmentScorch, effList: []float64{5, 10, 20}, scale: 50, ramping: true},
+			{ailment: data.AilmentBrittle, effList: []float64{5, 10}, scale: 25, ramping: true},
+			{ailment: data.AilmentSap, effList: []float64{5, 10}, scale: 100.0 / 3, ramping: false},
+		}
+
+		chillData := data.NonDamagingAilments[data.AilmentChill]
+		if activeSkill.SkillTypes[data.SkillTypeChillingArea] || activeSkill.SkillTypes[data.SkillTypeNonHitChill] {
+			skillFlags[SkillFlagChill] = true
+			output["ChillEffectMod"] = skillModList.Sum("INC", cfg, "EnemyChillEffect")
+			output["ChillDurationMod"] = 1 + skillModList.Sum("INC", cfg, "EnemyChillDuration")/100
+			chillMax := chillData.Max
+			if override, ok := skillModList.Override(nil, "ChillMax").(float64); ok {
+				chillMax = override
+			}
+			output["ChillSourceEffect"] = min(chillMax, math.Floor(*chillData.Default*(1+output["ChillEffectMod"]/100)))
+			if breakdown != nil {
+				breakdown.Set("DotChill", output["ChillSourceEffect"]).multiChain(
+					fmt.Sprintf("Effect of Chill: (capped at %.0f%%)", chillMax),
+					fmt.Sprintf("%.0f%% (base)", *chillData.Default),
+					fmt.Sprintf("= %.0f%%", output["ChillSourceEffect"]),
+					chainFactor{"%.2f (increased effect of chill)", 1 + output["ChillEffectMod"]/100},
+				)
+			}
+		}
+
+		if (output["FreezeChanceOnHit"] + output["FreezeChanceOnCrit"]) > 0 {
+			if globalBreakdown != nil {
+				globalBreakdown.Set("FreezeDurationMod", 0, fmt.Sprintf("Ailment mode: %s (can be changed in the Configuration tab)", ailmentMode))
+			}
+			sourceHitDmg, sourceCritDmg := calcAverageSourceDamage(data.AilmentFreeze)
+			baseVal := calcAilmentDamage("Freeze", sourceHitDmg, sourceCritDmg) * skillModList.More(cfg, "FreezeAsThoughDealing")
+			if baseVal > 0 {
+				skillFlags[SkillFlagFreeze] = true
+				skillFlags[SkillFlagChill] = true
+				output["FreezeDurationMod"] = 1 + skillModList.Sum("INC", cfg, "EnemyFreezeDuration")/100 + enemyDB.Sum("INC", nil, "SelfFreezeDuration")/100
+				if breakdown != nil {
+					s := breakdown.Stat("FreezeDPS")
+					s.line("For freeze to apply for the minimum of 0.3 seconds, target must have no more than %.0f Ailment Threshold.", baseVal*20*output["FreezeDurationMod"])
+					s.line("(Ailment Threshold is about equal to Life except on bosses where it is about half of their life)")
+				}
+			}
+		}
+
+		for _, val := range ailments {
+			name := string(val.ailment)
+			if (output[name+"ChanceOnHit"] + output[name+"ChanceOnCrit"]) <= 0 {
+				continue
+			}
+
+			if globalBreakdown != nil {
+				globalBreakdown.Set(name+"EffectMod", 0, fmt.Sprintf("Ailment mode: %s (can be changed in the Configuration tab)", ailmentMode))
+			}
+			sourceHitDmg, sourceCritDmg := calcAverageSourceDamage(val.ailment)
+			damage := calcAilmentDamage(name, sourceHitDmg, sourceCritDmg) * skillModList.More(cfg, name+"AsThoughDealing")
+			if damage <= 0 {
+				continue
+			}
+
+			ailmentData := data.NonDamagingAilments[val.ailment]
+			skillFlags[SkillFlag(strings.ToLower(name))] = true
+			incDur := skillModList.Sum("INC", cfg, "Enemy"+name+"Duration") + enemyDB.Sum("INC", nil, "Self"+name+"Duration")
+			moreDur := skillModList.More(cfg, "Enemy"+name+"Duration") * enemyDB.More(nil, "Self"+name+"Duration")
+			output[name+"Duration"] = *ailmentData.Duration * (1 + incDur/100) * moreDur * debuffDurationMult
+			output[name+"EffectMod"] = CalcMod(skillModList, cfg, "Enemy"+name+"Effect")
+
+			effect := func(damage float64) float64 {
+				return val.scale * math.Pow(damage/enemyThreshold, 0.4) * output[name+"EffectMod"]
+			}
+			thresh := func(value float64) float64 {
+				return damage * math.Pow(val.scale*output[name+"EffectMod"]/value, 2.5)
+			}
+
+			maximum := ailmentData.Max
+			if override, ok := skillModList.Override(nil, name+"Max").(float64); ok {
+				maximum = override
+			}
+			precision := math.Pow(10, ailmentData.Precision)
+
+			// Effect of the ailment inflicted on the configured enemy, ailments below the minimum effect do not apply
+			if enemyThreshold > 0 {
+				inflicted := math.Floor(min(effect(damage), maximum)*precision) / precision
+				if inflicted < ailmentData.Min {
+					inflicted = 0
+				}
+				output[name+"Effect"] = inflicted
+			}
+
+			if breakdown != nil {
+				current := max(min(utils.Ternary(val.ailment == data.AilmentChill, bonechill, globalOutput["Current"+name]), maximum), 0)
+				desired := max(min(enemyDB.Sum("BASE", nil, "Desired"+name+"Val"), maximum), 0)
+				effList := val.effList
+				if ailmentData.Min != 0 {
+					effList = append(effList, ailmentData.Min)
+				}
+				if enemyThreshold > 0 {
+					effList = append(effList, effect(damage))
+				}
+				if !slices.Contains(effList, maximum) {
+					effList = append(effList, maximum)
+				}
+				if current > 0 && !slices.Contains(effList, current) {
+					effList = append(effList, current)
+				}
+				if desired > 0 && !slices.Contains(effList, desired) && current == 0 {
+					effList = append(effList, desired)
+				}
+				slices.Sort(effList)
+
+				s := breakdown.Stat(name + "DPS")
+				if current > 0 && val.ramping {
+					s.line("Resulting ailment effect (with a %g%% %s on the enemy):", current, name)
+				} else {
+					s.line("Resulting ailment effect:")
+				}
+				for _, value := range effList {
+					threshValue := thresh(value)
+					isWhole := value == math.Floor(value)
+					value = math.Floor(value*precision) / precision
+
+					threshString := fmt.Sprintf("%.0f", math.Floor(threshValue))
+					if math.Floor(threshValue+0.5) == math.Floor(enemyThreshold+0.5) {
+						threshString += " (" + enemyIsBoss + ")"
+					}
+
+					labels := make([]string, 0)
+					if isWhole && value != 0 {
+						if val.ailment == data.AilmentChill && value == bonechill {
+							labels = append(labels, "bonechill")
+						} else if value == current {
+							labels = append(labels, "current")
+						}
+						if value == desired {
+							labels = append(labels, "desired")
+						}
+						if value == maximum {
+							labels = append(labels, "maximum")
+						}
+						if value == ailmentData.Min {
+							labels = append(labels, "minimum")
+						}
+					}
+
+					line := fmt.Sprintf("%s ailment threshold: %.*f%% %s", threshString, int(ailmentData.Precision), value, name)
+					if len(labels) > 0 {
+						line += " (" + strings.Join(labels, ", ") + ")"
+					}
+					s.line("%s", line)
+				}
+				s.line("(ailment threshold is about equal to life, except on bosses that have specific ailment thresholds)")
+			}
+
+			if breakdown != nil && output[name+"Duration"] != *ailmentData.Duration {
+				s := breakdown.Set(name+"Duration", output[name+"Duration"])
+				if isAttack {
+					s.line("%s:", pass.Label)
+				}
+				s.line("%.2fs (base duration)", *ailmentData.Duration)
+				if incDur != 0 {
+					s.line("x %.2f (increased/reduced duration)", 1+incDur/100)
+				}
+				if moreDur != 1 {
+					s.line("x %.2f (more/less duration)", moreDur)
+				}
+				if debuffDurationMult != 1 {
+					s.line("/ %.2f (debuff expires slower/faster)", 1/debuffDurationMult)
+				}
+				s.line("= %.2fs", output[name+"Duration"])
+			}
+		}
 		/*
 			TODO Calculate knockback chance/distance
 			output.KnockbackChance = m_min(100, output.KnockbackChanceOnHit * (1 - output.CritChance / 100) + output.KnockbackChanceOnCrit * output.CritChance / 100 + enemyDB:Sum("BASE", nil, "SelfKnockbackChance"))
@@ -3899,7 +3801,7 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 		combineStat("TotalPoisonDPS", "DPS")
 		combineStat("PoisonDamage", "CHANCE", "PoisonChance")
 
-		if skillData["showAverage"] == true {
+		if utils.HasTrue(skillData, "ShowAverage") {
 			combineStat("TotalPoisonAverageDamage", "DPS")
 		} else {
 			combineStat("TotalPoisonStacks", "DPS")
@@ -3911,7 +3813,7 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 		if skillFlags[SkillFlagIgniteCanStack] {
 			combineStat("IgniteDamage", "CHANCE", "IgniteChance")
 
-			if skillData["showAverage"] == true {
+			if utils.HasTrue(skillData, "ShowAverage") {
 				combineStat("TotalIgniteAverageDamage", "DPS")
 				combineStat("IgniteStacksMax", "DPS")
 				combineStat("TotalIgniteDPS", "DPS")
@@ -3922,20 +3824,25 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 		}
 
 		combineStat("ChillEffectMod", "AVERAGE")
+		combineStat("ChillEffect", "CHANCE", "ChillChance")
 		combineStat("ChillDuration", "AVERAGE")
 		combineStat("ShockChance", "AVERAGE")
 		combineStat("ShockDuration", "AVERAGE")
 		combineStat("ShockEffectMod", "AVERAGE")
+		combineStat("ShockEffect", "CHANCE", "ShockChance")
 		combineStat("FreezeChance", "AVERAGE")
 		combineStat("FreezeDurationMod", "AVERAGE")
 		combineStat("ScorchChance", "AVERAGE")
 		combineStat("ScorchEffectMod", "AVERAGE")
+		combineStat("ScorchEffect", "CHANCE", "ScorchChance")
 		combineStat("ScorchDuration", "AVERAGE")
 		combineStat("BrittleChance", "AVERAGE")
 		combineStat("BrittleEffectMod", "AVERAGE")
+		combineStat("BrittleEffect", "CHANCE", "BrittleChance")
 		combineStat("BrittleDuration", "AVERAGE")
 		combineStat("SapChance", "AVERAGE")
 		combineStat("SapEffectMod", "AVERAGE")
+		combineStat("SapEffect", "CHANCE", "SapChance")
 		combineStat("SapDuration", "AVERAGE")
 		combineStat("ImpaleChance", "AVERAGE")
 		combineStat("ImpaleStoredDamage", "AVERAGE")
@@ -4191,62 +4098,62 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 			end
 		end
 	*/
+	// Calculate combined DPS estimate, including DoTs
+	showAverage := utils.HasTrue(skillData, "ShowAverage")
+	baseDPS := output[utils.Ternary(showAverage, "AverageDamage", "TotalDPS")]
+	output["CombinedDPS"] = baseDPS
+	output["CombinedAvg"] = baseDPS
+	if skillFlags[SkillFlagDot] {
+		output["CombinedDPS"] += output["TotalDot"]
+		output["WithDotDPS"] = baseDPS + output["TotalDot"]
+	}
+	if quantityMultiplier > 1 && utils.Has(output, "TotalPoisonDPS") {
+		output["TotalPoisonDPS"] *= quantityMultiplier
+	}
+	output["CombinedDPS"] += output["TotalPoisonDPS"]
+	if showAverage {
+		output["CombinedAvg"] += output["PoisonDamage"]
+		output["WithPoisonDPS"] = baseDPS + output["TotalPoisonAverageDamage"]
+	} else {
+		output["WithPoisonDPS"] = baseDPS + output["TotalPoisonDPS"]
+	}
+	if skillFlags[SkillFlagIgnite] {
+		if skillFlags[SkillFlagIgniteCanStack] {
+			output["CombinedDPS"] += output["TotalIgniteDPS"]
+			if showAverage {
+				output["CombinedAvg"] = output["CombinedDPS"] + output["IgniteDamage"]
+			} else {
+				output["WithIgniteDPS"] = baseDPS + output["TotalIgniteDPS"]
+			}
+		} else if showAverage {
+			output["WithIgniteDPS"] = baseDPS + output["IgniteDamage"]
+			output["CombinedDPS"] += output["IgniteDPS"]
+			output["CombinedAvg"] += output["IgniteDamage"]
+		} else {
+			output["WithIgniteDPS"] = baseDPS + output["IgniteDPS"]
+			output["CombinedDPS"] += output["IgniteDPS"]
+		}
+	} else {
+		output["WithIgniteDPS"] = baseDPS
+	}
+	if skillFlags[SkillFlagBleed] {
+		if showAverage {
+			output["WithBleedDPS"] = baseDPS + output["BleedDamage"]
+			output["CombinedDPS"] += output["BleedDPS"]
+			output["CombinedAvg"] += output["BleedDamage"]
+		} else {
+			output["WithBleedDPS"] = baseDPS + output["BleedDPS"]
+			output["CombinedDPS"] += output["BleedDPS"]
+		}
+	} else {
+		output["WithBleedDPS"] = baseDPS
+	}
+	if skillFlags[SkillFlagDecay] {
+		output["CombinedDPS"] += output["DecayDPS"]
+	}
+	output["TotalDotDPS"] = output["TotalDot"] + output["TotalPoisonDPS"] + utils.GetOr(output, "TotalIgniteDPS", output["IgniteDPS"]) + output["BleedDPS"] + output["DecayDPS"]
 	/*
-		TODO -- Calculate combined DPS estimate, including DoTs
-		local baseDPS = output[(skillData.showAverage and "AverageDamage") or "TotalDPS"]
-		output.CombinedDPS = baseDPS
-		output.CombinedAvg = baseDPS
-		if skillFlags.dot then
-			output.CombinedDPS = output.CombinedDPS + (output.TotalDot or 0)
-			output.WithDotDPS = baseDPS + (output.TotalDot or 0)
-		end
-		if quantityMultiplier > 1 and output.TotalPoisonDPS then
-			output.TotalPoisonDPS = output.TotalPoisonDPS * quantityMultiplier
-		end
-		if skillData.showAverage then
-			output.CombinedDPS = output.CombinedDPS + (output.TotalPoisonDPS or 0)
-			output.CombinedAvg = output.CombinedAvg + (output.PoisonDamage or 0)
-			output.WithPoisonDPS = baseDPS + (output.TotalPoisonAverageDamage or 0)
-		else
-			output.CombinedDPS = output.CombinedDPS + (output.TotalPoisonDPS or 0)
-			output.WithPoisonDPS = baseDPS + (output.TotalPoisonDPS or 0)
-		end
-		if skillFlags.ignite then
-			if skillFlags.igniteCanStack then
-				if skillData.showAverage then
-					output.CombinedDPS = output.CombinedDPS + output.TotalIgniteDPS
-					output.CombinedAvg = output.CombinedDPS + output.IgniteDamage
-				else
-					output.CombinedDPS = output.CombinedDPS + output.TotalIgniteDPS
-					output.WithIgniteDPS = baseDPS + output.TotalIgniteDPS
-				end
-			elseif skillData.showAverage then
-				output.WithIgniteDPS = baseDPS + output.IgniteDamage
-				output.CombinedDPS = output.CombinedDPS + output.IgniteDPS
-				output.CombinedAvg = output.CombinedAvg + output.IgniteDamage
-			else
-				output.WithIgniteDPS = baseDPS + output.IgniteDPS
-				output.CombinedDPS = output.CombinedDPS + output.IgniteDPS
-			end
-		else
-			output.WithIgniteDPS = baseDPS
-		end
-		if skillFlags.bleed then
-			if skillData.showAverage then
-				output.WithBleedDPS = baseDPS + output.BleedDamage
-				output.CombinedDPS = output.CombinedDPS + output.BleedDPS
-				output.CombinedAvg = output.CombinedAvg + output.BleedDamage
-			else
-				output.WithBleedDPS = baseDPS + output.BleedDPS
-				output.CombinedDPS = output.CombinedDPS + output.BleedDPS
-			end
-		else
-			output.WithBleedDPS = baseDPS
-		end
-		if skillFlags.decay then
-			output.CombinedDPS = output.CombinedDPS + output.DecayDPS
-		end
-		output.TotalDotDPS = (output.TotalDot or 0) + (output.TotalPoisonDPS or 0) + (output.TotalIgniteDPS or output.IgniteDPS or 0) + (output.BleedDPS or 0) + (output.DecayDPS or 0)
+		TODO Impale and mirage DPS
 		if skillFlags.impale then
 			if skillFlags.attack then
 				output.ImpaleHit = ((output.MainHand.PhysicalHitAverage or output.OffHand.PhysicalHitAverage) + (output.OffHand.PhysicalHitAverage or output.MainHand.PhysicalHitAverage)) / 2 * (1-output.CritChance/100) + ((output.MainHand.PhysicalCritAverage or output.OffHand.PhysicalCritAverage) + (output.OffHand.PhysicalCritAverage or output.MainHand.PhysicalCritAverage)) / 2 * (output.CritChance/100)
@@ -4322,9 +4229,9 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 				bestCull = activeSkill.mirage.output.CullMultiplier
 			end
 		end
-
-		bestCull = m_max(bestCull, output.CullMultiplier)
-		output.CullingDPS = output.CombinedDPS * (bestCull - 1)
-		output.CombinedDPS = output.CombinedDPS * bestCull
 	*/
+
+	bestCull := math.Max(1, output["CullMultiplier"])
+	output["CullingDPS"] = output["CombinedDPS"] * (bestCull - 1)
+	output["CombinedDPS"] *= bestCull
 }
@@ -1,10 +1,179 @@
 package calculator
 
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/pob"
+)
+
 var envCache = &EnvironmentCache{}
 
-// crystalline:promise
-func (c *Calculator) BuildOutput(mode OutputMode) *Environment {
-	env, _, _, _ := InitEnv(c.PoB, envCache, mode)
+// BuildOutput calculates the build, using the context to load the tree data
+func (c *Calculator) BuildOutput(ctx context.Context, mode OutputMode) (*Environment, error) {
+	env, _, _, _, err := InitEnv(ctx, c.PoB, envCache, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	PerformCalc(env)
+
+	if mode == OutputModeMain {
+		env.FullDPS = calcFullDPS(env)
+		env.Player.Output["FullDPS"] = env.FullDPS.CombinedDPS
+		env.Player.Output["FullDotDPS"] = env.FullDPS.TotalDotDPS
+	}
+
+	return env, nil
+}
+
+// calcWithMainSkill calculates the build with the skill at the given index of the active skill list as the main skill
+func calcWithMainSkill(ctx context.Context, build *pob.PathOfBuilding, envCache *EnvironmentCache, index int, limitedProcessing bool) (*Environment, error) {
+	env, _, _, _, err := InitEnv(ctx, build, envCache, OutputModeMain)
+	if err != nil {
+		return nil, err
+	}
+
+	if index < 0 || index >= len(env.Player.ActiveSkillList) {
+		return nil, fmt.Errorf("active skill %d not found", index)
+	}
+
+	env.Player.MainSkill = env.Player.ActiveSkillList[index]
+	env.limitedProcessing = limitedProcessing
 	PerformCalc(env)
-	return env
+	return env, nil
+}
+
+// fullDPSSkillCount returns how many instances of the skill deal damage at the same time
+func fullDPSSkillCount(env *Environment, activeSkill *ActiveSkill) float64 {
+	count := float64(1)
+	if activeSkill.ActiveEffect.SrcInstance != nil && activeSkill.ActiveEffect.SrcInstance.Count > 0 {
+		count = float64(activeSkill.ActiveEffect.SrcInstance.Count)
+	}
+
+	if activeSkill.SkillFlags[SkillFlagTotem] {
+		totems := activeSkill.SkillModList.Sum(mod.TypeBase, activeSkill.SkillCfg, "ActiveTotemLimit", "ActiveBallistaLimit")
+		if override, ok := env.ModDB.Override(nil, "TotemsSummoned").(float64); ok {
+			totems = override
+		}
+		count *= math.Max(totems, 1)
+	}
+
+	return count
+}
+
+// calcFullDPS calculates every skill included in Full DPS as the main skill and combines their damage.
+// Each skill is calculated with all other skills active, so auras, curses and their limits are shared.
+func calcFullDPS(env *Environment) *FullDPS {
+	fullDPS := &FullDPS{
+		Skills: make([]FullDPSSkill, 0),
+	}
+	var bleedSource, igniteSource, burningGroundSource, causticGroundSource string
+
+	addOutput := func(output map[string]float64, name string, count float64, source string) {
+		if output["TotalDPS"] > 0 {
+			fullDPS.Skills = append(fullDPS.Skills, FullDPSSkill{Name: name, DPS: output["TotalDPS"], Count: count})
+			fullDPS.CombinedDPS += output["TotalDPS"] * count
+		}
+
+		// Only the strongest instance of these ailments applies to the enemy
+		if output["BleedDPS"] > fullDPS.BleedDPS {
+			fullDPS.BleedDPS = output["BleedDPS"]
+			bleedSource = source
+		}
+		if output["IgniteDPS"] > fullDPS.IgniteDPS {
+			fullDPS.IgniteDPS = output["IgniteDPS"]
+			igniteSource = source
+		}
+		if output["BurningGroundDPS"] > fullDPS.BurningGroundDPS {
+			fullDPS.BurningGroundDPS = output["BurningGroundDPS"]
+			burningGroundSource = source
+		}
+		if output["CausticGroundDPS"] > fullDPS.CausticGroundDPS {
+			fullDPS.CausticGroundDPS = output["CausticGroundDPS"]
+			causticGroundSource = source
+		}
+
+		fullDPS.TotalPoisonDPS += output["TotalPoisonDPS"] * count
+		fullDPS.ImpaleDPS += output["ImpaleDPS"] * count
+		fullDPS.DecayDPS += output["DecayDPS"]
+		fullDPS.DotDPS += output["TotalDot"]
+
+		if output["CullMultiplier"] > 1 && output["CullMultiplier"] > fullDPS.CullingMulti {
+			fullDPS.CullingMulti = output["CullMultiplier"]
+		}
+	}
+
+	for index, activeSkill := range env.Player.ActiveSkillList {
+		socketGroup, ok := activeSkill.SocketGroup.(*pob.Skill)
+		if !ok || socketGroup == nil || socketGroup.IncludeInFullDPS == nil || !*socketGroup.IncludeInFullDPS {
+			continue
+		}
+
+		name := skillDisplayName(activeSkill)
+		skillEnv, err := calcWithMainSkill(env.ctx, env.Build, env.Cache, index, false)
+		if err != nil {
+			env.DebugErrors = append(env.DebugErrors, fmt.Sprintf("failed to calculate full dps of %s: %s", name, err))
+			continue
+		}
+
+		count := fullDPSSkillCount(skillEnv, skillEnv.Player.MainSkill)
+		if minion := skillEnv.Minion; minion != nil {
+			minionName := name
+			if minion.MinionData != nil {
+				minionName = minion.MinionData.Name + " " + name
+			}
+			addOutput(minion.Output, minionName, count, name)
+		}
+		addOutput(skillEnv.Player.Output, name, count, name)
+	}
+
+	// Re-add ailment damage
+	if fullDPS.BleedDPS > 0 {
+		fullDPS.Skills = append(fullDPS.Skills, FullDPSSkill{Name: "Best Bleed DPS", DPS: fullDPS.BleedDPS, Count: 1, Source: bleedSource})
+		fullDPS.TotalDotDPS += fullDPS.BleedDPS
+	}
+	if fullDPS.IgniteDPS > 0 {
+		fullDPS.Skills = append(fullDPS.Skills, FullDPSSkill{Name: "Best Ignite DPS", DPS: fullDPS.IgniteDPS, Count: 1, Source: igniteSource})
+		fullDPS.TotalDotDPS += fullDPS.IgniteDPS
+	}
+	if fullDPS.BurningGroundDPS > 0 {
+		fullDPS.Skills = append(fullDPS.Skills, FullDPSSkill{Name: "Best Burning Ground DPS", DPS: fullDPS.BurningGroundDPS, Count: 1, Source: burningGroundSource})
+		fullDPS.TotalDotDPS += fullDPS.BurningGroundDPS
+	}
+	if fullDPS.TotalPoisonDPS > 0 {
+		fullDPS.Skills = append(fullDPS.Skills, FullDPSSkill{Name: "Full Poison DPS", DPS: fullDPS.TotalPoisonDPS, Count: 1})
+		fullDPS.TotalDotDPS += fullDPS.TotalPoisonDPS
+	}
+	if fullDPS.CausticGroundDPS > 0 {
+		fullDPS.Skills = append(fullDPS.Skills, FullDPSSkill{Name: "Best Caustic Ground DPS", DPS: fullDPS.CausticGroundDPS, Count: 1, Source: causticGroundSource})
+		fullDPS.TotalDotDPS += fullDPS.CausticGroundDPS
+	}
+	if fullDPS.ImpaleDPS > 0 {
+		fullDPS.Skills = append(fullDPS.Skills, FullDPSSkill{Name: "Full Impale DPS", DPS: fullDPS.ImpaleDPS, Count: 1})
+		fullDPS.CombinedDPS += fullDPS.ImpaleDPS
+	}
+	if fullDPS.DecayDPS > 0 {
+		fullDPS.Skills = append(fullDPS.Skills, FullDPSSkill{Name: "Full Decay DPS", DPS: fullDPS.DecayDPS, Count: 1})
+		fullDPS.TotalDotDPS += fullDPS.DecayDPS
+	}
+	if fullDPS.DotDPS > 0 {
+		fullDPS.Skills = append(fullDPS.Skills, FullDPSSkill{Name: "Full DoT DPS", DPS: fullDPS.DotDPS, Count: 1})
+		fullDPS.TotalDotDPS += fullDPS.DotDPS
+	}
+
+	// Culling applies to the combined hit damage
+	if fullDPS.CullingMulti > 0 {
+		fullDPS.CullingDPS = fullDPS.CombinedDPS * (fullDPS.CullingMulti - 1)
+		fullDPS.Skills = append(fullDPS.Skills, FullDPSSkill{Name: "Full Culling DPS", DPS: fullDPS.CullingDPS, Count: 1})
+		fullDPS.CombinedDPS += fullDPS.CullingDPS
+	}
+
+	fullDPS.TotalDotDPS = math.Min(fullDPS.TotalDotDPS, data.DotDpsCap)
+	fullDPS.CombinedDPS += fullDPS.TotalDotDPS
+
+	return fullDPS
 }
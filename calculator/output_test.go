@@ -3,6 +3,7 @@ package calculator
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/MarvinJWendt/testza"
@@ -12,8 +13,12 @@ import (
 
 	"github.com/Vilsol/go-pob/builds"
 	"github.com/Vilsol/go-pob/config"
+	"github.com/Vilsol/go-pob/data"
 	"github.com/Vilsol/go-pob/data/raw"
+	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/moddb"
 	"github.com/Vilsol/go-pob/pob"
+	"github.com/Vilsol/go-pob/utils"
 )
 
 func init() {
@@ -139,7 +144,8 @@ func TestOutput(t *testing.T) {
 			if test.baseDamage != nil {
 				skills := build.Skills.SkillSets
 				build.Skills.SkillSets = []pob.SkillSet{}
-				env := NewCalculator(*build).BuildOutput(OutputModeMain)
+				env, err := NewCalculator(*build).BuildOutput(context.Background(), OutputModeMain)
+				testza.AssertNoError(t, err)
 				assertNestedMapEqual(t, test.baseDamage, env.Player.OutputTable)
 				build.Skills.SkillSets = skills
 			}
@@ -147,10 +153,335 @@ func TestOutput(t *testing.T) {
 			for _, sg := range test.skillDamage {
 				t.Run(sg.name, func(t *testing.T) {
 					sgbuild := build.WithMainSocketGroup(sg.socketGroup)
-					env := NewCalculator(*sgbuild).BuildOutput(OutputModeMain)
+					env, err := NewCalculator(*sgbuild).BuildOutput(context.Background(), OutputModeMain)
+					testza.AssertNoError(t, err)
 					assertMapEqual(t, sg.damage, env.Player.Output)
 				})
 			}
 		})
 	}
 }
+
+func TestReservation(t *testing.T) {
+	d, err := os.ReadFile("../testdata/many-builds/9.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(d)
+	testza.AssertNoError(t, err)
+
+	env, err := NewCalculator(*build).BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	// Herald of Ash reserves life through Arrogance, the remaining auras reserve mana
+	testza.AssertEqual(t, 36.23, env.Player.ReservedLifePercent)
+	assertMapEqual(t, map[string]float64{
+		"LifeUnreserved": 2108,
+		"ManaUnreserved": 178,
+	}, env.Player.Output)
+	testza.AssertEqual(t, float64(0), env.Player.Output["ManaOverReserved"])
+	for _, err := range env.DebugErrors {
+		testza.AssertNotContains(t, err, "Build reserves")
+	}
+
+	env, _, _, _, err = InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	// Reserving more than the pool is flagged in the output
+	env.ModDB.AddMod(mod.NewFloat("ManaReserved", mod.TypeMore, 100).Source("Test"))
+	PerformCalc(env)
+	testza.AssertLess(t, env.Player.Output["ManaUnreserved"], 0)
+	testza.AssertEqual(t, float64(1), env.Player.Output["ManaOverReserved"])
+	testza.AssertEqual(t, float64(0), env.Player.Output["LifeOverReserved"])
+
+	// Arrogance moves forced mana reservations to life without changing the skill data
+	var herald *ActiveSkill
+	for _, activeSkill := range env.Player.ActiveSkillList {
+		if activeSkill.ActiveEffect.GrantedEffect.Raw.ID == "HeraldOfAsh" {
+			herald = activeSkill
+		}
+	}
+	testza.AssertNotNil(t, herald)
+	herald.SkillData["ManaReservationPercentForced"] = float64(10)
+	for i := 0; i < 2; i++ {
+		reservedLifePercent := env.Player.ReservedLifePercent
+		calculateSkillReservation(env, herald, map[string][]string{})
+		testza.AssertEqual(t, reservedLifePercent+10, env.Player.ReservedLifePercent)
+		testza.AssertEqual(t, float64(10), herald.SkillData["LifeReservedPercent"])
+	}
+	testza.AssertEqual(t, float64(10), herald.SkillData["ManaReservationPercentForced"])
+	testza.AssertNil(t, herald.SkillData["LifeReservationPercentForced"])
+}
+
+func TestBuffs(t *testing.T) {
+	d, err := os.ReadFile("../testdata/many-builds/2.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(d)
+	testza.AssertNoError(t, err)
+
+	env, err := NewCalculator(*build).BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	// Determination is scaled by the build's aura effect
+	source := mod.Source("Skill:Determination")
+	testza.AssertTrue(t, env.ModDB.Conditions["AffectedByDetermination"])
+	testza.AssertEqual(t, float64(2768), env.ModDB.Sum(mod.TypeBase, &moddb.ListCfg{Source: &source}, "Armour"))
+	testza.AssertEqual(t, float64(66), env.ModDB.Sum(mod.TypeMore, &moddb.ListCfg{Source: &source}, "Armour"))
+
+	// Despair is applied to the enemy, reduced by the boss curse effect
+	testza.AssertEqual(t, float64(1), env.Player.Output["EnemyCurseLimit"])
+	testza.AssertEqual(t, float64(1), env.ModDB.Multipliers["CurseOnEnemy"])
+	testza.AssertTrue(t, env.EnemyModDB.Conditions["Cursed"])
+	testza.AssertEqual(t, float64(4), env.EnemyModDB.Sum(mod.TypeBase, nil, "ChaosResist"))
+
+	// The same debuff from two Purifying Flame gems is only applied once
+	d, err = os.ReadFile("../testdata/many-builds/16.xml")
+	testza.AssertNoError(t, err)
+
+	gem := `<Gem skillId="Sanctify" level="1" count="1" qualityId="Alternate2" gemId="Metadata/Items/Gems/SkillGemSanctify" enabled="true" nameSpec="Purifying Flame" enableGlobal2="false" enableGlobal1="true" skillPart="1" quality="20"/>`
+	for _, xml := range []string{string(d), strings.Replace(string(d), gem, gem+gem, 1)} {
+		build, err = builds.ParseBuild([]byte(xml))
+		testza.AssertNoError(t, err)
+
+		env, err = NewCalculator(*build).BuildOutput(context.Background(), OutputModeMain)
+		testza.AssertNoError(t, err)
+
+		debuffs := env.EnemyModDB.Mods["DamageTakenConsecratedGround"]
+		testza.AssertLen(t, debuffs, 1)
+		testza.AssertEqual(t, float64(5), debuffs[0].Value())
+	}
+}
+
+func TestMinion(t *testing.T) {
+	d, err := os.ReadFile("../testdata/many-builds/8.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(d)
+	testza.AssertNoError(t, err)
+
+	// Summon Lightning Golem
+	env, err := NewCalculator(*build.WithMainSocketGroup(4)).BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	testza.AssertNotNil(t, env.Minion)
+	testza.AssertEqual(t, "SummonedLightningGolem", env.Minion.MinionType)
+	testza.AssertEqual(t, 72, env.Minion.Level)
+	testza.AssertEqual(t, "LightningGolemArcSummoned", env.Minion.MainSkill.ActiveEffect.GrantedEffect.Raw.ID)
+	testza.AssertTrue(t, env.Minion.MainSkill.SkillFlags[SkillFlagMinion])
+	testza.AssertEqual(t, float64(1), env.Player.Output["ActiveGolemLimit"])
+	assertMapEqual(t, map[string]float64{
+		"Life":            7466,
+		"EnergyShield":    1493,
+		"LightningResist": 70,
+	}, env.Minion.Output)
+	testza.AssertGreater(t, env.Minion.Output["TotalDPS"], 0)
+
+	// Base stats are read from the monster variety of the minion
+	testza.AssertEqual(t, "Lightning Golem", env.Minion.MinionData.Name)
+	testza.AssertEqual(t, 1.17, env.Minion.MinionData.AttackTime)
+	testza.AssertContains(t, env.Minion.MinionData.SkillList, "LightningGolemArcSummoned")
+
+	_, err = data.Minion("UnknownMinion")
+	testza.AssertNotNil(t, err)
+}
+
+func TestTrigger(t *testing.T) {
+	d, err := os.ReadFile("../testdata/many-builds/16.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(d)
+	testza.AssertNoError(t, err)
+
+	// Arctic Breath triggered by Cast On Critical Strike
+	env, err := NewCalculator(*build.WithMainSocketGroup(2)).BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	mainSkill := env.Player.MainSkill
+	testza.AssertEqual(t, "ArcticBreath", mainSkill.ActiveEffect.GrantedEffect.Raw.ID)
+	testza.AssertTrue(t, mainSkill.SkillFlags[SkillFlagTriggered])
+	testza.AssertTrue(t, mainSkill.SkillCfg.SkillCond["SkillIsTriggered"])
+
+	// The 0.15s cooldown is rounded up to 5 server ticks
+	assertMapEqual(t, map[string]float64{
+		"ActionTriggerRate": 6.060606060606061,
+		"SourceTriggerRate": 4.93,
+		"ServerTriggerRate": 4.93,
+	}, env.Player.Output)
+	testza.AssertEqual(t, mainSkill.SkillData["TriggerRate"], env.Player.Output["Speed"])
+	testza.AssertLess(t, env.Player.Output["Speed"], env.Player.Output["ServerTriggerRate"])
+
+	d, err = os.ReadFile("../testdata/many-builds/12.xml")
+	testza.AssertNoError(t, err)
+
+	build, err = builds.ParseBuild(d)
+	testza.AssertNoError(t, err)
+
+	// Molten Shell triggered by Cast when Damage Taken is limited by its cooldown
+	env, err = NewCalculator(*build.WithMainSocketGroup(5)).BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	testza.AssertEqual(t, "MoltenShell", env.Player.MainSkill.ActiveEffect.GrantedEffect.Raw.ID)
+	assertMapEqual(t, map[string]float64{
+		"ServerTriggerRate": 0.24838549428713363,
+		"Speed":             0.24838549428713363,
+	}, env.Player.Output)
+
+	// Swap Cast On Critical Strike for Cast while Channelling, so Cyclone triggers Arctic Breath at a fixed interval
+	d, err = os.ReadFile("../testdata/many-builds/16.xml")
+	testza.AssertNoError(t, err)
+
+	d = []byte(strings.Replace(string(d),
+		`skillId="SupportCastOnCrit" level="21" count="1" qualityId="Default" gemId="Metadata/Items/Gems/SupportGemCastOnCrit"`,
+		`skillId="SupportCastWhileChannelling" level="20" count="1" qualityId="Default" gemId="Metadata/Items/Gems/SupportGemCastWhileChannelling"`, 1))
+
+	build, err = builds.ParseBuild(d)
+	testza.AssertNoError(t, err)
+
+	env, err = NewCalculator(*build.WithMainSocketGroup(2)).BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	mainSkill = env.Player.MainSkill
+	testza.AssertEqual(t, "ArcticBreath", mainSkill.ActiveEffect.GrantedEffect.Raw.ID)
+	testza.AssertEqual(t, true, mainSkill.SkillData["Triggered"])
+	testza.AssertEqual(t, 0.35, mainSkill.SkillData["TriggerTime"])
+	assertMapEqual(t, map[string]float64{
+		"Time":        0.35,
+		"TriggerTime": 0.35,
+		"Speed":       1 / 0.35,
+	}, env.Player.Output)
+}
+
+func TestFullDPS(t *testing.T) {
+	d, err := os.ReadFile("../testdata/many-builds/7.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(d)
+	testza.AssertNoError(t, err)
+
+	mainSocketGroup := build.Build.MainSocketGroup
+	env, err := NewCalculator(*build).BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+	testza.AssertNotNil(t, env.FullDPS)
+
+	names := make([]string, len(env.FullDPS.Skills))
+	combined := float64(0)
+	for i, skill := range env.FullDPS.Skills {
+		names[i] = skill.Name
+		combined += skill.DPS * skill.Count
+	}
+
+	// Every socket group is included, auras and curses only contribute through the damaging skills
+	testza.AssertEqual(t, []string{
+		"Cyclone",
+		"Shockwave",
+		"Vaal Ancestral Warchief",
+		"Ancestral Warchief",
+		"Ancestral Protector",
+		"Sentinel of Purity Herald of Purity",
+		"Leap Slam",
+	}, names)
+
+	// Totems are counted once per active totem
+	testza.AssertEqual(t, float64(3), env.FullDPS.Skills[4].Count)
+
+	testza.AssertEqual(t, combined, env.FullDPS.CombinedDPS)
+	testza.AssertEqual(t, env.FullDPS.CombinedDPS, env.Player.Output["FullDPS"])
+	testza.AssertEqual(t, "Shockwave", skillDisplayName(env.Player.MainSkill))
+	testza.AssertEqual(t, mainSocketGroup, build.Build.MainSocketGroup)
+
+	// Calculating the build repeatedly selects the same main skill without changing the build
+	for i := 0; i < 2; i++ {
+		env, _, _, _, err = InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+		testza.AssertNoError(t, err)
+		testza.AssertEqual(t, mainSocketGroup-1, env.MainSocketGroup)
+		testza.AssertEqual(t, mainSocketGroup, build.Build.MainSocketGroup)
+	}
+}
+
+func TestAilments(t *testing.T) {
+	d, err := os.ReadFile("../testdata/builds/Fireball-full.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(d)
+	testza.AssertNoError(t, err)
+
+	env, err := NewCalculator(*build).BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	// Ignite does not stack, so only the strongest ignite deals damage
+	output := env.Player.Output
+	testza.AssertTrue(t, env.Player.MainSkill.SkillFlags[SkillFlagIgnite])
+	testza.AssertEqual(t, float64(4), output["IgniteDuration"])
+	testza.AssertInRange(t, output["IgniteDPS"], 34.37, 34.38)
+	testza.AssertEqual(t, output["IgniteDPS"]*output["IgniteDuration"], output["IgniteDamage"])
+	testza.AssertEqual(t, output["TotalDPS"]+output["IgniteDPS"], output["CombinedDPS"])
+	testza.AssertEqual(t, output["IgniteDPS"], output["TotalDotDPS"])
+
+	d, err = os.ReadFile("../testdata/many-builds/1.xml")
+	testza.AssertNoError(t, err)
+
+	build, err = builds.ParseBuild(d)
+	testza.AssertNoError(t, err)
+
+	env, err = NewCalculator(*build).BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	// Poison stacks without limit
+	output = env.Player.Output
+	testza.AssertEqual(t, "Poisonous Concoction", skillDisplayName(env.Player.MainSkill))
+	testza.AssertTrue(t, env.Player.MainSkill.SkillFlags[SkillFlagPoison])
+	testza.AssertGreater(t, output["TotalPoisonStacks"], 1)
+	testza.AssertEqual(t, output["PoisonDPS"]*output["TotalPoisonStacks"], output["TotalPoisonDPS"])
+	testza.AssertEqual(t, output["TotalDPS"]+output["TotalPoisonDPS"], output["WithPoisonDPS"])
+	testza.AssertEqual(t, float64(0), output["BleedDPS"])
+}
+
+func TestNonDamagingAilments(t *testing.T) {
+	d, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(d)
+	testza.AssertNoError(t, err)
+
+	build = build.WithMainSocketGroup(3)
+	build.SetConfigOption(pob.Input{Name: "conditionEnemyOnConsecratedGround", Boolean: utils.Ptr(true)})
+
+	env, _, _, _, err := InitEnv(context.Background(), build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	env.ModDB.AddMod(mod.NewFlag("FireCanShock", true).Source("Test"))
+	env.ModDB.AddMod(mod.NewFloat("EnemyShockChance", mod.TypeBase, 100).Source("Test"))
+	env.ModDB.AddMod(mod.NewFloat("ExtraExposure", mod.TypeBase, -5).Source("Test"))
+	env.EnemyModDB.AddMod(mod.NewFloat("FireExposure", mod.TypeBase, -10).Source("Test"))
+	env.EnemyModDB.AddMod(mod.NewFloat("DamageTakenConsecratedGround", mod.TypeIncrease, 10).Source("Test"))
+	PerformCalc(env)
+
+	// Fire hits can shock, capped at the maximum effect
+	output := env.Player.Output
+	testza.AssertEqual(t, float64(100), output["ShockChance"])
+	testza.AssertEqual(t, float64(50), output["ShockEffect"])
+
+	// Exposure is modified by the magnitude of all exposures
+	testza.AssertEqual(t, float64(-15), env.EnemyModDB.Sum(mod.TypeBase, nil, "FireResist"))
+
+	source := mod.Source("Consecrated Ground")
+	testza.AssertEqual(t, float64(10), env.EnemyModDB.Sum(mod.TypeIncrease, &moddb.ListCfg{Source: &source}, "DamageTaken"))
+
+	build.SetConfigOption(pob.Input{Name: "conditionEnemyShocked", Boolean: utils.Ptr(true)})
+	build.SetConfigOption(pob.Input{Name: "conditionShockEffect", Number: utils.Ptr[float64](30)})
+
+	env, err = NewCalculator(*build).BuildOutput(context.Background(), OutputModeMain)
+	testza.AssertNoError(t, err)
+
+	// Configured shock effect applies to the enemy
+	testza.AssertEqual(t, float64(30), env.Player.Output["CurrentShock"])
+	testza.AssertEqual(t, float64(50), env.Player.Output["MaximumShock"])
+
+	shocks := make([]float64, 0)
+	for _, tabulated := range env.EnemyModDB.Tabulate(mod.TypeIncrease, &moddb.ListCfg{SkillCond: map[string]bool{"Effective": true}}, "DamageTaken") {
+		if tabulated.Mod.GetSource() == mod.SourceShock {
+			shocks = append(shocks, tabulated.Value.(float64))
+		}
+	}
+	testza.AssertEqual(t, []float64{30}, shocks)
+}
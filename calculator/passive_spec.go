@@ -1,6 +1,12 @@
 package calculator
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+
 	"github.com/Vilsol/go-pob/data"
 	"github.com/Vilsol/go-pob/pob"
 )
@@ -10,6 +16,7 @@ type PassiveSpec struct {
 
 	Build       *pob.PathOfBuilding
 	TreeVersion data.TreeVersion
+	tree        *data.Tree
 
 	Nodes              map[string]interface{} // TODO Implement
 	AllocNodes         map[string]data.Node
@@ -17,7 +24,7 @@ type PassiveSpec struct {
 	AllocExtendedNodes map[string]interface{} // TODO Implement
 	Jewels             map[string]interface{} // TODO Implement
 	SubGraphs          map[string]interface{} // TODO Implement
-	MasterySelections  map[string]interface{} // TODO Implement
+	MasterySelections  map[string]int64       // Selected effect of each mastery node
 
 	ClassName      data.ClassName
 	AscendancyName data.AscendancyName
@@ -26,19 +33,125 @@ type PassiveSpec struct {
 	AllocatedMasteryCount int
 }
 
-func NewPassiveSpec(build *pob.PathOfBuilding, treeVersion data.TreeVersion) *PassiveSpec {
+func NewPassiveSpec(ctx context.Context, build *pob.PathOfBuilding, treeVersion data.TreeVersion) (*PassiveSpec, error) {
+	treeVersionData, err := data.GetTreeVersion(treeVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := treeVersionData.LoadTree(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tree: %w", err)
+	}
+
 	passiveSpec := &PassiveSpec{
-		Build:       build,
-		TreeVersion: treeVersion,
+		Build:             build,
+		TreeVersion:       treeVersion,
+		tree:              tree,
+		AllocNodes:        make(map[string]data.Node),
+		MasterySelections: make(map[string]int64),
 	}
 
-	passiveSpec.SelectClass(data.Scion)
+	className, ascendancyName := passiveSpec.buildClass()
+	passiveSpec.SelectClass(className)
+	passiveSpec.SelectAscendancyClass(ascendancyName)
 
-	return passiveSpec
+	passiveSpec.loadAllocatedNodes()
+	passiveSpec.BuildAllDependsAndPaths()
+
+	return passiveSpec, nil
 }
 
 func (p *PassiveSpec) Tree() *data.Tree {
-	return data.TreeVersions[p.TreeVersion].Tree()
+	return p.tree
+}
+
+// buildClass reads the class and ascendancy from the active spec, falling back to the names stored on the build
+func (p *PassiveSpec) buildClass() (data.ClassName, data.AscendancyName) {
+	tree := p.Tree()
+	if spec := p.Build.ActiveSpec(); spec != nil && spec.ClassID >= 0 && spec.ClassID < len(tree.Classes) {
+		class := tree.Classes[spec.ClassID]
+		if spec.AscendClassID > 0 && spec.AscendClassID <= len(class.Ascendancies) {
+			return class.Name, class.Ascendancies[spec.AscendClassID-1].Name
+		}
+		return class.Name, ""
+	}
+
+	className := data.ClassName(p.Build.Build.ClassName)
+	if _, ok := data.ClassIDs[className]; !ok {
+		className = data.Scion
+	}
+	return className, data.AscendancyName(p.Build.Build.AscendClassName)
+}
+
+func (p *PassiveSpec) loadAllocatedNodes() {
+	tree := p.Tree()
+	for _, id := range p.Build.Build.PassiveNodes {
+		strID := strconv.FormatInt(id, 10)
+		if node, ok := tree.Nodes[strID]; ok {
+			p.AllocNodes[strID] = node
+		}
+	}
+
+	if spec := p.Build.ActiveSpec(); spec != nil {
+		for _, selection := range spec.MasterySelections {
+			if err := p.selectMasteryEffect(selection.NodeID, selection.EffectID); err != nil {
+				slog.Warn("ignoring mastery selection", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// SelectMasteryEffect selects the effect of a mastery node, replacing the stats of the node if it is allocated
+func (p *PassiveSpec) SelectMasteryEffect(nodeID int64, effectID int64) error {
+	if err := p.selectMasteryEffect(nodeID, effectID); err != nil {
+		return err
+	}
+
+	p.updateAllocatedNodes()
+	return nil
+}
+
+func (p *PassiveSpec) selectMasteryEffect(nodeID int64, effectID int64) error {
+	if _, err := p.Tree().MasteryEffect(nodeID, effectID); err != nil {
+		return err
+	}
+
+	strID := strconv.FormatInt(nodeID, 10)
+	for id, selected := range p.MasterySelections {
+		if id != strID && selected == effectID {
+			return fmt.Errorf("effect %d is already selected on mastery %s", effectID, id)
+		}
+	}
+
+	p.MasterySelections[strID] = effectID
+	return nil
+}
+
+// updateAllocatedNodes applies the selected mastery effects and recounts allocated notables and masteries
+func (p *PassiveSpec) updateAllocatedNodes() {
+	p.AllocatedNotableCount = 0
+	p.AllocatedMasteryCount = 0
+
+	tree := p.Tree()
+	for id, node := range p.AllocNodes {
+		if node.IsMastery != nil && *node.IsMastery {
+			effectID, ok := p.MasterySelections[id]
+			if !ok {
+				// Masteries cannot be allocated without an effect
+				delete(p.AllocNodes, id)
+				continue
+			}
+
+			effect, _ := tree.MasteryEffect(*node.Skill, effectID)
+			node.Stats = effect.Stats
+			node.ReminderText = effect.ReminderText
+			p.AllocNodes[id] = node
+			p.AllocatedMasteryCount++
+		} else if node.IsNotable != nil && *node.IsNotable {
+			p.AllocatedNotableCount++
+		}
+	}
 }
 
 func (p *PassiveSpec) Class() data.Class {
@@ -46,55 +159,135 @@ func (p *PassiveSpec) Class() data.Class {
 }
 
 func (p *PassiveSpec) SelectClass(className data.ClassName) {
-	/*
-		TODO Implement
-		if self.curClassId then
-			-- Deallocate the current class's starting node
-			local oldStartNodeId = self.curClass.startNodeId
-			self.nodes[oldStartNodeId].alloc = false
-			self.allocNodes[oldStartNodeId] = nil
-		end
-	*/
+	tree := p.Tree()
+	if p.ClassName != "" {
+		// Deallocate the current class's starting node
+		if startNode := tree.ClassStartNode(data.ClassIDs[p.ClassName]); startNode != nil {
+			delete(p.AllocNodes, strconv.FormatInt(*startNode.Skill, 10))
+		}
+	}
 
 	p.ClassName = className
 
-	/*
-		TODO Implement
-		-- Allocate the new class's starting node
-		local startNode = self.nodes[class.startNodeId]
-		startNode.alloc = true
-		self.allocNodes[startNode.id] = startNode
-	*/
+	// Allocate the new class's starting node
+	if startNode := tree.ClassStartNode(data.ClassIDs[className]); startNode != nil {
+		p.AllocNodes[strconv.FormatInt(*startNode.Skill, 10)] = *startNode
+	}
 
-	p.SelectAscendancyClass(data.ClassAscendancies[className][0])
+	// Reset the ascendancy class, this will also rebuild the node paths and dependencies
+	p.SelectAscendancyClass("")
 }
 
 func (p *PassiveSpec) SelectAscendancyClass(ascendancyName data.AscendancyName) {
+	if !slices.ContainsFunc(p.Class().Ascendancies, func(ascendancy data.Ascendancy) bool {
+		return ascendancy.Name == ascendancyName
+	}) {
+		ascendancyName = ""
+	}
+
 	p.AscendancyName = ascendancyName
 
-	/*
-		TODO Implement
-		-- Deallocate any allocated ascendancy nodes that don't belong to the new ascendancy class
-		for id, node in pairs(self.allocNodes) do
-			if node.ascendancyName and node.ascendancyName ~= ascendClass.name then
-				node.alloc = false
-				self.allocNodes[id] = nil
-			end
-		end
-	*/
+	// Deallocate any allocated ascendancy nodes that don't belong to the new ascendancy class
+	for id, node := range p.AllocNodes {
+		if node.AscendancyName != nil && data.AscendancyName(*node.AscendancyName) != ascendancyName {
+			delete(p.AllocNodes, id)
+		}
+	}
 
-	/*
-		TODO Implement
-		if ascendClass.startNodeId then
-			-- Allocate the new ascendancy class's start node
-			local startNode = self.nodes[ascendClass.startNodeId]
-			startNode.alloc = true
-			self.allocNodes[startNode.id] = startNode
-		end
-	*/
+	if ascendancyName != "" {
+		// Allocate the new ascendancy class's start node
+		if startNode := p.Tree().AscendancyStartNode(ascendancyName); startNode != nil {
+			p.AllocNodes[strconv.FormatInt(*startNode.Skill, 10)] = *startNode
+		}
+	}
+
+	// Rebuild all the node paths and dependencies
+	p.BuildAllDependsAndPaths()
+}
+
+// BuildAllDependsAndPaths deallocates nodes that are no longer connected to a start node, then applies mastery effects and recounts allocated nodes
+func (p *PassiveSpec) BuildAllDependsAndPaths() {
+	// Nodes allocated through jewels can be disconnected from the rest of the tree
+	// TODO Only keep nodes within the radius of the jewel
+	if !p.hasDisconnectingJewel() {
+		p.pruneDisconnectedNodes()
+	}
+
+	p.updateAllocatedNodes()
 
 	/*
 		TODO Implement
-		self:BuildAllDependsAndPaths()
+		-- Rebuild node paths
+		self:BuildPathFromNode(...)
 	*/
 }
+
+func (p *PassiveSpec) pruneDisconnectedNodes() {
+	tree := p.Tree()
+	connected := make(map[string]bool)
+	queue := make([]string, 0)
+	for _, startNode := range []*data.Node{tree.ClassStartNode(data.ClassIDs[p.ClassName]), tree.AscendancyStartNode(p.AscendancyName)} {
+		if startNode == nil {
+			continue
+		}
+
+		id := strconv.FormatInt(*startNode.Skill, 10)
+		if _, ok := p.AllocNodes[id]; ok {
+			connected[id] = true
+			queue = append(queue, id)
+		}
+	}
+
+	for len(queue) > 0 {
+		node := p.AllocNodes[queue[0]]
+		queue = queue[1:]
+
+		// Masteries do not connect the nodes around them
+		if node.IsMastery != nil && *node.IsMastery {
+			continue
+		}
+
+		for _, other := range slices.Concat(node.Out, node.In) {
+			if _, ok := p.AllocNodes[other]; ok && !connected[other] {
+				connected[other] = true
+				queue = append(queue, other)
+			}
+		}
+	}
+
+	for id, node := range p.AllocNodes {
+		// TODO Nested cluster jewel sockets are connected through subgraphs
+		if node.ExpansionJewel != nil && node.ExpansionJewel.Parent != nil {
+			continue
+		}
+
+		if !connected[id] {
+			delete(p.AllocNodes, id)
+		}
+	}
+}
+
+// hasDisconnectingJewel reports whether an allocated jewel socket of the spec holds a jewel that allocates nodes without a path
+func (p *PassiveSpec) hasDisconnectingJewel() bool {
+	spec := p.Build.ActiveSpec()
+	if spec == nil || spec.Sockets == nil {
+		return false
+	}
+
+	for _, socket := range spec.Sockets.Sockets {
+		if _, ok := p.AllocNodes[strconv.FormatInt(socket.NodeID, 10)]; !ok {
+			continue
+		}
+
+		item := p.Build.Items.ItemByID(socket.ItemID)
+		if item == nil {
+			continue
+		}
+
+		switch item.Title {
+		case "Intuitive Leap", "Thread of Hope", "Impossible Escape":
+			return true
+		}
+	}
+	return false
+}
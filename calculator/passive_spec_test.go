@@ -0,0 +1,100 @@
+package calculator
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/Vilsol/go-pob/builds"
+	"github.com/Vilsol/go-pob/cache"
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/data/raw"
+)
+
+func TestPassiveSpecClass(t *testing.T) {
+	err := poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil)
+	testza.AssertNoError(t, err)
+
+	file, err := os.ReadFile("../testdata/many-builds/13.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	spec, err := NewPassiveSpec(context.Background(), build, data.LatestTreeVersion)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, data.Ranger, spec.ClassName)
+	testza.AssertEqual(t, data.Raider, spec.AscendancyName)
+	testza.AssertLen(t, spec.AllocNodes, len(build.Build.PassiveNodes))
+
+	rangerStart := spec.Tree().ClassStartNode(data.ClassIDs[data.Ranger])
+	raiderStart := spec.Tree().AscendancyStartNode(data.Raider)
+	deadeyeStart := spec.Tree().AscendancyStartNode(data.Deadeye)
+	testza.AssertNotNil(t, spec.AllocNodes[nodeKey(rangerStart)].Skill)
+	testza.AssertNotNil(t, spec.AllocNodes[nodeKey(raiderStart)].Skill)
+
+	// Switching ascendancy drops the nodes of the previous one
+	spec.SelectAscendancyClass(data.Deadeye)
+	testza.AssertNotNil(t, spec.AllocNodes[nodeKey(deadeyeStart)].Skill)
+	for _, node := range spec.AllocNodes {
+		if node.AscendancyName != nil {
+			testza.AssertEqual(t, string(data.Deadeye), *node.AscendancyName)
+		}
+	}
+
+	// Ascendancies of other classes cannot be selected
+	spec.SelectAscendancyClass(data.Juggernaut)
+	testza.AssertEqual(t, data.AscendancyName(""), spec.AscendancyName)
+
+	// Switching class leaves only the new starting node as nothing else is connected to it
+	spec.SelectClass(data.Marauder)
+	testza.AssertLen(t, spec.AllocNodes, 1)
+	testza.AssertNotNil(t, spec.AllocNodes[nodeKey(spec.Tree().ClassStartNode(data.ClassIDs[data.Marauder]))].Skill)
+	testza.AssertEqual(t, 0, spec.AllocatedNotableCount)
+	testza.AssertEqual(t, 0, spec.AllocatedMasteryCount)
+
+	build.SetClass(string(data.Witch))
+	testza.AssertNotNil(t, build.SetAscendancy(spec.Tree(), string(data.Juggernaut)))
+	testza.AssertNoError(t, build.SetAscendancy(spec.Tree(), string(data.Necromancer)))
+	spec, err = NewPassiveSpec(context.Background(), build, data.LatestTreeVersion)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, data.Witch, spec.ClassName)
+	testza.AssertEqual(t, data.Necromancer, spec.AscendancyName)
+	testza.AssertLen(t, spec.AllocNodes, 2)
+}
+
+func TestPassiveSpecDisconnectingJewel(t *testing.T) {
+	err := poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil)
+	testza.AssertNoError(t, err)
+
+	file, err := os.ReadFile("../testdata/builds/Fireball-full.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	// Intuitive Leap is socketed in the tree
+	spec, err := NewPassiveSpec(context.Background(), build, data.TreeVersion3_18)
+	testza.AssertNoError(t, err)
+	testza.AssertTrue(t, spec.hasDisconnectingJewel())
+
+	// Jewels that are not socketed in the spec do not count
+	sockets := build.ActiveSpec().Sockets
+	build.ActiveSpec().Sockets = nil
+	testza.AssertFalse(t, spec.hasDisconnectingJewel())
+
+	// Nor do jewels in unallocated sockets
+	build.ActiveSpec().Sockets = sockets
+	for _, socket := range sockets.Sockets {
+		delete(spec.AllocNodes, strconv.FormatInt(socket.NodeID, 10))
+	}
+	testza.AssertFalse(t, spec.hasDisconnectingJewel())
+}
+
+func nodeKey(node *data.Node) string {
+	return strconv.FormatInt(*node.Skill, 10)
+}
@@ -1,12 +1,17 @@
 package calculator
 
 import (
+	"fmt"
 	"math"
 	"sort"
+	"strings"
 
+	"github.com/Vilsol/go-pob-data/poe"
 	"github.com/Vilsol/go-pob/data"
+	raw2 "github.com/Vilsol/go-pob/data/raw"
 	"github.com/Vilsol/go-pob/mod"
 	"github.com/Vilsol/go-pob/moddb"
+	"github.com/Vilsol/go-pob/pob"
 	"github.com/Vilsol/go-pob/utils"
 )
 
@@ -39,14 +44,18 @@ func PerformCalc(env *Environment) {
 	for _, activeSkill := range env.Player.ActiveSkillList {
 		activeSkill.SkillModList = moddb.NewModList()
 		activeSkill.SkillModList.Parent = activeSkill.BaseSkillModList
+		activeSkill.SkillModList.SkillID = activeSkill.SkillCfg.SkillID
 		if activeSkill.Minion != nil {
-			/*
-				TODO -- Build minion skills
-				activeSkill.minion.modDB = new("ModDB")
-				activeSkill.minion.modDB.actor = activeSkill.minion
-				calcs.createMinionSkills(env, activeSkill)
-				activeSkill.skillPartName = activeSkill.minion.mainSkill.activeEffect.grantedEffect.name
-			*/
+			// Build minion skills
+			activeSkill.Minion.ModDB = moddb.NewModDB()
+			activeSkill.Minion.ModDB.Actor = activeSkill.Minion
+			if err := createMinionSkills(env, activeSkill); err != nil {
+				env.DebugErrors = append(env.DebugErrors, fmt.Sprintf("failed to create skills of %s: %s", activeSkill.ActiveEffect.GrantedEffect.Raw.ID, err))
+				activeSkill.Minion = nil
+				delete(activeSkill.SkillFlags, SkillFlagHaveMinion)
+				continue
+			}
+			// TODO activeSkill.skillPartName = activeSkill.minion.mainSkill.activeEffect.grantedEffect.name
 		}
 	}
 
@@ -60,45 +69,50 @@ func PerformCalc(env *Environment) {
 	//
 	// local output = env.player.output
 
-	/*
-		TODO Minions
-		env.minion = env.player.mainSkill.minion
-		if env.minion then
-			-- Initialise minion modifier database
-			output.Minion = { }
-			env.minion.output = output.Minion
-			env.minion.modDB.multipliers["Level"] = env.minion.level
-			calcs.initModDB(env, env.minion.modDB)
-			env.minion.modDB:NewMod("Life", "BASE", m_floor(env.minion.lifeTable[env.minion.level] * env.minion.minionData.life), "Base")
-			if env.minion.minionData.energyShield then
-				env.minion.modDB:NewMod("EnergyShield", "BASE", m_floor(env.data.monsterAllyLifeTable[env.minion.level] * env.minion.minionData.life * env.minion.minionData.energyShield), "Base")
-			end
-			if env.minion.minionData.armour then
-				env.minion.modDB:NewMod("Armour", "BASE", m_floor((10 + env.minion.level * 2) * env.minion.minionData.armour * 1.038 ^ env.minion.level), "Base")
-			end
-			env.minion.modDB:NewMod("Evasion", "BASE", round((30 + env.minion.level * 5) * 1.03 ^ env.minion.level), "Base")
-			env.minion.modDB:NewMod("Accuracy", "BASE", round((17 + env.minion.level / 2) * (env.minion.minionData.accuracy or 1) * 1.03 ^ env.minion.level), "Base")
-			env.minion.modDB:NewMod("CritMultiplier", "BASE", 30, "Base")
-			env.minion.modDB:NewMod("CritDegenMultiplier", "BASE", 30, "Base")
-			env.minion.modDB:NewMod("FireResist", "BASE", env.minion.minionData.fireResist, "Base")
-			env.minion.modDB:NewMod("ColdResist", "BASE", env.minion.minionData.coldResist, "Base")
-			env.minion.modDB:NewMod("LightningResist", "BASE", env.minion.minionData.lightningResist, "Base")
-			env.minion.modDB:NewMod("ChaosResist", "BASE", env.minion.minionData.chaosResist, "Base")
-			env.minion.modDB:NewMod("CritChance", "INC", 200, "Base", { type = "Multiplier", var = "PowerCharge" })
-			env.minion.modDB:NewMod("Speed", "INC", 15, "Base", { type = "Multiplier", var = "FrenzyCharge" })
-			env.minion.modDB:NewMod("Damage", "MORE", 4, "Base", { type = "Multiplier", var = "FrenzyCharge" })
-			env.minion.modDB:NewMod("MovementSpeed", "INC", 5, "Base", { type = "Multiplier", var = "FrenzyCharge" })
-			env.minion.modDB:NewMod("PhysicalDamageReduction", "BASE", 15, "Base", { type = "Multiplier", var = "EnduranceCharge" })
-			env.minion.modDB:NewMod("ElementalResist", "BASE", 15, "Base", { type = "Multiplier", var = "EnduranceCharge" })
-			env.minion.modDB:NewMod("ProjectileCount", "BASE", 1, "Base")
-			env.minion.modDB:NewMod("MaximumFortification", "BASE", 20, "Base")
-			env.minion.modDB:NewMod("Damage", "MORE", -50, "Base", 0, KeywordFlag.Poison)
-			env.minion.modDB:NewMod("Damage", "MORE", -50, "Base", 0, KeywordFlag.Ignite)
-			env.minion.modDB:NewMod("SkillData", "LIST", { key = "bleedBasePercent", value = 70/6 }, "Base")
-			env.minion.modDB:NewMod("Damage", "MORE", 200, "Base", 0, KeywordFlag.Bleed, { type = "ActorCondition", actor = "enemy", var = "Moving" })
-			for _, mod in ipairs(env.minion.minionData.modList) do
-				env.minion.modDB:AddMod(mod)
-			end
+	env.Minion = env.Player.MainSkill.Minion
+	if env.Minion != nil {
+		// Initialise minion modifier database
+		minion := env.Minion
+		minionDB := minion.ModDB
+		minionData := minion.MinionData
+		level := float64(minion.Level)
+		minion.Output = make(map[string]float64)
+		minion.OutputTable = make(map[OutTable]map[string]float64)
+		minionDB.Multipliers["Level"] = level
+		initModDB(env, minionDB)
+		minionDB.AddMod(mod.NewFloat("Life", mod.TypeBase, math.Floor(minion.LifeTable[minion.Level]*minionData.Life)).Source("Base"))
+		if minionData.EnergyShield != 0 {
+			minionDB.AddMod(mod.NewFloat("EnergyShield", mod.TypeBase, math.Floor(minion.LifeTable[minion.Level]*minionData.Life*minionData.EnergyShield)).Source("Base"))
+		}
+		if minionData.Armour != 0 {
+			minionDB.AddMod(mod.NewFloat("Armour", mod.TypeBase, math.Floor((10+level*2)*minionData.Armour*math.Pow(1.038, level))).Source("Base"))
+		}
+		accuracy := utils.Ternary(minionData.Accuracy != 0, minionData.Accuracy, 1)
+		minionDB.AddMod(mod.NewFloat("Evasion", mod.TypeBase, math.Round((30+level*5)*math.Pow(1.03, level))).Source("Base"))
+		minionDB.AddMod(mod.NewFloat("Accuracy", mod.TypeBase, math.Round((17+level/2)*accuracy*math.Pow(1.03, level))).Source("Base"))
+		minionDB.AddMod(mod.NewFloat("CritMultiplier", mod.TypeBase, 30).Source("Base"))
+		minionDB.AddMod(mod.NewFloat("CritDegenMultiplier", mod.TypeBase, 30).Source("Base"))
+		minionDB.AddMod(mod.NewFloat("FireResist", mod.TypeBase, minionData.FireResist).Source("Base"))
+		minionDB.AddMod(mod.NewFloat("ColdResist", mod.TypeBase, minionData.ColdResist).Source("Base"))
+		minionDB.AddMod(mod.NewFloat("LightningResist", mod.TypeBase, minionData.LightningResist).Source("Base"))
+		minionDB.AddMod(mod.NewFloat("ChaosResist", mod.TypeBase, minionData.ChaosResist).Source("Base"))
+		minionDB.AddMod(mod.NewFloat("CritChance", mod.TypeIncrease, 200).Source("Base").Tag(mod.Multiplier("PowerCharge").Base(0)))
+		minionDB.AddMod(mod.NewFloat("Speed", mod.TypeIncrease, 15).Source("Base").Tag(mod.Multiplier("FrenzyCharge").Base(0)))
+		minionDB.AddMod(mod.NewFloat("Damage", mod.TypeMore, 4).Source("Base").Tag(mod.Multiplier("FrenzyCharge").Base(0)))
+		minionDB.AddMod(mod.NewFloat("MovementSpeed", mod.TypeIncrease, 5).Source("Base").Tag(mod.Multiplier("FrenzyCharge").Base(0)))
+		minionDB.AddMod(mod.NewFloat("PhysicalDamageReduction", mod.TypeBase, 15).Source("Base").Tag(mod.Multiplier("EnduranceCharge").Base(0)))
+		minionDB.AddMod(mod.NewFloat("ElementalResist", mod.TypeBase, 15).Source("Base").Tag(mod.Multiplier("EnduranceCharge").Base(0)))
+		minionDB.AddMod(mod.NewFloat("ProjectileCount", mod.TypeBase, 1).Source("Base"))
+		minionDB.AddMod(mod.NewFloat("MaximumFortification", mod.TypeBase, 20).Source("Base"))
+		minionDB.AddMod(mod.NewFloat("Damage", mod.TypeMore, -50).Source("Base").KeywordFlag(mod.KeywordFlagPoison))
+		minionDB.AddMod(mod.NewFloat("Damage", mod.TypeMore, -50).Source("Base").KeywordFlag(mod.KeywordFlagIgnite))
+		minionDB.AddMod(mod.NewList("SkillData", mod.SkillData{Key: "bleedBasePercent", Value: 70.0 / 6}).Source("Base"))
+		minionDB.AddMod(mod.NewFloat("Damage", mod.TypeMore, 200).Source("Base").KeywordFlag(mod.KeywordFlagBleed).Tag(mod.ActorCondition("enemy", "Moving")))
+		for _, m := range minionData.ModList {
+			minionDB.AddMod(m)
+		}
+		/*
+			TODO Extra skill mods, Necromantic Aegis, The Iron Mass and minionUseBowAndQuiver
 			for _, mod in ipairs(env.player.mainSkill.extraSkillModList) do
 				env.minion.modDB:AddMod(mod)
 			end
@@ -117,33 +131,17 @@ func PerformCalc(env *Environment) {
 					env.minion.modDB:AddList(env.player.itemList["Weapon 2"].modList)
 				end
 			end
-			if env.minion.itemSet or env.minion.uses then
-				for slotName, slot in pairs(env.build.itemsTab.slots) do
-					if env.minion.uses[slotName] then
-						local item
-						if env.minion.itemSet then
-							if slot.weaponSet == 1 and env.minion.itemSet.useSecondWeaponSet then
-								slotName = slotName .. " Swap"
-							end
-							item = env.build.itemsTab.items[env.minion.itemSet[slotName].selItemId]
-						else
-							item = env.player.itemList[slotName]
-						end
-						if item then
-							env.minion.itemList[slotName] = item
-							env.minion.modDB:AddList(item.modList or item.slotModList[slot.slotNum])
-						end
-					end
-				end
-			end
-			if modDB:Flag(nil, "StrengthAddedToMinions") then
-				env.minion.modDB:NewMod("Str", "BASE", round(calcLib.val(modDB, "Str")), "Player")
-			end
-			if modDB:Flag(nil, "HalfStrengthAddedToMinions") then
-				env.minion.modDB:NewMod("Str", "BASE", round(calcLib.val(modDB, "Str") * 0.5), "Player")
-			end
-		end
-	*/
+		*/
+		for _, slotName := range []string{"Weapon 1", "Weapon 2", "Helmet", "Body Armour", "Gloves", "Boots"} {
+			if !minion.Uses[slotName] {
+				continue
+			}
+			if item, modList, _ := minionItem(env, minion, slotName); item != nil {
+				minion.ItemList[slotName] = item
+				minionDB.AddList(modList)
+			}
+		}
+	}
 
 	/*
 		TODO Aegis
@@ -248,13 +246,11 @@ func PerformCalc(env *Environment) {
 				modDB:NewMod("AlreadyGlobalWarcryCooldown", "FLAG", true, "Config") -- Prevents effect from applying multiple times
 			end
 		*/
-		/*
-			TODO Minion
-			if activeSkill.minion and activeSkill.minion.minionData and activeSkill.minion.minionData.limit then
-				local limit = activeSkill.skillModList:Sum("BASE", nil, activeSkill.minion.minionData.limit)
-				output[activeSkill.minion.minionData.limit] = m_max(limit, output[activeSkill.minion.minionData.limit] or 0)
-			end
-		*/
+		if activeSkill.Minion != nil && activeSkill.Minion.MinionData != nil && activeSkill.Minion.MinionData.Limit != "" {
+			limitName := activeSkill.Minion.MinionData.Limit
+			limit := activeSkill.SkillModList.Sum(mod.TypeBase, nil, limitName)
+			env.Player.Output[limitName] = max(limit, env.Player.Output[limitName])
+		}
 		/*
 			TODO Buffs
 			if env.mode_buffs and activeSkill.skillFlags.warcry then
@@ -363,37 +359,31 @@ func PerformCalc(env *Environment) {
 				end
 			end
 		*/
-		/*
-			TODO Triggers
-			if activeSkill.skillData.triggeredByBrand and not activeSkill.skillFlags.minion then
-				activeSkill.skillData.triggered = true
-				local spellCount, quality = 0
-				for _, skill in ipairs(env.player.activeSkillList) do
-					local match1 = skill.activeEffect.grantedEffect.fromItem and skill.socketGroup.slot == activeSkill.socketGroup.slot
-					local match2 = not skill.activeEffect.grantedEffect.fromItem and skill.socketGroup == activeSkill.socketGroup
-					if skill.skillData.triggeredByBrand and (match1 or match2) then
-						spellCount = spellCount + 1
-					end
-					if skill.activeEffect.grantedEffect.name == "Arcanist Brand" and (match1 or match2) then
-						quality = skill.activeEffect.quality / 2
-					end
-				end
-				addTriggerIncMoreMods(activeSkill, env.player.mainSkill)
-				activeSkill.skillModList:NewMod("ArcanistSpellsLinked", "BASE", spellCount, "Skill")
-				activeSkill.skillModList:NewMod("BrandActivationFrequency", "INC", quality, "Skill")
-			end
-			if activeSkill.skillData.triggeredOnDeath and not activeSkill.skillFlags.minion then
-				activeSkill.skillData.triggered = true
-				for _, value in ipairs(activeSkill.skillModList:Tabulate("INC", env.player.mainSkill.skillCfg, "TriggeredDamage")) do
-					activeSkill.skillModList:NewMod("Damage", "INC", value.mod.value, value.mod.source, value.mod.flags, value.mod.keywordFlags, unpack(value.mod))
-				end
-				for _, value in ipairs(activeSkill.skillModList:Tabulate("MORE", env.player.mainSkill.skillCfg, "TriggeredDamage")) do
-					activeSkill.skillModList:NewMod("Damage", "MORE", value.mod.value, value.mod.source, value.mod.flags, value.mod.keywordFlags, unpack(value.mod))
-				end
-				-- Set trigger time to 1 min in ms ( == 6000 ). Technically any large value would do.
-				activeSkill.skillData.triggerTime = 60 * 1000
-			end
-		*/
+		if skillDataFlag(activeSkill, "triggeredByBrand") && !activeSkill.SkillFlags[SkillFlagMinion] {
+			activeSkill.SkillData["Triggered"] = true
+			spellCount := float64(0)
+			quality := float64(0)
+			for _, skill := range env.Player.ActiveSkillList {
+				if !sameTriggerGroup(skill, activeSkill) {
+					continue
+				}
+				if skillDataFlag(skill, "triggeredByBrand") {
+					spellCount++
+				}
+				if skill.ActiveEffect.GrantedEffect.Raw.ID == "BrandSupport" {
+					quality = float64(skill.ActiveEffect.Quality) / 2
+				}
+			}
+			addTriggerIncMoreMods(activeSkill, env.Player.MainSkill)
+			activeSkill.SkillModList.AddMod(mod.NewFloat("ArcanistSpellsLinked", mod.TypeBase, spellCount).Source("Skill"))
+			activeSkill.SkillModList.AddMod(mod.NewFloat("BrandActivationFrequency", mod.TypeIncrease, quality).Source("Skill"))
+		}
+		if skillDataFlag(activeSkill, "triggeredOnDeath") && !activeSkill.SkillFlags[SkillFlagMinion] {
+			activeSkill.SkillData["Triggered"] = true
+			addTriggerIncMoreMods(activeSkill, env.Player.MainSkill)
+			// Any large trigger time would do, the skill is only triggered once on death
+			activeSkill.SkillData["TriggerTime"] = float64(60 * 1000)
+		}
 		/*
 			TODO -- The Saviour
 			if activeSkill.activeEffect.grantedEffect.name == "Reflection" or activeSkill.skillData.triggeredBySaviour then
@@ -403,18 +393,13 @@ func PerformCalc(env *Environment) {
 		*/
 	}
 
-	/*
-		TODO Breakdown Module
-		local breakdown = nil
-		if env.mode == "CALCS" then
-			-- Initialise breakdown module
-			breakdown = LoadModule(calcs.breakdownModule, modDB, output, env.player)
-			env.player.breakdown = breakdown
-			if env.minion then
-				env.minion.breakdown = LoadModule(calcs.breakdownModule, env.minion.modDB, env.minion.output, env.minion)
-			end
-		end
-	*/
+	if env.Mode == OutputModeCalcs {
+		// Initialise breakdown module
+		env.Player.Breakdown = newBreakdown(env.ModDB)
+		if env.Minion != nil {
+			env.Minion.Breakdown = newBreakdown(env.Minion.ModDB)
+		}
+	}
 
 	/*
 		TODO -- Special handling of Mageblood
@@ -513,130 +498,59 @@ func PerformCalc(env *Environment) {
 	// Calculate attributes and life/mana pools
 	doActorAttribsPoolsConditions(env, env.Player)
 
-	/*
-		TODO Calculate minion attributes and life/mana pools
-		if env.minion then
-			for _, value in ipairs(env.player.mainSkill.skillModList:List(env.player.mainSkill.skillCfg, "MinionModifier")) do
-				if not value.type or env.minion.type == value.type then
-					env.minion.modDB:AddMod(value.mod)
-				end
-			end
+	// Calculate minion attributes and life/mana pools
+	if env.Minion != nil {
+		mainSkill := env.Player.MainSkill
+		for _, value := range utils.CastSlice[mod.MinionModifier](mainSkill.SkillModList.List(mainSkill.SkillCfg, "MinionModifier")) {
+			// TODO value.type
+			env.Minion.ModDB.AddMod(value.Mod)
+		}
+		/*
+			TODO Minion keystones
 			for _, name in ipairs(env.minion.modDB:List(nil, "Keystone")) do
 				if env.spec.tree.keystoneMap[name] then
 					env.minion.modDB:AddList(env.spec.tree.keystoneMap[name].modList)
 				end
 			end
-			doActorAttribsPoolsConditions(env, env.minion)
-		end
-	*/
+		*/
+		if env.ModDB.Flag(nil, "StrengthAddedToMinions") {
+			env.Minion.ModDB.AddMod(mod.NewFloat("Str", mod.TypeBase, math.Round(CalcVal(env.ModDB, "Str", nil))).Source("Player"))
+		}
+		if env.ModDB.Flag(nil, "HalfStrengthAddedToMinions") {
+			env.Minion.ModDB.AddMod(mod.NewFloat("Str", mod.TypeBase, math.Round(CalcVal(env.ModDB, "Str", nil)*0.5)).Source("Player"))
+		}
+		doActorAttribsPoolsConditions(env, env.Minion)
+	}
 
-	/*
-		TODO -- Calculate skill life and mana reservations
-		env.player.reserved_LifeBase = 0
-		env.player.reserved_LifePercent = modDB:Sum("BASE", nil, "ExtraLifeReserved")
-		env.player.reserved_ManaBase = 0
-		env.player.reserved_ManaPercent = 0
-		if breakdown then
-			breakdown.LifeReserved = { reservations = { } }
-			breakdown.ManaReserved = { reservations = { } }
-		end
-		for _, activeSkill in ipairs(env.player.activeSkillList) do
-			if activeSkill.skillTypes[SkillType.HasReservation] and not activeSkill.skillTypes[SkillType.ReservationBecomesCost] then
-				local skillModList = activeSkill.skillModList
-				local skillCfg = activeSkill.skillCfg
-				local mult = skillModList:More(skillCfg, "SupportManaMultiplier")
-				local pool = { ["Mana"] = { }, ["Life"] = { } }
-				pool.Mana.baseFlat = activeSkill.skillData.manaReservationFlat or activeSkill.activeEffect.grantedEffectLevel.manaReservationFlat or 0
-				if skillModList:Flag(skillCfg, "ManaCostGainAsReservation") and activeSkill.activeEffect.grantedEffectLevel.cost then
-					pool.Mana.baseFlat = skillModList:Sum("BASE", skillCfg, "ManaCostBase") + (activeSkill.activeEffect.grantedEffectLevel.cost.Mana or 0)
-				end
-				pool.Mana.basePercent = activeSkill.skillData.manaReservationPercent or activeSkill.activeEffect.grantedEffectLevel.manaReservationPercent or 0
-				pool.Life.baseFlat = activeSkill.skillData.lifeReservationFlat or activeSkill.activeEffect.grantedEffectLevel.lifeReservationFlat or 0
-				if skillModList:Flag(skillCfg, "LifeCostGainAsReservation") and activeSkill.activeEffect.grantedEffectLevel.cost then
-					pool.Life.baseFlat = skillModList:Sum("BASE", skillCfg, "LifeCostBase") + (activeSkill.activeEffect.grantedEffectLevel.cost.Life or 0)
-				end
-				pool.Life.basePercent = activeSkill.skillData.lifeReservationPercent or activeSkill.activeEffect.grantedEffectLevel.lifeReservationPercent or 0
-				if skillModList:Flag(skillCfg, "BloodMagicReserved") then
-					pool.Life.baseFlat = pool.Life.baseFlat + pool.Mana.baseFlat
-					pool.Mana.baseFlat = 0
-					activeSkill.skillData["LifeReservationFlatForced"] = activeSkill.skillData["ManaReservationFlatForced"]
-					activeSkill.skillData["ManaReservationFlatForced"] = nil
-					pool.Life.basePercent = pool.Life.basePercent + pool.Mana.basePercent
-					pool.Mana.basePercent = 0
-					activeSkill.skillData["LifeReservationPercentForced"] = activeSkill.skillData["ManaReservationPercentForced"]
-					activeSkill.skillData["ManaReservationPercentForced"] = nil
-				end
-				for name, values in pairs(pool) do
-					values.more = skillModList:More(skillCfg, name.."Reserved", "Reserved")
-					values.inc = skillModList:Sum("INC", skillCfg, name.."Reserved", "Reserved")
-					values.efficiency = m_max(skillModList:Sum("INC", skillCfg, name.."ReservationEfficiency", "ReservationEfficiency"), -100)
-					-- used for Arcane Cloak calculations in ModStore.GetStat
-					env.player[name.."Efficiency"] = values.efficiency
-					if activeSkill.skillData[name.."ReservationFlatForced"] then
-						values.reservedFlat = activeSkill.skillData[name.."ReservationFlatForced"]
-					else
-						local baseFlatVal = m_floor(values.baseFlat * mult)
-						values.reservedFlat = 0
-						if values.more > 0 and values.inc > -100 and baseFlatVal ~= 0 then
-							values.reservedFlat = m_max(round(baseFlatVal * (100 + values.inc) / 100 * values.more / (1 + values.efficiency / 100), 0), 0)
-						end
-					end
-					if activeSkill.skillData[name.."ReservationPercentForced"] then
-						values.reservedPercent = activeSkill.skillData[name.."ReservationPercentForced"]
-					else
-						local basePercentVal = values.basePercent * mult
-						values.reservedPercent = 0
-						if values.more > 0 and values.inc > -100 and basePercentVal ~= 0 then
-							values.reservedPercent = m_max(round(basePercentVal * (100 + values.inc) / 100 * values.more / (1 + values.efficiency / 100), 2), 0)
-						end
-					end
-					if activeSkill.activeMineCount then
-						values.reservedFlat = values.reservedFlat * activeSkill.activeMineCount
-						values.reservedPercent = values.reservedPercent * activeSkill.activeMineCount
-					end
-					if values.reservedFlat ~= 0 then
-						activeSkill.skillData[name.."ReservedBase"] = values.reservedFlat
-						env.player["reserved_"..name.."Base"] = env.player["reserved_"..name.."Base"] + values.reservedFlat
-						if breakdown then
-							t_insert(breakdown[name.."Reserved"].reservations, {
-								skillName = activeSkill.activeEffect.grantedEffect.name,
-								base = values.baseFlat,
-								mult = mult ~= 1 and ("x "..mult),
-								more = values.more ~= 1 and ("x "..values.more),
-								inc = values.inc ~= 0 and ("x "..(1 + values.inc / 100)),
-								efficiency = values.efficiency ~= 0 and ("x " .. 1 / (1 + values.efficiency / 100)),
-								total = values.reservedFlat,
-							})
-						end
-					end
-					if values.reservedPercent ~= 0 then
-						activeSkill.skillData[name.."ReservedPercent"] = values.reservedPercent
-						activeSkill.skillData[name.."ReservedBase"] = (activeSkill.skillData[name.."ReservedBase"] or 0) + m_ceil(output[name] * values.reservedPercent / 100)
-						env.player["reserved_"..name.."Percent"] = env.player["reserved_"..name.."Percent"] + values.reservedPercent
-						if breakdown then
-							t_insert(breakdown[name.."Reserved"].reservations, {
-								skillName = activeSkill.activeEffect.grantedEffect.name,
-								base = values.basePercent .. "%",
-								mult = mult ~= 1 and ("x "..mult),
-								more = values.more ~= 1 and ("x "..values.more),
-								inc = values.inc ~= 0 and ("x "..(1 + values.inc / 100)),
-								efficiency = values.efficiency ~= 0 and ("x " .. 1 / (1 + values.efficiency / 100)),
-								total = values.reservedPercent .. "%",
-							})
-						end
-					end
-				end
-			end
-		end
-	*/
+	// Calculate skill life and mana reservations
+	env.Player.ReservedLifeBase = 0
+	env.Player.ReservedLifePercent = env.ModDB.Sum(mod.TypeBase, nil, "ExtraLifeReserved")
+	env.Player.ReservedManaBase = 0
+	env.Player.ReservedManaPercent = 0
+	reservationLines := map[string][]string{"Life": nil, "Mana": nil}
+	for _, activeSkill := range env.Player.ActiveSkillList {
+		if !activeSkill.SkillTypes[data.SkillTypeHasReservation] || activeSkill.SkillTypes[data.SkillTypeReservationBecomesCost] {
+			continue
+		}
 
-	/*
-		TODO -- Set the life/mana reservations
-		doActorLifeManaReservation(env.player)
-		if env.minion then
-			doActorLifeManaReservation(env.minion)
-		end
-	*/
+		calculateSkillReservation(env, activeSkill, reservationLines)
+	}
+
+	// Set the life/mana reservations
+	doActorLifeManaReservation(env.Player)
+	for _, pool := range []string{"Life", "Mana"} {
+		if env.Player.Breakdown != nil {
+			env.Player.Breakdown.Set(pool+"Reserved", env.Player.Output[pool+"Reserved"], reservationLines[pool]...)
+		}
+		if env.Player.Output[pool+"Unreserved"] < 0 {
+			env.Player.Output[pool+"OverReserved"] = 1
+			env.DebugErrors = append(env.DebugErrors, fmt.Sprintf("Build reserves more %s than it has (%.0f%%)", strings.ToLower(pool), 100-env.Player.Output[pool+"UnreservedPercent"]))
+		}
+	}
+
+	if env.Minion != nil {
+		doActorLifeManaReservation(env.Minion)
+	}
 
 	/*
 		TODO -- Process attribute requirements
@@ -713,32 +627,30 @@ func PerformCalc(env *Environment) {
 		end
 	*/
 
-	/*
-		TODO -- Calculate number of active heralds
-		if env.mode_buffs then
-			local heraldList = { }
-			for _, activeSkill in ipairs(env.player.activeSkillList) do
-				if activeSkill.skillTypes[SkillType.Herald] and not heraldList[activeSkill.skillCfg.skillName] then
-					heraldList[activeSkill.skillCfg.skillName] = true
-					modDB.multipliers["Herald"] = (modDB.multipliers["Herald"] or 0) + 1
-					modDB.conditions["AffectedByHerald"] = true
-				end
-			end
-		end
-	*/
+	// Calculate number of active heralds
+	if env.ModeBuffs {
+		heraldList := make(map[string]bool)
+		for _, activeSkill := range env.Player.ActiveSkillList {
+			skillName := activeSkill.ActiveEffect.GrantedEffect.Raw.GetActiveSkill().DisplayedName
+			if activeSkill.SkillTypes[data.SkillTypeHerald] && !heraldList[skillName] {
+				heraldList[skillName] = true
+				env.ModDB.Multipliers["Herald"]++
+				env.ModDB.Conditions["AffectedByHerald"] = true
+			}
+		}
+	}
 
-	/*
-		TODO -- Calculate number of active auras affecting self
-		if env.mode_buffs then
-			local auraList = { }
-			for _, activeSkill in ipairs(env.player.activeSkillList) do
-				if activeSkill.skillTypes[SkillType.Aura] and not activeSkill.skillTypes[SkillType.RemoteMined] and not activeSkill.skillData.auraCannotAffectSelf and not auraList[activeSkill.skillCfg.skillName] then
-					auraList[activeSkill.skillCfg.skillName] = true
-					modDB.multipliers["AuraAffectingSelf"] = (modDB.multipliers["AuraAffectingSelf"] or 0) + 1
-				end
-			end
-		end
-	*/
+	// Calculate number of active auras affecting self
+	if env.ModeBuffs {
+		auraList := make(map[string]bool)
+		for _, activeSkill := range env.Player.ActiveSkillList {
+			skillName := activeSkill.ActiveEffect.GrantedEffect.Raw.GetActiveSkill().DisplayedName
+			if activeSkill.SkillTypes[data.SkillTypeAura] && !activeSkill.SkillTypes[data.SkillTypeRemoteMined] && !skillDataFlag(activeSkill, "auraCannotAffectSelf") && !auraList[skillName] {
+				auraList[skillName] = true
+				env.ModDB.Multipliers["AuraAffectingSelf"]++
+			}
+		}
+	}
 
 	/*
 		TODO -- Deal with Consecrated Ground
@@ -761,214 +673,182 @@ func PerformCalc(env *Environment) {
 		end
 	*/
 
-	/*
-		TODO -- Combine buffs/debuffs
-		local buffs = { }
-		env.buffs = buffs
-		local guards = { }
-		local minionBuffs = { }
-		env.minionBuffs = minionBuffs
-		local debuffs = { }
-		env.debuffs = debuffs
-		local curses = { }
-		local minionCurses = {
-			limit = 1,
-		}
-		for spectreId = 1, #env.spec.build.spectreList do
-			local spectreData = data.minions[env.spec.build.spectreList[spectreId]]
-			for modId = 1, #spectreData.modList do
-				local modData = spectreData.modList[modId]
-				if modData.name == "EnemyCurseLimit" then
-					minionCurses.limit = modData.value + 1
-					break
-				end
-			end
-		end
-		local affectedByAura = { }
-		for _, activeSkill in ipairs(env.player.activeSkillList) do
-			local skillModList = activeSkill.skillModList
-			local skillCfg = activeSkill.skillCfg
-			for _, buff in ipairs(activeSkill.buffList) do
-				--Skip adding buff if reservation exceeds maximum
-				for _, value in ipairs({"Mana", "Life"}) do
-					if activeSkill.skillData[value.."ReservedBase"] and activeSkill.skillData[value.."ReservedBase"] > env.player.output[value] then
-						goto disableAura
-					end
-				end
-				if buff.cond and not skillModList:GetCondition(buff.cond, skillCfg) then
-					-- Nothing!
-				elseif buff.enemyCond and not enemyDB:GetCondition(buff.enemyCond) then
-					-- Also nothing :/
-				elseif buff.type == "Buff" then
-					if env.mode_buffs and (not activeSkill.skillFlags.totem or buff.allowTotemBuff) then
-						local skillCfg = buff.activeSkillBuff and skillCfg
-						local modStore = buff.activeSkillBuff and skillModList or modDB
-					 	if not buff.applyNotPlayer then
-							activeSkill.buffSkill = true
-							modDB.conditions["AffectedBy"..buff.name:gsub(" ","")] = true
-							local srcList = new("ModList")
-							local inc = modStore:Sum("INC", skillCfg, "BuffEffect", "BuffEffectOnSelf", "BuffEffectOnPlayer") + skillModList:Sum("INC", skillCfg, buff.name:gsub(" ", "").."Effect")
-							local more = modStore:More(skillCfg, "BuffEffect", "BuffEffectOnSelf")
-							srcList:ScaleAddList(buff.modList, (1 + inc / 100) * more)
-							mergeBuff(srcList, buffs, buff.name)
-							mergeBuff(buff.unscalableModList, buffs, buff.name)
-							if activeSkill.skillData.thisIsNotABuff then
-								buffs[buff.name].notBuff = true
-							end
-						end
-						if env.minion and (buff.applyMinions or buff.applyAllies) then
-							activeSkill.minionBuffSkill = true
-							env.minion.modDB.conditions["AffectedBy"..buff.name:gsub(" ","")] = true
-							local srcList = new("ModList")
-							local inc = modStore:Sum("INC", skillCfg, "BuffEffect", "BuffEffectOnMinion") + env.minion.modDB:Sum("INC", nil, "BuffEffectOnSelf")
-							local more = modStore:More(skillCfg, "BuffEffect", "BuffEffectOnMinion") * env.minion.modDB:More(nil, "BuffEffectOnSelf")
-							srcList:ScaleAddList(buff.modList, (1 + inc / 100) * more)
-							mergeBuff(srcList, minionBuffs, buff.name)
-							mergeBuff(buff.unscalableModList, minionBuffs, buff.name)
-						end
-					end
-				elseif buff.type == "Guard" then
-					if env.mode_buffs and (not activeSkill.skillFlags.totem or buff.allowTotemBuff) then
-						local skillCfg = buff.activeSkillBuff and skillCfg
-						local modStore = buff.activeSkillBuff and skillModList or modDB
-					 	if not buff.applyNotPlayer then
-							activeSkill.buffSkill = true
-							local srcList = new("ModList")
-							local inc = modStore:Sum("INC", skillCfg, "BuffEffect", "BuffEffectOnSelf", "BuffEffectOnPlayer")
-							local more = modStore:More(skillCfg, "BuffEffect", "BuffEffectOnSelf")
-							srcList:ScaleAddList(buff.modList, (1 + inc / 100) * more)
-							mergeBuff(srcList, guards, buff.name)
-							mergeBuff(buff.unscalableModList, guards, buff.name)
-						end
-					end
-				elseif buff.type == "Aura" then
-					if env.mode_buffs then
-						-- Check for extra modifiers to apply to aura skills
-						local extraAuraModList = { }
-						for _, value in ipairs(modDB:List(skillCfg, "ExtraAuraEffect")) do
-							local add = true
-							for _, mod in ipairs(extraAuraModList) do
-								if modLib.compareModParams(mod, value.mod) then
-									mod.value = mod.value + value.mod.value
-									add = false
-									break
-								end
-							end
-							if add then
-								t_insert(extraAuraModList, copyTable(value.mod, true))
-							end
-						end
-						if not activeSkill.skillData.auraCannotAffectSelf then
-							activeSkill.buffSkill = true
-							affectedByAura[env.player] = true
-							if buff.name:sub(1,4) == "Vaal" then
-								modDB.conditions["AffectedBy"..buff.name:sub(6):gsub(" ","")] = true
-							end
-							modDB.conditions["AffectedBy"..buff.name:gsub(" ","")] = true
-							local srcList = new("ModList")
-							local inc = skillModList:Sum("INC", skillCfg, "AuraEffect", "BuffEffect", "BuffEffectOnSelf", "AuraEffectOnSelf", "AuraBuffEffect", "SkillAuraEffectOnSelf")
-							local more = skillModList:More(skillCfg, "AuraEffect", "BuffEffect", "BuffEffectOnSelf", "AuraEffectOnSelf", "AuraBuffEffect", "SkillAuraEffectOnSelf")
-							local mult = (1 + inc / 100) * more
-							srcList:ScaleAddList(buff.modList, mult)
-							srcList:ScaleAddList(extraAuraModList, mult)
-							mergeBuff(srcList, buffs, buff.name)
-						end
-						if env.minion and not (modDB:Flag(nil, "SelfAurasCannotAffectAllies") or modDB:Flag(nil, "SelfAurasOnlyAffectYou") or modDB:Flag(nil, "SelfAuraSkillsCannotAffectAllies")) then
-							activeSkill.minionBuffSkill = true
-							affectedByAura[env.minion] = true
-							env.minion.modDB.conditions["AffectedBy"..buff.name:gsub(" ","")] = true
-							local srcList = new("ModList")
-							local inc = skillModList:Sum("INC", skillCfg, "AuraEffect", "BuffEffect") + env.minion.modDB:Sum("INC", nil, "BuffEffectOnSelf", "AuraEffectOnSelf")
-							local more = skillModList:More(skillCfg, "AuraEffect", "BuffEffect") * env.minion.modDB:More(nil, "BuffEffectOnSelf", "AuraEffectOnSelf")
-							local mult = (1 + inc / 100) * more
-							srcList:ScaleAddList(buff.modList, mult)
-							srcList:ScaleAddList(extraAuraModList, mult)
-							mergeBuff(srcList, minionBuffs, buff.name)
-						end
-					end
-				elseif buff.type == "Debuff" or buff.type == "AuraDebuff" then
-					local stackCount
-					if buff.stackVar then
-						stackCount = skillModList:Sum("BASE", skillCfg, "Multiplier:"..buff.stackVar)
-						if buff.stackLimit then
-							stackCount = m_min(stackCount, buff.stackLimit)
-						elseif buff.stackLimitVar then
-							stackCount = m_min(stackCount, skillModList:Sum("BASE", skillCfg, "Multiplier:"..buff.stackLimitVar))
-						end
-					else
-						stackCount = activeSkill.skillData.stackCount or 1
-					end
-					if env.mode_effective and stackCount > 0 then
-						activeSkill.debuffSkill = true
-						modDB.conditions["AffectedBy"..buff.name:gsub(" ","")] = true
-						local srcList = new("ModList")
-						local mult = 1
-						if buff.type == "AuraDebuff" then
-							mult = 0
-							if not modDB:Flag(nil, "SelfAurasOnlyAffectYou") then
-								local inc = skillModList:Sum("INC", skillCfg, "AuraEffect", "BuffEffect", "DebuffEffect")
-								local more = skillModList:More(skillCfg, "AuraEffect", "BuffEffect", "DebuffEffect")
-								mult = (1 + inc / 100) * more
-							end
-						end
-						if buff.type == "Debuff" then
-							local inc = skillModList:Sum("INC", skillCfg, "DebuffEffect")
-							local more = skillModList:More(skillCfg, "DebuffEffect")
-							mult = (1 + inc / 100) * more
-						end
-						srcList:ScaleAddList(buff.modList, mult * stackCount)
-						if activeSkill.skillData.stackCount or buff.stackVar then
-							srcList:NewMod("Multiplier:"..buff.name.."Stack", "BASE", stackCount, buff.name)
-						end
-						mergeBuff(srcList, debuffs, buff.name)
-					end
-				elseif buff.type == "Curse" or buff.type == "CurseBuff" then
-					local mark = activeSkill.skillTypes[SkillType.Mark]
-					if env.mode_effective and (not enemyDB:Flag(nil, "Hexproof") or modDB:Flag(nil, "CursesIgnoreHexproof")) or mark then
-						local curse = {
-							name = buff.name,
-							fromPlayer = true,
-							priority = determineCursePriority(buff.name, activeSkill),
-							isMark = mark,
-							ignoreHexLimit = modDB:Flag(activeSkill.skillCfg, "CursesIgnoreHexLimit") and not mark or false,
-							socketedCursesHexLimit = modDB:Flag(activeSkill.skillCfg, "SocketedCursesAdditionalLimit")
-						}
-						local inc = skillModList:Sum("INC", skillCfg, "CurseEffect") + enemyDB:Sum("INC", nil, "CurseEffectOnSelf")
-						if activeSkill.skillTypes[SkillType.Aura] then
-							inc = inc + skillModList:Sum("INC", skillCfg, "AuraEffect")
-						end
-						local more = skillModList:More(skillCfg, "CurseEffect")
-						-- This is non-ideal, but the only More for enemy is the boss effect
-						if not curse.isMark then
-							more = more * enemyDB:More(nil, "CurseEffectOnSelf")
-						end
-						local mult = 0
-						if not (modDB:Flag(nil, "SelfAurasOnlyAffectYou") and activeSkill.skillTypes[SkillType.Aura]) then --If your aura only effect you blasphemy does nothing
-							mult = (1 + inc / 100) * more
-						end
-						if buff.type == "Curse" then
-							curse.modList = new("ModList")
-							curse.modList:ScaleAddList(buff.modList, mult)
-						else
-							-- Curse applies a buff; scale by curse effect, then buff effect
-							local temp = new("ModList")
-							temp:ScaleAddList(buff.modList, mult)
-							curse.buffModList = new("ModList")
-							local buffInc = modDB:Sum("INC", skillCfg, "BuffEffectOnSelf")
-							local buffMore = modDB:More(skillCfg, "BuffEffectOnSelf")
-							curse.buffModList:ScaleAddList(temp, (1 + buffInc / 100) * buffMore)
-							if env.minion then
-								curse.minionBuffModList = new("ModList")
-								local buffInc = env.minion.modDB:Sum("INC", nil, "BuffEffectOnSelf")
-								local buffMore = env.minion.modDB:More(nil, "BuffEffectOnSelf")
-								curse.minionBuffModList:ScaleAddList(temp, (1 + buffInc / 100) * buffMore)
-							end
-						end
-						t_insert(curses, curse)
-					end
-				end
-				::disableAura::
-			end
+	// Combine buffs/debuffs
+	buffs := make(map[string]*moddb.ModList)
+	notBuffs := make(map[string]bool)
+	guards := make(map[string]*moddb.ModList)
+	minionBuffs := make(map[string]*moddb.ModList)
+	debuffs := make(map[string]*moddb.ModList)
+	curses := make([]*curse, 0)
+	// TODO Minion curses and the spectre curse limit
+	affectedByAura := make(map[*moddb.ModDB]bool)
+	for _, activeSkill := range env.Player.ActiveSkillList {
+		skillModList := activeSkill.SkillModList
+		skillCfg := activeSkill.SkillCfg
+
+		// Skip adding buffs if reservation exceeds maximum
+		buffList := activeSkill.BuffList
+		if reservationExceedsPool(env, activeSkill) {
+			buffList = nil
+		}
+
+		for _, buff := range buffList {
+			buffName := strings.ReplaceAll(buff.Name, " ", "")
+			switch buff.Type {
+			case "Buff", "Guard":
+				if !env.ModeBuffs || (activeSkill.SkillFlags[SkillFlagTotem] && !buff.AllowTotemBuff) {
+					continue
+				}
+
+				var buffCfg *moddb.ListCfg
+				var modStore moddb.ModStoreFuncs = env.ModDB
+				if buff.ActiveSkillBuff {
+					buffCfg = skillCfg
+					modStore = skillModList
+				}
+
+				if !buff.ApplyNotPlayer {
+					srcList := moddb.NewModList()
+					inc := modStore.Sum(mod.TypeIncrease, buffCfg, "BuffEffect", "BuffEffectOnSelf", "BuffEffectOnPlayer")
+					more := modStore.More(buffCfg, "BuffEffect", "BuffEffectOnSelf")
+					if buff.Type == "Guard" {
+						srcList.ScaleAddList(buff.ModList, (1+inc/100)*more)
+						mergeBuff(srcList, guards, buff.Name)
+						mergeBuff(buff.UnscalableModList, guards, buff.Name)
+						continue
+					}
+
+					env.ModDB.Conditions["AffectedBy"+buffName] = true
+					inc += skillModList.Sum(mod.TypeIncrease, buffCfg, buffName+"Effect")
+					srcList.ScaleAddList(buff.ModList, (1+inc/100)*more)
+					mergeBuff(srcList, buffs, buff.Name)
+					mergeBuff(buff.UnscalableModList, buffs, buff.Name)
+					if skillDataFlag(activeSkill, "thisIsNotABuff") {
+						notBuffs[buff.Name] = true
+					}
+				}
+
+				if buff.Type == "Buff" && env.Minion != nil && (buff.ApplyMinions || buff.ApplyAllies) {
+					env.Minion.ModDB.Conditions["AffectedBy"+buffName] = true
+					srcList := moddb.NewModList()
+					inc := modStore.Sum(mod.TypeIncrease, buffCfg, "BuffEffect", "BuffEffectOnMinion") + env.Minion.ModDB.Sum(mod.TypeIncrease, nil, "BuffEffectOnSelf")
+					more := modStore.More(buffCfg, "BuffEffect", "BuffEffectOnMinion") * env.Minion.ModDB.More(nil, "BuffEffectOnSelf")
+					srcList.ScaleAddList(buff.ModList, (1+inc/100)*more)
+					mergeBuff(srcList, minionBuffs, buff.Name)
+					mergeBuff(buff.UnscalableModList, minionBuffs, buff.Name)
+				}
+			case "Aura":
+				if !env.ModeBuffs {
+					continue
+				}
+
+				// Check for extra modifiers to apply to aura skills
+				extraAuraModList := moddb.NewModList()
+				for _, value := range utils.CastSlice[mod.ExtraAuraEffect](env.ModDB.List(skillCfg, "ExtraAuraEffect")) {
+					mergeBuffMod(extraAuraModList, value.Mod)
+				}
+
+				if !skillDataFlag(activeSkill, "auraCannotAffectSelf") {
+					affectedByAura[env.ModDB] = true
+					if strings.HasPrefix(buff.Name, "Vaal ") {
+						env.ModDB.Conditions["AffectedBy"+strings.ReplaceAll(buff.Name[5:], " ", "")] = true
+					}
+					env.ModDB.Conditions["AffectedBy"+buffName] = true
+					srcList := moddb.NewModList()
+					inc := skillModList.Sum(mod.TypeIncrease, skillCfg, "AuraEffect", "BuffEffect", "BuffEffectOnSelf", "AuraEffectOnSelf", "AuraBuffEffect", "SkillAuraEffectOnSelf")
+					more := skillModList.More(skillCfg, "AuraEffect", "BuffEffect", "BuffEffectOnSelf", "AuraEffectOnSelf", "AuraBuffEffect", "SkillAuraEffectOnSelf")
+					mult := (1 + inc/100) * more
+					srcList.ScaleAddList(buff.ModList, mult)
+					srcList.ScaleAddList(extraAuraModList, mult)
+					mergeBuff(srcList, buffs, buff.Name)
+				}
+
+				if env.Minion != nil && !env.ModDB.Flag(nil, "SelfAurasCannotAffectAllies", "SelfAurasOnlyAffectYou", "SelfAuraSkillsCannotAffectAllies") {
+					affectedByAura[env.Minion.ModDB] = true
+					env.Minion.ModDB.Conditions["AffectedBy"+buffName] = true
+					srcList := moddb.NewModList()
+					inc := skillModList.Sum(mod.TypeIncrease, skillCfg, "AuraEffect", "BuffEffect") + env.Minion.ModDB.Sum(mod.TypeIncrease, nil, "BuffEffectOnSelf", "AuraEffectOnSelf")
+					more := skillModList.More(skillCfg, "AuraEffect", "BuffEffect") * env.Minion.ModDB.More(nil, "BuffEffectOnSelf", "AuraEffectOnSelf")
+					mult := (1 + inc/100) * more
+					srcList.ScaleAddList(buff.ModList, mult)
+					srcList.ScaleAddList(extraAuraModList, mult)
+					mergeBuff(srcList, minionBuffs, buff.Name)
+				}
+			case "Debuff", "AuraDebuff":
+				// TODO Stack variables, no skill data provides a stack count yet so debuffs apply once
+				if !env.ModeEffective {
+					continue
+				}
+
+				env.ModDB.Conditions["AffectedBy"+buffName] = true
+				srcList := moddb.NewModList()
+				mult := float64(0)
+				if buff.Type == "AuraDebuff" {
+					if !env.ModDB.Flag(nil, "SelfAurasOnlyAffectYou") {
+						inc := skillModList.Sum(mod.TypeIncrease, skillCfg, "AuraEffect", "BuffEffect", "DebuffEffect")
+						more := skillModList.More(skillCfg, "AuraEffect", "BuffEffect", "DebuffEffect")
+						mult = (1 + inc/100) * more
+					}
+				} else {
+					inc := skillModList.Sum(mod.TypeIncrease, skillCfg, "DebuffEffect")
+					more := skillModList.More(skillCfg, "DebuffEffect")
+					mult = (1 + inc/100) * more
+				}
+				srcList.ScaleAddList(buff.ModList, mult)
+				mergeBuff(srcList, debuffs, buff.Name)
+			case "Curse", "CurseBuff":
+				mark := activeSkill.SkillTypes[data.SkillTypeMark]
+				if !mark && (!env.ModeEffective || (env.EnemyModDB.Flag(nil, "Hexproof") && !env.ModDB.Flag(nil, "CursesIgnoreHexproof"))) {
+					continue
+				}
+
+				newCurse := &curse{
+					name:                   buff.Name,
+					fromPlayer:             true,
+					priority:               determineCursePriority(buff.Name, activeSkill),
+					isMark:                 mark,
+					ignoreHexLimit:         env.ModDB.Flag(skillCfg, "CursesIgnoreHexLimit") && !mark,
+					socketedCursesHexLimit: env.ModDB.Flag(skillCfg, "SocketedCursesAdditionalLimit"),
+				}
+				inc := skillModList.Sum(mod.TypeIncrease, skillCfg, "CurseEffect") + env.EnemyModDB.Sum(mod.TypeIncrease, nil, "CurseEffectOnSelf")
+				if activeSkill.SkillTypes[data.SkillTypeAura] {
+					inc += skillModList.Sum(mod.TypeIncrease, skillCfg, "AuraEffect")
+				}
+				more := skillModList.More(skillCfg, "CurseEffect")
+				// This is non-ideal, but the only More for enemy is the boss effect
+				if !mark {
+					more *= env.EnemyModDB.More(nil, "CurseEffectOnSelf")
+				}
+				mult := float64(0)
+				// If your aura only affects you, Blasphemy does nothing
+				if !(env.ModDB.Flag(nil, "SelfAurasOnlyAffectYou") && activeSkill.SkillTypes[data.SkillTypeAura]) {
+					mult = (1 + inc/100) * more
+				}
+
+				if buff.Type == "Curse" {
+					newCurse.modList = moddb.NewModList()
+					newCurse.modList.ScaleAddList(buff.ModList, mult)
+				} else {
+					// Curse applies a buff; scale by curse effect, then buff effect
+					temp := moddb.NewModList()
+					temp.ScaleAddList(buff.ModList, mult)
+					newCurse.buffModList = moddb.NewModList()
+					buffInc := env.ModDB.Sum(mod.TypeIncrease, skillCfg, "BuffEffectOnSelf")
+					buffMore := env.ModDB.More(skillCfg, "BuffEffectOnSelf")
+					newCurse.buffModList.ScaleAddList(temp, (1+buffInc/100)*buffMore)
+					if env.Minion != nil {
+						newCurse.minionBuffModList = moddb.NewModList()
+						buffInc := env.Minion.ModDB.Sum(mod.TypeIncrease, nil, "BuffEffectOnSelf")
+						buffMore := env.Minion.ModDB.More(nil, "BuffEffectOnSelf")
+						newCurse.minionBuffModList.ScaleAddList(temp, (1+buffInc/100)*buffMore)
+					}
+				}
+				curses = append(curses, newCurse)
+			}
+		}
+
+		/*
+			TODO -- Buffs, auras, curses and debuffs from minion skills
 			if activeSkill.minion and activeSkill.minion.activeSkillList then
 				local castingMinion = activeSkill.minion
 				for _, activeSkill in ipairs(activeSkill.minion.activeSkillList) do
@@ -1052,8 +932,8 @@ func PerformCalc(env *Environment) {
 					end
 				end
 			end
-		end
-	*/
+		*/
+	}
 
 	/*
 		TODO -- Limited support for handling buffs originating from Spectres
@@ -1099,232 +979,138 @@ func PerformCalc(env *Environment) {
 		end
 	*/
 
-	/*
-		TODO -- Check for extra curses
-		for dest, modDB in pairs({[curses] = modDB, [minionCurses] = env.minion and env.minion.modDB}) do
-			for _, value in ipairs(modDB:List(nil, "ExtraCurse")) do
-				local gemModList = new("ModList")
-				local grantedEffect = env.data.skills[value.skillId]
-				if grantedEffect then
-					calcs.mergeSkillInstanceMods(env, gemModList, {
-						grantedEffect = grantedEffect,
-						level = value.level,
-						quality = 0,
-					})
-					local curseModList = { }
-					for _, mod in ipairs(gemModList) do
-						for _, tag in ipairs(mod) do
-							if tag.type == "GlobalEffect" and tag.effectType == "Curse" then
-								t_insert(curseModList, mod)
-								break
-							end
-						end
-					end
-					if value.applyToPlayer then
-						-- Sources for curses on the player don't usually respect any kind of limit, so there's little point bothering with slots
-						if modDB:Sum("BASE", nil, "AvoidCurse") < 100 then
-							modDB.conditions["Cursed"] = true
-							modDB.multipliers["CurseOnSelf"] = (modDB.multipliers["CurseOnSelf"] or 0) + 1
-							modDB.conditions["AffectedBy"..grantedEffect.name:gsub(" ","")] = true
-							local cfg = { skillName = grantedEffect.name }
-							local inc = modDB:Sum("INC", cfg, "CurseEffectOnSelf") + gemModList:Sum("INC", nil, "CurseEffectAgainstPlayer")
-							local more = modDB:More(cfg, "CurseEffectOnSelf") * gemModList:More(nil, "CurseEffectAgainstPlayer")
-							modDB:ScaleAddList(curseModList, (1 + inc / 100) * more)
-						end
-					elseif not enemyDB:Flag(nil, "Hexproof") or modDB:Flag(nil, "CursesIgnoreHexproof") then
-						local curse = {
-							name = grantedEffect.name,
-							fromPlayer = (dest == curses),
-							priority = determineCursePriority(grantedEffect.name),
-						}
-						curse.modList = new("ModList")
-						curse.modList:ScaleAddList(curseModList, (1 + enemyDB:Sum("INC", nil, "CurseEffectOnSelf") / 100) * enemyDB:More(nil, "CurseEffectOnSelf"))
-						t_insert(dest, curse)
-					end
-				end
-			end
-		end
+	// Check for extra curses
+	// TODO Extra curses granted to minions
+	for _, value := range utils.CastSlice[mod.ExtraCurse](env.ModDB.List(nil, "ExtraCurse")) {
+		grantedEffect := extraCurseGrantedEffect(value)
+		if grantedEffect == nil {
+			continue
+		}
 
-		-- Set curse limit
-		output.EnemyCurseLimit = modDB:Sum("BASE", nil, "EnemyCurseLimit")
-		curses.limit = output.EnemyCurseLimit
-		-- Assign curses to slots
-		local curseSlots = { }
-		env.curseSlots = curseSlots
-		-- Currently assume only 1 mark is possible
-		local markSlotted = false
-		for _, source in ipairs({curses, minionCurses}) do
-			for _, curse in ipairs(source) do
-				-- Calculate curses that ignore hex limit after
-				if not curse.ignoreHexLimit and not curse.socketedCursesHexLimit then
-					local slot
-					local skipAddingCurse = false
-					-- Check if we need to disable a certain curse aura.
-					for _, activeSkill in ipairs(env.player.activeSkillList) do
-						if (activeSkill.buffList[1] and curse.name == activeSkill.buffList[1].name and activeSkill.skillTypes[SkillType.Aura]) then
-							if modDB:Flag(nil, "SelfAurasOnlyAffectYou") then
-								skipAddingCurse = true
-								break
-							end
-							for _, value in ipairs({"Mana", "Life"}) do
-								if activeSkill.skillData[value.."ReservedBase"] and activeSkill.skillData[value.."ReservedBase"] > env.player.output[value] then
-									skipAddingCurse = true
-									break
-								end
-							end
-							break
-						end
-					end
-					for i = 1, source.limit do
-						-- Prevent multiple marks from being considered
-						if curse.isMark then
-							if markSlotted then
-								slot = nil
-								break
-							end
-						end
-						if not curseSlots[i] then
-							slot = i
-							break
-						elseif curseSlots[i].name == curse.name then
-							if curseSlots[i].priority < curse.priority then
-								slot = i
-							else
-								slot = nil
-							end
-							break
-						elseif curseSlots[i].priority < curse.priority then
-							slot = i
-						end
-					end
-					if slot then
-						if curseSlots[slot] and curseSlots[slot].isMark then
-							markSlotted = false
-						end
-						if skipAddingCurse == false then
-							curseSlots[slot] = curse
-						end
-						if curse.isMark then
-							markSlotted = true
-						end
-					end
-				end
-			end
-		end
+		gemModList := moddb.NewModList()
+		baseFlags, skillTypes := TypesToFlagsAndTypes(grantedEffect.GetActiveSkill().GetActiveSkillTypes())
+		CalcMergeSkillInstanceMods(env, gemModList, &GemEffect{
+			GrantedEffect: &GrantedEffect{
+				Raw:        grantedEffect,
+				SkillTypes: skillTypes,
+				BaseFlags:  baseFlags,
+			},
+			Level: value.Level,
+		}, nil)
+
+		curseModList := moddb.NewModList()
+		for _, m := range gemModList.Mods() {
+			for _, tag := range m.Tags() {
+				if effect, ok := tag.(*mod.GlobalEffectTag); ok && len(effect.GlobalEffectList) > 0 && effect.GlobalEffectList[0] == "Curse" {
+					curseModList.AddMod(m)
+					break
+				}
+			}
+		}
 
-		for _, source in ipairs({curses, minionCurses}) do
-			for _, curse in ipairs(source) do
-				if curse.ignoreHexLimit then
-					local skipAddingCurse = false
-					for i = 1, #curseSlots do
-						if curseSlots[i].name == curse.name then
-							-- if curse is higher priority, replace current curse with it, otherwise if same or lower priority skip it entirely
-							if curseSlots[i].priority < curse.priority then
-								curseSlots[i] = curse
-							end
-							skipAddingCurse = true
-							break
-						end
-					end
-					if not skipAddingCurse then
-						curseSlots[#curseSlots + 1] = curse
-					end
-				end
-				if curse.socketedCursesHexLimit then
-					local socketedCursesHexLimitValue = modDB:Sum("BASE", nil, "SocketedCursesHexLimitValue")
-					local skipAddingCurse = false
-					for i = 1, #curseSlots do
-						if curseSlots[i].name == curse.name then
-							-- if curse is higher priority, replace current curse with it, otherwise if same or lower priority skip it entirely
-							if curseSlots[i].priority < curse.priority then
-								curseSlots[i] = curse
-							end
-							skipAddingCurse = true
-							break
-						end
-						if i >= socketedCursesHexLimitValue then
-							skipAddingCurse = true
-						end
-					end
-					if not skipAddingCurse then
-						curseSlots[#curseSlots + 1] = curse
-					end
-				end
-			end
-		end
-	*/
+		curseName := grantedEffect.GetActiveSkill().DisplayedName
+		if value.ApplyToPlayer {
+			// Sources for curses on the player don't usually respect any kind of limit, so there's little point bothering with slots
+			if env.ModDB.Sum(mod.TypeBase, nil, "AvoidCurse") < 100 {
+				env.ModDB.Conditions["Cursed"] = true
+				env.ModDB.Multipliers["CurseOnSelf"]++
+				env.ModDB.Conditions["AffectedBy"+strings.ReplaceAll(curseName, " ", "")] = true
+				// TODO Filter CurseEffectOnSelf by skill name
+				inc := env.ModDB.Sum(mod.TypeIncrease, nil, "CurseEffectOnSelf") + gemModList.Sum(mod.TypeIncrease, nil, "CurseEffectAgainstPlayer")
+				more := env.ModDB.More(nil, "CurseEffectOnSelf") * gemModList.More(nil, "CurseEffectAgainstPlayer")
+				env.ModDB.ScaleAddList(curseModList, (1+inc/100)*more)
+			}
+		} else if !env.EnemyModDB.Flag(nil, "Hexproof") || env.ModDB.Flag(nil, "CursesIgnoreHexproof") {
+			newCurse := &curse{
+				name:       curseName,
+				fromPlayer: true,
+				priority:   determineCursePriority(curseName, nil),
+				modList:    moddb.NewModList(),
+			}
+			newCurse.modList.ScaleAddList(curseModList, (1+env.EnemyModDB.Sum(mod.TypeIncrease, nil, "CurseEffectOnSelf")/100)*env.EnemyModDB.More(nil, "CurseEffectOnSelf"))
+			curses = append(curses, newCurse)
+		}
+	}
 
-	/*
-		TODO -- Process guard buffs
-		local guardSlots = { }
-		local nonVaal = false
-		for name, modList in pairs(guards) do
-			if name == "Vaal Molten Shell" then
-				wipeTable(guardSlots)
-				nonVaal = false
-				t_insert(guardSlots, { name = name, modList = modList })
-				break
-			elseif name:match("^Vaal") then
-				t_insert(guardSlots, { name = name, modList = modList })
-			elseif not nonVaal then
-				t_insert(guardSlots, { name = name, modList = modList })
-				nonVaal = true
-			end
-		end
-		if nonVaal then
-			modDB.conditions["AffectedByNonVaalGuardSkill"] = true
-		end
-		for _, guard in ipairs(guardSlots) do
-			modDB.conditions["AffectedByGuardSkill"] = true
-			modDB.conditions["AffectedBy"..guard.name:gsub(" ","")] = true
-			mergeBuff(guard.modList, buffs, guard.name)
-		end
-	*/
+	// Set curse limit
+	env.Player.Output["EnemyCurseLimit"] = env.ModDB.Sum(mod.TypeBase, nil, "EnemyCurseLimit")
+	curseSlots := assignCurseSlots(env, curses, int(env.Player.Output["EnemyCurseLimit"]))
 
-	/*
-		TODO -- Apply buff/debuff modifiers
-		for _, modList in pairs(buffs) do
-			modDB:AddList(modList)
-			if not modList.notBuff then
-				modDB.multipliers["BuffOnSelf"] = (modDB.multipliers["BuffOnSelf"] or 0) + 1
-			end
-			if env.minion then
-				for _, value in ipairs(modList:List(env.player.mainSkill.skillCfg, "MinionModifier")) do
-					if not value.type or env.minion.type == value.type then
-						env.minion.modDB:AddMod(value.mod)
-					end
-				end
-			end
-		end
-		if env.minion then
-			for _, modList in pairs(minionBuffs) do
-				env.minion.modDB:AddList(modList)
-			end
-		end
-		for _, modList in pairs(debuffs) do
-			enemyDB:AddList(modList)
-		end
-		modDB.multipliers["CurseOnEnemy"] = #curseSlots
-		local affectedByCurse = { }
-		for _, slot in ipairs(curseSlots) do
-			enemyDB.conditions["Cursed"] = true
-			if slot.isMark then
-				enemyDB.conditions["Marked"] = true
-			end
-			if slot.fromPlayer then
-				affectedByCurse[env.enemy] = true
-			end
-			if slot.modList then
-				enemyDB:AddList(slot.modList)
-			end
-			if slot.buffModList then
-				modDB:AddList(slot.buffModList)
-			end
-			if slot.minionBuffModList then
-				env.minion.modDB:AddList(slot.minionBuffModList)
-			end
-		end
-	*/
+	// Process guard buffs
+	guardNames := make([]string, 0, len(guards))
+	for name := range guards {
+		guardNames = append(guardNames, name)
+	}
+	sort.Strings(guardNames)
+
+	guardSlots := make([]string, 0)
+	nonVaal := false
+	for _, name := range guardNames {
+		if name == "Vaal Molten Shell" {
+			guardSlots = []string{name}
+			nonVaal = false
+			break
+		} else if strings.HasPrefix(name, "Vaal") {
+			guardSlots = append(guardSlots, name)
+		} else if !nonVaal {
+			guardSlots = append(guardSlots, name)
+			nonVaal = true
+		}
+	}
+	if nonVaal {
+		env.ModDB.Conditions["AffectedByNonVaalGuardSkill"] = true
+	}
+	for _, name := range guardSlots {
+		env.ModDB.Conditions["AffectedByGuardSkill"] = true
+		env.ModDB.Conditions["AffectedBy"+strings.ReplaceAll(name, " ", "")] = true
+		mergeBuff(guards[name], buffs, name)
+	}
+
+	// Apply buff/debuff modifiers
+	for name, modList := range buffs {
+		env.ModDB.AddList(modList)
+		if !notBuffs[name] {
+			env.ModDB.Multipliers["BuffOnSelf"]++
+		}
+		if env.Minion != nil {
+			var mainSkillCfg *moddb.ListCfg
+			if env.Player.MainSkill != nil {
+				mainSkillCfg = env.Player.MainSkill.SkillCfg
+			}
+			// TODO Filter by minion type
+			for _, value := range utils.CastSlice[mod.MinionModifier](modList.List(mainSkillCfg, "MinionModifier")) {
+				env.Minion.ModDB.AddMod(value.Mod)
+			}
+		}
+	}
+	if env.Minion != nil {
+		for _, modList := range minionBuffs {
+			env.Minion.ModDB.AddList(modList)
+		}
+	}
+	for _, modList := range debuffs {
+		env.EnemyModDB.AddList(modList)
+	}
+	env.ModDB.Multipliers["CurseOnEnemy"] = float64(len(curseSlots))
+	affectedByCurse := make(map[*moddb.ModDB]bool)
+	for _, slot := range curseSlots {
+		env.EnemyModDB.Conditions["Cursed"] = true
+		if slot.isMark {
+			env.EnemyModDB.Conditions["Marked"] = true
+		}
+		if slot.fromPlayer {
+			affectedByCurse[env.EnemyModDB] = true
+		}
+		if slot.modList != nil {
+			env.EnemyModDB.AddList(slot.modList)
+		}
+		if slot.buffModList != nil {
+			env.ModDB.AddList(slot.buffModList)
+		}
+		if slot.minionBuffModList != nil && env.Minion != nil {
+			env.Minion.ModDB.AddList(slot.minionBuffModList)
+		}
+	}
 
 	/*
 		TODO -- Do another pass on the SkillList to catch effects of buffs, if needed
@@ -1357,109 +1143,13 @@ func PerformCalc(env *Environment) {
 		end
 	*/
 
-	/*
-		TODO -- Process Triggered Skill and Set Trigger Conditions
-		-- Cospri's Malice
-		if env.player.mainSkill.skillData.triggeredByCospris and not env.player.mainSkill.skillFlags.minion then
-			local spellCount = {}
-			local icdr = calcLib.mod(env.player.mainSkill.skillModList, env.player.mainSkill.skillCfg, "CooldownRecovery")
-			local trigRate = 0
-			local source = nil
-			for _, skill in ipairs(env.player.activeSkillList) do
-				if skill.skillTypes[SkillType.Melee] and band(skill.skillCfg.flags, bor(ModFlag.Sword, ModFlag.Weapon1H)) > 0 and skill ~= env.player.mainSkill then
-					source, trigRate = findTriggerSkill(env, skill, source, trigRate)
-				end
-				if skill.skillData.triggeredByCospris and env.player.mainSkill.socketGroup.slot == skill.socketGroup.slot then
-					t_insert(spellCount, { uuid = cacheSkillUUID(skill), cd = skill.skillData.cooldown / icdr, next_trig = 0, count = 0 })
-				end
-			end
-			if not source or #spellCount < 1 then
-				env.player.mainSkill.skillData.triggeredByCospris = nil
-				env.player.mainSkill.infoMessage = "No Cospri Triggering Skill Found"
-				env.player.mainSkill.infoMessage2 = "DPS reported assuming Self-Cast"
-				env.player.mainSkill.infoTrigger = ""
-			else
-				env.player.mainSkill.skillData.triggered = true
-				local uuid = cacheSkillUUID(source)
-				local sourceAPS = GlobalCache.cachedData["CACHE"][uuid].Speed
-				local dualWield = false
-
-				sourceAPS, dualWield = calcDualWieldImpact(env, sourceAPS, source.skillData.doubleHitsWhenDualWielding)
-
-				-- Get action trigger rate
-				trigRate = calcActualTriggerRate(env, source, sourceAPS, spellCount, output, breakdown, dualWield)
-
-				-- Account for chance to hit/crit
-				local sourceCritChance = GlobalCache.cachedData["CACHE"][uuid].CritChance
-				trigRate = trigRate * sourceCritChance / 100
-				if breakdown then
-					breakdown.Speed = {
-						s_format("%.2fs ^8(adjusted trigger rate)", output.ServerTriggerRate),
-						s_format("x %.2f%% ^8(%s effective crit chance)", sourceCritChance, source.activeEffect.grantedEffect.name),
-						s_format("= %.2f ^8per second", trigRate),
-					}
-				end
-
-				-- Account for Trigger-related INC/MORE modifiers
-				addTriggerIncMoreMods(env.player.mainSkill, env.player.mainSkill)
-				env.player.mainSkill.skillData.triggerRate = trigRate
-				env.player.mainSkill.skillData.triggerSource = source
-				env.player.mainSkill.infoMessage = "Cospri Triggering Skill: " .. source.activeEffect.grantedEffect.name
-				env.player.mainSkill.infoTrigger = "Cospri"
-			end
-		end
-
-		-- Mjolner
-		if env.player.mainSkill.skillData.triggeredByMjolner and not env.player.mainSkill.skillFlags.minion then
-			local spellCount = {}
-			local icdr = calcLib.mod(env.player.mainSkill.skillModList, env.player.mainSkill.skillCfg, "CooldownRecovery")
-			local trigRate = 0
-			local source = nil
-			for _, skill in ipairs(env.player.activeSkillList) do
-				if (skill.skillTypes[SkillType.Damage] or skill.skillTypes[SkillType.Attack]) and band(skill.skillCfg.flags, bor(ModFlag.Mace, ModFlag.Weapon1H)) > 0 and skill ~= env.player.mainSkill then
-					source, trigRate = findTriggerSkill(env, skill, source, trigRate)
-				end
-				if skill.skillData.triggeredByMjolner and env.player.mainSkill.socketGroup.slot == skill.socketGroup.slot then
-					t_insert(spellCount, { uuid = cacheSkillUUID(skill), cd = skill.skillData.cooldown / icdr, next_trig = 0, count = 0 })
-				end
-			end
-			if not source or #spellCount < 1 then
-				env.player.mainSkill.skillData.triggeredByMjolner = nil
-				env.player.mainSkill.infoMessage = "No Mjolner Triggering Skill Found"
-				env.player.mainSkill.infoMessage2 = "DPS reported assuming Self-Cast"
-				env.player.mainSkill.infoTrigger = ""
-			else
-				env.player.mainSkill.skillData.triggered = true
-				local uuid = cacheSkillUUID(source)
-				local sourceAPS = GlobalCache.cachedData["CACHE"][uuid].Speed
-				local dualWield = false
-
-				sourceAPS, dualWield = calcDualWieldImpact(env, sourceAPS, source.skillData.doubleHitsWhenDualWielding)
-
-				-- Get action trigger rate
-				trigRate = calcActualTriggerRate(env, source, sourceAPS, spellCount, output, breakdown, dualWield)
-
-				-- Account for chance to hit/crit
-				local sourceHitChance = GlobalCache.cachedData["CACHE"][uuid].HitChance
-				trigRate = trigRate * sourceHitChance / 100
-				if breakdown then
-					breakdown.Speed = {
-						s_format("%.2fs ^8(adjusted trigger rate)", output.ServerTriggerRate),
-						s_format("x %.0f%% ^8(%s hit chance)", sourceHitChance, source.activeEffect.grantedEffect.name),
-						s_format("= %.2f ^8per second", trigRate),
-					}
-				end
-
-				-- Account for Trigger-related INC/MORE modifiers
-				addTriggerIncMoreMods(env.player.mainSkill, env.player.mainSkill)
-				env.player.mainSkill.skillData.triggerRate = trigRate
-				env.player.mainSkill.skillData.triggerSource = source
-				env.player.mainSkill.infoMessage = "Mjolner Triggering Skill: " .. source.activeEffect.grantedEffect.name
-				env.player.mainSkill.infoTrigger = "Mjolner"
-			end
-		end
+	// Process triggered skills and set trigger conditions, skipped for cached trigger sources to prevent recursion
+	if !env.limitedProcessing {
+		processTriggeredSkill(env)
+	}
 
-		-- Mirage Archer Support
+	/*
+		TODO -- Mirage Archer Support
 		-- This creates and populates env.player.mainSkill.mirage table
 		if env.player.mainSkill.skillData.triggeredByMirageArcher and not env.player.mainSkill.skillFlags.minion and not env.player.mainSkill.marked then
 			local usedSkill = nil
@@ -1537,467 +1227,6 @@ func PerformCalc(env *Environment) {
 				env.player.mainSkill.infoMessage2 = "No Mirage Archer active skill found"
 			end
 		end
-
-		-- Kitava's Thirst
-		if env.player.mainSkill.skillData.triggeredByManaSpent and not env.player.mainSkill.skillFlags.minion then
-			local triggerName = "Kitava"
-			local spellCount = 0
-			local icdr = calcLib.mod(env.player.mainSkill.skillModList, env.player.mainSkill.skillCfg, "CooldownRecovery")
-			local reqManaCost = env.player.modDB:Sum("BASE", nil, "KitavaRequiredManaCost")
-			local trigRate = 0
-			local source = nil
-			for _, skill in ipairs(env.player.activeSkillList) do
-				if not skill.skillTypes[SkillType.Triggered] and skill ~= env.player.mainSkill and not skill.skillData.triggeredByManaSpent then
-					source, trigRate = findTriggerSkill(env, skill, source, trigRate, reqManaCost)
-				end
-				if skill.skillData.triggeredByManaSpent and env.player.mainSkill.socketGroup.slot == skill.socketGroup.slot then
-					spellCount = spellCount + 1
-				end
-			end
-
-			if not source or spellCount < 1 then
-				env.player.mainSkill.skillData.triggeredByManaSpent = nil
-				env.player.mainSkill.infoMessage = s_format("No %s Triggering Skill Found", triggerName)
-				env.player.mainSkill.infoMessage2 = "DPS reported assuming Self-Cast"
-				env.player.mainSkill.infoTrigger = ""
-			else
-				env.player.mainSkill.skillData.triggered = true
-
-				output.ActionTriggerRate = getTriggerActionTriggerRate(env.player.mainSkill.skillData.cooldown, env, breakdown)
-
-				-- Get action trigger rate
-				local kitavaCD = getTriggerDefaultCooldown(env.player.mainSkill.supportList, "SupportCastOnManaSpent")
-
-				trigRate = icdr / kitavaCD
-				output.SourceTriggerRate = trigRate
-				output.ServerTriggerRate = m_min(output.SourceTriggerRate, output.ActionTriggerRate)
-				if breakdown then
-					local modActionCooldown = kitavaCD / icdr
-					local rateCapAdjusted = m_ceil(modActionCooldown * data.misc.ServerTickRate) / data.misc.ServerTickRate
-					local extraICDRNeeded = m_ceil((modActionCooldown - rateCapAdjusted + data.misc.ServerTickTime) * icdr * 1000)
-					breakdown.SimData = {
-						s_format("%.2f ^8(base cooldown of kitava's trigger)", kitavaCD),
-						s_format("/ %.2f ^8(increased/reduced cooldown recovery)", icdr),
-						s_format("= %.4f ^8(final cooldown of trigger)", modActionCooldown),
-						s_format(""),
-						s_format("%.3f ^8(adjusted for server tick rate)", rateCapAdjusted),
-						s_format("^8(extra ICDR of %d%% would reach next breakpoint)", extraICDRNeeded),
-						s_format(""),
-						s_format("Trigger rate:"),
-						s_format("1 / %.3f", rateCapAdjusted),
-						s_format("= %.2f ^8per second", 1 / rateCapAdjusted),
-					}
-					breakdown.ServerTriggerRate = {
-						s_format("%.2f ^8(smaller of 'cap' and 'skill' trigger rates)", output.ServerTriggerRate),
-					}
-				end
-
-				-- Account for chance to trigger
-				local kitavaTriggerChance = env.player.modDB:Sum("BASE", nil, "KitavaTriggerChance")
-				trigRate = output.ServerTriggerRate * kitavaTriggerChance / 100
-				if breakdown then
-					breakdown.Speed = {
-						s_format("%.2fs ^8(adjusted trigger rate)", output.ServerTriggerRate),
-						s_format("x %.2f%% ^8(kitava's trigger chance)", kitavaTriggerChance),
-						s_format("= %.2f ^8per second", trigRate),
-					}
-				end
-
-				-- Account for Trigger-related INC/MORE modifiers
-				addTriggerIncMoreMods(env.player.mainSkill, env.player.mainSkill)
-				env.player.mainSkill.skillData.triggerRate = trigRate
-				env.player.mainSkill.skillData.triggerSource = source
-				env.player.mainSkill.infoMessage = "Kitava's Triggering Skill: " .. source.activeEffect.grantedEffect.name
-				env.player.mainSkill.infoTrigger = triggerName
-			end
-		end
-
-		-- Crafted Trigger
-		if env.player.mainSkill.skillData.triggeredByCraft and not env.player.mainSkill.skillFlags.minion then
-			local triggerName = "Crafted"
-			local spellCount = 0
-			local icdr = calcLib.mod(env.player.mainSkill.skillModList, env.player.mainSkill.skillCfg, "CooldownRecovery")
-			local trigRate = 0
-			local source = nil
-			for _, skill in ipairs(env.player.activeSkillList) do
-				if (skill.skillTypes[SkillType.Damage] or skill.skillTypes[SkillType.Attack] or skill.skillTypes[SkillType.Spell]) and skill ~= env.player.mainSkill and not skill.skillData.triggeredByCraft then
-					source, trigRate = skill, 0
-				end
-				if skill.skillData.triggeredByCraft and env.player.mainSkill.socketGroup.slot == skill.socketGroup.slot then
-					spellCount = spellCount + 1
-				end
-				-- we just need one source and one linked spell
-				if source and spellCount > 0 then
-					break
-				end
-			end
-			if not source or spellCount < 1 then
-				env.player.mainSkill.skillData.triggeredByCraft = nil
-				env.player.mainSkill.infoMessage = s_format("No %s Triggering Skill Found", triggerName)
-				env.player.mainSkill.infoMessage2 = "DPS reported assuming Self-Cast"
-				env.player.mainSkill.infoTrigger = ""
-			else
-				env.player.mainSkill.skillData.triggered = true
-
-				output.ActionTriggerRate = getTriggerActionTriggerRate(env.player.mainSkill.skillData.cooldown, env, breakdown)
-
-				-- Get action trigger rate
-				local craftedCD = getTriggerDefaultCooldown(env.player.mainSkill.supportList, "SupportTriggerSpellOnSkillUse")
-
-				trigRate = icdr / craftedCD
-				output.SourceTriggerRate = trigRate
-				output.ServerTriggerRate = m_min(output.SourceTriggerRate, output.ActionTriggerRate)
-				if breakdown then
-					local modActionCooldown = craftedCD / icdr
-					local rateCapAdjusted = m_ceil(modActionCooldown * data.misc.ServerTickRate) / data.misc.ServerTickRate
-					local extraICDRNeeded = m_ceil((modActionCooldown - rateCapAdjusted + data.misc.ServerTickTime) * icdr * 1000)
-					breakdown.SimData = {
-						s_format("%.2f ^8(base cooldown of crafted trigger)", craftedCD),
-						s_format("/ %.2f ^8(increased/reduced cooldown recovery)", icdr),
-						s_format("= %.4f ^8(final cooldown of trigger)", modActionCooldown),
-						s_format(""),
-						s_format("%.3f ^8(adjusted for server tick rate)", rateCapAdjusted),
-						s_format("^8(extra ICDR of %d%% would reach next breakpoint)", extraICDRNeeded),
-						s_format(""),
-						s_format("Trigger rate:"),
-						s_format("1 / %.3f", rateCapAdjusted),
-						s_format("= %.2f ^8per second", 1 / rateCapAdjusted),
-					}
-					breakdown.ServerTriggerRate = {
-						s_format("%.2f ^8(smaller of 'cap' and 'skill' trigger rates)", output.ServerTriggerRate),
-					}
-				end
-
-				-- Account for Trigger-related INC/MORE modifiers
-				addTriggerIncMoreMods(env.player.mainSkill, env.player.mainSkill)
-				env.player.mainSkill.skillData.triggerRate = output.ServerTriggerRate
-				env.player.mainSkill.skillData.triggerSource = source
-				env.player.mainSkill.infoMessage = "Weapon-Crafted Triggering Skill Found"
-				env.player.mainSkill.infoTrigger = triggerName
-				env.player.mainSkill.skillFlags.dontDisplay = true
-			end
-		end
-
-		-- Helmet Focus Trigger
-		if env.player.mainSkill.skillData.triggeredByFocus and not env.player.mainSkill.skillFlags.minion then
-			local triggerName = "Focus"
-			local spellCount = 0
-			local icdr = calcLib.mod(env.player.mainSkill.skillModList, env.player.mainSkill.skillCfg, "FocusCooldownRecovery")
-			local trigRate = 0
-			local source = env.player.modDB:Flag(nil, "Condition:Focused")
-			for _, skill in ipairs(env.player.activeSkillList) do
-				if skill.skillData.triggeredByFocus and env.player.mainSkill.socketGroup.slot == skill.socketGroup.slot then
-					spellCount = spellCount + 1
-				end
-			end
-			if not source or spellCount < 1 then
-				env.player.mainSkill.skillData.triggeredByFocus = nil
-				env.player.mainSkill.infoMessage = s_format("No %s Triggering Skill Found", triggerName)
-				env.player.mainSkill.infoMessage2 = "DPS reported assuming Self-Cast"
-				env.player.mainSkill.infoTrigger = ""
-			else
-				env.player.mainSkill.skillData.triggered = true
-
-				output.ActionTriggerRate = getTriggerActionTriggerRate(env.player.mainSkill.skillData.cooldown, env, breakdown, true)
-
-				-- Get action trigger rate
-				local skillFocus = env.data.skills["Focus"]
-				local focusCD = skillFocus.levels[1].cooldown
-
-				trigRate = icdr / focusCD
-				output.SourceTriggerRate = trigRate
-				output.ServerTriggerRate = m_min(output.SourceTriggerRate, output.ActionTriggerRate)
-				if breakdown then
-					local modActionCooldown = focusCD / icdr
-					local rateCapAdjusted = m_ceil(modActionCooldown * data.misc.ServerTickRate) / data.misc.ServerTickRate
-					breakdown.SimData = {
-						s_format("%.2f ^8(base cooldown of focus trigger)", focusCD),
-						s_format("/ %.2f ^8(increased/reduced cooldown recovery)", icdr),
-						s_format("= %.4f ^8(final cooldown of trigger)", modActionCooldown),
-						s_format(""),
-						s_format("%.3f ^8(adjusted for server tick rate)", rateCapAdjusted),
-						s_format(""),
-						s_format("Trigger rate:"),
-						s_format("1 / %.3f", rateCapAdjusted),
-						s_format("= %.2f ^8per second", 1 / rateCapAdjusted),
-					}
-					breakdown.ServerTriggerRate = {
-						s_format("%.2f ^8(smaller of 'cap' and 'skill' trigger rates)", output.ServerTriggerRate),
-					}
-				end
-
-				-- Account for Trigger-related INC/MORE modifiers
-				addTriggerIncMoreMods(env.player.mainSkill, env.player.mainSkill)
-				env.player.mainSkill.skillData.triggerRate = output.ServerTriggerRate
-				env.player.mainSkill.skillData.triggerSource = source
-				env.player.mainSkill.infoMessage = "Focus Triggering Skill Found"
-				env.player.mainSkill.infoTrigger = triggerName
-				env.player.mainSkill.skillFlags.dontDisplay = true
-			end
-		end
-
-		-- Unique Item Trigger
-		if env.player.mainSkill.skillData.triggeredByUnique and not env.player.mainSkill.skillFlags.minion then
-			local uniqueTriggerName = getUniqueItemTriggerName(env.player.mainSkill)
-			local triggerName = ""
-			local spellCount = {}
-			local icdr = calcLib.mod(env.player.mainSkill.skillModList, env.player.mainSkill.skillCfg, "CooldownRecovery")
-			local trigRate = 0
-			local source = nil
-			for _, skill in ipairs(env.player.activeSkillList) do
-				local cooldownOverride = skill.skillModList:Override(env.player.mainSkill.skillCfg, "CooldownRecovery")
-				if uniqueTriggerName == "Poet's Pen" then
-					triggerName = "Poet"
-					if (skill.skillTypes[SkillType.Damage] or skill.skillTypes[SkillType.Attack]) and band(skill.skillCfg.flags, ModFlag.Wand) > 0 and skill ~= env.player.mainSkill and not skill.skillData.triggeredByUnique then
-						source, trigRate = findTriggerSkill(env, skill, source, trigRate)
-					end
-					if skill.skillData.triggeredByUnique and env.player.mainSkill.socketGroup.slot == skill.socketGroup.slot and skill.skillTypes[SkillType.Spell] then
-						t_insert(spellCount, { uuid = cacheSkillUUID(skill), cd = cooldownOverride or (skill.skillData.cooldown / icdr), next_trig = 0, count = 0 })
-					end
-				elseif uniqueTriggerName == "Maloney's Mechanism" then
-					triggerName = "Maloney"
-					if skill.skillTypes[SkillType.Attack] and band(skill.skillCfg.flags, ModFlag.Bow) > 0 and skill ~= env.player.mainSkill and not skill.skillData.triggeredByUnique then
-						source, trigRate = findTriggerSkill(env, skill, source, trigRate)
-					end
-					if skill.skillData.triggeredByUnique and env.player.mainSkill.socketGroup.slot == skill.socketGroup.slot and skill.skillTypes[SkillType.RangedAttack] then
-						t_insert(spellCount, { uuid = cacheSkillUUID(skill), cd = cooldownOverride or (skill.skillData.cooldown / icdr), next_trig = 0, count = 0 })
-					end
-				elseif uniqueTriggerName == "Asenath's Chant" then
-					triggerName = "Asenath"
-					if (skill.skillTypes[SkillType.Damage] or skill.skillTypes[SkillType.Attack]) and band(skill.skillCfg.flags, ModFlag.Bow) > 0 and skill ~= env.player.mainSkill and not skill.skillData.triggeredByUnique then
-						source, trigRate = findTriggerSkill(env, skill, source, trigRate)
-					end
-					if skill.skillData.triggeredByUnique and env.player.mainSkill.socketGroup.slot == skill.socketGroup.slot and skill.skillTypes[SkillType.Spell] then
-						t_insert(spellCount, { uuid = cacheSkillUUID(skill), cd = cooldownOverride or (skill.skillData.cooldown / icdr), next_trig = 0, count = 0 })
-					end
-				elseif uniqueTriggerName == "Queen's Demand" then
-					triggerName = "QD"
-					if skill.activeEffect.grantedEffect.name == uniqueTriggerName then
-						source, trigRate = findTriggerSkill(env, skill, source, trigRate)
-					end
-					if skill.skillData.triggeredByUnique and env.player.mainSkill.socketGroup.slot == skill.socketGroup.slot then
-						t_insert(spellCount, { uuid = cacheSkillUUID(skill), cd = cooldownOverride or (skill.skillData.cooldown / icdr), next_trig = 0, count = 0 })
-					end
-				else
-					ConPrintf("[ERROR]: Unhandled Unique Trigger Name: " .. uniqueTriggerName)
-				end
-			end
-			if not source or #spellCount < 1 then
-				env.player.mainSkill.skillData.triggeredByUnique = nil
-				env.player.mainSkill.infoMessage = s_format("No %s Triggering Skill Found", triggerName)
-				env.player.mainSkill.infoMessage2 = "DPS reported assuming Self-Cast"
-				env.player.mainSkill.infoTrigger = ""
-			else
-				env.player.mainSkill.skillData.triggered = true
-				local uuid = cacheSkillUUID(source)
-				local sourceAPS = GlobalCache.cachedData["CACHE"][uuid].Speed
-				local dualWield = false
-
-				sourceAPS, dualWield = calcDualWieldImpact(env, sourceAPS, source.skillData.doubleHitsWhenDualWielding)
-
-				-- Get action trigger rate
-				trigRate = calcActualTriggerRate(env, source, sourceAPS, spellCount, output, breakdown, dualWield)
-
-				-- Account for Trigger-related INC/MORE modifiers
-				addTriggerIncMoreMods(env.player.mainSkill, env.player.mainSkill)
-
-				env.player.mainSkill.skillData.triggerRate = trigRate
-				env.player.mainSkill.skillData.triggerSource = source
-				env.player.mainSkill.skillData.triggerSourceUUID = cacheSkillUUID(source, env.mode)
-				env.player.mainSkill.skillData.triggerUnleash = source.skillModList:Flag(nil, "HasSeals") and source.skillTypes[SkillType.CanRapidFire]
-				env.player.mainSkill.infoMessage = env.player.mainSkill.activeEffect.grantedEffect.name .. "'s Trigger: " .. source.activeEffect.grantedEffect.name
-				env.player.mainSkill.infoTrigger = env.player.mainSkill.infoTrigger or triggerName
-			end
-		end
-
-		-- Cast On Critical Strike Support (CoC)
-		if env.player.mainSkill.skillData.triggeredByCoC and not env.player.mainSkill.skillFlags.minion then
-			local spellCount = {}
-			local icdr = calcLib.mod(env.player.mainSkill.skillModList, env.player.mainSkill.skillCfg, "CooldownRecovery")
-			local trigRate = 0
-			local source = nil
-			for _, skill in ipairs(env.player.activeSkillList) do
-				local match1 = env.player.mainSkill.activeEffect.grantedEffect.fromItem and skill.socketGroup.slot == env.player.mainSkill.socketGroup.slot
-				local match2 = (not env.player.mainSkill.activeEffect.grantedEffect.fromItem) and skill.socketGroup == env.player.mainSkill.socketGroup
-				if skill.skillTypes[SkillType.Attack] and skill ~= env.player.mainSkill and (match1 or match2) then
-					source, trigRate = findTriggerSkill(env, skill, source, trigRate)
-				end
-				if skill.skillData.triggeredByCoC and (match1 or match2) then
-					local cooldownOverride = skill.skillModList:Override(env.player.mainSkill.skillCfg, "CooldownRecovery")
-					t_insert(spellCount, { uuid = cacheSkillUUID(skill), cd = cooldownOverride or (skill.skillData.cooldown / icdr), next_trig = 0, count = 0 })
-				end
-			end
-			if not source or #spellCount < 1 then
-				env.player.mainSkill.skillData.triggeredByCoC = nil
-				env.player.mainSkill.infoMessage = "No CoC Triggering Skill Found"
-				env.player.mainSkill.infoMessage2 = "DPS reported assuming Self-Cast"
-				env.player.mainSkill.infoTrigger = ""
-			else
-				env.player.mainSkill.skillData.triggered = true
-				local uuid = cacheSkillUUID(source)
-				local sourceAPS = GlobalCache.cachedData["CACHE"][uuid].Speed
-
-				-- Get action trigger rate
-				trigRate = calcActualTriggerRate(env, source, sourceAPS, spellCount, output, breakdown)
-
-				-- Account for chance to hit/crit
-				local sourceCritChance = GlobalCache.cachedData["CACHE"][uuid].CritChance
-				trigRate = trigRate * sourceCritChance / 100
-				trigRate = trigRate * (source.skillData.chanceToTriggerOnCrit or 100) / 100
-				if breakdown then
-					breakdown.Speed = {
-						s_format("%.2fs ^8(adjusted trigger rate)", output.ServerTriggerRate),
-						s_format("x %.2f%% ^8(%s crit chance)", sourceCritChance, source.activeEffect.grantedEffect.name),
-						s_format("x %.2f%% ^8(chance to trigger on crit)", source.skillData.chanceToTriggerOnCrit or 100),
-						s_format("= %.2f ^8per second", trigRate),
-					}
-				end
-
-				-- Account for Trigger-related INC/MORE modifiers
-				addTriggerIncMoreMods(env.player.mainSkill, env.player.mainSkill)
-				env.player.mainSkill.skillData.triggerRate = trigRate
-				env.player.mainSkill.skillData.triggerSource = source
-				env.player.mainSkill.infoMessage = "CoC Triggering Skill: " .. source.activeEffect.grantedEffect.name
-				env.player.mainSkill.infoTrigger = "CoC"
-			end
-		end
-
-		-- Cast On Melee Kill Support (CoMK)
-		if env.player.mainSkill.skillData.triggeredByMeleeKill and not env.player.mainSkill.skillFlags.minion and modDB:Flag(nil, "Condition:KilledRecently") then
-			local spellCount = {}
-			local icdr = calcLib.mod(env.player.mainSkill.skillModList, env.player.mainSkill.skillCfg, "CooldownRecovery")
-			local trigRate = 0
-			local source = nil
-			for _, skill in ipairs(env.player.activeSkillList) do
-				local match1 = env.player.mainSkill.activeEffect.grantedEffect.fromItem and skill.socketGroup.slot == env.player.mainSkill.socketGroup.slot
-				local match2 = (not env.player.mainSkill.activeEffect.grantedEffect.fromItem) and skill.socketGroup == env.player.mainSkill.socketGroup
-				if skill.skillTypes[SkillType.Attack] and skill.skillTypes[SkillType.Melee] and skill ~= env.player.mainSkill and (match1 or match2) then
-					source, trigRate = findTriggerSkill(env, skill, source, trigRate)
-				end
-				if skill.skillData.triggeredByMeleeKill and (match1 or match2) then
-					local cooldownOverride = skill.skillModList:Override(env.player.mainSkill.skillCfg, "CooldownRecovery")
-					t_insert(spellCount, { uuid = cacheSkillUUID(skill), cd = cooldownOverride or (skill.skillData.cooldown / icdr), next_trig = 0, count = 0 })
-				end
-			end
-			if not source or #spellCount < 1 then
-				env.player.mainSkill.skillData.triggeredByMeleeKill = nil
-				env.player.mainSkill.infoMessage = "No CoMK Triggering Skill Found"
-				env.player.mainSkill.infoMessage2 = "DPS reported assuming Self-Cast"
-				env.player.mainSkill.infoTrigger = ""
-			else
-				env.player.mainSkill.skillData.triggered = true
-				local uuid = cacheSkillUUID(source)
-				local sourceAPS = GlobalCache.cachedData["CACHE"][uuid].Speed
-
-				-- Get action trigger rate
-				trigRate = calcActualTriggerRate(env, source, sourceAPS, spellCount, output, breakdown)
-
-				-- Account for chance to trigger on Melee Kill
-				trigRate = trigRate * source.skillData.chanceToTriggerOnMeleeKill / 100
-
-				if breakdown then
-					breakdown.Speed = {
-						s_format("%.2fs ^8(adjusted trigger rate)", output.ServerTriggerRate),
-						s_format("x %.2f%% ^8(chance to trigger on melee kill)", source.skillData.chanceToTriggerOnMeleeKill),
-						s_format("= %.2f ^8per second", trigRate),
-					}
-				end
-
-				-- Account for Trigger-related INC/MORE modifiers
-				addTriggerIncMoreMods(env.player.mainSkill, env.player.mainSkill)
-				env.player.mainSkill.skillData.triggerRate = trigRate
-				env.player.mainSkill.skillData.triggerSource = source
-				env.player.mainSkill.infoMessage = "CoMK Triggering Skill: " .. source.activeEffect.grantedEffect.name
-				env.player.mainSkill.infoTrigger = "CoMK"
-			end
-		end
-
-		-- Cast While Channelling
-		if env.player.mainSkill.skillData.triggeredWhileChannelling and not env.player.mainSkill.skillFlags.minion then
-			local spellCount = {}
-			local trigRate = 0
-			local source = nil
-			for _, skill in ipairs(env.player.activeSkillList) do
-				local match1 = env.player.mainSkill.activeEffect.grantedEffect.fromItem and skill.socketGroup.slot == env.player.mainSkill.socketGroup.slot
-				local match2 = (not env.player.mainSkill.activeEffect.grantedEffect.fromItem) and skill.socketGroup == env.player.mainSkill.socketGroup
-				if skill.skillTypes[SkillType.Channel] and skill ~= env.player.mainSkill and (match1 or match2) then
-					source, trigRate = findTriggerSkill(env, skill, source, trigRate)
-				end
-				if skill.skillData.triggeredWhileChannelling and (match1 or match2) then
-					t_insert(spellCount, { uuid = cacheSkillUUID(skill), cd = skill.skillData.cooldown, next_trig = 0, count = 0 })
-				end
-			end
-			if not source or #spellCount < 1 then
-				env.player.mainSkill.skillData.triggeredWhileChannelling = nil
-				env.player.mainSkill.infoMessage = "No CwC Triggering Skill Found"
-				env.player.mainSkill.infoMessage2 = "DPS reported assuming Self-Cast"
-				env.player.mainSkill.infoTrigger = ""
-			else
-				env.player.mainSkill.skillData.triggered = true
-
-				-- Get action trigger rate
-				trigRate = calcActualTriggerRate(env, source, nil, spellCount, output, breakdown)
-
-				-- Account for Trigger-related INC/MORE modifiers
-				addTriggerIncMoreMods(env.player.mainSkill, env.player.mainSkill)
-				env.player.mainSkill.skillData.triggerRate = trigRate
-				env.player.mainSkill.skillData.triggerSource = source
-				env.player.mainSkill.infoMessage = "CwC Triggering Skill: " .. source.activeEffect.grantedEffect.name
-				env.player.mainSkill.infoTrigger = "CwC"
-
-				env.player.mainSkill.skillFlags.dontDisplay = true
-			end
-		end
-
-		-- Triggered by parent attack
-		if env.minion and env.player.mainSkill.minion then
-			if env.minion.mainSkill.skillData.triggeredByParentAttack then
-				local spellCount = {}
-				local trigRate = 0
-				local source = nil
-				for _, skill in ipairs(env.player.activeSkillList) do
-					if skill.skillTypes[SkillType.Attack] and skill ~= env.player.mainSkill then
-						source, trigRate = findTriggerSkill(env, skill, source, trigRate)
-					end
-				end
-
-				local icdr = calcLib.mod(env.minion.mainSkill.skillModList, env.minion.mainSkill.skillCfg, "CooldownRecovery")
-				t_insert(spellCount, { uuid = cacheSkillUUID(env.minion.mainSkill), cd = env.minion.mainSkill.skillData.cooldown / icdr, next_trig = 0, count = 0 })
-
-				if not source then
-					env.minion.mainSkill.skillData.triggeredByParentAttack = nil
-					env.minion.mainSkill.infoMessage = "No triggering Skill Found"
-					env.minion.mainSkill.infoMessage2 = "DPS reported assuming regular cast"
-					env.minion.mainSkill.infoTrigger = ""
-				else
-					env.minion.mainSkill.skillData.triggered = true
-					local uuid = cacheSkillUUID(source)
-
-					local sourceAPS = GlobalCache.cachedData["CACHE"][uuid].Speed
-
-					-- Get action trigger rate
-					trigRate = calcActualTriggerRate(env, source, sourceAPS, spellCount, env.minion.output, env.minion.breakdown, false, true)
-
-					-- Account for chance to hit
-					local sourceHitChance = GlobalCache.cachedData["CACHE"][uuid].HitChance
-					trigRate = trigRate * sourceHitChance / 100
-					if env.minion.breakdown then
-						env.minion.breakdown.Speed = {
-							s_format("%.2fs ^8(adjusted trigger rate)", env.minion.output.ServerTriggerRate),
-							s_format("x %.2f%% ^8(%s Hit chance)", sourceHitChance, source.activeEffect.grantedEffect.name),
-							s_format("= %.2f ^8per second", trigRate),
-						}
-					end
-
-					-- Account for Trigger-related INC/MORE modifiers
-					addTriggerIncMoreMods(env.minion.mainSkill, env.minion.mainSkill)
-					env.minion.mainSkill.skillData.triggerRate = trigRate
-					env.minion.mainSkill.skillData.triggerSource = source
-					env.minion.mainSkill.infoMessage = "Triggering Skill: " .. source.activeEffect.grantedEffect.name
-					env.minion.mainSkill.infoTrigger = "Parent attack"
-				end
-			end
-		end
 	*/
 
 	/*
@@ -2012,94 +1241,37 @@ func PerformCalc(env *Environment) {
 		end
 	*/
 
-	/*
-		TODO -- Calculate maximum and apply the strongest non-damaging ailments
-		local ailmentData = data.nonDamagingAilment
-		local ailments = {
-			["Chill"] = { condition = "Chilled", mods = function(num)
-				local mods = {
-					modLib.createMod("ActionSpeed", "INC", -num, "Chill", { type = "Condition", var = "Chilled" })
-				}
-				if output.BonechillEffect then
-					t_insert(mods, modLib.createMod("ColdDamageTaken", "INC", output.BonechillEffect, "Bonechill", { type = "Limit", limit = output["MaximumChill"] }, { type = "Condition", var = "Chilled" }))
-				end
-				return mods
-			end },
-			["Shock"] = { condition = "Shocked", mods = function(num) return {
-				modLib.createMod("DamageTaken", "INC", num, "Shock", { type = "Condition", var = "Shocked" })
-			} end },
-			["Scorch"] = { condition = "Scorched", mods = function(num) return {
-				modLib.createMod("ElementalResist", "BASE", -num, "Scorch", { type = "Condition", var = "Scorched" })
-			} end },
-			["Brittle"] = { condition = "Brittle", mods = function(num) return {
-				modLib.createMod("SelfCritChance", "BASE", num, "Brittle", { type = "Condition", var = "Brittle" })
-			} end },
-			["Sap"] = { condition = "Sapped", mods = function(num) return {
-				modLib.createMod("Damage", "MORE", -num, "Sap", { type = "Condition", var = "Sapped" })
-			} end },
-		}
-
-		for ailment, val in pairs(ailments) do
-			if (enemyDB:Sum("BASE", nil, ailment.."Val") > 0
-			or modDB:Sum("BASE", nil, ailment.."Base", ailment.."Override")
-			or (ailment == "Chill" and output.BonechillEffect))
-			and not enemyDB:Flag(nil, "Condition:Already"..val.condition) then
-				local override = 0
-				for _, value in ipairs(modDB:Tabulate("BASE", nil, ailment.."Base", ailment.."Override")) do
-					local mod = value.mod
-					local effect = mod.value
-					if mod.name == ailment.."Override" then
-						enemyDB:NewMod("Condition:"..val.condition, "FLAG", true, mod.source)
-					end
-					if mod.name == ailment.."Base" then
-						effect = effect * calcLib.mod(modDB, nil, "Enemy"..ailment.."Effect")
-						modDB:NewMod(ailment.."Override", "BASE", effect, mod.source, mod.flags, mod.keywordFlags, unpack(mod))
-					end
-					override = m_max(override, effect or 0)
-				end
-				output["Maximum"..ailment] = modDB:Override(nil, ailment.."Max") or ailmentData[ailment].max
-				output["Current"..ailment] = m_floor(m_min(m_max(override, enemyDB:Sum("BASE", nil, ailment.."Val"), ailment == "Chill" and output.BonechillEffect or 0), output["Maximum"..ailment]) * (10 ^ ailmentData[ailment].precision)) / (10 ^ ailmentData[ailment].precision)
-				for _, mod in ipairs(val.mods(output["Current"..ailment])) do
-					enemyDB:AddMod(mod)
-				end
-				enemyDB:NewMod("Condition:Already"..val.condition, "FLAG", true, { type = "Condition", var = val.condition } ) -- Prevents ailment from applying doubly for minions
-			end
-		end
-	*/
-
-	/*
-		TODO -- Check for extra auras
-		for _, value in ipairs(modDB:List(nil, "ExtraAura")) do
-			local modList = { value.mod }
-			if not value.onlyAllies then
-				local inc = modDB:Sum("INC", nil, "BuffEffectOnSelf", "AuraEffectOnSelf")
-				local more = modDB:More(nil, "BuffEffectOnSelf", "AuraEffectOnSelf")
-				modDB:ScaleAddList(modList, (1 + inc / 100) * more)
-				if not value.notBuff then
-					modDB.multipliers["BuffOnSelf"] = (modDB.multipliers["BuffOnSelf"] or 0) + 1
-				end
-			end
-			if env.minion and not modDB:Flag(nil, "SelfAurasCannotAffectAllies") then
-				local inc = env.minion.modDB:Sum("INC", nil, "BuffEffectOnSelf", "AuraEffectOnSelf")
-				local more = env.minion.modDB:More(nil, "BuffEffectOnSelf", "AuraEffectOnSelf")
-				env.minion.modDB:ScaleAddList(modList, (1 + inc / 100) * more)
-			end
-		end
-	*/
+	// Calculate maximum and apply the strongest non-damaging ailments
+	applyNonDamagingAilments(env)
+
+	// Check for extra auras
+	for _, value := range utils.CastSlice[mod.ExtraAura](env.ModDB.List(nil, "ExtraAura")) {
+		modList := moddb.NewModList()
+		modList.AddMod(value.Mod)
+		if !value.OnlyAllies {
+			inc := env.ModDB.Sum(mod.TypeIncrease, nil, "BuffEffectOnSelf", "AuraEffectOnSelf")
+			more := env.ModDB.More(nil, "BuffEffectOnSelf", "AuraEffectOnSelf")
+			env.ModDB.ScaleAddList(modList, (1+inc/100)*more)
+			env.ModDB.Multipliers["BuffOnSelf"]++
+		}
+		if env.Minion != nil && !env.ModDB.Flag(nil, "SelfAurasCannotAffectAllies") {
+			inc := env.Minion.ModDB.Sum(mod.TypeIncrease, nil, "BuffEffectOnSelf", "AuraEffectOnSelf")
+			more := env.Minion.ModDB.More(nil, "BuffEffectOnSelf", "AuraEffectOnSelf")
+			env.Minion.ModDB.ScaleAddList(modList, (1+inc/100)*more)
+		}
+	}
 
-	/*
-		TODO -- Check for modifiers to apply to actors affected by player auras or curses
-		for _, value in ipairs(modDB:List(nil, "AffectedByAuraMod")) do
-			for actor in pairs(affectedByAura) do
-				actor.modDB:AddMod(value.mod)
-			end
-		end
-		for _, value in ipairs(modDB:List(nil, "AffectedByCurseMod")) do
-			for actor in pairs(affectedByCurse) do
-				actor.modDB:AddMod(value.mod)
-			end
-		end
-	*/
+	// Check for modifiers to apply to actors affected by player auras or curses
+	for _, value := range utils.CastSlice[mod.AffectedByAuraMod](env.ModDB.List(nil, "AffectedByAuraMod")) {
+		for actorDB := range affectedByAura {
+			actorDB.AddMod(value.Mod)
+		}
+	}
+	for _, value := range utils.CastSlice[mod.AffectedByCurseMod](env.ModDB.List(nil, "AffectedByCurseMod")) {
+		for actorDB := range affectedByCurse {
+			actorDB.AddMod(value.Mod)
+		}
+	}
 
 	// Merge keystones again to catch any that were added by buffs
 	mergeKeystones(env)
@@ -2120,7 +1292,7 @@ func PerformCalc(env *Environment) {
 	// Process misc buffs/modifiers
 	DoActorMisc(env, env.Player)
 	if env.Minion != nil {
-		// TODO doActorMisc(env, env.minion)
+		DoActorMisc(env, env.Minion)
 	}
 	DoActorMisc(env, env.Enemy)
 
@@ -2139,54 +1311,23 @@ func PerformCalc(env *Environment) {
 		}
 	}
 
-	/*
-		TODO -- Apply exposures
-		local major, minor = env.spec.treeVersion:match("(%d+)_(%d+)")
-		for _, element in ipairs({"Fire", "Cold", "Lightning"}) do
-			if tonumber(major) <= 3 and tonumber(minor) <= 15 -- Elemental Equilibrium pre-3.16 does not remove Exposure effects
-				or not modDB:Flag(nil, "ElementalEquilibrium") -- if Elemental Equilibrium isn't active we just process Exposure normally
-				or element == "Fire" and not enemyDB:Flag(nil, "Condition:HitByFireDamage")
-				or element == "Cold" and not enemyDB:Flag(nil, "Condition:HitByColdDamage")
-				or element == "Lightning" and not enemyDB:Flag(nil, "Condition:HitByLightningDamage") then
-				local min = math.huge
-				local source = ""
-				for _, mod in ipairs(enemyDB:Tabulate("BASE", nil, element.."Exposure")) do
-					if mod.value < min then
-						min = mod.value
-						source = mod.mod.source
-					end
-				end
-				if min ~= math.huge then
-					-- Modify the magnitude of all exposures
-					for _, mod in ipairs(modDB:Tabulate("BASE", nil, "ExtraExposure", "Extra"..element.."Exposure")) do
-						min = min + mod.value
-					end
-					enemyDB:NewMod(element.."Resist", "BASE", m_min(min, modDB:Override(nil, "ExposureMin")), source)
-					modDB:NewMod("Condition:AppliedExposureRecently", "FLAG", true, "")
-				end
-			end
-		end
-	*/
+	// Apply exposures
+	applyExposures(env)
 
-	/*
-		TODO -- Handle consecrated ground effects on enemies
-		if enemyDB:Flag(nil, "Condition:OnConsecratedGround") then
-			local effect = 1 + modDB:Sum("INC", nil, "ConsecratedGroundEffect") / 100
-			enemyDB:NewMod("DamageTaken", "INC", enemyDB:Sum("INC", nil, "DamageTakenConsecratedGround") * effect, "Consecrated Ground")
-		end
-	*/
+	// Handle consecrated ground effects on enemies
+	if env.EnemyModDB.Flag(nil, "Condition:OnConsecratedGround") {
+		effect := 1 + env.ModDB.Sum(mod.TypeIncrease, nil, "ConsecratedGroundEffect")/100
+		env.EnemyModDB.AddMod(mod.NewFloat("DamageTaken", mod.TypeIncrease, env.EnemyModDB.Sum(mod.TypeIncrease, nil, "DamageTakenConsecratedGround")*effect).Source("Consecrated Ground"))
+	}
 
 	// Defence/offence calculations
 	CalculateDefence(env, env.Player)
 	CalculateOffence(env, env.Player, env.Player.MainSkill)
 
-	/*
-		TODO Minion Defence/offence calculations
-		if env.minion then
-			calcs.defence(env, env.minion)
-			calcs.offence(env, env.minion, env.minion.mainSkill)
-		end
-	*/
+	if env.Minion != nil {
+		CalculateDefence(env, env.Minion)
+		CalculateOffence(env, env.Minion, env.Minion.MainSkill)
+	}
 
 	/*
 		TODO Cache Data
@@ -2204,77 +1345,72 @@ func doActorAttribsPoolsConditions(env *Environment, actor *Actor) {
 		local breakdown = actor.breakdown
 		local condList = modDB.conditions
 	*/
+	condList := actor.ModDB.Conditions
+
+	// Set conditions
+	if weapon2 := actor.ItemList["Weapon 2"]; weapon2 != nil && weapon2.Type() == data.Shield {
+		// TODO or (actor == env.player and env.aegisModList)
+		condList["UsingShield"] = true
+	}
+	if actor.ItemList["Weapon 2"] == nil {
+		condList["OffHandIsEmpty"] = true
+	}
+
+	setWeaponConditions := func(weaponData *WeaponData) {
+		info := weaponData.Info()
+		if info == nil {
+			return
+		}
+
+		condList["Using"+info.Flag] = true
+		if weaponData.CountsAsAll1H {
+			condList["UsingAxe"] = true
+			condList["UsingSword"] = true
+			condList["UsingDagger"] = true
+			condList["UsingMace"] = true
+			condList["UsingClaw"] = true
+			// GGG stated that a single Varunastra satisfied requirement for wielding two different weapons
+			condList["WieldingDifferentWeaponTypes"] = true
+		}
+		if info.Melee {
+			condList["UsingMeleeWeapon"] = true
+		}
+		if info.OneHand {
+			condList["UsingOneHandedWeapon"] = true
+		} else {
+			condList["UsingTwoHandedWeapon"] = true
+		}
+	}
+
+	if actor.WeaponData1 != nil && actor.WeaponData1.Type == data.None {
+		condList["Unarmed"] = true
+		if actor.ItemList["Weapon 2"] == nil && actor.ItemList["Gloves"] == nil {
+			condList["Unencumbered"] = true
+		}
+	} else {
+		setWeaponConditions(actor.WeaponData1)
+	}
+	setWeaponConditions(actor.WeaponData2)
+
+	if actor.WeaponData1.Info() != nil && actor.WeaponData2.Info() != nil {
+		weapon1, weapon2 := actor.WeaponData1, actor.WeaponData2
+		condList["DualWielding"] = true
+		if (weapon1.Type == data.Claw || weapon1.CountsAsAll1H) && (weapon2.Type == data.Claw || weapon2.CountsAsAll1H) {
+			condList["DualWieldingClaws"] = true
+		}
+		if (weapon1.Type == data.Dagger || weapon1.CountsAsAll1H) && (weapon2.Type == data.Dagger || weapon2.CountsAsAll1H) {
+			condList["DualWieldingDaggers"] = true
+		}
+		info1, info2 := weapon1.Info(), weapon2.Info()
+		if utils.Ternary(info1.Label != "", info1.Label, string(weapon1.Type)) != utils.Ternary(info2.Label != "", info2.Label, string(weapon2.Type)) {
+			if info1.OneHand && info2.OneHand {
+				condList["WieldingDifferentWeaponTypes"] = true
+			}
+		}
+	}
+
 	/*
 		TODO -- Set conditions
-		if (actor.itemList["Weapon 2"] and actor.itemList["Weapon 2"].type == "Shield") or (actor == env.player and env.aegisModList) then
-			condList["UsingShield"] = true
-		end
-		if not actor.itemList["Weapon 2"] then
-			condList["OffHandIsEmpty"] = true
-		end
-		if actor.weaponData1.type == "None" then
-			condList["Unarmed"] = true
-			if not actor.itemList["Weapon 2"] and not actor.itemList["Gloves"] then
-				condList["Unencumbered"] = true
-			end
-		else
-			local info = env.data.weaponTypeInfo[actor.weaponData1.type]
-			condList["Using"..info.flag] = true
-			if actor.weaponData1.countsAsAll1H then
-				condList["UsingAxe"] = true
-				condList["UsingSword"] = true
-				condList["UsingDagger"] = true
-				condList["UsingMace"] = true
-				condList["UsingClaw"] = true
-				-- GGG stated that a single Varunastra satisfied requirement for wielding two different weapons
-				condList["WieldingDifferentWeaponTypes"] = true
-			end
-			if info.melee then
-				condList["UsingMeleeWeapon"] = true
-			end
-			if info.oneHand then
-				condList["UsingOneHandedWeapon"] = true
-			else
-				condList["UsingTwoHandedWeapon"] = true
-			end
-		end
-		if actor.weaponData2.type then
-			local info = env.data.weaponTypeInfo[actor.weaponData2.type]
-			condList["Using"..info.flag] = true
-			if actor.weaponData2.countsAsAll1H then
-				condList["UsingAxe"] = true
-				condList["UsingSword"] = true
-				condList["UsingDagger"] = true
-				condList["UsingMace"] = true
-				condList["UsingClaw"] = true
-				-- GGG stated that a single Varunastra satisfied requirement for wielding two different weapons
-				condList["WieldingDifferentWeaponTypes"] = true
-			end
-			if info.melee then
-				condList["UsingMeleeWeapon"] = true
-			end
-			if info.oneHand then
-				condList["UsingOneHandedWeapon"] = true
-			else
-				condList["UsingTwoHandedWeapon"] = true
-			end
-		end
-		if actor.weaponData1.type and actor.weaponData2.type then
-			condList["DualWielding"] = true
-			if (actor.weaponData1.type == "Claw" or actor.weaponData1.countsAsAll1H) and (actor.weaponData2.type == "Claw" or actor.weaponData2.countsAsAll1H) then
-				condList["DualWieldingClaws"] = true
-			end
-			if (actor.weaponData1.type == "Dagger" or actor.weaponData1.countsAsAll1H) and (actor.weaponData2.type == "Dagger" or actor.weaponData2.countsAsAll1H) then
-				condList["DualWieldingDaggers"] = true
-			end
-			if (env.data.weaponTypeInfo[actor.weaponData1.type].label or actor.weaponData1.type) ~= (env.data.weaponTypeInfo[actor.weaponData2.type].label or actor.weaponData2.type) then
-				local info1 = env.data.weaponTypeInfo[actor.weaponData1.type]
-				local info2 = env.data.weaponTypeInfo[actor.weaponData2.type]
-				if info1.oneHand and info2.oneHand then
-					condList["WieldingDifferentWeaponTypes"] = true
-				end
-			end
-		end
 		if env.mode_combat then
 			if not modDB:Flag(nil, "NeverCrit") then
 				condList["CritInPast8Sec"] = true
@@ -2335,12 +1471,9 @@ func doActorAttribsPoolsConditions(env *Environment, actor *Actor) {
 		for p := 1; p <= 2; p++ {
 			for _, stat := range []string{"Str", "Dex", "Int"} {
 				actor.Output[stat] = math.Max(math.Round(CalcVal(actor.ModDB, stat, nil)), 0)
-				/*
-					TODO Breakdown
-					if breakdown then
-						breakdown[stat] = breakdown.simple(nil, nil, output[stat], stat)
-					end
-				*/
+				if actor.Breakdown != nil {
+					actor.Breakdown.simple(0, nil, actor.Output[stat], stat)
+				}
 			}
 
 			stats := []float64{actor.Output["Str"], actor.Output["Dex"], actor.Output["Int"]}
@@ -2421,113 +1554,576 @@ func doActorAttribsPoolsConditions(env *Environment, actor *Actor) {
 		calculateAttributes()
 	}
 
-	/*
-		TODO -- Calculate total attributes
-		output.TotalAttr = output.Str + output.Dex + output.Int
-	*/
-	/*
-		TODO -- Special case for Devotion
-		output.Devotion = modDB:Sum("BASE", nil, "Devotion")
-	*/
+	/*
+		TODO -- Calculate total attributes
+		output.TotalAttr = output.Str + output.Dex + output.Int
+	*/
+	/*
+		TODO -- Special case for Devotion
+		output.Devotion = modDB:Sum("BASE", nil, "Devotion")
+	*/
+
+	// Add attribute bonuses
+	if !actor.ModDB.Flag(nil, "NoAttributeBonuses") {
+		if !actor.ModDB.Flag(nil, "NoStrengthAttributeBonuses") {
+			if !actor.ModDB.Flag(nil, "NoStrBonusToLife") {
+				actor.ModDB.AddMod(mod.NewFloat("Life", mod.TypeBase, math.Floor(actor.Output["Str"]/2)).Source("Strength"))
+			}
+			strDmgBonusRatioOverride := actor.ModDB.Sum(mod.TypeBase, nil, "StrDmgBonusRatioOverride")
+			if strDmgBonusRatioOverride > 0 {
+				actor.StrDmgBonus = math.Floor((actor.Output["Str"] + actor.ModDB.Sum(mod.TypeBase, nil, "DexIntToMeleeBonus")) * strDmgBonusRatioOverride)
+			} else {
+				actor.StrDmgBonus = math.Floor((actor.Output["Str"] + actor.ModDB.Sum(mod.TypeBase, nil, "DexIntToMeleeBonus")) / 5)
+			}
+			actor.ModDB.AddMod(mod.NewFloat("PhysicalDamage", mod.TypeIncrease, actor.StrDmgBonus).Source("Strength").Flag(mod.MFlagMelee))
+		}
+
+		if !actor.ModDB.Flag(nil, "NoDexterityAttributeBonuses") {
+			accuracyMult := data.AccuracyPerDexBase
+			DexAccBonusOverride := actor.ModDB.Override(nil, "DexAccBonusOverride")
+			if DexAccBonusOverride != nil {
+				accuracyMult = DexAccBonusOverride.(float64)
+			}
+
+			actor.ModDB.AddMod(mod.NewFloat("Accuracy", mod.TypeBase, actor.Output["Dex"]*accuracyMult).Source("Dexterity"))
+			if !actor.ModDB.Flag(nil, "NoDexBonusToEvasion") {
+				actor.ModDB.AddMod(mod.NewFloat("Evasion", mod.TypeIncrease, math.Floor(actor.Output["Dex"]/5)).Source("Dexterity"))
+			}
+		}
+
+		if !actor.ModDB.Flag(nil, "NoIntelligenceAttributeBonuses") {
+			if !actor.ModDB.Flag(nil, "NoIntBonusToMana") {
+				actor.ModDB.AddMod(mod.NewFloat("Mana", mod.TypeBase, math.Floor(actor.Output["Int"]/2)).Source("Intelligence"))
+			}
+
+			if !actor.ModDB.Flag(nil, "NoIntBonusToES") {
+				actor.ModDB.AddMod(mod.NewFloat("EnergyShield", mod.TypeIncrease, math.Floor(actor.Output["Int"]/5)).Source("Intelligence"))
+			}
+		}
+	}
+
+	/*
+		TODO -- Check shrine buffs, must be done before life pool calculated for massive shrine
+		for _, value in ipairs(modDB:List(nil, "ShrineBuff")) do
+			modDB:ScaleAddList({ value.mod }, calcLib.mod(modDB, nil, "BuffEffectOnSelf", "ShrineBuffEffect"))
+		end
+
+		output.ChaosInoculation = modDB:Flag(nil, "ChaosInoculation")
+	*/
+	// Life/mana pools
+	if actor.ModDB.Flag(nil, "ChaosInoculation") {
+		actor.Output["Life"] = 1
+		actor.ModDB.Conditions["FullLife"] = true
+	} else {
+		base := actor.ModDB.Sum(mod.TypeBase, nil, "Life")
+		inc := actor.ModDB.Sum(mod.TypeIncrease, nil, "Life")
+		more := actor.ModDB.More(nil, "Life")
+		conv := actor.ModDB.Sum(mod.TypeBase, nil, "LifeConvertToEnergyShield")
+		actor.Output["Life"] = math.Max(math.Round(base*(1+inc/100)*more*(1-conv/100)), 1)
+		if actor.Breakdown != nil && (inc != 0 || more != 1 || conv != 0) {
+			s := actor.Breakdown.simple(0, nil, actor.Output["Life"], "Life")
+			if conv != 0 {
+				s.Lines = s.Lines[:len(s.Lines)-1]
+				s.line("x %.2f (converted to Energy Shield)", 1-conv/100)
+				s.line("= %g", actor.Output["Life"])
+			}
+		}
+	}
+
+	manaConv := actor.ModDB.Sum(mod.TypeBase, nil, "ManaConvertToArmour")
+	actor.Output["Mana"] = math.Round(CalcVal(actor.ModDB, "Mana", nil) * (1 - manaConv/100))
+	if actor.Breakdown != nil && (actor.ModDB.Sum(mod.TypeIncrease, nil, "Mana") != 0 || actor.ModDB.More(nil, "Mana") != 1 || manaConv != 0) {
+		s := actor.Breakdown.simple(0, nil, actor.Output["Mana"], "Mana")
+		if manaConv != 0 {
+			s.Lines = s.Lines[:len(s.Lines)-1]
+			s.line("x %.2f (converted to Armour)", 1-manaConv/100)
+			s.line("= %g", actor.Output["Mana"])
+		}
+	}
+
+	actor.Output["LowestOfMaximumLifeAndMaximumMana"] = math.Min(actor.Output["Life"], actor.Output["Mana"])
+}
+
+// calculateSkillReservation adds the life and mana reserved by the skill to the player reservations
+func calculateSkillReservation(env *Environment, activeSkill *ActiveSkill, reservationLines map[string][]string) {
+	skillModList := activeSkill.SkillModList
+	skillCfg := activeSkill.SkillCfg
+	skillData := activeSkill.SkillData
+	level := activeSkill.ActiveEffect.GrantedEffectLevel
+	mult := skillModList.More(skillCfg, "SupportManaMultiplier")
+
+	// baseValue returns the value of the skill data if set, otherwise the value of the gem level
+	baseValue := func(key string, levelValue *float64) float64 {
+		if value, ok := skillData[key].(float64); ok {
+			return value
+		}
+		return utils.UnwrapOrF(levelValue, 0)
+	}
+
+	baseFlat := map[string]float64{
+		"Mana": baseValue("ManaReservationFlat", level.ManaReservationFlat),
+		"Life": baseValue("LifeReservationFlat", level.LifeReservationFlat),
+	}
+	basePercent := map[string]float64{
+		"Mana": baseValue("ManaReservationPercent", level.ManaReservationPercent),
+		"Life": baseValue("LifeReservationPercent", level.LifeReservationPercent),
+	}
+	if level.Cost != nil {
+		if skillModList.Flag(skillCfg, "ManaCostGainAsReservation") {
+			baseFlat["Mana"] = skillModList.Sum(mod.TypeBase, skillCfg, "ManaCostBase") + float64(level.Cost["Mana"])
+		}
+		if skillModList.Flag(skillCfg, "LifeCostGainAsReservation") {
+			baseFlat["Life"] = skillModList.Sum(mod.TypeBase, skillCfg, "LifeCostBase") + float64(level.Cost["Life"])
+		}
+	}
+
+	// Forced reservations are read into a local table so moving them between pools leaves the skill data untouched
+	forced := make(map[string]float64, 4)
+	for _, pool := range []string{"Life", "Mana"} {
+		for _, kind := range []string{"Flat", "Percent"} {
+			if value, ok := skillData[pool+"Reservation"+kind+"Forced"].(float64); ok {
+				forced[pool+kind] = value
+			}
+		}
+	}
+
+	if skillModList.Flag(skillCfg, "BloodMagicReserved") {
+		baseFlat["Life"] += baseFlat["Mana"]
+		baseFlat["Mana"] = 0
+		basePercent["Life"] += basePercent["Mana"]
+		basePercent["Mana"] = 0
+		for _, kind := range []string{"Flat", "Percent"} {
+			if value, ok := forced["Mana"+kind]; ok {
+				forced["Life"+kind] = value
+			} else {
+				delete(forced, "Life"+kind)
+			}
+			delete(forced, "Mana"+kind)
+		}
+	}
+
+	skillName := activeSkill.ActiveEffect.GrantedEffect.Raw.GetActiveSkill().DisplayedName
+	for _, pool := range []string{"Life", "Mana"} {
+		more := skillModList.More(skillCfg, pool+"Reserved", "Reserved")
+		inc := skillModList.Sum(mod.TypeIncrease, skillCfg, pool+"Reserved", "Reserved")
+		efficiency := math.Max(skillModList.Sum(mod.TypeIncrease, skillCfg, pool+"ReservationEfficiency", "ReservationEfficiency"), -100)
+
+		reservedFlat := float64(0)
+		if value, ok := forced[pool+"Flat"]; ok {
+			reservedFlat = value
+		} else if baseFlatVal := math.Floor(baseFlat[pool] * mult); more > 0 && inc > -100 && baseFlatVal != 0 {
+			reservedFlat = math.Max(math.Round(baseFlatVal*(100+inc)/100*more/(1+efficiency/100)), 0)
+		}
+
+		reservedPercent := float64(0)
+		if value, ok := forced[pool+"Percent"]; ok {
+			reservedPercent = value
+		} else if basePercentVal := basePercent[pool] * mult; more > 0 && inc > -100 && basePercentVal != 0 {
+			reservedPercent = math.Max(utils.RoundTo(basePercentVal*(100+inc)/100*more/(1+efficiency/100), 2), 0)
+		}
+
+		// TODO Multiply by the active mine count once mines are supported
+
+		// reservationLine describes how the reservation of the skill was calculated
+		reservationLine := func(base string, total string) string {
+			line := skillName + ": " + base
+			if mult != 1 {
+				line += fmt.Sprintf(" x %g", mult)
+			}
+			if more != 1 {
+				line += fmt.Sprintf(" x %g", more)
+			}
+			if inc != 0 {
+				line += fmt.Sprintf(" x %g", 1+inc/100)
+			}
+			if efficiency != 0 {
+				line += fmt.Sprintf(" x %.4f", 1/(1+efficiency/100))
+			}
+			return line + " = " + total
+		}
+
+		delete(skillData, pool+"ReservedBase")
+		delete(skillData, pool+"ReservedPercent")
+		if reservedFlat != 0 {
+			skillData[pool+"ReservedBase"] = reservedFlat
+			if pool == "Life" {
+				env.Player.ReservedLifeBase += reservedFlat
+			} else {
+				env.Player.ReservedManaBase += reservedFlat
+			}
+			reservationLines[pool] = append(reservationLines[pool], reservationLine(fmt.Sprintf("%g", baseFlat[pool]), fmt.Sprintf("%g", reservedFlat)))
+		}
+		if reservedPercent != 0 {
+			skillData[pool+"ReservedPercent"] = reservedPercent
+			reservedBase, _ := skillData[pool+"ReservedBase"].(float64)
+			skillData[pool+"ReservedBase"] = reservedBase + math.Ceil(env.Player.Output[pool]*reservedPercent/100)
+			if pool == "Life" {
+				env.Player.ReservedLifePercent += reservedPercent
+			} else {
+				env.Player.ReservedManaPercent += reservedPercent
+			}
+			reservationLines[pool] = append(reservationLines[pool], reservationLine(fmt.Sprintf("%g%%", basePercent[pool]), fmt.Sprintf("%g%%", reservedPercent)))
+		}
+	}
+}
+
+// curse is a curse competing for one of the enemy's curse slots
+type curse struct {
+	name                   string
+	fromPlayer             bool
+	priority               float64
+	isMark                 bool
+	ignoreHexLimit         bool
+	socketedCursesHexLimit bool
+	modList                *moddb.ModList
+	buffModList            *moddb.ModList
+	minionBuffModList      *moddb.ModList
+}
+
+// mergeBuff merges the source list into the named destination list, keeping the highest value of matching mods
+func mergeBuff(src *moddb.ModList, destTable map[string]*moddb.ModList, destKey string) {
+	dest, ok := destTable[destKey]
+	if !ok {
+		dest = moddb.NewModList()
+		destTable[destKey] = dest
+	}
+	if src == nil {
+		return
+	}
+
+	for _, m := range src.Mods() {
+		match := false
+		if m.Type() != mod.TypeList {
+			destMods := dest.Mods()
+			for i, destMod := range destMods {
+				if !compareModParams(m, destMod) {
+					continue
+				}
+
+				value, ok := m.Value().(float64)
+				destValue, destOk := destMod.Value().(float64)
+				if ok && destOk && value > destValue {
+					destMods[i] = m
+				}
+				match = true
+				break
+			}
+		}
+		if !match {
+			dest.AddMod(m)
+		}
+	}
+}
+
+// reservationExceedsPool reports whether the skill reserves more life or mana than the player has
+func reservationExceedsPool(env *Environment, activeSkill *ActiveSkill) bool {
+	for _, pool := range []string{"Mana", "Life"} {
+		if reserved, ok := activeSkill.SkillData[pool+"ReservedBase"].(float64); ok && reserved > env.Player.Output[pool] {
+			return true
+		}
+	}
+	return false
+}
+
+// determineCursePriority returns the priority of a curse, combining its base priority with the socket, slot and source it comes from
+func determineCursePriority(curseName string, activeSkill *ActiveSkill) float64 {
+	source := ""
+	slot := ""
+	socket := 1
+	if activeSkill != nil {
+		if socketGroup, ok := activeSkill.SocketGroup.(*pob.Skill); ok && socketGroup != nil {
+			source = socketGroup.Source
+			slot = socketGroup.Slot
+			for i, gem := range socketGroup.Gems {
+				if gem.NameSpec == curseName {
+					socket = i + 1
+				}
+			}
+		}
+	}
+
+	basePriority := data.CursePriority[curseName]
+	socketPriority := float64(socket) * data.CursePriority["SocketPriorityBase"]
+	slotPriority := data.CursePriority[strings.ReplaceAll(slot, " Swap", "")]
+	sourcePriority := float64(0)
+	if activeSkill != nil && activeSkill.SkillTypes[data.SkillTypeAura] {
+		sourcePriority = data.CursePriority["CurseFromAura"]
+	} else if source != "" {
+		sourcePriority = data.CursePriority["CurseFromEquipment"]
+	}
+
+	return basePriority + socketPriority + slotPriority + sourcePriority
+}
+
+// assignCurseSlots picks the curses that end up on the enemy, respecting the curse limit and curse priorities
+func assignCurseSlots(env *Environment, curses []*curse, limit int) []*curse {
+	curseSlots := make([]*curse, limit)
+
+	// Currently assume only 1 mark is possible
+	markSlotted := false
+	for _, c := range curses {
+		// Calculate curses that ignore hex limit after
+		if c.ignoreHexLimit || c.socketedCursesHexLimit {
+			continue
+		}
+
+		// Check if we need to disable a certain curse aura
+		skipAddingCurse := false
+		for _, activeSkill := range env.Player.ActiveSkillList {
+			if len(activeSkill.BuffList) > 0 && c.name == activeSkill.BuffList[0].Name && activeSkill.SkillTypes[data.SkillTypeAura] {
+				skipAddingCurse = env.ModDB.Flag(nil, "SelfAurasOnlyAffectYou") || reservationExceedsPool(env, activeSkill)
+				break
+			}
+		}
+
+		slot := -1
+		for i := 0; i < limit; i++ {
+			// Prevent multiple marks from being considered
+			if c.isMark && markSlotted {
+				slot = -1
+				break
+			}
+
+			if curseSlots[i] == nil {
+				slot = i
+				break
+			} else if curseSlots[i].name == c.name {
+				slot = utils.Ternary(curseSlots[i].priority < c.priority, i, -1)
+				break
+			} else if curseSlots[i].priority < c.priority {
+				slot = i
+			}
+		}
+
+		if slot >= 0 {
+			if curseSlots[slot] != nil && curseSlots[slot].isMark {
+				markSlotted = false
+			}
+			if !skipAddingCurse {
+				curseSlots[slot] = c
+			}
+			if c.isMark {
+				markSlotted = true
+			}
+		}
+	}
+
+	// Slots are filled in order, so any empty slot marks the end of the list
+	for i, c := range curseSlots {
+		if c == nil {
+			curseSlots = curseSlots[:i]
+			break
+		}
+	}
+
+	for _, c := range curses {
+		if c.ignoreHexLimit {
+			skipAddingCurse := false
+			for i := range curseSlots {
+				if curseSlots[i].name == c.name {
+					// If curse is higher priority, replace current curse with it, otherwise if same or lower priority skip it entirely
+					if curseSlots[i].priority < c.priority {
+						curseSlots[i] = c
+					}
+					skipAddingCurse = true
+					break
+				}
+			}
+			if !skipAddingCurse {
+				curseSlots = append(curseSlots, c)
+			}
+		}
+
+		if c.socketedCursesHexLimit {
+			socketedCursesHexLimitValue := int(env.ModDB.Sum(mod.TypeBase, nil, "SocketedCursesHexLimitValue"))
+			skipAddingCurse := false
+			for i := range curseSlots {
+				if curseSlots[i].name == c.name {
+					// If curse is higher priority, replace current curse with it, otherwise if same or lower priority skip it entirely
+					if curseSlots[i].priority < c.priority {
+						curseSlots[i] = c
+					}
+					skipAddingCurse = true
+					break
+				}
+				if i+1 >= socketedCursesHexLimitValue {
+					skipAddingCurse = true
+				}
+			}
+			if !skipAddingCurse {
+				curseSlots = append(curseSlots, c)
+			}
+		}
+	}
+
+	return curseSlots
+}
+
+// extraCurseGrantedEffect resolves the granted effect of a curse applied by a modifier, looking it up by name if no ID is given
+func extraCurseGrantedEffect(value mod.ExtraCurse) *poe.GrantedEffect {
+	if value.SkillID != "" {
+		return poe.GrantedEffectByID(value.SkillID)
+	}
+
+	for _, grantedEffect := range poe.GrantedEffects {
+		if !grantedEffect.IsSupport && grantedEffect.GetActiveSkill() != nil && grantedEffect.GetActiveSkill().DisplayedName == value.SkillName {
+			return grantedEffect
+		}
+	}
+	return nil
+}
+
+// nonDamagingAilment is a non-damaging ailment that can be applied to the enemy
+type nonDamagingAilment struct {
+	ailment   data.Ailment
+	condition string
+	mods      func(output map[string]float64, num float64) []mod.Mod
+}
+
+var nonDamagingAilmentList = []nonDamagingAilment{
+	{ailment: data.AilmentChill, condition: "Chilled", mods: func(output map[string]float64, num float64) []mod.Mod {
+		mods := []mod.Mod{
+			mod.NewFloat("ActionSpeed", mod.TypeIncrease, -num).Source(mod.SourceChill).Tag(mod.Condition("Chilled")),
+		}
+		if output["BonechillEffect"] > 0 {
+			mods = append(mods, mod.NewFloat("ColdDamageTaken", mod.TypeIncrease, math.Min(output["BonechillEffect"], output["MaximumChill"])).Source("Bonechill").Tag(mod.Condition("Chilled")))
+		}
+		return mods
+	}},
+	{ailment: data.AilmentShock, condition: "Shocked", mods: func(output map[string]float64, num float64) []mod.Mod {
+		return []mod.Mod{mod.NewFloat("DamageTaken", mod.TypeIncrease, num).Source(mod.SourceShock).Tag(mod.Condition("Shocked"))}
+	}},
+	{ailment: data.AilmentScorch, condition: "Scorched", mods: func(output map[string]float64, num float64) []mod.Mod {
+		return []mod.Mod{mod.NewFloat("ElementalResist", mod.TypeBase, -num).Source(mod.SourceScorch).Tag(mod.Condition("Scorched"))}
+	}},
+	{ailment: data.AilmentBrittle, condition: "Brittle", mods: func(output map[string]float64, num float64) []mod.Mod {
+		return []mod.Mod{mod.NewFloat("SelfCritChance", mod.TypeBase, num).Source(mod.SourceBrittle).Tag(mod.Condition("Brittle"))}
+	}},
+	{ailment: data.AilmentSap, condition: "Sapped", mods: func(output map[string]float64, num float64) []mod.Mod {
+		return []mod.Mod{mod.NewFloat("Damage", mod.TypeMore, -num).Source(mod.SourceSap).Tag(mod.Condition("Sapped"))}
+	}},
+}
+
+// applyNonDamagingAilments applies the strongest of each non-damaging ailment to the enemy,
+// taken from the player's guaranteed ailment modifiers and the configured ailment effects
+func applyNonDamagingAilments(env *Environment) {
+	output := env.Player.Output
+	for _, value := range nonDamagingAilmentList {
+		name := string(value.ailment)
+		ailmentData := data.NonDamagingAilments[value.ailment]
+
+		enemyVal := env.EnemyModDB.Sum(mod.TypeBase, nil, name+"Val")
+		if override, ok := env.EnemyModDB.Override(nil, name+"Val").(float64); ok {
+			enemyVal = override
+		}
+		bonechill := float64(0)
+		if value.ailment == data.AilmentChill {
+			bonechill = output["BonechillEffect"]
+		}
 
-	// Add attribute bonuses
-	if !env.ModDB.Flag(nil, "NoAttributeBonuses") {
-		if !env.ModDB.Flag(nil, "NoStrengthAttributeBonuses") {
-			if !env.ModDB.Flag(nil, "NoStrBonusToLife") {
-				env.ModDB.AddMod(mod.NewFloat("Life", mod.TypeBase, math.Floor(actor.Output["Str"]/2)).Source("Strength"))
+		if enemyVal <= 0 && env.ModDB.Sum(mod.TypeBase, nil, name+"Base", name+"Override") <= 0 && bonechill <= 0 {
+			continue
+		}
+		if env.EnemyModDB.Flag(nil, "Condition:Already"+value.condition) {
+			continue
+		}
+
+		override := float64(0)
+		for _, tabulated := range env.ModDB.Tabulate(mod.TypeBase, nil, name+"Base", name+"Override") {
+			effect, _ := tabulated.Value.(float64)
+			if tabulated.Mod.Name() == name+"Override" {
+				env.EnemyModDB.AddMod(mod.NewFlag("Condition:"+value.condition, true).Source(tabulated.Mod.GetSource()))
 			}
-			strDmgBonusRatioOverride := env.ModDB.Sum(mod.TypeBase, nil, "StrDmgBonusRatioOverride")
-			if strDmgBonusRatioOverride > 0 {
-				actor.StrDmgBonus = math.Floor((actor.Output["Str"] + env.ModDB.Sum(mod.TypeBase, nil, "DexIntToMeleeBonus")) * strDmgBonusRatioOverride)
-			} else {
-				actor.StrDmgBonus = math.Floor((actor.Output["Str"] + env.ModDB.Sum(mod.TypeBase, nil, "DexIntToMeleeBonus")) / 5)
+			if tabulated.Mod.Name() == name+"Base" {
+				effect *= CalcMod(env.ModDB, nil, "Enemy"+name+"Effect")
+				env.ModDB.AddMod(mod.NewFloat(name+"Override", mod.TypeBase, effect).Source(tabulated.Mod.GetSource()).Flag(tabulated.Mod.Flags()).KeywordFlag(tabulated.Mod.KeywordFlags()).Tag(tabulated.Mod.Tags()...))
 			}
-			env.ModDB.AddMod(mod.NewFloat("PhysicalDamage", mod.TypeIncrease, actor.StrDmgBonus).Source("Strength").Flag(mod.MFlagMelee))
+			override = math.Max(override, effect)
 		}
 
-		if !env.ModDB.Flag(nil, "NoDexterityAttributeBonuses") {
-			accuracyMult := data.AccuracyPerDexBase
-			DexAccBonusOverride := env.ModDB.Override(nil, "DexAccBonusOverride")
-			if DexAccBonusOverride != nil {
-				accuracyMult = DexAccBonusOverride.(float64)
-			}
+		output["Maximum"+name] = ailmentData.Max
+		if maximum, ok := env.ModDB.Override(nil, name+"Max").(float64); ok {
+			output["Maximum"+name] = maximum
+		}
+		precision := math.Pow(10, ailmentData.Precision)
+		output["Current"+name] = math.Floor(min(max(override, enemyVal, bonechill), output["Maximum"+name])*precision) / precision
+		for _, m := range value.mods(output, output["Current"+name]) {
+			env.EnemyModDB.AddMod(m)
+		}
 
-			env.ModDB.AddMod(mod.NewFloat("Accuracy", mod.TypeBase, actor.Output["Dex"]*accuracyMult).Source("Dexterity"))
-			if !env.ModDB.Flag(nil, "NoDexBonusToEvasion") {
-				env.ModDB.AddMod(mod.NewFloat("Evasion", mod.TypeIncrease, math.Floor(actor.Output["Dex"]/5)).Source("Dexterity"))
-			}
+		// Prevents the ailment from applying doubly for minions
+		env.EnemyModDB.AddMod(mod.NewFlag("Condition:Already"+value.condition, true).Tag(mod.Condition(value.condition)))
+	}
+}
+
+// applyExposures applies the strongest exposure of each element to the enemy
+func applyExposures(env *Environment) {
+	// Elemental Equilibrium pre-3.16 does not remove Exposure effects
+	legacyEquilibrium := false
+	if treeVersion, err := data.GetTreeVersion(env.Spec.TreeVersion); err == nil {
+		legacyEquilibrium = treeVersion.Num < 3.16
+	}
+
+	for _, element := range []data.DamageType{data.DamageTypeFire, data.DamageTypeCold, data.DamageTypeLightning} {
+		if !legacyEquilibrium && env.ModDB.Flag(nil, "ElementalEquilibrium") && env.EnemyModDB.Flag(nil, "Condition:HitBy"+string(element)+"Damage") {
+			continue
 		}
 
-		if !env.ModDB.Flag(nil, "NoIntelligenceAttributeBonuses") {
-			if !env.ModDB.Flag(nil, "NoIntBonusToMana") {
-				env.ModDB.AddMod(mod.NewFloat("Mana", mod.TypeBase, math.Floor(actor.Output["Int"]/2)).Source("Intelligence"))
+		exposure := math.Inf(1)
+		source := mod.Source("")
+		for _, tabulated := range env.EnemyModDB.Tabulate(mod.TypeBase, nil, string(element)+"Exposure") {
+			if value, _ := tabulated.Value.(float64); value < exposure {
+				exposure = value
+				source = tabulated.Mod.GetSource()
 			}
+		}
+		if math.IsInf(exposure, 1) {
+			continue
+		}
 
-			if !env.ModDB.Flag(nil, "NoIntBonusToES") {
-				env.ModDB.AddMod(mod.NewFloat("EnergyShield", mod.TypeIncrease, math.Floor(actor.Output["Int"]/5)).Source("Intelligence"))
-			}
+		// Modify the magnitude of all exposures
+		for _, tabulated := range env.ModDB.Tabulate(mod.TypeBase, nil, "ExtraExposure", "Extra"+string(element)+"Exposure") {
+			value, _ := tabulated.Value.(float64)
+			exposure += value
 		}
+		if exposureMin, ok := env.ModDB.Override(nil, "ExposureMin").(float64); ok {
+			exposure = math.Min(exposure, exposureMin)
+		}
+		env.EnemyModDB.AddMod(mod.NewFloat(string(element)+"Resist", mod.TypeBase, exposure).Source(source))
+		env.ModDB.AddMod(mod.NewFlag("Condition:AppliedExposureRecently", true).Source(""))
 	}
+}
 
-	/*
-		TODO -- Check shrine buffs, must be done before life pool calculated for massive shrine
-		for _, value in ipairs(modDB:List(nil, "ShrineBuff")) do
-			modDB:ScaleAddList({ value.mod }, calcLib.mod(modDB, nil, "BuffEffectOnSelf", "ShrineBuffEffect"))
-		end
+func doActorLifeManaReservation(actor *Actor) {
+	pools := map[string][2]float64{
+		"Life": {actor.ReservedLifeBase, actor.ReservedLifePercent},
+		"Mana": {actor.ReservedManaBase, actor.ReservedManaPercent},
+	}
 
-		output.ChaosInoculation = modDB:Flag(nil, "ChaosInoculation")
-	*/
-	/*
-		TODO -- Life/mana pools
-		if output.ChaosInoculation then
-			output.Life = 1
-			condList["FullLife"] = true
-		else
-			local base = modDB:Sum("BASE", nil, "Life")
-			local inc = modDB:Sum("INC", nil, "Life")
-			local more = modDB:More(nil, "Life")
-			local conv = modDB:Sum("BASE", nil, "LifeConvertToEnergyShield")
-			output.Life = m_max(round(base * (1 + inc/100) * more * (1 - conv/100)), 1)
-			if breakdown then
-				if inc ~= 0 or more ~= 1 or conv ~= 0 then
-					breakdown.Life = { }
-					breakdown.Life[1] = s_format("%g ^8(base)", base)
-					if inc ~= 0 then
-						t_insert(breakdown.Life, s_format("x %.2f ^8(increased/reduced)", 1 + inc/100))
-					end
-					if more ~= 1 then
-						t_insert(breakdown.Life, s_format("x %.2f ^8(more/less)", more))
-					end
-					if conv ~= 0 then
-						t_insert(breakdown.Life, s_format("x %.2f ^8(converted to Energy Shield)", 1 - conv/100))
-					end
-					t_insert(breakdown.Life, s_format("= %g", output.Life))
-				end
-			end
-		end
-		local manaConv = modDB:Sum("BASE", nil, "ManaConvertToArmour")
-		output.Mana = round(calcLib.val(modDB, "Mana") * (1 - manaConv / 100))
-		local base = modDB:Sum("BASE", nil, "Mana")
-		local inc = modDB:Sum("INC", nil, "Mana")
-		local more = modDB:More(nil, "Mana")
-		if breakdown then
-			if inc ~= 0 or more ~= 1 or manaConv ~= 0 then
-				breakdown.Mana = { }
-				breakdown.Mana[1] = s_format("%g ^8(base)", base)
-				if inc ~= 0 then
-					t_insert(breakdown.Mana, s_format("x %.2f ^8(increased/reduced)", 1 + inc/100))
-				end
-				if more ~= 1 then
-					t_insert(breakdown.Mana, s_format("x %.2f ^8(more/less)", more))
-				end
-				if manaConv ~= 0 then
-					t_insert(breakdown.Mana, s_format("x %.2f ^8(converted to Armour)", 1 - manaConv/100))
-				end
-				t_insert(breakdown.Mana, s_format("= %g", output.Mana))
+	for _, pool := range []string{"Life", "Mana"} {
+		maxPool := actor.Output[pool]
+		if maxPool <= 0 {
+			continue
+		}
+
+		reserved := pools[pool][0] + math.Ceil(maxPool*pools[pool][1]/100)
+		actor.Output[pool+"Reserved"] = math.Min(reserved, maxPool)
+		actor.Output[pool+"ReservedPercent"] = math.Min(reserved/maxPool*100, 100)
+		actor.Output[pool+"Unreserved"] = maxPool - reserved
+		actor.Output[pool+"UnreservedPercent"] = (maxPool - reserved) / maxPool * 100
+		if (maxPool-reserved)/maxPool <= data.LowPoolThreshold {
+			actor.ModDB.Conditions["Low"+pool] = true
+		}
+
+		/*
+			TODO Auras granted by reserved life or mana
+			for _, value in ipairs(modDB:List(nil, "GrantReserved"..pool.."AsAura")) do
+				local auraMod = copyTable(value.mod)
+				auraMod.value = m_floor(auraMod.value * m_min(reserved, max))
+				modDB:NewMod("ExtraAura", "LIST", { mod = auraMod })
 			end
-		end
-		output.LowestOfMaximumLifeAndMaximumMana = m_min(output.Life, output.Mana)
-	*/
+		*/
+	}
 }
 
 func mergeKeystones(env *Environment) {
@@ -2863,3 +2459,651 @@ func DoActorMisc(env *Environment, actor *Actor) {
 		*/
 	}
 }
+
+// cachedSkill is the result of calculating another skill of the player as the main skill
+type cachedSkill struct {
+	ActiveSkill *ActiveSkill
+	Output      map[string]float64
+}
+
+// calcCachedSkill calculates a skill of the player as if it was the main skill, caching the result in the environment
+func calcCachedSkill(env *Environment, skill *ActiveSkill) *cachedSkill {
+	index := -1
+	for i, activeSkill := range env.Player.ActiveSkillList {
+		if activeSkill == skill {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil
+	}
+
+	if cached, ok := env.skillCache[index]; ok {
+		return cached
+	}
+
+	var cached *cachedSkill
+	skillEnv, err := calcWithMainSkill(env.ctx, env.Build, env.Cache, index, true)
+	if err != nil {
+		env.DebugErrors = append(env.DebugErrors, fmt.Sprintf("failed to calculate trigger source %s: %s", skill.ActiveEffect.GrantedEffect.Raw.ID, err))
+	} else {
+		cached = &cachedSkill{
+			ActiveSkill: skillEnv.Player.MainSkill,
+			Output:      skillEnv.Player.Output,
+		}
+	}
+
+	if env.skillCache == nil {
+		env.skillCache = make(map[int]*cachedSkill)
+	}
+	env.skillCache[index] = cached
+	return cached
+}
+
+// triggerSpell is a skill sharing a trigger source with the main skill
+type triggerSpell struct {
+	skill    *ActiveSkill
+	cooldown float64
+	nextTrig float64
+	count    float64
+}
+
+const (
+	triggerSimTime  = 100.0
+	triggerTimeStep = 0.0001
+)
+
+// skillDisplayName returns the name of the skill as shown in game
+func skillDisplayName(skill *ActiveSkill) string {
+	if activeSkill := skill.ActiveEffect.GrantedEffect.Raw.GetActiveSkill(); activeSkill != nil {
+		return activeSkill.DisplayedName
+	}
+	return skill.ActiveEffect.GrantedEffect.Raw.ID
+}
+
+// sameTriggerGroup reports whether both skills are socketed in the same socket group
+func sameTriggerGroup(a *ActiveSkill, b *ActiveSkill) bool {
+	return a.SocketGroup != nil && a.SocketGroup == b.SocketGroup
+}
+
+// sameTriggerSlot reports whether both skills are socketed in the same item
+func sameTriggerSlot(a *ActiveSkill, b *ActiveSkill) bool {
+	return sameTriggerGroup(a, b) || (a.SlotName != "" && a.SlotName == b.SlotName)
+}
+
+// skillHasWeaponFlags reports whether the skill is used with a weapon matching any of the flags
+func skillHasWeaponFlags(skill *ActiveSkill, flags mod.MFlag) bool {
+	return skill.SkillCfg != nil && skill.SkillCfg.Flags != nil && *skill.SkillCfg.Flags&flags > 0
+}
+
+// addTriggerIncMoreMods converts TriggeredDamage modifiers of the skill into Damage modifiers
+func addTriggerIncMoreMods(activeSkill *ActiveSkill, sourceSkill *ActiveSkill) {
+	var cfg *moddb.ListCfg
+	if sourceSkill != nil {
+		cfg = sourceSkill.SkillCfg
+	}
+
+	for _, modType := range []mod.Type{mod.TypeIncrease, mod.TypeMore} {
+		for _, value := range activeSkill.SkillModList.Tabulate(modType, cfg, "TriggeredDamage") {
+			amount, ok := value.Value.(float64)
+			if !ok {
+				continue
+			}
+			activeSkill.SkillModList.AddMod(mod.NewFloat("Damage", modType, amount).
+				Source(value.Mod.GetSource()).
+				Flag(value.Mod.Flags()).
+				KeywordFlag(value.Mod.KeywordFlags()).
+				Tag(value.Mod.Tags()...))
+		}
+	}
+}
+
+// getTriggerDefaultCooldown returns the cooldown of the support gem that triggers the skill
+func getTriggerDefaultCooldown(supportList []*GemEffect, id string) float64 {
+	for _, support := range supportList {
+		if support.GrantedEffect.Raw.ID != id {
+			continue
+		}
+		level := raw2.GetCalculatedGrantedEffect(support.GrantedEffect.Raw).GetCalculatedLevels()[support.Level]
+		if level != nil && level.Cooldown != nil {
+			return *level.Cooldown
+		}
+	}
+	return 0
+}
+
+// skillManaCost returns the mana cost of a cached skill, falling back to the base cost of the gem while costs are not calculated
+func skillManaCost(cached *cachedSkill) float64 {
+	if cost, ok := cached.Output["ManaCost"]; ok {
+		return cost
+	}
+	if level := cached.ActiveSkill.ActiveEffect.GrantedEffectLevel; level != nil {
+		return float64(level.Cost["Mana"])
+	}
+	return 0
+}
+
+// findTriggerSkill returns whichever of the current source and the skill is used more often
+func findTriggerSkill(env *Environment, skill *ActiveSkill, source *cachedSkill, reqManaCost float64) *cachedSkill {
+	cached := calcCachedSkill(env, skill)
+	if cached == nil {
+		return source
+	}
+
+	speed, ok := cached.Output["Speed"]
+	if !ok || speed <= 0 {
+		return source
+	}
+
+	if reqManaCost > 0 && skillManaCost(cached) < reqManaCost {
+		return source
+	}
+
+	if source == nil || speed > source.Output["Speed"] {
+		return cached
+	}
+	return source
+}
+
+// calcDualWieldImpact halves the rate of the source when dual wielding, as only one weapon is used per attack
+func calcDualWieldImpact(env *Environment, sourceAPS float64, source *ActiveSkill) (float64, bool) {
+	weapon1 := env.Player.WeaponData1
+	weapon2 := env.Player.WeaponData2
+	if weapon1 == nil || weapon2 == nil || weapon1.Type == "" || weapon2.Type == "" {
+		return sourceAPS, false
+	}
+
+	if !skillDataFlag(source, "doubleHitsWhenDualWielding") {
+		sourceAPS /= 2
+	}
+	return sourceAPS, true
+}
+
+// getTriggerActionTriggerRate returns the highest rate a cooldown allows, as cooldowns are rounded up to the next server tick
+func getTriggerActionTriggerRate(breakdown *Breakdown, stat string, label string, cooldown float64, icdr float64) float64 {
+	if cooldown <= 0 {
+		// Nothing can be triggered more than once per server tick
+		rate := float64(data.ServerTickRate)
+		if breakdown != nil {
+			breakdown.Set(stat, rate, fmt.Sprintf("%.2f (server tick rate)", rate))
+		}
+		return rate
+	}
+
+	modActionCooldown := cooldown / icdr
+	ticks := math.Ceil(modActionCooldown * data.ServerTickRate)
+	rateCapAdjusted := ticks / data.ServerTickRate
+	rate := 1 / rateCapAdjusted
+
+	if breakdown != nil {
+		s := breakdown.Set(stat, rate)
+		s.line("%.2f (base cooldown of %s)", cooldown, label)
+		s.line("/ %.2f (increased/reduced cooldown recovery)", icdr)
+		s.line("= %.4f (final cooldown of trigger)", modActionCooldown)
+		s.line("%.3f (adjusted for server tick rate)", rateCapAdjusted)
+		if ticks > 1 {
+			// The next breakpoint is reached once the cooldown fits in one less server tick
+			extraICDRNeeded := math.Ceil((cooldown/((ticks-1)/data.ServerTickRate) - icdr) * 100)
+			s.line("(extra ICDR of %.0f%% would reach next breakpoint)", extraICDRNeeded)
+		}
+		s.line("1 / %.3f", rateCapAdjusted)
+		s.line("= %.2f per second", rate)
+	}
+
+	return rate
+}
+
+// calcMultiSpellRotationImpact simulates the source triggering the skills in rotation, skipping skills on cooldown, and returns the rate of the main skill
+func calcMultiSpellRotationImpact(spells []*triggerSpell, sourceAPS float64, mainSkill *ActiveSkill) float64 {
+	if sourceAPS <= 0 {
+		return 0
+	}
+
+	tickTime := 1 / float64(data.ServerTickRate)
+	triggerIncrement := 1 / sourceAPS
+	index := 0
+	time := float64(0)
+	tick := float64(0)
+	currTick := float64(0)
+	nextTrigger := float64(0)
+
+	for time < triggerSimTime {
+		currIndex := index
+
+		if time >= nextTrigger {
+			for spells[index].nextTrig > time {
+				index = (index + 1) % len(spells)
+				if index == currIndex {
+					// Every skill is on cooldown and the trigger is wasted, attacks are not bound to the server tick
+					nextTrigger = time + triggerIncrement
+					break
+				}
+			}
+
+			if spells[index].nextTrig <= time {
+				spells[index].count++
+				// The cooldown starts at the beginning of the current tick and ends at the start of a tick
+				tempTick := tick
+				for currTick+spells[index].cooldown > tempTick {
+					tempTick += tickTime
+				}
+				spells[index].nextTrig = tempTick
+				index = (index + 1) % len(spells)
+				nextTrigger = time + triggerIncrement
+			}
+		}
+
+		time += triggerTimeStep
+		if tick < time {
+			currTick = tick
+			tick += tickTime
+		}
+	}
+
+	for _, spell := range spells {
+		if spell.skill == mainSkill {
+			return spell.count / triggerSimTime
+		}
+	}
+	return 0
+}
+
+// calcActualTriggerRate calculates the rate the skill is triggered at by the source, limited by the cooldown of the skill
+func calcActualTriggerRate(actor *Actor, skill *ActiveSkill, source *cachedSkill, sourceAPS float64, spells []*triggerSpell, dualWield bool) float64 {
+	output := actor.Output
+	breakdown := actor.Breakdown
+
+	icdr := CalcMod(skill.SkillModList, skill.SkillCfg, "CooldownRecovery")
+	cooldown, _ := skill.SkillData["Cooldown"].(float64)
+	output["ActionTriggerRate"] = getTriggerActionTriggerRate(breakdown, "ActionTriggerRate", "triggered skill", cooldown, icdr)
+
+	if len(spells) > 0 {
+		output["SourceTriggerRate"] = calcMultiSpellRotationImpact(spells, sourceAPS, skill)
+	} else {
+		output["SourceTriggerRate"] = sourceAPS
+	}
+	output["ServerTriggerRate"] = math.Min(output["SourceTriggerRate"], output["ActionTriggerRate"])
+
+	if breakdown != nil {
+		s := breakdown.Set("SourceTriggerRate", output["SourceTriggerRate"])
+		s.line("%.2f (%s uses per second)", sourceAPS, skillDisplayName(source.ActiveSkill))
+		if dualWield {
+			s.line("(dual wielding, each weapon is used every other attack)")
+		}
+		if len(spells) > 1 {
+			s.line("Triggers are shared between %d skills:", len(spells))
+			for _, spell := range spells {
+				s.line("%.2f (%s)", spell.count/triggerSimTime, skillDisplayName(spell.skill))
+			}
+		}
+		breakdown.Set("ServerTriggerRate", output["ServerTriggerRate"], fmt.Sprintf("%.2f (smaller of 'cap' and 'skill' trigger rates)", output["ServerTriggerRate"]))
+	}
+
+	return output["ServerTriggerRate"]
+}
+
+// calcCooldownTriggerRate calculates the rate of a trigger that activates whenever its own cooldown allows
+func calcCooldownTriggerRate(actor *Actor, skill *ActiveSkill, label string, triggerCooldown float64, icdr float64) float64 {
+	output := actor.Output
+	breakdown := actor.Breakdown
+
+	cooldown, _ := skill.SkillData["Cooldown"].(float64)
+	output["ActionTriggerRate"] = getTriggerActionTriggerRate(breakdown, "ActionTriggerRate", "triggered skill", cooldown, icdr)
+	output["SourceTriggerRate"] = getTriggerActionTriggerRate(breakdown, "SourceTriggerRate", label, triggerCooldown, icdr)
+	output["ServerTriggerRate"] = math.Min(output["SourceTriggerRate"], output["ActionTriggerRate"])
+
+	if breakdown != nil {
+		breakdown.Set("ServerTriggerRate", output["ServerTriggerRate"], fmt.Sprintf("%.2f (smaller of 'cap' and 'skill' trigger rates)", output["ServerTriggerRate"]))
+	}
+
+	return output["ServerTriggerRate"]
+}
+
+// setTriggerRate marks the skill as triggered at the given rate
+func setTriggerRate(skill *ActiveSkill, rate float64) {
+	addTriggerIncMoreMods(skill, skill)
+	skill.SkillData["Triggered"] = true
+	skill.SkillData["TriggerRate"] = rate
+}
+
+// findSkillTrigger finds the most used source that can trigger the skill and calculates the rate the skill is triggered at.
+// Returns nil and removes the trigger flag from the skill if there is no source, so it is calculated as self-cast.
+func findSkillTrigger(env *Environment, mainSkill *ActiveSkill, flag string, isSource func(skill *ActiveSkill) bool, isLinked func(skill *ActiveSkill) bool, dualWield bool) (*cachedSkill, float64) {
+	icdr := CalcMod(mainSkill.SkillModList, mainSkill.SkillCfg, "CooldownRecovery")
+
+	var source *cachedSkill
+	spells := make([]*triggerSpell, 0)
+	for _, skill := range env.Player.ActiveSkillList {
+		if skill != mainSkill && !skillDataFlag(skill, flag) && isSource(skill) {
+			source = findTriggerSkill(env, skill, source, 0)
+		}
+		if skillDataFlag(skill, flag) && isLinked(skill) {
+			spell := &triggerSpell{skill: skill}
+			if override, ok := skill.SkillModList.Override(mainSkill.SkillCfg, "CooldownRecovery").(float64); ok {
+				spell.cooldown = override
+			} else {
+				cooldown, _ := skill.SkillData["Cooldown"].(float64)
+				spell.cooldown = cooldown / icdr
+			}
+			spells = append(spells, spell)
+		}
+	}
+
+	if source == nil || len(spells) == 0 {
+		delete(mainSkill.SkillData, flag)
+		return nil, 0
+	}
+
+	sourceAPS := source.Output["Speed"]
+	dualWielded := false
+	if dualWield {
+		sourceAPS, dualWielded = calcDualWieldImpact(env, sourceAPS, source.ActiveSkill)
+	}
+
+	return source, calcActualTriggerRate(env.Player, mainSkill, source, sourceAPS, spells, dualWielded)
+}
+
+// processTriggeredSkill finds the source of the main skill's trigger and sets the rate it is triggered at
+func processTriggeredSkill(env *Environment) {
+	mainSkill := env.Player.MainSkill
+	if mainSkill != nil && !mainSkill.SkillFlags[SkillFlagMinion] {
+		processPlayerTrigger(env, mainSkill)
+	}
+
+	if env.Minion != nil && env.Minion.MainSkill != nil && skillDataFlag(env.Minion.MainSkill, "triggeredByParentAttack") {
+		processParentAttackTrigger(env, env.Minion.MainSkill)
+	}
+}
+
+// processPlayerTrigger handles the player's main skill being triggered by another skill, item or event
+func processPlayerTrigger(env *Environment, mainSkill *ActiveSkill) {
+	output := env.Player.Output
+	breakdown := env.Player.Breakdown
+	linkedToMain := func(skill *ActiveSkill) bool {
+		return sameTriggerGroup(skill, mainSkill)
+	}
+	inMainSlot := func(skill *ActiveSkill) bool {
+		return sameTriggerSlot(skill, mainSkill)
+	}
+
+	switch {
+	case skillDataFlag(mainSkill, "triggeredByCospris"):
+		source, rate := findSkillTrigger(env, mainSkill, "triggeredByCospris", func(skill *ActiveSkill) bool {
+			return skill.SkillTypes[data.SkillTypeMelee] && skillHasWeaponFlags(skill, mod.MFlagSword|mod.MFlagWeapon1H)
+		}, inMainSlot, true)
+		if source == nil {
+			return
+		}
+
+		// Account for chance to crit
+		critChance := source.Output["CritChance"]
+		if breakdown != nil {
+			breakdown.Set("Speed", rate*critChance/100,
+				fmt.Sprintf("%.2f (adjusted trigger rate)", rate),
+				fmt.Sprintf("x %.2f%% (%s effective crit chance)", critChance, skillDisplayName(source.ActiveSkill)),
+				fmt.Sprintf("= %.2f per second", rate*critChance/100),
+			)
+		}
+		setTriggerRate(mainSkill, rate*critChance/100)
+
+	case skillDataFlag(mainSkill, "triggeredByMjolner"):
+		source, rate := findSkillTrigger(env, mainSkill, "triggeredByMjolner", func(skill *ActiveSkill) bool {
+			return (skill.SkillTypes[data.SkillTypeDamage] || skill.SkillTypes[data.SkillTypeAttack]) && skillHasWeaponFlags(skill, mod.MFlagMace|mod.MFlagWeapon1H)
+		}, inMainSlot, true)
+		if source == nil {
+			return
+		}
+
+		// Account for chance to hit
+		hitChance := source.Output["HitChance"]
+		if breakdown != nil {
+			breakdown.Set("Speed", rate*hitChance/100,
+				fmt.Sprintf("%.2f (adjusted trigger rate)", rate),
+				fmt.Sprintf("x %.0f%% (%s hit chance)", hitChance, skillDisplayName(source.ActiveSkill)),
+				fmt.Sprintf("= %.2f per second", rate*hitChance/100),
+			)
+		}
+		setTriggerRate(mainSkill, rate*hitChance/100)
+
+	case skillDataFlag(mainSkill, "triggeredByManaSpent"):
+		// Kitava's Thirst triggers on skills that cost enough mana
+		reqManaCost := env.ModDB.Sum(mod.TypeBase, nil, "KitavaRequiredManaCost")
+		var source *cachedSkill
+		for _, skill := range env.Player.ActiveSkillList {
+			if skill != mainSkill && !skill.SkillTypes[data.SkillTypeTriggered] && !skillDataFlag(skill, "triggeredByManaSpent") {
+				source = findTriggerSkill(env, skill, source, reqManaCost)
+			}
+		}
+		if source == nil {
+			delete(mainSkill.SkillData, "triggeredByManaSpent")
+			return
+		}
+
+		icdr := CalcMod(mainSkill.SkillModList, mainSkill.SkillCfg, "CooldownRecovery")
+		kitavaCooldown := getTriggerDefaultCooldown(mainSkill.SupportList, "SupportCastOnManaSpent")
+		rate := calcCooldownTriggerRate(env.Player, mainSkill, "kitava's trigger", kitavaCooldown, icdr)
+
+		// Account for chance to trigger
+		triggerChance := env.ModDB.Sum(mod.TypeBase, nil, "KitavaTriggerChance")
+		if breakdown != nil {
+			breakdown.Set("Speed", rate*triggerChance/100,
+				fmt.Sprintf("%.2f (adjusted trigger rate)", rate),
+				fmt.Sprintf("x %.2f%% (kitava's trigger chance)", triggerChance),
+				fmt.Sprintf("= %.2f per second", rate*triggerChance/100),
+			)
+		}
+		setTriggerRate(mainSkill, rate*triggerChance/100)
+
+	case skillDataFlag(mainSkill, "triggeredByCraft"):
+		// The crafted trigger only needs any other skill to be used
+		found := false
+		for _, skill := range env.Player.ActiveSkillList {
+			if skill != mainSkill && !skillDataFlag(skill, "triggeredByCraft") && (skill.SkillTypes[data.SkillTypeDamage] || skill.SkillTypes[data.SkillTypeAttack] || skill.SkillTypes[data.SkillTypeSpell]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			delete(mainSkill.SkillData, "triggeredByCraft")
+			return
+		}
+
+		icdr := CalcMod(mainSkill.SkillModList, mainSkill.SkillCfg, "CooldownRecovery")
+		craftedCooldown := getTriggerDefaultCooldown(mainSkill.SupportList, "SupportTriggerSpellOnSkillUse")
+		setTriggerRate(mainSkill, calcCooldownTriggerRate(env.Player, mainSkill, "crafted trigger", craftedCooldown, icdr))
+
+	case skillDataFlag(mainSkill, "triggeredByFocus"):
+		if !env.ModDB.Flag(nil, "Condition:Focused") {
+			delete(mainSkill.SkillData, "triggeredByFocus")
+			return
+		}
+
+		focusCooldown := float64(0)
+		if focus := poe.GrantedEffectByID("Focus"); focus != nil {
+			if level := raw2.GetCalculatedGrantedEffect(focus).GetCalculatedLevels()[1]; level != nil && level.Cooldown != nil {
+				focusCooldown = *level.Cooldown
+			}
+		}
+
+		icdr := CalcMod(mainSkill.SkillModList, mainSkill.SkillCfg, "FocusCooldownRecovery")
+		setTriggerRate(mainSkill, calcCooldownTriggerRate(env.Player, mainSkill, "focus trigger", focusCooldown, icdr))
+
+	case skillDataFlag(mainSkill, "triggeredByUnique"):
+		processUniqueTrigger(env, mainSkill)
+
+	case skillDataFlag(mainSkill, "triggeredByCoC"):
+		source, rate := findSkillTrigger(env, mainSkill, "triggeredByCoC", func(skill *ActiveSkill) bool {
+			return skill.SkillTypes[data.SkillTypeAttack] && linkedToMain(skill)
+		}, linkedToMain, false)
+		if source == nil {
+			return
+		}
+
+		// Account for chance to crit and chance to trigger on crit
+		critChance := source.Output["CritChance"]
+		triggerChance := float64(100)
+		if chance, ok := source.ActiveSkill.SkillData["chanceToTriggerOnCrit"].(float64); ok {
+			triggerChance = chance
+		}
+		triggerRate := rate * critChance / 100 * triggerChance / 100
+		if breakdown != nil {
+			breakdown.Set("Speed", triggerRate,
+				fmt.Sprintf("%.2f (adjusted trigger rate)", rate),
+				fmt.Sprintf("x %.2f%% (%s crit chance)", critChance, skillDisplayName(source.ActiveSkill)),
+				fmt.Sprintf("x %.2f%% (chance to trigger on crit)", triggerChance),
+				fmt.Sprintf("= %.2f per second", triggerRate),
+			)
+		}
+		setTriggerRate(mainSkill, triggerRate)
+
+	case skillDataFlag(mainSkill, "triggeredByMeleeKill"):
+		if !env.ModDB.Flag(nil, "Condition:KilledRecently") {
+			return
+		}
+
+		source, rate := findSkillTrigger(env, mainSkill, "triggeredByMeleeKill", func(skill *ActiveSkill) bool {
+			return skill.SkillTypes[data.SkillTypeAttack] && skill.SkillTypes[data.SkillTypeMelee] && linkedToMain(skill)
+		}, linkedToMain, false)
+		if source == nil {
+			return
+		}
+
+		// Account for chance to trigger on melee kill
+		triggerChance := float64(100)
+		if chance, ok := source.ActiveSkill.SkillData["chanceToTriggerOnMeleeKill"].(float64); ok {
+			triggerChance = chance
+		}
+		if breakdown != nil {
+			breakdown.Set("Speed", rate*triggerChance/100,
+				fmt.Sprintf("%.2f (adjusted trigger rate)", rate),
+				fmt.Sprintf("x %.2f%% (chance to trigger on melee kill)", triggerChance),
+				fmt.Sprintf("= %.2f per second", rate*triggerChance/100),
+			)
+		}
+		setTriggerRate(mainSkill, rate*triggerChance/100)
+
+	case skillDataFlag(mainSkill, "triggeredWhileChannelling"):
+		// Cast while Channelling triggers every linked spell at a fixed interval while the source is channelled
+		var source *cachedSkill
+		for _, skill := range env.Player.ActiveSkillList {
+			if skill != mainSkill && skill.SkillTypes[data.SkillTypeChannel] && linkedToMain(skill) {
+				source = findTriggerSkill(env, skill, source, 0)
+			}
+		}
+		triggerTime := float64(0)
+		if source != nil {
+			triggerTime, _ = source.ActiveSkill.SkillData["TriggerTime"].(float64)
+		}
+		if triggerTime <= 0 {
+			delete(mainSkill.SkillData, "triggeredWhileChannelling")
+			return
+		}
+
+		// The interval is used as the trigger time of the spell, which applies cooldown recovery to it
+		setTriggerRate(mainSkill, calcActualTriggerRate(env.Player, mainSkill, source, 1/triggerTime, nil, false))
+		mainSkill.SkillData["TriggerTime"] = triggerTime
+
+	case skillDataFlag(mainSkill, "triggeredByDamageTaken"):
+		// Assumes enough damage is taken to trigger the skill whenever its cooldown allows
+		icdr := CalcMod(mainSkill.SkillModList, mainSkill.SkillCfg, "CooldownRecovery")
+		cooldown, _ := mainSkill.SkillData["Cooldown"].(float64)
+		output["ActionTriggerRate"] = getTriggerActionTriggerRate(breakdown, "ActionTriggerRate", "triggered skill", cooldown, icdr)
+		output["SourceTriggerRate"] = output["ActionTriggerRate"]
+		output["ServerTriggerRate"] = output["ActionTriggerRate"]
+		setTriggerRate(mainSkill, output["ServerTriggerRate"])
+	}
+}
+
+// processUniqueTrigger handles skills triggered by the support granted by a unique item
+func processUniqueTrigger(env *Environment, mainSkill *ActiveSkill) {
+	breakdown := env.Player.Breakdown
+
+	var isSource func(skill *ActiveSkill) bool
+	var isLinked func(skill *ActiveSkill) bool
+	for _, support := range mainSkill.SupportList {
+		switch support.GrantedEffect.Raw.ID {
+		case "SupportTriggerSpellOnAttack":
+			// Poet's Pen
+			isSource = func(skill *ActiveSkill) bool {
+				return (skill.SkillTypes[data.SkillTypeDamage] || skill.SkillTypes[data.SkillTypeAttack]) && skillHasWeaponFlags(skill, mod.MFlagWand)
+			}
+			isLinked = func(skill *ActiveSkill) bool {
+				return sameTriggerSlot(skill, mainSkill) && skill.SkillTypes[data.SkillTypeSpell]
+			}
+		case "SupportTriggerBowSkillOnBowAttack":
+			// Maloney's Mechanism
+			isSource = func(skill *ActiveSkill) bool {
+				return skill.SkillTypes[data.SkillTypeAttack] && skillHasWeaponFlags(skill, mod.MFlagBow)
+			}
+			isLinked = func(skill *ActiveSkill) bool {
+				return sameTriggerSlot(skill, mainSkill) && skill.SkillTypes[data.SkillTypeRangedAttack]
+			}
+		case "SupportTriggerSpellOnBowAttack":
+			// Asenath's Chant
+			isSource = func(skill *ActiveSkill) bool {
+				return (skill.SkillTypes[data.SkillTypeDamage] || skill.SkillTypes[data.SkillTypeAttack]) && skillHasWeaponFlags(skill, mod.MFlagBow)
+			}
+			isLinked = func(skill *ActiveSkill) bool {
+				return sameTriggerSlot(skill, mainSkill) && skill.SkillTypes[data.SkillTypeSpell]
+			}
+		}
+	}
+	if isSource == nil {
+		env.DebugErrors = append(env.DebugErrors, fmt.Sprintf("unhandled unique trigger for %s", mainSkill.ActiveEffect.GrantedEffect.Raw.ID))
+		return
+	}
+
+	source, rate := findSkillTrigger(env, mainSkill, "triggeredByUnique", isSource, isLinked, true)
+	if source == nil {
+		return
+	}
+
+	// Unleash repeats the source, every repeat can trigger the skill
+	if source.ActiveSkill.SkillModList.Flag(nil, "HasSeals") && source.ActiveSkill.SkillTypes[data.SkillTypeCanRapidFire] {
+		dpsMultiplier := float64(1)
+		if multiplier, ok := source.ActiveSkill.SkillData["DpsMultiplier"].(float64); ok {
+			dpsMultiplier = multiplier
+		}
+		if breakdown != nil {
+			breakdown.Set("Speed", rate*dpsMultiplier,
+				fmt.Sprintf("%.2f (trigger rate)", rate),
+				fmt.Sprintf("x %.2f (multiplier from Unleash)", dpsMultiplier),
+				fmt.Sprintf("= %.2f", rate*dpsMultiplier),
+			)
+		}
+		rate *= dpsMultiplier
+	}
+
+	setTriggerRate(mainSkill, rate)
+}
+
+// processParentAttackTrigger handles minion skills triggered by the attacks of the player
+func processParentAttackTrigger(env *Environment, minionSkill *ActiveSkill) {
+	var source *cachedSkill
+	for _, skill := range env.Player.ActiveSkillList {
+		if skill != env.Player.MainSkill && skill.SkillTypes[data.SkillTypeAttack] {
+			source = findTriggerSkill(env, skill, source, 0)
+		}
+	}
+	if source == nil {
+		delete(minionSkill.SkillData, "triggeredByParentAttack")
+		return
+	}
+
+	icdr := CalcMod(minionSkill.SkillModList, minionSkill.SkillCfg, "CooldownRecovery")
+	cooldown, _ := minionSkill.SkillData["Cooldown"].(float64)
+	spells := []*triggerSpell{{skill: minionSkill, cooldown: cooldown / icdr}}
+	rate := calcActualTriggerRate(env.Minion, minionSkill, source, source.Output["Speed"], spells, false)
+
+	// Account for chance to hit
+	hitChance := source.Output["HitChance"]
+	if env.Minion.Breakdown != nil {
+		env.Minion.Breakdown.Set("Speed", rate*hitChance/100,
+			fmt.Sprintf("%.2f (adjusted trigger rate)", rate),
+			fmt.Sprintf("x %.2f%% (%s hit chance)", hitChance, skillDisplayName(source.ActiveSkill)),
+			fmt.Sprintf("= %.2f per second", rate*hitChance/100),
+		)
+	}
+	setTriggerRate(minionSkill, rate*hitChance/100)
+}
@@ -1,6 +1,10 @@
 package calculator
 
 import (
+	"context"
+	"maps"
+	"sync"
+
 	"github.com/Vilsol/go-pob-data/poe"
 	"github.com/Vilsol/go-pob/data"
 	"github.com/Vilsol/go-pob/data/raw"
@@ -44,12 +48,15 @@ type Environment struct {
 	ModDB      *moddb.ModDB
 	EnemyModDB *moddb.ModDB
 	ItemModDB  *moddb.ModDB
-	Minion     *moddb.ModDB
 
 	EnemyLevel int
 
+	ConfigInput       map[string]interface{} // Values saved in the build
+	ConfigPlaceholder map[string]interface{} // Values used when the build does not set an option, such as boss preset defaults
+
 	Player *Actor
 	Enemy  *Actor
+	Minion *Actor // Minion of the player's main skill, if any
 
 	RequirementsTableItems map[string]interface{}   // TODO Implement
 	RequirementsTableGems  []*RequirementsTableGems // TODO Implement
@@ -74,26 +81,122 @@ type Environment struct {
 	MainSocketGroup int
 
 	DebugErrors []string
+
+	FullDPS *FullDPS // Combined damage of the skills included in Full DPS, only calculated in MAIN mode
+
+	ctx               context.Context      // Context of the calculation, used when other skills are calculated as the main skill
+	skillCache        map[int]*cachedSkill // Other skills calculated as the main skill, keyed by their index in the active skill list
+	limitedProcessing bool                 // Set while calculating a cached skill, skips processing that depends on other skills
+}
+
+// FullDPS is the combined damage of every skill included in Full DPS
+type FullDPS struct {
+	CombinedDPS float64
+	TotalDotDPS float64
+	Skills      []FullDPSSkill
+
+	BleedDPS         float64
+	IgniteDPS        float64
+	BurningGroundDPS float64
+	TotalPoisonDPS   float64
+	CausticGroundDPS float64
+	ImpaleDPS        float64
+	DecayDPS         float64
+	DotDPS           float64
+	CullingMulti     float64
+	CullingDPS       float64
+}
+
+// FullDPSSkill is the contribution of a single skill or damage source to Full DPS
+type FullDPSSkill struct {
+	Name   string
+	DPS    float64
+	Count  float64
+	Source string // Skill that applied the ailment, only set for the best ailment entries
 }
 
 type EnvironmentCache struct {
-	TreeVersion  data.TreeVersion
-	modsForNodes map[string]moddb.ModList // Mods for all nodes cached after being parsed
+	modsForNodes     map[data.TreeVersion]map[string]moddb.ModList // Mods for all nodes cached after being parsed, per tree version
+	modsForItemLines map[string]parsedModLine                      // Mods for item mod lines cached after being parsed, guarded by itemLinesMu
+	itemLinesMu      sync.Mutex
+}
+
+// maxCachedItemLines bounds the item mod line cache, it is cleared once full
+const maxCachedItemLines = 20000
+
+type parsedModLine struct {
+	mods  []mod.Mod
+	extra string
+}
+
+// itemLineMods parses an item mod line, reusing the result for lines parsed before.
+// The mods are shared between calls, so they have to be cloned before being changed.
+func (c *EnvironmentCache) itemLineMods(line string) ([]mod.Mod, string) {
+	c.itemLinesMu.Lock()
+	parsed, ok := c.modsForItemLines[line]
+	c.itemLinesMu.Unlock()
+	if ok {
+		return parsed.mods, parsed.extra
+	}
+
+	mods, extra := parseMod(line, 1)
+	if mods != nil && extra != "" {
+		mods, extra = parseMod(line, 2)
+	}
+
+	c.itemLinesMu.Lock()
+	defer c.itemLinesMu.Unlock()
+	if c.modsForItemLines == nil || len(c.modsForItemLines) >= maxCachedItemLines {
+		c.modsForItemLines = make(map[string]parsedModLine)
+	}
+	c.modsForItemLines[line] = parsedModLine{mods: mods, extra: extra}
+	return mods, extra
+}
+
+func (c *EnvironmentCache) nodeMods(treeVersion data.TreeVersion, size int) map[string]moddb.ModList {
+	if c.modsForNodes == nil {
+		c.modsForNodes = make(map[data.TreeVersion]map[string]moddb.ModList)
+	}
+
+	nodeMods, ok := c.modsForNodes[treeVersion]
+	if !ok {
+		nodeMods = make(map[string]moddb.ModList, size)
+		c.modsForNodes[treeVersion] = nodeMods
+	}
+	return nodeMods
 }
 
 type Actor struct {
 	ModDB           *moddb.ModDB
 	Level           int
-	Enemy           *Actor                 `json:"-"`
-	ItemList        map[string]interface{} // TODO Implement
+	Enemy           *Actor `json:"-"`
+	ItemList        map[string]*pob.Item
 	ActiveSkillList []*ActiveSkill
 	Output          map[string]float64
 	OutputTable     map[OutTable]map[string]float64
-	MainSkill       *ActiveSkill           // TODO Implement
-	Breakdown       interface{}            // TODO Implement
-	WeaponData1     map[string]interface{} // TODO Implement. Might be SomeSource?
-	WeaponData2     map[string]interface{} // TODO Implement. Might be SomeSource?
+	MainSkill       *ActiveSkill // TODO Implement
+	Breakdown       *Breakdown   // Only populated in OutputModeCalcs
+	WeaponData1     *WeaponData
+	WeaponData2     *WeaponData
+	ArmourData      map[string]*ArmourData // Keyed by slot name
 	StrDmgBonus     float64
+
+	// Percentage of each incoming damage type that is taken as each damage type
+	DamageShiftTable map[data.DamageType]map[data.DamageType]float64
+
+	// Skill life and mana reservations, percentages are of the maximum pool
+	ReservedLifeBase    float64
+	ReservedLifePercent float64
+	ReservedManaBase    float64
+	ReservedManaPercent float64
+
+	// Only populated for minions
+	Parent     *Actor `json:"-"`
+	MinionType string
+	MinionData *data.MinionData
+	LifeTable  []float64
+	Uses       map[string]bool // Item slots the minion takes items from
+	ItemSet    *pob.ItemSet
 }
 
 func (a *Actor) GetOutput(stat string) (float64, bool) {
@@ -101,13 +204,86 @@ func (a *Actor) GetOutput(stat string) (float64, bool) {
 	return v, ok
 }
 
-// TODO Fix Name
-type SomeSource struct {
-	Type        string
-	CritChance  float64
-	PhysicalMin *float64
-	PhysicalMax *float64
-	AttackRate  *float64
+// WeaponData holds the base stats of an equipped weapon after local modifiers have been applied
+type WeaponData struct {
+	Type                 data.ItemClassName
+	Name                 string
+	AttackRate           float64
+	AttackSpeedInc       float64
+	CritChance           float64
+	Range                float64
+	RangeBonus           float64
+	Damage               map[data.DamageType]DamageRange
+	CountsAsDualWielding bool
+	CountsAsAll1H        bool
+}
+
+type DamageRange struct {
+	Min float64
+	Max float64
+}
+
+// ArmourData holds the defences of an equipped armour or shield after local modifiers have been applied
+type ArmourData struct {
+	Armour       float64
+	Evasion      float64
+	EnergyShield float64
+	Ward         float64
+	BlockChance  float64
+}
+
+// NewUnarmedWeaponData returns the weapon data used when the given class has no main hand weapon
+func NewUnarmedWeaponData(className data.ClassName) *WeaponData {
+	unarmed := data.UnarmedWeaponData[data.ClassIDs[className]]
+	return &WeaponData{
+		Type:       data.ItemClassName(unarmed["type"].(string)),
+		AttackRate: unarmed["AttackRate"].(float64),
+		CritChance: unarmed["CritChance"].(float64),
+		Damage: map[data.DamageType]DamageRange{
+			data.DamageTypePhysical: {
+				Min: unarmed["PhysicalMin"].(float64),
+				Max: unarmed["PhysicalMax"].(float64),
+			},
+		},
+	}
+}
+
+// Clone returns a copy of the weapon data that does not share its damage table
+func (w *WeaponData) Clone() *WeaponData {
+	if w == nil {
+		return nil
+	}
+	out := *w
+	out.Damage = maps.Clone(w.Damage)
+	return &out
+}
+
+// Info returns the weapon type information, or nil if the weapon data does not describe a weapon
+func (w *WeaponData) Info() *data.WeaponTypeInfo {
+	if w == nil {
+		return nil
+	}
+	return data.WeaponTypes[w.Type]
+}
+
+// Source converts the weapon data into the stat table used by damage passes
+func (w *WeaponData) Source() map[string]interface{} {
+	source := make(map[string]interface{})
+	if w == nil {
+		return source
+	}
+
+	source["type"] = string(w.Type)
+	source["AttackRate"] = w.AttackRate
+	source["AttackSpeedInc"] = w.AttackSpeedInc
+	source["CritChance"] = w.CritChance
+	source["Range"] = w.Range
+	for damageType, damage := range w.Damage {
+		source[string(damageType)+"Min"] = damage.Min
+		source[string(damageType)+"Max"] = damage.Max
+	}
+
+	return source
 }
 
 type Out string
@@ -139,7 +315,8 @@ type ActiveSkill struct {
 	SocketGroup      interface{}
 	SummonSkill      *ActiveSkill
 	ConversionTable  map[data.DamageType]ConversionTable
-	Minion           interface{}
+	Minion           *Actor
+	MinionList       []string
 	Weapon1Flags     mod.MFlag
 	Weapon2Flags     mod.MFlag
 	EffectList       []*GemEffect
@@ -149,6 +326,24 @@ type ActiveSkill struct {
 	MinionSkillTypes map[data.SkillType]bool
 	BleedCfg         *moddb.ListCfg
 	OHBleedCfg       *moddb.ListCfg
+	PoisonCfg        *moddb.ListCfg
+	OHPoisonCfg      *moddb.ListCfg
+	IgniteCfg        *moddb.ListCfg
+	OHIgniteCfg      *moddb.ListCfg
+	BuffList         []*Buff
+}
+
+// Buff is a group of global effect modifiers separated from a skill, to be applied to the player, minions, allies or enemies
+type Buff struct {
+	Type              string
+	Name              string
+	ActiveSkillBuff   bool
+	ApplyNotPlayer    bool
+	ApplyMinions      bool
+	ApplyAllies       bool
+	AllowTotemBuff    bool
+	ModList           *moddb.ModList
+	UnscalableModList *moddb.ModList
 }
 
 type ConversionTable struct {
@@ -188,6 +383,21 @@ const (
 	SkillFlagBleed            = SkillFlag("bleed")
 	SkillFlagDuration         = SkillFlag("duration")
 	SkillFlagIgniteCanStack   = SkillFlag("igniteCanStack")
+	SkillFlagIgniteToChaos    = SkillFlag("igniteToChaos")
+	SkillFlagPoison           = SkillFlag("poison")
+	SkillFlagIgnite           = SkillFlag("ignite")
+	SkillFlagChill            = SkillFlag("chill")
+	SkillFlagFreeze           = SkillFlag("freeze")
+	SkillFlagImpale           = SkillFlag("impale")
+	SkillFlagDot              = SkillFlag("dot")
+	SkillFlagDecay            = SkillFlag("decay")
+	SkillFlagLeechLife        = SkillFlag("leechLife")
+	SkillFlagLeechES          = SkillFlag("leechES")
+	SkillFlagLeechMana        = SkillFlag("leechMana")
+	SkillFlagMinion           = SkillFlag("minion")
+	SkillFlagHaveMinion       = SkillFlag("haveMinion")
+	SkillFlagTriggered        = SkillFlag("triggered")
+	SkillFlagFocused          = SkillFlag("focused")
 )
 
 type SkillData struct {
@@ -219,7 +429,10 @@ type GrantedEffect struct {
 func (g *GrantedEffect) WeaponTypes() []data.ItemClassName {
 	out := make([]data.ItemClassName, len(g.Raw.WeaponRestrictions))
 	for i, restriction := range g.Raw.WeaponRestrictions {
-		out[i] = data.ItemClassName(poe.ItemClasses[restriction].Name)
+		out[i] = data.ItemClassName(poe.ItemClasses[restriction].ID)
+		if out[i] == data.Unarmed {
+			out[i] = data.None
+		}
 	}
 	return out
 }
@@ -241,7 +454,7 @@ type DamagePass struct {
 	Source    map[string]interface{}
 	Config    *moddb.ListCfg
 	Output    map[string]float64
-	Breakdown interface{} // TODO Implement Breakdown
+	Breakdown *Breakdown
 }
 
 type RequirementsTableGems struct {
@@ -262,6 +475,7 @@ type GemEffect struct {
 
 	// For Active Gems
 	GrantedEffectLevel *raw.CalculatedLevel
+	ActorLevel         int // Level of the minion using the skill, stats are interpolated with it when set
 
 	// For Support Gems
 	Superseded   bool
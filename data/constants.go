@@ -196,22 +196,23 @@ const (
 	AccuracyPerDexBase        = float64(2)
 	BrandAttachmentRangeBase  = 30
 	ProjectileDistanceCap     = 150
+	DotDpsCap                 = float64(1<<31-1) / 60
 
 	// Expected values to calculate EHP
-	stdBossDPSMult      = 4 / 4.25
-	pinnacleBossDPSMult = 8 / 4.25
-	pinnacleBossPen     = 25 / 5
-	uberBossDPSMult     = 10 / 4.25
-	uberBossPen         = 40 / 5
+	StdBossDPSMult      = 4 / 4.25
+	PinnacleBossDPSMult = 8 / 4.25
+	PinnacleBossPen     = 25 / 5
+	UberBossDPSMult     = 10 / 4.25
+	UberBossPen         = 40 / 5
 
 	// ehp helper function magic numbers
-	ehpCalcSpeedUp = 8
+	EHPCalcSpeedUp = 8
 
 	// depth needs to be a power of speedUp (in this case 8^3, will run 3 recursive calls deep)
-	ehpCalcMaxDepth = 512
+	EHPCalcMaxDepth = 512
 
 	// max hits is currently depth + speedup - 1 to give as much accuracy with as few cycles as possible, but can be increased for more accuracy
-	ehpCalcMaxHitsToCalc = 519
+	EHPCalcMaxHitsToCalc = 519
 )
 
 // All arrays start with a 0 element as from translation from Lua all array accesses start at 1
@@ -223,6 +224,51 @@ var MonsterAllyLifeTable = []float64{0, 15, 17, 20, 23, 26, 30, 33, 37, 41, 46,
 var MonsterDamageTable = []float64{0, 4.9899997711182, 5.5599999427795, 6.1599998474121, 6.8099999427795, 7.5, 8.2299995422363, 9, 9.8199996948242, 10.699999809265, 11.619999885559, 12.60000038147, 13.640000343323, 14.739999771118, 15.909999847412, 17.139999389648, 18.450000762939, 19.829999923706, 21.290000915527, 22.840000152588, 24.469999313354, 26.190000534058, 28.010000228882, 29.940000534058, 31.959999084473, 34.110000610352, 36.360000610352, 38.75, 41.259998321533, 43.909999847412, 46.700000762939, 49.650001525879, 52.75, 56.009998321533, 59.450000762939, 63.080001831055, 66.889999389648, 70.910003662109, 75.129997253418, 79.580001831055, 84.26000213623, 89.180000305176, 94.349998474121, 99.800003051758, 105.51999664307, 111.5299987793, 117.86000061035, 124.5, 131.49000549316, 138.83000183105, 146.5299987793, 154.63000488281, 163.13999938965, 172.07000732422, 181.44999694824, 191.30000305176, 201.63000488281, 212.47999572754, 223.86999511719, 235.83000183105, 248.36999511719, 261.5299987793, 275.32998657227, 289.82000732422, 305.01000976563, 320.94000244141, 337.64999389648, 355.17999267578, 373.54998779297, 392.80999755859, 413.01000976563, 434.17999267578, 456.36999511719, 479.61999511719, 504, 529.53997802734, 556.29998779297, 584.34997558594, 613.72998046875, 644.5, 676.75, 710.52001953125, 745.89001464844, 782.94000244141, 821.72998046875, 862.35998535156, 904.90002441406, 949.44000244141, 996.07000732422, 1044.8900146484, 1096, 1149.5, 1205.5, 1264.1099853516, 1325.4499511719, 1389.6400146484, 1456.8199462891, 1527.1199951172, 1600.6800537109, 1677.6400146484, 1758.1700439453}
 var MonsterArmourTable = []float64{0, 22, 26, 31, 36, 42, 48, 55, 62, 70, 78, 87, 97, 107, 119, 131, 144, 158, 173, 190, 207, 226, 246, 267, 290, 315, 341, 370, 400, 432, 467, 504, 543, 585, 630, 678, 730, 785, 843, 905, 972, 1042, 1118, 1198, 1284, 1375, 1472, 1575, 1685, 1802, 1927, 2059, 2200, 2350, 2509, 2678, 2858, 3050, 3253, 3469, 3698, 3942, 4201, 4476, 4768, 5078, 5407, 5756, 6127, 6520, 6937, 7380, 7850, 8348, 8876, 9436, 10030, 10660, 11328, 12036, 12787, 13582, 14425, 15319, 16265, 17268, 18331, 19457, 20649, 21913, 23250, 24667, 26168, 27756, 29438, 31220, 33105, 35101, 37214, 39450, 41817}
 
+// MapLevelLifeMult returns the hidden life multiplier of monsters in maps of the given level
+func MapLevelLifeMult(level int) float64 {
+	for _, row := range poe.MonsterMapDifficulties {
+		if row.MapLevel == level {
+			return 1 + mapDifficultyStat("map_hidden_monster_life_+%_final", []int{row.StatsKey1, row.StatsKey2}, []int{row.Stat1Value, row.Stat2Value})/100
+		}
+	}
+	return 1
+}
+
+// MapLevelBossLifeMult returns the hidden life multiplier of map bosses in maps of the given level
+func MapLevelBossLifeMult(level int) float64 {
+	for _, row := range poe.MonsterMapBossDifficulties {
+		if row.MapLevel == level {
+			return 1 + mapBossDifficultyStat(row, "map_hidden_monster_life_+%_final")/100
+		}
+	}
+	return 1
+}
+
+// MapLevelBossAilmentMult returns the ailment threshold multiplier of map bosses in maps of the given level
+func MapLevelBossAilmentMult(level int) float64 {
+	for _, row := range poe.MonsterMapBossDifficulties {
+		if row.MapLevel == level {
+			return 1 + mapBossDifficultyStat(row, "ailment_threshold_pluspercent_final_for_map_boss")/100
+		}
+	}
+	return 1
+}
+
+func mapBossDifficultyStat(row *poe.MonsterMapBossDifficulty, statID string) float64 {
+	keys := []int{row.StatsKey1, row.StatsKey2, row.StatsKey3, row.StatsKey4, row.StatsKey5}
+	values := []int{row.Stat1Value, row.Stat2Value, row.Stat3Value, row.Stat4Value, row.Stat5Value}
+	return mapDifficultyStat(statID, keys, values)
+}
+
+func mapDifficultyStat(statID string, keys []int, values []int) float64 {
+	for i, key := range keys {
+		if key >= 0 && key < len(poe.Stats) && poe.Stats[key].ID == statID {
+			return float64(values[i])
+		}
+	}
+	return 0
+}
+
 var UnarmedWeaponData = map[int]map[string]interface{}{
 	0: {"type": "None", "AttackRate": 1.2, "CritChance": float64(0), "PhysicalMin": float64(2), "PhysicalMax": float64(6)}, // Scion
 	1: {"type": "None", "AttackRate": 1.2, "CritChance": float64(0), "PhysicalMin": float64(2), "PhysicalMax": float64(8)}, // Marauder
@@ -379,13 +425,15 @@ var WeaponTypes = map[ItemClassName]*WeaponTypeInfo{
 	Bow:                   {OneHand: false, Melee: false, Flag: "Bow", ModFlag: mod.MFlagBow},
 	Claw:                  {OneHand: true, Melee: true, Flag: "Claw", ModFlag: mod.MFlagClaw},
 	Dagger:                {OneHand: true, Melee: true, Flag: "Dagger", ModFlag: mod.MFlagDagger},
+	RuneDagger:            {OneHand: true, Melee: true, Flag: "Dagger", Label: string(Dagger), ModFlag: mod.MFlagDagger},
 	Staff:                 {OneHand: false, Melee: true, Flag: "Staff", ModFlag: mod.MFlagStaff},
+	Warstaff:              {OneHand: false, Melee: true, Flag: "Staff", Label: string(Staff), ModFlag: mod.MFlagStaff},
 	Wand:                  {OneHand: true, Melee: false, Flag: "Wand", ModFlag: mod.MFlagWand},
 	OneHandAxe:            {OneHand: true, Melee: true, Flag: "Axe", ModFlag: mod.MFlagAxe},
 	OneHandMace:           {OneHand: true, Melee: true, Flag: "Mace", ModFlag: mod.MFlagMace},
 	OneHandSword:          {OneHand: true, Melee: true, Flag: "Sword", ModFlag: mod.MFlagSword},
-	Sceptre:               {OneHand: true, Melee: true, Flag: "Mace", Label: "One Handed Mace", ModFlag: mod.MFlagMace},
-	ThrustingOneHandSword: {OneHand: true, Melee: true, Flag: "Sword", Label: "One Handed Sword", ModFlag: mod.MFlagSword},
+	Sceptre:               {OneHand: true, Melee: true, Flag: "Mace", Label: string(OneHandMace), ModFlag: mod.MFlagMace},
+	ThrustingOneHandSword: {OneHand: true, Melee: true, Flag: "Sword", Label: string(OneHandSword), ModFlag: mod.MFlagSword},
 	FishingRod:            {OneHand: false, Melee: true, Flag: "Fishing", ModFlag: mod.MFlagFishing},
 	TwoHandAxe:            {OneHand: false, Melee: true, Flag: "Axe", ModFlag: mod.MFlagAxe},
 	TwoHandMace:           {OneHand: false, Melee: true, Flag: "Mace", ModFlag: mod.MFlagMace},
@@ -415,3 +463,37 @@ const (
 	Wand                  ItemClassName = "Wand"
 	Warstaff              ItemClassName = "Warstaff"
 )
+
+// CursePriority determines which curse takes a curse slot when the curse limit is reached, higher values win
+var CursePriority = map[string]float64{
+	"Temporal Chains":    1, // Despair and Elemental Weakness override Temporal Chains
+	"Enfeeble":           2, // Elemental Weakness and Vulnerability override Enfeeble
+	"Vulnerability":      3, // Despair and Elemental Weakness override Vulnerability
+	"Elemental Weakness": 4, // Despair and Flammability override Elemental Weakness
+	"Flammability":       5, // Frostbite overrides Flammability
+	"Frostbite":          6,
+	"Conductivity":       7,
+	"Despair":            8,
+	"Punishment":         9,
+	"Poacher's Mark":     10,
+	"Assassin's Mark":    11,
+	"Warlord's Mark":     12,
+	"Sniper's Mark":      13,
+
+	// Sort by socket
+	"SocketPriorityBase": 100,
+
+	// Sort by slot
+	"Weapon 1":    1000,
+	"Amulet":      2000,
+	"Helmet":      3000,
+	"Weapon 2":    4000,
+	"Body Armour": 5000,
+	"Gloves":      6000,
+	"Boots":       7000,
+	"Ring 1":      8000,
+	"Ring 2":      9000,
+
+	"CurseFromEquipment": 10000,
+	"CurseFromAura":      20000,
+}
@@ -0,0 +1,243 @@
+package data
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/Vilsol/go-pob/mod"
+)
+
+// MinionData holds the base stats of a minion, life, armour and damage are multipliers for the monster level tables
+type MinionData struct {
+	Variety         string // Monster variety the name, life, damage, attack and skills are read from
+	Name            string
+	Life            float64
+	EnergyShield    float64 // Fraction of life granted as energy shield
+	Armour          float64
+	FireResist      float64
+	ColdResist      float64
+	LightningResist float64
+	ChaosResist     float64
+	Damage          float64
+	DamageSpread    float64
+	DamageFixup     float64
+	AttackTime      float64
+	AttackRange     float64
+	Accuracy        float64
+	WeaponType      ItemClassName
+	Limit           string // Name of the mod that limits the number of active minions
+	SkillList       []string
+	ModList         []mod.Mod
+}
+
+// MinionSkill lists the minions a skill can summon
+type MinionSkill struct {
+	MinionList       []string
+	MinionUses       map[string]bool // Item slots of the player or item set that the minion uses
+	MinionHasItemSet bool
+}
+
+// MinionSkills is keyed by granted effect ID
+var MinionSkills = map[string]*MinionSkill{
+	"RaiseZombie":          {MinionList: []string{"RaisedZombie"}},
+	"SummonSkeletons":      {MinionList: []string{"RaisedSkeleton"}},
+	"SummonRagingSpirit":   {MinionList: []string{"SummonedRagingSpirit"}},
+	"SummonChaosGolem":     {MinionList: []string{"SummonedChaosGolem"}},
+	"SummonFireGolem":      {MinionList: []string{"SummonedFlameGolem"}},
+	"SummonIceGolem":       {MinionList: []string{"SummonedIceGolem"}},
+	"SummonLightningGolem": {MinionList: []string{"SummonedLightningGolem"}},
+	"SummonRockGolem":      {MinionList: []string{"SummonedStoneGolem"}},
+	"HeraldOfPurity":       {MinionList: []string{"AxisEliteSoldierHeraldOfLight"}},
+	"HeraldOfAgony":        {MinionList: []string{"HeraldOfAgonySpiderPlated"}},
+	"AnimateArmour": {
+		MinionList: []string{"AnimatedArmour"},
+		MinionUses: map[string]bool{
+			"Weapon 1":    true,
+			"Weapon 2":    true,
+			"Helmet":      true,
+			"Body Armour": true,
+			"Gloves":      true,
+			"Boots":       true,
+		},
+		MinionHasItemSet: true,
+	},
+}
+
+// minions holds the stats of minions that are not part of their monster variety, keyed by the minion ID saved in the skillMinion attribute of gems
+var minions = map[string]*MinionData{
+
+	"RaisedZombie": {
+		Variety:         "Metadata/Monsters/RaisedZombies/RaisedZombieStandard",
+		Armour:          0.7,
+		FireResist:      40,
+		ColdResist:      40,
+		LightningResist: 40,
+		ChaosResist:     20,
+		DamageSpread:    0.4,
+		Accuracy:        1,
+		Limit:           "ActiveZombieLimit",
+	},
+	"RaisedSkeleton": {
+		Variety:         "Metadata/Monsters/RaisedSkeletons/RaisedSkeletonStandard",
+		FireResist:      40,
+		ColdResist:      40,
+		LightningResist: 40,
+		ChaosResist:     20,
+		DamageSpread:    0.2,
+		Accuracy:        1,
+		WeaponType:      OneHandSword,
+		Limit:           "ActiveSkeletonLimit",
+	},
+	"SummonedRagingSpirit": {
+		Variety:         "Metadata/Monsters/SummonedSkull/SummonedSkull",
+		FireResist:      40,
+		ColdResist:      40,
+		LightningResist: 40,
+		ChaosResist:     20,
+		DamageSpread:    0.2,
+		Accuracy:        1,
+		WeaponType:      OneHandSword,
+		Limit:           "ActiveRagingSpiritLimit",
+		ModList: []mod.Mod{
+			mod.NewFloat("PhysicalDamageConvertToFire", mod.TypeBase, 50),
+		},
+	},
+	"SummonedChaosGolem": {
+		Variety:         "Metadata/Monsters/ChaosElemental/ChaosElementalSummoned",
+		Armour:          0.8,
+		FireResist:      40,
+		ColdResist:      40,
+		LightningResist: 40,
+		ChaosResist:     60,
+		DamageSpread:    0.2,
+		Accuracy:        1,
+		Limit:           "ActiveGolemLimit",
+	},
+	"SummonedFlameGolem": {
+		Variety:         "Metadata/Monsters/FireElemental/FireElementalSummoned",
+		EnergyShield:    0.2,
+		FireResist:      70,
+		ColdResist:      40,
+		LightningResist: 40,
+		ChaosResist:     20,
+		DamageSpread:    0.2,
+		Accuracy:        1,
+		Limit:           "ActiveGolemLimit",
+	},
+	"SummonedIceGolem": {
+		Variety:         "Metadata/Monsters/IceElemental/IceElementalSummoned",
+		EnergyShield:    0.4,
+		FireResist:      40,
+		ColdResist:      70,
+		LightningResist: 40,
+		ChaosResist:     20,
+		DamageSpread:    0.2,
+		Accuracy:        1,
+		Limit:           "ActiveGolemLimit",
+	},
+	"SummonedLightningGolem": {
+		Variety:         "Metadata/Monsters/LightningGolem/LightningGolemSummoned",
+		EnergyShield:    0.2,
+		FireResist:      40,
+		ColdResist:      40,
+		LightningResist: 70,
+		ChaosResist:     20,
+		DamageSpread:    0.2,
+		Accuracy:        1,
+		Limit:           "ActiveGolemLimit",
+	},
+	"SummonedStoneGolem": {
+		Variety:         "Metadata/Monsters/RockGolem/RockGolemSummoned",
+		Armour:          0.8,
+		FireResist:      40,
+		ColdResist:      40,
+		LightningResist: 40,
+		ChaosResist:     20,
+		DamageSpread:    0.2,
+		Accuracy:        1,
+		Limit:           "ActiveGolemLimit",
+	},
+	"AxisEliteSoldierHeraldOfLight": {
+		Variety:         "Metadata/Monsters/Axis/AxisEliteSoldierHeraldOfLight",
+		Armour:          0.5,
+		FireResist:      40,
+		ColdResist:      40,
+		LightningResist: 40,
+		ChaosResist:     20,
+		DamageSpread:    0.2,
+		Accuracy:        1,
+		Limit:           "ActiveSentinelOfPurityLimit",
+	},
+	"HeraldOfAgonySpiderPlated": {
+		Variety:         "Metadata/Monsters/SpiderPlated/HeraldOfAgonySpiderPlated",
+		Armour:          0.5,
+		FireResist:      40,
+		ColdResist:      40,
+		LightningResist: 40,
+		ChaosResist:     20,
+		DamageSpread:    0.2,
+		Accuracy:        1,
+		ModList: []mod.Mod{
+			mod.NewFloat("PhysicalDamageConvertToChaos", mod.TypeBase, 40),
+		},
+	},
+	"AnimatedArmour": {
+		Variety:         "Metadata/Monsters/AnimatedItem/AnimatedArmour",
+		Armour:          0.5,
+		FireResist:      40,
+		ColdResist:      40,
+		LightningResist: 40,
+		ChaosResist:     20,
+		DamageSpread:    0.2,
+		Accuracy:        1,
+	},
+}
+
+var minionCache = struct {
+	sync.Mutex
+	byID map[string]*MinionData
+}{byID: make(map[string]*MinionData)}
+
+// Minion returns the stats of a minion, the game data has to be loaded before the first call
+func Minion(id string) (*MinionData, error) {
+	minionCache.Lock()
+	defer minionCache.Unlock()
+
+	if minion, ok := minionCache.byID[id]; ok {
+		return minion, nil
+	}
+
+	base, ok := minions[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown minion %s", id)
+	}
+
+	var variety *poe.MonsterVariety
+	for _, v := range poe.MonsterVarieties {
+		if v.ID == base.Variety {
+			variety = v
+			break
+		}
+	}
+	if variety == nil {
+		return nil, fmt.Errorf("monster variety %s of minion %s is not loaded", base.Variety, id)
+	}
+
+	minion := *base
+	minion.Name = variety.Name
+	minion.Life = float64(variety.LifeMultiplier) / 100
+	minion.Damage = float64(variety.DamageMultiplier) / 100
+	minion.AttackTime = float64(variety.AttackSpeed) / 1000
+	minion.AttackRange = float64(variety.MaximumAttackDistance)
+	minion.SkillList = make([]string, 0, len(variety.GrantedEffectsKeys))
+	for _, key := range variety.GrantedEffectsKeys {
+		if key >= 0 && key < len(poe.GrantedEffects) {
+			minion.SkillList = append(minion.SkillList, poe.GrantedEffects[key].ID)
+		}
+	}
+
+	minionCache.byID[id] = &minion
+	return &minion, nil
+}
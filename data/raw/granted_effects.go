@@ -211,9 +211,12 @@ func (g *CalculatedGrantedEffect) calculate() {
 		g.calculatedConstantStats[stat.ID] = float64(grantedEffectStatSet.ConstantStatsValues[i])
 	}
 
-	// TODO Pull manually defined stat additions
+	// TODO Pull remaining manually defined stat additions
 	g.calculatedStatMap = loader.NewComputationCache[string, *StatMap](func(key string) *StatMap {
 		oldMap := SkillStatMap[key]
+		if specificMap, ok := SkillSpecificStatMap[g.ID][key]; ok {
+			oldMap = specificMap
+		}
 		if oldMap != nil {
 			newMap := oldMap.Clone()
 			for i, m := range newMap.Mods {
@@ -0,0 +1,279 @@
+package raw
+
+import (
+	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/utils"
+)
+
+// SkillSpecificStatMap holds stat mappings that only apply to a single granted effect, keyed by granted effect ID.
+// Entries take precedence over SkillStatMap.
+var SkillSpecificStatMap = map[string]map[string]*StatMap{
+	//
+	// Auras
+	//
+	"Anger": {
+		"attack_minimum_added_fire_damage": {
+			Mods: []mod.Mod{mod.NewFloat("FireMin", "BASE", 0).KeywordFlag(mod.KeywordFlagAttack).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"attack_maximum_added_fire_damage": {
+			Mods: []mod.Mod{mod.NewFloat("FireMax", "BASE", 0).KeywordFlag(mod.KeywordFlagAttack).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"spell_minimum_added_fire_damage": {
+			Mods: []mod.Mod{mod.NewFloat("FireMin", "BASE", 0).KeywordFlag(mod.KeywordFlagSpell).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"spell_maximum_added_fire_damage": {
+			Mods: []mod.Mod{mod.NewFloat("FireMax", "BASE", 0).KeywordFlag(mod.KeywordFlagSpell).Tag(mod.GlobalEffect("Aura"))},
+		},
+	},
+	"Hatred": {
+		"physical_damage_%_to_add_as_cold": {
+			Mods: []mod.Mod{mod.NewFloat("PhysicalDamageGainAsCold", "BASE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"hatred_aura_cold_damage_+%_final": {
+			Mods: []mod.Mod{mod.NewFloat("ColdDamage", "MORE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+	},
+	"Wrath": {
+		"attack_minimum_added_lightning_damage": {
+			Mods: []mod.Mod{mod.NewFloat("LightningMin", "BASE", 0).KeywordFlag(mod.KeywordFlagAttack).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"attack_maximum_added_lightning_damage": {
+			Mods: []mod.Mod{mod.NewFloat("LightningMax", "BASE", 0).KeywordFlag(mod.KeywordFlagAttack).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"wrath_aura_spell_lightning_damage_+%_final": {
+			Mods: []mod.Mod{mod.NewFloat("LightningDamage", "MORE", 0).Flag(mod.MFlagSpell).Tag(mod.GlobalEffect("Aura"))},
+		},
+	},
+	"Grace": {
+		"base_evasion_rating": {
+			Mods: []mod.Mod{mod.NewFloat("Evasion", "BASE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"grace_aura_evasion_rating_+%_final": {
+			Mods: []mod.Mod{mod.NewFloat("Evasion", "MORE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+	},
+	"Determination": {
+		"base_physical_damage_reduction_rating": {
+			Mods: []mod.Mod{mod.NewFloat("Armour", "BASE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"determination_aura_armour_+%_final": {
+			Mods: []mod.Mod{mod.NewFloat("Armour", "MORE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+	},
+	"Discipline": {
+		"base_maximum_energy_shield": {
+			Mods: []mod.Mod{mod.NewFloat("EnergyShield", "BASE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"energy_shield_recharge_rate_+%": {
+			Mods: []mod.Mod{mod.NewFloat("EnergyShieldRecharge", "INC", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+	},
+	"Clarity": {
+		"base_mana_regeneration_rate_per_minute": {
+			Mods: []mod.Mod{mod.NewFloat("ManaRegen", "BASE", 0).Tag(mod.GlobalEffect("Aura"))},
+			Div:  utils.Ptr(float64(60)),
+		},
+	},
+	"Vitality": {
+		"base_life_regeneration_rate_per_minute": {
+			Mods: []mod.Mod{mod.NewFloat("LifeRegen", "BASE", 0).Tag(mod.GlobalEffect("Aura"))},
+			Div:  utils.Ptr(float64(60)),
+		},
+	},
+	"Haste": {
+		"attack_speed_+%_granted_from_skill": {
+			Mods: []mod.Mod{mod.NewFloat("Speed", "INC", 0).Flag(mod.MFlagAttack).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"cast_speed_+%_granted_from_skill": {
+			Mods: []mod.Mod{mod.NewFloat("Speed", "INC", 0).Flag(mod.MFlagCast).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"base_movement_velocity_+%": {
+			Mods: []mod.Mod{mod.NewFloat("MovementSpeed", "INC", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+	},
+	"AccuracyAndCritsAura": {
+		"accuracy_rating": {
+			Mods: []mod.Mod{mod.NewFloat("Accuracy", "BASE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"critical_strike_chance_+%": {
+			Mods: []mod.Mod{mod.NewFloat("CritChance", "INC", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+	},
+	"SpellDamageAura": {
+		"spell_damage_aura_spell_damage_+%_final": {
+			Mods: []mod.Mod{mod.NewFloat("Damage", "MORE", 0).Flag(mod.MFlagSpell).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"spell_critical_strike_chance_+%": {
+			Mods: []mod.Mod{mod.NewFloat("CritChance", "INC", 0).Flag(mod.MFlagSpell).Tag(mod.GlobalEffect("Aura"))},
+		},
+	},
+	"DamageOverTimeAura": {
+		"delirium_aura_damage_over_time_+%_final": {
+			Mods: []mod.Mod{mod.NewFloat("Damage", "MORE", 0).Flag(mod.MFlagDot).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"delirium_skill_effect_duration_+%": {
+			Mods: []mod.Mod{mod.NewFloat("Duration", "INC", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+	},
+	"PhysicalDamageAura": {
+		"physical_damage_aura_nearby_enemies_physical_damage_taken_+%": {
+			Mods: []mod.Mod{mod.NewFloat("PhysicalDamageTaken", "INC", 0).Tag(mod.GlobalEffect("AuraDebuff"))},
+		},
+	},
+	"Purity": {
+		"base_resist_all_elements_%": {
+			Mods: []mod.Mod{mod.NewFloat("ElementalResist", "BASE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+	},
+	"FireResistAura": {
+		"base_fire_damage_resistance_%": {
+			Mods: []mod.Mod{mod.NewFloat("FireResist", "BASE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"base_maximum_fire_damage_resistance_%": {
+			Mods: []mod.Mod{mod.NewFloat("FireResistMax", "BASE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+	},
+	"ColdResistAura": {
+		"base_cold_damage_resistance_%": {
+			Mods: []mod.Mod{mod.NewFloat("ColdResist", "BASE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"base_maximum_cold_damage_resistance_%": {
+			Mods: []mod.Mod{mod.NewFloat("ColdResistMax", "BASE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+	},
+	"LightningResistAura": {
+		"base_lightning_damage_resistance_%": {
+			Mods: []mod.Mod{mod.NewFloat("LightningResist", "BASE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+		"base_maximum_lightning_damage_resistance_%": {
+			Mods: []mod.Mod{mod.NewFloat("LightningResistMax", "BASE", 0).Tag(mod.GlobalEffect("Aura"))},
+		},
+	},
+
+	//
+	// Heralds
+	//
+	"HeraldOfAsh": {
+		"physical_damage_%_to_add_as_fire": {
+			Mods: []mod.Mod{mod.NewFloat("PhysicalDamageGainAsFire", "BASE", 0).Tag(mod.GlobalEffect("Buff"))},
+		},
+		"herald_of_ash_spell_fire_damage_+%_final": {
+			Mods: []mod.Mod{mod.NewFloat("FireDamage", "MORE", 0).Flag(mod.MFlagSpell).Tag(mod.GlobalEffect("Buff"))},
+		},
+	},
+	"HeraldOfIce": {
+		"spell_minimum_added_cold_damage": {
+			Mods: []mod.Mod{mod.NewFloat("ColdMin", "BASE", 0).KeywordFlag(mod.KeywordFlagSpell).Tag(mod.GlobalEffect("Buff"))},
+		},
+		"spell_maximum_added_cold_damage": {
+			Mods: []mod.Mod{mod.NewFloat("ColdMax", "BASE", 0).KeywordFlag(mod.KeywordFlagSpell).Tag(mod.GlobalEffect("Buff"))},
+		},
+		"attack_minimum_added_cold_damage": {
+			Mods: []mod.Mod{mod.NewFloat("ColdMin", "BASE", 0).KeywordFlag(mod.KeywordFlagAttack).Tag(mod.GlobalEffect("Buff"))},
+		},
+		"attack_maximum_added_cold_damage": {
+			Mods: []mod.Mod{mod.NewFloat("ColdMax", "BASE", 0).KeywordFlag(mod.KeywordFlagAttack).Tag(mod.GlobalEffect("Buff"))},
+		},
+	},
+	"HeraldOfThunder": {
+		"spell_minimum_added_lightning_damage": {
+			Mods: []mod.Mod{mod.NewFloat("LightningMin", "BASE", 0).KeywordFlag(mod.KeywordFlagSpell).Tag(mod.GlobalEffect("Buff"))},
+		},
+		"spell_maximum_added_lightning_damage": {
+			Mods: []mod.Mod{mod.NewFloat("LightningMax", "BASE", 0).KeywordFlag(mod.KeywordFlagSpell).Tag(mod.GlobalEffect("Buff"))},
+		},
+		"attack_minimum_added_lightning_damage": {
+			Mods: []mod.Mod{mod.NewFloat("LightningMin", "BASE", 0).KeywordFlag(mod.KeywordFlagAttack).Tag(mod.GlobalEffect("Buff"))},
+		},
+		"attack_maximum_added_lightning_damage": {
+			Mods: []mod.Mod{mod.NewFloat("LightningMax", "BASE", 0).KeywordFlag(mod.KeywordFlagAttack).Tag(mod.GlobalEffect("Buff"))},
+		},
+	},
+	"HeraldOfPurity": {
+		"herald_of_purity_physical_damage_+%_final": {
+			Mods: []mod.Mod{mod.NewFloat("PhysicalDamage", "MORE", 0).Tag(mod.GlobalEffect("Buff"))},
+		},
+	},
+
+	//
+	// Curses
+	//
+	"Flammability": {
+		"base_fire_damage_resistance_%": {
+			Mods: []mod.Mod{mod.NewFloat("FireResist", "BASE", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+		"chance_to_be_ignited_%": {
+			Mods: []mod.Mod{mod.NewFloat("SelfIgniteChance", "BASE", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+	},
+	"Frostbite": {
+		"base_cold_damage_resistance_%": {
+			Mods: []mod.Mod{mod.NewFloat("ColdResist", "BASE", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+		"chance_to_be_frozen_%": {
+			Mods: []mod.Mod{mod.NewFloat("SelfFreezeChance", "BASE", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+	},
+	"Conductivity": {
+		"base_lightning_damage_resistance_%": {
+			Mods: []mod.Mod{mod.NewFloat("LightningResist", "BASE", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+		"chance_to_be_shocked_%": {
+			Mods: []mod.Mod{mod.NewFloat("SelfShockChance", "BASE", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+	},
+	"ElementalWeakness": {
+		"base_resist_all_elements_%": {
+			Mods: []mod.Mod{mod.NewFloat("ElementalResist", "BASE", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+	},
+	"Despair": {
+		"base_chaos_damage_resistance_%": {
+			Mods: []mod.Mod{mod.NewFloat("ChaosResist", "BASE", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+		"minimum_added_chaos_damage_taken": {
+			Mods: []mod.Mod{mod.NewFloat("SelfChaosMin", "BASE", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+		"maximum_added_chaos_damage_taken": {
+			Mods: []mod.Mod{mod.NewFloat("SelfChaosMax", "BASE", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+		"degen_effect_+%": {
+			Mods: []mod.Mod{mod.NewFloat("DamageTakenOverTime", "INC", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+	},
+	"Vulnerability": {
+		"physical_damage_taken_+%": {
+			Mods: []mod.Mod{mod.NewFloat("PhysicalDamageTaken", "INC", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+		"receive_bleeding_chance_%_when_hit_by_attack": {
+			Mods: []mod.Mod{mod.NewFloat("SelfBleedChance", "BASE", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+	},
+	"Punishment": {
+		"damage_taken_+%_on_low_life": {
+			Mods: []mod.Mod{mod.NewFloat("DamageTaken", "INC", 0).Tag(mod.GlobalEffect("Curse"), mod.Condition("LowLife"))},
+		},
+	},
+	"Enfeeble": {
+		"accuracy_rating_+%": {
+			Mods: []mod.Mod{mod.NewFloat("Accuracy", "INC", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+		"enfeeble_damage_+%_final": {
+			Mods: []mod.Mod{mod.NewFloat("Damage", "MORE", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+	},
+	"TemporalChains": {
+		"temporal_chains_action_speed_+%_final": {
+			Mods: []mod.Mod{mod.NewFloat("TemporalChainsActionSpeed", "INC", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+	},
+	"AssassinsMark": {
+		"enemy_additional_critical_strike_chance_against_self": {
+			Mods: []mod.Mod{mod.NewFloat("SelfCritChance", "BASE", 0).Tag(mod.GlobalEffect("Curse"))},
+			Div:  utils.Ptr(float64(100)),
+		},
+		"enemy_additional_critical_strike_multiplier_against_self": {
+			Mods: []mod.Mod{mod.NewFloat("SelfCritMultiplier", "BASE", 0).Tag(mod.GlobalEffect("Curse"))},
+		},
+	},
+}
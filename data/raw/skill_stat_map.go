@@ -6,7 +6,7 @@ import (
 )
 
 func skill(dataKey string, dataValue float64) mod.Mod {
-	return mod.NewList("SkillData", &mod.SkillData{
+	return mod.NewList("SkillData", mod.SkillData{
 		Key:   dataKey,
 		Value: dataValue,
 	})
@@ -212,6 +212,19 @@ var SkillStatMap = map[string]*StatMap{
 	"cast_spell_while_linked_skill_channelling": {
 		Mods: []mod.Mod{skill("triggeredWhileChannelling", 1).Tag(mod.SkillType("Triggerable"), mod.SkillType("Spell"))},
 	},
+	"cast_while_channelling_time_ms": {
+		Mods: []mod.Mod{skill("TriggerTime", 0)},
+		Div:  utils.Ptr(float64(1000)),
+	},
+	"cast_on_damage_taken_%": {
+		Mods: []mod.Mod{skill("triggeredByDamageTaken", 1).Tag(mod.SkillType("Triggerable"), mod.SkillType("Spell"))},
+	},
+	"triggered_by_brand_support": {
+		Mods: []mod.Mod{skill("triggeredByBrand", 1).Tag(mod.SkillType("Triggerable"), mod.SkillType("Spell"))},
+	},
+	"spell_only_castable_on_death": {
+		Mods: []mod.Mod{skill("triggeredOnDeath", 1).Tag(mod.SkillType("Triggerable"), mod.SkillType("Spell"))},
+	},
 	"skill_triggered_by_snipe": {
 		Mods: []mod.Mod{skill("triggered", 1).Tag(mod.SkillType("Triggerable"))},
 	},
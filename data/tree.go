@@ -0,0 +1,46 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// MasteryEffect returns the effect with the given id if it can be selected on the given mastery node
+func (t *Tree) MasteryEffect(nodeID int64, effectID int64) (*MasteryEffect, error) {
+	node, ok := t.Nodes[strconv.FormatInt(nodeID, 10)]
+	if !ok {
+		return nil, fmt.Errorf("node %d does not exist", nodeID)
+	}
+
+	if node.IsMastery == nil || !*node.IsMastery {
+		return nil, fmt.Errorf("node %d is not a mastery", nodeID)
+	}
+
+	for i, effect := range node.MasteryEffects {
+		if effect.Effect == effectID {
+			return &node.MasteryEffects[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("effect %d is not available on mastery %d", effectID, nodeID)
+}
+
+// ClassStartNode returns the starting node of the class at the given index
+func (t *Tree) ClassStartNode(classID int) *Node {
+	for _, node := range t.Nodes {
+		if node.ClassStartIndex != nil && *node.ClassStartIndex == int64(classID) {
+			return &node
+		}
+	}
+	return nil
+}
+
+// AscendancyStartNode returns the starting node of the given ascendancy
+func (t *Tree) AscendancyStartNode(ascendancyName AscendancyName) *Node {
+	for _, node := range t.Nodes {
+		if node.IsAscendancyStart != nil && *node.IsAscendancyStart && node.AscendancyName != nil && AscendancyName(*node.AscendancyName) == ascendancyName {
+			return &node
+		}
+	}
+	return nil
+}
@@ -2,8 +2,10 @@ package data
 
 import (
 	"context"
+	"sync"
 	"testing"
 
+	"github.com/MarvinJWendt/testza"
 	"github.com/Vilsol/go-pob-data/poe"
 	"github.com/Vilsol/go-pob/cache"
 
@@ -20,13 +22,78 @@ func init() {
 }
 
 func TestLoadTreeGraph(t *testing.T) {
-	TreeVersions[TreeVersion3_18].getGraph()
+	TreeVersions[TreeVersion3_18].getGraph(TreeVersions[TreeVersion3_18].Tree())
 }
 
 func BenchmarkGraphSearch(b *testing.B) {
-	TreeVersions[TreeVersion3_18].getGraph()
+	TreeVersions[TreeVersion3_18].getGraph(TreeVersions[TreeVersion3_18].Tree())
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		TreeVersions[TreeVersion3_18].CalculateTreePath([]int64{48828, 55373, 2151, 47062, 15144, 62103}, 23881)
+		_, _ = TreeVersions[TreeVersion3_18].CalculateTreePath(context.Background(), []int64{48828, 55373, 2151, 47062, 15144, 62103}, 23881)
+	}
+}
+
+func TestCalculatePrunableNodes(t *testing.T) {
+	tree := TreeVersions[TreeVersion3_18]
+
+	scionStart := int64(58833)
+	roots := []int64{48828, 55373, 2151, 47062, 15144, 62103}
+	path, err := tree.CalculateTreePath(context.Background(), roots, 23881)
+	testza.AssertNoError(t, err)
+	testza.AssertGreater(t, len(path), 3)
+
+	active := append([]int64{scionStart}, roots...)
+	active = append(active, path[1:]...)
+
+	// The end of a path has nothing depending on it
+	prunable, err := tree.CalculatePrunableNodes(context.Background(), active, scionStart, 23881)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, []int64{23881}, prunable)
+
+	// Everything past the target is disconnected
+	middle := len(path) / 2
+	prunable, err = tree.CalculatePrunableNodes(context.Background(), active, scionStart, path[middle])
+	testza.AssertNoError(t, err)
+	testza.AssertLen(t, prunable, len(path)-middle)
+	for _, id := range path[middle:] {
+		testza.AssertContains(t, prunable, id)
+	}
+
+	// Nodes next to the start stay connected
+	prunable, err = tree.CalculatePrunableNodes(context.Background(), append([]int64{scionStart}, roots...), scionStart, roots[0])
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, []int64{roots[0]}, prunable)
+
+	// Removing the start disconnects everything
+	prunable, err = tree.CalculatePrunableNodes(context.Background(), active, scionStart, scionStart)
+	testza.AssertNoError(t, err)
+	testza.AssertLen(t, prunable, len(active))
+}
+
+func TestCalculatePrunableNodesConcurrent(t *testing.T) {
+	tree := &TreeVersionData{Display: "3.18", cachedTree: TreeVersions[TreeVersion3_18].Tree()}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			prunable, err := tree.CalculatePrunableNodes(context.Background(), []int64{58833, 48828}, 58833, 48828)
+			testza.AssertNoError(t, err)
+			testza.AssertEqual(t, []int64{48828}, prunable)
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkCalculatePrunableNodes(b *testing.B) {
+	tree := TreeVersions[TreeVersion3_18]
+	roots := []int64{58833, 48828, 55373, 2151, 47062, 15144, 62103}
+	path, _ := tree.CalculateTreePath(context.Background(), roots, 23881)
+	active := append(roots, path...)
+	tree.getLinks(tree.Tree())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = tree.CalculatePrunableNodes(context.Background(), active, 58833, active[len(active)/2])
 	}
 }
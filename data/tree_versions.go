@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/Vilsol/go-pob/cache"
 	"github.com/andybalholm/brotli"
@@ -32,77 +36,162 @@ const (
 const LatestTreeVersion = TreeVersion3_18
 const DefaultTreeVersion = TreeVersion3_10
 
+var (
+	ErrTreeNotFound = errors.New("tree not found")
+	ErrDataCorrupt  = errors.New("data is corrupt")
+)
+
+// TreeFetchTimeout limits how long downloading a tree may take
+var TreeFetchTimeout = time.Minute
+
 type TreeVersionData struct {
 	Display      string
 	Num          float64
 	URL          string
+	mu           sync.Mutex
 	cachedTree   *Tree
 	rawTree      []byte
 	graph        graph.Graph[int64, int64]
 	adjacencyMap map[int64]map[int64]graph.Edge[int64]
+	links        map[int64][]int64
 }
 
 const cdnTreeBase = "https://go-pob-data.pages.dev/data/%s/tree/data.json.br"
 
+// GetTreeVersion returns the data of a tree version, or ErrTreeNotFound if the version is not known
+func GetTreeVersion(version TreeVersion) (*TreeVersionData, error) {
+	treeVersion, ok := TreeVersions[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown version %s", ErrTreeNotFound, version)
+	}
+	return treeVersion, nil
+}
+
+// Tree is like LoadTree, but panics if the tree cannot be loaded
 func (v *TreeVersionData) Tree() *Tree {
+	tree, err := v.LoadTree(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	return tree
+}
+
+// RawTree is like LoadRawTree, but panics if the tree cannot be loaded
+func (v *TreeVersionData) RawTree() []byte {
+	rawTree, err := v.LoadRawTree(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	return rawTree
+}
+
+// LoadTree loads and decodes the tree, reusing it once loaded
+func (v *TreeVersionData) LoadTree(ctx context.Context) (*Tree, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
 	if v.cachedTree != nil {
-		return v.cachedTree
+		return v.cachedTree, nil
+	}
+
+	rawTree, err := v.loadRawTree(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	var outTree Tree
-	if err := json.Unmarshal(v.RawTree(), &outTree); err != nil {
-		panic(fmt.Errorf("failed to decode file: %w", err))
+	if err := json.Unmarshal(rawTree, &outTree); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode tree %s: %w", ErrDataCorrupt, v.Display, err)
 	}
 	v.cachedTree = &outTree
 
-	return v.cachedTree
+	return v.cachedTree, nil
 }
 
-func (v *TreeVersionData) RawTree() []byte {
+// LoadRawTree returns the tree json, reading it from the cache or fetching it if it is not cached
+func (v *TreeVersionData) LoadRawTree(ctx context.Context) ([]byte, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.loadRawTree(ctx)
+}
+
+func (v *TreeVersionData) loadRawTree(ctx context.Context) ([]byte, error) {
 	if v.rawTree != nil {
-		return v.rawTree
+		return v.rawTree, nil
 	}
 
 	treeURL := fmt.Sprintf(cdnTreeBase, v.Display)
+	fromCache := cache.Disk().Exists(treeURL)
+
 	var compressedTree []byte
-	if cache.Disk().Exists(treeURL) {
-		var err error
+	var err error
+	if fromCache {
 		compressedTree, err = cache.Disk().Get(treeURL)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s: %w", ErrTreeNotFound, v.Display, err)
+		}
 		if err != nil {
-			panic(err)
+			return nil, fmt.Errorf("failed to read tree from cache: %w", err)
 		}
 	} else {
-		slog.Debug("fetching", slog.String("url", treeURL))
-		req, _ := http.NewRequest(http.MethodGet, treeURL, nil)
-		req = req.WithContext(context.Background())
-		response, err := http.DefaultClient.Do(req)
+		compressedTree, err = fetchTree(ctx, treeURL)
 		if err != nil {
-			panic(fmt.Errorf("failed to fetch url: %s: %w", treeURL, err))
+			return nil, err
 		}
-		defer response.Body.Close()
+	}
 
-		compressedTree, err = io.ReadAll(response.Body)
-		if err != nil {
-			panic(fmt.Errorf("failed to read response body: %w", err))
-		}
+	rawTree, err := io.ReadAll(brotli.NewReader(bytes.NewReader(compressedTree)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decompress tree %s: %w", ErrDataCorrupt, v.Display, err)
+	}
 
-		defer func() {
-			_ = cache.Disk().Set(treeURL, compressedTree)
-		}()
+	if !fromCache {
+		_ = cache.Disk().Set(treeURL, compressedTree)
 	}
 
-	unzipStream := brotli.NewReader(bytes.NewReader(compressedTree))
+	v.rawTree = rawTree
 
-	var err error
-	v.rawTree, err = io.ReadAll(unzipStream)
+	return v.rawTree, nil
+}
+
+func fetchTree(ctx context.Context, treeURL string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, TreeFetchTimeout)
+	defer cancel()
+
+	slog.Debug("fetching", slog.String("url", treeURL))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, treeURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	response, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch url: %s: %w", treeURL, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrTreeNotFound, treeURL)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch url: %s: unexpected status %s", treeURL, response.Status)
+	}
+
+	compressedTree, err := io.ReadAll(response.Body)
 	if err != nil {
-		panic(fmt.Errorf("failed to read unzipped data: %w", err))
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return v.rawTree
+	return compressedTree, nil
 }
 
-func (v *TreeVersionData) getGraph() (graph.Graph[int64, int64], map[int64]map[int64]graph.Edge[int64]) {
+// getGraph returns the graph of the tree and its adjacency map, building them once the tree is loaded
+func (v *TreeVersionData) getGraph(tree *Tree) (graph.Graph[int64, int64], map[int64]map[int64]graph.Edge[int64]) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
 	if v.graph != nil {
 		return v.graph, v.adjacencyMap
 	}
@@ -111,7 +200,7 @@ func (v *TreeVersionData) getGraph() (graph.Graph[int64, int64], map[int64]map[i
 		return v
 	}, graph.Directed())
 
-	for _, node := range v.Tree().Nodes {
+	for _, node := range tree.Nodes {
 		if node.Skill == nil {
 			continue
 		}
@@ -119,7 +208,7 @@ func (v *TreeVersionData) getGraph() (graph.Graph[int64, int64], map[int64]map[i
 		_ = g.AddVertex(*node.Skill)
 	}
 
-	for _, node := range v.Tree().Nodes {
+	for _, node := range tree.Nodes {
 		if node.Skill == nil {
 			continue
 		}
@@ -130,7 +219,7 @@ func (v *TreeVersionData) getGraph() (graph.Graph[int64, int64], map[int64]map[i
 				continue
 			}
 
-			targetNode := v.Tree().Nodes[target]
+			targetNode := tree.Nodes[target]
 			if targetNode.ClassStartIndex != nil {
 				continue
 			}
@@ -154,9 +243,14 @@ func (v *TreeVersionData) getGraph() (graph.Graph[int64, int64], map[int64]map[i
 	return v.graph, v.adjacencyMap
 }
 
-func (v *TreeVersionData) CalculateTreePath(activeNodes []int64, target int64) []int64 {
-	g, adjacencyMap := v.getGraph()
-	//found := int64(-1)
+// CalculateTreePath returns the shortest path from the target to any of the active nodes
+func (v *TreeVersionData) CalculateTreePath(ctx context.Context, activeNodes []int64, target int64) ([]int64, error) {
+	tree, err := v.LoadTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	g, adjacencyMap := v.getGraph(tree)
 
 	mappedNodes := make(map[int64]bool, len(activeNodes))
 	for _, node := range activeNodes {
@@ -168,7 +262,104 @@ func (v *TreeVersionData) CalculateTreePath(activeNodes []int64, target int64) [
 		return ok
 	})
 
-	return resultPath
+	return resultPath, nil
+}
+
+// getLinks returns the nodes linked to each node in either direction
+func (v *TreeVersionData) getLinks(tree *Tree) map[int64][]int64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.links != nil {
+		return v.links
+	}
+
+	links := make(map[int64][]int64, len(tree.Nodes))
+	for _, node := range tree.Nodes {
+		if node.Skill == nil {
+			continue
+		}
+
+		for _, target := range node.Out {
+			targetID, err := strconv.ParseInt(target, 10, 64)
+			if err != nil {
+				continue
+			}
+
+			links[*node.Skill] = append(links[*node.Skill], targetID)
+			links[targetID] = append(links[targetID], *node.Skill)
+		}
+	}
+
+	v.links = links
+
+	return v.links
+}
+
+// CalculatePrunableNodes returns the target and every allocated node that would no longer be connected to a start node once the target is deallocated
+func (v *TreeVersionData) CalculatePrunableNodes(ctx context.Context, activeNodes []int64, classStartNode int64, target int64) ([]int64, error) {
+	tree, err := v.LoadTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	links := v.getLinks(tree)
+	nodes := tree.Nodes
+
+	allocated := make(map[int64]bool, len(activeNodes))
+	for _, id := range activeNodes {
+		allocated[id] = true
+	}
+	delete(allocated, target)
+
+	connected := make(map[int64]bool, len(activeNodes))
+	queue := make([]int64, 0, len(activeNodes))
+	for _, id := range activeNodes {
+		node := nodes[strconv.FormatInt(id, 10)]
+		if allocated[id] && (id == classStartNode || (node.IsAscendancyStart != nil && *node.IsAscendancyStart)) {
+			connected[id] = true
+			queue = append(queue, id)
+		}
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		// Masteries do not connect the nodes around them
+		if node := nodes[strconv.FormatInt(current, 10)]; node.IsMastery != nil && *node.IsMastery {
+			continue
+		}
+
+		for _, other := range links[current] {
+			if allocated[other] && !connected[other] {
+				connected[other] = true
+				queue = append(queue, other)
+			}
+		}
+	}
+
+	prunable := []int64{target}
+	for _, id := range activeNodes {
+		if id == target || connected[id] {
+			continue
+		}
+
+		node, ok := nodes[strconv.FormatInt(id, 10)]
+		if !ok {
+			// Cluster jewel nodes are not part of the tree
+			continue
+		}
+
+		// TODO Nested cluster jewel sockets are connected through subgraphs
+		if node.ExpansionJewel != nil && node.ExpansionJewel.Parent != nil {
+			continue
+		}
+
+		prunable = append(prunable, id)
+	}
+
+	return prunable, nil
 }
 
 // BFS is an adapted version of graph.BFS that also returns the traversal path
@@ -220,3 +411,11 @@ func BFS[K comparable, T any](g graph.Graph[K, T], adjacencyMap map[K]map[K]grap
 }
 
 var TreeVersions = make(map[TreeVersion]*TreeVersionData)
+
+// ResolveTreeVersion returns the given version if its tree data is available, otherwise the latest version
+func ResolveTreeVersion(version TreeVersion) (TreeVersion, bool) {
+	if _, ok := TreeVersions[version]; ok {
+		return version, true
+	}
+	return LatestTreeVersion, false
+}
@@ -0,0 +1,62 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MarvinJWendt/testza"
+	"github.com/andybalholm/brotli"
+
+	"github.com/Vilsol/go-pob/cache"
+)
+
+func compress(t *testing.T, raw string) []byte {
+	t.Helper()
+
+	var b bytes.Buffer
+	w := brotli.NewWriter(&b)
+	_, err := w.Write([]byte(raw))
+	testza.AssertNoError(t, err)
+	testza.AssertNoError(t, w.Close())
+	return b.Bytes()
+}
+
+func TestLoadTreeErrors(t *testing.T) {
+	_, err := GetTreeVersion("0_1")
+	testza.AssertErrorIs(t, err, ErrTreeNotFound)
+
+	cache.Use(cache.FS(fstest.MapFS{
+		"9.97/tree/data.json.br": &fstest.MapFile{Data: []byte("not brotli")},
+		"9.98/tree/data.json.br": &fstest.MapFile{Data: compress(t, "not json")},
+		"9.99/tree/data.json.br": &fstest.MapFile{Data: compress(t, `{"tree":"Default","nodes":{}}`)},
+	}))
+	defer cache.Use(nil)
+
+	_, err = (&TreeVersionData{Display: "9.96"}).LoadTree(context.Background())
+	testza.AssertErrorIs(t, err, ErrTreeNotFound)
+
+	_, err = (&TreeVersionData{Display: "9.96"}).CalculateTreePath(context.Background(), []int64{1}, 2)
+	testza.AssertErrorIs(t, err, ErrTreeNotFound)
+
+	_, err = (&TreeVersionData{Display: "9.97"}).LoadRawTree(context.Background())
+	testza.AssertErrorIs(t, err, ErrDataCorrupt)
+
+	_, err = (&TreeVersionData{Display: "9.98"}).LoadTree(context.Background())
+	testza.AssertErrorIs(t, err, ErrDataCorrupt)
+
+	valid := &TreeVersionData{Display: "9.99"}
+	tree, err := valid.LoadTree(context.Background())
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, "Default", tree.Tree)
+	testza.AssertEqual(t, tree, valid.Tree())
+}
+
+func TestLoadTreeCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := (&TreeVersionData{Display: "9.95"}).LoadTree(ctx)
+	testza.AssertErrorIs(t, err, context.Canceled)
+}
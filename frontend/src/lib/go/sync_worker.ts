@@ -1,7 +1,7 @@
 import { expose, proxy } from 'comlink';
 import '$lib/console_hook';
 import '../../wasm_exec.js';
-import { initializeCrystalline, cache, raw, config, pob, builds, calculator, exposition } from '../types';
+import { initializeCrystalline, cache, raw, config, pob, builds, exposition } from '../types';
 import type { Outputs } from '../custom_types';
 import localforage from 'localforage';
 import type { currentBuild } from '../global';
@@ -94,13 +94,13 @@ class PoBWorker {
       return;
     }
 
-    const calc = calculator.NewCalculator(this.currentBuild);
-    if (!calc) {
+    console.log('TICK from', source);
+    const [out, err] = await exposition.BuildOutput(this.currentBuild, 'MAIN');
+    if (err) {
+      console.error(err);
       return;
     }
 
-    console.log('TICK from', source);
-    const out = await calc.BuildOutput('MAIN');
     if (!out || !out.Player || !out.Player.MainSkill) {
       return;
     }
@@ -202,7 +202,11 @@ class PoBWorker {
   }
 
   async GetTree(version: string): Promise<string> {
-    const rawData = await exposition.GetRawTree(version);
+    const [rawData, err] = await exposition.GetRawTree(version);
+    if (err) {
+      throw err;
+    }
+
     if (!rawData) {
       throw new Error('Failed loading tree');
     }
@@ -215,7 +219,19 @@ class PoBWorker {
   }
 
   SetAscendancy(value: string) {
-    this.currentBuild?.SetAscendancy(value);
+    if (!this.currentBuild) {
+      return;
+    }
+
+    const [build, err] = exposition.SetAscendancy(this.currentBuild, value);
+    if (err) {
+      console.error(err);
+      return;
+    }
+
+    if (build) {
+      this.currentBuild = build;
+    }
     void this.Tick('SetAscendancy');
   }
 
@@ -235,7 +251,11 @@ class PoBWorker {
   }
 
   CalculateTreePath(version: string, activeNodes: number[], target: number) {
-    return exposition.CalculateTreePath(version, activeNodes, target);
+    const [path, err] = exposition.CalculateTreePath(version, activeNodes, target);
+    if (err) {
+      throw err;
+    }
+    return path;
   }
 
   BuildInfo() {
@@ -1,7 +1,10 @@
 /* eslint-disable */
 export declare namespace builds {
+  function ExportBuild(build?: pob.PathOfBuilding): [string, Error];
   function ParseBuild(rawXML?: Uint8Array): [(pob.PathOfBuilding | undefined), Error];
   function ParseBuildStr(rawXML: string): [(pob.PathOfBuilding | undefined), Error];
+  function SerializeBuild(build?: pob.PathOfBuilding): [(Uint8Array | undefined), Error];
+  function SerializeBuildStr(build?: pob.PathOfBuilding): [string, Error];
 }
 export declare namespace cache {
   function InitializeDiskCache(arg1: (arg1: string) => Promise<(Uint8Array | undefined)>, arg2: (arg1: string, arg2?: Uint8Array) => Promise<void>, arg3: (arg1: string) => Promise<boolean>): Promise<void>;
@@ -9,62 +12,153 @@ export declare namespace cache {
 export declare namespace calculator {
   interface ActiveSkill {
     SkillFlags?: Record<string, boolean>;
-    SkillModList?: calculator.ModList;
-    SkillCfg?: calculator.ListCfg;
+    SkillModList?: moddb.ModList;
+    SkillCfg?: moddb.ListCfg;
     SkillTypes?: Record<string, boolean>;
     SkillData?: Record<string, unknown | undefined>;
     ActiveEffect?: calculator.GemEffect;
-    Weapon1Cfg?: calculator.ListCfg;
-    Weapon2Cfg?: calculator.ListCfg;
+    Weapon1Cfg?: moddb.ListCfg;
+    Weapon2Cfg?: moddb.ListCfg;
     SupportList?: Array<calculator.GemEffect | undefined>;
     Actor?: calculator.Actor;
     SocketGroup?: unknown;
     SummonSkill?: calculator.ActiveSkill;
     ConversionTable?: Record<string, calculator.ConversionTable>;
-    Minion?: unknown;
+    Minion?: calculator.Actor;
+    MinionList?: Array<string>;
     Weapon1Flags: number;
     Weapon2Flags: number;
     EffectList?: Array<calculator.GemEffect | undefined>;
     DisableReason: string;
-    BaseSkillModList?: calculator.ModList;
+    BaseSkillModList?: moddb.ModList;
     SlotName: string;
     MinionSkillTypes?: Record<string, boolean>;
-    BleedCfg?: calculator.ListCfg;
-    OHBleedCfg?: calculator.ListCfg;
+    BleedCfg?: moddb.ListCfg;
+    OHBleedCfg?: moddb.ListCfg;
+    PoisonCfg?: moddb.ListCfg;
+    OHPoisonCfg?: moddb.ListCfg;
+    IgniteCfg?: moddb.ListCfg;
+    OHIgniteCfg?: moddb.ListCfg;
+    BuffList?: Array<calculator.Buff | undefined>;
   }
   interface Actor {
-    ModDB?: calculator.ModDB;
+    ModDB?: moddb.ModDB;
     Level: number;
     Enemy?: calculator.Actor;
-    ItemList?: Record<string, unknown | undefined>;
+    ItemList?: Record<string, pob.Item | undefined>;
     ActiveSkillList?: Array<calculator.ActiveSkill | undefined>;
     Output?: Record<string, number>;
     OutputTable?: Record<string, Record<string, number> | undefined>;
     MainSkill?: calculator.ActiveSkill;
-    Breakdown?: unknown;
-    WeaponData1?: Record<string, unknown | undefined>;
-    WeaponData2?: Record<string, unknown | undefined>;
+    Breakdown?: calculator.Breakdown;
+    WeaponData1?: calculator.WeaponData;
+    WeaponData2?: calculator.WeaponData;
+    ArmourData?: Record<string, calculator.ArmourData | undefined>;
     StrDmgBonus: number;
+    DamageShiftTable?: Record<string, Record<string, number> | undefined>;
+    ReservedLifeBase: number;
+    ReservedLifePercent: number;
+    ReservedManaBase: number;
+    ReservedManaPercent: number;
+    Parent?: calculator.Actor;
+    MinionType: string;
+    MinionData?: data.MinionData;
+    LifeTable?: Array<number>;
+    Uses?: Record<string, boolean>;
+    ItemSet?: pob.ItemSet;
+    GetOutput(stat: string): [number, boolean];
+  }
+  interface ArmourData {
+    Armour: number;
+    Evasion: number;
+    EnergyShield: number;
+    Ward: number;
+    BlockChance: number;
+  }
+  interface Breakdown {
+    Stats?: Record<string, calculator.StatBreakdown | undefined>;
+    MainHand?: calculator.Breakdown;
+    OffHand?: calculator.Breakdown;
+    Set(stat: string, total: number, lines?: Array<string>): (calculator.StatBreakdown | undefined);
+    Stat(stat: string): (calculator.StatBreakdown | undefined);
+  }
+  interface BreakdownMod {
+    Name: string;
+    Type: string;
+    Value: number;
+    Source: string;
+    Flags: number;
+    KeywordFlags: number;
+  }
+  interface Buff {
+    Type: string;
+    Name: string;
+    ActiveSkillBuff: boolean;
+    ApplyNotPlayer: boolean;
+    ApplyMinions: boolean;
+    ApplyAllies: boolean;
+    AllowTotemBuff: boolean;
+    ModList?: moddb.ModList;
+    UnscalableModList?: moddb.ModList;
   }
   interface Calculator {
     PoB?: pob.PathOfBuilding;
-    BuildOutput(mode: string): Promise<(calculator.Environment | undefined)>;
+  }
+  interface ConfigOption {
+    Name: string;
+    Section: string;
+    Label: string;
+    Tooltip: string;
+    Type: string;
+    Default?: unknown;
+    Values?: Array<calculator.ConfigValue>;
+    Apply(val?: unknown, modList?: moddb.ModList, enemyModList?: moddb.ModList): void;
+    WithDefault(val?: unknown): (calculator.ConfigOption | undefined);
+  }
+  interface ConfigSection {
+    Name: string;
+    Options?: Array<calculator.ConfigOption | undefined>;
+  }
+  interface ConfigValue {
+    Value?: unknown;
+    Label: string;
   }
   interface ConversionTable {
     Targets?: Record<string, number>;
     Mult: number;
   }
+  interface DamageRange {
+    Min: number;
+    Max: number;
+  }
+  interface DamageTypeBreakdown {
+    Source: string;
+    BaseMin: number;
+    BaseMax: number;
+    Inc: number;
+    More: number;
+    ConvSrcMin: number;
+    ConvSrcMax: number;
+    TotalMin: number;
+    TotalMax: number;
+    ConvDst: string;
+    ConvDstPercent: number;
+    Mods?: Array<calculator.BreakdownMod>;
+  }
   interface Environment {
+    Cache?: calculator.EnvironmentCache;
     Build?: pob.PathOfBuilding;
     Mode: string;
     Spec?: calculator.PassiveSpec;
-    ModDB?: calculator.ModDB;
-    EnemyModDB?: calculator.ModDB;
-    ItemModDB?: calculator.ModDB;
-    Minion?: calculator.ModDB;
+    ModDB?: moddb.ModDB;
+    EnemyModDB?: moddb.ModDB;
+    ItemModDB?: moddb.ModDB;
     EnemyLevel: number;
+    ConfigInput?: Record<string, unknown | undefined>;
+    ConfigPlaceholder?: Record<string, unknown | undefined>;
     Player?: calculator.Actor;
     Enemy?: calculator.Actor;
+    Minion?: calculator.Actor;
     RequirementsTableItems?: Record<string, unknown | undefined>;
     RequirementsTableGems?: Array<calculator.RequirementsTableGems | undefined>;
     RadiusJewelList?: Record<string, unknown | undefined>;
@@ -82,6 +176,30 @@ export declare namespace calculator {
     KeystonesAdded?: Record<string, unknown | undefined>;
     MainSocketGroup: number;
     DebugErrors?: Array<string>;
+    FullDPS?: calculator.FullDPS;
+  }
+  interface EnvironmentCache {
+  }
+  interface FullDPS {
+    CombinedDPS: number;
+    TotalDotDPS: number;
+    Skills?: Array<calculator.FullDPSSkill>;
+    BleedDPS: number;
+    IgniteDPS: number;
+    BurningGroundDPS: number;
+    TotalPoisonDPS: number;
+    CausticGroundDPS: number;
+    ImpaleDPS: number;
+    DecayDPS: number;
+    DotDPS: number;
+    CullingMulti: number;
+    CullingDPS: number;
+  }
+  interface FullDPSSkill {
+    Name: string;
+    DPS: number;
+    Count: number;
+    Source: string;
   }
   interface GemEffect {
     GrantedEffect?: calculator.GrantedEffect;
@@ -91,6 +209,7 @@ export declare namespace calculator {
     SrcInstance?: pob.Gem;
     GemData?: poe.SkillGem;
     GrantedEffectLevel?: raw.CalculatedLevel;
+    ActorLevel: number;
     Superseded: boolean;
     IsSupporting?: Record<pob.Gem | undefined, boolean>;
     Values?: Record<string, number>;
@@ -105,58 +224,6 @@ export declare namespace calculator {
     DamageEffectiveness(): number;
     WeaponTypes(): (Array<string> | undefined);
   }
-  interface ListCfg {
-    Flags?: number;
-    KeywordFlags?: number;
-    Source?: string;
-    SkillStats?: Record<string, number>;
-    SkillCond?: Record<string, boolean>;
-    SlotName: string;
-  }
-  interface ModDB {
-    ModStore?: calculator.ModStore;
-    Mods?: Record<string, Array<unknown | undefined> | undefined>;
-    AddDB(db?: calculator.ModDB): void;
-    AddList(list?: calculator.ModList): void;
-    AddMod(newMod?: unknown): void;
-    Clone(): (unknown | undefined);
-    EvalMod(arg1?: unknown, arg2?: calculator.ListCfg): (unknown | undefined);
-    Flag(cfg?: calculator.ListCfg, names?: Array<string>): boolean;
-    GetCondition(arg1: string, arg2?: calculator.ListCfg, arg3: boolean): [boolean, boolean];
-    GetMultiplier(arg1: string, arg2?: calculator.ListCfg, arg3: boolean): number;
-    GetStat(arg1: string, arg2?: calculator.ListCfg): number;
-    List(cfg?: calculator.ListCfg, names?: Array<string>): (Array<unknown | undefined> | undefined);
-    More(cfg?: calculator.ListCfg, names?: Array<string>): number;
-    Override(cfg?: calculator.ListCfg, names?: Array<string>): (unknown | undefined);
-    Sum(modType: string, cfg?: calculator.ListCfg, names?: Array<string>): number;
-  }
-  interface ModList {
-    ModStore?: calculator.ModStore;
-    AddDB(db?: calculator.ModList): void;
-    AddMod(newMod?: unknown): void;
-    Clone(): (unknown | undefined);
-    EvalMod(arg1?: unknown, arg2?: calculator.ListCfg): (unknown | undefined);
-    Flag(cfg?: calculator.ListCfg, names?: Array<string>): boolean;
-    GetCondition(arg1: string, arg2?: calculator.ListCfg, arg3: boolean): [boolean, boolean];
-    GetMultiplier(arg1: string, arg2?: calculator.ListCfg, arg3: boolean): number;
-    GetStat(arg1: string, arg2?: calculator.ListCfg): number;
-    List(cfg?: calculator.ListCfg, names?: Array<string>): (Array<unknown | undefined> | undefined);
-    More(cfg?: calculator.ListCfg, names?: Array<string>): number;
-    Override(cfg?: calculator.ListCfg, names?: Array<string>): (unknown | undefined);
-    Sum(modType: string, cfg?: calculator.ListCfg, names?: Array<string>): number;
-  }
-  interface ModStore {
-    Parent?: unknown;
-    Child?: unknown;
-    Actor?: calculator.Actor;
-    Multipliers?: Record<string, number>;
-    Conditions?: Record<string, boolean>;
-    Clone(): (calculator.ModStore | undefined);
-    EvalMod(m?: unknown, cfg?: calculator.ListCfg): (unknown | undefined);
-    GetCondition(variable: string, cfg?: calculator.ListCfg, noMod: boolean): [boolean, boolean];
-    GetMultiplier(variable: string, cfg?: calculator.ListCfg, noMod: boolean): number;
-    GetStat(stat: string, cfg?: calculator.ListCfg): number;
-  }
   interface PassiveSpec {
     Build?: pob.PathOfBuilding;
     TreeVersion: string;
@@ -166,14 +233,16 @@ export declare namespace calculator {
     AllocExtendedNodes?: Record<string, unknown | undefined>;
     Jewels?: Record<string, unknown | undefined>;
     SubGraphs?: Record<string, unknown | undefined>;
-    MasterySelections?: Record<string, unknown | undefined>;
+    MasterySelections?: Record<string, number>;
     ClassName: string;
     AscendancyName: string;
     AllocatedNotableCount: number;
     AllocatedMasteryCount: number;
+    BuildAllDependsAndPaths(): void;
     Class(): data.Class;
     SelectAscendancyClass(ascendancyName: string): void;
     SelectClass(className: string): void;
+    SelectMasteryEffect(nodeID: number, effectID: number): Error;
     Tree(): (data.Tree | undefined);
   }
   interface RequirementsTableGems {
@@ -183,6 +252,33 @@ export declare namespace calculator {
     Dex: number;
     Int: number;
   }
+  interface StatBreakdown {
+    Base: number;
+    Inc: number;
+    More: number;
+    Total: number;
+    Mods?: Array<calculator.BreakdownMod>;
+    DamageTypes?: Array<calculator.DamageTypeBreakdown | undefined>;
+    Lines?: Array<string>;
+  }
+  interface WeaponData {
+    Type: string;
+    Name: string;
+    AttackRate: number;
+    AttackSpeedInc: number;
+    CritChance: number;
+    Range: number;
+    RangeBonus: number;
+    Damage?: Record<string, calculator.DamageRange>;
+    CountsAsDualWielding: boolean;
+    CountsAsAll1H: boolean;
+    Clone(): (calculator.WeaponData | undefined);
+    Info(): (data.WeaponTypeInfo | undefined);
+    Source(): (Record<string, unknown | undefined> | undefined);
+  }
+  function ConfigOptions(): (Array<calculator.ConfigOption | undefined> | undefined);
+  function ConfigSections(): (Array<calculator.ConfigSection | undefined> | undefined);
+  function GetConfigOption(name: string): (calculator.ConfigOption | undefined);
   function NewCalculator(build: pob.PathOfBuilding): (calculator.Calculator | undefined);
 }
 export declare namespace config {
@@ -259,6 +355,27 @@ export declare namespace data {
     Stats?: Array<string>;
     ReminderText?: Array<string>;
   }
+  interface MinionData {
+    Variety: string;
+    Name: string;
+    Life: number;
+    EnergyShield: number;
+    Armour: number;
+    FireResist: number;
+    ColdResist: number;
+    LightningResist: number;
+    ChaosResist: number;
+    Damage: number;
+    DamageSpread: number;
+    DamageFixup: number;
+    AttackTime: number;
+    AttackRange: number;
+    Accuracy: number;
+    WeaponType: string;
+    Limit: string;
+    SkillList?: Array<string>;
+    ModList?: Array<unknown | undefined>;
+  }
   interface Node {
     Skill?: number;
     Name?: string;
@@ -340,6 +457,16 @@ export declare namespace data {
     Sprites: data.Sprites;
     ImageZoomLevels?: Array<number>;
     Points: data.Points;
+    AscendancyStartNode(ascendancyName: string): (data.Node | undefined);
+    ClassStartNode(classID: number): (data.Node | undefined);
+    MasteryEffect(nodeID: number, effectID: number): [(data.MasteryEffect | undefined), Error];
+  }
+  interface WeaponTypeInfo {
+    OneHand: boolean;
+    Melee: boolean;
+    Flag: string;
+    Label: string;
+    ModFlag: number;
   }
 }
 export declare namespace debug {
@@ -376,10 +503,14 @@ export declare namespace exposition {
     Support: boolean;
     CalculateStuff(): void;
   }
-  function CalculateTreePath(version: string, activeNodes?: Array<number>, target: number): (Array<number> | undefined);
-  function GetRawTree(version: string): Promise<(Uint8Array | undefined)>;
+  function BuildOutput(build: pob.PathOfBuilding, mode: string): Promise<[(calculator.Environment | undefined), Error]>;
+  function CalculatePrunableNodes(version: string, activeNodes?: Array<number>, classStartNode: number, target: number): [(Array<number> | undefined), Error];
+  function CalculateTreePath(version: string, activeNodes?: Array<number>, target: number): [(Array<number> | undefined), Error];
+  function GetRawTree(version: string): Promise<[(Uint8Array | undefined), Error]>;
   function GetSkillGems(): (Array<exposition.SkillGem> | undefined);
   function GetStatByIndex(id: number): (poe.Stat | undefined);
+  function SetAscendancy(build: pob.PathOfBuilding, ascendancy: string): [(pob.PathOfBuilding | undefined), Error];
+  function SetMasteryEffect(build: pob.PathOfBuilding, nodeID: number, effectID: number): [(pob.PathOfBuilding | undefined), Error];
 }
 export declare namespace fwd {
   interface Reader {
@@ -397,6 +528,105 @@ export declare namespace fwd {
     WriteTo(w?: unknown): [number, Error];
   }
 }
+export declare namespace moddb {
+  interface ListCfg {
+    Flags?: number;
+    KeywordFlags?: number;
+    Source?: string;
+    SkillStats?: Record<string, number>;
+    SkillCond?: Record<string, boolean>;
+    SkillID: string;
+    SlotName: string;
+  }
+  interface ModDB {
+    ModStore?: moddb.ModStore;
+    Mods?: Record<string, Array<unknown | undefined> | undefined>;
+    AddDB(db?: moddb.ModDB): void;
+    AddList(list?: moddb.ModList): void;
+    AddMod(newMod?: unknown): void;
+    Clone(): (unknown | undefined);
+    Flag(cfg?: moddb.ListCfg, names?: Array<string>): boolean;
+    GetCondition(arg1: string, arg2?: moddb.ListCfg, arg3: boolean): [boolean, boolean];
+    GetMultiplier(arg1: string, arg2?: moddb.ListCfg, arg3: boolean): number;
+    List(cfg?: moddb.ListCfg, names?: Array<string>): (Array<unknown | undefined> | undefined);
+    Max(cfg?: moddb.ListCfg, names?: Array<string>): [number, boolean];
+    More(cfg?: moddb.ListCfg, names?: Array<string>): number;
+    Override(cfg?: moddb.ListCfg, names?: Array<string>): (unknown | undefined);
+    ScaleAddList(list?: moddb.ModList, scale: number): void;
+    StartTrace(): (moddb.Trace | undefined);
+    StopTrace(): void;
+    Sum(modType: string, cfg?: moddb.ListCfg, names?: Array<string>): number;
+    Tabulate(modType: string, cfg?: moddb.ListCfg, names?: Array<string>): (Array<moddb.TabulatedMod> | undefined);
+  }
+  interface ModList {
+    ModStore?: moddb.ModStore;
+    AddDB(db?: moddb.ModList): void;
+    AddMod(newMod?: unknown): void;
+    Clone(): (unknown | undefined);
+    Flag(cfg?: moddb.ListCfg, names?: Array<string>): boolean;
+    GetCondition(arg1: string, arg2?: moddb.ListCfg, arg3: boolean): [boolean, boolean];
+    GetMultiplier(arg1: string, arg2?: moddb.ListCfg, arg3: boolean): number;
+    List(cfg?: moddb.ListCfg, names?: Array<string>): (Array<unknown | undefined> | undefined);
+    Max(cfg?: moddb.ListCfg, names?: Array<string>): [number, boolean];
+    Mods(): (Array<unknown | undefined> | undefined);
+    More(cfg?: moddb.ListCfg, names?: Array<string>): number;
+    Override(cfg?: moddb.ListCfg, names?: Array<string>): (unknown | undefined);
+    RemoveMods(filter: (arg1?: unknown) => Promise<boolean>): Promise<(Array<unknown | undefined> | undefined)>;
+    ScaleAddList(list?: moddb.ModList, scale: number): void;
+    ScaleAddMod(newMod?: unknown, scale: number): void;
+    StartTrace(): (moddb.Trace | undefined);
+    StopTrace(): void;
+    Sum(modType: string, cfg?: moddb.ListCfg, names?: Array<string>): number;
+    Tabulate(modType: string, cfg?: moddb.ListCfg, names?: Array<string>): (Array<moddb.TabulatedMod> | undefined);
+  }
+  interface ModStore {
+    Parent?: unknown;
+    Child?: unknown;
+    Actor?: unknown;
+    Multipliers?: Record<string, number>;
+    Conditions?: Record<string, boolean>;
+    SkillID: string;
+    Clone(): (moddb.ModStore | undefined);
+    GetCondition(variable: string, cfg?: moddb.ListCfg, noMod: boolean): [boolean, boolean];
+    GetMultiplier(variable: string, cfg?: moddb.ListCfg, noMod: boolean): number;
+    StartTrace(): (moddb.Trace | undefined);
+    StopTrace(): void;
+  }
+  interface TabulatedMod {
+    Value?: unknown;
+    Mod?: unknown;
+  }
+  interface Trace {
+    Queries?: Array<moddb.TraceQuery | undefined>;
+    String(): string;
+  }
+  interface TraceQuery {
+    Func: string;
+    ModType: string;
+    Names?: Array<string>;
+    Cfg?: moddb.ListCfg;
+    Depth: number;
+    Result?: unknown;
+    Mods?: Array<moddb.TracedMod | undefined>;
+    Nested?: Array<moddb.TraceQuery | undefined>;
+  }
+  interface TracedMod {
+    Name: string;
+    Type: string;
+    Source: string;
+    Flags: number;
+    KeywordFlags: number;
+    Value?: unknown;
+    Result?: unknown;
+    Depth: number;
+    Tags?: Array<moddb.TracedTag>;
+  }
+  interface TracedTag {
+    Type: string;
+    Tag?: unknown;
+    Value?: unknown;
+  }
+}
 export declare namespace msgp {
   interface Reader {
     R?: fwd.Reader;
@@ -496,14 +726,19 @@ export declare namespace pob {
     PassiveNodes?: Array<number>;
     PassiveNodesStartPaths?: Record<number, Array<number> | undefined>;
     PlayerStats: Array<pob.PlayerStat>;
+    UnknownAttrs?: Array<xml.Attr>;
+    UnknownElements?: Array<pob.UnknownElement>;
   }
   interface Calcs {
     Inputs: Array<pob.Input>;
     Sections: Array<pob.Section>;
+    UnknownElements?: Array<pob.UnknownElement>;
   }
   interface Config {
     Inputs: Array<pob.Input>;
     Placeholders: Array<pob.Input>;
+    UnknownAttrs?: Array<xml.Attr>;
+    UnknownElements?: Array<pob.UnknownElement>;
   }
   interface Gem {
     Quality: number;
@@ -520,22 +755,131 @@ export declare namespace pob {
     SkillID: string;
     SkillMinionItemSet: number;
     SkillMinion: string;
+    SkillMinionItemSetCalcs: number;
+    SkillMinionCalcs: string;
+    SkillMinionSkill: number;
+    SkillMinionSkillCalcs: number;
+    UnknownAttrs?: Array<xml.Attr>;
   }
   interface Input {
     Name: string;
     Boolean?: boolean;
     Number?: number;
     String?: string;
+    UnknownAttrs?: Array<xml.Attr>;
+  }
+  interface Item {
+    ID: number;
+    Variant?: number;
+    VariantAlt?: number;
+    Raw: string;
+    ModRanges: Array<pob.ModRange>;
+    UnknownAttrs?: Array<xml.Attr>;
+    UnknownElements?: Array<pob.UnknownElement>;
+    Rarity: string;
+    Title: string;
+    BaseName: string;
+    UniqueID: string;
+    League: string;
+    ItemLevel: number;
+    LevelReq: number;
+    Quality: number;
+    Armour: number;
+    Evasion: number;
+    EnergyShield: number;
+    Ward: number;
+    Radius: string;
+    LimitedTo: number;
+    Sockets: Array<pob.Socket>;
+    Influences: Array<string>;
+    Corrupted: boolean;
+    Mirrored: boolean;
+    Split: boolean;
+    Synthesised: boolean;
+    Fractured: boolean;
+    Crafted: boolean;
+    Variants: Array<string>;
+    SelectedVariant: number;
+    HasAltVariant: boolean;
+    SelectedAlt: number;
+    Prefixes: Array<pob.ItemAffix>;
+    Suffixes: Array<pob.ItemAffix>;
+    EnchantModLines: Array<pob.ItemModLine>;
+    ImplicitModLines: Array<pob.ItemModLine>;
+    ExplicitModLines: Array<pob.ItemModLine>;
+    ArmourBasePercentile?: number;
+    EvasionBasePercentile?: number;
+    EnergyShieldBasePercentile?: number;
+    WardBasePercentile?: number;
+    AbyssalSocketCount(): number;
+    ActiveModLines(): (Array<pob.ItemModLine> | undefined);
+    ArmourType(): (poe.ArmourType | undefined);
+    BaseType(): (poe.BaseItemType | undefined);
+    HasVariant(line: pob.ItemModLine): boolean;
+    ItemClass(): (poe.ItemClass | undefined);
+    Links(): number;
+    MarshalXML(e?: xml.Encoder, start: xml.StartElement): Error;
+    Parse(): Error;
+    ShieldType(): (poe.ShieldType | undefined);
+    Type(): string;
+    WeaponType(): (poe.WeaponType | undefined);
+  }
+  interface ItemAffix {
+    ModID: string;
+    Range: number;
+  }
+  interface ItemModLine {
+    Line: string;
+    Range: number;
+    Variants: Array<number>;
+    Tags: Array<string>;
+    Crafted: boolean;
+    Fractured: boolean;
+    Custom: boolean;
+    Scourge: boolean;
+    Crucible: boolean;
+    Synthesis: boolean;
+    Mutated: boolean;
+    Enchant: boolean;
+    Implicit: boolean;
+    Value(): string;
   }
   interface ItemSet {
     ID: string;
     UseSecondWeaponSet?: boolean;
-    Slots?: Array<pob.Slot>;
+    Slots: Array<pob.Slot>;
+    UnknownAttrs?: Array<xml.Attr>;
+    UnknownElements?: Array<pob.UnknownElement>;
   }
   interface Items {
     ActiveItemSet: number;
     UseSecondWeaponSet?: boolean;
+    Items: Array<pob.Item>;
+    Slots: Array<pob.Slot>;
     ItemSets: Array<pob.ItemSet>;
+    UnknownAttrs?: Array<xml.Attr>;
+    UnknownElements?: Array<pob.UnknownElement>;
+    ActiveSet(): (pob.ItemSet | undefined);
+    ItemByID(id: number): (pob.Item | undefined);
+    ParseItems(): Error;
+    SetOrFirst(id: number): (pob.ItemSet | undefined);
+    SetSlotItem(set?: pob.ItemSet, name: string): (pob.Item | undefined);
+    SlotItem(slot: pob.Slot): (pob.Item | undefined);
+  }
+  interface JewelSocket {
+    ItemID: number;
+    NodeID: number;
+  }
+  interface JewelSockets {
+    Sockets: Array<pob.JewelSocket>;
+  }
+  interface MasterySelection {
+    NodeID: number;
+    EffectID: number;
+  }
+  interface ModRange {
+    ID: number;
+    Range: number;
   }
   interface PathOfBuilding {
     Build: pob.Build;
@@ -546,14 +890,21 @@ export declare namespace pob {
     Skills: pob.Skills;
     TreeView: pob.TreeView;
     Config: pob.Config;
+    UnknownElements?: Array<pob.UnknownElement>;
+    ActiveSpec(): (pob.Spec | undefined);
     AddNewSocketGroup(): void;
+    AddSpec(title: string): number;
     AllocateNodes(nodeIds?: Array<number>): void;
+    CopySpec(index: number): [number, Error];
     DeallocateNodes(nodeId: number): void;
     DeleteAllSocketGroups(): void;
     DeleteSocketGroup(index: number): void;
+    DeleteSpec(index: number): Error;
     GetStringOption(name: string): string;
+    ListSpecs(): (Array<pob.Spec> | undefined);
     RemoveConfigOption(name: string): void;
-    SetAscendancy(ascendancy: string): void;
+    RemoveMasteryEffect(nodeID: number): void;
+    SetActiveSpec(index: number): Error;
     SetClass(clazz: string): void;
     SetConfigOption(value: pob.Input): void;
     SetDefaultGemLevel(gemLevel: number): void;
@@ -572,11 +923,13 @@ export declare namespace pob {
   interface PlayerStat {
     Value: number;
     Stat: string;
+    UnknownAttrs?: Array<xml.Attr>;
   }
   interface Section {
     Collapsed: boolean;
     ID: string;
     Subsection: string;
+    UnknownAttrs?: Array<xml.Attr>;
   }
   interface Skill {
     MainActiveSkillCalcs: number;
@@ -584,10 +937,12 @@ export declare namespace pob {
     Label: string;
     Enabled: boolean;
     IncludeInFullDPS?: boolean;
-    Gems: Array<pob.Gem>;
     Slot: string;
+    Source: string;
+    Gems: Array<pob.Gem>;
+    UnknownAttrs?: Array<xml.Attr>;
+    UnknownElements?: Array<pob.UnknownElement>;
     SlotEnabled: boolean;
-    Source?: unknown;
     DisplayLabel: string;
     DisplaySkillList?: unknown;
     DisplaySkillListCalcs?: unknown;
@@ -595,6 +950,7 @@ export declare namespace pob {
   interface SkillSet {
     ID: number;
     Skills: Array<pob.Skill>;
+    UnknownAttrs?: Array<xml.Attr>;
   }
   interface Skills {
     SortGemsByDPSField: string;
@@ -606,22 +962,38 @@ export declare namespace pob {
     ActiveSkillSet: number;
     SortGemsByDPS: boolean;
     SkillSets: Array<pob.SkillSet>;
+    UnknownAttrs?: Array<xml.Attr>;
+    UnknownElements?: Array<pob.UnknownElement>;
   }
   interface Slot {
     ItemID: number;
     Name: string;
+    Active: boolean;
+    UnknownAttrs?: Array<xml.Attr>;
+  }
+  interface Socket {
+    Color: string;
+    Group: number;
   }
   interface Spec {
+    Title: string;
     ClassID: number;
     AscendClassID: number;
     TreeVersion: string;
     NodesAttr: string;
     MasteryEffects: string;
+    Nodes?: Array<number>;
+    MasterySelections?: Array<pob.MasterySelection>;
     URL: string;
+    Sockets: pob.JewelSockets;
+    UnknownAttrs?: Array<xml.Attr>;
+    UnknownElements?: Array<pob.UnknownElement>;
   }
   interface Tree {
     ActiveSpec: number;
     Specs: Array<pob.Spec>;
+    UnknownAttrs?: Array<xml.Attr>;
+    UnknownElements?: Array<pob.UnknownElement>;
   }
   interface TreeView {
     ZoomLevel: number;
@@ -630,6 +1002,12 @@ export declare namespace pob {
     SearchStr: string;
     ShowHeatMap?: boolean;
     ShowStatDifferences: boolean;
+    UnknownAttrs?: Array<xml.Attr>;
+  }
+  interface UnknownElement {
+    XMLName: xml.Name;
+    Attrs?: Array<xml.Attr>;
+    InnerXML: string;
   }
   const BuildInfo: debug.BuildInfo | undefined;
   function CompressEncode(xml: string): [string, Error];
@@ -655,6 +1033,14 @@ export declare namespace poe {
     Msgsize(): number;
     UnmarshalMsg(arg1?: Uint8Array): [(Uint8Array | undefined), Error];
   }
+  interface ArmourType {
+    ArmourType: raw.ArmourType;
+    DecodeMsg(arg1?: msgp.Reader): Error;
+    EncodeMsg(arg1?: msgp.Writer): Error;
+    MarshalMsg(arg1?: Uint8Array): [(Uint8Array | undefined), Error];
+    Msgsize(): number;
+    UnmarshalMsg(arg1?: Uint8Array): [(Uint8Array | undefined), Error];
+  }
   interface BaseItemType {
     BaseItemType: raw.BaseItemType;
     DecodeMsg(arg1?: msgp.Reader): Error;
@@ -737,6 +1123,14 @@ export declare namespace poe {
     Msgsize(): number;
     UnmarshalMsg(arg1?: Uint8Array): [(Uint8Array | undefined), Error];
   }
+  interface ShieldType {
+    ShieldType: raw.ShieldType;
+    DecodeMsg(arg1?: msgp.Reader): Error;
+    EncodeMsg(arg1?: msgp.Writer): Error;
+    MarshalMsg(arg1?: Uint8Array): [(Uint8Array | undefined), Error];
+    Msgsize(): number;
+    UnmarshalMsg(arg1?: Uint8Array): [(Uint8Array | undefined), Error];
+  }
   interface SkillGem {
     SkillGem: raw.SkillGem;
     DecodeMsg(arg1?: msgp.Reader): Error;
@@ -768,6 +1162,14 @@ export declare namespace poe {
     Msgsize(): number;
     UnmarshalMsg(arg1?: Uint8Array): [(Uint8Array | undefined), Error];
   }
+  interface WeaponType {
+    WeaponType: raw.WeaponType;
+    DecodeMsg(arg1?: msgp.Reader): Error;
+    EncodeMsg(arg1?: msgp.Writer): Error;
+    MarshalMsg(arg1?: Uint8Array): [(Uint8Array | undefined), Error];
+    Msgsize(): number;
+    UnmarshalMsg(arg1?: Uint8Array): [(Uint8Array | undefined), Error];
+  }
 }
 export declare namespace raw {
   interface ActiveSkill {
@@ -805,6 +1207,24 @@ export declare namespace raw {
     Msgsize(): number;
     UnmarshalMsg(bts?: Uint8Array): [(Uint8Array | undefined), Error];
   }
+  interface ArmourType {
+    ArmourMax: number;
+    ArmourMin: number;
+    BaseItemTypesKey: number;
+    EnergyShieldMax: number;
+    EnergyShieldMin: number;
+    EvasionMax: number;
+    EvasionMin: number;
+    IncreasedMovementSpeed: number;
+    WardMax: number;
+    WardMin: number;
+    Key: number;
+    DecodeMsg(dc?: msgp.Reader): Error;
+    EncodeMsg(en?: msgp.Writer): Error;
+    MarshalMsg(b?: Uint8Array): [(Uint8Array | undefined), Error];
+    Msgsize(): number;
+    UnmarshalMsg(bts?: Uint8Array): [(Uint8Array | undefined), Error];
+  }
   interface BaseItemType {
     SoundEffect?: number;
     EquipAchievementItemsKey?: number;
@@ -996,6 +1416,16 @@ export declare namespace raw {
     Msgsize(): number;
     UnmarshalMsg(bts?: Uint8Array): [(Uint8Array | undefined), Error];
   }
+  interface ShieldType {
+    BaseItemTypesKey: number;
+    Block: number;
+    Key: number;
+    DecodeMsg(dc?: msgp.Reader): Error;
+    EncodeMsg(en?: msgp.Writer): Error;
+    MarshalMsg(b?: Uint8Array): [(Uint8Array | undefined), Error];
+    Msgsize(): number;
+    UnmarshalMsg(bts?: Uint8Array): [(Uint8Array | undefined), Error];
+  }
   interface SkillGem {
     VaalGem?: number;
     RegularVariant?: number;
@@ -1051,6 +1481,21 @@ export declare namespace raw {
     Msgsize(): number;
     UnmarshalMsg(bts?: Uint8Array): [(Uint8Array | undefined), Error];
   }
+  interface WeaponType {
+    BaseItemTypesKey: number;
+    Critical: number;
+    DamageMax: number;
+    DamageMin: number;
+    Null6: number;
+    RangeMax: number;
+    Speed: number;
+    Key: number;
+    DecodeMsg(dc?: msgp.Reader): Error;
+    EncodeMsg(en?: msgp.Writer): Error;
+    MarshalMsg(b?: Uint8Array): [(Uint8Array | undefined), Error];
+    Msgsize(): number;
+    UnmarshalMsg(bts?: Uint8Array): [(Uint8Array | undefined), Error];
+  }
   function InitializeAll(version: string, updateFunc: (arg1: string) => Promise<void>): Promise<Error>;
 }
 export declare namespace time {
@@ -1061,7 +1506,9 @@ export declare namespace time {
     Add(d: number): time.Time;
     AddDate(years: number, months: number, days: number): time.Time;
     After(u: time.Time): boolean;
+    AppendBinary(b?: Uint8Array): [(Uint8Array | undefined), Error];
     AppendFormat(b?: Uint8Array, layout: string): (Uint8Array | undefined);
+    AppendText(b?: Uint8Array): [(Uint8Array | undefined), Error];
     Before(u: time.Time): boolean;
     Clock(): [number, number, number];
     Compare(u: time.Time): number;
@@ -1105,4 +1552,31 @@ export declare namespace time {
     ZoneBounds(): [time.Time, time.Time];
   }
 }
+export declare namespace xml {
+  interface Attr {
+    Name: xml.Name;
+    Value: string;
+  }
+  interface Encoder {
+    Close(): Error;
+    Encode(v?: unknown): Error;
+    EncodeElement(v?: unknown, start: xml.StartElement): Error;
+    EncodeToken(t?: unknown): Error;
+    Flush(): Error;
+    Indent(prefix: string, indent: string): void;
+  }
+  interface EndElement {
+    Name: xml.Name;
+  }
+  interface Name {
+    Space: string;
+    Local: string;
+  }
+  interface StartElement {
+    Name: xml.Name;
+    Attr?: Array<xml.Attr>;
+    Copy(): xml.StartElement;
+    End(): xml.EndElement;
+  }
+}
 export const initializeCrystalline: () => void;
\ No newline at end of file
@@ -10,23 +10,33 @@ export let raw;
 
 export const initializeCrystalline = () => {
   builds = {
+    ExportBuild: globalThis['go']['go-pob']['builds']['ExportBuild'],
     ParseBuild: globalThis['go']['go-pob']['builds']['ParseBuild'],
-    ParseBuildStr: globalThis['go']['go-pob']['builds']['ParseBuildStr']
+    ParseBuildStr: globalThis['go']['go-pob']['builds']['ParseBuildStr'],
+    SerializeBuild: globalThis['go']['go-pob']['builds']['SerializeBuild'],
+    SerializeBuildStr: globalThis['go']['go-pob']['builds']['SerializeBuildStr']
   };
   cache = {
     InitializeDiskCache: globalThis['go']['go-pob']['cache']['InitializeDiskCache']
   };
   calculator = {
+    ConfigOptions: globalThis['go']['go-pob']['calculator']['ConfigOptions'],
+    ConfigSections: globalThis['go']['go-pob']['calculator']['ConfigSections'],
+    GetConfigOption: globalThis['go']['go-pob']['calculator']['GetConfigOption'],
     NewCalculator: globalThis['go']['go-pob']['calculator']['NewCalculator']
   };
   config = {
     InitLogging: globalThis['go']['go-pob']['config']['InitLogging']
   };
   exposition = {
+    BuildOutput: globalThis['go']['go-pob']['exposition']['BuildOutput'],
+    CalculatePrunableNodes: globalThis['go']['go-pob']['exposition']['CalculatePrunableNodes'],
     CalculateTreePath: globalThis['go']['go-pob']['exposition']['CalculateTreePath'],
     GetRawTree: globalThis['go']['go-pob']['exposition']['GetRawTree'],
     GetSkillGems: globalThis['go']['go-pob']['exposition']['GetSkillGems'],
-    GetStatByIndex: globalThis['go']['go-pob']['exposition']['GetStatByIndex']
+    GetStatByIndex: globalThis['go']['go-pob']['exposition']['GetStatByIndex'],
+    SetAscendancy: globalThis['go']['go-pob']['exposition']['SetAscendancy'],
+    SetMasteryEffect: globalThis['go']['go-pob']['exposition']['SetMasteryEffect']
   };
   pob = {
     BuildInfo: globalThis['go']['go-pob']['pob']['BuildInfo'],
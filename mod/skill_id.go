@@ -29,9 +29,12 @@ func (t SkillIDTag) Type() Type {
 	return t.TagType
 }
 
+// ID returns the granted effect ID of the skill, resolving skills referenced by name
 func (t SkillIDTag) ID() string {
 	if t.IDTag == "" {
-		return poe.ActiveSkillsByDisplayName[t.Name].SkillID
+		if effect, ok := poe.GrantedEffectByActiveSkillName[t.Name]; ok {
+			return effect.ID
+		}
 	}
 	return t.IDTag
 }
@@ -51,6 +51,8 @@ func (m *ModDB) AddDB(db *ModDB) {
 }
 
 func (m *ModDB) List(cfg *ListCfg, names ...string) []interface{} {
+	query := m.traceQuery("List", mod.TypeList, cfg, names)
+
 	result := make([]interface{}, 0)
 
 	for _, name := range names {
@@ -69,13 +71,17 @@ func (m *ModDB) List(cfg *ListCfg, names ...string) []interface{} {
 	}
 
 	if m.Parent != nil {
+		m.traceParent()
 		result = append(result, m.Parent.List(cfg, names...)...)
 	}
 
+	m.traceEnd(query, result)
 	return result
 }
 
 func (m *ModDB) Sum(modType mod.Type, cfg *ListCfg, names ...string) float64 {
+	query := m.traceQuery("Sum", modType, cfg, names)
+
 	result := float64(0)
 
 	for _, name := range names {
@@ -94,13 +100,17 @@ func (m *ModDB) Sum(modType mod.Type, cfg *ListCfg, names ...string) float64 {
 	}
 
 	if m.Parent != nil {
+		m.traceParent()
 		result += m.Parent.Sum(modType, cfg, names...)
 	}
 
+	m.traceEnd(query, result)
 	return result
 }
 
 func (m *ModDB) Flag(cfg *ListCfg, names ...string) bool {
+	query := m.traceQuery("Flag", mod.TypeFlag, cfg, names)
+
 	for _, name := range names {
 		for _, mo := range m.Mods[name] {
 			if mo.Type() == mod.TypeFlag &&
@@ -110,6 +120,7 @@ func (m *ModDB) Flag(cfg *ListCfg, names ...string) bool {
 
 				value := m.evalMod(mo, cfg)
 				if value != nil && value.(bool) {
+					m.traceEnd(query, true)
 					return true
 				}
 			}
@@ -117,15 +128,20 @@ func (m *ModDB) Flag(cfg *ListCfg, names ...string) bool {
 	}
 
 	if m.Parent != nil {
+		m.traceParent()
 		if m.Parent.Flag(cfg, names...) {
+			m.traceEnd(query, true)
 			return true
 		}
 	}
 
+	m.traceEnd(query, false)
 	return false
 }
 
 func (m *ModDB) More(cfg *ListCfg, names ...string) float64 {
+	query := m.traceQuery("More", mod.TypeMore, cfg, names)
+
 	result := float64(1)
 
 	for _, name := range names {
@@ -144,13 +160,17 @@ func (m *ModDB) More(cfg *ListCfg, names ...string) float64 {
 	}
 
 	if m.Parent != nil {
+		m.traceParent()
 		result *= m.Parent.More(cfg, names...)
 	}
 
+	m.traceEnd(query, result)
 	return result
 }
 
 func (m *ModDB) Override(cfg *ListCfg, names ...string) interface{} {
+	query := m.traceQuery("Override", mod.TypeOverride, cfg, names)
+
 	mappedNames := make(map[string]bool, 0)
 	for _, name := range names {
 		mappedNames[name] = true
@@ -165,6 +185,7 @@ func (m *ModDB) Override(cfg *ListCfg, names ...string) interface{} {
 
 				value := m.evalMod(mo, cfg)
 				if value != nil {
+					m.traceEnd(query, value)
 					return value
 				}
 			}
@@ -172,17 +193,100 @@ func (m *ModDB) Override(cfg *ListCfg, names ...string) interface{} {
 	}
 
 	if m.Parent != nil {
+		m.traceParent()
 		p := m.Parent.Override(cfg, names...)
 		if p != nil {
+			m.traceEnd(query, p)
 			return p
 		}
 	}
 
+	m.traceEnd(query, nil)
 	return nil
 }
 
+// Max returns the highest value of the MAX mods matching the query, false if there are none
+func (m *ModDB) Max(cfg *ListCfg, names ...string) (float64, bool) {
+	query := m.traceQuery("Max", mod.TypeMAX, cfg, names)
+
+	result := float64(0)
+	found := false
+
+	for _, name := range names {
+		for _, mo := range m.Mods[name] {
+			if mo.Type() == mod.TypeMAX &&
+				(cfg == nil || cfg.Flags == nil || (*cfg.Flags)&mo.Flags() == mo.Flags()) &&
+				(cfg == nil || cfg.KeywordFlags == nil || mod.MatchKeywordFlags(*cfg.KeywordFlags, mo.KeywordFlags())) &&
+				(cfg == nil || cfg.Source == nil || *cfg.Source == mo.GetSource()) {
+
+				value := m.evalMod(mo, cfg)
+				if value != nil && (!found || value.(float64) > result) {
+					result = value.(float64)
+					found = true
+				}
+			}
+		}
+	}
+
+	if m.Parent != nil {
+		m.traceParent()
+		if p, ok := m.Parent.Max(cfg, names...); ok && (!found || p > result) {
+			result = p
+			found = true
+		}
+	}
+
+	if found {
+		m.traceEnd(query, result)
+	} else {
+		m.traceEnd(query, nil)
+	}
+	return result, found
+}
+
+// Tabulate returns every mod contributing to the query, an empty modType matches mods of all types
+func (m *ModDB) Tabulate(modType mod.Type, cfg *ListCfg, names ...string) []TabulatedMod {
+	query := m.traceQuery("Tabulate", modType, cfg, names)
+
+	result := make([]TabulatedMod, 0)
+
+	for _, name := range names {
+		for _, mo := range m.Mods[name] {
+			if (modType == "" || mo.Type() == modType) &&
+				(cfg == nil || cfg.Flags == nil || (*cfg.Flags)&mo.Flags() == mo.Flags()) &&
+				(cfg == nil || cfg.KeywordFlags == nil || mod.MatchKeywordFlags(*cfg.KeywordFlags, mo.KeywordFlags())) &&
+				(cfg == nil || cfg.Source == nil || *cfg.Source == mo.GetSource()) {
+
+				value := m.evalMod(mo, cfg)
+				if includeTabulated(value) {
+					result = append(result, TabulatedMod{Value: value, Mod: mo})
+				}
+			}
+		}
+	}
+
+	if m.Parent != nil {
+		m.traceParent()
+		result = append(result, m.Parent.Tabulate(modType, cfg, names...)...)
+	}
+
+	m.traceEnd(query, result)
+	return result
+}
+
 func (m *ModDB) AddList(list *ModList) {
 	for _, newMod := range list.mods {
 		m.AddMod(newMod)
 	}
 }
+
+// ScaleAddList adds every mod of the list with its value multiplied by scale
+func (m *ModDB) ScaleAddList(list *ModList, scale float64) {
+	if list == nil {
+		return
+	}
+
+	for _, newMod := range list.mods {
+		m.AddMod(scaleMod(newMod, scale))
+	}
+}
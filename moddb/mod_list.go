@@ -47,7 +47,46 @@ func (m *ModList) AddDB(db *ModList) {
 	m.mods = append(m.mods, newMods...)
 }
 
+// ScaleAddMod adds the mod with its value multiplied by scale
+func (m *ModList) ScaleAddMod(newMod mod.Mod, scale float64) {
+	m.AddMod(scaleMod(newMod, scale))
+}
+
+// ScaleAddList adds every mod of the list with its value multiplied by scale
+func (m *ModList) ScaleAddList(list *ModList, scale float64) {
+	if list == nil {
+		return
+	}
+
+	for _, newMod := range list.mods {
+		m.ScaleAddMod(newMod, scale)
+	}
+}
+
+// Mods returns the mods stored directly in this list
+func (m *ModList) Mods() []mod.Mod {
+	return m.mods
+}
+
+// RemoveMods removes every mod matching the filter and returns the removed mods
+func (m *ModList) RemoveMods(filter func(mod.Mod) bool) []mod.Mod {
+	removed := make([]mod.Mod, 0)
+	kept := m.mods[:0]
+	for _, mo := range m.mods {
+		if filter(mo) {
+			removed = append(removed, mo)
+		} else {
+			kept = append(kept, mo)
+		}
+	}
+	m.mods = kept
+	return removed
+}
+
 func (m *ModList) List(cfg *ListCfg, names ...string) []interface{} {
+	cfg = m.skillCfg(cfg)
+	query := m.traceQuery("List", mod.TypeList, cfg, names)
+
 	result := make([]interface{}, 0)
 
 	mappedNames := make(map[string]bool, 0)
@@ -73,13 +112,18 @@ func (m *ModList) List(cfg *ListCfg, names ...string) []interface{} {
 	}
 
 	if m.Parent != nil {
+		m.traceParent()
 		result = append(result, m.Parent.List(cfg, names...)...)
 	}
 
+	m.traceEnd(query, result)
 	return result
 }
 
 func (m *ModList) Sum(modType mod.Type, cfg *ListCfg, names ...string) float64 {
+	cfg = m.skillCfg(cfg)
+	query := m.traceQuery("Sum", modType, cfg, names)
+
 	result := float64(0)
 
 	mappedNames := make(map[string]bool, 0)
@@ -105,13 +149,18 @@ func (m *ModList) Sum(modType mod.Type, cfg *ListCfg, names ...string) float64 {
 	}
 
 	if m.Parent != nil {
+		m.traceParent()
 		result += m.Parent.Sum(modType, cfg, names...)
 	}
 
+	m.traceEnd(query, result)
 	return result
 }
 
 func (m *ModList) More(cfg *ListCfg, names ...string) float64 {
+	cfg = m.skillCfg(cfg)
+	query := m.traceQuery("More", mod.TypeMore, cfg, names)
+
 	result := float64(1)
 
 	mappedNames := make(map[string]bool, 0)
@@ -137,13 +186,18 @@ func (m *ModList) More(cfg *ListCfg, names ...string) float64 {
 	}
 
 	if m.Parent != nil {
+		m.traceParent()
 		result *= m.Parent.More(cfg, names...)
 	}
 
+	m.traceEnd(query, result)
 	return result
 }
 
 func (m *ModList) Flag(cfg *ListCfg, names ...string) bool {
+	cfg = m.skillCfg(cfg)
+	query := m.traceQuery("Flag", mod.TypeFlag, cfg, names)
+
 	mappedNames := make(map[string]bool, 0)
 	for _, name := range names {
 		mappedNames[name] = true
@@ -161,21 +215,28 @@ func (m *ModList) Flag(cfg *ListCfg, names ...string) bool {
 
 			value := m.evalMod(mo, cfg)
 			if value != nil && value.(bool) {
+				m.traceEnd(query, true)
 				return true
 			}
 		}
 	}
 
 	if m.Parent != nil {
+		m.traceParent()
 		if m.Parent.Flag(cfg, names...) {
+			m.traceEnd(query, true)
 			return true
 		}
 	}
 
+	m.traceEnd(query, false)
 	return false
 }
 
 func (m *ModList) Override(cfg *ListCfg, names ...string) interface{} {
+	cfg = m.skillCfg(cfg)
+	query := m.traceQuery("Override", mod.TypeOverride, cfg, names)
+
 	mappedNames := make(map[string]bool, 0)
 	for _, name := range names {
 		mappedNames[name] = true
@@ -193,17 +254,106 @@ func (m *ModList) Override(cfg *ListCfg, names ...string) interface{} {
 
 			value := m.evalMod(mo, cfg)
 			if value != nil {
+				m.traceEnd(query, value)
 				return value
 			}
 		}
 	}
 
 	if m.Parent != nil {
+		m.traceParent()
 		p := m.Parent.Override(cfg, names...)
 		if p != nil {
+			m.traceEnd(query, p)
 			return p
 		}
 	}
 
+	m.traceEnd(query, nil)
 	return nil
 }
+
+// Max returns the highest value of the MAX mods matching the query, false if there are none
+func (m *ModList) Max(cfg *ListCfg, names ...string) (float64, bool) {
+	cfg = m.skillCfg(cfg)
+	query := m.traceQuery("Max", mod.TypeMAX, cfg, names)
+
+	result := float64(0)
+	found := false
+
+	mappedNames := make(map[string]bool, 0)
+	for _, name := range names {
+		mappedNames[name] = true
+	}
+
+	for _, mo := range m.mods {
+		if _, ok := mappedNames[mo.Name()]; !ok {
+			continue
+		}
+
+		if mo.Type() == mod.TypeMAX &&
+			(cfg == nil || cfg.Flags == nil || (*cfg.Flags)&mo.Flags() == mo.Flags()) &&
+			(cfg == nil || cfg.KeywordFlags == nil || mod.MatchKeywordFlags(*cfg.KeywordFlags, mo.KeywordFlags())) &&
+			(cfg == nil || cfg.Source == nil || *cfg.Source == mo.GetSource()) {
+
+			value := m.evalMod(mo, cfg)
+			if value != nil && (!found || value.(float64) > result) {
+				result = value.(float64)
+				found = true
+			}
+		}
+	}
+
+	if m.Parent != nil {
+		m.traceParent()
+		if p, ok := m.Parent.Max(cfg, names...); ok && (!found || p > result) {
+			result = p
+			found = true
+		}
+	}
+
+	if found {
+		m.traceEnd(query, result)
+	} else {
+		m.traceEnd(query, nil)
+	}
+	return result, found
+}
+
+// Tabulate returns every mod contributing to the query, an empty modType matches mods of all types
+func (m *ModList) Tabulate(modType mod.Type, cfg *ListCfg, names ...string) []TabulatedMod {
+	cfg = m.skillCfg(cfg)
+	query := m.traceQuery("Tabulate", modType, cfg, names)
+
+	result := make([]TabulatedMod, 0)
+
+	mappedNames := make(map[string]bool, 0)
+	for _, name := range names {
+		mappedNames[name] = true
+	}
+
+	for _, mo := range m.mods {
+		if _, ok := mappedNames[mo.Name()]; !ok {
+			continue
+		}
+
+		if (modType == "" || mo.Type() == modType) &&
+			(cfg == nil || cfg.Flags == nil || (*cfg.Flags)&mo.Flags() == mo.Flags()) &&
+			(cfg == nil || cfg.KeywordFlags == nil || mod.MatchKeywordFlags(*cfg.KeywordFlags, mo.KeywordFlags())) &&
+			(cfg == nil || cfg.Source == nil || *cfg.Source == mo.GetSource()) {
+
+			value := m.evalMod(mo, cfg)
+			if includeTabulated(value) {
+				result = append(result, TabulatedMod{Value: value, Mod: mo})
+			}
+		}
+	}
+
+	if m.Parent != nil {
+		m.traceParent()
+		result = append(result, m.Parent.Tabulate(modType, cfg, names...)...)
+	}
+
+	m.traceEnd(query, result)
+	return result
+}
@@ -170,6 +170,16 @@ func TestSum(t *testing.T) {
 			},
 			expected: 20,
 		},
+		{
+			name: "unmatched condition before matched condition",
+			mods: []mod.Mod{
+				mod.NewFloat("testMod", mod.TypeBase, 10).Tag(mod.Condition("Missing")).Tag(mod.Condition("Missing").Neg(true)),
+				mod.NewFloat("testMod", mod.TypeBase, 20).Tag(mod.Condition("Missing").Neg(true)),
+			},
+			modType:     mod.TypeBase,
+			mappedNames: []string{"testMod"},
+			expected:    20,
+		},
 	}
 
 	for _, test := range tc {
@@ -373,3 +383,100 @@ func TestOverride(t *testing.T) {
 		})
 	}
 }
+
+func TestTabulate(t *testing.T) {
+	inc := mod.NewFloat("testMod", mod.TypeIncrease, 10).Source("Tree:1")
+	more := mod.NewFloat("testMod", mod.TypeMore, 20).Source("Item:2")
+	zero := mod.NewFloat("testMod", mod.TypeIncrease, 0)
+	fire := mod.NewFloat("testMod", mod.TypeIncrease, 30).KeywordFlag(mod.KeywordFlagFire)
+
+	p := NewModList()
+	p.AddMod(more)
+	m := NewModList()
+	m.Parent = p
+	m.AddMod(inc)
+	m.AddMod(zero)
+	m.AddMod(fire)
+	m.AddMod(mod.NewFloat("otherMod", mod.TypeIncrease, 40))
+
+	cfg := &ListCfg{KeywordFlags: utils.Ptr(mod.KeywordFlagCold)}
+	testza.AssertEqual(t, []TabulatedMod{{Value: float64(10), Mod: inc}}, m.Tabulate(mod.TypeIncrease, cfg, "testMod"))
+	testza.AssertEqual(t, []TabulatedMod{
+		{Value: float64(10), Mod: inc},
+		{Value: float64(20), Mod: more},
+	}, m.Tabulate("", cfg, "testMod"))
+
+	db := NewModDB()
+	db.AddMod(inc)
+	db.AddMod(more)
+	testza.AssertEqual(t, []TabulatedMod{{Value: float64(20), Mod: more}}, db.Tabulate(mod.TypeMore, nil, "testMod"))
+}
+
+func TestMax(t *testing.T) {
+	m := NewModList()
+	_, ok := m.Max(nil, "testMod")
+	testza.AssertFalse(t, ok)
+
+	m.AddMod(mod.NewFloat("testMod", mod.TypeMAX, 10))
+	m.AddMod(mod.NewFloat("testMod", mod.TypeMAX, -5))
+	m.AddMod(mod.NewFloat("testMod", mod.TypeBase, 50))
+
+	p := NewModDB()
+	p.AddMod(mod.NewFloat("testMod", mod.TypeMAX, 30).KeywordFlag(mod.KeywordFlagFire))
+	m.Parent = p
+
+	value, ok := m.Max(nil, "testMod")
+	testza.AssertTrue(t, ok)
+	testza.AssertEqual(t, float64(30), value)
+
+	value, ok = m.Max(&ListCfg{KeywordFlags: utils.Ptr(mod.KeywordFlagCold)}, "testMod")
+	testza.AssertTrue(t, ok)
+	testza.AssertEqual(t, float64(10), value)
+}
+
+func TestScaleAddList(t *testing.T) {
+	src := NewModList()
+	src.AddMod(mod.NewFloat("testMod", mod.TypeBase, 15))
+	src.AddMod(mod.NewFloat("testMod", mod.TypeBase, 10).Tag(mod.GlobalEffect("Buff").Unscalable(true)))
+
+	m := NewModList()
+	m.ScaleAddList(src, 1.5)
+	testza.AssertEqual(t, float64(32), m.Sum(mod.TypeBase, nil, "testMod"))
+
+	db := NewModDB()
+	db.ScaleAddList(src, -1)
+	testza.AssertEqual(t, float64(10), db.Sum(mod.TypeBase, nil, "testMod"))
+
+	removed := m.RemoveMods(func(m mod.Mod) bool {
+		return len(m.Tags()) > 0
+	})
+	testza.AssertLen(t, removed, 1)
+	testza.AssertEqual(t, float64(22), m.Sum(mod.TypeBase, nil, "testMod"))
+
+	// Mods wrapped by list mods are scaled without changing the source
+	wrapped := NewModList()
+	wrapped.AddMod(mod.NewList("ExtraAura", mod.ExtraAura{Mod: mod.NewFloat("Armour", mod.TypeBase, 100)}))
+	scaled := NewModList()
+	scaled.ScaleAddList(wrapped, 1.5)
+	auras := scaled.List(nil, "ExtraAura")
+	testza.AssertLen(t, auras, 1)
+	testza.AssertEqual(t, float64(150), auras[0].(mod.ExtraAura).Mod.Value())
+	testza.AssertEqual(t, float64(100), wrapped.List(nil, "ExtraAura")[0].(mod.ExtraAura).Mod.Value())
+}
+
+func TestSkillID(t *testing.T) {
+	parent := NewModDB()
+	parent.AddMod(mod.NewFloat("testMod", mod.TypeBase, 10).Tag(mod.SkillId("Fireball")))
+
+	list := NewModList()
+	list.Parent = parent
+	list.AddMod(mod.NewFloat("testMod", mod.TypeBase, 5).Tag(mod.SkillId("Fireball")))
+
+	// Queries without a skill cannot match skill IDs
+	testza.AssertEqual(t, float64(0), parent.Sum(mod.TypeBase, nil, "testMod"))
+
+	// Queries on the store of a skill match its ID
+	list.SkillID = "Fireball"
+	testza.AssertEqual(t, float64(15), list.Sum(mod.TypeBase, nil, "testMod"))
+	testza.AssertEqual(t, float64(0), list.Sum(mod.TypeBase, &ListCfg{SkillID: "Frostbolt"}, "testMod"))
+}
@@ -5,6 +5,7 @@ import (
 	"math"
 
 	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/utils"
 )
 
 type ListCfg struct {
@@ -13,6 +14,7 @@ type ListCfg struct {
 	Source       *mod.Source
 	SkillStats   map[string]float64
 	SkillCond    map[string]bool
+	SkillID      string // Granted effect ID of the skill
 	SlotName     string
 }
 
@@ -22,11 +24,32 @@ type ModStoreFuncs interface {
 	More(cfg *ListCfg, names ...string) float64
 	Flag(cfg *ListCfg, names ...string) bool
 	Override(cfg *ListCfg, names ...string) interface{}
+	Max(cfg *ListCfg, names ...string) (float64, bool)
+	Tabulate(modType mod.Type, cfg *ListCfg, names ...string) []TabulatedMod
 	GetMultiplier(variable string, cfg *ListCfg, noMod bool) float64
 	GetCondition(variable string, cfg *ListCfg, noMod bool) (bool, bool)
 	Clone() ModStoreFuncs
 }
 
+// TabulatedMod is a mod matched by Tabulate together with the value it evaluated to
+type TabulatedMod struct {
+	Value interface{}
+	Mod   mod.Mod
+}
+
+// includeTabulated reports whether an evaluated value contributes anything
+func includeTabulated(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+
+	if f, ok := value.(float64); ok && f == 0 {
+		return false
+	}
+
+	return true
+}
+
 type Actor interface {
 	GetOutput(string) (float64, bool)
 }
@@ -37,6 +60,10 @@ type ModStore struct {
 	Actor       Actor
 	Multipliers map[string]float64
 	Conditions  map[string]bool
+	SkillID     string // Granted effect ID of the skill the store belongs to, matched by queries without a config
+
+	trace      *Trace
+	traceDepth int
 }
 
 func NewModStore(parent ModStoreFuncs) *ModStore {
@@ -59,11 +86,20 @@ func (s *ModStore) Clone() *ModStore {
 
 	out := NewModStore(parent)
 	out.Actor = s.Actor
+	out.SkillID = s.SkillID
 	maps.Copy(out.Multipliers, s.Multipliers)
 	maps.Copy(out.Conditions, s.Conditions)
 	return out
 }
 
+// skillCfg returns the config of the query, matching skill ID tags against the skill of the store if the query has no config
+func (s *ModStore) skillCfg(cfg *ListCfg) *ListCfg {
+	if cfg == nil && s.SkillID != "" {
+		return &ListCfg{SkillID: s.SkillID}
+	}
+	return cfg
+}
+
 func (s *ModStore) evalMultiplier(m mod.Mod, cfg *ListCfg, tag *mod.MultiplierTag) interface{} {
 	value := m.Value()
 
@@ -434,13 +470,6 @@ func (s *ModStore) evalActorConditionTag(m mod.Mod, cfg *ListCfg, tag *mod.Actor
 				return
 			end
 	*/
-	/*
-		TODO SkillId
-		case *mod.SkillIdTag:
-			if not cfg or not cfg.skillGrantedEffect or cfg.skillGrantedEffect.id ~= tag.skillId then
-				return
-			end
-	*/
 	/*
 		TODO SkillPart
 		case *mod.SkillPartTag:
@@ -536,10 +565,7 @@ func (s *ModStore) evalActorConditionTag(m mod.Mod, cfg *ListCfg, tag *mod.Actor
 func (s *ModStore) evalMod(m mod.Mod, cfg *ListCfg) interface{} {
 	value := m.Value()
 
-	if len(m.Tags()) == 0 {
-		return value
-	}
-
+	var tags []TracedTag
 	for _, raw := range m.Tags() {
 		switch tag := raw.(type) {
 		case *mod.MultiplierTag:
@@ -552,9 +578,23 @@ func (s *ModStore) evalMod(m mod.Mod, cfg *ListCfg) interface{} {
 			value = s.evalConditionTag(m, cfg, tag)
 		case *mod.ActorConditionTag:
 			value = s.evalActorConditionTag(m, cfg, tag)
+		case *mod.SkillIDTag:
+			if cfg == nil || cfg.SkillID == "" || cfg.SkillID != tag.ID() {
+				value = nil
+			}
+		}
+
+		if s.trace != nil {
+			tags = append(tags, TracedTag{Type: raw.Type(), Tag: raw, Value: value})
+		}
+
+		// A tag that does not match excludes the mod regardless of the remaining tags
+		if value == nil {
+			break
 		}
 	}
 
+	s.traceMod(m, value, tags)
 	return value
 }
 
@@ -638,3 +678,70 @@ func (s *ModStore) GetCondition(variable string, cfg *ListCfg, noMod bool) (bool
 
 	return out, false
 }
+
+// scaleMod returns a copy of the mod with its value multiplied by scale, unscalable global effects are returned as is
+func scaleMod(m mod.Mod, scale float64) mod.Mod {
+	if m == nil || scale == 1 {
+		return m
+	}
+
+	for _, tag := range m.Tags() {
+		if effect, ok := tag.(*mod.GlobalEffectTag); ok && effect.UnscalableTag {
+			return m
+		}
+	}
+
+	switch cast := m.(type) {
+	case *mod.FloatMod:
+		scaled := cast.Clone().(*mod.FloatMod)
+		scaled.ModValue = utils.ModF(utils.RoundTo(cast.ModValue*math.Max(scale, 0), 2))
+		return scaled
+	case *mod.ListMod:
+		value, ok := scaleWrappedMod(cast.ModValue, scale)
+		if !ok {
+			return m
+		}
+		scaled := cast.Clone().(*mod.ListMod)
+		scaled.ModValue = value
+		return scaled
+	}
+
+	return m
+}
+
+// scaleWrappedMod returns a copy of a list mod value with the mod it wraps scaled, or false if the value does not wrap a mod
+func scaleWrappedMod(value interface{}, scale float64) (interface{}, bool) {
+	switch v := value.(type) {
+	case mod.ExtraAuraEffect:
+		v.Mod = scaleMod(v.Mod, scale)
+		return v, true
+	case mod.ExtraAura:
+		v.Mod = scaleMod(v.Mod, scale)
+		return v, true
+	case mod.AffectedByAuraMod:
+		v.Mod = scaleMod(v.Mod, scale)
+		return v, true
+	case mod.AffectedByCurseMod:
+		v.Mod = scaleMod(v.Mod, scale)
+		return v, true
+	case mod.MinionModifier:
+		v.Mod = scaleMod(v.Mod, scale)
+		return v, true
+	case mod.EnemyModifier:
+		v.Mod = scaleMod(v.Mod, scale)
+		return v, true
+	case mod.ExtraSkillMod:
+		v.Mod = scaleMod(v.Mod, scale)
+		return v, true
+	case mod.GrantReservedLifeAsAura:
+		v.Mod = scaleMod(v.Mod, scale)
+		return v, true
+	case mod.GrantReservedManaAsAura:
+		v.Mod = scaleMod(v.Mod, scale)
+		return v, true
+	case mod.ShrineBuff:
+		v.Mod = scaleMod(v.Mod, scale)
+		return v, true
+	}
+	return nil, false
+}
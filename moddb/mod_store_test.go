@@ -62,3 +62,42 @@ func TestMultiplier(t *testing.T) {
 		})
 	}
 }
+
+func TestTrace(t *testing.T) {
+	p := NewModDB()
+	p.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 10).Source("Tree:1"))
+
+	m := NewModDB()
+	m.Parent = p
+	m.Multipliers["Rage"] = 4
+	m.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 5).Source("Item:2").Tag(mod.Multiplier("Rage")))
+	m.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 50).Tag(mod.Condition("Leeching")))
+
+	trace := m.StartTrace()
+	testza.AssertEqual(t, float64(30), m.Sum(mod.TypeIncrease, nil, "Damage"))
+	m.StopTrace()
+	testza.AssertEqual(t, float64(30), m.Sum(mod.TypeIncrease, nil, "Damage"))
+
+	testza.AssertLen(t, trace.Queries, 1)
+	query := trace.Queries[0]
+	testza.AssertEqual(t, "Sum", query.Func)
+	testza.AssertEqual(t, float64(30), query.Result)
+	testza.AssertLen(t, query.Mods, 3)
+
+	testza.AssertEqual(t, mod.Source("Item:2"), query.Mods[0].Source)
+	testza.AssertEqual(t, float64(5), query.Mods[0].Value)
+	testza.AssertEqual(t, float64(20), query.Mods[0].Result)
+	testza.AssertEqual(t, []TracedTag{{Type: mod.TypeMultiplier, Tag: mod.Multiplier("Rage"), Value: float64(20)}}, query.Mods[0].Tags)
+	testza.AssertEqual(t, 0, query.Mods[0].Depth)
+
+	testza.AssertNil(t, query.Mods[1].Result)
+	testza.AssertLen(t, query.Nested, 1)
+	testza.AssertEqual(t, "Flag", query.Nested[0].Func)
+	testza.AssertEqual(t, []string{"Condition:Leeching"}, query.Nested[0].Names)
+	testza.AssertEqual(t, false, query.Nested[0].Result)
+
+	testza.AssertEqual(t, mod.Source("Tree:1"), query.Mods[2].Source)
+	testza.AssertEqual(t, 1, query.Mods[2].Depth)
+
+	testza.AssertContains(t, trace.String(), "Sum(INC Damage) [depth 0] = 30")
+}
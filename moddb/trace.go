@@ -0,0 +1,162 @@
+package moddb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Vilsol/go-pob/mod"
+)
+
+// Trace records every query made against a store and its parents while tracing is enabled
+type Trace struct {
+	Queries []*TraceQuery
+
+	stack  []*TraceQuery
+	parent bool
+}
+
+// TraceQuery is a single call to List, Sum, More, Flag, Override, Max or Tabulate
+type TraceQuery struct {
+	Func    string
+	ModType mod.Type
+	Names   []string
+	Cfg     *ListCfg
+	Depth   int // Depth of the store that was queried, 0 is the store tracing was started on
+	Result  interface{}
+
+	Mods   []*TracedMod
+	Nested []*TraceQuery // Queries made while evaluating the tags of the matched mods
+}
+
+// TracedMod is a mod matched by a query
+type TracedMod struct {
+	Name         string
+	Type         mod.Type
+	Source       mod.Source
+	Flags        mod.MFlag
+	KeywordFlags mod.KeywordFlag
+	Value        interface{} // Value before tags were evaluated
+	Result       interface{} // Value after tags were evaluated, nil if a tag excluded the mod
+	Depth        int         // Depth of the store that supplied the mod, 0 is the store tracing was started on
+
+	Tags []TracedTag
+}
+
+// TracedTag is the result of evaluating a single tag of a mod
+type TracedTag struct {
+	Type  mod.Type
+	Tag   mod.Tag
+	Value interface{}
+}
+
+// StartTrace enables tracing on the store and all of its parents
+func (s *ModStore) StartTrace() *Trace {
+	trace := &Trace{}
+	s.setTrace(trace, 0)
+	return trace
+}
+
+// StopTrace disables tracing on the store and all of its parents
+func (s *ModStore) StopTrace() {
+	s.setTrace(nil, 0)
+}
+
+// traceable is implemented by stores that can record their queries, parents that do not implement it are not traced
+type traceable interface {
+	setTrace(trace *Trace, depth int)
+}
+
+func (s *ModStore) setTrace(trace *Trace, depth int) {
+	s.trace = trace
+	s.traceDepth = depth
+	if parent, ok := s.Parent.(traceable); ok {
+		parent.setTrace(trace, depth+1)
+	}
+}
+
+// traceQuery starts recording a query, unless it is being delegated from a child store
+func (s *ModStore) traceQuery(fn string, modType mod.Type, cfg *ListCfg, names []string) *TraceQuery {
+	if s.trace == nil {
+		return nil
+	}
+
+	if s.trace.parent {
+		s.trace.parent = false
+		return nil
+	}
+
+	query := &TraceQuery{
+		Func:    fn,
+		ModType: modType,
+		Names:   names,
+		Cfg:     cfg,
+		Depth:   s.traceDepth,
+	}
+
+	if len(s.trace.stack) > 0 {
+		top := s.trace.stack[len(s.trace.stack)-1]
+		top.Nested = append(top.Nested, query)
+	} else {
+		s.trace.Queries = append(s.trace.Queries, query)
+	}
+
+	s.trace.stack = append(s.trace.stack, query)
+	return query
+}
+
+// traceParent marks the next query as a continuation of the current one in the parent store
+func (s *ModStore) traceParent() {
+	if s.trace != nil {
+		s.trace.parent = true
+	}
+}
+
+func (s *ModStore) traceEnd(query *TraceQuery, result interface{}) {
+	if query == nil {
+		return
+	}
+
+	query.Result = result
+	s.trace.parent = false
+	s.trace.stack = s.trace.stack[:len(s.trace.stack)-1]
+}
+
+func (s *ModStore) traceMod(m mod.Mod, result interface{}, tags []TracedTag) {
+	if s.trace == nil || len(s.trace.stack) == 0 {
+		return
+	}
+
+	query := s.trace.stack[len(s.trace.stack)-1]
+	query.Mods = append(query.Mods, &TracedMod{
+		Name:         m.Name(),
+		Type:         m.Type(),
+		Source:       m.GetSource(),
+		Flags:        m.Flags(),
+		KeywordFlags: m.KeywordFlags(),
+		Value:        m.Value(),
+		Result:       result,
+		Depth:        s.traceDepth,
+		Tags:         tags,
+	})
+}
+
+func (t *Trace) String() string {
+	var sb strings.Builder
+	for _, query := range t.Queries {
+		query.write(&sb, "")
+	}
+	return sb.String()
+}
+
+func (q *TraceQuery) write(sb *strings.Builder, indent string) {
+	sb.WriteString(fmt.Sprintf("%s%s(%s %s) [depth %d] = %v\n", indent, q.Func, q.ModType, strings.Join(q.Names, ", "), q.Depth, q.Result))
+	for _, m := range q.Mods {
+		sb.WriteString(fmt.Sprintf("%s  %s %s %v -> %v [source %s, depth %d]\n", indent, m.Type, m.Name, m.Value, m.Result, m.Source, m.Depth))
+		for _, tag := range m.Tags {
+			sb.WriteString(fmt.Sprintf("%s    %s -> %v\n", indent, tag.Type, tag.Value))
+		}
+	}
+	for _, nested := range q.Nested {
+		nested.write(sb, indent+"  ")
+	}
+}
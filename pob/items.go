@@ -0,0 +1,797 @@
+package pob
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/Vilsol/go-pob/data"
+)
+
+type ItemRarity string
+
+const (
+	RarityNormal = ItemRarity("NORMAL")
+	RarityMagic  = ItemRarity("MAGIC")
+	RarityRare   = ItemRarity("RARE")
+	RarityUnique = ItemRarity("UNIQUE")
+	RarityRelic  = ItemRarity("RELIC")
+)
+
+type Influence string
+
+const (
+	InfluenceShaper        = Influence("Shaper")
+	InfluenceElder         = Influence("Elder")
+	InfluenceCrusader      = Influence("Crusader")
+	InfluenceHunter        = Influence("Hunter")
+	InfluenceRedeemer      = Influence("Redeemer")
+	InfluenceWarlord       = Influence("Warlord")
+	InfluenceSearingExarch = Influence("Searing Exarch")
+	InfluenceEaterOfWorlds = Influence("Eater of Worlds")
+)
+
+var influenceLines = map[string]Influence{
+	"Shaper Item":          InfluenceShaper,
+	"Elder Item":           InfluenceElder,
+	"Crusader Item":        InfluenceCrusader,
+	"Hunter Item":          InfluenceHunter,
+	"Redeemer Item":        InfluenceRedeemer,
+	"Warlord Item":         InfluenceWarlord,
+	"Searing Exarch Item":  InfluenceSearingExarch,
+	"Eater of Worlds Item": InfluenceEaterOfWorlds,
+}
+
+// ErrGameDataNotLoaded is returned when items are parsed before the game data is loaded, as their bases cannot be resolved
+var ErrGameDataNotLoaded = errors.New("game data is not loaded")
+
+// DefaultItemAffixQuality is the roll used for ranged mod lines that do not specify one
+const DefaultItemAffixQuality = 0.5
+
+type Item struct {
+	ID         int        `xml:"id,attr"`
+	Variant    *int       `xml:"variant,attr,omitempty"`
+	VariantAlt *int       `xml:"variantAlt,attr,omitempty"`
+	Raw        string     `xml:",chardata"`
+	ModRanges  []ModRange `xml:"ModRange" crystalline:"not_nil"`
+
+	UnknownAttrs    []xml.Attr       `xml:",any,attr"`
+	UnknownElements []UnknownElement `xml:",any"`
+
+	Rarity          ItemRarity  `xml:"-"`
+	Title           string      `xml:"-"`
+	BaseName        string      `xml:"-"`
+	UniqueID        string      `xml:"-"`
+	League          string      `xml:"-"`
+	ItemLevel       int         `xml:"-"`
+	LevelReq        int         `xml:"-"`
+	Quality         int         `xml:"-"`
+	Armour          int         `xml:"-"`
+	Evasion         int         `xml:"-"`
+	EnergyShield    int         `xml:"-"`
+	Ward            int         `xml:"-"`
+	Radius          string      `xml:"-"`
+	LimitedTo       int         `xml:"-"`
+	Sockets         []Socket    `xml:"-" crystalline:"not_nil"`
+	Influences      []Influence `xml:"-" crystalline:"not_nil"`
+	Corrupted       bool        `xml:"-"`
+	Mirrored        bool        `xml:"-"`
+	Split           bool        `xml:"-"`
+	Synthesised     bool        `xml:"-"`
+	Fractured       bool        `xml:"-"`
+	Crafted         bool        `xml:"-"`
+	Variants        []string    `xml:"-" crystalline:"not_nil"`
+	SelectedVariant int         `xml:"-"`
+	HasAltVariant   bool        `xml:"-"`
+	SelectedAlt     int         `xml:"-"`
+	Prefixes        []ItemAffix `xml:"-" crystalline:"not_nil"`
+	Suffixes        []ItemAffix `xml:"-" crystalline:"not_nil"`
+
+	EnchantModLines  []ItemModLine `xml:"-" crystalline:"not_nil"`
+	ImplicitModLines []ItemModLine `xml:"-" crystalline:"not_nil"`
+	ExplicitModLines []ItemModLine `xml:"-" crystalline:"not_nil"`
+
+	// Position of the base defences within the range of the base type, the maximum if not specified
+	ArmourBasePercentile       *float64 `xml:"-"`
+	EvasionBasePercentile      *float64 `xml:"-"`
+	EnergyShieldBasePercentile *float64 `xml:"-"`
+	WardBasePercentile         *float64 `xml:"-"`
+}
+
+type ModRange struct {
+	ID    int     `xml:"id,attr"`
+	Range float64 `xml:"range,attr"`
+}
+
+// MarshalXML writes the item text with literal line breaks, as Path of Building does
+func (i Item) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "id"}, Value: strconv.Itoa(i.ID)})
+	if i.Variant != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "variant"}, Value: strconv.Itoa(*i.Variant)})
+	}
+	if i.VariantAlt != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "variantAlt"}, Value: strconv.Itoa(*i.VariantAlt)})
+	}
+	start.Attr = append(start.Attr, i.UnknownAttrs...)
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if err := e.EncodeToken(xml.CharData(i.Raw)); err != nil {
+		return err
+	}
+
+	for _, modRange := range i.ModRanges {
+		if err := e.EncodeElement(modRange, xml.StartElement{Name: xml.Name{Local: "ModRange"}}); err != nil {
+			return err
+		}
+	}
+
+	for _, element := range i.UnknownElements {
+		if err := e.Encode(element); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+type Socket struct {
+	Color string
+	Group int
+}
+
+type ItemAffix struct {
+	ModID string
+	Range float64
+}
+
+type ItemModLine struct {
+	Line      string
+	Range     float64
+	Variants  []int    `crystalline:"not_nil"`
+	Tags      []string `crystalline:"not_nil"`
+	Crafted   bool
+	Fractured bool
+	Custom    bool
+	Scourge   bool
+	Crucible  bool
+	Synthesis bool
+	Mutated   bool
+	Enchant   bool
+	Implicit  bool
+
+	rangeSet bool
+}
+
+var (
+	itemSpecRegex      = regexp.MustCompile(`^([\p{L} ]+): (.+)$`)
+	modLinePrefixRegex = regexp.MustCompile(`^\{(\w+)(?::([^}]*))?\}`)
+	modLineSuffixRegex = regexp.MustCompile(` \((enchant|implicit|crafted|fractured|scourge|crucible)\)$`)
+	modRangeRegex      = regexp.MustCompile(`(\+?)\((-?\d+\.?\d*)-(-?\d+\.?\d*)\)`)
+	leadingIntRegex    = regexp.MustCompile(`^[+-]?\d+`)
+
+	negativeIncreasedRegex = regexp.MustCompile(`-(\d+\.?\d*)% increased`)
+	negativeMoreRegex      = regexp.MustCompile(`-(\d+\.?\d*)% more`)
+)
+
+// Parse populates the item model from the raw item text stored in the build
+func (i *Item) Parse() error {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(i.Raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "Rarity: ") {
+		return fmt.Errorf("item %d is missing its rarity", i.ID)
+	}
+
+	// The rarity is only set once the item is parsed, so that items that failed to parse are not used
+	i.Rarity = ""
+	rarity := ItemRarity(strings.ToUpper(strings.TrimPrefix(lines[0], "Rarity: ")))
+	lines = lines[1:]
+
+	switch rarity {
+	case RarityRare, RarityUnique, RarityRelic:
+		if len(lines) < 2 {
+			return fmt.Errorf("item %d is missing its name or base", i.ID)
+		}
+		i.Title = lines[0]
+		i.BaseName = lines[1]
+		lines = lines[2:]
+	case RarityNormal, RarityMagic:
+		if len(lines) < 1 {
+			return fmt.Errorf("item %d is missing its name", i.ID)
+		}
+		i.Title = lines[0]
+		if rarity == RarityNormal {
+			i.BaseName = strings.TrimPrefix(i.Title, "Superior ")
+		}
+		lines = lines[1:]
+	default:
+		return fmt.Errorf("item %d has unknown rarity: %s", i.ID, rarity)
+	}
+
+	i.Sockets = make([]Socket, 0)
+	i.Influences = make([]Influence, 0)
+	i.Variants = make([]string, 0)
+	i.Prefixes = make([]ItemAffix, 0)
+	i.Suffixes = make([]ItemAffix, 0)
+	i.EnchantModLines = make([]ItemModLine, 0)
+	i.ImplicitModLines = make([]ItemModLine, 0)
+	i.ExplicitModLines = make([]ItemModLine, 0)
+
+	inMods := false
+	implicits := 0
+	for _, line := range lines {
+		if i.parseFlagLine(line) {
+			continue
+		}
+
+		if !inMods {
+			if match := itemSpecRegex.FindStringSubmatch(line); match != nil {
+				if done, err := i.parseSpecLine(match[1], match[2]); err != nil {
+					return fmt.Errorf("item %d has invalid %s: %w", i.ID, match[1], err)
+				} else if done {
+					implicits, _ = strconv.Atoi(match[2])
+					inMods = true
+				}
+				continue
+			}
+
+			if line == i.BaseName || strings.HasPrefix(line, "Requires ") {
+				continue
+			}
+
+			if _, ok := poe.BaseItemTypeByNameMap[line]; ok {
+				i.BaseName = line
+				continue
+			}
+
+			inMods = true
+		}
+
+		modLine := parseModLine(line)
+		switch {
+		case modLine.Enchant:
+			i.EnchantModLines = append(i.EnchantModLines, modLine)
+			implicits--
+		case modLine.Implicit || modLine.Scourge || implicits > 0:
+			modLine.Implicit = true
+			i.ImplicitModLines = append(i.ImplicitModLines, modLine)
+			implicits--
+		default:
+			i.ExplicitModLines = append(i.ExplicitModLines, modLine)
+		}
+	}
+
+	if i.Variant != nil {
+		i.SelectedVariant = *i.Variant
+	}
+
+	if i.VariantAlt != nil {
+		i.SelectedAlt = *i.VariantAlt
+	}
+
+	if len(i.Variants) > 0 && i.SelectedVariant == 0 {
+		i.SelectedVariant = len(i.Variants)
+	}
+
+	// Legacy ModRange elements index into enchants, implicits and explicits in that order
+	allLines := i.allModLines()
+	for _, modRange := range i.ModRanges {
+		if modRange.ID > 0 && modRange.ID <= len(allLines) && !allLines[modRange.ID-1].rangeSet {
+			allLines[modRange.ID-1].Range = modRange.Range
+		}
+	}
+
+	// Magic item names wrap the base with their affixes
+	if i.BaseName == "" && rarity == RarityMagic {
+		i.BaseName = findBaseName(i.Title)
+	}
+
+	i.Rarity = rarity
+
+	return nil
+}
+
+func (i *Item) parseFlagLine(line string) bool {
+	if influence, ok := influenceLines[line]; ok {
+		i.Influences = append(i.Influences, influence)
+		return true
+	}
+
+	switch line {
+	case "Corrupted":
+		i.Corrupted = true
+	case "Mirrored":
+		i.Mirrored = true
+	case "Split":
+		i.Split = true
+	case "Synthesised Item":
+		i.Synthesised = true
+	case "Fractured Item":
+		i.Fractured = true
+	default:
+		return false
+	}
+
+	return true
+}
+
+// parseSpecLine handles the "Key: Value" header lines and reports whether the implicit count was reached
+func (i *Item) parseSpecLine(key string, value string) (bool, error) {
+	var err error
+	switch key {
+	case "Unique ID":
+		i.UniqueID = value
+	case "League":
+		i.League = value
+	case "Item Level":
+		i.ItemLevel, err = parseLeadingInt(value)
+	case "LevelReq":
+		i.LevelReq, err = parseLeadingInt(value)
+	case "Quality":
+		i.Quality, err = parseLeadingInt(value)
+	case "Armour":
+		i.Armour, err = parseLeadingInt(value)
+	case "Evasion":
+		i.Evasion, err = parseLeadingInt(value)
+	case "Energy Shield":
+		i.EnergyShield, err = parseLeadingInt(value)
+	case "Ward":
+		i.Ward, err = parseLeadingInt(value)
+	case "ArmourBasePercentile":
+		i.ArmourBasePercentile, err = parsePercentile(value)
+	case "EvasionBasePercentile":
+		i.EvasionBasePercentile, err = parsePercentile(value)
+	case "EnergyShieldBasePercentile":
+		i.EnergyShieldBasePercentile, err = parsePercentile(value)
+	case "WardBasePercentile":
+		i.WardBasePercentile, err = parsePercentile(value)
+	case "Radius":
+		i.Radius = value
+	case "Limited to":
+		i.LimitedTo, err = parseLeadingInt(value)
+	case "Sockets":
+		i.Sockets = parseSockets(value)
+	case "Variant":
+		i.Variants = append(i.Variants, value)
+	case "Selected Variant":
+		i.SelectedVariant, err = parseLeadingInt(value)
+	case "Has Alt Variant":
+		i.HasAltVariant = value == "true"
+	case "Selected Alt Variant":
+		i.SelectedAlt, err = parseLeadingInt(value)
+	case "Crafted":
+		i.Crafted = value == "true"
+	case "Prefix", "Suffix":
+		modLine := parseModLine(value)
+		if modLine.Line != "None" {
+			affix := ItemAffix{ModID: modLine.Line, Range: modLine.Range}
+			if key == "Prefix" {
+				i.Prefixes = append(i.Prefixes, affix)
+			} else {
+				i.Suffixes = append(i.Suffixes, affix)
+			}
+		}
+	case "Implicits":
+		_, err = parseLeadingInt(value)
+		return true, err
+	}
+
+	return false, err
+}
+
+func parseLeadingInt(value string) (int, error) {
+	match := leadingIntRegex.FindString(value)
+	if match == "" {
+		return 0, fmt.Errorf("not a number: %s", value)
+	}
+	return strconv.Atoi(match)
+}
+
+func parsePercentile(value string) (*float64, error) {
+	percentile, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return nil, err
+	}
+	return &percentile, nil
+}
+
+func parseSockets(value string) []Socket {
+	sockets := make([]Socket, 0)
+	for group, linked := range strings.Fields(value) {
+		for _, color := range strings.Split(linked, "-") {
+			if color != "" {
+				sockets = append(sockets, Socket{Color: color, Group: group})
+			}
+		}
+	}
+	return sockets
+}
+
+func parseModLine(line string) ItemModLine {
+	modLine := ItemModLine{
+		Range:    DefaultItemAffixQuality,
+		Variants: make([]int, 0),
+		Tags:     make([]string, 0),
+	}
+
+	for {
+		match := modLinePrefixRegex.FindStringSubmatch(line)
+		if match == nil {
+			break
+		}
+		line = line[len(match[0]):]
+
+		switch match[1] {
+		case "crafted":
+			modLine.Crafted = true
+		case "fractured":
+			modLine.Fractured = true
+		case "custom":
+			modLine.Custom = true
+		case "scourge":
+			modLine.Scourge = true
+		case "crucible":
+			modLine.Crucible = true
+		case "synthesis":
+			modLine.Synthesis = true
+		case "mutated":
+			modLine.Mutated = true
+		case "enchant":
+			modLine.Enchant = true
+		case "implicit":
+			modLine.Implicit = true
+		case "range":
+			if r, err := strconv.ParseFloat(match[2], 64); err == nil {
+				modLine.Range = r
+				modLine.rangeSet = true
+			}
+		case "variant":
+			for _, v := range strings.Split(match[2], ",") {
+				if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+					modLine.Variants = append(modLine.Variants, n)
+				}
+			}
+		case "tags":
+			modLine.Tags = append(modLine.Tags, strings.Split(match[2], ",")...)
+		}
+	}
+
+	if match := modLineSuffixRegex.FindStringSubmatch(line); match != nil {
+		line = strings.TrimSuffix(line, match[0])
+		switch match[1] {
+		case "enchant":
+			modLine.Enchant = true
+		case "implicit":
+			modLine.Implicit = true
+		case "crafted":
+			modLine.Crafted = true
+		case "fractured":
+			modLine.Fractured = true
+		case "scourge":
+			modLine.Scourge = true
+		case "crucible":
+			modLine.Crucible = true
+		}
+	}
+
+	modLine.Line = line
+
+	return modLine
+}
+
+func (i *Item) allModLines() []*ItemModLine {
+	out := make([]*ItemModLine, 0, len(i.EnchantModLines)+len(i.ImplicitModLines)+len(i.ExplicitModLines))
+	for _, lines := range [][]ItemModLine{i.EnchantModLines, i.ImplicitModLines, i.ExplicitModLines} {
+		for j := range lines {
+			out = append(out, &lines[j])
+		}
+	}
+	return out
+}
+
+// Value returns the mod line with any ranges resolved using the line's roll
+func (l ItemModLine) Value() string {
+	return ApplyRange(l.Line, l.Range)
+}
+
+// ApplyRange replaces every "(min-max)" in a mod line with the value rolled at the given range
+func ApplyRange(line string, r float64) string {
+	if !strings.Contains(line, "-") {
+		return line
+	}
+
+	line = modRangeRegex.ReplaceAllStringFunc(line, func(s string) string {
+		match := modRangeRegex.FindStringSubmatch(s)
+		minVal, _ := strconv.ParseFloat(match[2], 64)
+		maxVal, _ := strconv.ParseFloat(match[3], 64)
+
+		precision := math.Pow(10, float64(max(decimals(match[2]), decimals(match[3]))))
+		value := math.Floor((minVal+r*(maxVal-minVal))*precision+0.5) / precision
+
+		plus := match[1]
+		if value < 0 {
+			plus = ""
+		}
+
+		return plus + strconv.FormatFloat(value, 'f', -1, 64)
+	})
+
+	line = negativeIncreasedRegex.ReplaceAllString(line, "$1% reduced")
+	return negativeMoreRegex.ReplaceAllString(line, "$1% less")
+}
+
+func decimals(s string) int {
+	if idx := strings.IndexByte(s, '.'); idx >= 0 {
+		return len(s) - idx - 1
+	}
+	return 0
+}
+
+// HasVariant reports whether a mod line applies to the currently selected variant(s)
+func (i *Item) HasVariant(line ItemModLine) bool {
+	if len(line.Variants) == 0 {
+		return true
+	}
+
+	if slices.Contains(line.Variants, i.SelectedVariant) {
+		return true
+	}
+
+	return i.HasAltVariant && slices.Contains(line.Variants, i.SelectedAlt)
+}
+
+// ActiveModLines returns all enchant, implicit and explicit mod lines that apply to the selected variant
+func (i *Item) ActiveModLines() []ItemModLine {
+	out := make([]ItemModLine, 0, len(i.EnchantModLines)+len(i.ImplicitModLines)+len(i.ExplicitModLines))
+	for _, lines := range [][]ItemModLine{i.EnchantModLines, i.ImplicitModLines, i.ExplicitModLines} {
+		for _, line := range lines {
+			if i.HasVariant(line) {
+				out = append(out, line)
+			}
+		}
+	}
+	return out
+}
+
+// Links returns the size of the largest linked socket group
+func (i *Item) Links() int {
+	groups := make(map[int]int)
+	out := 0
+	for _, socket := range i.Sockets {
+		groups[socket.Group]++
+		out = max(out, groups[socket.Group])
+	}
+	return out
+}
+
+// AbyssalSocketCount returns the number of abyssal sockets on the item
+func (i *Item) AbyssalSocketCount() int {
+	out := 0
+	for _, socket := range i.Sockets {
+		if socket.Color == "A" {
+			out++
+		}
+	}
+	return out
+}
+
+// BaseType resolves the item base against the loaded game data
+func (i *Item) BaseType() *poe.BaseItemType {
+	if base, ok := poe.BaseItemTypeByNameMap[i.BaseName]; ok {
+		return base
+	}
+
+	// Path of Building disambiguates some bases with a suffix, e.g. "Two-Toned Boots (Evasion/Energy Shield)"
+	if idx := strings.Index(i.BaseName, " ("); idx > 0 {
+		return poe.BaseItemTypeByNameMap[i.BaseName[:idx]]
+	}
+
+	return nil
+}
+
+// findBaseName returns the longest known base type name contained in a magic item name
+func findBaseName(name string) string {
+	out := ""
+	for _, base := range poe.BaseItemTypes {
+		if len(base.Name) <= len(out) || !strings.Contains(name, base.Name) {
+			continue
+		}
+
+		idx := strings.Index(name, base.Name)
+		end := idx + len(base.Name)
+		if (idx == 0 || name[idx-1] == ' ') && (end == len(name) || name[end] == ' ') {
+			out = base.Name
+		}
+	}
+	return out
+}
+
+// ItemClass resolves the item class of the item base
+func (i *Item) ItemClass() *poe.ItemClass {
+	base := i.BaseType()
+	if base == nil || base.ItemClassesKey < 0 || base.ItemClassesKey >= len(poe.ItemClasses) {
+		return nil
+	}
+
+	return poe.ItemClasses[base.ItemClassesKey]
+}
+
+// Type returns the item class name of the item, or an empty string if the base is unknown
+func (i *Item) Type() data.ItemClassName {
+	class := i.ItemClass()
+	if class == nil {
+		return ""
+	}
+
+	return data.ItemClassName(class.ID)
+}
+
+// baseIndex maps base item keys to the rows of a game data table, it is rebuilt when the table is reloaded
+type baseIndex[T any] struct {
+	mu     sync.Mutex
+	rows   []*T
+	byBase map[int]*T
+}
+
+func (b *baseIndex[T]) get(rows []*T, baseKey func(*T) int, key int) *T {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.byBase == nil || len(rows) != len(b.rows) || (len(rows) > 0 && rows[0] != b.rows[0]) {
+		b.byBase = make(map[int]*T, len(rows))
+		for _, row := range rows {
+			if _, ok := b.byBase[baseKey(row)]; !ok {
+				b.byBase[baseKey(row)] = row
+			}
+		}
+		b.rows = rows
+	}
+
+	return b.byBase[key]
+}
+
+var (
+	weaponTypeIndex baseIndex[poe.WeaponType]
+	armourTypeIndex baseIndex[poe.ArmourType]
+	shieldTypeIndex baseIndex[poe.ShieldType]
+)
+
+// WeaponType returns the base weapon stats of the item, or nil if the item is not a weapon
+func (i *Item) WeaponType() *poe.WeaponType {
+	base := i.BaseType()
+	if base == nil {
+		return nil
+	}
+
+	return weaponTypeIndex.get(poe.WeaponTypes, func(w *poe.WeaponType) int { return w.BaseItemTypesKey }, base.Key)
+}
+
+// ArmourType returns the base defences of the item, or nil if the item is not an armour or shield
+func (i *Item) ArmourType() *poe.ArmourType {
+	base := i.BaseType()
+	if base == nil {
+		return nil
+	}
+
+	return armourTypeIndex.get(poe.ArmourTypes, func(a *poe.ArmourType) int { return a.BaseItemTypesKey }, base.Key)
+}
+
+// ShieldType returns the base block chance of the item, or nil if the item is not a shield
+func (i *Item) ShieldType() *poe.ShieldType {
+	base := i.BaseType()
+	if base == nil {
+		return nil
+	}
+
+	return shieldTypeIndex.get(poe.ShieldTypes, func(s *poe.ShieldType) int { return s.BaseItemTypesKey }, base.Key)
+}
+
+// ItemByID returns the item with the given id, or nil if there is none
+func (i *Items) ItemByID(id int) *Item {
+	// The index is only built by ParseItems, items added or copied later are found by scanning
+	if idx, ok := i.itemIndex[id]; ok && idx < len(i.Items) && i.Items[idx].ID == id {
+		return &i.Items[idx]
+	}
+
+	for idx := range i.Items {
+		if i.Items[idx].ID == id {
+			return &i.Items[idx]
+		}
+	}
+	return nil
+}
+
+// ActiveSet returns the currently active item set
+func (i *Items) ActiveSet() *ItemSet {
+	return i.SetOrFirst(i.ActiveItemSet)
+}
+
+// SetOrFirst returns the item set with the given ID, falling back to the first item set
+func (i *Items) SetOrFirst(id int) *ItemSet {
+	for idx := range i.ItemSets {
+		if i.ItemSets[idx].ID == strconv.Itoa(id) {
+			return &i.ItemSets[idx]
+		}
+	}
+
+	if len(i.ItemSets) > 0 {
+		return &i.ItemSets[0]
+	}
+
+	return nil
+}
+
+// SlotItem returns the item equipped in the slot, or nil if the slot is empty or its item could not be parsed
+func (i *Items) SlotItem(slot Slot) *Item {
+	if slot.ItemID == 0 {
+		return nil
+	}
+
+	item := i.ItemByID(slot.ItemID)
+	if item == nil || item.Rarity == "" {
+		return nil
+	}
+
+	return item
+}
+
+// SetSlotItem returns the item equipped in the named slot of the item set, or nil if the slot is empty
+func (i *Items) SetSlotItem(set *ItemSet, name string) *Item {
+	for _, slot := range set.Slots {
+		if slot.Name == name {
+			return i.SlotItem(slot)
+		}
+	}
+	return nil
+}
+
+// ParseItems parses every item, logging the items and slots that cannot be used so the rest of the build still loads.
+// Item bases are resolved against the game data, so it has to be loaded first.
+func (i *Items) ParseItems() error {
+	if len(i.Items) > 0 && len(poe.BaseItemTypeByNameMap) == 0 {
+		return ErrGameDataNotLoaded
+	}
+
+	i.itemIndex = make(map[int]int, len(i.Items))
+	for idx := range i.Items {
+		if _, ok := i.itemIndex[i.Items[idx].ID]; !ok {
+			i.itemIndex[i.Items[idx].ID] = idx
+		}
+
+		if err := i.Items[idx].Parse(); err != nil {
+			slog.Warn("ignoring item", slog.String("error", err.Error()))
+		}
+	}
+
+	check := func(slots []Slot) {
+		for _, slot := range slots {
+			if slot.ItemID != 0 && i.ItemByID(slot.ItemID) == nil {
+				slog.Warn("ignoring slot with unknown item", slog.String("slot", slot.Name), slog.Int("item", slot.ItemID))
+			}
+		}
+	}
+
+	check(i.Slots)
+	for _, set := range i.ItemSets {
+		check(set.Slots)
+	}
+
+	return nil
+}
@@ -1,7 +1,11 @@
 package pob
 
 import (
+	"fmt"
+	"slices"
 	"strconv"
+
+	"github.com/Vilsol/go-pob/data"
 )
 
 func (b *PathOfBuilding) WithMainSocketGroup(mainSocketGroup int) *PathOfBuilding {
@@ -103,10 +107,39 @@ func (b *PathOfBuilding) DeleteAllSocketGroups() {
 
 func (b *PathOfBuilding) SetClass(clazz string) {
 	b.Build.ClassName = clazz
+	if spec := b.ActiveSpec(); spec != nil {
+		if classID, ok := data.ClassIDs[data.ClassName(clazz)]; ok {
+			spec.ClassID = classID
+			spec.AscendClassID = 0
+		}
+	}
 }
 
-func (b *PathOfBuilding) SetAscendancy(ascendancy string) {
+// SetAscendancy selects the ascendancy of the current class, "None" removes it.
+// The tree must be the one of the active spec, it is used to validate the ascendancy.
+func (b *PathOfBuilding) SetAscendancy(tree *data.Tree, ascendancy string) error {
+	if spec := b.ActiveSpec(); spec != nil {
+		if spec.ClassID >= 0 && spec.ClassID < len(tree.Classes) {
+			// Ascendancy ids follow the order of the tree data, 0 is no ascendancy
+			ascendClassID := -1
+			if ascendancy == "" || ascendancy == "None" {
+				ascendClassID = 0
+			}
+			for i, ascendancyClass := range tree.Classes[spec.ClassID].Ascendancies {
+				if string(ascendancyClass.Name) == ascendancy {
+					ascendClassID = i + 1
+				}
+			}
+
+			if ascendClassID < 0 {
+				return fmt.Errorf("unknown ascendancy %s for class %s", ascendancy, tree.Classes[spec.ClassID].Name)
+			}
+			spec.AscendClassID = ascendClassID
+		}
+	}
+
 	b.Build.AscendClassName = ascendancy
+	return nil
 }
 
 func (b *PathOfBuilding) SetLevel(level int) {
@@ -115,11 +148,149 @@ func (b *PathOfBuilding) SetLevel(level int) {
 
 func (b *PathOfBuilding) AllocateNodes(nodeIds []int64) {
 	b.Build.PassiveNodes = append(b.Build.PassiveNodes, nodeIds...)
+	b.syncActiveSpec()
 }
 
 func (b *PathOfBuilding) DeallocateNodes(nodeId int64) {
 	var newNodes, err = removeValue(b.Build.PassiveNodes, nodeId)
 	if err == nil {
 		b.Build.PassiveNodes = newNodes
+		b.syncActiveSpec()
+		b.RemoveMasteryEffect(nodeId)
+	}
+}
+
+// SetMasteryEffect selects the effect of a mastery node in the active spec.
+// The tree must be the one of the active spec, it is used to validate the effect.
+func (b *PathOfBuilding) SetMasteryEffect(tree *data.Tree, nodeID int64, effectID int64) error {
+	spec := b.ActiveSpec()
+	if spec == nil {
+		return fmt.Errorf("build has no active spec")
+	}
+
+	if _, err := tree.MasteryEffect(nodeID, effectID); err != nil {
+		return fmt.Errorf("failed to select mastery effect: %w", err)
+	}
+
+	for _, selection := range spec.MasterySelections {
+		if selection.NodeID != nodeID && selection.EffectID == effectID {
+			return fmt.Errorf("effect %d is already selected on mastery %d", effectID, selection.NodeID)
+		}
+	}
+
+	for i, selection := range spec.MasterySelections {
+		if selection.NodeID == nodeID {
+			spec.MasterySelections[i].EffectID = effectID
+			return nil
+		}
+	}
+
+	spec.MasterySelections = append(spec.MasterySelections, MasterySelection{
+		NodeID:   nodeID,
+		EffectID: effectID,
+	})
+	return nil
+}
+
+func (b *PathOfBuilding) RemoveMasteryEffect(nodeID int64) {
+	if spec := b.ActiveSpec(); spec != nil {
+		spec.MasterySelections = slices.DeleteFunc(spec.MasterySelections, func(selection MasterySelection) bool {
+			return selection.NodeID == nodeID
+		})
+	}
+}
+
+// ActiveSpec returns the selected passive tree spec, or nil if the build has none
+func (b *PathOfBuilding) ActiveSpec() *Spec {
+	if b.Tree.ActiveSpec < 1 || b.Tree.ActiveSpec > len(b.Tree.Specs) {
+		return nil
+	}
+	return &b.Tree.Specs[b.Tree.ActiveSpec-1]
+}
+
+// syncActiveSpec stores the working passive node list back into the active spec
+func (b *PathOfBuilding) syncActiveSpec() {
+	if spec := b.ActiveSpec(); spec != nil {
+		spec.Nodes = slices.Clone(b.Build.PassiveNodes)
+	}
+}
+
+func (b *PathOfBuilding) ListSpecs() []Spec {
+	b.syncActiveSpec()
+	return b.Tree.Specs
+}
+
+// SetActiveSpec selects the spec at the given index and loads its passive nodes
+func (b *PathOfBuilding) SetActiveSpec(index int) error {
+	if index < 0 || index >= len(b.Tree.Specs) {
+		return fmt.Errorf("spec %d does not exist", index)
+	}
+
+	b.syncActiveSpec()
+	b.Tree.ActiveSpec = index + 1
+	b.Build.PassiveNodes = slices.Clone(b.Tree.Specs[index].Nodes)
+	return nil
+}
+
+// AddSpec appends an empty spec on the latest tree for the current class and returns its index
+func (b *PathOfBuilding) AddSpec(title string) int {
+	spec := Spec{
+		Title:             title,
+		TreeVersion:       data.LatestTreeVersion,
+		Nodes:             make([]int64, 0),
+		MasterySelections: make([]MasterySelection, 0),
+	}
+	if active := b.ActiveSpec(); active != nil {
+		spec.ClassID = active.ClassID
+		spec.AscendClassID = active.AscendClassID
+	}
+
+	b.Tree.Specs = append(b.Tree.Specs, spec)
+	if len(b.Tree.Specs) == 1 {
+		b.Tree.ActiveSpec = 1
+		b.Build.PassiveNodes = slices.Clone(spec.Nodes)
+	}
+	return len(b.Tree.Specs) - 1
+}
+
+// CopySpec appends a copy of the spec at the given index and returns the index of the copy
+func (b *PathOfBuilding) CopySpec(index int) (int, error) {
+	if index < 0 || index >= len(b.Tree.Specs) {
+		return 0, fmt.Errorf("spec %d does not exist", index)
+	}
+
+	b.syncActiveSpec()
+	spec := b.Tree.Specs[index]
+	spec.Nodes = slices.Clone(spec.Nodes)
+	spec.MasterySelections = slices.Clone(spec.MasterySelections)
+	spec.UnknownAttrs = slices.Clone(spec.UnknownAttrs)
+	spec.UnknownElements = slices.Clone(spec.UnknownElements)
+	if spec.Sockets != nil {
+		spec.Sockets = &JewelSockets{Sockets: slices.Clone(spec.Sockets.Sockets)}
+	}
+
+	b.Tree.Specs = append(b.Tree.Specs, spec)
+	return len(b.Tree.Specs) - 1, nil
+}
+
+// DeleteSpec removes the spec at the given index, the last remaining spec cannot be deleted
+func (b *PathOfBuilding) DeleteSpec(index int) error {
+	if index < 0 || index >= len(b.Tree.Specs) {
+		return fmt.Errorf("spec %d does not exist", index)
+	}
+
+	if len(b.Tree.Specs) == 1 {
+		return fmt.Errorf("cannot delete the only spec")
+	}
+
+	b.syncActiveSpec()
+	b.Tree.Specs = slices.Delete(b.Tree.Specs, index, index+1)
+
+	if index < b.Tree.ActiveSpec-1 {
+		b.Tree.ActiveSpec--
+	} else if index == b.Tree.ActiveSpec-1 {
+		b.Tree.ActiveSpec = min(index+1, len(b.Tree.Specs))
+		b.Build.PassiveNodes = slices.Clone(b.ActiveSpec().Nodes)
 	}
+	return nil
 }
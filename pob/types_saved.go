@@ -1,6 +1,8 @@
 package pob
 
 import (
+	"encoding/xml"
+
 	"github.com/Vilsol/go-pob/data"
 )
 
@@ -8,11 +10,27 @@ type PathOfBuilding struct {
 	Build    Build    `xml:"Build"`
 	Tree     Tree     `xml:"Tree"`
 	Calcs    Calcs    `xml:"Calcs"`
-	Notes    string   `xml:"Notes"`
+	Notes    Text     `xml:"Notes"`
 	Items    Items    `xml:"Items"`
 	Skills   Skills   `xml:"Skills"`
 	TreeView TreeView `xml:"TreeView"`
 	Config   Config   `xml:"Config"`
+
+	UnknownElements []UnknownElement `xml:",any"`
+}
+
+// UnknownElement holds an element that is not modelled, so that it can be written back unchanged
+type UnknownElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	InnerXML string     `xml:",innerxml"`
+}
+
+// Text is element text that is written back with literal line breaks
+type Text string
+
+func (t Text) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(xml.CharData(t), start)
 }
 
 type BuildViewMode string
@@ -28,42 +46,59 @@ const (
 )
 
 type Build struct {
-	PantheonMinorGod       string           `xml:"pantheonMinorGod,attr"` // TODO Enum
-	PantheonMajorGod       string           `xml:"pantheonMajorGod,attr"` // TODO Enum
-	Bandit                 string           `xml:"bandit,attr"`           // TODO Enum
-	ViewMode               BuildViewMode    `xml:"viewMode,attr"`
-	ClassName              string           `xml:"className,attr"`       // TODO Enum
-	AscendClassName        string           `xml:"ascendClassName,attr"` // TODO Enum
-	Level                  int              `xml:"level,attr"`
-	MainSocketGroup        int              `xml:"mainSocketGroup,attr"`
-	TargetVersion          data.GameVersion `xml:"targetVersion,attr"`
-	PassiveNodes           []int64
-	PassiveNodesStartPaths map[int64][]int64
+	PantheonMinorGod       string            `xml:"pantheonMinorGod,attr"` // TODO Enum
+	PantheonMajorGod       string            `xml:"pantheonMajorGod,attr"` // TODO Enum
+	Bandit                 string            `xml:"bandit,attr"`           // TODO Enum
+	ViewMode               BuildViewMode     `xml:"viewMode,attr"`
+	ClassName              string            `xml:"className,attr"`       // TODO Enum
+	AscendClassName        string            `xml:"ascendClassName,attr"` // TODO Enum
+	Level                  int               `xml:"level,attr"`
+	MainSocketGroup        int               `xml:"mainSocketGroup,attr"`
+	TargetVersion          data.GameVersion  `xml:"targetVersion,attr"`
+	PassiveNodes           []int64           `xml:"-"`
+	PassiveNodesStartPaths map[int64][]int64 `xml:"-"`
 
 	PlayerStats []PlayerStat `xml:"PlayerStat" crystalline:"not_nil"`
+
+	UnknownAttrs    []xml.Attr       `xml:",any,attr"`
+	UnknownElements []UnknownElement `xml:",any"`
 }
 
 type PlayerStat struct {
 	Value float64 `xml:"value,attr"`
 	Stat  string  `xml:"stat,attr"`
+
+	UnknownAttrs []xml.Attr `xml:",any,attr"`
 }
 
 type Tree struct {
 	ActiveSpec int `xml:"activeSpec,attr"`
 
 	Specs []Spec `xml:"Spec" crystalline:"not_nil"`
+
+	UnknownAttrs    []xml.Attr       `xml:",any,attr"`
+	UnknownElements []UnknownElement `xml:",any"`
 }
 
 type Calcs struct {
 	Inputs   []Input   `xml:"Input" crystalline:"not_nil"`
 	Sections []Section `xml:"Section" crystalline:"not_nil"`
+
+	UnknownElements []UnknownElement `xml:",any"`
 }
 
 type Items struct {
 	ActiveItemSet      int   `xml:"activeItemSet,attr"`
 	UseSecondWeaponSet *bool `xml:"useSecondWeaponSet,attr,omitempty"`
 
+	Items    []Item    `xml:"Item" crystalline:"not_nil"`
+	Slots    []Slot    `xml:"Slot" crystalline:"not_nil"`
 	ItemSets []ItemSet `xml:"ItemSet" crystalline:"not_nil"`
+
+	UnknownAttrs    []xml.Attr       `xml:",any,attr"`
+	UnknownElements []UnknownElement `xml:",any"`
+
+	itemIndex map[int]int // Position of each item by its id, built by ParseItems
 }
 
 type Skills struct {
@@ -77,6 +112,9 @@ type Skills struct {
 	SortGemsByDPS                 bool    `xml:"sortGemsByDPS,attr"`
 
 	SkillSets []SkillSet `xml:"SkillSet" crystalline:"not_nil"`
+
+	UnknownAttrs    []xml.Attr       `xml:",any,attr"`
+	UnknownElements []UnknownElement `xml:",any"`
 }
 
 type TreeView struct {
@@ -86,11 +124,16 @@ type TreeView struct {
 	SearchStr           string  `xml:"searchStr,attr"`
 	ShowHeatMap         *bool   `xml:"showHeatMap,attr,omitempty"`
 	ShowStatDifferences bool    `xml:"showStatDifferences,attr"`
+
+	UnknownAttrs []xml.Attr `xml:",any,attr"`
 }
 
 type Config struct {
 	Inputs       []Input `xml:"Input" crystalline:"not_nil"`
 	Placeholders []Input `xml:"Placeholder" crystalline:"not_nil"`
+
+	UnknownAttrs    []xml.Attr       `xml:",any,attr"`
+	UnknownElements []UnknownElement `xml:",any"`
 }
 
 type Input struct {
@@ -98,12 +141,16 @@ type Input struct {
 	Boolean *bool    `xml:"boolean,attr"`
 	Number  *float64 `xml:"number,attr"`
 	String  *string  `xml:"string,attr"`
+
+	UnknownAttrs []xml.Attr `xml:",any,attr"`
 }
 
 type Section struct {
 	Collapsed  bool   `xml:"collapsed,attr"`
 	ID         string `xml:"id,attr"`
 	Subsection string `xml:"subsection,attr"`
+
+	UnknownAttrs []xml.Attr `xml:",any,attr"`
 }
 
 type ItemSet struct {
@@ -111,51 +158,69 @@ type ItemSet struct {
 	UseSecondWeaponSet *bool  `xml:"useSecondWeaponSet,attr,omitempty"`
 
 	Slots []Slot `xml:"Slot"`
+
+	UnknownAttrs    []xml.Attr       `xml:",any,attr"`
+	UnknownElements []UnknownElement `xml:",any"`
 }
 
 type Slot struct {
 	ItemID int    `xml:"itemId,attr"`
 	Name   string `xml:"name,attr"`
+	Active bool   `xml:"active,attr,omitempty"`
+
+	UnknownAttrs []xml.Attr `xml:",any,attr"`
 }
 
 type SkillSet struct {
 	ID int `xml:"id,attr"`
 
 	Skills []Skill `xml:"Skill" crystalline:"not_nil"`
+
+	UnknownAttrs []xml.Attr `xml:",any,attr"`
 }
 
 type Skill struct {
-	MainActiveSkillCalcs int    `xml:"mainActiveSkillCalcs,attr"`
-	MainActiveSkill      int    `xml:"mainActiveSkill,attr"`
+	MainActiveSkillCalcs int    `xml:"mainActiveSkillCalcs,attr,omitempty"`
+	MainActiveSkill      int    `xml:"mainActiveSkill,attr,omitempty"`
 	Label                string `xml:"label,attr"`
 	Enabled              bool   `xml:"enabled,attr"`
 	IncludeInFullDPS     *bool  `xml:"includeInFullDPS,attr,omitempty"`
+	Slot                 string `xml:"slot,attr,omitempty"`   // TODO Slot
+	Source               string `xml:"source,attr,omitempty"` // TODO Source
 
 	Gems []Gem `xml:"Gem" crystalline:"not_nil"`
 
-	Slot                  string // TODO Slot
-	SlotEnabled           bool
-	Source                interface{} // TODO Source
-	DisplayLabel          string
-	DisplaySkillList      interface{}
-	DisplaySkillListCalcs interface{}
+	UnknownAttrs    []xml.Attr       `xml:",any,attr"`
+	UnknownElements []UnknownElement `xml:",any"`
+
+	SlotEnabled           bool        `xml:"-"`
+	DisplayLabel          string      `xml:"-"`
+	DisplaySkillList      interface{} `xml:"-"`
+	DisplaySkillListCalcs interface{} `xml:"-"`
 }
 
 type Gem struct {
 	Quality            int    `xml:"quality,attr"`
-	SkillPart          int    `xml:"skillPart,attr"`
+	SkillPart          int    `xml:"skillPart,attr,omitempty"`
 	EnableGlobal2      bool   `xml:"enableGlobal2,attr"`
-	SkillPartCalcs     int    `xml:"skillPartCalcs,attr"`
-	QualityID          string `xml:"qualityId,attr"`
-	GemID              string `xml:"gemId,attr"`
+	SkillPartCalcs     int    `xml:"skillPartCalcs,attr,omitempty"`
+	QualityID          string `xml:"qualityId,attr,omitempty"`
+	GemID              string `xml:"gemId,attr,omitempty"`
 	Enabled            bool   `xml:"enabled,attr"`
-	Count              int    `xml:"count,attr"`
+	Count              int    `xml:"count,attr,omitempty"`
 	EnableGlobal1      bool   `xml:"enableGlobal1,attr"`
 	NameSpec           string `xml:"nameSpec,attr"`
 	Level              int    `xml:"level,attr"`
 	SkillID            string `xml:"skillId,attr"`
-	SkillMinionItemSet int    `xml:"skillMinionItemSet,attr"`
-	SkillMinion        string `xml:"skillMinion,attr"`
+	SkillMinionItemSet int    `xml:"skillMinionItemSet,attr,omitempty"`
+	SkillMinion        string `xml:"skillMinion,attr,omitempty"`
+
+	SkillMinionItemSetCalcs int    `xml:"skillMinionItemSetCalcs,attr,omitempty"`
+	SkillMinionCalcs        string `xml:"skillMinionCalcs,attr,omitempty"`
+	SkillMinionSkill        int    `xml:"skillMinionSkill,attr,omitempty"`
+	SkillMinionSkillCalcs   int    `xml:"skillMinionSkillCalcs,attr,omitempty"`
+
+	UnknownAttrs []xml.Attr `xml:",any,attr"`
 
 	// TODO
 	//DisplayEffect interface{}
@@ -163,10 +228,33 @@ type Gem struct {
 }
 
 type Spec struct {
-	ClassID        int              `xml:"classID,attr"`       // TODO Enum
-	AscendClassID  int              `xml:"ascendClassID,attr"` // TODO Enum
-	TreeVersion    data.TreeVersion `xml:"treeVersion,attr"`   // TODO Enum
-	NodesAttr      string           `xml:"nodes,attr"`
-	MasteryEffects string           `xml:"masteryEffects,attr"`
-	URL            string           `xml:"URL"`
+	Title             string             `xml:"title,attr,omitempty"`
+	ClassID           int                `xml:"classId,attr"`       // TODO Enum
+	AscendClassID     int                `xml:"ascendClassId,attr"` // TODO Enum
+	TreeVersion       data.TreeVersion   `xml:"treeVersion,attr"`   // TODO Enum
+	NodesAttr         string             `xml:"nodes,attr"`
+	MasteryEffects    string             `xml:"masteryEffects,attr"`
+	Nodes             []int64            `xml:"-"`
+	MasterySelections []MasterySelection `xml:"-"`
+	URL               Text               `xml:"URL"`
+	Sockets           *JewelSockets      `xml:"Sockets"`
+
+	UnknownAttrs    []xml.Attr       `xml:",any,attr"`
+	UnknownElements []UnknownElement `xml:",any"`
+}
+
+// JewelSockets lists the items socketed in the jewel sockets of a spec
+type JewelSockets struct {
+	Sockets []JewelSocket `xml:"Socket" crystalline:"not_nil"`
+}
+
+type JewelSocket struct {
+	ItemID int   `xml:"itemId,attr"`
+	NodeID int64 `xml:"nodeId,attr"`
+}
+
+// MasterySelection is the effect picked on an allocated mastery node
+type MasterySelection struct {
+	NodeID   int64
+	EffectID int64
 }
@@ -3,8 +3,13 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"os"
 
+	"github.com/Vilsol/go-pob/cache"
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/data/raw"
 	"github.com/Vilsol/go-pob/wasm/exposition"
 )
 
@@ -18,6 +23,27 @@ func main() {
 	switch os.Args[1] {
 	case "types":
 		generateTypes()
+	case "data":
+		if len(os.Args) < 3 {
+			fmt.Println("usage: go run -tags tools tools.go data <directory>")
+			os.Exit(1)
+		}
+		exportData(os.Args[2])
+	}
+}
+
+// exportData writes all game and tree data into a directory that can be used with cache.Directory or GO_POB_DATA_DIR
+func exportData(dir string) {
+	cache.Use(cache.Mirror(cache.Disk(), dir))
+
+	if err := raw.InitializeAll(raw.LatestVersion, func(string) {}); err != nil {
+		panic(err)
+	}
+
+	for _, treeVersion := range data.TreeVersions {
+		if _, err := treeVersion.LoadRawTree(context.Background()); err != nil {
+			panic(err)
+		}
 	}
 }
 
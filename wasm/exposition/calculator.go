@@ -0,0 +1,14 @@
+package exposition
+
+import (
+	"github.com/Vilsol/go-pob/calculator"
+	"github.com/Vilsol/go-pob/pob"
+)
+
+// BuildOutput calculates the build
+func BuildOutput(build pob.PathOfBuilding, mode calculator.OutputMode) (*calculator.Environment, error) {
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	return calculator.NewCalculator(build).BuildOutput(ctx, mode)
+}
@@ -16,7 +16,10 @@ import (
 func Expose() *crystalline.Exposer {
 	e := crystalline.NewExposer("go-pob")
 
-	crystalline.MarkPromise("calculator.Calculator", "BuildOutput")
+	// These need a context or tree data, which javascript cannot provide, so they are wrapped by this package
+	crystalline.MarkIgnored("calculator.Calculator", "BuildOutput")
+	crystalline.MarkIgnored("pob.PathOfBuilding", "SetAscendancy")
+	crystalline.MarkIgnored("pob.PathOfBuilding", "SetMasteryEffect")
 
 	crystalline.MarkIgnored("msgp.Reader", "ReadComplex64")
 	crystalline.MarkIgnored("msgp.Reader", "ReadComplex128")
@@ -29,8 +32,14 @@ func Expose() *crystalline.Exposer {
 
 	e.ExposeFuncOrPanic(builds.ParseBuild)
 	e.ExposeFuncOrPanic(builds.ParseBuildStr)
+	e.ExposeFuncOrPanic(builds.SerializeBuild)
+	e.ExposeFuncOrPanic(builds.SerializeBuildStr)
+	e.ExposeFuncOrPanic(builds.ExportBuild)
 
 	e.ExposeFuncOrPanic(calculator.NewCalculator)
+	e.ExposeFuncOrPanic(calculator.ConfigOptions)
+	e.ExposeFuncOrPanic(calculator.GetConfigOption)
+	e.ExposeFuncOrPanic(calculator.ConfigSections)
 	e.ExposeFuncOrPanicPromise(raw.InitializeAll)
 	e.ExposeFuncOrPanic(cache.InitializeDiskCache)
 	e.ExposeFuncOrPanic(config.InitLogging)
@@ -39,6 +48,10 @@ func Expose() *crystalline.Exposer {
 	e.ExposeFuncOrPanicPromise(GetRawTree)
 	e.ExposeFuncOrPanic(GetStatByIndex)
 	e.ExposeFuncOrPanic(CalculateTreePath)
+	e.ExposeFuncOrPanic(CalculatePrunableNodes)
+	e.ExposeFuncOrPanic(SetAscendancy)
+	e.ExposeFuncOrPanic(SetMasteryEffect)
+	e.ExposeFuncOrPanicPromise(BuildOutput)
 
 	info, _ := debug.ReadBuildInfo()
 	e.ExposeOrPanic(info, "pob", "BuildInfo")
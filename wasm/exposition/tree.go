@@ -1,20 +1,105 @@
 package exposition
 
-import "github.com/Vilsol/go-pob/data"
+import (
+	"context"
+	"fmt"
+	"time"
 
-func GetRawTree(version data.TreeVersion) []byte {
-	return data.TreeVersions[version].RawTree()
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/pob"
+)
+
+// requestTimeout limits how long a call from javascript may wait for data to load
+const requestTimeout = 5 * time.Minute
+
+// requestContext creates the context of a call from javascript, which cannot pass one itself
+func requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), requestTimeout)
+}
+
+func GetRawTree(version data.TreeVersion) ([]byte, error) {
+	treeVersion, err := data.GetTreeVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	return treeVersion.LoadRawTree(ctx)
+}
+
+func CalculateTreePath(version data.TreeVersion, activeNodes []int64, target int64) ([]int64, error) {
+	treeVersion, err := data.GetTreeVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	return treeVersion.CalculateTreePath(ctx, activeNodes, target)
+}
+
+func CalculatePrunableNodes(version data.TreeVersion, activeNodes []int64, classStartNode int64, target int64) ([]int64, error) {
+	treeVersion, err := data.GetTreeVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	return treeVersion.CalculatePrunableNodes(ctx, activeNodes, classStartNode, target)
+}
+
+// SetAscendancy selects the ascendancy of the build, validating it against the tree of the active spec.
+// The build is copied when it is passed from javascript, so the changed build is returned.
+func SetAscendancy(build pob.PathOfBuilding, ascendancy string) (*pob.PathOfBuilding, error) {
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	tree, err := loadBuildTree(ctx, &build)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := build.SetAscendancy(tree, ascendancy); err != nil {
+		return nil, err
+	}
+
+	return &build, nil
 }
 
-func CalculateTreePath(version data.TreeVersion, activeNodes []int64, target int64) []int64 {
-	return data.TreeVersions[version].CalculateTreePath(activeNodes, target)
+// SetMasteryEffect selects the effect of a mastery node, validating it against the tree of the active spec.
+// The build is copied when it is passed from javascript, so the changed build is returned.
+func SetMasteryEffect(build pob.PathOfBuilding, nodeID int64, effectID int64) (*pob.PathOfBuilding, error) {
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	tree, err := loadBuildTree(ctx, &build)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := build.SetMasteryEffect(tree, nodeID, effectID); err != nil {
+		return nil, err
+	}
+
+	return &build, nil
 }
 
-// TODO: Need some algorithm that figures out which nodes would be disconnected and therefore removed if the target node is removed
-// Important steps:
-// 1a. On allocation calculate and store a list of adjacent nodes that are currently on a path towards a start node (pathsToStart)
-// 1b. Also make sure to check all previously allocated nodes and recalculate any new start paths adjacent nodes
-// 2. On Deallocate start pruning all subtrees connected to the target node that ARE NOT listed in the pathsToStart
-func CalculatePrunableNodes(version data.TreeVersion, activeNodes []int64, target int64) []int64 {
-	return make([]int64, 0)
+// loadBuildTree loads the tree of the active spec of the build
+func loadBuildTree(ctx context.Context, build *pob.PathOfBuilding) (*data.Tree, error) {
+	treeVersion := data.LatestTreeVersion
+	if spec := build.ActiveSpec(); spec != nil {
+		treeVersion, _ = data.ResolveTreeVersion(spec.TreeVersion)
+	}
+
+	tree, err := data.TreeVersions[treeVersion].LoadTree(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tree: %w", err)
+	}
+
+	return tree, nil
 }